@@ -17,11 +17,16 @@ import (
 	"github.com/healthcare-market-research/backend/internal/cache"
 	"github.com/healthcare-market-research/backend/internal/config"
 	"github.com/healthcare-market-research/backend/internal/db"
+	"github.com/healthcare-market-research/backend/internal/domain/attachment"
+	"github.com/healthcare-market-research/backend/internal/domain/seo"
 	"github.com/healthcare-market-research/backend/internal/handler"
 	"github.com/healthcare-market-research/backend/internal/middleware"
 	"github.com/healthcare-market-research/backend/internal/repository"
 	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/healthcare-market-research/backend/internal/storage"
 	"github.com/healthcare-market-research/backend/pkg/logger"
+	"github.com/healthcare-market-research/backend/pkg/pagination"
+	"github.com/healthcare-market-research/backend/pkg/validation"
 	"github.com/joho/godotenv"
 
 	_ "github.com/healthcare-market-research/backend/docs"
@@ -59,9 +64,24 @@ import (
 // @tag.name Reports
 // @tag.description Operations related to healthcare market research reports
 
+// @tag.name Report Templates
+// @tag.description Saved starting points for new reports (admin/editor only)
+
 // @tag.name Categories
 // @tag.description Operations related to report categories and hierarchies
 
+// @tag.name Geographies
+// @tag.description Geography taxonomy used to tag and filter reports
+
+// @tag.name Tags
+// @tag.description Tags shared by reports, blogs and press releases
+
+// @tag.name Related
+// @tag.description Related content linking reports, blogs and press releases
+
+// @tag.name Companies
+// @tag.description Company directory for report key players
+
 // @tag.name Authors
 // @tag.description Operations related to report authors and analysts
 
@@ -82,6 +102,15 @@ import (
 
 // @tag.name PressReleases
 // @tag.description Press release management and publishing
+
+// @tag.name Search
+// @tag.description Full-text search across published reports, blogs and press releases
+
+// @tag.name Redirects
+// @tag.description Slug history and redirects for renamed content
+
+// @tag.name SEO
+// @tag.description Sitemaps, RSS/Atom feeds and robots.txt for the public site
 func main() {
 	// Load .env file
 	if err := godotenv.Load(); err != nil {
@@ -95,15 +124,18 @@ func main() {
 	logger.Init(cfg.Environment)
 	logger.Info("Starting Healthcare Market Research API", "environment", cfg.Environment)
 
+	// Sign pagination cursors with a key derived from the JWT secret
+	pagination.SetSigningKey(cfg.Auth.JWTSecret)
+
 	// Connect to database
 	if err := db.Connect(cfg); err != nil {
 		logger.Error("Failed to connect to database", "error", err)
 		os.Exit(1)
 	}
 
-	// Run migrations
-	if err := db.Migrate(); err != nil {
-		logger.Error("Failed to run migrations", "error", err)
+	// Refuse to start against a schema that is missing migrations
+	if err := db.CheckSchema(context.Background()); err != nil {
+		logger.Error("Database schema is not up to date", "error", err)
 		os.Exit(1)
 	}
 
@@ -114,6 +146,21 @@ func main() {
 		logger.Info("Redis connected successfully")
 	}
 
+	// Initialize image storage
+	imageStorage, err := storage.New(&cfg.Storage)
+	if err != nil {
+		logger.Error("Failed to initialize image storage", "error", err)
+		os.Exit(1)
+	}
+	logger.Info("Image storage initialized", "driver", cfg.Storage.Driver)
+
+	// PDF media kits and other documents
+	documentStorage, err := storage.NewDocumentStorage(&cfg.Storage)
+	if err != nil {
+		logger.Error("Failed to initialize document storage", "error", err)
+		os.Exit(1)
+	}
+
 	// Initialize repositories
 	userRepo := repository.NewUserRepository(db.DB)
 	categoryRepo := repository.NewCategoryRepository(db.DB)
@@ -125,26 +172,48 @@ func main() {
 	blogRepo := repository.NewBlogRepository(db.DB)
 	pressReleaseRepo := repository.NewPressReleaseRepository(db.DB)
 	dashboardRepo := repository.NewDashboardRepository(db.DB)
+	searchRepo := repository.NewSearchRepository(db.DB)
+	redirectRepo := repository.NewRedirectRepository(db.DB)
+	seoRepo := repository.NewSEORepository(db.DB)
+	imageGCRepo := repository.NewImageGCRepository(db.DB)
+	attachmentRepo := repository.NewAttachmentRepository(db.DB)
+	chartRepo := repository.NewChartRepository(db.DB)
+	companyRepo := repository.NewCompanyRepository(db.DB)
+	geographyRepo := repository.NewGeographyRepository(db.DB)
+	tagRepo := repository.NewTagRepository(db.DB)
+	relatedRepo := repository.NewRelatedRepository(db.DB)
+	reportTemplateRepo := repository.NewReportTemplateRepository(db.DB)
+	transactor := repository.NewTransactor(db.DB)
 
 	// Initialize services
 	userService := service.NewUserService(userRepo)
 	authService := service.NewAuthService(userRepo, &cfg.Auth)
-	categoryService := service.NewCategoryService(categoryRepo)
-	cloudflareService := service.NewCloudflareImagesService(&cfg.Cloudflare)
-	reportService := service.NewReportService(reportRepo, reportImageRepo, cloudflareService)
-	authorService := service.NewAuthorService(authorRepo, cloudflareService)
+	categoryService := service.NewCategoryService(categoryRepo, redirectRepo, imageStorage)
+	companyService := service.NewCompanyService(companyRepo, redirectRepo, imageStorage)
+	geographyService := service.NewGeographyService(geographyRepo)
+	tagService := service.NewTagService(tagRepo, redirectRepo)
+	relatedService := service.NewRelatedService(relatedRepo, &cfg.Related)
+	reportService := service.NewReportService(reportRepo, reportImageRepo, imageStorage, userRepo, redirectRepo, companyRepo, geographyService, reportTemplateRepo, transactor)
+	reportTemplateService := service.NewReportTemplateService(reportTemplateRepo, geographyService)
+	reportImportService := service.NewReportImportService(reportService, reportRepo, categoryRepo, authorRepo)
+	authorService := service.NewAuthorService(authorRepo, imageStorage)
 	auditService := service.NewAuditService(auditRepo)
 	formService := service.NewFormService(formRepo)
-	reportImageService := service.NewReportImageService(reportImageRepo, reportRepo, cloudflareService)
-	blogService := service.NewBlogService(blogRepo)
-	pressReleaseService := service.NewPressReleaseService(pressReleaseRepo)
+	reportImageService := service.NewReportImageService(reportImageRepo, reportRepo, imageStorage)
+	chartService := service.NewChartService(chartRepo, reportRepo, reportImageRepo)
+	blogService := service.NewBlogService(blogRepo, redirectRepo, transactor)
+	pressReleaseService := service.NewPressReleaseService(pressReleaseRepo, redirectRepo, transactor)
+	attachmentService := service.NewAttachmentService(attachmentRepo, imageStorage, documentStorage)
+	searchService := service.NewSearchService(searchRepo)
+	redirectService := service.NewRedirectService(redirectRepo)
+	seoService := service.NewSEOService(seoRepo, categoryRepo, &cfg.Site)
 	dashboardService := service.NewDashboardService(
 		dashboardRepo, reportRepo, blogRepo, pressReleaseRepo,
 		userRepo, formRepo, auditRepo,
 	)
 
 	// Initialize scheduler service
-	schedulerService := service.NewSchedulerService(reportRepo, blogRepo, pressReleaseRepo)
+	schedulerService := service.NewSchedulerService(reportService, auditService, blogRepo, pressReleaseRepo)
 
 	// Start scheduler with context
 	ctx, cancel := context.WithCancel(context.Background())
@@ -152,20 +221,47 @@ func main() {
 	schedulerService.Start(ctx)
 	defer schedulerService.Stop()
 
+	// Periodically delete stored images no record uses any more
+	if cfg.ImageGC.Enabled {
+		imageGCService := service.NewImageGCService(imageGCRepo, imageStorage, auditService, &cfg.ImageGC)
+		imageGCService.Start(ctx)
+		defer imageGCService.Stop()
+	}
+
+	// Periodically recompute related content suggestions
+	if cfg.Related.Enabled {
+		relatedService.Start(ctx)
+		defer relatedService.Stop()
+	}
+
+	// Structured data for public content pages
+	jsonld := seo.NewJSONLDBuilder(cfg.Site.URL, cfg.Site.Name)
+
 	// Initialize handlers
 	healthHandler := handler.NewHealthHandler()
 	authHandler := handler.NewAuthHandler(authService, auditService)
 	userHandler := handler.NewUserHandler(userService, auditService)
-	categoryHandler := handler.NewCategoryHandler(categoryService)
-	reportHandler := handler.NewReportHandler(reportService, authorRepo)
+	categoryHandler := handler.NewCategoryHandler(categoryService, auditService)
+	companyHandler := handler.NewCompanyHandler(companyService, auditService)
+	geographyHandler := handler.NewGeographyHandler(geographyService)
+	tagHandler := handler.NewTagHandler(tagService, auditService)
+	relatedHandler := handler.NewRelatedHandler(relatedService, auditService)
+	reportHandler := handler.NewReportHandler(reportService, authorRepo, auditService, jsonld, relatedService)
+	reportTemplateHandler := handler.NewReportTemplateHandler(reportTemplateService, auditService)
+	reportImportHandler := handler.NewReportImportHandler(reportImportService, auditService)
 	authorHandler := handler.NewAuthorHandler(authorService)
 	auditHandler := handler.NewAuditHandler(auditService)
 	roleHandler := handler.NewRoleHandler()
 	formHandler := handler.NewFormHandler(formService)
 	reportImageHandler := handler.NewReportImageHandler(reportImageService)
-	blogHandler := handler.NewBlogHandler(blogService)
-	pressReleaseHandler := handler.NewPressReleaseHandler(pressReleaseService)
+	chartHandler := handler.NewChartHandler(chartService, auditService)
+	blogHandler := handler.NewBlogHandler(blogService, jsonld, relatedService)
+	pressReleaseHandler := handler.NewPressReleaseHandler(pressReleaseService, jsonld, relatedService)
+	attachmentHandler := handler.NewAttachmentHandler(attachmentService, auditService)
 	dashboardHandler := handler.NewDashboardHandler(dashboardService)
+	searchHandler := handler.NewSearchHandler(searchService)
+	redirectHandler := handler.NewRedirectHandler(redirectService, auditService)
+	seoHandler := handler.NewSEOHandler(seoService)
 
 	// Initialize Fiber app
 	app := fiber.New(fiber.Config{
@@ -173,6 +269,8 @@ func main() {
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 10 * time.Second,
 		IdleTimeout:  120 * time.Second,
+		// Room for the largest upload, a PDF media kit, plus form overhead
+		BodyLimit: validation.MaxDocumentSize + 1024*1024,
 	})
 
 	// Global middleware
@@ -196,6 +294,20 @@ func main() {
 	// Swagger documentation
 	app.Get("/swagger/*", swagger.HandlerDefault)
 
+	// Uploaded files kept on local disk: every upload with the local driver,
+	// and documents with Cloudflare Images
+	if cfg.Storage.Driver == storage.DriverLocal || cfg.Storage.Driver == storage.DriverCloudflare {
+		app.Static(storage.LocalRoute, cfg.Storage.Local.Dir, fiber.Static{
+			MaxAge: 86400,
+		})
+	}
+
+	// Sitemaps, feeds and robots.txt for the public site
+	app.Get("/robots.txt", seoHandler.Robots)
+	app.Get("/sitemap.xml", seoHandler.SitemapIndex)
+	app.Get("/sitemaps/:name", seoHandler.Sitemap)
+	app.Get("/feeds/:section.:format", seoHandler.Feed)
+
 	// API v1 routes
 	v1 := app.Group("/api/v1")
 
@@ -217,28 +329,114 @@ func main() {
 
 	// Report routes (public read, protected write)
 	v1.Get("/reports", reportHandler.GetAll)
+	v1.Get("/reports/compare", reportHandler.Compare)
 	v1.Get("/reports/author/:id", reportHandler.GetByAuthorID)
 	v1.Get("/reports/:slug", reportHandler.GetBySlug)
-	v1.Get("/search", reportHandler.Search)
 	v1.Post("/reports", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), reportHandler.Create)
+	v1.Post("/reports/import", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), reportImportHandler.Import)
 	v1.Put("/reports/:id", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), reportHandler.Update)
+	v1.Post("/reports/:id/clone", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), reportHandler.Clone)
 	v1.Patch("/reports/:id/soft-delete", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), reportHandler.SoftDelete)
 	v1.Patch("/reports/:id/restore", middleware.RequireAuth(authService), middleware.RequireRole("admin"), reportHandler.Restore)
 	v1.Delete("/reports/:id", middleware.RequireAuth(authService), middleware.RequireRole("admin"), reportHandler.Delete)
 	v1.Patch("/reports/:id/schedule", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), reportHandler.SchedulePublish)
 	v1.Patch("/reports/:id/cancel-schedule", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), reportHandler.CancelScheduledPublish)
 
+	// Report editorial workflow routes
+	v1.Patch("/reports/:id/submit-review", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), reportHandler.SubmitForReview)
+	v1.Patch("/reports/:id/approve", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), reportHandler.Approve)
+	v1.Patch("/reports/:id/reject", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), reportHandler.Reject)
+	v1.Patch("/reports/:id/publish", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), reportHandler.Publish)
+	v1.Patch("/reports/:id/archive", middleware.RequireAuth(authService), middleware.RequireRole("admin"), reportHandler.Archive)
+	v1.Patch("/reports/:id/reopen", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), reportHandler.Reopen)
+	v1.Patch("/reports/:id/reviewer", middleware.RequireAuth(authService), middleware.RequireRole("admin"), reportHandler.AssignReviewer)
+	v1.Get("/reports/:id/comments", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), reportHandler.GetReviewComments)
+	v1.Post("/reports/:id/comments", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), reportHandler.AddReviewComment)
+
+	// Report version history routes (admin/editor only)
+	v1.Get("/reports/:id/versions", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), reportHandler.ListVersions)
+	v1.Get("/reports/:id/versions/diff", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), reportHandler.DiffVersions)
+	v1.Get("/reports/:id/versions/:version", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), reportHandler.GetVersion)
+	v1.Post("/reports/:id/versions/:version/rollback", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), reportHandler.RollbackVersion)
+
 	// Report image routes (admin/editor only)
 	v1.Post("/reports/:reportId/images", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), reportImageHandler.UploadImage)
 	v1.Get("/reports/:reportId/images", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), reportImageHandler.ListImages)
+	v1.Patch("/reports/:reportId/images/reorder", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), reportImageHandler.ReorderImages)
 	v1.Get("/reports/images/:imageId", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), reportImageHandler.GetByID)
 	v1.Patch("/reports/images/:imageId", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), reportImageHandler.UpdateMetadata)
 	v1.Delete("/reports/images/:imageId", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), reportImageHandler.DeleteImage)
 
+	// Report chart routes (admin/editor only)
+	v1.Get("/reports/:id/charts", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), chartHandler.List)
+	v1.Post("/reports/:id/charts", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), chartHandler.Create)
+	v1.Get("/reports/:id/charts/:chartId", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), chartHandler.Get)
+	v1.Put("/reports/:id/charts/:chartId", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), chartHandler.Update)
+	v1.Delete("/reports/:id/charts/:chartId", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), chartHandler.Delete)
+	v1.Get("/reports/:id/charts/:chartId/export", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), chartHandler.Export)
+
+	// Search routes (public)
+	v1.Get("/search", searchHandler.Search)
+
+	// Report template routes (admin/editor only)
+	reportTemplates := v1.Group("/report-templates", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"))
+	reportTemplates.Get("/", reportTemplateHandler.GetAll)
+	reportTemplates.Get("/:id", reportTemplateHandler.GetByID)
+	reportTemplates.Post("/", reportTemplateHandler.Create)
+	reportTemplates.Put("/:id", reportTemplateHandler.Update)
+	reportTemplates.Delete("/:id", middleware.RequireRole("admin"), reportTemplateHandler.Delete)
+
+	// Redirect routes (public resolver, admin/editor management)
+	v1.Get("/redirects/resolve", redirectHandler.Resolve)
+	redirects := v1.Group("/redirects", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"))
+	redirects.Get("/", redirectHandler.GetAll)
+	redirects.Get("/:id", redirectHandler.GetByID)
+	redirects.Post("/", redirectHandler.Create)
+	redirects.Put("/:id", redirectHandler.Update)
+	redirects.Delete("/:id", redirectHandler.Delete)
+
 	// Category routes (public read, protected write)
 	v1.Get("/categories", categoryHandler.GetAll)
 	v1.Get("/categories/:slug", categoryHandler.GetBySlug)
 	v1.Get("/categories/:slug/reports", reportHandler.GetByCategorySlug)
+	v1.Post("/categories", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), categoryHandler.Create)
+	v1.Patch("/categories/reorder", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), categoryHandler.Reorder)
+	v1.Put("/categories/:id", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), categoryHandler.Update)
+	v1.Patch("/categories/:id/deactivate", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), categoryHandler.Deactivate)
+	v1.Post("/categories/:id/image", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), categoryHandler.UploadImage)
+	v1.Delete("/categories/:id/image", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), categoryHandler.DeleteImage)
+	registerAttachmentRoutes(v1.Group("/categories/:id/attachments"), attachment.OwnerCategory, attachmentHandler, authService)
+
+	// Geography taxonomy (public)
+	v1.Get("/geographies", geographyHandler.GetAll)
+
+	// Tag routes (public read, protected write)
+	v1.Get("/tags", tagHandler.GetAll)
+	v1.Get("/tags/:slug", tagHandler.GetBySlug)
+	v1.Post("/tags", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), tagHandler.Create)
+	v1.Put("/tags/:id", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), tagHandler.Update)
+	v1.Delete("/tags/:id", middleware.RequireAuth(authService), middleware.RequireRole("admin"), tagHandler.Delete)
+	v1.Post("/tags/:id/merge", middleware.RequireAuth(authService), middleware.RequireRole("admin"), tagHandler.Merge)
+
+	// Related content routes (admin/editor only; items are listed publicly on each read by slug)
+	v1.Post("/related/refresh", middleware.RequireAuth(authService), middleware.RequireRole("admin"), relatedHandler.Refresh)
+	relations := v1.Group("/related", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"))
+	relations.Get("/", relatedHandler.GetAll)
+	relations.Post("/", relatedHandler.Create)
+	relations.Patch("/:id/accept", relatedHandler.Accept)
+	relations.Patch("/:id/reject", relatedHandler.Reject)
+	relations.Delete("/:id", relatedHandler.Delete)
+
+	// Company routes (public read, protected write)
+	v1.Get("/companies", companyHandler.GetAll)
+	v1.Get("/companies/:slug", companyHandler.GetBySlug)
+	v1.Post("/companies", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), companyHandler.Create)
+	v1.Post("/companies/import", middleware.RequireAuth(authService), middleware.RequireRole("admin"), companyHandler.Import)
+	v1.Put("/companies/:id", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), companyHandler.Update)
+	v1.Delete("/companies/:id", middleware.RequireAuth(authService), middleware.RequireRole("admin"), companyHandler.Delete)
+	v1.Post("/companies/:id/merge", middleware.RequireAuth(authService), middleware.RequireRole("admin"), companyHandler.Merge)
+	v1.Post("/companies/:id/logo", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), companyHandler.UploadLogo)
+	v1.Delete("/companies/:id/logo", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), companyHandler.DeleteLogo)
 
 	// Author routes (public read, protected write)
 	v1.Get("/authors", authorHandler.GetAll)
@@ -290,6 +488,7 @@ func main() {
 	v1.Patch("/blogs/:id/restore", middleware.RequireAuth(authService), middleware.RequireRole("admin"), blogHandler.Restore)
 	v1.Patch("/blogs/:id/schedule", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), blogHandler.SchedulePublish)
 	v1.Patch("/blogs/:id/cancel-schedule", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), blogHandler.CancelScheduledPublish)
+	registerAttachmentRoutes(v1.Group("/blogs/:id/attachments"), attachment.OwnerBlog, attachmentHandler, authService)
 
 	// Press Release routes
 	v1.Get("/press-releases", pressReleaseHandler.GetAll)
@@ -305,6 +504,7 @@ func main() {
 	v1.Patch("/press-releases/:id/restore", middleware.RequireAuth(authService), middleware.RequireRole("admin"), pressReleaseHandler.Restore)
 	v1.Patch("/press-releases/:id/schedule", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), pressReleaseHandler.SchedulePublish)
 	v1.Patch("/press-releases/:id/cancel-schedule", middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"), pressReleaseHandler.CancelScheduledPublish)
+	registerAttachmentRoutes(v1.Group("/press-releases/:id/attachments"), attachment.OwnerPressRelease, attachmentHandler, authService)
 
 	// Dashboard routes (requires authentication)
 	dashboard := v1.Group("/dashboard", middleware.RequireAuth(authService))
@@ -339,3 +539,14 @@ func main() {
 	}
 	logger.Info("Server shutdown complete")
 }
+
+// registerAttachmentRoutes mounts the attachment endpoints of one owner type
+// on its group, for admins and editors only
+func registerAttachmentRoutes(router fiber.Router, ownerType string, h *handler.AttachmentHandler, authService service.AuthService) {
+	router.Use(middleware.RequireAuth(authService), middleware.RequireRole("admin", "editor"))
+	router.Get("/", h.List(ownerType))
+	router.Post("/", h.Upload(ownerType))
+	router.Patch("/reorder", h.Reorder(ownerType))
+	router.Put("/:attachmentId", h.Update(ownerType))
+	router.Delete("/:attachmentId", h.Delete(ownerType))
+}
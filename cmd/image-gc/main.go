@@ -0,0 +1,120 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/healthcare-market-research/backend/internal/config"
+	"github.com/healthcare-market-research/backend/internal/db"
+	"github.com/healthcare-market-research/backend/internal/domain/media"
+	"github.com/healthcare-market-research/backend/internal/repository"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/healthcare-market-research/backend/internal/storage"
+	"github.com/healthcare-market-research/backend/pkg/logger"
+	"github.com/joho/godotenv"
+)
+
+const usage = `Usage: image-gc [-dry-run=false] [-retention 720h] [-json]
+
+Lists stored images that no report gallery image, author, category,
+attachment or company logo uses. Orphans are remembered between runs; with
+-dry-run=false the ones orphaned for longer than the retention window are
+deleted and audit logged.
+
+Flags:
+`
+
+func main() {
+	// Load .env file
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: .env file not found, using system environment variables")
+	}
+
+	cfg := config.Load()
+
+	dryRun := flag.Bool("dry-run", true, "Only report orphans, delete nothing")
+	retention := flag.Duration("retention", cfg.ImageGC.Retention, "How long an image must be orphaned before it is deleted")
+	asJSON := flag.Bool("json", false, "Print the report as JSON")
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	cfg.ImageGC.Retention = *retention
+	logger.Init(cfg.Environment)
+
+	if err := db.Connect(cfg); err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	imageStorage, err := storage.New(&cfg.Storage)
+	if err != nil {
+		log.Fatalf("Failed to initialize image storage: %v", err)
+	}
+
+	auditService := service.NewAuditService(repository.NewAuditRepository(db.DB))
+	gc := service.NewImageGCService(repository.NewImageGCRepository(db.DB), imageStorage, auditService, &cfg.ImageGC)
+
+	report, err := gc.Run(*dryRun)
+	if err != nil {
+		log.Fatalf("Image garbage collection failed: %v", err)
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			log.Fatalf("Failed to write report: %v", err)
+		}
+	} else {
+		printReport(report)
+	}
+
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func printReport(report *media.GCReport) {
+	if len(report.Orphans) > 0 {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "STATUS\tREASON\tORPHANED\tSIZE\tURL")
+		for _, orphan := range report.Orphans {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n",
+				orphanStatus(orphan), orphan.Reason,
+				orphan.OrphanedAt.Format(time.RFC3339), orphan.Size, orphan.URL)
+		}
+		w.Flush()
+		fmt.Println()
+	}
+
+	fmt.Printf("Scanned %d images: %d referenced, %d orphaned (%d bytes), %d past retention (cutoff %s)\n",
+		report.Scanned, report.Referenced, len(report.Orphans), report.OrphanSize, report.Due,
+		report.Cutoff.Format(time.RFC3339))
+	if report.DryRun {
+		fmt.Println("Dry run: nothing was deleted. Run with -dry-run=false to delete images past retention.")
+		return
+	}
+	fmt.Printf("Deleted %d images, %d failed, purged %d inactive report images\n",
+		report.Deleted, report.Failed, report.Purged)
+}
+
+func orphanStatus(orphan media.Orphan) string {
+	switch {
+	case orphan.Deleted:
+		return "deleted"
+	case orphan.Error != "":
+		return "failed"
+	case orphan.Due:
+		return "due"
+	default:
+		return "retained"
+	}
+}
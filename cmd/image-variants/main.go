@@ -0,0 +1,112 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/healthcare-market-research/backend/internal/config"
+	"github.com/healthcare-market-research/backend/internal/db"
+	"github.com/healthcare-market-research/backend/internal/domain/media"
+	"github.com/healthcare-market-research/backend/internal/repository"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/healthcare-market-research/backend/internal/storage"
+	"github.com/healthcare-market-research/backend/pkg/logger"
+	"github.com/joho/godotenv"
+)
+
+const usage = `Usage: image-variants [-dry-run=false] [-json]
+
+Lists report gallery images, authors, categories, image attachments and
+company logos saved without resized variants, such as ones uploaded before
+variants were rendered. With -dry-run=false the variants are rendered from
+the stored image and saved. Linked images from other hosts are skipped.
+
+Flags:
+`
+
+func main() {
+	// Load .env file
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: .env file not found, using system environment variables")
+	}
+
+	cfg := config.Load()
+
+	dryRun := flag.Bool("dry-run", true, "Only list images without variants, render nothing")
+	asJSON := flag.Bool("json", false, "Print the report as JSON")
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	logger.Init(cfg.Environment)
+
+	if err := db.Connect(cfg); err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	imageStorage, err := storage.New(&cfg.Storage)
+	if err != nil {
+		log.Fatalf("Failed to initialize image storage: %v", err)
+	}
+
+	variants := service.NewImageVariantService(repository.NewImageVariantRepository(db.DB), imageStorage)
+
+	report, err := variants.Backfill(*dryRun)
+	if err != nil {
+		log.Fatalf("Variant backfill failed: %v", err)
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			log.Fatalf("Failed to write report: %v", err)
+		}
+	} else {
+		printReport(report)
+	}
+
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func printReport(report *media.VariantBackfillReport) {
+	if len(report.Images) > 0 {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "STATUS\tENTITY\tID\tURL\tERROR")
+		for _, image := range report.Images {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n",
+				imageStatus(image), image.EntityType, image.EntityID, image.URL, image.Error)
+		}
+		w.Flush()
+		fmt.Println()
+	}
+
+	fmt.Printf("Found %d images without variants, %d skipped\n", len(report.Images), report.Skipped)
+	if report.DryRun {
+		fmt.Println("Dry run: nothing was rendered. Run with -dry-run=false to render and save variants.")
+		return
+	}
+	fmt.Printf("Saved variants for %d images, %d failed\n", report.Saved, report.Failed)
+}
+
+func imageStatus(image media.VariantBackfill) string {
+	switch {
+	case image.Saved:
+		return "saved"
+	case image.Error != "":
+		return "failed"
+	case image.Skipped:
+		return "skipped"
+	default:
+		return "missing"
+	}
+}
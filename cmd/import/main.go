@@ -0,0 +1,194 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+
+	"github.com/healthcare-market-research/backend/internal/cache"
+	"github.com/healthcare-market-research/backend/internal/config"
+	"github.com/healthcare-market-research/backend/internal/db"
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/domain/user"
+	"github.com/healthcare-market-research/backend/internal/repository"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/healthcare-market-research/backend/internal/storage"
+	"github.com/healthcare-market-research/backend/pkg/logger"
+	"github.com/joho/godotenv"
+)
+
+const usage = `Usage: import -user editor@example.com [-dry-run=false] [-format csv|ndjson] [-errors failed.csv] [-json] FILE
+
+Creates or updates a report for every row of a CSV or NDJSON file, matching
+reports by slug, with the same checks as the API. By default every row is
+only checked; run with -dry-run=false to save the rows that pass. Exits with
+status 1 when any row failed.
+
+Flags:
+`
+
+func main() {
+	// Load .env file
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: .env file not found, using system environment variables")
+	}
+
+	dryRun := flag.Bool("dry-run", true, "Only check the rows, write nothing")
+	format := flag.String("format", "", "csv or ndjson (default from the file extension)")
+	email := flag.String("user", "", "Email of the admin or editor the reports are saved as (required)")
+	errorsPath := flag.String("errors", "", "Write the failed rows as CSV to this file")
+	asJSON := flag.Bool("json", false, "Print the result as JSON")
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 || *email == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	path := flag.Arg(0)
+	if *format == "" {
+		*format = report.ImportFormatFor(path)
+	}
+	if *format == "" {
+		log.Fatalf("Cannot tell the format of %s; pass -format csv or -format ndjson", path)
+	}
+
+	cfg := config.Load()
+	logger.Init(cfg.Environment)
+
+	if err := db.Connect(cfg); err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	// Saved reports drop the API's cached lists when Redis is reachable
+	if err := cache.Connect(cfg); err != nil {
+		log.Printf("Warning: failed to connect to Redis, cached report lists will not be cleared: %v", err)
+	} else {
+		defer cache.Close()
+	}
+
+	imageStorage, err := storage.New(&cfg.Storage)
+	if err != nil {
+		log.Fatalf("Failed to initialize image storage: %v", err)
+	}
+
+	userRepo := repository.NewUserRepository(db.DB)
+	actor, err := userRepo.GetByEmail(*email)
+	if err != nil {
+		log.Fatalf("Unknown user %s: %v", *email, err)
+	}
+	if actor.Role != user.RoleAdmin && actor.Role != user.RoleEditor {
+		log.Fatalf("User %s is a %s; imports need an admin or editor", actor.Email, actor.Role)
+	}
+
+	authorRepo := repository.NewAuthorRepository(db.DB)
+	reportRepo := repository.NewReportRepository(db.DB, authorRepo)
+	redirectRepo := repository.NewRedirectRepository(db.DB)
+	reportService := service.NewReportService(
+		reportRepo,
+		repository.NewReportImageRepository(db.DB),
+		imageStorage,
+		userRepo,
+		redirectRepo,
+		repository.NewCompanyRepository(db.DB),
+		service.NewGeographyService(repository.NewGeographyRepository(db.DB)),
+		repository.NewReportTemplateRepository(db.DB),
+		repository.NewTransactor(db.DB),
+	)
+	importer := service.NewReportImportService(reportService, reportRepo, repository.NewCategoryRepository(db.DB), authorRepo)
+
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("Failed to open %s: %v", path, err)
+	}
+	defer file.Close()
+
+	result, err := importer.Import(file, *format, *dryRun, actor.ID)
+	if err != nil {
+		log.Fatalf("Import failed: %v", err)
+	}
+
+	if !*dryRun && result.Created+result.Updated > 0 {
+		auditService := service.NewAuditService(repository.NewAuditRepository(db.DB))
+		entry := &audit.AuditEntry{
+			UserID:     &actor.ID,
+			UserEmail:  actor.Email,
+			UserRole:   actor.Role,
+			Action:     audit.ActionReportImport,
+			EntityType: audit.EntityReport,
+			Changes: audit.Changes{
+				"file":    {New: path},
+				"created": {New: result.Created},
+				"updated": {New: result.Updated},
+				"failed":  {New: result.Failed},
+			},
+			Status: audit.StatusSuccess,
+		}
+		if err := auditService.Log(entry); err != nil {
+			log.Printf("Warning: failed to audit the import: %v", err)
+		}
+	}
+
+	if *errorsPath != "" && result.Failed > 0 {
+		if err := writeErrors(*errorsPath, result); err != nil {
+			log.Fatalf("Failed to write %s: %v", *errorsPath, err)
+		}
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			log.Fatalf("Failed to write result: %v", err)
+		}
+	} else {
+		printResult(result)
+	}
+
+	if result.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func writeErrors(path string, result *report.ImportResult) error {
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := result.WriteErrorsCSV(out); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func printResult(result *report.ImportResult) {
+	if result.Failed > 0 {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ROW\tSLUG\tERROR")
+		for _, row := range result.Rows {
+			if row.Error != "" {
+				fmt.Fprintf(w, "%d\t%s\t%s\n", row.Row, row.Slug, row.Error)
+			}
+		}
+		w.Flush()
+		fmt.Println()
+	}
+
+	if result.DryRun {
+		fmt.Printf("Checked %d rows: %d would be created, %d updated, %d failed\n",
+			len(result.Rows), result.Created, result.Updated, result.Failed)
+		fmt.Println("Dry run: nothing was written. Run with -dry-run=false to save the rows that pass.")
+		return
+	}
+	fmt.Printf("Imported %d rows: %d created, %d updated, %d failed\n",
+		len(result.Rows), result.Created, result.Updated, result.Failed)
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/healthcare-market-research/backend/internal/config"
+	"github.com/healthcare-market-research/backend/internal/db"
+	"github.com/healthcare-market-research/backend/internal/db/migrate"
+	"github.com/joho/godotenv"
+)
+
+const usage = `Usage: migrate [-dir migrations] <command> [args]
+
+Commands:
+  up [N]         Apply all pending migrations, or the next N
+  down [N]       Roll back the last migration, or the last N
+  status         List migrations and whether they are applied
+  redo           Roll back the last migration and apply it again
+  create <name>  Write an empty up/down pair for the next version into -dir
+`
+
+func main() {
+	dir := flag.String("dir", "migrations", "Directory new migrations are created in")
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	command := args[0]
+
+	// create only writes files and needs no database
+	if command == "create" {
+		if len(args) < 2 {
+			log.Fatal("create needs a migration name, e.g. migrate create add_report_tags")
+		}
+		upPath, downPath, err := migrate.Create(*dir, args[1])
+		if err != nil {
+			log.Fatalf("Failed to create migration: %v", err)
+		}
+		fmt.Println("Created", upPath)
+		fmt.Println("Created", downPath)
+		return
+	}
+
+	// Load .env file
+	if err := godotenv.Load(); err != nil {
+		log.Println("Warning: .env file not found, using system environment variables")
+	}
+
+	cfg := config.Load()
+
+	if err := db.Connect(cfg); err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	migrator, err := db.NewMigrator()
+	if err != nil {
+		log.Fatalf("Failed to load migrations: %v", err)
+	}
+	migrator.Logf = func(format string, args ...interface{}) {
+		fmt.Printf(format+"\n", args...)
+	}
+
+	ctx := context.Background()
+
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx, countArg(args, 0))
+		if err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		if len(applied) == 0 {
+			fmt.Println("✓ Schema is up to date")
+			return
+		}
+		fmt.Printf("✓ Applied %d migration(s)\n", len(applied))
+
+	case "down":
+		reverted, err := migrator.Down(ctx, countArg(args, 1))
+		if err != nil {
+			log.Fatalf("Rollback failed: %v", err)
+		}
+		fmt.Printf("✓ Rolled back %d migration(s)\n", len(reverted))
+
+	case "redo":
+		redone, err := migrator.Redo(ctx)
+		if err != nil {
+			log.Fatalf("Redo failed: %v", err)
+		}
+		fmt.Printf("✓ Redid %s\n", redone)
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatalf("Failed to read migration status: %v", err)
+		}
+		printStatus(statuses)
+
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+// countArg parses the optional step count after up/down
+func countArg(args []string, fallback int) int {
+	if len(args) < 2 {
+		return fallback
+	}
+	n, err := strconv.Atoi(args[1])
+	if err != nil || n < 1 {
+		log.Fatalf("Invalid count %q: must be a positive number", args[1])
+	}
+	return n
+}
+
+func printStatus(statuses []migrate.Status) {
+	pending := 0
+	for _, s := range statuses {
+		state := "pending"
+		switch {
+		case s.Missing:
+			state = "applied " + s.AppliedAt.Format("2006-01-02 15:04:05") + " (no file)"
+		case s.Modified:
+			state = "applied " + s.AppliedAt.Format("2006-01-02 15:04:05") + " (file changed since)"
+		case s.Applied:
+			state = "applied " + s.AppliedAt.Format("2006-01-02 15:04:05")
+		default:
+			pending++
+		}
+		fmt.Printf("%03d_%-45s %s\n", s.Version, s.Name, state)
+	}
+	fmt.Printf("\n%d migration(s), %d pending\n", len(statuses), pending)
+}
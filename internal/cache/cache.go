@@ -3,6 +3,7 @@ package cache
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -13,6 +14,10 @@ import (
 )
 
 var Client *redis.Client
+
+// ErrNotConnected is returned by Get when Connect has not been called, so
+// callers such as tests without Redis fall through to the source of truth
+var ErrNotConnected = errors.New("cache not connected")
 var ctx = context.Background()
 var sfGroup singleflight.Group
 
@@ -37,10 +42,16 @@ func Set(key string, value interface{}, ttl time.Duration) error {
 	if err != nil {
 		return err
 	}
+	if Client == nil {
+		return nil
+	}
 	return Client.Set(ctx, key, data, ttl).Err()
 }
 
 func Get(key string, dest interface{}) error {
+	if Client == nil {
+		return ErrNotConnected
+	}
 	data, err := Client.Get(ctx, key).Result()
 	if err != nil {
 		return err
@@ -49,10 +60,16 @@ func Get(key string, dest interface{}) error {
 }
 
 func Delete(key string) error {
+	if Client == nil {
+		return nil
+	}
 	return Client.Del(ctx, key).Err()
 }
 
 func DeletePattern(pattern string) error {
+	if Client == nil {
+		return nil
+	}
 	var cursor uint64
 	for {
 		var keys []string
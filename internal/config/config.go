@@ -14,7 +14,10 @@ type Config struct {
 	Redis       RedisConfig
 	Auth        AuthConfig
 	RateLimit   RateLimitConfig
-	Cloudflare  CloudflareConfig
+	Storage     StorageConfig
+	ImageGC     ImageGCConfig
+	Related     RelatedConfig
+	Site        SiteConfig
 }
 
 type DatabaseConfig struct {
@@ -46,12 +49,60 @@ type RateLimitConfig struct {
 	LoginWindow      time.Duration
 }
 
+// StorageConfig selects where uploaded images are kept. Driver is one of
+// "local", "s3" or "cloudflare"; only the matching section is used.
+type StorageConfig struct {
+	Driver     string
+	Local      LocalStorageConfig
+	S3         S3Config
+	Cloudflare CloudflareConfig
+}
+
+// LocalStorageConfig keeps images on disk and serves them from the API
+type LocalStorageConfig struct {
+	Dir     string
+	BaseURL string // Public URL the directory is served under
+}
+
+// S3Config works against AWS S3 and S3-compatible stores such as MinIO
+type S3Config struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	PublicURL       string // Optional CDN or bucket URL images are served from
+	UsePathStyle    bool   // Required by MinIO
+}
+
 type CloudflareConfig struct {
 	AccountID   string
 	APIToken    string
 	DeliveryURL string
 }
 
+// ImageGCConfig controls the background job that deletes stored images no
+// record uses any more
+type ImageGCConfig struct {
+	Enabled   bool
+	Interval  time.Duration
+	Retention time.Duration // Orphans younger than this are kept
+	DryRun    bool          // Only report orphans
+}
+
+// RelatedConfig controls the background job that recomputes related content
+// suggestions
+type RelatedConfig struct {
+	Enabled  bool
+	Interval time.Duration
+}
+
+// SiteConfig describes the public website that sitemaps and feeds link to
+type SiteConfig struct {
+	URL  string
+	Name string
+}
+
 func Load() *Config {
 	redisDB, err := strconv.Atoi(getEnv("REDIS_DB", "0"))
 	if err != nil {
@@ -69,9 +120,21 @@ func Load() *Config {
 		rateLimitMaxAttempts = 5
 	}
 
+	port := getEnv("PORT", "8081")
+
+	// Existing deployments only set the Cloudflare variables, so keep using
+	// Cloudflare for them unless a driver is chosen explicitly
+	storageDriver := os.Getenv("STORAGE_DRIVER")
+	if storageDriver == "" {
+		storageDriver = "local"
+		if os.Getenv("CLOUDFLARE_ACCOUNT_ID") != "" {
+			storageDriver = "cloudflare"
+		}
+	}
+
 	return &Config{
 		Environment: getEnv("ENVIRONMENT", "development"),
-		Port:        getEnv("PORT", "8081"),
+		Port:        port,
 		Database: DatabaseConfig{
 			URL:      os.Getenv("DATABASE_URL"), // Railway provides this
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -97,10 +160,40 @@ func Load() *Config {
 			LoginMaxAttempts: rateLimitMaxAttempts,
 			LoginWindow:      rateLimitWindow,
 		},
-		Cloudflare: CloudflareConfig{
-			AccountID:   getEnv("CLOUDFLARE_ACCOUNT_ID", ""),
-			APIToken:    getEnv("CLOUDFLARE_IMAGES_API_TOKEN", ""),
-			DeliveryURL: getEnv("CLOUDFLARE_DELIVERY_URL", ""),
+		Storage: StorageConfig{
+			Driver: storageDriver,
+			Local: LocalStorageConfig{
+				Dir:     getEnv("STORAGE_LOCAL_DIR", "./uploads"),
+				BaseURL: getEnv("STORAGE_LOCAL_BASE_URL", "http://localhost:"+port+"/uploads"),
+			},
+			S3: S3Config{
+				Endpoint:        getEnv("S3_ENDPOINT", "https://s3.amazonaws.com"),
+				Region:          getEnv("S3_REGION", "us-east-1"),
+				Bucket:          os.Getenv("S3_BUCKET"),
+				AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+				SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+				PublicURL:       os.Getenv("S3_PUBLIC_URL"),
+				UsePathStyle:    getEnv("S3_FORCE_PATH_STYLE", "false") == "true",
+			},
+			Cloudflare: CloudflareConfig{
+				AccountID:   os.Getenv("CLOUDFLARE_ACCOUNT_ID"),
+				APIToken:    os.Getenv("CLOUDFLARE_IMAGES_API_TOKEN"),
+				DeliveryURL: os.Getenv("CLOUDFLARE_DELIVERY_URL"),
+			},
+		},
+		ImageGC: ImageGCConfig{
+			Enabled:   getEnv("IMAGE_GC_ENABLED", "false") == "true",
+			Interval:  parseDuration(getEnv("IMAGE_GC_INTERVAL", "24h")),
+			Retention: parseDuration(getEnv("IMAGE_GC_RETENTION", "720h")),
+			DryRun:    getEnv("IMAGE_GC_DRY_RUN", "false") == "true",
+		},
+		Related: RelatedConfig{
+			Enabled:  getEnv("RELATED_REFRESH_ENABLED", "true") == "true",
+			Interval: parseDuration(getEnv("RELATED_REFRESH_INTERVAL", "6h")),
+		},
+		Site: SiteConfig{
+			URL:  getEnv("SITE_URL", "http://localhost:3000"),
+			Name: getEnv("SITE_NAME", "Healthcare Market Research"),
 		},
 	}
 }
@@ -1,19 +1,14 @@
 package db
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
 
 	"github.com/healthcare-market-research/backend/internal/config"
-	"github.com/healthcare-market-research/backend/internal/domain/audit"
-	"github.com/healthcare-market-research/backend/internal/domain/author"
-	"github.com/healthcare-market-research/backend/internal/domain/blog"
-	"github.com/healthcare-market-research/backend/internal/domain/category"
-	"github.com/healthcare-market-research/backend/internal/domain/form"
-	"github.com/healthcare-market-research/backend/internal/domain/press_release"
-	"github.com/healthcare-market-research/backend/internal/domain/report"
-	"github.com/healthcare-market-research/backend/internal/domain/user"
+	"github.com/healthcare-market-research/backend/internal/db/migrate"
+	migrationfiles "github.com/healthcare-market-research/backend/migrations"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -71,61 +66,52 @@ func Connect(cfg *config.Config) error {
 	return nil
 }
 
-func Migrate() error {
+// NewMigrator returns a migrator for the migrations embedded in the binary
+func NewMigrator() (*migrate.Migrator, error) {
 	if DB == nil {
-		return fmt.Errorf("database not initialized")
+		return nil, fmt.Errorf("database not initialized")
 	}
 
-	// Drop sub_categories and market_segments tables if they exist
-	DB.Exec("DROP TABLE IF EXISTS market_segments CASCADE")
-	DB.Exec("DROP TABLE IF EXISTS sub_categories CASCADE")
-
-	// Run AutoMigrate to create/update tables
-	err := DB.AutoMigrate(
-		&user.User{},
-		&category.Category{},
-		&author.Author{},
-		&report.Report{},
-		&report.ChartMetadata{},
-		&report.ReportVersion{},
-		&report.ReportImage{},
-		&audit.AuditLog{},
-		&form.FormSubmission{},
-		&blog.Blog{},
-		&press_release.PressRelease{},
-	)
+	sqlDB, err := DB.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database instance: %w", err)
+	}
 
+	migrations, err := migrate.Load(migrationfiles.FS)
 	if err != nil {
-		return fmt.Errorf("failed to run migrations: %w", err)
+		return nil, fmt.Errorf("failed to load migrations: %w", err)
 	}
 
-	// Clean up old columns if they exist (after tables are created)
-	DB.Exec("ALTER TABLE IF EXISTS reports DROP COLUMN IF EXISTS sub_category_id")
-	DB.Exec("ALTER TABLE IF EXISTS reports DROP COLUMN IF EXISTS market_segment_id")
-
-	// Add foreign key constraint for report_images.uploaded_by (GORM doesn't auto-create this)
-	// Check if constraint already exists before adding
-	var constraintExists bool
-	DB.Raw(`
-		SELECT EXISTS (
-			SELECT 1 FROM information_schema.table_constraints
-			WHERE constraint_name = 'fk_report_images_user'
-			AND table_name = 'report_images'
-			AND table_schema = CURRENT_SCHEMA()
-		)
-	`).Scan(&constraintExists)
-
-	if !constraintExists {
-		DB.Exec(`
-			ALTER TABLE report_images
-			ADD CONSTRAINT fk_report_images_user
-			FOREIGN KEY (uploaded_by)
-			REFERENCES users(id)
-			ON DELETE SET NULL
-		`)
+	return migrate.New(sqlDB, migrations), nil
+}
+
+// CheckSchema fails when the database has not applied every migration this
+// binary ships with, so the server never runs against an older schema.
+// Migrations are applied with cmd/migrate, not on boot.
+func CheckSchema(ctx context.Context) error {
+	migrator, err := NewMigrator()
+	if err != nil {
+		return err
+	}
+
+	pending, err := migrator.Pending(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("database schema is behind: %d pending migration(s), first %s; run `go run ./cmd/migrate up`", len(pending), pending[0])
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	for _, s := range statuses {
+		if s.Modified {
+			log.Printf("Warning: migration %03d_%s was edited after it was applied", s.Version, s.Name)
+		}
 	}
 
-	log.Println("Database migrations completed successfully")
 	return nil
 }
 
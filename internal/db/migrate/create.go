@@ -0,0 +1,43 @@
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var nameCleaner = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Create writes an empty up/down pair for the next version in dir and returns
+// their paths
+func Create(dir, name string) (string, string, error) {
+	name = strings.Trim(nameCleaner.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return "", "", fmt.Errorf("migration name is required")
+	}
+
+	migrations, err := Load(os.DirFS(dir))
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", dir, err)
+	}
+
+	var version int64 = 1
+	if len(migrations) > 0 {
+		version = migrations[len(migrations)-1].Version + 1
+	}
+
+	base := fmt.Sprintf("%03d_%s", version, name)
+	upPath := filepath.Join(dir, base+".up.sql")
+	downPath := filepath.Join(dir, base+".down.sql")
+
+	if err := os.WriteFile(upPath, []byte(fmt.Sprintf("-- %s\n", strings.ReplaceAll(name, "_", " "))), 0o644); err != nil {
+		return "", "", err
+	}
+	if err := os.WriteFile(downPath, []byte(fmt.Sprintf("-- Revert %s\n", strings.ReplaceAll(name, "_", " "))), 0o644); err != nil {
+		return "", "", err
+	}
+
+	return upPath, downPath, nil
+}
@@ -0,0 +1,470 @@
+// Package migrate applies the versioned SQL migrations in migrations/ and
+// records them in the schema_migrations table.
+//
+// A migration is a pair of files named <version>_<name>.up.sql and
+// <version>_<name>.down.sql. The down file is optional; a migration without one
+// cannot be rolled back. Each migration runs in its own transaction unless its
+// up file starts with "-- migrate:no-transaction"; such a file must hold a single
+// statement (e.g. CREATE INDEX CONCURRENTLY).
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// lockKey is the Postgres advisory lock held while migrating, so that pods
+// starting at the same time apply each migration once
+const lockKey int64 = 720_431_905_118_003
+
+// NoTransactionDirective marks an up file that must run outside a transaction
+const NoTransactionDirective = "-- migrate:no-transaction"
+
+const createTableSQL = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version BIGINT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    checksum VARCHAR(64) NOT NULL,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+)`
+
+var (
+	ErrNoDownMigration   = errors.New("migration has no down file")
+	ErrChecksumMismatch  = errors.New("applied migration was changed")
+	ErrNothingToRollBack = errors.New("no applied migrations to roll back")
+)
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration is a single schema change
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// Reversible reports whether the migration has a down file with SQL in it
+func (m Migration) Reversible() bool {
+	for _, line := range strings.Split(m.Down, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "--") {
+			return true
+		}
+	}
+	return false
+}
+
+// String names the migration the way its files are named
+func (m Migration) String() string {
+	return fmt.Sprintf("%03d_%s", m.Version, m.Name)
+}
+
+func (m Migration) transactional() bool {
+	return !strings.HasPrefix(strings.TrimSpace(m.Up), NoTransactionDirective)
+}
+
+// AppliedMigration is a row of schema_migrations
+type AppliedMigration struct {
+	Version   int64
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// Status describes one migration known to the files, the database or both
+type Status struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt *time.Time
+	// Modified means the up file no longer matches what was applied
+	Modified bool
+	// Missing means the database has a version that no file describes,
+	// e.g. it was migrated by a newer release
+	Missing bool
+}
+
+// Load reads the migrations in fsys, ordered by version. Files that do not
+// follow the naming scheme, duplicate versions and down files without an up
+// file are errors, so that clashing numbers are caught before anything runs.
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migration file %s: name must look like 001_add_table.up.sql", entry.Name())
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migration file %s: %w", entry.Name(), err)
+		}
+		name, direction := match[2], match[3]
+
+		body, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+		if m.Name != name {
+			return nil, fmt.Errorf("migration version %d is used by both %s and %s", version, m.Name, name)
+		}
+
+		if direction == "up" {
+			m.Up = string(body)
+			m.Checksum = checksum(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %s has a down file but no up file", m)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+func checksum(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// Migrator applies and rolls back migrations against a Postgres database
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	// Logf reports each migration as it runs; defaults to no output
+	Logf func(format string, args ...interface{})
+}
+
+// New creates a migrator for the given migrations
+func New(db *sql.DB, migrations []Migration) *Migrator {
+	return &Migrator{db: db, migrations: migrations, Logf: func(string, ...interface{}) {}}
+}
+
+// Migrations returns the known migrations in version order
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// Up applies pending migrations in version order, at most limit of them when
+// limit is positive, and returns the ones it applied
+func (m *Migrator) Up(ctx context.Context, limit int) ([]Migration, error) {
+	var done []Migration
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := verifyChecksums(m.migrations, applied); err != nil {
+			return err
+		}
+
+		for _, mig := range Pending(m.migrations, applied) {
+			if limit > 0 && len(done) == limit {
+				break
+			}
+			m.Logf("applying %s", mig)
+			if err := m.apply(ctx, conn, mig); err != nil {
+				return err
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+
+	return done, err
+}
+
+// Down rolls back the most recently applied migrations, newest first
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if steps < 1 {
+		steps = 1
+	}
+	var done []Migration
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			return ErrNothingToRollBack
+		}
+
+		known := make(map[int64]Migration, len(m.migrations))
+		for _, mig := range m.migrations {
+			known[mig.Version] = mig
+		}
+
+		for i := len(applied) - 1; i >= 0 && len(done) < steps; i-- {
+			mig, ok := known[applied[i].Version]
+			if !ok {
+				return fmt.Errorf("migration %03d_%s is applied but has no files here", applied[i].Version, applied[i].Name)
+			}
+			if !mig.Reversible() {
+				return fmt.Errorf("%s: %w", mig, ErrNoDownMigration)
+			}
+			m.Logf("rolling back %s", mig)
+			if err := m.revert(ctx, conn, mig); err != nil {
+				return err
+			}
+			done = append(done, mig)
+		}
+		return nil
+	})
+
+	return done, err
+}
+
+// Redo rolls back the latest migration and applies it again
+func (m *Migrator) Redo(ctx context.Context) (*Migration, error) {
+	var redone *Migration
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		applied, err := appliedMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			return ErrNothingToRollBack
+		}
+
+		latest := applied[len(applied)-1]
+		for _, mig := range m.migrations {
+			if mig.Version != latest.Version {
+				continue
+			}
+			if !mig.Reversible() {
+				return fmt.Errorf("%s: %w", mig, ErrNoDownMigration)
+			}
+			m.Logf("rolling back %s", mig)
+			if err := m.revert(ctx, conn, mig); err != nil {
+				return err
+			}
+			m.Logf("applying %s", mig)
+			if err := m.apply(ctx, conn, mig); err != nil {
+				return err
+			}
+			redone = &mig
+			return nil
+		}
+		return fmt.Errorf("migration %03d_%s is applied but has no files here", latest.Version, latest.Name)
+	})
+
+	return redone, err
+}
+
+// Status lists every migration with whether and when it was applied
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return BuildStatus(m.migrations, applied), nil
+}
+
+// Pending returns the migrations the database has not applied yet. It does not
+// take the lock or create schema_migrations, so it is safe on every boot.
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	applied, err := m.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return Pending(m.migrations, applied), nil
+}
+
+// Applied reads schema_migrations; a database without the table has applied nothing
+func (m *Migrator) Applied(ctx context.Context) ([]AppliedMigration, error) {
+	var table sql.NullString
+	if err := m.db.QueryRowContext(ctx, "SELECT to_regclass('schema_migrations')::text").Scan(&table); err != nil {
+		return nil, err
+	}
+	if !table.Valid {
+		return nil, nil
+	}
+	return appliedMigrations(ctx, m.db)
+}
+
+// Pending returns the migrations that are not in applied, in version order
+func Pending(migrations []Migration, applied []AppliedMigration) []Migration {
+	done := make(map[int64]bool, len(applied))
+	for _, a := range applied {
+		done[a.Version] = true
+	}
+
+	var pending []Migration
+	for _, mig := range migrations {
+		if !done[mig.Version] {
+			pending = append(pending, mig)
+		}
+	}
+	return pending
+}
+
+// BuildStatus merges the migration files with the rows of schema_migrations
+func BuildStatus(migrations []Migration, applied []AppliedMigration) []Status {
+	appliedByVersion := make(map[int64]AppliedMigration, len(applied))
+	for _, a := range applied {
+		appliedByVersion[a.Version] = a
+	}
+
+	statuses := make([]Status, 0, len(migrations))
+	known := make(map[int64]bool, len(migrations))
+	for _, mig := range migrations {
+		known[mig.Version] = true
+		s := Status{Version: mig.Version, Name: mig.Name}
+		if a, ok := appliedByVersion[mig.Version]; ok {
+			appliedAt := a.AppliedAt
+			s.Applied = true
+			s.AppliedAt = &appliedAt
+			s.Modified = a.Checksum != mig.Checksum
+		}
+		statuses = append(statuses, s)
+	}
+
+	for _, a := range applied {
+		if known[a.Version] {
+			continue
+		}
+		appliedAt := a.AppliedAt
+		statuses = append(statuses, Status{Version: a.Version, Name: a.Name, Applied: true, AppliedAt: &appliedAt, Missing: true})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses
+}
+
+// verifyChecksums refuses to migrate when an applied migration was edited
+// afterwards, since the database no longer matches the files
+func verifyChecksums(migrations []Migration, applied []AppliedMigration) error {
+	for _, s := range BuildStatus(migrations, applied) {
+		if s.Modified {
+			return fmt.Errorf("%03d_%s: %w", s.Version, s.Name, ErrChecksumMismatch)
+		}
+	}
+	return nil
+}
+
+// withLock runs fn on a single connection holding the advisory lock. The table
+// is created under the lock so that concurrent first runs do not race either.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", lockKey)
+
+	if _, err := conn.ExecContext(ctx, createTableSQL); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	return fn(conn)
+}
+
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, mig Migration) error {
+	record := func(exec execer) error {
+		_, err := exec.ExecContext(ctx,
+			"INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)",
+			mig.Version, mig.Name, mig.Checksum,
+		)
+		return err
+	}
+	return m.run(ctx, conn, mig, mig.Up, record)
+}
+
+func (m *Migrator) revert(ctx context.Context, conn *sql.Conn, mig Migration) error {
+	unrecord := func(exec execer) error {
+		_, err := exec.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", mig.Version)
+		return err
+	}
+	return m.run(ctx, conn, mig, mig.Down, unrecord)
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// run executes a migration body and updates schema_migrations, in one
+// transaction unless the migration opted out
+func (m *Migrator) run(ctx context.Context, conn *sql.Conn, mig Migration, body string, bookkeeping func(execer) error) error {
+	if !mig.transactional() {
+		if _, err := conn.ExecContext(ctx, body); err != nil {
+			return fmt.Errorf("%s: %w", mig, err)
+		}
+		return bookkeeping(conn)
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, body); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("%s: %w", mig, err)
+	}
+	if err := bookkeeping(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+func appliedMigrations(ctx context.Context, q querier) ([]AppliedMigration, error) {
+	rows, err := q.QueryContext(ctx, "SELECT version, name, checksum, applied_at FROM schema_migrations ORDER BY version")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var applied []AppliedMigration
+	for rows.Next() {
+		var a AppliedMigration
+		if err := rows.Scan(&a.Version, &a.Name, &a.Checksum, &a.AppliedAt); err != nil {
+			return nil, err
+		}
+		applied = append(applied, a)
+	}
+	return applied, rows.Err()
+}
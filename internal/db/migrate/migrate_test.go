@@ -0,0 +1,120 @@
+package migrate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	migrationfiles "github.com/healthcare-market-research/backend/migrations"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func file(body string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(body)}
+}
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"002_add_tags.up.sql":      file("CREATE TABLE tags (id BIGSERIAL PRIMARY KEY);"),
+		"002_add_tags.down.sql":    file("DROP TABLE tags;"),
+		"001_baseline.up.sql":      file("CREATE TABLE users (id BIGSERIAL PRIMARY KEY);"),
+		"embed.go":                 file("package migrations"),
+		"legacy/001_initial.sql":   file("-- not a migration"),
+		"010_no_down_yet.up.sql":   file("SELECT 1;"),
+		"010_no_down_yet.down.sql": file("-- Revert no down yet\n"),
+	}
+
+	migrations, err := Load(fsys)
+	require.NoError(t, err)
+	require.Len(t, migrations, 3)
+
+	assert.Equal(t, int64(1), migrations[0].Version)
+	assert.Equal(t, "baseline", migrations[0].Name)
+	assert.False(t, migrations[0].Reversible())
+	assert.Len(t, migrations[0].Checksum, 64)
+
+	assert.Equal(t, "002_add_tags", migrations[1].String())
+	assert.True(t, migrations[1].Reversible())
+
+	// A down file with only comments cannot roll anything back
+	assert.False(t, migrations[2].Reversible())
+}
+
+func TestLoadRejectsClashingVersions(t *testing.T) {
+	fsys := fstest.MapFS{
+		"018_add_dashboard_indexes.up.sql":    file("SELECT 1;"),
+		"018_add_soft_delete_to_blogs.up.sql": file("SELECT 1;"),
+	}
+
+	_, err := Load(fsys)
+	assert.ErrorContains(t, err, "version 18 is used by both")
+}
+
+func TestLoadRejectsMalformedNames(t *testing.T) {
+	_, err := Load(fstest.MapFS{"019_add_scheduled_publishing.sql": file("SELECT 1;")})
+	assert.ErrorContains(t, err, "name must look like")
+
+	_, err = Load(fstest.MapFS{"003_drop_things.down.sql": file("SELECT 1;")})
+	assert.ErrorContains(t, err, "no up file")
+}
+
+func TestTransactional(t *testing.T) {
+	assert.True(t, Migration{Up: "CREATE INDEX idx ON t (c);"}.transactional())
+	assert.False(t, Migration{Up: NoTransactionDirective + "\nCREATE INDEX CONCURRENTLY idx ON t (c);"}.transactional())
+}
+
+func TestPendingAndStatus(t *testing.T) {
+	migrations := []Migration{
+		{Version: 31, Name: "baseline", Checksum: "aaa"},
+		{Version: 32, Name: "add_tags", Checksum: "bbb"},
+		{Version: 33, Name: "add_companies", Checksum: "ccc"},
+	}
+	appliedAt := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	applied := []AppliedMigration{
+		{Version: 31, Name: "baseline", Checksum: "aaa", AppliedAt: appliedAt},
+		{Version: 32, Name: "add_tags", Checksum: "changed", AppliedAt: appliedAt},
+		{Version: 40, Name: "from_newer_release", Checksum: "ddd", AppliedAt: appliedAt},
+	}
+
+	pending := Pending(migrations, applied)
+	require.Len(t, pending, 1)
+	assert.Equal(t, int64(33), pending[0].Version)
+
+	statuses := BuildStatus(migrations, applied)
+	require.Len(t, statuses, 4)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[0].Modified)
+	assert.True(t, statuses[1].Modified)
+	assert.False(t, statuses[2].Applied)
+	assert.True(t, statuses[3].Missing)
+
+	assert.ErrorIs(t, verifyChecksums(migrations, applied), ErrChecksumMismatch)
+	assert.NoError(t, verifyChecksums(migrations, applied[:1]))
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "031_baseline_schema.up.sql"), []byte("SELECT 1;"), 0o644))
+
+	upPath, downPath, err := Create(dir, "Add report tags")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "032_add_report_tags.up.sql"), upPath)
+	assert.Equal(t, filepath.Join(dir, "032_add_report_tags.down.sql"), downPath)
+
+	migrations, err := Load(os.DirFS(dir))
+	require.NoError(t, err)
+	assert.Len(t, migrations, 2)
+
+	_, _, err = Create(dir, "!!!")
+	assert.Error(t, err)
+}
+
+func TestEmbeddedMigrationsLoad(t *testing.T) {
+	migrations, err := Load(migrationfiles.FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+	assert.Equal(t, "baseline_schema", migrations[0].Name)
+}
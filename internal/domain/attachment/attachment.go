@@ -0,0 +1,77 @@
+package attachment
+
+import (
+	"time"
+
+	"github.com/healthcare-market-research/backend/internal/domain/media"
+)
+
+// Owner types attachments can hang off
+const (
+	OwnerBlog         = "blog"
+	OwnerPressRelease = "press_release"
+	OwnerCategory     = "category"
+)
+
+// Attachment kinds
+const (
+	KindImage    = "image"
+	KindDocument = "document"
+)
+
+// Attachment is an uploaded image or document belonging to a blog, press
+// release or category. One image per owner can be featured.
+// @Description Uploaded image or PDF media kit attached to content
+type Attachment struct {
+	ID         uint           `json:"id" gorm:"primaryKey" example:"1"`
+	OwnerType  string         `json:"owner_type" gorm:"type:varchar(20);not null;index:idx_attachments_owner,priority:1" example:"blog"`
+	OwnerID    uint           `json:"owner_id" gorm:"not null;index:idx_attachments_owner,priority:2" example:"12"`
+	Kind       string         `json:"kind" gorm:"type:varchar(20);not null" example:"image"`
+	URL        string         `json:"url" gorm:"type:varchar(500);not null" example:"https://cdn.example.com/2024/01/3f2a.png"`
+	Variants   media.Variants `json:"variants,omitempty" gorm:"type:jsonb"`
+	FileName   string         `json:"file_name" gorm:"type:varchar(255)" example:"market-chart.png"`
+	MimeType   string         `json:"mime_type" gorm:"type:varchar(100)" example:"image/png"`
+	ByteSize   int64          `json:"byte_size" example:"48213"`
+	Caption    string         `json:"caption,omitempty" gorm:"type:varchar(500)" example:"Global market share by region, 2024"`
+	AltText    string         `json:"alt_text,omitempty" gorm:"type:varchar(255)" example:"Bar chart of market share by region"`
+	SortOrder  int            `json:"sort_order" gorm:"not null;default:0" example:"0"`
+	IsFeatured bool           `json:"is_featured" gorm:"not null;default:false" example:"false"`
+	UploadedBy *uint          `json:"uploaded_by,omitempty" example:"5"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Attachment) TableName() string {
+	return "attachments"
+}
+
+// Featured returns the featured image among attachments, if any
+func Featured(attachments []Attachment) *Attachment {
+	for i := range attachments {
+		if attachments[i].IsFeatured {
+			return &attachments[i]
+		}
+	}
+	return nil
+}
+
+// UploadAttachmentRequest holds the form fields sent with an upload
+type UploadAttachmentRequest struct {
+	Caption    string `validate:"max=500"`
+	AltText    string `validate:"max=255"`
+	IsFeatured bool
+}
+
+// UpdateAttachmentRequest is the request body for editing an attachment
+type UpdateAttachmentRequest struct {
+	Caption    *string `json:"caption,omitempty" validate:"omitempty,max=500"`
+	AltText    *string `json:"alt_text,omitempty" validate:"omitempty,max=255"`
+	IsFeatured *bool   `json:"is_featured,omitempty"`
+}
+
+// ReorderAttachmentsRequest lists all of an owner's attachment IDs in their
+// new order
+type ReorderAttachmentsRequest struct {
+	IDs []uint `json:"ids" validate:"required,min=1"`
+}
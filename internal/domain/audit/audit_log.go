@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"errors"
 	"time"
+
+	"github.com/healthcare-market-research/backend/pkg/pagination"
 )
 
 // Action constants - standardized action names
@@ -22,28 +24,90 @@ const (
 	ActionRoleChange = "user.role_change"
 
 	// Report actions
-	ActionReportCreate  = "report.create"
-	ActionReportUpdate  = "report.update"
-	ActionReportDelete  = "report.delete"
-	ActionReportPublish = "report.publish"
+	ActionReportCreate         = "report.create"
+	ActionReportUpdate         = "report.update"
+	ActionReportDelete         = "report.delete"
+	ActionReportPublish        = "report.publish"
+	ActionReportRollback       = "report.rollback"
+	ActionReportSubmitReview   = "report.submit_review"
+	ActionReportApprove        = "report.approve"
+	ActionReportReject         = "report.reject"
+	ActionReportArchive        = "report.archive"
+	ActionReportReopen         = "report.reopen"
+	ActionReportAssignReviewer = "report.assign_reviewer"
+	ActionReportClone          = "report.clone"
+	ActionReportImport         = "report.import"
+
+	// Report template actions
+	ActionReportTemplateCreate = "report_template.create"
+	ActionReportTemplateUpdate = "report_template.update"
+	ActionReportTemplateDelete = "report_template.delete"
 
 	// Category actions
 	ActionCategoryCreate = "category.create"
 	ActionCategoryUpdate = "category.update"
 	ActionCategoryDelete = "category.delete"
 
+	// Redirect actions
+	ActionRedirectCreate = "redirect.create"
+	ActionRedirectUpdate = "redirect.update"
+	ActionRedirectDelete = "redirect.delete"
+
 	// Author actions
 	ActionAuthorCreate = "author.create"
 	ActionAuthorUpdate = "author.update"
 	ActionAuthorDelete = "author.delete"
+
+	// Image actions
+	ActionImageGCDelete = "image.gc_delete"
+
+	// Chart actions
+	ActionChartCreate = "chart.create"
+	ActionChartUpdate = "chart.update"
+	ActionChartDelete = "chart.delete"
+
+	// Attachment actions
+	ActionAttachmentCreate  = "attachment.create"
+	ActionAttachmentUpdate  = "attachment.update"
+	ActionAttachmentDelete  = "attachment.delete"
+	ActionAttachmentReorder = "attachment.reorder"
+
+	// Company actions
+	ActionCompanyCreate = "company.create"
+	ActionCompanyUpdate = "company.update"
+	ActionCompanyDelete = "company.delete"
+	ActionCompanyMerge  = "company.merge"
+	ActionCompanyImport = "company.import"
+
+	// Tag actions
+	ActionTagCreate = "tag.create"
+	ActionTagUpdate = "tag.update"
+	ActionTagDelete = "tag.delete"
+	ActionTagMerge  = "tag.merge"
+
+	// Related content actions
+	ActionRelationCreate  = "relation.create"
+	ActionRelationAccept  = "relation.accept"
+	ActionRelationReject  = "relation.reject"
+	ActionRelationDelete  = "relation.delete"
+	ActionRelationRefresh = "relation.refresh"
 )
 
 // EntityType constants
 const (
-	EntityUser     = "user"
-	EntityReport   = "report"
-	EntityCategory = "category"
-	EntityAuthor   = "author"
+	EntityUser           = "user"
+	EntityReport         = "report"
+	EntityCategory       = "category"
+	EntityAuthor         = "author"
+	EntityRedirect       = "redirect"
+	EntityImage          = "image"
+	EntityReportImage    = "report_image"
+	EntityAttachment     = "attachment"
+	EntityChart          = "chart"
+	EntityCompany        = "company"
+	EntityTag            = "tag"
+	EntityRelation       = "relation"
+	EntityReportTemplate = "report_template"
 )
 
 // Status constants
@@ -116,6 +180,8 @@ type AuditLogFilters struct {
 	IPAddress  string
 	Page       int
 	Limit      int
+	Cursor     *pagination.Cursor // Keyset pagination; Page is ignored when set
+	SkipCount  bool               // Don't compute the total count
 }
 
 // AuditLogResponse for API responses
@@ -6,8 +6,11 @@ import (
 	"errors"
 	"time"
 
+	"github.com/healthcare-market-research/backend/internal/domain/attachment"
 	"github.com/healthcare-market-research/backend/internal/domain/author"
 	"github.com/healthcare-market-research/backend/internal/domain/category"
+	"github.com/healthcare-market-research/backend/internal/domain/related"
+	"github.com/healthcare-market-research/backend/pkg/pagination"
 )
 
 // BlogStatus represents the status of a blog post
@@ -51,10 +54,14 @@ type Blog struct {
 	Excerpt     string         `json:"excerpt" gorm:"type:varchar(500);not null"`
 	Content     string         `json:"content" gorm:"type:text;not null"`
 	CategoryID  uint           `json:"categoryId" gorm:"not null;index"`
+	// Tags are the comma-joined names of the tags; content_tags holds the links
 	Tags        string         `json:"tags" gorm:"type:varchar(500)"`
 	AuthorID    uint               `json:"authorId" gorm:"not null;index"`
 	Author      *author.Author     `json:"author,omitempty" gorm:"foreignKey:AuthorID"`
 	Category    *category.Category `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
+	// Attachments are loaded with single blogs, in display order
+	Attachments   []attachment.Attachment `json:"attachments,omitempty" gorm:"polymorphic:Owner;polymorphicValue:blog"`
+	FeaturedImage *attachment.Attachment  `json:"featuredImage,omitempty" gorm:"-"`
 	Status                  BlogStatus `json:"status" gorm:"type:varchar(20);default:'draft';index"`
 	PublishDate             *time.Time `json:"publishDate,omitempty" gorm:"index"`
 	ScheduledPublishEnabled bool       `json:"scheduledPublishEnabled" gorm:"default:false"`
@@ -62,6 +69,7 @@ type Blog struct {
 	Metadata    BlogMetadata   `json:"metadata" gorm:"type:jsonb"`
 	ReviewedBy  *uint          `json:"reviewedBy,omitempty" gorm:"index"`
 	ReviewedAt  *time.Time     `json:"reviewedAt,omitempty"`
+	Version     int            `json:"version" gorm:"not null;default:1"` // Optimistic lock, exposed as the ETag
 	DeletedAt   *time.Time     `json:"deletedAt,omitempty" gorm:"index"`
 	CreatedAt   time.Time      `json:"createdAt"`
 	UpdatedAt   time.Time      `json:"updatedAt"`
@@ -98,6 +106,7 @@ type UpdateBlogRequest struct {
 	PublishDate *string       `json:"publishDate,omitempty"`
 	Location    *string       `json:"location,omitempty"`
 	Metadata    *BlogMetadata `json:"metadata,omitempty"`
+	Version     *int          `json:"version,omitempty"` // Version the edit is based on; If-Match takes precedence
 }
 
 // GetBlogsQuery represents query parameters for filtering blogs
@@ -111,18 +120,28 @@ type GetBlogsQuery struct {
 	Deleted    string
 	Page       int
 	Limit      int
+	Cursor     *pagination.Cursor // Keyset pagination; Page is ignored when set
+	SkipCount  bool               // Don't compute the total count
 }
 
-// BlogListResponse represents a list of blogs with pagination
+// BlogListResponse represents a list of blogs with pagination.
+// Total and TotalPages are omitted when counting is skipped (count=false);
+// Cursor is set when another page can be fetched with keyset pagination.
 type BlogListResponse struct {
 	Blogs      []Blog `json:"blogs"`
-	Total      int64  `json:"total"`
-	Page       int    `json:"page"`
+	Total      *int64 `json:"total,omitempty"`
+	Page       int    `json:"page,omitempty"`
 	Limit      int    `json:"limit"`
-	TotalPages int    `json:"totalPages"`
+	TotalPages *int   `json:"totalPages,omitempty"`
+	Cursor     string `json:"cursor,omitempty"`
 }
 
 // BlogResponse represents a single blog response
 type BlogResponse struct {
 	Blog Blog `json:"blog"`
+	// JSONLD is the post's schema.org BlogPosting, set on public reads
+	JSONLD interface{} `json:"jsonLd,omitempty"`
+	// Related are the reports, blogs and press releases linked to the post,
+	// set on reads by slug
+	Related []related.Item `json:"related,omitempty"`
 }
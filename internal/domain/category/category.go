@@ -2,15 +2,105 @@ package category
 
 import (
 	"time"
+
+	"github.com/healthcare-market-research/backend/internal/domain/attachment"
+	"github.com/healthcare-market-research/backend/internal/domain/media"
 )
 
 type Category struct {
-	ID          uint      `json:"id" gorm:"primaryKey"`
-	Name        string    `json:"name" gorm:"type:varchar(255);not null"`
-	Slug        string    `json:"slug" gorm:"type:varchar(255);uniqueIndex;not null"`
-	Description string    `json:"description" gorm:"type:text"`
-	ImageURL    string    `json:"image_url" gorm:"type:varchar(500)"`
-	IsActive    bool      `json:"is_active" gorm:"default:true"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          uint   `json:"id" gorm:"primaryKey"`
+	ParentID    *uint  `json:"parent_id,omitempty" gorm:"index"`
+	Name        string `json:"name" gorm:"type:varchar(255);not null"`
+	Slug        string `json:"slug" gorm:"type:varchar(255);uniqueIndex;not null"`
+	Description string `json:"description" gorm:"type:text"`
+	ImageURL    string `json:"image_url" gorm:"type:varchar(500)"`
+	// ImageVariants are set when the image is uploaded rather than linked
+	ImageVariants media.Variants `json:"variants,omitempty" gorm:"type:jsonb"`
+	SortOrder     int            `json:"sort_order" gorm:"default:0;index"`
+	IsActive      bool           `json:"is_active" gorm:"default:true"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+
+	// Children is populated when categories are returned as a tree
+	Children []Category `json:"children,omitempty" gorm:"-"`
+	// Attachments are loaded with single categories, in display order
+	Attachments []attachment.Attachment `json:"attachments,omitempty" gorm:"polymorphic:Owner;polymorphicValue:category"`
+}
+
+// CreateCategoryRequest is the request body for creating a category
+type CreateCategoryRequest struct {
+	Name        string `json:"name" validate:"required,min=2,max=255"`
+	Slug        string `json:"slug,omitempty"`
+	Description string `json:"description,omitempty"`
+	ImageURL    string `json:"image_url,omitempty"`
+	ParentID    *uint  `json:"parent_id,omitempty"`
+	SortOrder   int    `json:"sort_order,omitempty"`
+}
+
+// UpdateCategoryRequest is the request body for updating a category (partial updates supported)
+type UpdateCategoryRequest struct {
+	Name        *string `json:"name,omitempty" validate:"omitempty,min=2,max=255"`
+	Slug        *string `json:"slug,omitempty"`
+	Description *string `json:"description,omitempty"`
+	ImageURL    *string `json:"image_url,omitempty"`
+	ParentID    *uint   `json:"parent_id,omitempty"`
+	// ClearParent moves the category to the root level when true
+	ClearParent bool  `json:"clear_parent,omitempty"`
+	SortOrder   *int  `json:"sort_order,omitempty"`
+	IsActive    *bool `json:"is_active,omitempty"`
+}
+
+// ReorderItem sets the position of a single category among its siblings.
+// The parent only changes when ParentID or ClearParent is given.
+type ReorderItem struct {
+	ID       uint  `json:"id"`
+	ParentID *uint `json:"parent_id,omitempty"`
+	// ClearParent moves the category to the root level when true
+	ClearParent bool `json:"clear_parent,omitempty"`
+	SortOrder   int  `json:"sort_order"`
+}
+
+// MovesParent reports whether the item changes the category's parent
+func (i ReorderItem) MovesParent() bool {
+	return i.ParentID != nil || i.ClearParent
+}
+
+// ReorderCategoriesRequest is the request body for bulk reordering categories
+type ReorderCategoriesRequest struct {
+	Items []ReorderItem `json:"items"`
+}
+
+// BuildTree arranges a flat list of categories into a nested tree.
+// Categories whose parent is missing from the list are treated as roots.
+func BuildTree(categories []Category) []Category {
+	byParent := make(map[uint][]Category)
+	present := make(map[uint]bool, len(categories))
+	for _, c := range categories {
+		present[c.ID] = true
+	}
+
+	var roots []Category
+	for _, c := range categories {
+		if c.ParentID != nil && present[*c.ParentID] && *c.ParentID != c.ID {
+			byParent[*c.ParentID] = append(byParent[*c.ParentID], c)
+		} else {
+			roots = append(roots, c)
+		}
+	}
+
+	var attach func(nodes []Category, depth int) []Category
+	attach = func(nodes []Category, depth int) []Category {
+		// Guard against cycles in corrupted data
+		if depth > len(categories) {
+			return nodes
+		}
+		for i := range nodes {
+			if children, ok := byParent[nodes[i].ID]; ok {
+				nodes[i].Children = attach(children, depth+1)
+			}
+		}
+		return nodes
+	}
+
+	return attach(roots, 0)
 }
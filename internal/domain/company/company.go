@@ -0,0 +1,195 @@
+package company
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/healthcare-market-research/backend/internal/domain/media"
+)
+
+// Company is an organization listed as a key player in reports. Reports
+// reference it by ID and keep their own market share and rank.
+// @Description Company directory entry
+type Company struct {
+	ID          uint   `json:"id" gorm:"primaryKey" example:"1"`
+	Name        string `json:"name" gorm:"type:varchar(255);not null" example:"Siemens Healthineers"`
+	Slug        string `json:"slug" gorm:"type:varchar(255);uniqueIndex;not null" example:"siemens-healthineers"`
+	Aliases     Names  `json:"aliases,omitempty" gorm:"type:jsonb" example:"Siemens Healthcare"`
+	Website     string `json:"website,omitempty" gorm:"type:varchar(500)" example:"https://www.siemens-healthineers.com"`
+	HQCountry   string `json:"hq_country,omitempty" gorm:"type:varchar(2)" example:"DE"`
+	Description string `json:"description,omitempty" gorm:"type:text"`
+	LogoURL     string `json:"logo_url,omitempty" gorm:"type:varchar(500)"`
+	// LogoVariants are set when the logo is uploaded rather than linked
+	LogoVariants media.Variants `json:"variants,omitempty" gorm:"type:jsonb"`
+	// NameKeys hold the matching keys of the name and aliases
+	NameKeys  Names     `json:"-" gorm:"type:jsonb;not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Company) TableName() string {
+	return "companies"
+}
+
+// Names is a list of names stored as a JSON array
+type Names []string
+
+func (n Names) Value() (driver.Value, error) {
+	if n == nil {
+		return "[]", nil
+	}
+	return json.Marshal(n)
+}
+
+func (n *Names) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, &n)
+}
+
+// legalSuffixes are dropped from the end of a name when matching, so
+// "Medtronic plc" and "Medtronic" are the same company
+var legalSuffixes = map[string]bool{
+	"inc": true, "incorporated": true, "corp": true, "corporation": true,
+	"co": true, "company": true, "ltd": true, "limited": true, "llc": true,
+	"plc": true, "gmbh": true, "ag": true, "sa": true, "nv": true, "bv": true,
+	"spa": true, "kk": true, "lp": true, "llp": true, "holdings": true,
+}
+
+// NameKey reduces a company name to the form used to match names: lower
+// case, punctuation dropped and legal suffixes removed
+func NameKey(name string) string {
+	name = strings.ReplaceAll(strings.ToLower(name), "&", " and ")
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for len(words) > 1 && legalSuffixes[words[len(words)-1]] {
+		words = words[:len(words)-1]
+	}
+	return strings.Join(words, " ")
+}
+
+// Keys returns the distinct matching keys of the company's name and aliases
+func (c *Company) Keys() Names {
+	keys := Names{}
+	seen := map[string]bool{}
+	for _, name := range append([]string{c.Name}, c.Aliases...) {
+		key := NameKey(name)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// CleanAliases trims aliases and drops empty ones and any that match the
+// name or an earlier alias
+func CleanAliases(name string, aliases []string) Names {
+	cleaned := Names{}
+	seen := map[string]bool{NameKey(name): true}
+	for _, alias := range aliases {
+		alias = strings.TrimSpace(alias)
+		key := NameKey(alias)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		cleaned = append(cleaned, alias)
+	}
+	return cleaned
+}
+
+// ValidCountryCode reports whether code looks like an ISO 3166-1 alpha-2 code
+func ValidCountryCode(code string) bool {
+	if len(code) != 2 {
+		return false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// CreateCompanyRequest is the request body for creating a company
+type CreateCompanyRequest struct {
+	Name        string   `json:"name" validate:"required,min=2,max=255"`
+	Slug        string   `json:"slug,omitempty"`
+	Aliases     []string `json:"aliases,omitempty"`
+	Website     string   `json:"website,omitempty"`
+	HQCountry   string   `json:"hq_country,omitempty" example:"DE"`
+	Description string   `json:"description,omitempty"`
+	LogoURL     string   `json:"logo_url,omitempty"`
+}
+
+// UpdateCompanyRequest is the request body for updating a company (partial updates supported)
+type UpdateCompanyRequest struct {
+	Name        *string   `json:"name,omitempty" validate:"omitempty,min=2,max=255"`
+	Slug        *string   `json:"slug,omitempty"`
+	Aliases     *[]string `json:"aliases,omitempty"`
+	Website     *string   `json:"website,omitempty"`
+	HQCountry   *string   `json:"hq_country,omitempty"`
+	Description *string   `json:"description,omitempty"`
+	LogoURL     *string   `json:"logo_url,omitempty"`
+}
+
+// CompanyReport is a published report a company appears in, with the
+// company's standing in that report
+type CompanyReport struct {
+	ReportID    uint       `json:"report_id" example:"12"`
+	Title       string     `json:"title" example:"Medical Imaging Market"`
+	Slug        string     `json:"slug" example:"medical-imaging-market"`
+	PublishDate *time.Time `json:"publish_date,omitempty"`
+	MarketShare string     `json:"market_share,omitempty" example:"18%"`
+	Rank        int        `json:"rank,omitempty" example:"1"`
+}
+
+// CompanyDetail is a company with the reports it appears in
+type CompanyDetail struct {
+	Company
+	Reports []CompanyReport `json:"reports"`
+}
+
+// MergeCompaniesRequest is the request body for merging duplicate companies
+// into one
+type MergeCompaniesRequest struct {
+	SourceIDs []uint `json:"source_ids"`
+}
+
+// MergeResult describes a completed merge
+type MergeResult struct {
+	Company        *Company `json:"company"`
+	MergedIDs      []uint   `json:"merged_ids"`
+	ReportsUpdated int      `json:"reports_updated"`
+}
+
+// ImportedCompany is a company created from key player names
+type ImportedCompany struct {
+	ID      uint   `json:"id,omitempty"`
+	Name    string `json:"name"`
+	Slug    string `json:"slug"`
+	Players int    `json:"players"`
+}
+
+// ImportResult describes a key player import. With DryRun nothing is written
+// and created companies have no ID.
+type ImportResult struct {
+	DryRun         bool              `json:"dry_run"`
+	Created        []ImportedCompany `json:"created"`
+	PlayersLinked  int               `json:"players_linked"`
+	PlayersMatched int               `json:"players_matched"`
+	ReportsUpdated int               `json:"reports_updated"`
+}
@@ -0,0 +1,42 @@
+package company
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNameKey(t *testing.T) {
+	tests := map[string]string{
+		"Siemens Healthineers AG": "siemens healthineers",
+		"Medtronic plc":           "medtronic",
+		"  GE HealthCare, Inc. ":  "ge healthcare",
+		"Johnson & Johnson":       "johnson and johnson",
+		"Abbott Laboratories":     "abbott laboratories",
+		"Co":                      "co",
+		"---":                     "",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, NameKey(in), in)
+	}
+}
+
+func TestCompanyKeys(t *testing.T) {
+	c := &Company{
+		Name:    "Siemens Healthineers AG",
+		Aliases: Names{"Siemens Healthcare", "siemens healthineers", ""},
+	}
+	assert.Equal(t, Names{"siemens healthineers", "siemens healthcare"}, c.Keys())
+}
+
+func TestCleanAliases(t *testing.T) {
+	aliases := CleanAliases("Medtronic plc", []string{" Medtronic ", "Covidien", "covidien ltd", "  "})
+	assert.Equal(t, Names{"Covidien"}, aliases)
+}
+
+func TestValidCountryCode(t *testing.T) {
+	assert.True(t, ValidCountryCode("DE"))
+	assert.False(t, ValidCountryCode("de"))
+	assert.False(t, ValidCountryCode("DEU"))
+	assert.False(t, ValidCountryCode(""))
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"errors"
 	"time"
+
+	"github.com/healthcare-market-research/backend/pkg/pagination"
 )
 
 // FormCategory represents the type of form submission
@@ -146,6 +148,8 @@ type GetSubmissionsQuery struct {
 	Limit     int
 	SortBy    string
 	SortOrder string
+	Cursor    *pagination.Cursor // Keyset pagination; only valid when sorting by createdAt
+	SkipCount bool               // Don't compute the total count
 }
 
 // SubmissionStats represents statistics about form submissions
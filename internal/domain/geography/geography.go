@@ -0,0 +1,242 @@
+package geography
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Levels of the taxonomy, from widest to narrowest
+const (
+	LevelGlobal  = "global"
+	LevelRegion  = "region"
+	LevelCountry = "country"
+)
+
+// Geography is a node of the Global > Region > Country taxonomy. Countries
+// are keyed by their ISO 3166-1 alpha-2 code.
+// @Description Geography a report can cover
+type Geography struct {
+	Code       string  `json:"code" gorm:"primaryKey;type:varchar(10)" example:"US"`
+	Name       string  `json:"name" gorm:"type:varchar(100);uniqueIndex;not null" example:"United States"`
+	Level      string  `json:"level" gorm:"type:varchar(10);not null" example:"country"`
+	ParentCode *string `json:"parent_code,omitempty" gorm:"type:varchar(10);index" example:"NAM"`
+	Aliases    Aliases `json:"aliases,omitempty" gorm:"type:jsonb;not null"`
+
+	// Children is populated when geographies are returned as a tree
+	Children []Geography `json:"children,omitempty" gorm:"-"`
+}
+
+// TableName specifies the table name for GORM
+func (Geography) TableName() string {
+	return "geographies"
+}
+
+// Aliases are other names a geography is known by
+type Aliases []string
+
+func (a Aliases) Value() (driver.Value, error) {
+	if a == nil {
+		return "[]", nil
+	}
+	return json.Marshal(a)
+}
+
+func (a *Aliases) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, &a)
+}
+
+// Key reduces a geography name, alias or code to the form used to match it:
+// lower case, "&" read as "and", dots dropped and other punctuation treated
+// as a space. migrations/039 uses the same rules.
+func Key(value string) string {
+	value = strings.ReplaceAll(strings.ToLower(value), "&", " and ")
+	value = strings.ReplaceAll(value, ".", "")
+	words := strings.FieldsFunc(value, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
+
+// Taxonomy resolves free-text geography values against the known geographies
+type Taxonomy struct {
+	all      []Geography
+	byKey    map[string]*Geography
+	byCode   map[string]*Geography
+	children map[string][]*Geography
+}
+
+// NewTaxonomy indexes geographies by name, alias and code. When keys clash a
+// name wins over an alias and an alias over a code, so "NA" can stand for
+// North America while Namibia is still found by name.
+func NewTaxonomy(geographies []Geography) *Taxonomy {
+	t := &Taxonomy{
+		all:      geographies,
+		byKey:    map[string]*Geography{},
+		byCode:   map[string]*Geography{},
+		children: map[string][]*Geography{},
+	}
+
+	passes := []func(g *Geography) []string{
+		func(g *Geography) []string { return []string{g.Name} },
+		func(g *Geography) []string { return g.Aliases },
+		func(g *Geography) []string { return []string{g.Code} },
+	}
+	for _, keys := range passes {
+		for i := range t.all {
+			for _, value := range keys(&t.all[i]) {
+				key := Key(value)
+				if _, taken := t.byKey[key]; key != "" && !taken {
+					t.byKey[key] = &t.all[i]
+				}
+			}
+		}
+	}
+
+	for i := range t.all {
+		t.byCode[t.all[i].Code] = &t.all[i]
+		if parent := t.all[i].ParentCode; parent != nil {
+			t.children[*parent] = append(t.children[*parent], &t.all[i])
+		}
+	}
+
+	return t
+}
+
+// Resolve finds the geography a value names
+func (t *Taxonomy) Resolve(value string) (*Geography, bool) {
+	g, ok := t.byKey[Key(value)]
+	return g, ok
+}
+
+// Normalize replaces each value with the canonical name of the geography it
+// names, dropping duplicates and keeping the order. Values that name no
+// geography are returned in the error.
+func (t *Taxonomy) Normalize(values []string) ([]string, error) {
+	names := make([]string, 0, len(values))
+	seen := map[string]bool{}
+	var unknown []string
+	for _, value := range values {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		g, ok := t.Resolve(value)
+		if !ok {
+			unknown = append(unknown, strings.TrimSpace(value))
+			continue
+		}
+		if !seen[g.Name] {
+			seen[g.Name] = true
+			names = append(names, g.Name)
+		}
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("unknown geography %s", strings.Join(quoteAll(unknown), ", "))
+	}
+	return names, nil
+}
+
+// Expand returns the names a geography filter should match: each value's
+// canonical name and the names of everything beneath it, so a region matches
+// reports tagged with any of its countries. Global is not expanded, because
+// a filter for it is looking for worldwide reports rather than every report.
+// Unknown values are kept as given so legacy tags can still be found.
+func (t *Taxonomy) Expand(values []string) []string {
+	var names []string
+	seen := map[string]bool{}
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	var walk func(g *Geography)
+	walk = func(g *Geography) {
+		add(g.Name)
+		if g.Level == LevelGlobal {
+			return
+		}
+		for _, child := range t.children[g.Code] {
+			walk(child)
+		}
+	}
+
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		if g, ok := t.Resolve(value); ok {
+			walk(g)
+		} else {
+			add(value)
+		}
+	}
+	return names
+}
+
+// Within reports whether the geography value names lies inside area, which
+// is any geography above it in the taxonomy. A geography is not within
+// itself, and values either one doesn't know are never within anything.
+func (t *Taxonomy) Within(value, area string) bool {
+	g, ok := t.Resolve(value)
+	if !ok {
+		return false
+	}
+	outer, ok := t.Resolve(area)
+	if !ok {
+		return false
+	}
+	for g.ParentCode != nil {
+		if g, ok = t.byCode[*g.ParentCode]; !ok {
+			return false
+		}
+		if g.Code == outer.Code {
+			return true
+		}
+	}
+	return false
+}
+
+// Tree nests the geographies under their parents, each level sorted by name
+func (t *Taxonomy) Tree() []Geography {
+	var build func(nodes []*Geography) []Geography
+	build = func(nodes []*Geography) []Geography {
+		tree := make([]Geography, 0, len(nodes))
+		for _, n := range nodes {
+			g := *n
+			g.Children = build(t.children[g.Code])
+			tree = append(tree, g)
+		}
+		sort.Slice(tree, func(i, j int) bool { return tree[i].Name < tree[j].Name })
+		return tree
+	}
+
+	var roots []*Geography
+	for i := range t.all {
+		if t.all[i].ParentCode == nil {
+			roots = append(roots, &t.all[i])
+		}
+	}
+	return build(roots)
+}
+
+func quoteAll(values []string) []string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return quoted
+}
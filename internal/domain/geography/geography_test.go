@@ -0,0 +1,118 @@
+package geography
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func code(v string) *string { return &v }
+
+func testTaxonomy() *Taxonomy {
+	return NewTaxonomy([]Geography{
+		{Code: "GLOBAL", Name: "Global", Level: LevelGlobal, Aliases: Aliases{"Worldwide"}},
+		{Code: "NAM", Name: "North America", Level: LevelRegion, ParentCode: code("GLOBAL"), Aliases: Aliases{"NA", "N. America"}},
+		{Code: "EUR", Name: "Europe", Level: LevelRegion, ParentCode: code("GLOBAL")},
+		{Code: "MEA", Name: "Middle East and Africa", Level: LevelRegion, ParentCode: code("GLOBAL")},
+		{Code: "US", Name: "United States", Level: LevelCountry, ParentCode: code("NAM"), Aliases: Aliases{"USA", "U.S."}},
+		{Code: "CA", Name: "Canada", Level: LevelCountry, ParentCode: code("NAM")},
+		{Code: "DE", Name: "Germany", Level: LevelCountry, ParentCode: code("EUR")},
+		{Code: "NA", Name: "Namibia", Level: LevelCountry, ParentCode: code("MEA")},
+	})
+}
+
+func TestKey(t *testing.T) {
+	tests := map[string]string{
+		"North America":        "north america",
+		"  U.S.A. ":            "usa",
+		"Middle East & Africa": "middle east and africa",
+		"Asia-Pacific":         "asia pacific",
+		"Côte d'Ivoire":        "côte d ivoire",
+		"---":                  "",
+	}
+	for in, want := range tests {
+		assert.Equal(t, want, Key(in), in)
+	}
+}
+
+func TestTaxonomyResolve(t *testing.T) {
+	tax := testTaxonomy()
+
+	for value, want := range map[string]string{
+		"north america":        "North America",
+		"N. America":           "North America",
+		"USA":                  "United States",
+		"us":                   "United States",
+		"de":                   "Germany",
+		"Middle East & Africa": "Middle East and Africa",
+		// The alias wins over Namibia's code, which is still found by name
+		"NA":      "North America",
+		"Namibia": "Namibia",
+	} {
+		g, ok := tax.Resolve(value)
+		require.True(t, ok, value)
+		assert.Equal(t, want, g.Name, value)
+	}
+
+	_, ok := tax.Resolve("Atlantis")
+	assert.False(t, ok)
+}
+
+func TestTaxonomyNormalize(t *testing.T) {
+	tax := testTaxonomy()
+
+	names, err := tax.Normalize([]string{"usa", " Europe ", "United States", "", "Worldwide"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"United States", "Europe", "Global"}, names)
+
+	_, err = tax.Normalize([]string{"Europe", "Atlantis", "Lemuria"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"Atlantis", "Lemuria"`)
+}
+
+func TestTaxonomyExpand(t *testing.T) {
+	tax := testTaxonomy()
+
+	assert.Equal(t,
+		[]string{"North America", "United States", "Canada"},
+		tax.Expand([]string{"NAM"}))
+
+	// Global is not expanded into every region and country
+	assert.Equal(t, []string{"Global"}, tax.Expand([]string{"worldwide"}))
+
+	// Countries are not repeated and unknown values are kept
+	assert.Equal(t,
+		[]string{"United States", "North America", "Canada", "Asia"},
+		tax.Expand([]string{"US", "North America", " Asia "}))
+}
+
+func TestTaxonomyWithin(t *testing.T) {
+	tax := testTaxonomy()
+
+	assert.True(t, tax.Within("USA", "North America"))
+	assert.True(t, tax.Within("Germany", "Global"))
+	assert.True(t, tax.Within("Europe", "worldwide"))
+	assert.False(t, tax.Within("United States", "United States"))
+	assert.False(t, tax.Within("North America", "United States"))
+	assert.False(t, tax.Within("Germany", "North America"))
+	assert.False(t, tax.Within("Atlantis", "Global"))
+}
+
+func TestTaxonomyTree(t *testing.T) {
+	tree := testTaxonomy().Tree()
+
+	require.Len(t, tree, 1)
+	assert.Equal(t, "Global", tree[0].Name)
+
+	regions := tree[0].Children
+	require.Len(t, regions, 3)
+	assert.Equal(t, "Europe", regions[0].Name)
+	assert.Equal(t, "North America", regions[2].Name)
+
+	countries := regions[2].Children
+	require.Len(t, countries, 2)
+	assert.Equal(t, "Canada", countries[0].Name)
+	assert.Equal(t, "United States", countries[1].Name)
+	assert.Empty(t, countries[0].Children)
+}
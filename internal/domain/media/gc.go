@@ -0,0 +1,79 @@
+package media
+
+import "time"
+
+// Reasons an image is collected
+const (
+	// OrphanUnreferenced images are not used by any record
+	OrphanUnreferenced = "unreferenced"
+	// OrphanInactive images only belong to deactivated report images
+	OrphanInactive = "inactive"
+)
+
+// ImageReference is an image URL saved on a record, along with its variants
+type ImageReference struct {
+	EntityType string
+	EntityID   uint
+	ImageURL   string
+	Variants   Variants
+	// Active is false for deactivated report images, which only keep their
+	// files for the retention window
+	Active    bool
+	UpdatedAt time.Time
+}
+
+// URLs lists the image and all of its variants
+func (r *ImageReference) URLs() []string {
+	urls := r.Variants.URLs()
+	if r.ImageURL != "" {
+		urls = append(urls, r.ImageURL)
+	}
+	return urls
+}
+
+// OrphanedImage records when a stored image was first found unreferenced
+type OrphanedImage struct {
+	Key         string    `gorm:"primaryKey;type:varchar(500)"`
+	URL         string    `gorm:"type:varchar(1000);not null"`
+	Size        int64     `gorm:"not null;default:0"`
+	FirstSeenAt time.Time `gorm:"not null;index"`
+	LastSeenAt  time.Time `gorm:"not null"`
+}
+
+// TableName specifies the table name for GORM
+func (OrphanedImage) TableName() string {
+	return "orphaned_images"
+}
+
+// Orphan is a stored image that no active record uses. Due orphans have been
+// unreferenced or inactive for longer than the retention window.
+type Orphan struct {
+	Key          string    `json:"key"`
+	URL          string    `json:"url"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"last_modified"`
+	Reason       string    `json:"reason"`
+	EntityType   string    `json:"entity_type,omitempty"`
+	EntityID     *uint     `json:"entity_id,omitempty"`
+	OrphanedAt   time.Time `json:"orphaned_at"`
+	Due          bool      `json:"due"`
+	Deleted      bool      `json:"deleted"`
+	Error        string    `json:"error,omitempty"`
+}
+
+// GCReport summarizes one garbage collection run
+type GCReport struct {
+	DryRun     bool      `json:"dry_run"`
+	Cutoff     time.Time `json:"cutoff"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	Scanned    int       `json:"scanned"`
+	Referenced int       `json:"referenced"`
+	Orphans    []Orphan  `json:"orphans"`
+	OrphanSize int64     `json:"orphan_size"`
+	Due        int       `json:"due"`
+	Deleted    int       `json:"deleted"`
+	Failed     int       `json:"failed"`
+	// Purged counts inactive report images removed once their files were gone
+	Purged int `json:"purged"`
+}
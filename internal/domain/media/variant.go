@@ -0,0 +1,107 @@
+package media
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// Variant names
+const (
+	VariantThumb = "thumb"
+	VariantCard  = "card"
+	VariantOG    = "og"
+	VariantFull  = "full"
+)
+
+// VariantSpec describes how a variant is rendered from the original. Fit
+// variants are scaled down to fit the box and never enlarged; cropped ones
+// are scaled and center-cropped to exactly Width x Height.
+type VariantSpec struct {
+	Name   string
+	Width  int
+	Height int
+	Crop   bool
+}
+
+// VariantSpecs are rendered for every uploaded report, author and category image
+var VariantSpecs = []VariantSpec{
+	{Name: VariantThumb, Width: 320, Height: 320},
+	{Name: VariantCard, Width: 640, Height: 640},
+	{Name: VariantOG, Width: 1200, Height: 630, Crop: true},
+	{Name: VariantFull, Width: 2048, Height: 2048},
+}
+
+// Variant is one rendition of an image, in the original format and in WebP
+// @Description Resized rendition of an image
+type Variant struct {
+	URL    string `json:"url" example:"https://cdn.example.com/2024/01/3f2a.png"`
+	WebP   string `json:"webp" example:"https://cdn.example.com/2024/01/9c1d.webp"`
+	Width  int    `json:"width" example:"320"`
+	Height int    `json:"height" example:"180"`
+}
+
+// Variants maps variant names to their renditions
+type Variants map[string]Variant
+
+// URLs lists every stored file of every variant. Variants of WebP originals
+// point URL and WebP at the same file, which is listed once.
+func (v Variants) URLs() []string {
+	urls := make([]string, 0, 2*len(v))
+	for _, variant := range v {
+		if variant.URL != "" {
+			urls = append(urls, variant.URL)
+		}
+		if variant.WebP != "" && variant.WebP != variant.URL {
+			urls = append(urls, variant.WebP)
+		}
+	}
+	return urls
+}
+
+func (v Variants) Value() (driver.Value, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
+
+func (v *Variants) Scan(value interface{}) error {
+	if value == nil {
+		*v = nil
+		return nil
+	}
+	var bytes []byte
+	switch data := value.(type) {
+	case []byte:
+		bytes = data
+	case string:
+		bytes = []byte(data)
+	default:
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, v)
+}
+
+// VariantBackfill is one image the variant backfill visited
+type VariantBackfill struct {
+	EntityType string `json:"entity_type"`
+	EntityID   uint   `json:"entity_id"`
+	URL        string `json:"url"`
+	// Skipped images are not in the configured storage, such as linked ones
+	Skipped bool   `json:"skipped"`
+	Saved   bool   `json:"saved"`
+	Error   string `json:"error,omitempty"`
+}
+
+// VariantBackfillReport summarizes one variant backfill run
+type VariantBackfillReport struct {
+	DryRun     bool              `json:"dry_run"`
+	StartedAt  time.Time         `json:"started_at"`
+	FinishedAt time.Time         `json:"finished_at"`
+	Images     []VariantBackfill `json:"images"`
+	Saved      int               `json:"saved"`
+	Skipped    int               `json:"skipped"`
+	Failed     int               `json:"failed"`
+}
@@ -0,0 +1,43 @@
+package media
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVariants_URLs(t *testing.T) {
+	v := Variants{
+		VariantThumb: {URL: "https://cdn.example.com/thumb.png", WebP: "https://cdn.example.com/thumb.webp"},
+		VariantCard:  {URL: "https://cdn.example.com/card.webp", WebP: "https://cdn.example.com/card.webp"},
+	}
+
+	assert.ElementsMatch(t, []string{
+		"https://cdn.example.com/thumb.png",
+		"https://cdn.example.com/thumb.webp",
+		"https://cdn.example.com/card.webp",
+	}, v.URLs())
+	assert.Empty(t, Variants(nil).URLs())
+}
+
+func TestVariants_ValueScan(t *testing.T) {
+	v := Variants{VariantOG: {URL: "https://cdn.example.com/og.jpg", WebP: "https://cdn.example.com/og.webp", Width: 1200, Height: 630}}
+
+	value, err := v.Value()
+	require.NoError(t, err)
+
+	var scanned Variants
+	require.NoError(t, scanned.Scan(value))
+	assert.Equal(t, v, scanned)
+
+	require.NoError(t, scanned.Scan(string(value.([]byte))))
+	assert.Equal(t, v, scanned)
+
+	require.NoError(t, scanned.Scan(nil))
+	assert.Nil(t, scanned)
+
+	value, err = Variants(nil).Value()
+	require.NoError(t, err)
+	assert.Nil(t, value)
+}
@@ -6,8 +6,11 @@ import (
 	"errors"
 	"time"
 
+	"github.com/healthcare-market-research/backend/internal/domain/attachment"
 	"github.com/healthcare-market-research/backend/internal/domain/author"
 	"github.com/healthcare-market-research/backend/internal/domain/category"
+	"github.com/healthcare-market-research/backend/internal/domain/related"
+	"github.com/healthcare-market-research/backend/pkg/pagination"
 )
 
 // PressReleaseStatus represents the status of a press release
@@ -51,10 +54,15 @@ type PressRelease struct {
 	Excerpt     string                 `json:"excerpt" gorm:"type:varchar(500);not null"`
 	Content     string                 `json:"content" gorm:"type:text;not null"`
 	CategoryID  uint                   `json:"categoryId" gorm:"not null;index"`
+	// Tags are the comma-joined names of the tags; content_tags holds the links
 	Tags        string                 `json:"tags" gorm:"type:varchar(500)"`
 	AuthorID    uint                   `json:"authorId" gorm:"not null;index"`
 	Author      *author.Author         `json:"author,omitempty" gorm:"foreignKey:AuthorID"`
 	Category    *category.Category     `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
+	// Attachments (images and PDF media kits) are loaded with single press
+	// releases, in display order
+	Attachments   []attachment.Attachment `json:"attachments,omitempty" gorm:"polymorphic:Owner;polymorphicValue:press_release"`
+	FeaturedImage *attachment.Attachment  `json:"featuredImage,omitempty" gorm:"-"`
 	Status                  PressReleaseStatus `json:"status" gorm:"type:varchar(20);default:'draft';index"`
 	PublishDate             *time.Time         `json:"publishDate,omitempty" gorm:"index"`
 	ScheduledPublishEnabled bool               `json:"scheduledPublishEnabled" gorm:"default:false"`
@@ -62,6 +70,7 @@ type PressRelease struct {
 	Metadata    PressReleaseMetadata   `json:"metadata" gorm:"type:jsonb"`
 	ReviewedBy  *uint                  `json:"reviewedBy,omitempty" gorm:"index"`
 	ReviewedAt  *time.Time             `json:"reviewedAt,omitempty"`
+	Version     int                    `json:"version" gorm:"not null;default:1"` // Optimistic lock, exposed as the ETag
 	DeletedAt   *time.Time             `json:"deletedAt,omitempty" gorm:"index"`
 	CreatedAt   time.Time              `json:"createdAt"`
 	UpdatedAt   time.Time              `json:"updatedAt"`
@@ -98,6 +107,7 @@ type UpdatePressReleaseRequest struct {
 	PublishDate *string                `json:"publishDate,omitempty"`
 	Location    *string                `json:"location,omitempty"`
 	Metadata    *PressReleaseMetadata  `json:"metadata,omitempty"`
+	Version     *int                   `json:"version,omitempty"` // Version the edit is based on; If-Match takes precedence
 }
 
 // GetPressReleasesQuery represents query parameters for filtering press releases
@@ -111,18 +121,28 @@ type GetPressReleasesQuery struct {
 	Deleted    string
 	Page       int
 	Limit      int
+	Cursor     *pagination.Cursor // Keyset pagination; Page is ignored when set
+	SkipCount  bool               // Don't compute the total count
 }
 
-// PressReleaseListResponse represents a list of press releases with pagination
+// PressReleaseListResponse represents a list of press releases with pagination.
+// Total and TotalPages are omitted when counting is skipped (count=false);
+// Cursor is set when another page can be fetched with keyset pagination.
 type PressReleaseListResponse struct {
 	PressReleases []PressRelease `json:"pressReleases"`
-	Total         int64          `json:"total"`
-	Page          int            `json:"page"`
+	Total         *int64         `json:"total,omitempty"`
+	Page          int            `json:"page,omitempty"`
 	Limit         int            `json:"limit"`
-	TotalPages    int            `json:"totalPages"`
+	TotalPages    *int           `json:"totalPages,omitempty"`
+	Cursor        string         `json:"cursor,omitempty"`
 }
 
 // PressReleaseResponse represents a single press release response
 type PressReleaseResponse struct {
 	PressRelease PressRelease `json:"pressRelease"`
+	// JSONLD is the press release's schema.org NewsArticle, set on public reads
+	JSONLD interface{} `json:"jsonLd,omitempty"`
+	// Related are the reports, blogs and press releases linked to the
+	// release, set on reads by slug
+	Related []related.Item `json:"related,omitempty"`
 }
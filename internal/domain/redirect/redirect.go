@@ -0,0 +1,101 @@
+package redirect
+
+import (
+	"fmt"
+	"time"
+)
+
+// Entity types that keep a slug history
+const (
+	EntityReport       = "report"
+	EntityBlog         = "blog"
+	EntityPressRelease = "press_release"
+	EntityCategory     = "category"
+	EntityCompany      = "company"
+	EntityTag          = "tag"
+)
+
+// EntityTypes lists every entity type a redirect can point at
+var EntityTypes = []string{EntityReport, EntityBlog, EntityPressRelease, EntityCategory, EntityCompany, EntityTag}
+
+// ValidEntityType reports whether redirects are supported for the entity type
+func ValidEntityType(entityType string) bool {
+	for _, t := range EntityTypes {
+		if t == entityType {
+			return true
+		}
+	}
+	return false
+}
+
+// SlugRedirect maps a retired slug to the entity that used it. The target slug
+// is looked up when the redirect is resolved, so renaming an entity twice never
+// produces a redirect chain.
+type SlugRedirect struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	EntityType string    `json:"entity_type" gorm:"type:varchar(30);not null;uniqueIndex:idx_slug_redirects_type_slug"`
+	OldSlug    string    `json:"old_slug" gorm:"type:varchar(255);not null;uniqueIndex:idx_slug_redirects_type_slug"`
+	EntityID   uint      `json:"entity_id" gorm:"not null;index"`
+	IsManual   bool      `json:"is_manual" gorm:"default:false"`
+	CreatedBy  *uint     `json:"created_by,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+
+	// CurrentSlug is the slug the redirect resolves to, filled in for listings
+	CurrentSlug string `json:"current_slug,omitempty" gorm:"-"`
+}
+
+// TableName specifies the table name for GORM
+func (SlugRedirect) TableName() string {
+	return "slug_redirects"
+}
+
+// Target is where a retired slug now lives
+type Target struct {
+	EntityType string `json:"entity_type"`
+	EntityID   uint   `json:"entity_id"`
+	OldSlug    string `json:"old_slug"`
+	Slug       string `json:"slug"`
+	Location   string `json:"location"`
+}
+
+// Location returns the public API path of an entity's slug lookup
+func Location(entityType, slug string) string {
+	switch entityType {
+	case EntityReport:
+		return fmt.Sprintf("/api/v1/reports/%s", slug)
+	case EntityBlog:
+		return fmt.Sprintf("/api/v1/blogs/slug/%s", slug)
+	case EntityPressRelease:
+		return fmt.Sprintf("/api/v1/press-releases/slug/%s", slug)
+	case EntityCategory:
+		return fmt.Sprintf("/api/v1/categories/%s", slug)
+	case EntityCompany:
+		return fmt.Sprintf("/api/v1/companies/%s", slug)
+	case EntityTag:
+		return fmt.Sprintf("/api/v1/tags/%s", slug)
+	}
+	return ""
+}
+
+// CreateRedirectRequest is the request body for adding a manual redirect
+type CreateRedirectRequest struct {
+	EntityType string `json:"entity_type"`
+	OldSlug    string `json:"old_slug"`
+	EntityID   uint   `json:"entity_id"`
+}
+
+// UpdateRedirectRequest is the request body for changing a redirect (partial updates supported)
+type UpdateRedirectRequest struct {
+	OldSlug  *string `json:"old_slug,omitempty"`
+	EntityID *uint   `json:"entity_id,omitempty"`
+}
+
+// RedirectFilters for listing redirects
+type RedirectFilters struct {
+	EntityType string
+	EntityID   *uint
+	Search     string
+	Page       int
+	Limit      int
+}
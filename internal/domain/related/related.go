@@ -0,0 +1,178 @@
+package related
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/healthcare-market-research/backend/internal/domain/tag"
+)
+
+// Relation statuses. Curated relations are made by editors; suggestions are
+// computed and stay suggested until an editor accepts or rejects them.
+const (
+	StatusCurated   = "curated"
+	StatusSuggested = "suggested"
+	StatusAccepted  = "accepted"
+	StatusRejected  = "rejected" // never shown and never suggested again
+)
+
+// Statuses lists every relation status
+var Statuses = []string{StatusCurated, StatusSuggested, StatusAccepted, StatusRejected}
+
+// ValidStatus reports whether status is a relation status
+func ValidStatus(status string) bool {
+	for _, s := range Statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Ref identifies a report, blog or press release. Content types are the
+// ones tags use.
+type Ref struct {
+	Type string `json:"type" example:"report"`
+	ID   uint   `json:"id" example:"12"`
+}
+
+// Valid reports whether the ref names a content type that can be related
+func (r Ref) Valid() bool {
+	return r.ID != 0 && tag.ValidContentType(r.Type)
+}
+
+// Less orders refs by type, then ID
+func (r Ref) Less(other Ref) bool {
+	if r.Type != other.Type {
+		return r.Type < other.Type
+	}
+	return r.ID < other.ID
+}
+
+func (r Ref) String() string {
+	return fmt.Sprintf("%s:%d", r.Type, r.ID)
+}
+
+// Pair orders two refs the way a relation stores them
+func Pair(a, b Ref) (Ref, Ref) {
+	if b.Less(a) {
+		return b, a
+	}
+	return a, b
+}
+
+// Relation links two content items. Relations have no direction: each pair
+// is stored once, the lower ref as the source, and shows on both items.
+// @Description Relation between two content items
+type Relation struct {
+	ID         uint       `json:"id" gorm:"primaryKey" example:"1"`
+	SourceType string     `json:"source_type" gorm:"type:varchar(20);not null" example:"blog"`
+	SourceID   uint       `json:"source_id" gorm:"not null" example:"4"`
+	TargetType string     `json:"target_type" gorm:"type:varchar(20);not null" example:"report"`
+	TargetID   uint       `json:"target_id" gorm:"not null" example:"12"`
+	Status     string     `json:"status" gorm:"type:varchar(20);not null;default:'suggested';index" example:"suggested"`
+	Score      float64    `json:"score" gorm:"not null;default:0" example:"6.5"`
+	Reasons    Reasons    `json:"reasons" gorm:"type:jsonb;not null"`
+	CreatedBy  *uint      `json:"created_by,omitempty"`
+	ReviewedBy *uint      `json:"reviewed_by,omitempty"`
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Relation) TableName() string {
+	return "content_relations"
+}
+
+// Source returns the relation's lower ref
+func (r *Relation) Source() Ref {
+	return Ref{Type: r.SourceType, ID: r.SourceID}
+}
+
+// Target returns the relation's higher ref
+func (r *Relation) Target() Ref {
+	return Ref{Type: r.TargetType, ID: r.TargetID}
+}
+
+// SetPair stores the two refs in relation order
+func (r *Relation) SetPair(a, b Ref) {
+	source, target := Pair(a, b)
+	r.SourceType, r.SourceID = source.Type, source.ID
+	r.TargetType, r.TargetID = target.Type, target.ID
+}
+
+// Shown reports whether the relation is listed with its content
+func (r *Relation) Shown() bool {
+	return r.Status != StatusRejected
+}
+
+// Reasons records what a suggestion's score is made of
+type Reasons struct {
+	Category    bool     `json:"category,omitempty"`
+	Tags        int      `json:"tags,omitempty" example:"2"`
+	Geographies []string `json:"geographies,omitempty"`
+	KeyPlayers  int      `json:"key_players,omitempty" example:"1"`
+	// Text is the similarity of the titles and summaries, from 0 to 1
+	Text float64 `json:"text,omitempty" example:"0.42"`
+}
+
+func (r Reasons) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+func (r *Reasons) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, &r)
+}
+
+// Item is a related report, blog or press release
+type Item struct {
+	Type        string     `json:"type" example:"report"`
+	ID          uint       `json:"id" example:"12"`
+	Title       string     `json:"title" example:"Medical Imaging Market"`
+	Slug        string     `json:"slug" example:"medical-imaging-market"`
+	Excerpt     string     `json:"excerpt,omitempty"`
+	PublishDate *time.Time `json:"publish_date,omitempty"`
+	// Curated is set when an editor made or accepted the relation
+	Curated bool `json:"curated"`
+}
+
+// Ref returns the item's ref
+func (i *Item) Ref() Ref {
+	return Ref{Type: i.Type, ID: i.ID}
+}
+
+// RelationDetail is a relation with both of its items, for review
+type RelationDetail struct {
+	Relation
+	Source *Item `json:"source,omitempty"`
+	Target *Item `json:"target,omitempty"`
+}
+
+// CreateRelationRequest is the request body for relating two content items
+type CreateRelationRequest struct {
+	SourceType string `json:"source_type" example:"press_release"`
+	SourceID   uint   `json:"source_id" example:"7"`
+	TargetType string `json:"target_type" example:"report"`
+	TargetID   uint   `json:"target_id" example:"12"`
+}
+
+// RefreshResult describes a run of the suggestion engine
+type RefreshResult struct {
+	Items     int       `json:"items" example:"420"`
+	Suggested int       `json:"suggested" example:"1310"`
+	Added     int       `json:"added" example:"25"`
+	Removed   int       `json:"removed" example:"8"`
+	StartedAt time.Time `json:"started_at"`
+	Duration  string    `json:"duration" example:"1.2s"`
+}
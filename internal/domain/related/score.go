@@ -0,0 +1,284 @@
+package related
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Suggestion scoring. A shared category alone is not enough to suggest two
+// items; it takes a shared tag, key player or similar wording as well.
+const (
+	weightCategory  = 2.0
+	weightTag       = 1.5
+	weightGeography = 1.0
+	weightKeyPlayer = 1.5
+	weightText      = 5.0
+
+	// Shared tags, geographies and key players past these add nothing more
+	maxTags        = 3
+	maxGeographies = 2
+	maxKeyPlayers  = 3
+
+	// MinScore is the lowest score suggested
+	MinScore = 3.0
+	// MaxSuggestions is how many suggestions each item keeps
+	MaxSuggestions = 8
+)
+
+// Profile holds what the suggestion engine compares about a content item
+type Profile struct {
+	Ref
+	CategoryID  uint
+	TagIDs      []uint
+	Geographies []string
+	Companies   []uint
+
+	titleTerms map[string]bool
+	bodyTerms  map[string]bool
+}
+
+// NewProfile builds an item's profile. Only reports have geographies and
+// key players; body is the summary or excerpt.
+func NewProfile(ref Ref, categoryID uint, tagIDs []uint, geographies []string, companies []uint, title, body string) Profile {
+	return Profile{
+		Ref:         ref,
+		CategoryID:  categoryID,
+		TagIDs:      tagIDs,
+		Geographies: geographies,
+		Companies:   companies,
+		titleTerms:  Terms(title),
+		bodyTerms:   Terms(body),
+	}
+}
+
+// Score rates how closely two items are related
+func Score(a, b *Profile) (float64, Reasons) {
+	var reasons Reasons
+	score := 0.0
+
+	if a.CategoryID != 0 && a.CategoryID == b.CategoryID {
+		reasons.Category = true
+		score += weightCategory
+	}
+
+	reasons.Tags = countShared(a.TagIDs, b.TagIDs)
+	score += weightTag * float64(min(reasons.Tags, maxTags))
+
+	reasons.Geographies = sharedGeographies(a.Geographies, b.Geographies)
+	score += weightGeography * float64(min(len(reasons.Geographies), maxGeographies))
+
+	reasons.KeyPlayers = countShared(a.Companies, b.Companies)
+	score += weightKeyPlayer * float64(min(reasons.KeyPlayers, maxKeyPlayers))
+
+	reasons.Text = round2(TextSimilarity(a, b))
+	score += weightText * reasons.Text
+
+	return round2(score), reasons
+}
+
+// TextSimilarity compares the wording of two items from 0 to 1. Titles are
+// compared by overlap, so a short title contained in a longer one matches
+// fully, and bodies by cosine similarity.
+func TextSimilarity(a, b *Profile) float64 {
+	title := 0.0
+	if n := min(len(a.titleTerms), len(b.titleTerms)); n > 0 {
+		title = float64(intersect(a.titleTerms, b.titleTerms)) / float64(n)
+	}
+	body := 0.0
+	if len(a.bodyTerms) > 0 && len(b.bodyTerms) > 0 {
+		body = float64(intersect(a.bodyTerms, b.bodyTerms)) / math.Sqrt(float64(len(a.bodyTerms)*len(b.bodyTerms)))
+	}
+	return (title + body) / 2
+}
+
+// Suggest scores every pair of items sharing a category, tag, geography, key
+// player or title word and keeps each item's best maxPerItem pairs scoring
+// at least minScore. Pairs are returned once, in relation order.
+func Suggest(profiles []Profile, maxPerItem int, minScore float64) []Relation {
+	index := map[string][]int{}
+	for i := range profiles {
+		for _, key := range profiles[i].keys() {
+			index[key] = append(index[key], i)
+		}
+	}
+
+	type scored struct {
+		other   int
+		score   float64
+		reasons Reasons
+	}
+	best := make([][]scored, len(profiles))
+
+	for i := range profiles {
+		seen := map[int]bool{}
+		for _, key := range profiles[i].keys() {
+			for _, j := range index[key] {
+				// Each pair is scored once, from its lower index
+				if j <= i || seen[j] {
+					continue
+				}
+				seen[j] = true
+				score, reasons := Score(&profiles[i], &profiles[j])
+				if score < minScore {
+					continue
+				}
+				best[i] = append(best[i], scored{other: j, score: score, reasons: reasons})
+				best[j] = append(best[j], scored{other: i, score: score, reasons: reasons})
+			}
+		}
+	}
+
+	kept := map[[2]int]Relation{}
+	for i, candidates := range best {
+		sort.Slice(candidates, func(x, y int) bool {
+			if candidates[x].score != candidates[y].score {
+				return candidates[x].score > candidates[y].score
+			}
+			return profiles[candidates[x].other].Ref.Less(profiles[candidates[y].other].Ref)
+		})
+		if len(candidates) > maxPerItem {
+			candidates = candidates[:maxPerItem]
+		}
+		for _, c := range candidates {
+			key := [2]int{min(i, c.other), max(i, c.other)}
+			if _, ok := kept[key]; ok {
+				continue
+			}
+			rel := Relation{Status: StatusSuggested, Score: c.score, Reasons: c.reasons}
+			rel.SetPair(profiles[i].Ref, profiles[c.other].Ref)
+			kept[key] = rel
+		}
+	}
+
+	suggestions := make([]Relation, 0, len(kept))
+	for _, rel := range kept {
+		suggestions = append(suggestions, rel)
+	}
+	sort.Slice(suggestions, func(x, y int) bool {
+		a, b := suggestions[x], suggestions[y]
+		if a.Source() != b.Source() {
+			return a.Source().Less(b.Source())
+		}
+		return a.Target().Less(b.Target())
+	})
+	return suggestions
+}
+
+// keys are the index entries candidates are found by. Body words are left
+// out: they are too common to narrow anything down.
+func (p *Profile) keys() []string {
+	var keys []string
+	if p.CategoryID != 0 {
+		keys = append(keys, "category:"+uintKey(p.CategoryID))
+	}
+	for _, id := range p.TagIDs {
+		keys = append(keys, "tag:"+uintKey(id))
+	}
+	for _, g := range p.Geographies {
+		if !broadGeography(g) {
+			keys = append(keys, "geography:"+strings.ToLower(g))
+		}
+	}
+	for _, id := range p.Companies {
+		keys = append(keys, "company:"+uintKey(id))
+	}
+	for term := range p.titleTerms {
+		keys = append(keys, "term:"+term)
+	}
+	return keys
+}
+
+// Terms splits text into the lower-case words compared between items,
+// without stop words, words under three letters and a plural s
+func Terms(text string) map[string]bool {
+	terms := map[string]bool{}
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, w := range words {
+		if len(w) < 3 || stopWords[w] {
+			continue
+		}
+		if len(w) > 4 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") {
+			w = strings.TrimSuffix(w, "s")
+		}
+		terms[w] = true
+	}
+	return terms
+}
+
+// stopWords are common English words and words nearly every report title
+// carries
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "from": true, "that": true,
+	"this": true, "are": true, "was": true, "were": true, "will": true, "has": true,
+	"have": true, "its": true, "into": true, "our": true, "their": true, "out": true,
+	"about": true, "over": true, "new": true, "how": true, "what": true, "why": true,
+	"not": true, "but": true, "can": true, "more": true, "than": true, "which": true,
+	"market": true, "markets": true, "report": true, "global": true, "industry": true,
+	"size": true, "share": true, "growth": true, "forecast": true, "analysis": true,
+	"trends": true, "outlook": true, "healthcare": true, "research": true,
+}
+
+// broadGeography is true for geographies too wide to relate items by
+func broadGeography(name string) bool {
+	return strings.EqualFold(strings.TrimSpace(name), "global")
+}
+
+func sharedGeographies(a, b []string) []string {
+	inB := map[string]bool{}
+	for _, g := range b {
+		inB[strings.ToLower(g)] = true
+	}
+	var shared []string
+	seen := map[string]bool{}
+	for _, g := range a {
+		key := strings.ToLower(g)
+		if broadGeography(g) || !inB[key] || seen[key] {
+			continue
+		}
+		seen[key] = true
+		shared = append(shared, g)
+	}
+	return shared
+}
+
+func countShared(a, b []uint) int {
+	inB := map[uint]bool{}
+	for _, id := range b {
+		inB[id] = true
+	}
+	n := 0
+	seen := map[uint]bool{}
+	for _, id := range a {
+		if inB[id] && !seen[id] {
+			seen[id] = true
+			n++
+		}
+	}
+	return n
+}
+
+func intersect(a, b map[string]bool) int {
+	if len(b) < len(a) {
+		a, b = b, a
+	}
+	n := 0
+	for term := range a {
+		if b[term] {
+			n++
+		}
+	}
+	return n
+}
+
+func uintKey(id uint) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,79 @@
+package related
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPair(t *testing.T) {
+	report := Ref{Type: "report", ID: 3}
+	blog := Ref{Type: "blog", ID: 9}
+
+	source, target := Pair(report, blog)
+	assert.Equal(t, blog, source)
+	assert.Equal(t, report, target)
+
+	source, target = Pair(Ref{Type: "report", ID: 12}, report)
+	assert.Equal(t, report, source)
+	assert.Equal(t, Ref{Type: "report", ID: 12}, target)
+}
+
+func TestTerms(t *testing.T) {
+	terms := Terms("The Global Medical Imaging Market: Scanners & Diagnostics, 2024")
+	assert.Equal(t, map[string]bool{"medical": true, "imaging": true, "scanner": true, "diagnostic": true, "2024": true}, terms)
+}
+
+func TestScore(t *testing.T) {
+	rep := NewProfile(Ref{Type: "report", ID: 1}, 4, []uint{1, 2}, []string{"Global", "United States"}, []uint{10, 11},
+		"Medical Imaging Market", "Demand for MRI and CT scanners keeps rising across hospitals.")
+	release := NewProfile(Ref{Type: "press_release", ID: 7}, 4, []uint{2}, nil, nil,
+		"Medical Imaging Market to Reach $45 Billion by 2030", "MRI and CT scanner demand drives hospital spending.")
+
+	score, reasons := Score(&rep, &release)
+	assert.True(t, reasons.Category)
+	assert.Equal(t, 1, reasons.Tags)
+	assert.Empty(t, reasons.Geographies)
+	assert.Zero(t, reasons.KeyPlayers)
+	assert.Greater(t, reasons.Text, 0.5)
+	assert.GreaterOrEqual(t, score, MinScore)
+
+	// Global is shared by most reports and does not count
+	other := NewProfile(Ref{Type: "report", ID: 2}, 8, nil, []string{"Global", "united states"}, []uint{11}, "Dental Implants", "")
+	_, reasons = Score(&rep, &other)
+	assert.Equal(t, []string{"United States"}, reasons.Geographies)
+	assert.Equal(t, 1, reasons.KeyPlayers)
+}
+
+func TestSuggest(t *testing.T) {
+	profiles := []Profile{
+		NewProfile(Ref{Type: "report", ID: 1}, 4, []uint{1}, nil, nil, "Medical Imaging Market", ""),
+		NewProfile(Ref{Type: "press_release", ID: 7}, 4, []uint{1}, nil, nil, "Medical Imaging Market Set to Grow", ""),
+		NewProfile(Ref{Type: "blog", ID: 2}, 4, nil, nil, nil, "Hospital Staffing in 2025", ""),
+		NewProfile(Ref{Type: "blog", ID: 3}, 9, nil, nil, nil, "Dental Implants Explained", ""),
+	}
+
+	suggestions := Suggest(profiles, MaxSuggestions, MinScore)
+	if assert.Len(t, suggestions, 1) {
+		s := suggestions[0]
+		assert.Equal(t, Ref{Type: "press_release", ID: 7}, s.Source())
+		assert.Equal(t, Ref{Type: "report", ID: 1}, s.Target())
+		assert.Equal(t, StatusSuggested, s.Status)
+		assert.Equal(t, 6.0, s.Score)
+	}
+}
+
+func TestSuggest_KeepsBestPerItem(t *testing.T) {
+	profiles := []Profile{
+		NewProfile(Ref{Type: "report", ID: 1}, 0, []uint{1, 2, 3}, nil, nil, "", ""),
+		NewProfile(Ref{Type: "blog", ID: 2}, 0, []uint{1, 2}, nil, nil, "", ""),
+		NewProfile(Ref{Type: "blog", ID: 3}, 0, []uint{1, 2, 3}, nil, nil, "", ""),
+	}
+
+	// Every pair scores enough, but the report and blog 2 are neither's best
+	suggestions := Suggest(profiles, 1, MinScore)
+	assert.Len(t, suggestions, 2)
+	for _, s := range suggestions {
+		assert.NotEqual(t, [2]Ref{{Type: "blog", ID: 2}, {Type: "report", ID: 1}}, [2]Ref{s.Source(), s.Target()})
+	}
+}
@@ -0,0 +1,179 @@
+package report
+
+import (
+	"database/sql/driver"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Chart types
+const (
+	ChartBar        = "bar"
+	ChartStackedBar = "stacked_bar"
+	ChartLine       = "line"
+	ChartArea       = "area"
+	ChartPie        = "pie"
+	ChartDonut      = "donut"
+	ChartScatter    = "scatter"
+	ChartTable      = "table"
+)
+
+// ChartTypes lists the chart types a chart can be rendered as
+var ChartTypes = []string{ChartBar, ChartStackedBar, ChartLine, ChartArea, ChartPie, ChartDonut, ChartScatter, ChartTable}
+
+// Limits on the size of a chart's data
+const (
+	MaxChartLabels = 500
+	MaxChartSeries = 50
+)
+
+// IsValidChartType reports whether t is one of ChartTypes
+func IsValidChartType(t string) bool {
+	for _, chartType := range ChartTypes {
+		if chartType == t {
+			return true
+		}
+	}
+	return false
+}
+
+// ChartSeries is one named row of values, aligned with the chart's labels.
+// Missing values are null.
+type ChartSeries struct {
+	Name   string     `json:"name" example:"North America"`
+	Values []*float64 `json:"values" swaggertype:"array,number" example:"12.4,13.9,15.6"`
+}
+
+// ChartData is the data a chart is drawn from
+// @Description Chart data: one value per label in every series
+type ChartData struct {
+	Labels []string      `json:"labels" example:"2024,2025,2026"`
+	Series []ChartSeries `json:"series"`
+	Units  string        `json:"units,omitempty" example:"USD Billion"`
+	Source string        `json:"source,omitempty" example:"Company filings and analyst estimates"`
+}
+
+// Validate checks that the data has labels and series and that every series
+// has one value per label
+func (d *ChartData) Validate() error {
+	if len(d.Labels) == 0 {
+		return errors.New("chart data needs at least one label")
+	}
+	if len(d.Labels) > MaxChartLabels {
+		return fmt.Errorf("chart data can have at most %d labels", MaxChartLabels)
+	}
+	if len(d.Series) == 0 {
+		return errors.New("chart data needs at least one series")
+	}
+	if len(d.Series) > MaxChartSeries {
+		return fmt.Errorf("chart data can have at most %d series", MaxChartSeries)
+	}
+	for i, series := range d.Series {
+		if strings.TrimSpace(series.Name) == "" {
+			return fmt.Errorf("series %d needs a name", i+1)
+		}
+		if len(series.Values) != len(d.Labels) {
+			return fmt.Errorf("series %q has %d values for %d labels", series.Name, len(series.Values), len(d.Labels))
+		}
+	}
+	return nil
+}
+
+// Points counts the values that are present
+func (d *ChartData) Points() int {
+	points := 0
+	for _, series := range d.Series {
+		for _, v := range series.Values {
+			if v != nil {
+				points++
+			}
+		}
+	}
+	return points
+}
+
+// WriteCSV writes one row per label, with a column per series. Missing
+// values are left empty.
+func (d *ChartData) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	header := make([]string, 0, len(d.Series)+1)
+	header = append(header, "label")
+	for _, series := range d.Series {
+		header = append(header, series.Name)
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for i, label := range d.Labels {
+		row := make([]string, 0, len(d.Series)+1)
+		row = append(row, label)
+		for _, series := range d.Series {
+			cell := ""
+			if i < len(series.Values) && series.Values[i] != nil {
+				cell = strconv.FormatFloat(*series.Values[i], 'f', -1, 64)
+			}
+			row = append(row, cell)
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func (d ChartData) Value() (driver.Value, error) {
+	return json.Marshal(d)
+}
+
+func (d *ChartData) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, d)
+}
+
+// CreateChartRequest represents the request body for adding a chart to a report
+type CreateChartRequest struct {
+	Title       string     `json:"title" validate:"required,min=2,max=500" example:"Market Size by Region, 2024-2030"`
+	ChartType   string     `json:"chart_type" validate:"required" example:"bar"`
+	Description string     `json:"description,omitempty"`
+	Data        *ChartData `json:"data,omitempty"`
+	ImageID     *uint      `json:"image_id,omitempty" example:"12"`
+	Order       *int       `json:"order,omitempty" example:"0"`
+	IsActive    *bool      `json:"is_active,omitempty" example:"true"`
+}
+
+// UpdateChartRequest represents the request body for updating a chart. Only
+// the fields given are changed; ClearImage unlinks the fallback image.
+type UpdateChartRequest struct {
+	Title       *string    `json:"title,omitempty"`
+	ChartType   *string    `json:"chart_type,omitempty"`
+	Description *string    `json:"description,omitempty"`
+	Data        *ChartData `json:"data,omitempty"`
+	ImageID     *uint      `json:"image_id,omitempty"`
+	ClearImage  bool       `json:"clear_image,omitempty"`
+	Order       *int       `json:"order,omitempty"`
+	IsActive    *bool      `json:"is_active,omitempty"`
+}
+
+// ChartExport is the JSON export of a chart's data
+type ChartExport struct {
+	ID        uint       `json:"id"`
+	ReportID  uint       `json:"report_id"`
+	Title     string     `json:"title"`
+	ChartType string     `json:"chart_type"`
+	Data      *ChartData `json:"data"`
+}
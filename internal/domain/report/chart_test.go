@@ -0,0 +1,51 @@
+package report
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func value(v float64) *float64 {
+	return &v
+}
+
+func TestChartDataValidate(t *testing.T) {
+	valid := &ChartData{
+		Labels: []string{"2024", "2025"},
+		Series: []ChartSeries{{Name: "Europe", Values: []*float64{value(1.5), nil}}},
+	}
+	assert.NoError(t, valid.Validate())
+
+	assert.Error(t, (&ChartData{Series: valid.Series}).Validate(), "no labels")
+	assert.Error(t, (&ChartData{Labels: valid.Labels}).Validate(), "no series")
+	assert.Error(t, (&ChartData{
+		Labels: valid.Labels,
+		Series: []ChartSeries{{Name: " ", Values: []*float64{nil, nil}}},
+	}).Validate(), "unnamed series")
+	assert.Error(t, (&ChartData{
+		Labels: valid.Labels,
+		Series: []ChartSeries{{Name: "Asia", Values: []*float64{value(1)}}},
+	}).Validate(), "values do not match labels")
+}
+
+func TestChartDataPointsAndCSV(t *testing.T) {
+	data := &ChartData{
+		Labels: []string{"2024", "2025, est."},
+		Series: []ChartSeries{
+			{Name: "Europe", Values: []*float64{value(1.5), nil}},
+			{Name: "Asia", Values: []*float64{value(2), value(2.25)}},
+		},
+	}
+	assert.Equal(t, 3, data.Points())
+
+	var buf bytes.Buffer
+	assert.NoError(t, data.WriteCSV(&buf))
+	assert.Equal(t, "label,Europe,Asia\n2024,1.5,2\n\"2025, est.\",,2.25\n", buf.String())
+}
+
+func TestIsValidChartType(t *testing.T) {
+	assert.True(t, IsValidChartType(ChartStackedBar))
+	assert.False(t, IsValidChartType("radar"))
+}
@@ -0,0 +1,48 @@
+package report
+
+// CloneRequest is the optional body for cloning a report. The title and
+// slug default to the original's with a "copy" suffix.
+type CloneRequest struct {
+	Title         string `json:"title,omitempty" example:"Cardiac Monitoring Market - Europe"`
+	Slug          string `json:"slug,omitempty" example:"cardiac-monitoring-market-europe"`
+	IncludeImages bool   `json:"include_images"`
+}
+
+// CloneResult is the draft a clone created
+type CloneResult struct {
+	Report     *Report `json:"report"`
+	ClonedFrom uint    `json:"cloned_from"`
+	Images     int     `json:"images"` // Gallery images copied
+}
+
+// Clone returns a new report with the content of r: sections, FAQs, key
+// players, market metrics, pricing, geography, tags and SEO fields. Workflow
+// state, publishing, tracking fields and internal notes are left behind.
+func (r *Report) Clone() Report {
+	clone := Report{
+		CategoryID:      r.CategoryID,
+		Title:           r.Title,
+		Slug:            r.Slug,
+		Description:     r.Description,
+		Summary:         r.Summary,
+		Price:           r.Price,
+		DiscountedPrice: r.DiscountedPrice,
+		Currency:        r.Currency,
+		PageCount:       r.PageCount,
+		Formats:         append(StringSlice{}, r.Formats...),
+		Geography:       append(StringSlice{}, r.Geography...),
+		Tags:            append(StringSlice{}, r.Tags...),
+		AuthorIDs:       append(UintSlice{}, r.AuthorIDs...),
+		KeyPlayers:      append(KeyPlayers{}, r.KeyPlayers...),
+		Sections:        r.Sections,
+		FAQs:            append(FAQs{}, r.FAQs...),
+		MetaTitle:       r.MetaTitle,
+		MetaDescription: r.MetaDescription,
+		MetaKeywords:    r.MetaKeywords,
+	}
+	if r.MarketMetrics != nil {
+		metrics := *r.MarketMetrics
+		clone.MarketMetrics = &metrics
+	}
+	return clone
+}
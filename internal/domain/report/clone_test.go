@@ -0,0 +1,59 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportClone(t *testing.T) {
+	editor := uint(7)
+	company := uint(3)
+	now := time.Now()
+	original := &Report{
+		ID:            12,
+		CategoryID:    4,
+		Title:         "Cardiac Monitoring Market",
+		Slug:          "cardiac-monitoring-market",
+		Summary:       "Summary",
+		Price:         3999,
+		Geography:     StringSlice{"North America"},
+		Tags:          StringSlice{"Cardiology"},
+		Status:        StatusPublished,
+		IsFeatured:    true,
+		PublishDate:   &now,
+		MarketMetrics: &MarketMetrics{CurrentRevenue: "USD 4.2 Billion", CurrentYear: 2024},
+		KeyPlayers:    KeyPlayers{{CompanyID: &company, Name: "Medtronic"}},
+		Sections:      ReportSections{TableOfContents: "1. Introduction"},
+		FAQs:          FAQs{{Question: "Q", Answer: "A"}},
+		CreatedBy:     &editor,
+		InternalNotes: "renewal pending",
+		ReviewedBy:    &editor,
+		Version:       6,
+	}
+
+	clone := original.Clone()
+
+	assert.Zero(t, clone.ID)
+	assert.Equal(t, original.Sections, clone.Sections)
+	assert.Equal(t, original.FAQs, clone.FAQs)
+	assert.Equal(t, original.KeyPlayers, clone.KeyPlayers)
+	assert.Equal(t, original.Tags, clone.Tags)
+	assert.Equal(t, 3999.0, clone.Price)
+
+	// Workflow, publishing and tracking stay with the original
+	assert.Empty(t, clone.Status)
+	assert.False(t, clone.IsFeatured)
+	assert.Nil(t, clone.PublishDate)
+	assert.Nil(t, clone.CreatedBy)
+	assert.Nil(t, clone.ReviewedBy)
+	assert.Empty(t, clone.InternalNotes)
+	assert.Zero(t, clone.Version)
+
+	// Editing the clone leaves the original alone
+	clone.MarketMetrics.CurrentRevenue = "USD 5 Billion"
+	clone.Geography[0] = "Europe"
+	assert.Equal(t, "USD 4.2 Billion", original.MarketMetrics.CurrentRevenue)
+	assert.Equal(t, StringSlice{"North America"}, original.Geography)
+}
@@ -0,0 +1,272 @@
+package report
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/healthcare-market-research/backend/internal/domain/geography"
+)
+
+// Bounds on how many reports can be compared at once
+const (
+	MinCompared = 2
+	MaxCompared = 5
+)
+
+// Compared metrics
+const (
+	MetricMarketSize   = "market_size"
+	MetricForecastSize = "forecast_size"
+	MetricCAGR         = "cagr"
+)
+
+// How a report covers a geography in a comparison
+const (
+	CoverageListed = "listed" // the report lists the geography
+	CoverageWithin = "within" // the report lists a wider geography that holds it
+	CoverageNone   = ""
+)
+
+// Comparison lines up reports side by side. Reports are the columns; every
+// other slice has one entry per report, in the same order.
+// @Description Reports compared side by side. Row values line up with reports.
+type Comparison struct {
+	Reports    []Report            `json:"reports"`
+	Metrics    []MetricRow         `json:"metrics"`
+	KeyPlayers KeyPlayerComparison `json:"key_players"`
+	Geography  []CoverageRow       `json:"geography"`
+}
+
+// MetricRow compares one market metric across the reports
+type MetricRow struct {
+	Metric string       `json:"metric" example:"market_size"` // market_size, forecast_size or cagr
+	Unit   string       `json:"unit" example:"usd_millions"`  // usd_millions for sizes, percent for cagr
+	Values []MetricCell `json:"values"`
+}
+
+// MetricCell is one report's value for a metric. Value is empty when the
+// report doesn't state the metric or it couldn't be converted to US dollars.
+type MetricCell struct {
+	Value   *float64 `json:"value"`
+	Display string   `json:"display,omitempty" example:"USD 4.2 Billion"` // As written in the report
+	Period  string   `json:"period,omitempty" example:"2024"`             // Year of a size, years of a CAGR
+}
+
+// KeyPlayerComparison splits the key players into companies several reports
+// list and companies only one does
+type KeyPlayerComparison struct {
+	Overlapping []PlayerRow `json:"overlapping"`
+	Unique      []PlayerRow `json:"unique"`
+}
+
+// PlayerRow is one company across the reports. Players holds each report's
+// entry for it, or null where the report doesn't list it.
+type PlayerRow struct {
+	CompanyID *uint        `json:"companyId,omitempty"`
+	Name      string       `json:"name"`
+	Reports   int          `json:"reports"` // Number of reports listing the company
+	Players   []*KeyPlayer `json:"players"`
+}
+
+// CoverageRow is one geography and how each report covers it
+type CoverageRow struct {
+	Geography string   `json:"geography"`
+	Level     string   `json:"level,omitempty"`
+	Coverage  []string `json:"coverage"` // listed, within or empty
+	Shared    bool     `json:"shared"`   // Every report covers it
+}
+
+// Compare lines up the reports in the order given. Their market metrics are
+// normalized first; metrics that can't be read are compared as missing.
+// taxonomy places geographies inside the regions that hold them and may be
+// nil, in which case only listed geographies count.
+func Compare(reports []Report, taxonomy *geography.Taxonomy) *Comparison {
+	for i := range reports {
+		if m := reports[i].MarketMetrics; m != nil {
+			normalized := *m
+			if normalized.Normalize() == nil {
+				reports[i].MarketMetrics = &normalized
+			}
+		}
+	}
+
+	return &Comparison{
+		Reports:    reports,
+		Metrics:    compareMetrics(reports),
+		KeyPlayers: compareKeyPlayers(reports),
+		Geography:  compareGeography(reports, taxonomy),
+	}
+}
+
+func compareMetrics(reports []Report) []MetricRow {
+	rows := []MetricRow{
+		{Metric: MetricMarketSize, Unit: "usd_millions"},
+		{Metric: MetricForecastSize, Unit: "usd_millions"},
+		{Metric: MetricCAGR, Unit: "percent"},
+	}
+
+	for i := range rows {
+		rows[i].Values = make([]MetricCell, len(reports))
+		for j, rep := range reports {
+			m := rep.MarketMetrics
+			if m == nil {
+				continue
+			}
+			cell := &rows[i].Values[j]
+			switch rows[i].Metric {
+			case MetricMarketSize:
+				*cell = sizeCell(m.CurrentRevenue, m.CurrentValue, m.CurrentYear)
+			case MetricForecastSize:
+				*cell = sizeCell(m.ForecastRevenue, m.ForecastValue, m.ForecastYear)
+			case MetricCAGR:
+				cell.Value = m.CAGRPercent
+				cell.Display = m.CAGR
+				cell.Period = cagrPeriod(m)
+			}
+		}
+	}
+	return rows
+}
+
+func sizeCell(display string, value *MarketValue, year int) MetricCell {
+	cell := MetricCell{Display: display}
+	if value != nil {
+		cell.Value = value.USDMillions
+	}
+	if year != 0 {
+		cell.Period = strconv.Itoa(year)
+	}
+	return cell
+}
+
+// cagrPeriod is the span a CAGR covers, falling back to the years of the
+// current and forecast values
+func cagrPeriod(m *MarketMetrics) string {
+	start, end := m.CAGRStartYear, m.CAGREndYear
+	if start == 0 {
+		start = m.CurrentYear
+	}
+	if end == 0 {
+		end = m.ForecastYear
+	}
+	if start == 0 || end == 0 {
+		return ""
+	}
+	return strconv.Itoa(start) + "-" + strconv.Itoa(end)
+}
+
+// compareKeyPlayers matches players by company, or by name for players not
+// linked to the directory. An unlinked player whose name a linked player
+// carries in another report is taken to be the same company.
+func compareKeyPlayers(reports []Report) KeyPlayerComparison {
+	linked := map[string]uint{}
+	for _, rep := range reports {
+		for _, p := range rep.KeyPlayers {
+			if p.CompanyID != nil {
+				linked[playerName(p.Name)] = *p.CompanyID
+			}
+		}
+	}
+
+	company := func(p KeyPlayer) *uint {
+		if p.CompanyID != nil {
+			return p.CompanyID
+		}
+		if id, ok := linked[playerName(p.Name)]; ok {
+			return &id
+		}
+		return nil
+	}
+
+	var rows []*PlayerRow
+	byKey := map[string]*PlayerRow{}
+	for i, rep := range reports {
+		for j := range rep.KeyPlayers {
+			p := rep.KeyPlayers[j]
+			if strings.TrimSpace(p.Name) == "" && p.CompanyID == nil {
+				continue
+			}
+			id := company(p)
+			k := "name:" + playerName(p.Name)
+			if id != nil {
+				k = "company:" + strconv.FormatUint(uint64(*id), 10)
+			}
+			row, ok := byKey[k]
+			if !ok {
+				row = &PlayerRow{CompanyID: id, Name: p.Name, Players: make([]*KeyPlayer, len(reports))}
+				byKey[k] = row
+				rows = append(rows, row)
+			}
+			// A report listing a company twice keeps its first entry
+			if row.Players[i] == nil {
+				row.Players[i] = &p
+				row.Reports++
+			}
+		}
+	}
+
+	comparison := KeyPlayerComparison{Overlapping: []PlayerRow{}, Unique: []PlayerRow{}}
+	for _, row := range rows {
+		if row.Reports > 1 {
+			comparison.Overlapping = append(comparison.Overlapping, *row)
+		} else {
+			comparison.Unique = append(comparison.Unique, *row)
+		}
+	}
+	// Companies most reports list first; the rest keep the order they were listed in
+	sort.SliceStable(comparison.Overlapping, func(i, j int) bool {
+		return comparison.Overlapping[i].Reports > comparison.Overlapping[j].Reports
+	})
+	return comparison
+}
+
+func playerName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// compareGeography lists every geography a report names, in the order they
+// are first named, with how each report covers it
+func compareGeography(reports []Report, taxonomy *geography.Taxonomy) []CoverageRow {
+	rows := []CoverageRow{}
+	seen := map[string]bool{}
+	for _, rep := range reports {
+		for _, name := range rep.Geography {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			row := CoverageRow{Geography: name, Coverage: make([]string, len(reports)), Shared: true}
+			if taxonomy != nil {
+				if g, ok := taxonomy.Resolve(name); ok {
+					row.Level = g.Level
+				}
+			}
+			for i, other := range reports {
+				row.Coverage[i] = coverage(other.Geography, name, taxonomy)
+				if row.Coverage[i] == CoverageNone {
+					row.Shared = false
+				}
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func coverage(listed []string, name string, taxonomy *geography.Taxonomy) string {
+	for _, g := range listed {
+		if g == name {
+			return CoverageListed
+		}
+	}
+	if taxonomy != nil {
+		for _, g := range listed {
+			if taxonomy.Within(name, g) {
+				return CoverageWithin
+			}
+		}
+	}
+	return CoverageNone
+}
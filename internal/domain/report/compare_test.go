@@ -0,0 +1,113 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/healthcare-market-research/backend/internal/domain/geography"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func companyID(id uint) *uint { return &id }
+
+func TestCompare(t *testing.T) {
+	parent := func(code string) *string { return &code }
+	taxonomy := geography.NewTaxonomy([]geography.Geography{
+		{Code: "GLOBAL", Name: "Global", Level: geography.LevelGlobal},
+		{Code: "NAM", Name: "North America", Level: geography.LevelRegion, ParentCode: parent("GLOBAL")},
+		{Code: "US", Name: "United States", Level: geography.LevelCountry, ParentCode: parent("NAM")},
+		{Code: "DE", Name: "Germany", Level: geography.LevelCountry, ParentCode: parent("GLOBAL")},
+	})
+
+	reports := []Report{
+		{
+			ID:        1,
+			Title:     "Cardiac Monitoring Market",
+			Geography: StringSlice{"North America", "Germany"},
+			MarketMetrics: &MarketMetrics{
+				CurrentRevenue: "USD 4.2 Billion", CurrentYear: 2024,
+				ForecastRevenue: "EUR 6 Billion", ForecastYear: 2030,
+				CAGR: "7.1%",
+			},
+			KeyPlayers: KeyPlayers{
+				{CompanyID: companyID(3), Name: "Medtronic", Rank: 1},
+				{Name: "iRhythm Technologies", Rank: 2},
+			},
+		},
+		{
+			ID:        2,
+			Title:     "Remote Patient Monitoring Market",
+			Geography: StringSlice{"United States"},
+			MarketMetrics: &MarketMetrics{
+				CurrentRevenue: "N/A", CurrentYear: 2024,
+			},
+			KeyPlayers: KeyPlayers{
+				{Name: "medtronic ", MarketShare: "12%"},
+				{CompanyID: companyID(8), Name: "Philips"},
+			},
+		},
+	}
+
+	cmp := Compare(reports, taxonomy)
+	require.Len(t, cmp.Reports, 2)
+
+	require.Len(t, cmp.Metrics, 3)
+	size := cmp.Metrics[0]
+	assert.Equal(t, MetricMarketSize, size.Metric)
+	require.Len(t, size.Values, 2)
+	require.NotNil(t, size.Values[0].Value)
+	assert.InDelta(t, 4200, *size.Values[0].Value, 0.0001)
+	assert.Equal(t, "2024", size.Values[0].Period)
+	// Unreadable sizes are shown as written with no value
+	assert.Nil(t, size.Values[1].Value)
+	assert.Equal(t, "N/A", size.Values[1].Display)
+
+	forecast := cmp.Metrics[1]
+	require.NotNil(t, forecast.Values[0].Value)
+	assert.InDelta(t, 6480, *forecast.Values[0].Value, 0.0001)
+
+	cagr := cmp.Metrics[2]
+	require.NotNil(t, cagr.Values[0].Value)
+	assert.Equal(t, 7.1, *cagr.Values[0].Value)
+	assert.Equal(t, "2024-2030", cagr.Values[0].Period)
+	assert.Nil(t, cagr.Values[1].Value)
+
+	// The unlinked Medtronic is matched by name to the linked one
+	require.Len(t, cmp.KeyPlayers.Overlapping, 1)
+	medtronic := cmp.KeyPlayers.Overlapping[0]
+	assert.Equal(t, uint(3), *medtronic.CompanyID)
+	assert.Equal(t, 2, medtronic.Reports)
+	assert.Equal(t, 1, medtronic.Players[0].Rank)
+	assert.Equal(t, "12%", medtronic.Players[1].MarketShare)
+
+	require.Len(t, cmp.KeyPlayers.Unique, 2)
+	assert.Equal(t, "iRhythm Technologies", cmp.KeyPlayers.Unique[0].Name)
+	assert.Nil(t, cmp.KeyPlayers.Unique[0].Players[1])
+	assert.Equal(t, "Philips", cmp.KeyPlayers.Unique[1].Name)
+
+	require.Len(t, cmp.Geography, 3)
+	assert.Equal(t, CoverageRow{
+		Geography: "North America", Level: geography.LevelRegion,
+		Coverage: []string{CoverageListed, CoverageNone},
+	}, cmp.Geography[0])
+	assert.Equal(t, []string{CoverageListed, CoverageNone}, cmp.Geography[1].Coverage)
+	// The first report covers the United States through North America
+	assert.Equal(t, CoverageRow{
+		Geography: "United States", Level: geography.LevelCountry,
+		Coverage: []string{CoverageWithin, CoverageListed}, Shared: true,
+	}, cmp.Geography[2])
+}
+
+func TestCompare_WithoutTaxonomy(t *testing.T) {
+	cmp := Compare([]Report{
+		{ID: 1, Geography: StringSlice{"North America"}},
+		{ID: 2, Geography: StringSlice{"United States", "North America"}},
+	}, nil)
+
+	require.Len(t, cmp.Geography, 2)
+	assert.True(t, cmp.Geography[0].Shared)
+	assert.Empty(t, cmp.Geography[1].Level)
+	assert.Equal(t, []string{CoverageNone, CoverageListed}, cmp.Geography[1].Coverage)
+	assert.Empty(t, cmp.KeyPlayers.Overlapping)
+	assert.Nil(t, cmp.Metrics[0].Values[0].Value)
+}
@@ -0,0 +1,54 @@
+package report
+
+// PriceBand is a named price range used for filtering and facet counts.
+// Min is inclusive and Max is exclusive; a nil bound is open-ended.
+type PriceBand struct {
+	Key   string
+	Label string
+	Min   *float64
+	Max   *float64
+}
+
+func priceBound(v float64) *float64 {
+	return &v
+}
+
+// PriceBands lists the available price ranges in display order
+var PriceBands = []PriceBand{
+	{Key: "under-2000", Label: "Under $2,000", Max: priceBound(2000)},
+	{Key: "2000-3999", Label: "$2,000 - $3,999", Min: priceBound(2000), Max: priceBound(4000)},
+	{Key: "4000-5999", Label: "$4,000 - $5,999", Min: priceBound(4000), Max: priceBound(6000)},
+	{Key: "6000-plus", Label: "$6,000 and above", Min: priceBound(6000)},
+}
+
+// FindPriceBand returns the price band with the given key
+func FindPriceBand(key string) (PriceBand, bool) {
+	for _, band := range PriceBands {
+		if band.Key == key {
+			return band, true
+		}
+	}
+	return PriceBand{}, false
+}
+
+// FacetBucket is a single filter value with the number of matching reports
+type FacetBucket struct {
+	Value string `json:"value"`
+	Label string `json:"label,omitempty"`
+	Count int64  `json:"count"`
+}
+
+// ReportFacets holds facet buckets for the report listing. Each dimension is
+// counted against every other active filter, but not its own.
+type ReportFacets struct {
+	Category     []FacetBucket `json:"category"`
+	Geography    []FacetBucket `json:"geography"`
+	PriceBand    []FacetBucket `json:"price_band"`
+	ForecastYear []FacetBucket `json:"forecast_year"`
+}
+
+// ReportListWithFacets is returned by the report listing when facets are requested
+type ReportListWithFacets struct {
+	Reports []Report     `json:"reports"`
+	Facets  ReportFacets `json:"facets"`
+}
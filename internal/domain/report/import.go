@@ -0,0 +1,435 @@
+package report
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Import file formats
+const (
+	ImportFormatCSV    = "csv"
+	ImportFormatNDJSON = "ndjson"
+)
+
+// Import row outcomes
+const (
+	ImportCreate = "create"
+	ImportUpdate = "update"
+)
+
+// MaxImportRows bounds the rows of one import file
+const MaxImportRows = 2000
+
+// ImportListSeparator separates the values of list columns in CSV files
+const ImportListSeparator = ";"
+
+// maxImportLine bounds one NDJSON line; report sections can be long
+const maxImportLine = 10 * 1024 * 1024
+
+// Kinds of CSV column
+const (
+	columnText     = "text"
+	columnNumber   = "number"
+	columnInt      = "int"
+	columnList     = "list"
+	columnIDs      = "ids"
+	columnJSON     = "json"
+	columnPlayers  = "players"
+	columnCategory = "category"
+	columnAuthors  = "authors"
+)
+
+type importColumn struct {
+	path []string // JSON keys of the report field, nested for metrics and sections
+	kind string
+}
+
+// importColumns maps CSV columns onto the JSON fields of a report. Category
+// and authors take names that are resolved to IDs on import.
+var importColumns = map[string]importColumn{
+	"title":               {[]string{"title"}, columnText},
+	"slug":                {[]string{"slug"}, columnText},
+	"description":         {[]string{"description"}, columnText},
+	"summary":             {[]string{"summary"}, columnText},
+	"category":            {nil, columnCategory},
+	"category_id":         {[]string{"category_id"}, columnInt},
+	"price":               {[]string{"price"}, columnNumber},
+	"discounted_price":    {[]string{"discounted_price"}, columnNumber},
+	"currency":            {[]string{"currency"}, columnText},
+	"page_count":          {[]string{"page_count"}, columnInt},
+	"formats":             {[]string{"formats"}, columnList},
+	"geography":           {[]string{"geography"}, columnList},
+	"tags":                {[]string{"tags"}, columnList},
+	"authors":             {nil, columnAuthors},
+	"author_ids":          {[]string{"author_ids"}, columnIDs},
+	"current_revenue":     {[]string{"market_metrics", "currentRevenue"}, columnText},
+	"current_year":        {[]string{"market_metrics", "currentYear"}, columnInt},
+	"forecast_revenue":    {[]string{"market_metrics", "forecastRevenue"}, columnText},
+	"forecast_year":       {[]string{"market_metrics", "forecastYear"}, columnInt},
+	"cagr":                {[]string{"market_metrics", "cagr"}, columnText},
+	"cagr_start_year":     {[]string{"market_metrics", "cagrStartYear"}, columnInt},
+	"cagr_end_year":       {[]string{"market_metrics", "cagrEndYear"}, columnInt},
+	"key_players":         {[]string{"key_players"}, columnPlayers},
+	"faqs":                {[]string{"faqs"}, columnJSON},
+	"table_of_contents":   {[]string{"sections", "tableOfContents"}, columnText},
+	"market_details":      {[]string{"sections", "marketDetails"}, columnText},
+	"key_players_section": {[]string{"sections", "keyPlayers"}, columnText},
+	"meta_title":          {[]string{"meta_title"}, columnText},
+	"meta_description":    {[]string{"meta_description"}, columnText},
+	"meta_keywords":       {[]string{"meta_keywords"}, columnText},
+	"internal_notes":      {[]string{"internal_notes"}, columnText},
+}
+
+// ImportRow is one report read from an import file. Fields holds what the
+// row sets in the JSON form POST /reports takes; Category and Authors are
+// names still to be resolved to IDs.
+type ImportRow struct {
+	Row      int // Line of the file the row starts on
+	Slug     string
+	Category string
+	Authors  []string
+	Fields   json.RawMessage
+	Err      error // Set when the row itself could not be read
+}
+
+// ImportRowResult is what happened to one row. Action is empty when the row
+// failed, and ReportID is empty for reports a dry run would create.
+type ImportRowResult struct {
+	Row      int    `json:"row"`
+	Slug     string `json:"slug"`
+	Action   string `json:"action,omitempty"`
+	ReportID uint   `json:"report_id,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// ImportResult describes a report import. With DryRun nothing is written and
+// the counts say what a real run would do.
+type ImportResult struct {
+	DryRun  bool              `json:"dry_run"`
+	Created int               `json:"created"`
+	Updated int               `json:"updated"`
+	Failed  int               `json:"failed"`
+	Rows    []ImportRowResult `json:"rows"`
+}
+
+// Add records the outcome of a row
+func (r *ImportResult) Add(row ImportRowResult) {
+	switch {
+	case row.Error != "":
+		r.Failed++
+	case row.Action == ImportCreate:
+		r.Created++
+	case row.Action == ImportUpdate:
+		r.Updated++
+	}
+	r.Rows = append(r.Rows, row)
+}
+
+// WriteErrorsCSV writes the failed rows with their row number, slug and error
+func (r *ImportResult) WriteErrorsCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	if err := out.Write([]string{"row", "slug", "error"}); err != nil {
+		return err
+	}
+	for _, row := range r.Rows {
+		if row.Error == "" {
+			continue
+		}
+		if err := out.Write([]string{strconv.Itoa(row.Row), row.Slug, row.Error}); err != nil {
+			return err
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+// ImportFormatFor returns the import format a file name's extension implies,
+// or "" when it implies none
+func ImportFormatFor(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return ImportFormatCSV
+	case ".ndjson", ".jsonl":
+		return ImportFormatNDJSON
+	default:
+		return ""
+	}
+}
+
+// ParseImport reads the rows of a CSV or NDJSON file. An unreadable file is
+// an error; a row that can't be read carries its own error so the rest of
+// the file can still be checked.
+//
+// CSV files start with a header of known columns, slug among them. List
+// columns (formats, geography, tags, authors, author_ids) separate values
+// with ImportListSeparator, key_players takes names the same way or a JSON
+// array, and faqs takes a JSON array. Blank cells set nothing.
+//
+// NDJSON files hold one report per line as POST /reports takes it, plus
+// optional "category" and "authors" names.
+func ParseImport(r io.Reader, format string) ([]ImportRow, error) {
+	var rows []ImportRow
+	var err error
+	switch format {
+	case ImportFormatCSV:
+		rows, err = parseImportCSV(r)
+	case ImportFormatNDJSON:
+		rows, err = parseImportNDJSON(r)
+	default:
+		return nil, fmt.Errorf("unsupported format %q: use %s or %s", format, ImportFormatCSV, ImportFormatNDJSON)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("the file has no rows")
+	}
+	return rows, nil
+}
+
+func parseImportCSV(r io.Reader) ([]ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := importColumns[name]; !ok {
+			return nil, fmt.Errorf("unknown column %q", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("column %q appears twice", name)
+		}
+		seen[name] = true
+		header[i] = name
+	}
+	if !seen["slug"] {
+		return nil, errors.New("a slug column is required")
+	}
+
+	var rows []ImportRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if blankRecord(record) {
+			continue
+		}
+		if len(rows) == MaxImportRows {
+			return nil, fmt.Errorf("the file has more than %d rows", MaxImportRows)
+		}
+
+		line, _ := reader.FieldPos(0)
+		row := ImportRow{Row: line}
+		if len(record) != len(header) {
+			row.Err = fmt.Errorf("expected %d cells, found %d", len(header), len(record))
+		} else {
+			readCSVRow(&row, header, record)
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func blankRecord(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// readCSVRow fills row from the cells of one CSV record
+func readCSVRow(row *ImportRow, header, record []string) {
+	fields := map[string]interface{}{}
+	for i, name := range header {
+		cell := strings.TrimSpace(record[i])
+		if cell == "" {
+			continue
+		}
+		column := importColumns[name]
+
+		switch column.kind {
+		case columnCategory:
+			row.Category = cell
+			continue
+		case columnAuthors:
+			row.Authors = splitImportList(cell)
+			continue
+		}
+
+		value, err := column.parse(cell)
+		if err != nil {
+			row.Err = fmt.Errorf("%s: %v", name, err)
+			return
+		}
+		setImportField(fields, column.path, value)
+	}
+
+	row.Slug, _ = fields["slug"].(string)
+	row.Fields, _ = json.Marshal(fields)
+}
+
+func (c importColumn) parse(cell string) (interface{}, error) {
+	switch c.kind {
+	case columnNumber:
+		n, err := strconv.ParseFloat(cell, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", cell)
+		}
+		return n, nil
+	case columnInt:
+		n, err := strconv.Atoi(cell)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a whole number", cell)
+		}
+		return n, nil
+	case columnList:
+		return splitImportList(cell), nil
+	case columnIDs:
+		var ids []uint64
+		for _, value := range splitImportList(cell) {
+			id, err := strconv.ParseUint(value, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not an ID", value)
+			}
+			ids = append(ids, id)
+		}
+		return ids, nil
+	case columnJSON:
+		if !json.Valid([]byte(cell)) {
+			return nil, errors.New("not valid JSON")
+		}
+		return json.RawMessage(cell), nil
+	case columnPlayers:
+		if strings.HasPrefix(cell, "[") {
+			if !json.Valid([]byte(cell)) {
+				return nil, errors.New("not valid JSON")
+			}
+			return json.RawMessage(cell), nil
+		}
+		var players []KeyPlayer
+		for _, name := range splitImportList(cell) {
+			players = append(players, KeyPlayer{Name: name})
+		}
+		return players, nil
+	default:
+		return cell, nil
+	}
+}
+
+// splitImportList splits a list cell, dropping blank values
+func splitImportList(cell string) []string {
+	values := []string{}
+	for _, value := range strings.Split(cell, ImportListSeparator) {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func setImportField(fields map[string]interface{}, path []string, value interface{}) {
+	for _, key := range path[:len(path)-1] {
+		nested, ok := fields[key].(map[string]interface{})
+		if !ok {
+			nested = map[string]interface{}{}
+			fields[key] = nested
+		}
+		fields = nested
+	}
+	fields[path[len(path)-1]] = value
+}
+
+func parseImportNDJSON(r io.Reader) ([]ImportRow, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxImportLine)
+
+	var rows []ImportRow
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if line == 1 {
+			text = bytes.TrimPrefix(text, []byte("\ufeff"))
+		}
+		if len(text) == 0 {
+			continue
+		}
+		if len(rows) == MaxImportRows {
+			return nil, fmt.Errorf("the file has more than %d rows", MaxImportRows)
+		}
+
+		row := ImportRow{Row: line}
+		var names struct {
+			Slug     string   `json:"slug"`
+			Category string   `json:"category"`
+			Authors  []string `json:"authors"`
+		}
+		if text[0] != '{' {
+			row.Err = errors.New("each line must be a JSON object")
+		} else if err := json.Unmarshal(text, &names); err != nil {
+			row.Err = err
+		} else {
+			row.Slug = strings.TrimSpace(names.Slug)
+			row.Category = strings.TrimSpace(names.Category)
+			row.Authors = names.Authors
+			// The scanner reuses its buffer
+			row.Fields = append(json.RawMessage{}, text...)
+		}
+		rows = append(rows, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// Apply sets the fields of the row on rep, which is either a new report or
+// the stored one with the row's slug. The ID, status, version, workflow and
+// tracking fields of rep are kept whatever the row holds.
+func (row *ImportRow) Apply(rep *Report) error {
+	kept := *rep
+	if err := json.Unmarshal(row.Fields, rep); err != nil {
+		return err
+	}
+
+	rep.ID = kept.ID
+	rep.Status = kept.Status
+	rep.Version = kept.Version
+	rep.MetricsUnparsed = kept.MetricsUnparsed
+	rep.CreatedBy = kept.CreatedBy
+	rep.UpdatedBy = kept.UpdatedBy
+	rep.ReviewerID = kept.ReviewerID
+	rep.SubmittedBy = kept.SubmittedBy
+	rep.SubmittedAt = kept.SubmittedAt
+	rep.ReviewedBy = kept.ReviewedBy
+	rep.ReviewedAt = kept.ReviewedAt
+	rep.RejectionReason = kept.RejectionReason
+	rep.CreatedAt = kept.CreatedAt
+	rep.UpdatedAt = kept.UpdatedAt
+	rep.DeletedAt = kept.DeletedAt
+	return nil
+}
@@ -0,0 +1,127 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseImportCSV(t *testing.T) {
+	file := "\ufeffSlug,Title,Category,Authors,Geography,Price,Current_Revenue,CAGR,Key_Players,Table_Of_Contents\n" +
+		"cardiac-monitoring-market,Cardiac Monitoring Market,Cardiology,Jane Doe; Ravi Patel,North America;Europe,3999,USD 4.2 Billion,6.9%,Medtronic; Philips,\"1. Introduction\n2. Market Overview\"\n" +
+		",,,,,,,,,\n" +
+		"dental-implants-market,Dental Implants Market,,,Global,cheap,,,,\n" +
+		"short-row,Short\n"
+
+	rows, err := ParseImport(strings.NewReader(file), ImportFormatCSV)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	first := rows[0]
+	assert.Equal(t, 2, first.Row)
+	assert.NoError(t, first.Err)
+	assert.Equal(t, "cardiac-monitoring-market", first.Slug)
+	assert.Equal(t, "Cardiology", first.Category)
+	assert.Equal(t, []string{"Jane Doe", "Ravi Patel"}, first.Authors)
+
+	var rep Report
+	require.NoError(t, first.Apply(&rep))
+	assert.Equal(t, "Cardiac Monitoring Market", rep.Title)
+	assert.Equal(t, StringSlice{"North America", "Europe"}, rep.Geography)
+	assert.Equal(t, 3999.0, rep.Price)
+	require.NotNil(t, rep.MarketMetrics)
+	assert.Equal(t, "USD 4.2 Billion", rep.MarketMetrics.CurrentRevenue)
+	assert.Equal(t, "6.9%", rep.MarketMetrics.CAGR)
+	assert.Equal(t, KeyPlayers{{Name: "Medtronic"}, {Name: "Philips"}}, rep.KeyPlayers)
+	assert.Equal(t, "1. Introduction\n2. Market Overview", rep.Sections.TableOfContents)
+
+	// The blank line is skipped; rows keep the line they start on
+	assert.Equal(t, 5, rows[1].Row)
+	assert.EqualError(t, rows[1].Err, `price: "cheap" is not a number`)
+
+	assert.Equal(t, 6, rows[2].Row)
+	assert.EqualError(t, rows[2].Err, "expected 10 cells, found 2")
+}
+
+func TestParseImportCSV_Header(t *testing.T) {
+	_, err := ParseImport(strings.NewReader("slug,title,colour\n"), ImportFormatCSV)
+	assert.EqualError(t, err, `unknown column "colour"`)
+
+	_, err = ParseImport(strings.NewReader("title,summary\nA,B\n"), ImportFormatCSV)
+	assert.EqualError(t, err, "a slug column is required")
+
+	_, err = ParseImport(strings.NewReader("slug,title\n"), ImportFormatCSV)
+	assert.EqualError(t, err, "the file has no rows")
+
+	_, err = ParseImport(strings.NewReader("slug\na\n"), "xlsx")
+	assert.Error(t, err)
+}
+
+func TestParseImportNDJSON(t *testing.T) {
+	file := `{"slug":"cardiac-monitoring-market","title":"Cardiac Monitoring Market","category":"cardiology","authors":["Jane Doe"],"faqs":[{"question":"Q","answer":"A"}]}
+
+["not", "an", "object"]
+{"slug":"dental-implants-market","price":"cheap"}
+`
+	rows, err := ParseImport(strings.NewReader(file), ImportFormatNDJSON)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	assert.Equal(t, 1, rows[0].Row)
+	assert.Equal(t, "cardiac-monitoring-market", rows[0].Slug)
+	assert.Equal(t, "cardiology", rows[0].Category)
+	assert.Equal(t, []string{"Jane Doe"}, rows[0].Authors)
+
+	assert.Equal(t, 3, rows[1].Row)
+	assert.Error(t, rows[1].Err)
+
+	// Field types are checked when the row is applied
+	assert.NoError(t, rows[2].Err)
+	assert.Error(t, rows[2].Apply(&Report{}))
+}
+
+func TestImportRowApply_KeepsStoredState(t *testing.T) {
+	editor := uint(7)
+	stored := &Report{
+		ID:          12,
+		Slug:        "cardiac-monitoring-market",
+		Title:       "Cardiac Monitoring Market",
+		Summary:     "Stored summary",
+		Status:      StatusPublished,
+		Version:     4,
+		CreatedBy:   &editor,
+		Geography:   StringSlice{"Global"},
+		Sections:    ReportSections{MarketDetails: "Stored details"},
+		SubmittedBy: &editor,
+	}
+
+	row := ImportRow{Fields: []byte(`{"slug":"cardiac-monitoring-market","title":"Cardiac Monitoring Market 2025","status":"draft","version":1,"id":99,"sections":{"tableOfContents":"1. Introduction"}}`)}
+	require.NoError(t, row.Apply(stored))
+
+	assert.Equal(t, "Cardiac Monitoring Market 2025", stored.Title)
+	assert.Equal(t, "Stored summary", stored.Summary)
+	assert.Equal(t, "Stored details", stored.Sections.MarketDetails)
+	assert.Equal(t, "1. Introduction", stored.Sections.TableOfContents)
+	assert.Equal(t, uint(12), stored.ID)
+	assert.Equal(t, StatusPublished, stored.Status)
+	assert.Equal(t, 4, stored.Version)
+	assert.Equal(t, &editor, stored.SubmittedBy)
+}
+
+func TestImportResult(t *testing.T) {
+	result := &ImportResult{}
+	result.Add(ImportRowResult{Row: 2, Slug: "a", Action: ImportCreate})
+	result.Add(ImportRowResult{Row: 3, Slug: "b", Action: ImportUpdate, ReportID: 12})
+	result.Add(ImportRowResult{Row: 4, Slug: "c, d", Error: "Summary is required (minimum 50 characters)"})
+
+	assert.Equal(t, 1, result.Created)
+	assert.Equal(t, 1, result.Updated)
+	assert.Equal(t, 1, result.Failed)
+
+	var buf bytes.Buffer
+	require.NoError(t, result.WriteErrorsCSV(&buf))
+	assert.Equal(t, "row,slug,error\n4,\"c, d\",Summary is required (minimum 50 characters)\n", buf.String())
+}
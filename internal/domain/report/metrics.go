@@ -0,0 +1,284 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Market value scales
+const (
+	ScaleUnits    = ""
+	ScaleThousand = "thousand"
+	ScaleMillion  = "million"
+	ScaleBillion  = "billion"
+	ScaleTrillion = "trillion"
+)
+
+// scaleMillions is the number of millions in one unit of each scale
+var scaleMillions = map[string]float64{
+	ScaleUnits:    0.000001,
+	ScaleThousand: 0.001,
+	ScaleMillion:  1,
+	ScaleBillion:  1000,
+	ScaleTrillion: 1000000,
+}
+
+var scaleAliases = map[string]string{
+	"K": ScaleThousand, "THOUSAND": ScaleThousand, "THOUSANDS": ScaleThousand,
+	"M": ScaleMillion, "MN": ScaleMillion, "MILLION": ScaleMillion, "MILLIONS": ScaleMillion,
+	"B": ScaleBillion, "BN": ScaleBillion, "BILLION": ScaleBillion, "BILLIONS": ScaleBillion,
+	"T": ScaleTrillion, "TN": ScaleTrillion, "TRILLION": ScaleTrillion, "TRILLIONS": ScaleTrillion,
+}
+
+// currencyAliases maps the codes and symbols used in market size strings to
+// ISO 4217 codes
+var currencyAliases = map[string]string{
+	"USD": "USD", "US$": "USD", "$": "USD",
+	"EUR": "EUR", "€": "EUR",
+	"GBP": "GBP", "£": "GBP",
+	"JPY": "JPY", "¥": "JPY",
+	"CNY": "CNY", "RMB": "CNY",
+	"INR": "INR", "₹": "INR",
+	"CAD": "CAD", "C$": "CAD",
+	"AUD": "AUD", "A$": "AUD",
+	"CHF": "CHF",
+}
+
+// USDRates are fixed reference rates used to rank and filter market sizes
+// across currencies. They are not exchange quotes; migrations/037 holds the
+// same table for the backfill.
+var USDRates = map[string]float64{
+	"USD": 1,
+	"EUR": 1.08,
+	"GBP": 1.27,
+	"JPY": 0.0067,
+	"CNY": 0.14,
+	"INR": 0.012,
+	"CAD": 0.73,
+	"AUD": 0.66,
+	"CHF": 1.13,
+}
+
+// CAGRTolerance is how far, in percentage points, a stated CAGR may be from
+// the rate implied by the current and forecast values
+const CAGRTolerance = 0.5
+
+// marketValuePattern matches "USD 4.2 Billion", "$4.2B", "4,200 million EUR"
+// and the like once upper-cased
+var marketValuePattern = regexp.MustCompile(`^(US\$|USD|\$|EUR|€|GBP|£|JPY|¥|CNY|RMB|INR|₹|CAD|C\$|AUD|A\$|CHF)?\s*([0-9][0-9,]*(?:\.[0-9]+)?)\s*(THOUSANDS?|MILLIONS?|BILLIONS?|TRILLIONS?|MN|BN|TN|K|M|B|T)?\s*(USD|EUR|GBP|JPY|CNY|RMB|INR|CAD|AUD|CHF)?$`)
+
+var cagrPattern = regexp.MustCompile(`^(-?[0-9]+(?:\.[0-9]+)?)\s*%?$`)
+
+// MarketValue is a market size parsed from its display string
+// @Description Numeric market size. usdMillions is normalized with fixed reference rates for sorting and filtering.
+type MarketValue struct {
+	Amount      float64  `json:"amount" example:"4.2"`                 // Value as written, in Scale units
+	Scale       string   `json:"scale,omitempty" example:"billion"`    // thousand, million, billion, trillion or empty for units
+	Currency    string   `json:"currency" example:"USD"`               // ISO 4217 code
+	Year        int      `json:"year,omitempty" example:"2024"`        // Year the value applies to
+	USDMillions *float64 `json:"usdMillions,omitempty" example:"4200"` // Value in millions of US dollars; empty for currencies without a rate
+}
+
+// ParseMarketValue parses a market size such as "USD 4.2 Billion". A value
+// without a currency is taken to be in US dollars.
+func ParseMarketValue(s string, year int) (*MarketValue, error) {
+	m := marketValuePattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(s)))
+	if m == nil {
+		return nil, fmt.Errorf("cannot read %q as a market size", s)
+	}
+
+	currency := "USD"
+	if m[1] != "" && m[4] != "" && currencyAliases[m[1]] != currencyAliases[m[4]] {
+		return nil, fmt.Errorf("%q names two currencies", s)
+	}
+	if m[1] != "" {
+		currency = currencyAliases[m[1]]
+	} else if m[4] != "" {
+		currency = currencyAliases[m[4]]
+	}
+
+	amount, err := strconv.ParseFloat(strings.ReplaceAll(m[2], ",", ""), 64)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read %q as a market size", s)
+	}
+
+	v := &MarketValue{
+		Amount:   amount,
+		Scale:    scaleAliases[m[3]],
+		Currency: currency,
+		Year:     year,
+	}
+	v.normalize()
+	return v, nil
+}
+
+// normalize sets USDMillions from the amount, scale and currency
+func (v *MarketValue) normalize() {
+	v.USDMillions = nil
+	rate, ok := USDRates[v.Currency]
+	factor, known := scaleMillions[v.Scale]
+	if !ok || !known {
+		return
+	}
+	usd := v.Amount * factor * rate
+	v.USDMillions = &usd
+}
+
+// Millions returns the value in millions of its own currency
+func (v *MarketValue) Millions() float64 {
+	return v.Amount * scaleMillions[v.Scale]
+}
+
+// String formats the value the way market sizes are displayed, e.g. "USD 4.2 Billion"
+func (v *MarketValue) String() string {
+	s := v.Currency + " " + strconv.FormatFloat(v.Amount, 'f', -1, 64)
+	if v.Scale != "" {
+		s += " " + strings.ToUpper(v.Scale[:1]) + v.Scale[1:]
+	}
+	return s
+}
+
+// validate checks a value sent without its display string
+func (v *MarketValue) validate() error {
+	if v.Amount < 0 || math.IsNaN(v.Amount) || math.IsInf(v.Amount, 0) {
+		return errors.New("market size must not be negative")
+	}
+	if _, ok := scaleMillions[v.Scale]; !ok {
+		return fmt.Errorf("unknown scale %q", v.Scale)
+	}
+	if len(v.Currency) != 3 || strings.ToUpper(v.Currency) != v.Currency {
+		return fmt.Errorf("currency must be an ISO 4217 code, got %q", v.Currency)
+	}
+	return nil
+}
+
+// ParseCAGR parses a growth rate such as "7.5%" into percent
+func ParseCAGR(s string) (float64, error) {
+	m := cagrPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return 0, fmt.Errorf("cannot read %q as a CAGR", s)
+	}
+	return strconv.ParseFloat(m[1], 64)
+}
+
+// ImpliedCAGR returns the compound annual growth rate, in percent, that takes
+// start to end over the given number of years
+func ImpliedCAGR(start, end float64, years int) float64 {
+	return (math.Pow(end/start, 1/float64(years)) - 1) * 100
+}
+
+// Normalize fills the numeric fields from the display strings. Values and
+// rates sent without a string get one formatted from the numbers. The
+// strings win when both are given.
+func (m *MarketMetrics) Normalize() error {
+	if m == nil {
+		return nil
+	}
+
+	current, err := normalizeValue(m.CurrentRevenue, m.CurrentValue, m.CurrentYear)
+	if err != nil {
+		return fmt.Errorf("currentRevenue: %w", err)
+	}
+	m.CurrentValue = current
+	if current != nil {
+		m.CurrentRevenue = strings.TrimSpace(m.CurrentRevenue)
+		if m.CurrentRevenue == "" {
+			m.CurrentRevenue = current.String()
+		}
+	}
+
+	forecast, err := normalizeValue(m.ForecastRevenue, m.ForecastValue, m.ForecastYear)
+	if err != nil {
+		return fmt.Errorf("forecastRevenue: %w", err)
+	}
+	m.ForecastValue = forecast
+	if forecast != nil {
+		m.ForecastRevenue = strings.TrimSpace(m.ForecastRevenue)
+		if m.ForecastRevenue == "" {
+			m.ForecastRevenue = forecast.String()
+		}
+	}
+
+	if strings.TrimSpace(m.CAGR) != "" {
+		cagr, err := ParseCAGR(m.CAGR)
+		if err != nil {
+			return fmt.Errorf("cagr: %w", err)
+		}
+		m.CAGRPercent = &cagr
+	} else if m.CAGRPercent != nil {
+		m.CAGR = strconv.FormatFloat(*m.CAGRPercent, 'f', -1, 64) + "%"
+	}
+
+	return nil
+}
+
+// SameAs reports whether m states the same metrics as other: the same years
+// and strings. A value or rate given without its string is taken as changed.
+func (m *MarketMetrics) SameAs(other *MarketMetrics) bool {
+	if m == nil || other == nil {
+		return m == other
+	}
+	if m.CurrentYear != other.CurrentYear || m.ForecastYear != other.ForecastYear ||
+		m.CAGRStartYear != other.CAGRStartYear || m.CAGREndYear != other.CAGREndYear {
+		return false
+	}
+	return sameStated(m.CurrentRevenue, m.CurrentValue != nil, other.CurrentRevenue) &&
+		sameStated(m.ForecastRevenue, m.ForecastValue != nil, other.ForecastRevenue) &&
+		sameStated(m.CAGR, m.CAGRPercent != nil, other.CAGR)
+}
+
+func sameStated(display string, hasNumber bool, otherDisplay string) bool {
+	display = strings.TrimSpace(display)
+	if display == "" && hasNumber {
+		return false
+	}
+	return display == strings.TrimSpace(otherDisplay)
+}
+
+func normalizeValue(display string, value *MarketValue, year int) (*MarketValue, error) {
+	if strings.TrimSpace(display) != "" {
+		return ParseMarketValue(display, year)
+	}
+	if value == nil {
+		return nil, nil
+	}
+	if err := value.validate(); err != nil {
+		return nil, err
+	}
+	value.Year = year
+	value.normalize()
+	return value, nil
+}
+
+// ValidateCAGR checks that the stated CAGR is within CAGRTolerance of the
+// rate implied by the current and forecast values. It only applies when both
+// values are in the same currency and the CAGR covers the years they are for.
+func (m *MarketMetrics) ValidateCAGR() error {
+	if m == nil || m.CAGRPercent == nil || m.CurrentValue == nil || m.ForecastValue == nil {
+		return nil
+	}
+
+	start, end := m.CurrentYear, m.ForecastYear
+	if start == 0 || end <= start || m.CurrentValue.Currency != m.ForecastValue.Currency {
+		return nil
+	}
+	if (m.CAGRStartYear != 0 && m.CAGRStartYear != start) || (m.CAGREndYear != 0 && m.CAGREndYear != end) {
+		return nil
+	}
+
+	from, to := m.CurrentValue.Millions(), m.ForecastValue.Millions()
+	if from <= 0 || to <= 0 {
+		return nil
+	}
+
+	implied := ImpliedCAGR(from, to, end-start)
+	if math.Abs(implied-*m.CAGRPercent) > CAGRTolerance {
+		return fmt.Errorf("cagr %.2f%% does not match the %.2f%% implied by %s (%d) and %s (%d)",
+			*m.CAGRPercent, implied, m.CurrentValue, start, m.ForecastValue, end)
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMarketValue(t *testing.T) {
+	tests := []struct {
+		in          string
+		amount      float64
+		scale       string
+		currency    string
+		usdMillions float64
+	}{
+		{"USD 4.2 Billion", 4.2, ScaleBillion, "USD", 4200},
+		{"$4.2B", 4.2, ScaleBillion, "USD", 4200},
+		{"US$ 1,250 Million", 1250, ScaleMillion, "USD", 1250},
+		{"€ 300 mn", 300, ScaleMillion, "EUR", 324},
+		{"2.5 trillion JPY", 2.5, ScaleTrillion, "JPY", 16750},
+		{"850 Thousand", 850, ScaleThousand, "USD", 0.85},
+	}
+	for _, tt := range tests {
+		v, err := ParseMarketValue(tt.in, 2024)
+		require.NoError(t, err, tt.in)
+		assert.Equal(t, tt.amount, v.Amount, tt.in)
+		assert.Equal(t, tt.scale, v.Scale, tt.in)
+		assert.Equal(t, tt.currency, v.Currency, tt.in)
+		assert.Equal(t, 2024, v.Year, tt.in)
+		require.NotNil(t, v.USDMillions, tt.in)
+		assert.InDelta(t, tt.usdMillions, *v.USDMillions, 0.0001, tt.in)
+	}
+
+	for _, in := range []string{"N/A", "about 4 billion", "USD 4.2 Billion EUR", "4.2 quadrillion"} {
+		_, err := ParseMarketValue(in, 0)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestMarketValueString(t *testing.T) {
+	v := &MarketValue{Amount: 4.2, Scale: ScaleBillion, Currency: "USD"}
+	assert.Equal(t, "USD 4.2 Billion", v.String())
+}
+
+func TestParseCAGR(t *testing.T) {
+	cagr, err := ParseCAGR(" 6.9% ")
+	assert.NoError(t, err)
+	assert.Equal(t, 6.9, cagr)
+
+	cagr, err = ParseCAGR("-1.5")
+	assert.NoError(t, err)
+	assert.Equal(t, -1.5, cagr)
+
+	_, err = ParseCAGR("around 7%")
+	assert.Error(t, err)
+}
+
+func TestMarketMetricsNormalize(t *testing.T) {
+	t.Run("Parses strings", func(t *testing.T) {
+		m := &MarketMetrics{CurrentRevenue: "USD 4.2 Billion", CurrentYear: 2024, CAGR: "6.9%"}
+		require.NoError(t, m.Normalize())
+		assert.Equal(t, 4.2, m.CurrentValue.Amount)
+		assert.Equal(t, 2024, m.CurrentValue.Year)
+		assert.Equal(t, 6.9, *m.CAGRPercent)
+		assert.Nil(t, m.ForecastValue)
+	})
+
+	t.Run("Formats numbers sent without strings", func(t *testing.T) {
+		cagr := 7.25
+		m := &MarketMetrics{
+			ForecastValue: &MarketValue{Amount: 6.3, Scale: ScaleBillion, Currency: "EUR"},
+			ForecastYear:  2030,
+			CAGRPercent:   &cagr,
+		}
+		require.NoError(t, m.Normalize())
+		assert.Equal(t, "EUR 6.3 Billion", m.ForecastRevenue)
+		assert.Equal(t, "7.25%", m.CAGR)
+		assert.InDelta(t, 6804, *m.ForecastValue.USDMillions, 0.0001)
+	})
+
+	t.Run("Rejects unreadable strings", func(t *testing.T) {
+		assert.Error(t, (&MarketMetrics{ForecastRevenue: "TBD"}).Normalize())
+		assert.Error(t, (&MarketMetrics{CAGR: "high"}).Normalize())
+		assert.Error(t, (&MarketMetrics{CurrentValue: &MarketValue{Amount: 1, Currency: "usd"}}).Normalize())
+	})
+
+	t.Run("Nil metrics", func(t *testing.T) {
+		var m *MarketMetrics
+		assert.NoError(t, m.Normalize())
+		assert.NoError(t, m.ValidateCAGR())
+	})
+}
+
+func TestMarketMetricsValidateCAGR(t *testing.T) {
+	metrics := func(cagr string) *MarketMetrics {
+		m := &MarketMetrics{
+			CurrentRevenue:  "USD 4.2 Billion",
+			CurrentYear:     2024,
+			ForecastRevenue: "USD 6.3 Billion",
+			ForecastYear:    2030,
+			CAGR:            cagr,
+		}
+		require.NoError(t, m.Normalize())
+		return m
+	}
+
+	// 4.2 -> 6.3 over six years is 6.99% a year
+	assert.NoError(t, metrics("6.9%").ValidateCAGR())
+	assert.NoError(t, metrics("7.4%").ValidateCAGR())
+	assert.Error(t, metrics("9.5%").ValidateCAGR())
+
+	// A CAGR over a different period can't be checked against the values
+	m := metrics("9.5%")
+	m.CAGRStartYear, m.CAGREndYear = 2025, 2032
+	assert.NoError(t, m.ValidateCAGR())
+}
+
+func TestMarketMetricsSameAs(t *testing.T) {
+	stored := &MarketMetrics{CurrentRevenue: "around 4 billion", CurrentYear: 2024, CAGR: "9.5%"}
+
+	// What was sent back as it was stored is the same, numbers or not
+	sent := *stored
+	assert.True(t, sent.SameAs(stored))
+	cagr := 9.5
+	sent.CAGRPercent = &cagr
+	assert.True(t, sent.SameAs(stored))
+	sent.CAGR = " 9.5% "
+	assert.True(t, sent.SameAs(stored))
+
+	sent.CAGR = "7%"
+	assert.False(t, sent.SameAs(stored))
+
+	// A number without its string is a change
+	sent.CAGR = ""
+	assert.False(t, sent.SameAs(stored))
+
+	moved := *stored
+	moved.CurrentYear = 2025
+	assert.False(t, moved.SameAs(stored))
+
+	var none *MarketMetrics
+	assert.True(t, none.SameAs(nil))
+	assert.False(t, none.SameAs(stored))
+}
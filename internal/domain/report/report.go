@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/healthcare-market-research/backend/internal/domain/author"
+	"github.com/healthcare-market-research/backend/internal/domain/related"
 )
 
 // MarketMetrics contains market size and forecast data
@@ -18,6 +19,11 @@ type MarketMetrics struct {
 	CAGR             string `json:"cagr,omitempty"`
 	CAGRStartYear    int    `json:"cagrStartYear,omitempty"`
 	CAGREndYear      int    `json:"cagrEndYear,omitempty"`
+
+	// Numeric forms of the strings above, filled by Normalize
+	CurrentValue  *MarketValue `json:"currentValue,omitempty"`
+	ForecastValue *MarketValue `json:"forecastValue,omitempty"`
+	CAGRPercent   *float64     `json:"cagrPercent,omitempty" example:"6.9"`
 }
 
 // Value implements the driver.Valuer interface for GORM
@@ -37,8 +43,10 @@ func (m *MarketMetrics) Scan(value interface{}) error {
 	return json.Unmarshal(bytes, &m)
 }
 
-// KeyPlayer represents a market competitor
+// KeyPlayer represents a market competitor. CompanyID links it to the
+// company directory; market share and rank stay specific to the report.
 type KeyPlayer struct {
+	CompanyID   *uint  `json:"companyId,omitempty"`
 	Name        string `json:"name"`
 	MarketShare string `json:"marketShare,omitempty"`
 	Rank        int    `json:"rank,omitempty"`
@@ -63,6 +71,36 @@ func (k *KeyPlayers) Scan(value interface{}) error {
 	return json.Unmarshal(bytes, &k)
 }
 
+// ReplaceCompany points players linked to any of fromIDs at toID. A player
+// that would repeat a company already listed is dropped, keeping the
+// earlier entry. It reports whether anything changed.
+func (k KeyPlayers) ReplaceCompany(fromIDs []uint, toID uint) (KeyPlayers, bool) {
+	from := make(map[uint]bool, len(fromIDs))
+	for _, id := range fromIDs {
+		from[id] = true
+	}
+
+	replaced := make(KeyPlayers, 0, len(k))
+	listed := map[uint]bool{}
+	changed := false
+	for _, p := range k {
+		if p.CompanyID != nil && from[*p.CompanyID] {
+			id := toID
+			p.CompanyID = &id
+			changed = true
+		}
+		if p.CompanyID != nil {
+			if listed[*p.CompanyID] {
+				changed = true
+				continue
+			}
+			listed[*p.CompanyID] = true
+		}
+		replaced = append(replaced, p)
+	}
+	return replaced, changed
+}
+
 // ReportSections contains all report content sections
 type ReportSections struct {
 	KeyPlayers      string `json:"keyPlayers"`
@@ -169,8 +207,10 @@ type Report struct {
 	PageCount       int             `json:"page_count" gorm:"default:0"`
 	Formats         StringSlice     `json:"formats,omitempty" gorm:"type:jsonb"`
 	Geography       StringSlice     `json:"geography" gorm:"type:jsonb;not null"`
+	// Tags are the names of the report's tags; content_tags holds the links
+	Tags            StringSlice     `json:"tags" gorm:"type:jsonb;not null;default:'[]'"`
 
-	// Status and access
+	// Status and access (changed only through the editorial workflow, see workflow.go)
 	Status                  string     `json:"status" gorm:"type:varchar(20);default:'draft';index"`
 	IsFeatured              bool       `json:"is_featured" gorm:"default:false"`
 
@@ -184,6 +224,8 @@ type Report struct {
 	// Market data
 	MarketMetrics   *MarketMetrics  `json:"market_metrics,omitempty" gorm:"type:jsonb"`
 	KeyPlayers      KeyPlayers      `json:"key_players,omitempty" gorm:"type:jsonb"`
+	// Set when the market metrics strings could not be parsed (admin metadata)
+	MetricsUnparsed bool            `json:"metrics_unparsed,omitempty" gorm:"not null;default:false"`
 
 	// Content sections
 	Sections        ReportSections  `json:"sections" gorm:"type:jsonb;not null"`
@@ -201,35 +243,76 @@ type Report struct {
 	UpdatedBy         *uint          `json:"updated_by,omitempty" gorm:"index"`
 	InternalNotes     string         `json:"internal_notes,omitempty" gorm:"type:text"`
 
+	// Editorial review (admin metadata)
+	ReviewerID        *uint          `json:"reviewer_id,omitempty" gorm:"index"`
+	SubmittedBy       *uint          `json:"submitted_by,omitempty"`
+	SubmittedAt       *time.Time     `json:"submitted_at,omitempty"`
+	ReviewedBy        *uint          `json:"reviewed_by,omitempty" gorm:"index"`
+	ReviewedAt        *time.Time     `json:"reviewed_at,omitempty"`
+	RejectionReason   string         `json:"rejection_reason,omitempty" gorm:"type:text"`
+
+	// Optimistic locking: incremented on every write, exposed as the ETag
+	Version         int             `json:"version" gorm:"not null;default:1"`
+
 	// Timestamps
 	CreatedAt       time.Time       `json:"created_at"`
 	UpdatedAt       time.Time       `json:"updated_at"`
 	DeletedAt       *time.Time      `json:"deleted_at,omitempty" gorm:"index"`
 }
 
+// CheckRequired returns an error naming the first required field the report
+// is missing. The messages are meant to be shown to editors as they are.
+func (r *Report) CheckRequired() error {
+	switch {
+	case r.Title == "":
+		return errors.New("Title is required (minimum 10 characters)")
+	case r.Slug == "":
+		return errors.New("Slug is required")
+	case r.CategoryID == 0:
+		return errors.New("Category ID is required")
+	case r.Summary == "":
+		return errors.New("Summary is required (minimum 50 characters)")
+	case len(r.Geography) == 0:
+		return errors.New("At least one geography is required")
+	}
+	return nil
+}
+
 type ChartMetadata struct {
-	ID          uint      `json:"id" gorm:"primaryKey"`
-	ReportID    uint      `json:"report_id" gorm:"index;not null"`
-	Title       string    `json:"title" gorm:"type:varchar(500);not null"`
-	ChartType   string    `json:"chart_type" gorm:"type:varchar(50)"` // bar, line, pie, etc.
-	Description string    `json:"description" gorm:"type:text"`
-	DataPoints  int       `json:"data_points" gorm:"default:0"`
-	Order       int       `json:"order" gorm:"default:0"`
-	IsActive    bool      `json:"is_active" gorm:"default:true"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
-	Report      *Report   `json:"report,omitempty" gorm:"foreignKey:ReportID"`
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	ReportID    uint       `json:"report_id" gorm:"index;not null"`
+	Title       string     `json:"title" gorm:"type:varchar(500);not null"`
+	ChartType   string     `json:"chart_type" gorm:"type:varchar(50)"` // one of ChartTypes
+	Description string     `json:"description" gorm:"type:text"`
+	DataPoints  int        `json:"data_points" gorm:"default:0"` // values present in Data
+	Data        *ChartData `json:"data,omitempty" gorm:"type:jsonb"`
+	// Uploaded report image shown when the chart can't be drawn
+	ImageID      *uint     `json:"image_id,omitempty"`
+	ImageURL     string    `json:"image_url,omitempty" gorm:"->"`
+	ImageAltText string    `json:"image_alt_text,omitempty" gorm:"->"`
+	Order        int       `json:"order" gorm:"default:0"`
+	IsActive     bool      `json:"is_active" gorm:"default:true"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	Report       *Report   `json:"report,omitempty" gorm:"foreignKey:ReportID"`
 }
 
-// ReportVersion stores historical versions of reports
+// ReportVersion stores historical versions of reports. A version is recorded on
+// every save; PublishedBy/PublishedAt are only set when that save published the report.
 type ReportVersion struct {
 	ID              uint            `json:"id" gorm:"primaryKey"`
-	ReportID        uint            `json:"report_id" gorm:"index;not null"`
-	VersionNumber   int             `json:"version_number" gorm:"not null"`
-	PublishedBy     uint            `json:"published_by" gorm:"not null"` // User ID who published
-	PublishedAt     time.Time       `json:"published_at" gorm:"not null"`
+	ReportID        uint            `json:"report_id" gorm:"index;not null;uniqueIndex:idx_report_versions_report_version"`
+	VersionNumber   int             `json:"version_number" gorm:"not null;uniqueIndex:idx_report_versions_report_version"`
+	ChangeType      string          `json:"change_type" gorm:"type:varchar(20);default:'update'"`
+	CreatedBy       *uint           `json:"created_by,omitempty" gorm:"index"`
+	RestoredFrom    *int            `json:"restored_from,omitempty"` // Version number a rollback restored
+	PublishedBy     *uint           `json:"published_by,omitempty"` // User ID who published
+	PublishedAt     *time.Time      `json:"published_at,omitempty"`
 	Sections        ReportSections  `json:"sections" gorm:"type:jsonb;not null"`
 
+	// Full report payload at the time of the save
+	Snapshot        *ReportSnapshot `json:"snapshot,omitempty" gorm:"type:jsonb"`
+
 	// SEO Metadata (versioned)
 	MetaTitle       string          `json:"meta_title,omitempty" gorm:"type:varchar(255)"`
 	MetaDescription string          `json:"meta_description,omitempty" gorm:"type:varchar(500)"`
@@ -245,6 +328,10 @@ type ReportWithRelations struct {
 	Authors      []author.Author  `json:"authors,omitempty" gorm:"-"`
 	Charts       []ChartMetadata  `json:"charts,omitempty" gorm:"-"`
 	Versions     []ReportVersion  `json:"versions,omitempty" gorm:"-"`
+	// Schema.org Product/Dataset and FAQPage, set on public reads
+	JSONLD       interface{}      `json:"jsonLd,omitempty" gorm:"-"`
+	// Blogs, press releases and reports linked to the report, set on reads
+	Related      []related.Item   `json:"related,omitempty" gorm:"-"`
 }
 
 // UserInfo represents user information for admin responses
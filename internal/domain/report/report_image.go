@@ -1,20 +1,54 @@
 package report
 
-import "time"
+import (
+	"time"
+
+	"github.com/healthcare-market-research/backend/internal/domain/media"
+)
 
 // ReportImage represents an image associated with a report (charts, graphs, diagrams, infographics)
 // These images are for internal/admin use only and are not exposed through public report APIs
 // @Description Report image model for storing multiple images per report
 type ReportImage struct {
-	ID         uint      `json:"id" gorm:"primaryKey" example:"1"`                                                                                                                                                        // Unique identifier
-	ReportID   uint      `json:"report_id" gorm:"index:idx_report_images_report_id;index:idx_report_images_report_active,priority:1;not null;constraint:OnDelete:CASCADE" example:"123"`                                // ID of the associated report
-	ImageURL   string    `json:"image_url" gorm:"type:varchar(500);not null" example:"https://example.com/images/chart-1.png"`                                                                                           // URL to the image from external image service
-	Title      string    `json:"title,omitempty" gorm:"type:varchar(255)" example:"Market Size Forecast 2024-2030"`                                                                                                      // Descriptive title for the image
-	IsActive   bool      `json:"is_active" gorm:"default:true;index:idx_report_images_is_active;index:idx_report_images_report_active,priority:2" example:"true"`                                                       // Soft delete flag - false to hide without losing data
-	UploadedBy *uint     `json:"uploaded_by,omitempty" gorm:"index:idx_report_images_uploaded_by;constraint:OnDelete:SET NULL" example:"5"`                                                                              // ID of user who uploaded the image
-	CreatedAt  time.Time `json:"created_at" example:"2024-01-15T10:30:00Z"`                                                                                                                                               // Timestamp when image was created
-	UpdatedAt  time.Time `json:"updated_at" example:"2024-01-15T10:30:00Z"`                                                                                                                                               // Timestamp when image was last updated
+	ID          uint           `json:"id" gorm:"primaryKey" example:"1"`                                                                                                                       // Unique identifier
+	ReportID    uint           `json:"report_id" gorm:"index:idx_report_images_report_id;index:idx_report_images_report_active,priority:1;not null;constraint:OnDelete:CASCADE" example:"123"` // ID of the associated report
+	ImageURL    string         `json:"image_url" gorm:"type:varchar(500);not null" example:"https://example.com/images/chart-1.png"`                                                           // URL to the image from external image service
+	Variants    media.Variants `json:"variants,omitempty" gorm:"type:jsonb"`                                                                                                                   // Resized renditions (thumb, card, og, full) in the original format and WebP
+	Title       string         `json:"title,omitempty" gorm:"type:varchar(255)" example:"Market Size Forecast 2024-2030"`                                                                      // Descriptive title for the image
+	AltText     string         `json:"alt_text,omitempty" gorm:"type:varchar(255)" example:"Bar chart of market size from 2024 to 2030"`                                                       // Text alternative for screen readers
+	Caption     string         `json:"caption,omitempty" gorm:"type:varchar(500)" example:"Source: company filings and analyst estimates"`                                                     // Caption shown below the image
+	SortOrder   int            `json:"sort_order" gorm:"not null;default:0" example:"0"`                                                                                                       // Position within the report, lowest first
+	Width       int            `json:"width,omitempty" example:"1600"`                                                                                                                         // Width of the original in pixels
+	Height      int            `json:"height,omitempty" example:"900"`                                                                                                                         // Height of the original in pixels
+	ByteSize    int64          `json:"byte_size,omitempty" example:"184320"`                                                                                                                   // Size of the original in bytes
+	ContentHash string         `json:"content_hash,omitempty" gorm:"type:varchar(64)" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`                              // SHA-256 of the original, used to skip duplicate uploads
+	MimeType    string         `json:"mime_type,omitempty" gorm:"type:varchar(100)" example:"image/png"`                                                                                       // MIME type of the original
+	IsActive    bool           `json:"is_active" gorm:"default:true;index:idx_report_images_is_active;index:idx_report_images_report_active,priority:2" example:"true"`                        // Soft delete flag - false to hide without losing data
+	UploadedBy  *uint          `json:"uploaded_by,omitempty" gorm:"index:idx_report_images_uploaded_by;constraint:OnDelete:SET NULL" example:"5"`                                              // ID of user who uploaded the image
+	CreatedAt   time.Time      `json:"created_at" example:"2024-01-15T10:30:00Z"`                                                                                                              // Timestamp when image was created
+	UpdatedAt   time.Time      `json:"updated_at" example:"2024-01-15T10:30:00Z"`                                                                                                              // Timestamp when image was last updated
 
 	// Foreign key relationship
 	Report *Report `json:"report,omitempty" gorm:"foreignKey:ReportID;constraint:OnDelete:CASCADE"` // Associated report (optional in responses)
 }
+
+// UploadImageRequest holds the text sent along with an uploaded report image
+type UploadImageRequest struct {
+	Title   string
+	AltText string
+	Caption string
+}
+
+// UpdateImageMetadataRequest represents the request body for updating image metadata
+type UpdateImageMetadataRequest struct {
+	Title     *string `json:"title,omitempty"`
+	AltText   *string `json:"alt_text,omitempty"`
+	Caption   *string `json:"caption,omitempty"`
+	SortOrder *int    `json:"sort_order,omitempty"`
+	IsActive  *bool   `json:"is_active,omitempty"`
+}
+
+// ReorderImagesRequest lists the IDs of a report's active images in their new order
+type ReorderImagesRequest struct {
+	IDs []uint `json:"ids"`
+}
@@ -0,0 +1,31 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyPlayersReplaceCompany(t *testing.T) {
+	id := func(v uint) *uint { return &v }
+
+	players := KeyPlayers{
+		{CompanyID: id(1), Name: "Siemens Healthineers", Rank: 1},
+		{CompanyID: id(2), Name: "Siemens Healthcare", Rank: 2},
+		{CompanyID: id(3), Name: "GE HealthCare", Rank: 3},
+		{Name: "Philips", Rank: 4},
+	}
+
+	replaced, changed := players.ReplaceCompany([]uint{2}, 1)
+	assert.True(t, changed)
+	// The duplicate entry is dropped and the earlier one kept
+	assert.Len(t, replaced, 3)
+	assert.Equal(t, "Siemens Healthineers", replaced[0].Name)
+	assert.Equal(t, uint(3), *replaced[1].CompanyID)
+	assert.Nil(t, replaced[2].CompanyID)
+	// The original list is left alone
+	assert.Equal(t, uint(2), *players[1].CompanyID)
+
+	_, changed = players.ReplaceCompany([]uint{9}, 1)
+	assert.False(t, changed)
+}
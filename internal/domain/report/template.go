@@ -0,0 +1,79 @@
+package report
+
+import (
+	"strings"
+	"time"
+)
+
+// ReportTemplate is a saved starting point for new reports: a table of
+// contents skeleton, standard FAQs, default geography and pricing
+// @Description Saved starting point for new reports
+type ReportTemplate struct {
+	ID          uint   `json:"id" gorm:"primaryKey"`
+	Name        string `json:"name" gorm:"type:varchar(255);not null" example:"Medical Devices - Global"`
+	Description string `json:"description,omitempty" gorm:"type:text"`
+	// Category new reports are filed under unless they name one
+	CategoryID *uint `json:"category_id,omitempty" gorm:"index"`
+
+	TableOfContents string      `json:"table_of_contents" gorm:"type:text"`
+	FAQs            FAQs        `json:"faqs" gorm:"type:jsonb;not null;default:'[]'"`
+	Geography       StringSlice `json:"geography" gorm:"type:jsonb;not null;default:'[]'"`
+
+	Price           float64 `json:"price" gorm:"type:decimal(10,2);default:0"`
+	DiscountedPrice float64 `json:"discounted_price" gorm:"type:decimal(10,2);default:0"`
+	Currency        string  `json:"currency,omitempty" gorm:"type:varchar(3)"`
+
+	CreatedBy *uint     `json:"created_by,omitempty"`
+	UpdatedBy *uint     `json:"updated_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (ReportTemplate) TableName() string {
+	return "report_templates"
+}
+
+// ReportTemplateRequest is the body for creating or replacing a template
+type ReportTemplateRequest struct {
+	Name            string      `json:"name" example:"Medical Devices - Global"`
+	Description     string      `json:"description,omitempty"`
+	CategoryID      *uint       `json:"category_id,omitempty"`
+	TableOfContents string      `json:"table_of_contents"`
+	FAQs            FAQs        `json:"faqs"`
+	Geography       StringSlice `json:"geography"`
+	Price           float64     `json:"price"`
+	DiscountedPrice float64     `json:"discounted_price"`
+	Currency        string      `json:"currency,omitempty" example:"USD"`
+}
+
+// CreateReportRequest is a new report, optionally started from a template
+type CreateReportRequest struct {
+	Report
+	TemplateID *uint `json:"templateId,omitempty" example:"3"`
+}
+
+// Apply fills what a new report leaves empty from the template. Anything the
+// report sets itself is kept; pricing is taken as a whole, so a report
+// with its own price keeps its own discount and currency too.
+func (t *ReportTemplate) Apply(rep *Report) {
+	if rep.CategoryID == 0 && t.CategoryID != nil {
+		rep.CategoryID = *t.CategoryID
+	}
+	if strings.TrimSpace(rep.Sections.TableOfContents) == "" {
+		rep.Sections.TableOfContents = t.TableOfContents
+	}
+	if len(rep.FAQs) == 0 && len(t.FAQs) > 0 {
+		rep.FAQs = append(FAQs{}, t.FAQs...)
+	}
+	if len(rep.Geography) == 0 && len(t.Geography) > 0 {
+		rep.Geography = append(StringSlice{}, t.Geography...)
+	}
+	if rep.Price == 0 && rep.DiscountedPrice == 0 {
+		rep.Price = t.Price
+		rep.DiscountedPrice = t.DiscountedPrice
+		if t.Currency != "" {
+			rep.Currency = t.Currency
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportTemplateApply(t *testing.T) {
+	category := uint(4)
+	tmpl := &ReportTemplate{
+		CategoryID:      &category,
+		TableOfContents: "1. Introduction\n2. Market Overview",
+		FAQs:            FAQs{{Question: "What is the market size?", Answer: "See chapter 2."}},
+		Geography:       StringSlice{"Global"},
+		Price:           3999,
+		DiscountedPrice: 2999,
+		Currency:        "USD",
+	}
+
+	t.Run("Fills what the report leaves empty", func(t *testing.T) {
+		rep := &Report{Title: "Cardiac Monitoring Market"}
+		tmpl.Apply(rep)
+
+		assert.Equal(t, uint(4), rep.CategoryID)
+		assert.Equal(t, tmpl.TableOfContents, rep.Sections.TableOfContents)
+		assert.Equal(t, tmpl.FAQs, rep.FAQs)
+		assert.Equal(t, StringSlice{"Global"}, rep.Geography)
+		assert.Equal(t, 3999.0, rep.Price)
+		assert.Equal(t, 2999.0, rep.DiscountedPrice)
+
+		// The report gets its own copies
+		rep.Geography[0] = "Europe"
+		assert.Equal(t, StringSlice{"Global"}, tmpl.Geography)
+	})
+
+	t.Run("Keeps what the report sets", func(t *testing.T) {
+		rep := &Report{
+			CategoryID: 9,
+			Sections:   ReportSections{TableOfContents: "1. Scope"},
+			Geography:  StringSlice{"Europe"},
+			Price:      4999,
+			Currency:   "EUR",
+		}
+		tmpl.Apply(rep)
+
+		assert.Equal(t, uint(9), rep.CategoryID)
+		assert.Equal(t, "1. Scope", rep.Sections.TableOfContents)
+		assert.Equal(t, StringSlice{"Europe"}, rep.Geography)
+		assert.Equal(t, 4999.0, rep.Price)
+		assert.Zero(t, rep.DiscountedPrice)
+		assert.Equal(t, "EUR", rep.Currency)
+		assert.Len(t, rep.FAQs, 1)
+	})
+}
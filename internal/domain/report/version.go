@@ -0,0 +1,197 @@
+package report
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"sort"
+)
+
+// Version change types
+const (
+	VersionChangeCreate   = "create"
+	VersionChangeUpdate   = "update"
+	VersionChangePublish  = "publish"
+	VersionChangeRollback = "rollback"
+)
+
+// ReportSnapshot is the versioned report payload. Workflow fields (status,
+// publish date, scheduling) and admin metadata are not part of it, so a
+// rollback restores content without changing the report's publishing state.
+// Tags are nil in snapshots recorded before tags were versioned.
+type ReportSnapshot struct {
+	Title           string         `json:"title"`
+	Slug            string         `json:"slug"`
+	Description     string         `json:"description"`
+	Summary         string         `json:"summary"`
+	CategoryID      uint           `json:"category_id"`
+	Price           float64        `json:"price"`
+	DiscountedPrice float64        `json:"discounted_price"`
+	Currency        string         `json:"currency"`
+	PageCount       int            `json:"page_count"`
+	Formats         StringSlice    `json:"formats"`
+	Geography       StringSlice    `json:"geography"`
+	Tags            StringSlice    `json:"tags"`
+	IsFeatured      bool           `json:"is_featured"`
+	AuthorIDs       UintSlice      `json:"author_ids"`
+	MarketMetrics   *MarketMetrics `json:"market_metrics"`
+	KeyPlayers      KeyPlayers     `json:"key_players"`
+	Sections        ReportSections `json:"sections"`
+	FAQs            FAQs           `json:"faqs"`
+	MetaTitle       string         `json:"meta_title"`
+	MetaDescription string         `json:"meta_description"`
+	MetaKeywords    string         `json:"meta_keywords"`
+}
+
+func (s ReportSnapshot) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+func (s *ReportSnapshot) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	data, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(data, &s)
+}
+
+// NewSnapshot captures the versioned fields of a report
+func NewSnapshot(r *Report) *ReportSnapshot {
+	return &ReportSnapshot{
+		Title:           r.Title,
+		Slug:            r.Slug,
+		Description:     r.Description,
+		Summary:         r.Summary,
+		CategoryID:      r.CategoryID,
+		Price:           r.Price,
+		DiscountedPrice: r.DiscountedPrice,
+		Currency:        r.Currency,
+		PageCount:       r.PageCount,
+		Formats:         r.Formats,
+		Geography:       r.Geography,
+		Tags:            append(StringSlice{}, r.Tags...),
+		IsFeatured:      r.IsFeatured,
+		AuthorIDs:       r.AuthorIDs,
+		MarketMetrics:   r.MarketMetrics,
+		KeyPlayers:      r.KeyPlayers,
+		Sections:        r.Sections,
+		FAQs:            r.FAQs,
+		MetaTitle:       r.MetaTitle,
+		MetaDescription: r.MetaDescription,
+		MetaKeywords:    r.MetaKeywords,
+	}
+}
+
+// ApplyTo copies the snapshot onto a report. The slug is left untouched so a
+// rollback never breaks existing URLs.
+func (s *ReportSnapshot) ApplyTo(r *Report) {
+	r.Title = s.Title
+	r.Description = s.Description
+	r.Summary = s.Summary
+	r.CategoryID = s.CategoryID
+	r.Price = s.Price
+	r.DiscountedPrice = s.DiscountedPrice
+	r.Currency = s.Currency
+	r.PageCount = s.PageCount
+	r.Formats = s.Formats
+	r.Geography = s.Geography
+	r.Tags = s.Tags
+	r.IsFeatured = s.IsFeatured
+	r.AuthorIDs = s.AuthorIDs
+	r.MarketMetrics = s.MarketMetrics
+	r.KeyPlayers = s.KeyPlayers
+	r.Sections = s.Sections
+	r.FAQs = s.FAQs
+	r.MetaTitle = s.MetaTitle
+	r.MetaDescription = s.MetaDescription
+	r.MetaKeywords = s.MetaKeywords
+}
+
+// Payload returns the version's snapshot. Versions recorded before snapshots
+// existed only carry sections and SEO metadata, so only those are returned.
+func (v *ReportVersion) Payload() *ReportSnapshot {
+	if v.Snapshot != nil {
+		return v.Snapshot
+	}
+	return &ReportSnapshot{
+		Sections:        v.Sections,
+		MetaTitle:       v.MetaTitle,
+		MetaDescription: v.MetaDescription,
+		MetaKeywords:    v.MetaKeywords,
+	}
+}
+
+// FieldDiff is a single changed field between two versions
+type FieldDiff struct {
+	Field string          `json:"field"`
+	Old   json.RawMessage `json:"old"`
+	New   json.RawMessage `json:"new"`
+}
+
+// VersionDiff lists the fields that differ between two versions
+type VersionDiff struct {
+	ReportID    uint        `json:"report_id"`
+	FromVersion int         `json:"from_version"`
+	ToVersion   int         `json:"to_version"`
+	Changes     []FieldDiff `json:"changes"`
+}
+
+// DiffSnapshots compares two snapshots field by field, using the JSON field
+// names. Nested values (sections, metrics, key players) are compared as a whole.
+func DiffSnapshots(from, to *ReportSnapshot) ([]FieldDiff, error) {
+	fromFields, err := snapshotFields(from)
+	if err != nil {
+		return nil, err
+	}
+	toFields, err := snapshotFields(to)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(toFields))
+	for name := range toFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	changes := []FieldDiff{}
+	for _, name := range names {
+		oldValue, newValue := fromFields[name], toFields[name]
+		if bytes.Equal(oldValue, newValue) {
+			continue
+		}
+		changes = append(changes, FieldDiff{Field: name, Old: oldValue, New: newValue})
+	}
+
+	return changes, nil
+}
+
+func snapshotFields(s *ReportSnapshot) (map[string]json.RawMessage, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	// An empty list and a missing list are the same content
+	for name, value := range fields {
+		if bytes.Equal(value, []byte("[]")) {
+			fields[name] = json.RawMessage("null")
+		}
+	}
+	return fields, nil
+}
+
+// RollbackResult is returned after a report is restored from an earlier version
+type RollbackResult struct {
+	Report  *Report        `json:"report"`
+	Version *ReportVersion `json:"version"`
+	Changes []FieldDiff    `json:"changes"`
+}
@@ -0,0 +1,75 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	from := &ReportSnapshot{
+		Title:      "Oncology Market",
+		Price:      2999,
+		AuthorIDs:  UintSlice{1},
+		KeyPlayers: KeyPlayers{{Name: "Acme", Rank: 1}},
+		Formats:    StringSlice{},
+	}
+	to := &ReportSnapshot{
+		Title:      "Oncology Market",
+		Price:      3999,
+		AuthorIDs:  UintSlice{1, 2},
+		KeyPlayers: KeyPlayers{{Name: "Acme", Rank: 1}},
+	}
+
+	changes, err := DiffSnapshots(from, to)
+	assert.NoError(t, err)
+
+	fields := make([]string, 0, len(changes))
+	for _, c := range changes {
+		fields = append(fields, c.Field)
+	}
+	// Fields are sorted; an empty list and a missing list are not a change
+	assert.Equal(t, []string{"author_ids", "price"}, fields)
+	assert.JSONEq(t, "2999", string(changes[1].Old))
+	assert.JSONEq(t, "3999", string(changes[1].New))
+}
+
+func TestSnapshotTags(t *testing.T) {
+	rep := &Report{Title: "Oncology Market", Tags: StringSlice{"Oncology"}}
+	from := NewSnapshot(rep)
+	rep.Tags = append(rep.Tags, "Biologics")
+
+	changes, err := DiffSnapshots(from, NewSnapshot(rep))
+	assert.NoError(t, err)
+	if assert.Len(t, changes, 1) {
+		assert.Equal(t, "tags", changes[0].Field)
+		assert.JSONEq(t, `["Oncology"]`, string(changes[0].Old))
+		assert.JSONEq(t, `["Oncology", "Biologics"]`, string(changes[0].New))
+	}
+
+	// The snapshot keeps its own copy of the tags
+	rep.Tags[0] = "Renamed"
+	from.ApplyTo(rep)
+	assert.Equal(t, StringSlice{"Oncology"}, rep.Tags)
+}
+
+func TestSnapshotApplyToKeepsSlug(t *testing.T) {
+	rep := &Report{ID: 5, Slug: "oncology-market-2030", Title: "New title", Status: "published"}
+	snapshot := &ReportSnapshot{Slug: "oncology-market", Title: "Old title", FAQs: FAQs{{Question: "Q", Answer: "A"}}}
+
+	snapshot.ApplyTo(rep)
+
+	assert.Equal(t, "Old title", rep.Title)
+	assert.Equal(t, "oncology-market-2030", rep.Slug)
+	assert.Equal(t, "published", rep.Status)
+	assert.Len(t, rep.FAQs, 1)
+}
+
+func TestVersionPayloadFallsBackForLegacyVersions(t *testing.T) {
+	v := &ReportVersion{MetaTitle: "Legacy", Sections: ReportSections{MarketDetails: "details"}}
+
+	payload := v.Payload()
+
+	assert.Equal(t, "Legacy", payload.MetaTitle)
+	assert.Equal(t, "details", payload.Sections.MarketDetails)
+}
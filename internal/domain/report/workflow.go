@@ -0,0 +1,155 @@
+package report
+
+import (
+	"time"
+
+	"github.com/healthcare-market-research/backend/internal/domain/user"
+)
+
+// Report status constants
+const (
+	StatusDraft     = "draft"
+	StatusReview    = "review"
+	StatusApproved  = "approved"
+	StatusPublished = "published"
+	StatusArchived  = "archived"
+)
+
+// Workflow actions
+const (
+	WorkflowSubmit  = "submit"
+	WorkflowApprove = "approve"
+	WorkflowReject  = "reject"
+	WorkflowPublish = "publish"
+	WorkflowArchive = "archive"
+	WorkflowReopen  = "reopen"
+)
+
+// Transition describes one edge of the editorial state machine
+type Transition struct {
+	Action string
+	From   []string
+	To     string
+	// Roles that may always perform the transition
+	Roles []string
+	// ReviewerAllowed lets the assigned reviewer perform the transition regardless of role
+	ReviewerAllowed bool
+	// NoteRequired means a comment (e.g. the rejection reason) must be given
+	NoteRequired bool
+	// NotBySubmitter bars the user who submitted the report for review
+	NotBySubmitter bool
+}
+
+// Transitions is the report editorial workflow:
+// draft → review → approved → published → archived. A rejected review goes
+// back to draft, and approved or archived reports can be reopened as drafts.
+var Transitions = map[string]Transition{
+	WorkflowSubmit: {
+		Action: WorkflowSubmit,
+		From:   []string{StatusDraft},
+		To:     StatusReview,
+		Roles:  []string{user.RoleAdmin, user.RoleEditor},
+	},
+	WorkflowApprove: {
+		Action:          WorkflowApprove,
+		From:            []string{StatusReview},
+		To:              StatusApproved,
+		Roles:           []string{user.RoleAdmin},
+		ReviewerAllowed: true,
+		NotBySubmitter:  true,
+	},
+	WorkflowReject: {
+		Action:          WorkflowReject,
+		From:            []string{StatusReview},
+		To:              StatusDraft,
+		Roles:           []string{user.RoleAdmin},
+		ReviewerAllowed: true,
+		NoteRequired:    true,
+		NotBySubmitter:  true,
+	},
+	WorkflowPublish: {
+		Action: WorkflowPublish,
+		From:   []string{StatusApproved},
+		To:     StatusPublished,
+		Roles:  []string{user.RoleAdmin, user.RoleEditor},
+	},
+	WorkflowArchive: {
+		Action: WorkflowArchive,
+		From:   []string{StatusPublished},
+		To:     StatusArchived,
+		Roles:  []string{user.RoleAdmin},
+	},
+	WorkflowReopen: {
+		Action: WorkflowReopen,
+		From:   []string{StatusApproved, StatusArchived},
+		To:     StatusDraft,
+		Roles:  []string{user.RoleAdmin, user.RoleEditor},
+	},
+}
+
+// AllowsFrom reports whether the transition can start from the given status
+func (t Transition) AllowsFrom(status string) bool {
+	for _, from := range t.From {
+		if from == status {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowedFor reports whether a user may perform the transition on a report
+func (t Transition) AllowedFor(actor Actor, rep *Report) bool {
+	for _, role := range t.Roles {
+		if role == actor.Role {
+			return true
+		}
+	}
+	return t.ReviewerAllowed && rep.ReviewerID != nil && *rep.ReviewerID == actor.UserID
+}
+
+// BarsActor reports whether the transition is closed to the actor because
+// they submitted the report for review
+func (t Transition) BarsActor(actor Actor, rep *Report) bool {
+	return t.NotBySubmitter && rep.SubmittedBy != nil && *rep.SubmittedBy == actor.UserID
+}
+
+// Actor is the user performing a workflow action
+type Actor struct {
+	UserID uint
+	Role   string
+}
+
+// SchedulerActor publishes scheduled reports. It has no user, so nothing it
+// does is attributed to one.
+var SchedulerActor = Actor{}
+
+// ReviewComment is a reviewer or editor note on a report. Comments left with a
+// workflow action (such as a rejection reason) record that action.
+type ReviewComment struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	ReportID  uint      `json:"report_id" gorm:"index;not null"`
+	UserID    uint      `json:"user_id" gorm:"index;not null"`
+	Action    string    `json:"action,omitempty" gorm:"type:varchar(20)"`
+	Body      string    `json:"body" gorm:"type:text;not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName specifies the table name for GORM
+func (ReviewComment) TableName() string {
+	return "report_review_comments"
+}
+
+// TransitionRequest is the optional body of a workflow endpoint
+type TransitionRequest struct {
+	Comment string `json:"comment"`
+}
+
+// AssignReviewerRequest assigns (or clears, with null) the report reviewer
+type AssignReviewerRequest struct {
+	ReviewerID *uint `json:"reviewer_id"`
+}
+
+// CreateReviewCommentRequest adds a free-form review comment
+type CreateReviewCommentRequest struct {
+	Body string `json:"body"`
+}
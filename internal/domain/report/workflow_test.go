@@ -0,0 +1,36 @@
+package report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransitionAllowsFrom(t *testing.T) {
+	assert.True(t, Transitions[WorkflowSubmit].AllowsFrom(StatusDraft))
+	assert.False(t, Transitions[WorkflowPublish].AllowsFrom(StatusDraft))
+	assert.False(t, Transitions[WorkflowPublish].AllowsFrom(StatusReview))
+	assert.True(t, Transitions[WorkflowPublish].AllowsFrom(StatusApproved))
+	assert.True(t, Transitions[WorkflowReopen].AllowsFrom(StatusArchived))
+}
+
+func TestTransitionAllowedFor(t *testing.T) {
+	reviewerID := uint(12)
+	rep := &Report{ID: 5, Status: StatusReview, ReviewerID: &reviewerID}
+	approve := Transitions[WorkflowApprove]
+
+	assert.True(t, approve.AllowedFor(Actor{UserID: 1, Role: "admin"}, rep))
+	assert.True(t, approve.AllowedFor(Actor{UserID: 12, Role: "editor"}, rep), "assigned reviewer")
+	assert.False(t, approve.AllowedFor(Actor{UserID: 7, Role: "editor"}, rep), "other editor")
+	assert.False(t, Transitions[WorkflowArchive].AllowedFor(Actor{UserID: 12, Role: "editor"}, rep))
+}
+
+func TestTransitionBarsActor(t *testing.T) {
+	submitter := uint(7)
+	rep := &Report{ID: 5, Status: StatusReview, SubmittedBy: &submitter}
+
+	assert.True(t, Transitions[WorkflowApprove].BarsActor(Actor{UserID: 7, Role: "admin"}, rep))
+	assert.True(t, Transitions[WorkflowReject].BarsActor(Actor{UserID: 7, Role: "editor"}, rep))
+	assert.False(t, Transitions[WorkflowApprove].BarsActor(Actor{UserID: 12, Role: "editor"}, rep))
+	assert.False(t, Transitions[WorkflowPublish].BarsActor(Actor{UserID: 7, Role: "editor"}, rep))
+}
@@ -0,0 +1,63 @@
+package search
+
+import "time"
+
+// ContentType identifies the kind of content a search result points to
+type ContentType string
+
+const (
+	TypeReport       ContentType = "report"
+	TypeBlog         ContentType = "blog"
+	TypePressRelease ContentType = "press_release"
+)
+
+// AllTypes lists every searchable content type in display order
+var AllTypes = []ContentType{TypeReport, TypeBlog, TypePressRelease}
+
+// IsValid reports whether the content type is searchable
+func (t ContentType) IsValid() bool {
+	for _, valid := range AllTypes {
+		if t == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// Query represents the parameters of a cross-content search
+type Query struct {
+	Q     string
+	Types []ContentType // Empty means all types
+	Page  int
+	Limit int
+}
+
+// Result is a single ranked search hit
+type Result struct {
+	Type         ContentType `json:"type"`
+	ID           uint        `json:"id"`
+	Title        string      `json:"title"`
+	Slug         string      `json:"slug"`
+	Snippet      string      `json:"snippet"`
+	CategoryName string      `json:"category_name,omitempty"`
+	Rank         float64     `json:"rank"`
+	PublishDate  *time.Time  `json:"publish_date,omitempty"`
+	CreatedAt    time.Time   `json:"created_at"`
+}
+
+// TypeFacet holds the number of matches for a content type
+type TypeFacet struct {
+	Type  ContentType `json:"type"`
+	Count int64       `json:"count"`
+}
+
+// SearchResponse is the payload returned by the search endpoint
+type SearchResponse struct {
+	Results []Result `json:"results"`
+	Facets  Facets   `json:"facets"`
+}
+
+// Facets groups the facet counts returned alongside search results
+type Facets struct {
+	Type []TypeFacet `json:"type"`
+}
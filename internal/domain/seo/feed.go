@@ -0,0 +1,234 @@
+package seo
+
+import (
+	"encoding/xml"
+	"fmt"
+	"time"
+)
+
+// FeedSize is the number of most recent items in a feed
+const FeedSize = 50
+
+// Feed formats
+const (
+	FormatRSS  = "rss"
+	FormatAtom = "atom"
+)
+
+// AtomNamespace is the XML namespace of Atom documents
+const AtomNamespace = "http://www.w3.org/2005/Atom"
+
+// FeedSections lists the sections that publish feeds
+var FeedSections = []Section{SectionBlogs, SectionPressReleases}
+
+// HasFeed reports whether the section publishes a feed
+func (s Section) HasFeed() bool {
+	for _, f := range FeedSections {
+		if s == f {
+			return true
+		}
+	}
+	return false
+}
+
+// FeedItem is a published post as it appears in a feed
+type FeedItem struct {
+	ID           uint
+	Title        string
+	Slug         string
+	Excerpt      string
+	AuthorName   string
+	CategoryName string
+	PublishDate  *time.Time
+	UpdatedAt    time.Time
+}
+
+// published returns when the item went live, falling back to its last update
+func (i FeedItem) published() time.Time {
+	if i.PublishDate != nil {
+		return *i.PublishDate
+	}
+	return i.UpdatedAt
+}
+
+// FeedInfo describes the feed itself
+type FeedInfo struct {
+	Title       string
+	Description string
+	// SiteURL is the site base URL; item links are built from it
+	SiteURL string
+	// SelfURL is where the feed document is served
+	SelfURL string
+	Section Section
+}
+
+// RSS is an RSS 2.0 document
+type RSS struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	AtomNS  string     `xml:"xmlns:atom,attr"`
+	Channel RSSChannel `xml:"channel"`
+}
+
+// RSSChannel is the channel of an RSS document
+type RSSChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	AtomLink      AtomLink  `xml:"atom:link"`
+	Items         []RSSItem `xml:"item"`
+}
+
+// RSSItem is a single RSS entry
+type RSSItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	Description string  `xml:"description"`
+	GUID        RSSGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+	Category    string  `xml:"category,omitempty"`
+}
+
+// RSSGUID identifies an RSS item
+type RSSGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+// AtomFeed is an Atom 1.0 document
+type AtomFeed struct {
+	XMLName  xml.Name    `xml:"feed"`
+	Xmlns    string      `xml:"xmlns,attr"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	ID       string      `xml:"id"`
+	Updated  string      `xml:"updated"`
+	Links    []AtomLink  `xml:"link"`
+	Entries  []AtomEntry `xml:"entry"`
+}
+
+// AtomLink is a link element shared by RSS (atom:link) and Atom
+type AtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+// AtomEntry is a single Atom entry
+type AtomEntry struct {
+	Title     string        `xml:"title"`
+	ID        string        `xml:"id"`
+	Links     []AtomLink    `xml:"link"`
+	Published string        `xml:"published"`
+	Updated   string        `xml:"updated"`
+	Summary   string        `xml:"summary,omitempty"`
+	Author    *AtomPerson   `xml:"author,omitempty"`
+	Category  *AtomCategory `xml:"category,omitempty"`
+}
+
+// AtomPerson names the author of an entry
+type AtomPerson struct {
+	Name string `xml:"name"`
+}
+
+// AtomCategory tags an entry with its category
+type AtomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// BuildRSS renders items as an RSS 2.0 channel
+func BuildRSS(info FeedInfo, items []FeedItem) RSS {
+	channel := RSSChannel{
+		Title:       info.Title,
+		Link:        info.SiteURL,
+		Description: info.Description,
+		AtomLink:    AtomLink{Href: info.SelfURL, Rel: "self", Type: "application/rss+xml"},
+		Items:       make([]RSSItem, 0, len(items)),
+	}
+	if len(items) > 0 {
+		channel.LastBuildDate = latestUpdate(items).UTC().Format(time.RFC1123Z)
+	}
+
+	for _, item := range items {
+		link := itemLink(info, item)
+		channel.Items = append(channel.Items, RSSItem{
+			Title:       item.Title,
+			Link:        link,
+			Description: item.Excerpt,
+			GUID:        RSSGUID{Value: link, IsPermaLink: true},
+			PubDate:     item.published().UTC().Format(time.RFC1123Z),
+			Category:    item.CategoryName,
+		})
+	}
+
+	return RSS{Version: "2.0", AtomNS: AtomNamespace, Channel: channel}
+}
+
+// BuildAtom renders items as an Atom feed
+func BuildAtom(info FeedInfo, items []FeedItem) AtomFeed {
+	feed := AtomFeed{
+		Xmlns:    AtomNamespace,
+		Title:    info.Title,
+		Subtitle: info.Description,
+		ID:       info.SelfURL,
+		Updated:  latestUpdate(items).UTC().Format(time.RFC3339),
+		Links: []AtomLink{
+			{Href: info.SelfURL, Rel: "self", Type: "application/atom+xml"},
+			{Href: info.SiteURL, Rel: "alternate", Type: "text/html"},
+		},
+		Entries: make([]AtomEntry, 0, len(items)),
+	}
+
+	for _, item := range items {
+		link := itemLink(info, item)
+		entry := AtomEntry{
+			Title:     item.Title,
+			ID:        link,
+			Links:     []AtomLink{{Href: link, Rel: "alternate", Type: "text/html"}},
+			Published: item.published().UTC().Format(time.RFC3339),
+			Updated:   item.UpdatedAt.UTC().Format(time.RFC3339),
+			Summary:   item.Excerpt,
+		}
+		if item.AuthorName != "" {
+			entry.Author = &AtomPerson{Name: item.AuthorName}
+		}
+		if item.CategoryName != "" {
+			entry.Category = &AtomCategory{Term: item.CategoryName}
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	return feed
+}
+
+func itemLink(info FeedInfo, item FeedItem) string {
+	return joinURL(info.SiteURL, info.Section.PagePath(Entry{ID: item.ID, Slug: item.Slug}))
+}
+
+// latestUpdate is the feed's updated time: the newest item change, or the
+// Unix epoch for an empty feed so the output stays stable between requests
+func latestUpdate(items []FeedItem) time.Time {
+	var latest time.Time
+	for _, item := range items {
+		if item.UpdatedAt.After(latest) {
+			latest = item.UpdatedAt
+		}
+	}
+	if latest.IsZero() {
+		return time.Unix(0, 0)
+	}
+	return latest
+}
+
+// FeedTitle is the default title of a section's feed
+func FeedTitle(siteName string, section Section, categoryName string) string {
+	label := map[Section]string{
+		SectionBlogs:         "Blog",
+		SectionPressReleases: "Press Releases",
+	}[section]
+	if categoryName != "" {
+		return fmt.Sprintf("%s %s: %s", siteName, label, categoryName)
+	}
+	return fmt.Sprintf("%s %s", siteName, label)
+}
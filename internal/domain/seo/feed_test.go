@@ -0,0 +1,63 @@
+package seo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func feedFixture() (FeedInfo, []FeedItem) {
+	published := time.Date(2026, 2, 10, 9, 0, 0, 0, time.UTC)
+	info := FeedInfo{
+		Title:   FeedTitle("HMR", SectionBlogs, ""),
+		SiteURL: "https://example.com",
+		SelfURL: "https://example.com/feeds/blogs.rss",
+		Section: SectionBlogs,
+	}
+	items := []FeedItem{
+		{ID: 1, Title: "Oncology trends", Slug: "oncology-trends", Excerpt: "Summary", AuthorName: "Jane", CategoryName: "Oncology", PublishDate: &published, UpdatedAt: published.Add(time.Hour)},
+		{ID: 2, Title: "Draft date missing", Slug: "no-date", UpdatedAt: published.Add(-time.Hour)},
+	}
+	return info, items
+}
+
+func TestBuildRSS(t *testing.T) {
+	info, items := feedFixture()
+
+	out, err := Marshal(BuildRSS(info, items))
+	assert.NoError(t, err)
+
+	doc := string(out)
+	assert.Contains(t, doc, `<rss version="2.0" xmlns:atom="`+AtomNamespace+`">`)
+	assert.Contains(t, doc, "<title>HMR Blog</title>")
+	assert.Contains(t, doc, `<atom:link href="https://example.com/feeds/blogs.rss" rel="self" type="application/rss+xml"></atom:link>`)
+	assert.Contains(t, doc, "<lastBuildDate>Tue, 10 Feb 2026 10:00:00 +0000</lastBuildDate>")
+	assert.Contains(t, doc, `<guid isPermaLink="true">https://example.com/blogs/oncology-trends</guid>`)
+	// Items without a publish date fall back to their last update
+	assert.Contains(t, doc, "<pubDate>Tue, 10 Feb 2026 08:00:00 +0000</pubDate>")
+}
+
+func TestBuildAtom(t *testing.T) {
+	info, items := feedFixture()
+
+	feed := BuildAtom(info, items)
+
+	assert.Equal(t, "2026-02-10T10:00:00Z", feed.Updated)
+	assert.Len(t, feed.Entries, 2)
+	assert.Equal(t, "https://example.com/blogs/oncology-trends", feed.Entries[0].ID)
+	assert.Equal(t, "Jane", feed.Entries[0].Author.Name)
+	assert.Nil(t, feed.Entries[1].Author)
+	assert.Nil(t, feed.Entries[1].Category)
+}
+
+func TestBuildAtomEmptyFeedIsStable(t *testing.T) {
+	info, _ := feedFixture()
+
+	assert.Equal(t, "1970-01-01T00:00:00Z", BuildAtom(info, nil).Updated)
+}
+
+func TestFeedTitle(t *testing.T) {
+	assert.Equal(t, "HMR Press Releases", FeedTitle("HMR", SectionPressReleases, ""))
+	assert.Equal(t, "HMR Blog: Oncology", FeedTitle("HMR", SectionBlogs, "Oncology"))
+}
@@ -0,0 +1,345 @@
+package seo
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/healthcare-market-research/backend/internal/domain/author"
+	"github.com/healthcare-market-research/backend/internal/domain/blog"
+	"github.com/healthcare-market-research/backend/internal/domain/category"
+	"github.com/healthcare-market-research/backend/internal/domain/press_release"
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+)
+
+// SchemaContext is the JSON-LD @context of every document
+const SchemaContext = "https://schema.org"
+
+// DefaultCurrency is used for offers when a report has no currency set
+const DefaultCurrency = "USD"
+
+// JSONLD is a schema.org document. Reports carry more than one node (the
+// product and its FAQ page), so every document is a graph.
+type JSONLD struct {
+	Context string        `json:"@context"`
+	Graph   []interface{} `json:"@graph"`
+}
+
+// Organization is the site publishing the content
+type Organization struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+// Person is a content author
+type Person struct {
+	Type     string   `json:"@type"`
+	Name     string   `json:"name"`
+	JobTitle string   `json:"jobTitle,omitempty"`
+	URL      string   `json:"url,omitempty"`
+	Image    string   `json:"image,omitempty"`
+	SameAs   []string `json:"sameAs,omitempty"`
+}
+
+// ReportNode describes a report as both a Product (it is sold) and a Dataset
+type ReportNode struct {
+	Type            []string      `json:"@type"`
+	ID              string        `json:"@id"`
+	Name            string        `json:"name"`
+	Description     string        `json:"description,omitempty"`
+	URL             string        `json:"url"`
+	SKU             string        `json:"sku"`
+	Category        string        `json:"category,omitempty"`
+	Keywords        string        `json:"keywords,omitempty"`
+	SpatialCoverage []string      `json:"spatialCoverage,omitempty"`
+	DatePublished   string        `json:"datePublished,omitempty"`
+	DateModified    string        `json:"dateModified,omitempty"`
+	Author          []Person      `json:"author,omitempty"`
+	Brand           *Organization `json:"brand,omitempty"`
+	Publisher       *Organization `json:"publisher,omitempty"`
+	Offers          *Offer        `json:"offers,omitempty"`
+}
+
+// Offer is the price a report is sold at
+type Offer struct {
+	Type               string                  `json:"@type"`
+	URL                string                  `json:"url"`
+	Price              string                  `json:"price"`
+	PriceCurrency      string                  `json:"priceCurrency"`
+	Availability       string                  `json:"availability"`
+	PriceSpecification *UnitPriceSpecification `json:"priceSpecification,omitempty"`
+}
+
+// UnitPriceSpecification carries the list price of a discounted offer
+type UnitPriceSpecification struct {
+	Type          string `json:"@type"`
+	PriceType     string `json:"priceType"`
+	Price         string `json:"price"`
+	PriceCurrency string `json:"priceCurrency"`
+}
+
+// FAQPage lists a report's FAQs
+type FAQPage struct {
+	Type       string     `json:"@type"`
+	ID         string     `json:"@id"`
+	MainEntity []Question `json:"mainEntity"`
+}
+
+// Question is a single FAQ entry
+type Question struct {
+	Type           string `json:"@type"`
+	Name           string `json:"name"`
+	AcceptedAnswer Answer `json:"acceptedAnswer"`
+}
+
+// Answer answers a FAQ question
+type Answer struct {
+	Type string `json:"@type"`
+	Text string `json:"text"`
+}
+
+// Article is a BlogPosting or a NewsArticle
+type Article struct {
+	Type             string        `json:"@type"`
+	ID               string        `json:"@id"`
+	Headline         string        `json:"headline"`
+	Description      string        `json:"description,omitempty"`
+	URL              string        `json:"url"`
+	MainEntityOfPage string        `json:"mainEntityOfPage"`
+	ArticleSection   string        `json:"articleSection,omitempty"`
+	Keywords         string        `json:"keywords,omitempty"`
+	Dateline         string        `json:"dateline,omitempty"`
+	DatePublished    string        `json:"datePublished"`
+	DateModified     string        `json:"dateModified"`
+	Author           []Person      `json:"author,omitempty"`
+	Publisher        *Organization `json:"publisher"`
+}
+
+// JSONLDBuilder builds the structured data of content pages on the site
+type JSONLDBuilder struct {
+	siteURL  string
+	siteName string
+}
+
+// NewJSONLDBuilder creates a builder for pages under siteURL
+func NewJSONLDBuilder(siteURL, siteName string) *JSONLDBuilder {
+	return &JSONLDBuilder{siteURL: strings.TrimRight(siteURL, "/"), siteName: siteName}
+}
+
+// Report describes a report as a Product/Dataset with its offer, authors and,
+// when it has any, its FAQs as a FAQPage
+func (b *JSONLDBuilder) Report(r *report.ReportWithRelations) JSONLD {
+	url := b.pageURL(SectionReports, Entry{ID: r.ID, Slug: r.Slug})
+	publisher := b.publisher()
+
+	node := ReportNode{
+		Type:            []string{"Product", "Dataset"},
+		ID:              url + "#report",
+		Name:            r.Title,
+		Description:     firstNonEmpty(r.MetaDescription, r.Summary, r.Description),
+		URL:             url,
+		SKU:             r.Slug,
+		Category:        r.CategoryName,
+		Keywords:        r.MetaKeywords,
+		SpatialCoverage: r.Geography,
+		DatePublished:   formatLastMod(r.PublishDate),
+		DateModified:    formatLastMod(&r.UpdatedAt),
+		Author:          b.people(r.Authors),
+		Brand:           publisher,
+		Publisher:       publisher,
+		Offers:          reportOffer(url, r.Price, r.DiscountedPrice, r.Currency),
+	}
+
+	doc := JSONLD{Context: SchemaContext, Graph: []interface{}{node}}
+	if faq := faqPage(url, r.FAQs); faq != nil {
+		doc.Graph = append(doc.Graph, faq)
+	}
+	return doc
+}
+
+// Blog describes a blog post as a BlogPosting
+func (b *JSONLDBuilder) Blog(post *blog.Blog) JSONLD {
+	article := b.article("BlogPosting", SectionBlogs, articleSource{
+		id:          post.ID,
+		slug:        post.Slug,
+		title:       post.Title,
+		description: firstNonEmpty(post.Metadata.MetaDescription, post.Excerpt),
+		tags:        post.Tags,
+		keywords:    post.Metadata.Keywords,
+		author:      post.Author,
+		category:    post.Category,
+		publishDate: post.PublishDate,
+		createdAt:   post.CreatedAt,
+		updatedAt:   post.UpdatedAt,
+	})
+	return JSONLD{Context: SchemaContext, Graph: []interface{}{article}}
+}
+
+// PressRelease describes a press release as a NewsArticle, using its location
+// as the dateline
+func (b *JSONLDBuilder) PressRelease(pr *press_release.PressRelease) JSONLD {
+	article := b.article("NewsArticle", SectionPressReleases, articleSource{
+		id:          pr.ID,
+		slug:        pr.Slug,
+		title:       pr.Title,
+		description: firstNonEmpty(pr.Metadata.MetaDescription, pr.Excerpt),
+		tags:        pr.Tags,
+		keywords:    pr.Metadata.Keywords,
+		author:      pr.Author,
+		category:    pr.Category,
+		publishDate: pr.PublishDate,
+		createdAt:   pr.CreatedAt,
+		updatedAt:   pr.UpdatedAt,
+	})
+	article.Dateline = pr.Location
+	return JSONLD{Context: SchemaContext, Graph: []interface{}{article}}
+}
+
+// articleSource holds the fields blogs and press releases have in common
+type articleSource struct {
+	id          uint
+	slug        string
+	title       string
+	description string
+	tags        string
+	keywords    []string
+	author      *author.Author
+	category    *category.Category
+	publishDate *time.Time
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+func (b *JSONLDBuilder) article(schemaType string, section Section, src articleSource) Article {
+	url := b.pageURL(section, Entry{ID: src.id, Slug: src.slug})
+
+	published := src.publishDate
+	if published == nil {
+		published = &src.createdAt
+	}
+
+	article := Article{
+		Type:             schemaType,
+		ID:               url + "#article",
+		Headline:         src.title,
+		Description:      src.description,
+		URL:              url,
+		MainEntityOfPage: url,
+		Keywords:         articleKeywords(src.tags, src.keywords),
+		DatePublished:    formatLastMod(published),
+		DateModified:     formatLastMod(&src.updatedAt),
+		Publisher:        b.publisher(),
+	}
+	if src.author != nil {
+		article.Author = b.people([]author.Author{*src.author})
+	}
+	if src.category != nil {
+		article.ArticleSection = src.category.Name
+	}
+	return article
+}
+
+func (b *JSONLDBuilder) publisher() *Organization {
+	return &Organization{Type: "Organization", Name: b.siteName, URL: b.siteURL}
+}
+
+func (b *JSONLDBuilder) people(authors []author.Author) []Person {
+	if len(authors) == 0 {
+		return nil
+	}
+	people := make([]Person, 0, len(authors))
+	for _, a := range authors {
+		p := Person{
+			Type:     "Person",
+			Name:     a.Name,
+			JobTitle: a.Role,
+			URL:      b.pageURL(SectionAuthors, Entry{ID: a.ID}),
+			Image:    a.ImageURL,
+		}
+		if a.LinkedinURL != "" {
+			p.SameAs = []string{a.LinkedinURL}
+		}
+		people = append(people, p)
+	}
+	return people
+}
+
+func (b *JSONLDBuilder) pageURL(section Section, e Entry) string {
+	return joinURL(b.siteURL, section.PagePath(e))
+}
+
+// reportOffer is the report's current price, with the list price attached when
+// it is discounted. Reports without a price are not offered.
+func reportOffer(url string, price, discountedPrice float64, currency string) *Offer {
+	if price <= 0 {
+		return nil
+	}
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+
+	offer := &Offer{
+		Type:          "Offer",
+		URL:           url,
+		Price:         formatPrice(price),
+		PriceCurrency: currency,
+		Availability:  "https://schema.org/InStock",
+	}
+	if discountedPrice > 0 && discountedPrice < price {
+		offer.Price = formatPrice(discountedPrice)
+		offer.PriceSpecification = &UnitPriceSpecification{
+			Type:          "UnitPriceSpecification",
+			PriceType:     "https://schema.org/ListPrice",
+			Price:         formatPrice(price),
+			PriceCurrency: currency,
+		}
+	}
+	return offer
+}
+
+func faqPage(url string, faqs report.FAQs) *FAQPage {
+	questions := make([]Question, 0, len(faqs))
+	for _, f := range faqs {
+		if strings.TrimSpace(f.Question) == "" || strings.TrimSpace(f.Answer) == "" {
+			continue
+		}
+		questions = append(questions, Question{
+			Type:           "Question",
+			Name:           f.Question,
+			AcceptedAnswer: Answer{Type: "Answer", Text: f.Answer},
+		})
+	}
+	if len(questions) == 0 {
+		return nil
+	}
+	return &FAQPage{Type: "FAQPage", ID: url + "#faq", MainEntity: questions}
+}
+
+// articleKeywords prefers the SEO keywords and falls back to the post's tags
+func articleKeywords(tags string, keywords []string) string {
+	if len(keywords) > 0 {
+		return strings.Join(keywords, ", ")
+	}
+	parts := strings.Split(tags, ",")
+	cleaned := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			cleaned = append(cleaned, p)
+		}
+	}
+	return strings.Join(cleaned, ", ")
+}
+
+func formatPrice(v float64) string {
+	return fmt.Sprintf("%.2f", v)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,163 @@
+package seo
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/healthcare-market-research/backend/internal/domain/author"
+	"github.com/healthcare-market-research/backend/internal/domain/blog"
+	"github.com/healthcare-market-research/backend/internal/domain/category"
+	"github.com/healthcare-market-research/backend/internal/domain/press_release"
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	fixtureDate    = time.Date(2026, 1, 15, 8, 30, 0, 0, time.UTC)
+	fixtureUpdated = time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC)
+	fixtureAuthor  = author.Author{ID: 3, Name: "Jane Doe", Role: "Lead Analyst", LinkedinURL: "https://linkedin.com/in/janedoe"}
+)
+
+func fixtureReport() *report.ReportWithRelations {
+	return &report.ReportWithRelations{
+		Report: report.Report{
+			ID:              12,
+			Title:           "Oncology Drugs Market",
+			Slug:            "oncology-drugs-market",
+			Summary:         "Market size and forecast",
+			Price:           3999,
+			DiscountedPrice: 2999,
+			Currency:        "EUR",
+			Geography:       report.StringSlice{"Global", "Europe"},
+			PublishDate:     &fixtureDate,
+			AuthorIDs:       report.UintSlice{3},
+			FAQs: report.FAQs{
+				{Question: "How big is the market?", Answer: "USD 200 billion in 2025."},
+				{Question: "", Answer: "Dropped"},
+			},
+			UpdatedAt: fixtureUpdated,
+		},
+		CategoryName: "Oncology",
+		Authors:      []author.Author{fixtureAuthor},
+	}
+}
+
+// toMap round-trips a document through JSON to assert on the emitted keys
+func toMap(t *testing.T, v interface{}) map[string]interface{} {
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	var out map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &out))
+	return out
+}
+
+func TestJSONLDReport(t *testing.T) {
+	builder := NewJSONLDBuilder("https://example.com/", "HMR")
+
+	doc := toMap(t, builder.Report(fixtureReport()))
+	assert.Equal(t, SchemaContext, doc["@context"])
+
+	graph := doc["@graph"].([]interface{})
+	require.Len(t, graph, 2)
+
+	product := graph[0].(map[string]interface{})
+	assert.Equal(t, []interface{}{"Product", "Dataset"}, product["@type"])
+	assert.Equal(t, "https://example.com/reports/oncology-drugs-market", product["url"])
+	assert.Equal(t, "Market size and forecast", product["description"])
+	assert.Equal(t, "2026-01-15T08:30:00Z", product["datePublished"])
+	assert.Equal(t, []interface{}{"Global", "Europe"}, product["spatialCoverage"])
+
+	offer := product["offers"].(map[string]interface{})
+	assert.Equal(t, "2999.00", offer["price"])
+	assert.Equal(t, "EUR", offer["priceCurrency"])
+	listPrice := offer["priceSpecification"].(map[string]interface{})
+	assert.Equal(t, "3999.00", listPrice["price"])
+
+	authors := product["author"].([]interface{})
+	require.Len(t, authors, 1)
+	person := authors[0].(map[string]interface{})
+	assert.Equal(t, "Jane Doe", person["name"])
+	assert.Equal(t, "https://example.com/authors/3", person["url"])
+	assert.Equal(t, []interface{}{"https://linkedin.com/in/janedoe"}, person["sameAs"])
+
+	faq := graph[1].(map[string]interface{})
+	assert.Equal(t, "FAQPage", faq["@type"])
+	questions := faq["mainEntity"].([]interface{})
+	require.Len(t, questions, 1)
+	answer := questions[0].(map[string]interface{})["acceptedAnswer"].(map[string]interface{})
+	assert.Equal(t, "USD 200 billion in 2025.", answer["text"])
+}
+
+func TestJSONLDReportWithoutPriceOrFAQs(t *testing.T) {
+	rep := fixtureReport()
+	rep.Price = 0
+	rep.DiscountedPrice = 0
+	rep.FAQs = nil
+	rep.Authors = nil
+
+	doc := toMap(t, NewJSONLDBuilder("https://example.com", "HMR").Report(rep))
+
+	graph := doc["@graph"].([]interface{})
+	require.Len(t, graph, 1)
+	product := graph[0].(map[string]interface{})
+	assert.NotContains(t, product, "offers")
+	assert.NotContains(t, product, "author")
+}
+
+func TestReportOfferWithoutDiscount(t *testing.T) {
+	offer := reportOffer("https://example.com/reports/x", 1500, 0, "")
+
+	require.NotNil(t, offer)
+	assert.Equal(t, "1500.00", offer.Price)
+	assert.Equal(t, DefaultCurrency, offer.PriceCurrency)
+	assert.Nil(t, offer.PriceSpecification)
+}
+
+func TestJSONLDBlog(t *testing.T) {
+	post := &blog.Blog{
+		ID:        4,
+		Title:     "Five trends in oncology",
+		Slug:      "five-trends-in-oncology",
+		Excerpt:   "What changed this year",
+		Tags:      "oncology, trends ,",
+		Author:    &fixtureAuthor,
+		Category:  &category.Category{Name: "Oncology"},
+		CreatedAt: fixtureDate,
+		UpdatedAt: fixtureUpdated,
+	}
+
+	doc := toMap(t, NewJSONLDBuilder("https://example.com", "HMR").Blog(post))
+
+	article := doc["@graph"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "BlogPosting", article["@type"])
+	assert.Equal(t, "Five trends in oncology", article["headline"])
+	assert.Equal(t, "https://example.com/blogs/five-trends-in-oncology", article["mainEntityOfPage"])
+	assert.Equal(t, "oncology, trends", article["keywords"])
+	assert.Equal(t, "Oncology", article["articleSection"])
+	// Without a publish date the creation date is used
+	assert.Equal(t, "2026-01-15T08:30:00Z", article["datePublished"])
+	assert.Equal(t, "HMR", article["publisher"].(map[string]interface{})["name"])
+}
+
+func TestJSONLDPressRelease(t *testing.T) {
+	pr := &press_release.PressRelease{
+		ID:          9,
+		Title:       "HMR launches oncology tracker",
+		Slug:        "hmr-launches-oncology-tracker",
+		Excerpt:     "A new quarterly tracker",
+		Location:    "Boston, MA",
+		PublishDate: &fixtureDate,
+		Metadata:    press_release.PressReleaseMetadata{Keywords: []string{"oncology", "tracker"}},
+		UpdatedAt:   fixtureUpdated,
+	}
+
+	doc := toMap(t, NewJSONLDBuilder("https://example.com", "HMR").PressRelease(pr))
+
+	article := doc["@graph"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "NewsArticle", article["@type"])
+	assert.Equal(t, "Boston, MA", article["dateline"])
+	assert.Equal(t, "oncology, tracker", article["keywords"])
+	assert.NotContains(t, article, "author")
+}
@@ -0,0 +1,175 @@
+package seo
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SitemapNamespace is the XML namespace of sitemaps and sitemap indexes
+const SitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// MaxURLsPerSitemap is the protocol limit for a single sitemap file
+const MaxURLsPerSitemap = 50000
+
+// Section identifies a kind of public page listed in the sitemap
+type Section string
+
+const (
+	SectionReports       Section = "reports"
+	SectionBlogs         Section = "blogs"
+	SectionPressReleases Section = "press-releases"
+	SectionCategories    Section = "categories"
+	SectionAuthors       Section = "authors"
+)
+
+// AllSections lists every section in sitemap index order
+var AllSections = []Section{SectionReports, SectionBlogs, SectionPressReleases, SectionCategories, SectionAuthors}
+
+// IsValid reports whether the section has a sitemap
+func (s Section) IsValid() bool {
+	for _, valid := range AllSections {
+		if s == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// PagePath returns the site path of a page in the section. Authors have no
+// slug and are addressed by ID.
+func (s Section) PagePath(e Entry) string {
+	if s == SectionAuthors {
+		return fmt.Sprintf("/authors/%d", e.ID)
+	}
+	return fmt.Sprintf("/%s/%s", s, e.Slug)
+}
+
+// SectionStats summarizes the published pages of a section
+type SectionStats struct {
+	Section Section
+	Count   int64
+	LastMod *time.Time
+}
+
+// Pages returns how many sitemap files the section needs
+func (s SectionStats) Pages() int {
+	if s.Count == 0 {
+		return 0
+	}
+	return int((s.Count + MaxURLsPerSitemap - 1) / MaxURLsPerSitemap)
+}
+
+// Entry is a single page listed in a sitemap
+type Entry struct {
+	ID        uint
+	Slug      string
+	UpdatedAt time.Time
+}
+
+// SitemapName returns the file name of a section's sitemap page. The first
+// page is "reports.xml", later pages are "reports-2.xml" and so on.
+func SitemapName(section Section, page int) string {
+	if page <= 1 {
+		return string(section) + ".xml"
+	}
+	return fmt.Sprintf("%s-%d.xml", section, page)
+}
+
+// ParseSitemapName is the inverse of SitemapName
+func ParseSitemapName(name string) (Section, int, bool) {
+	base, ok := strings.CutSuffix(name, ".xml")
+	if !ok {
+		return "", 0, false
+	}
+
+	if section := Section(base); section.IsValid() {
+		return section, 1, true
+	}
+
+	idx := strings.LastIndex(base, "-")
+	if idx < 0 {
+		return "", 0, false
+	}
+	page, err := strconv.Atoi(base[idx+1:])
+	section := Section(base[:idx])
+	if err != nil || page < 2 || !section.IsValid() {
+		return "", 0, false
+	}
+
+	return section, page, true
+}
+
+// URLSet is a sitemap file
+type URLSet struct {
+	XMLName xml.Name `xml:"urlset"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	URLs    []URL    `xml:"url"`
+}
+
+// URL is a page entry in a sitemap
+type URL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// SitemapIndex lists the child sitemaps
+type SitemapIndex struct {
+	XMLName  xml.Name  `xml:"sitemapindex"`
+	Xmlns    string    `xml:"xmlns,attr"`
+	Sitemaps []Sitemap `xml:"sitemap"`
+}
+
+// Sitemap is a child sitemap entry in the index
+type Sitemap struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// BuildURLSet lists the section's pages under the site base URL
+func BuildURLSet(baseURL string, section Section, entries []Entry) URLSet {
+	set := URLSet{Xmlns: SitemapNamespace, URLs: make([]URL, 0, len(entries))}
+	for _, e := range entries {
+		set.URLs = append(set.URLs, URL{
+			Loc:     joinURL(baseURL, section.PagePath(e)),
+			LastMod: formatLastMod(&e.UpdatedAt),
+		})
+	}
+	return set
+}
+
+// BuildSitemapIndex lists one child sitemap per page of every non-empty section
+func BuildSitemapIndex(baseURL string, stats []SectionStats) SitemapIndex {
+	index := SitemapIndex{Xmlns: SitemapNamespace, Sitemaps: []Sitemap{}}
+	for _, s := range stats {
+		for page := 1; page <= s.Pages(); page++ {
+			index.Sitemaps = append(index.Sitemaps, Sitemap{
+				Loc:     joinURL(baseURL, "/sitemaps/"+SitemapName(s.Section, page)),
+				LastMod: formatLastMod(s.LastMod),
+			})
+		}
+	}
+	return index
+}
+
+// Marshal renders a sitemap document with the XML declaration
+func Marshal(v interface{}) ([]byte, error) {
+	body, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+func formatLastMod(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func joinURL(baseURL, path string) string {
+	return strings.TrimRight(baseURL, "/") + path
+}
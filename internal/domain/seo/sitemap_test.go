@@ -0,0 +1,70 @@
+package seo
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSitemapName(t *testing.T) {
+	tests := []struct {
+		name    string
+		section Section
+		page    int
+		ok      bool
+	}{
+		{"reports.xml", SectionReports, 1, true},
+		{"press-releases.xml", SectionPressReleases, 1, true},
+		{"press-releases-3.xml", SectionPressReleases, 3, true},
+		{"reports-1.xml", "", 0, false},
+		{"reports-x.xml", "", 0, false},
+		{"users.xml", "", 0, false},
+		{"reports", "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			section, page, ok := ParseSitemapName(tt.name)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.section, section)
+			assert.Equal(t, tt.page, page)
+		})
+	}
+}
+
+func TestBuildSitemapIndexSplitsLargeSections(t *testing.T) {
+	lastMod := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	stats := []SectionStats{
+		{Section: SectionReports, Count: MaxURLsPerSitemap + 1, LastMod: &lastMod},
+		{Section: SectionBlogs, Count: 0},
+		{Section: SectionAuthors, Count: 3, LastMod: &lastMod},
+	}
+
+	index := BuildSitemapIndex("https://example.com/", stats)
+
+	locs := make([]string, 0, len(index.Sitemaps))
+	for _, s := range index.Sitemaps {
+		locs = append(locs, s.Loc)
+	}
+	assert.Equal(t, []string{
+		"https://example.com/sitemaps/reports.xml",
+		"https://example.com/sitemaps/reports-2.xml",
+		"https://example.com/sitemaps/authors.xml",
+	}, locs)
+	assert.Equal(t, "2026-03-01T12:00:00Z", index.Sitemaps[0].LastMod)
+}
+
+func TestBuildURLSet(t *testing.T) {
+	updated := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	set := BuildURLSet("https://example.com", SectionAuthors, []Entry{{ID: 4, UpdatedAt: updated}})
+	out, err := Marshal(set)
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), "<?xml"))
+	assert.Contains(t, string(out), `<urlset xmlns="`+SitemapNamespace+`">`)
+	assert.Contains(t, string(out), "<loc>https://example.com/authors/4</loc>")
+	assert.Contains(t, string(out), "<lastmod>2026-03-01T12:00:00Z</lastmod>")
+}
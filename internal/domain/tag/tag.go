@@ -0,0 +1,178 @@
+package tag
+
+import (
+	"strings"
+	"time"
+)
+
+// Content types that can be tagged
+const (
+	ContentReport       = "report"
+	ContentBlog         = "blog"
+	ContentPressRelease = "press_release"
+)
+
+// ContentTypes lists every content type a tag can be attached to
+var ContentTypes = []string{ContentReport, ContentBlog, ContentPressRelease}
+
+// ValidContentType reports whether content of the type can be tagged
+func ValidContentType(contentType string) bool {
+	for _, t := range ContentTypes {
+		if t == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxNameLength is the longest tag name accepted
+const MaxNameLength = 100
+
+// Tag is a label shared by reports, blogs and press releases
+// @Description Tag attached to content
+type Tag struct {
+	ID        uint      `json:"id" gorm:"primaryKey" example:"1"`
+	Name      string    `json:"name" gorm:"type:varchar(100);not null" example:"Digital Health"`
+	Slug      string    `json:"slug" gorm:"type:varchar(120);uniqueIndex;not null" example:"digital-health"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName specifies the table name for GORM
+func (Tag) TableName() string {
+	return "tags"
+}
+
+// ContentTag attaches a tag to a report, blog or press release.
+// content_type/content_id is polymorphic, so there is no foreign key on it;
+// triggers remove the links of deleted content.
+type ContentTag struct {
+	TagID       uint   `gorm:"primaryKey"`
+	ContentType string `gorm:"primaryKey;type:varchar(20)"`
+	ContentID   uint   `gorm:"primaryKey"`
+	Position    int    `gorm:"not null;default:0"`
+	CreatedAt   time.Time
+}
+
+// TableName specifies the table name for GORM
+func (ContentTag) TableName() string {
+	return "content_tags"
+}
+
+// CleanName trims a tag name and collapses runs of whitespace
+func CleanName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// Split reads a comma-separated tag list, dropping empty entries and any
+// that repeat an earlier one ignoring case
+func Split(list string) []string {
+	return Clean(strings.Split(list, ","))
+}
+
+// Clean tidies tag names, dropping empty entries and any that repeat an
+// earlier one ignoring case
+func Clean(names []string) []string {
+	cleaned := []string{}
+	seen := map[string]bool{}
+	for _, name := range names {
+		name = CleanName(name)
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		cleaned = append(cleaned, name)
+	}
+	return cleaned
+}
+
+// Join writes tag names back as the comma-separated list blogs and press
+// releases keep
+func Join(names []string) string {
+	return strings.Join(names, ", ")
+}
+
+// Names returns the names of tags in order
+func Names(tags []Tag) []string {
+	names := make([]string, len(tags))
+	for i, t := range tags {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// Usage counts the published content a tag is attached to
+type Usage struct {
+	Reports       int64 `json:"reports" example:"4"`
+	Blogs         int64 `json:"blogs" example:"2"`
+	PressReleases int64 `json:"press_releases" example:"1"`
+	Total         int64 `json:"total" example:"7"`
+}
+
+// Add counts n items of a content type
+func (u *Usage) Add(contentType string, n int64) {
+	switch contentType {
+	case ContentReport:
+		u.Reports += n
+	case ContentBlog:
+		u.Blogs += n
+	case ContentPressRelease:
+		u.PressReleases += n
+	default:
+		return
+	}
+	u.Total += n
+}
+
+// TagWithUsage is a tag with its usage counts
+type TagWithUsage struct {
+	Tag
+	Usage Usage `json:"usage"`
+}
+
+// TaggedContent is a published item carrying a tag
+type TaggedContent struct {
+	Type        string     `json:"type" example:"report"`
+	ID          uint       `json:"id" example:"12"`
+	Title       string     `json:"title" example:"Medical Imaging Market"`
+	Slug        string     `json:"slug" example:"medical-imaging-market"`
+	Excerpt     string     `json:"excerpt,omitempty"`
+	PublishDate *time.Time `json:"publish_date,omitempty"`
+}
+
+// TagDetail is a tag with a page of the published content carrying it
+type TagDetail struct {
+	TagWithUsage
+	Content []TaggedContent `json:"content"`
+}
+
+// ContentRef identifies one tagged item
+type ContentRef struct {
+	ContentType string
+	ContentID   uint
+}
+
+// CreateTagRequest is the request body for creating a tag
+type CreateTagRequest struct {
+	Name string `json:"name" validate:"required,max=100"`
+	Slug string `json:"slug,omitempty"`
+}
+
+// UpdateTagRequest is the request body for renaming a tag (partial updates supported)
+type UpdateTagRequest struct {
+	Name *string `json:"name,omitempty" validate:"omitempty,max=100"`
+	Slug *string `json:"slug,omitempty"`
+}
+
+// MergeTagsRequest is the request body for merging duplicate tags into one
+type MergeTagsRequest struct {
+	SourceIDs []uint `json:"source_ids"`
+}
+
+// MergeResult describes a completed merge
+type MergeResult struct {
+	Tag            *Tag   `json:"tag"`
+	MergedIDs      []uint `json:"merged_ids"`
+	ContentUpdated int    `json:"content_updated"`
+}
@@ -0,0 +1,32 @@
+package tag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplit(t *testing.T) {
+	assert.Equal(t, []string{"Digital Health", "AI", "telehealth"},
+		Split(" Digital   Health, AI,, ai ,telehealth, digital health "))
+	assert.Equal(t, []string{}, Split(""))
+}
+
+func TestJoin(t *testing.T) {
+	assert.Equal(t, "Digital Health, AI", Join([]string{"Digital Health", "AI"}))
+	assert.Equal(t, Split(Join([]string{"Digital Health", "AI"})), []string{"Digital Health", "AI"})
+}
+
+func TestUsageAdd(t *testing.T) {
+	var u Usage
+	u.Add(ContentReport, 3)
+	u.Add(ContentBlog, 2)
+	u.Add(ContentPressRelease, 1)
+	u.Add("whitepaper", 5)
+	assert.Equal(t, Usage{Reports: 3, Blogs: 2, PressReleases: 1, Total: 6}, u)
+}
+
+func TestValidContentType(t *testing.T) {
+	assert.True(t, ValidContentType(ContentPressRelease))
+	assert.False(t, ValidContentType("press-release"))
+}
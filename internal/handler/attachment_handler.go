@@ -0,0 +1,325 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/attachment"
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/middleware"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/healthcare-market-research/backend/pkg/response"
+	"github.com/healthcare-market-research/backend/pkg/validation"
+)
+
+// AttachmentHandler serves the attachment endpoints shared by blogs, press
+// releases and categories. Each method returns the handler for one owner type.
+type AttachmentHandler struct {
+	service      service.AttachmentService
+	auditService service.AuditService
+}
+
+func NewAttachmentHandler(service service.AttachmentService, auditService service.AuditService) *AttachmentHandler {
+	return &AttachmentHandler{
+		service:      service,
+		auditService: auditService,
+	}
+}
+
+// List godoc
+// @Summary List attachments
+// @Description Get the attachments of a blog, press release or category in display order. Requires admin or editor role.
+// @Tags Attachments
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Owner ID"
+// @Success 200 {object} response.Response{data=[]attachment.Attachment} "Attachments"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid ID"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Owner not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/blogs/{id}/attachments [get]
+// @Router /api/v1/press-releases/{id}/attachments [get]
+// @Router /api/v1/categories/{id}/attachments [get]
+func (h *AttachmentHandler) List(ownerType string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ownerID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+		if err != nil {
+			return response.BadRequest(c, "Invalid ID")
+		}
+
+		attachments, err := h.service.List(ownerType, uint(ownerID))
+		if err != nil {
+			return h.handleError(c, err, "Failed to fetch attachments")
+		}
+
+		return response.Success(c, attachments)
+	}
+}
+
+// Upload godoc
+// @Summary Upload attachment
+// @Description Attach an image (JPEG, PNG, WebP or GIF, max 10MB) to a blog, press release or category. Images get thumb, card, og and full variants. Press releases also accept PDF media kits (max 25MB). Attachments are appended after the existing ones; a featured image replaces the owner's current one. Requires admin or editor role.
+// @Tags Attachments
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Owner ID"
+// @Param file formData file true "Image or PDF file"
+// @Param caption formData string false "Caption (max 500 chars)"
+// @Param alt_text formData string false "Alt text (max 255 chars)"
+// @Param is_featured formData bool false "Make this the featured image"
+// @Success 201 {object} response.Response{data=attachment.Attachment} "Attachment created"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid file or input"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Owner not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/blogs/{id}/attachments [post]
+// @Router /api/v1/press-releases/{id}/attachments [post]
+// @Router /api/v1/categories/{id}/attachments [post]
+func (h *AttachmentHandler) Upload(ownerType string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ownerID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+		if err != nil {
+			return response.BadRequest(c, "Invalid ID")
+		}
+
+		file, err := c.FormFile("file")
+		if err != nil {
+			return response.BadRequest(c, "No file provided")
+		}
+		if validation.IsDocumentFile(file) {
+			err = validation.ValidateDocumentFile(file)
+		} else {
+			err = validation.ValidateImageFile(file)
+		}
+		if err != nil {
+			return response.BadRequest(c, err.Error())
+		}
+
+		req := attachment.UploadAttachmentRequest{
+			Caption: strings.TrimSpace(c.FormValue("caption")),
+			AltText: strings.TrimSpace(c.FormValue("alt_text")),
+		}
+		if featured := c.FormValue("is_featured"); featured != "" {
+			req.IsFeatured, err = strconv.ParseBool(featured)
+			if err != nil {
+				return response.BadRequest(c, "is_featured must be true or false")
+			}
+		}
+		if msg := validateImageText(&req.AltText, &req.Caption); msg != "" {
+			return response.BadRequest(c, msg)
+		}
+
+		var uploadedBy *uint
+		if userID, ok := c.Locals("userID").(uint); ok {
+			uploadedBy = &userID
+		}
+
+		a, err := h.service.Upload(ownerType, uint(ownerID), file, &req, uploadedBy)
+		if err != nil {
+			return h.handleError(c, err, "Failed to upload attachment")
+		}
+
+		entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionAttachmentCreate)
+		entry.EntityType = audit.EntityAttachment
+		entry.EntityID = &a.ID
+		entry.Changes = audit.Changes{
+			"owner":       {New: ownerType + ":" + strconv.FormatUint(ownerID, 10)},
+			"url":         {New: a.URL},
+			"is_featured": {New: a.IsFeatured},
+		}
+		h.auditService.LogAsync(entry)
+
+		return c.Status(fiber.StatusCreated).JSON(response.Response{
+			Success: true,
+			Data:    a,
+		})
+	}
+}
+
+// Update godoc
+// @Summary Update attachment
+// @Description Edit the caption and alt text of an attachment or change whether it is the featured image. Only images can be featured. Requires admin or editor role.
+// @Tags Attachments
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Owner ID"
+// @Param attachmentId path int true "Attachment ID"
+// @Param request body attachment.UpdateAttachmentRequest true "Fields to update"
+// @Success 200 {object} response.Response{data=attachment.Attachment} "Updated attachment"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid input"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Attachment not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/blogs/{id}/attachments/{attachmentId} [put]
+// @Router /api/v1/press-releases/{id}/attachments/{attachmentId} [put]
+// @Router /api/v1/categories/{id}/attachments/{attachmentId} [put]
+func (h *AttachmentHandler) Update(ownerType string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ownerID, attachmentID, msg := parseAttachmentParams(c)
+		if msg != "" {
+			return response.BadRequest(c, msg)
+		}
+
+		var req attachment.UpdateAttachmentRequest
+		if err := c.BodyParser(&req); err != nil {
+			return response.BadRequest(c, "Invalid request body: "+err.Error())
+		}
+		if msg := validateImageText(req.AltText, req.Caption); msg != "" {
+			return response.BadRequest(c, msg)
+		}
+
+		a, err := h.service.Update(ownerType, ownerID, attachmentID, &req)
+		if err != nil {
+			return h.handleError(c, err, "Failed to update attachment")
+		}
+
+		changes := audit.Changes{}
+		if req.Caption != nil {
+			changes["caption"] = audit.FieldChange{New: a.Caption}
+		}
+		if req.AltText != nil {
+			changes["alt_text"] = audit.FieldChange{New: a.AltText}
+		}
+		if req.IsFeatured != nil {
+			changes["is_featured"] = audit.FieldChange{New: a.IsFeatured}
+		}
+		entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionAttachmentUpdate)
+		entry.EntityType = audit.EntityAttachment
+		entry.EntityID = &a.ID
+		entry.Changes = changes
+		h.auditService.LogAsync(entry)
+
+		return response.Success(c, a)
+	}
+}
+
+// Reorder godoc
+// @Summary Reorder attachments
+// @Description Set the display order of an owner's attachments. The IDs must list every attachment exactly once. Requires admin or editor role.
+// @Tags Attachments
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Owner ID"
+// @Param request body attachment.ReorderAttachmentsRequest true "Attachment IDs in their new order"
+// @Success 200 {object} response.Response{data=[]attachment.Attachment} "Attachments in their new order"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid or incomplete order"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Owner not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/blogs/{id}/attachments/reorder [patch]
+// @Router /api/v1/press-releases/{id}/attachments/reorder [patch]
+// @Router /api/v1/categories/{id}/attachments/reorder [patch]
+func (h *AttachmentHandler) Reorder(ownerType string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ownerID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+		if err != nil {
+			return response.BadRequest(c, "Invalid ID")
+		}
+
+		var req attachment.ReorderAttachmentsRequest
+		if err := c.BodyParser(&req); err != nil {
+			return response.BadRequest(c, "Invalid request body: "+err.Error())
+		}
+
+		attachments, err := h.service.Reorder(ownerType, uint(ownerID), req.IDs)
+		if err != nil {
+			return h.handleError(c, err, "Failed to reorder attachments")
+		}
+
+		auditCtx := middleware.GetAuditContext(c)
+		for i := range attachments {
+			a := attachments[i]
+			entry := middleware.NewAuditEntry(auditCtx, audit.ActionAttachmentReorder)
+			entry.EntityType = audit.EntityAttachment
+			entry.EntityID = &a.ID
+			entry.Changes = audit.Changes{
+				"sort_order": {Old: nil, New: a.SortOrder},
+			}
+			h.auditService.LogAsync(entry)
+		}
+
+		return response.Success(c, attachments)
+	}
+}
+
+// Delete godoc
+// @Summary Delete attachment
+// @Description Remove an attachment and delete its files from storage. Requires admin or editor role.
+// @Tags Attachments
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Owner ID"
+// @Param attachmentId path int true "Attachment ID"
+// @Success 200 {object} response.Response{data=map[string]string} "Attachment deleted"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid ID"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Attachment not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/blogs/{id}/attachments/{attachmentId} [delete]
+// @Router /api/v1/press-releases/{id}/attachments/{attachmentId} [delete]
+// @Router /api/v1/categories/{id}/attachments/{attachmentId} [delete]
+func (h *AttachmentHandler) Delete(ownerType string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ownerID, attachmentID, msg := parseAttachmentParams(c)
+		if msg != "" {
+			return response.BadRequest(c, msg)
+		}
+
+		a, err := h.service.Delete(ownerType, ownerID, attachmentID)
+		if err != nil {
+			return h.handleError(c, err, "Failed to delete attachment")
+		}
+
+		entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionAttachmentDelete)
+		entry.EntityType = audit.EntityAttachment
+		entry.EntityID = &a.ID
+		entry.Changes = audit.Changes{
+			"url": {Old: a.URL, New: nil},
+		}
+		h.auditService.LogAsync(entry)
+
+		return response.Success(c, fiber.Map{
+			"message": "Attachment deleted successfully",
+		})
+	}
+}
+
+func (h *AttachmentHandler) handleError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, service.ErrAttachmentOwnerNotFound):
+		return response.NotFound(c, "Owner not found")
+	case errors.Is(err, service.ErrAttachmentNotFound):
+		return response.NotFound(c, "Attachment not found")
+	case errors.Is(err, service.ErrDocumentsNotAllowed),
+		errors.Is(err, service.ErrOnlyImagesFeatured),
+		errors.Is(err, service.ErrInvalidAttachmentOrder):
+		return response.BadRequest(c, err.Error())
+	default:
+		return response.InternalError(c, fallback)
+	}
+}
+
+// parseAttachmentParams reads the owner and attachment IDs, returning a
+// message for the first invalid one
+func parseAttachmentParams(c *fiber.Ctx) (uint, uint, string) {
+	ownerID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return 0, 0, "Invalid ID"
+	}
+	attachmentID, err := strconv.ParseUint(c.Params("attachmentId"), 10, 32)
+	if err != nil {
+		return 0, 0, "Invalid attachment ID"
+	}
+	return uint(ownerID), uint(attachmentID), ""
+}
@@ -0,0 +1,245 @@
+package handler
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/attachment"
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAttachmentService is a mock implementation of AttachmentService
+type MockAttachmentService struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentService) List(ownerType string, ownerID uint) ([]attachment.Attachment, error) {
+	args := m.Called(ownerType, ownerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]attachment.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentService) Upload(ownerType string, ownerID uint, file *multipart.FileHeader, req *attachment.UploadAttachmentRequest, uploadedBy *uint) (*attachment.Attachment, error) {
+	args := m.Called(ownerType, ownerID, file, req, uploadedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*attachment.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentService) Update(ownerType string, ownerID, id uint, req *attachment.UpdateAttachmentRequest) (*attachment.Attachment, error) {
+	args := m.Called(ownerType, ownerID, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*attachment.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentService) Reorder(ownerType string, ownerID uint, ids []uint) ([]attachment.Attachment, error) {
+	args := m.Called(ownerType, ownerID, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]attachment.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentService) Delete(ownerType string, ownerID, id uint) (*attachment.Attachment, error) {
+	args := m.Called(ownerType, ownerID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*attachment.Attachment), args.Error(1)
+}
+
+func setupAttachmentTestApp(handler *AttachmentHandler) *fiber.App {
+	app := fiber.New()
+
+	for prefix, ownerType := range map[string]string{
+		"/api/v1/blogs/:id/attachments":          attachment.OwnerBlog,
+		"/api/v1/press-releases/:id/attachments": attachment.OwnerPressRelease,
+	} {
+		app.Post(prefix, handler.Upload(ownerType))
+		app.Patch(prefix+"/reorder", handler.Reorder(ownerType))
+		app.Put(prefix+"/:attachmentId", handler.Update(ownerType))
+		app.Delete(prefix+"/:attachmentId", handler.Delete(ownerType))
+	}
+
+	return app
+}
+
+func multipartRequest(url, filename string, content []byte, fields map[string]string) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", filename)
+	part.Write(content)
+	for key, value := range fields {
+		writer.WriteField(key, value)
+	}
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, url, body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestAttachmentHandler_Upload(t *testing.T) {
+	pdf := []byte("%PDF-1.7\n%media kit\n")
+
+	t.Run("Successfully upload media kit", func(t *testing.T) {
+		mockService := new(MockAttachmentService)
+		auditService := &MockAuditService{}
+		app := setupAttachmentTestApp(NewAttachmentHandler(mockService, auditService))
+
+		mockService.On("Upload", attachment.OwnerPressRelease, uint(7), mock.Anything,
+			&attachment.UploadAttachmentRequest{Caption: "Media kit"}, (*uint)(nil)).
+			Return(&attachment.Attachment{ID: 1, Kind: attachment.KindDocument, URL: "http://localhost/kit.pdf"}, nil).Once()
+
+		resp, err := app.Test(multipartRequest("/api/v1/press-releases/7/attachments", "kit.pdf", pdf,
+			map[string]string{"caption": "  Media kit "}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+		assert.Len(t, auditService.entries, 1)
+		assert.Equal(t, audit.ActionAttachmentCreate, auditService.entries[0].Action)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Fail with invalid PDF", func(t *testing.T) {
+		mockService := new(MockAttachmentService)
+		app := setupAttachmentTestApp(NewAttachmentHandler(mockService, &MockAuditService{}))
+
+		resp, err := app.Test(multipartRequest("/api/v1/press-releases/7/attachments", "kit.pdf", []byte("not a pdf"), nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		mockService.AssertNotCalled(t, "Upload")
+	})
+
+	t.Run("Fail with invalid featured flag", func(t *testing.T) {
+		mockService := new(MockAttachmentService)
+		app := setupAttachmentTestApp(NewAttachmentHandler(mockService, &MockAuditService{}))
+
+		resp, err := app.Test(multipartRequest("/api/v1/press-releases/7/attachments", "kit.pdf", pdf,
+			map[string]string{"is_featured": "maybe"}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("Fail when owner does not accept documents", func(t *testing.T) {
+		mockService := new(MockAttachmentService)
+		app := setupAttachmentTestApp(NewAttachmentHandler(mockService, &MockAuditService{}))
+
+		mockService.On("Upload", attachment.OwnerBlog, uint(3), mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, service.ErrDocumentsNotAllowed).Once()
+
+		resp, err := app.Test(multipartRequest("/api/v1/blogs/3/attachments", "kit.pdf", pdf, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("Fail when owner is missing", func(t *testing.T) {
+		mockService := new(MockAttachmentService)
+		app := setupAttachmentTestApp(NewAttachmentHandler(mockService, &MockAuditService{}))
+
+		mockService.On("Upload", attachment.OwnerBlog, uint(99), mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, service.ErrAttachmentOwnerNotFound).Once()
+
+		resp, err := app.Test(multipartRequest("/api/v1/blogs/99/attachments", "kit.pdf", pdf, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestAttachmentHandler_Update(t *testing.T) {
+	t.Run("Successfully feature image", func(t *testing.T) {
+		mockService := new(MockAttachmentService)
+		auditService := &MockAuditService{}
+		app := setupAttachmentTestApp(NewAttachmentHandler(mockService, auditService))
+
+		featured := true
+		mockService.On("Update", attachment.OwnerBlog, uint(3), uint(5), &attachment.UpdateAttachmentRequest{IsFeatured: &featured}).
+			Return(&attachment.Attachment{ID: 5, IsFeatured: true}, nil).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPut, "/api/v1/blogs/3/attachments/5", map[string]interface{}{"is_featured": true}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Len(t, auditService.entries, 1)
+		assert.Contains(t, auditService.entries[0].Changes, "is_featured")
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Fail with long alt text", func(t *testing.T) {
+		mockService := new(MockAttachmentService)
+		app := setupAttachmentTestApp(NewAttachmentHandler(mockService, &MockAuditService{}))
+
+		resp, err := app.Test(jsonRequest(http.MethodPut, "/api/v1/blogs/3/attachments/5",
+			map[string]interface{}{"alt_text": string(bytes.Repeat([]byte("a"), 256))}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		mockService.AssertNotCalled(t, "Update")
+	})
+
+	t.Run("Fail when attachment belongs to another owner", func(t *testing.T) {
+		mockService := new(MockAttachmentService)
+		app := setupAttachmentTestApp(NewAttachmentHandler(mockService, &MockAuditService{}))
+
+		mockService.On("Update", attachment.OwnerBlog, uint(3), uint(6), mock.Anything).
+			Return(nil, service.ErrAttachmentNotFound).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPut, "/api/v1/blogs/3/attachments/6", map[string]interface{}{"caption": "x"}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestAttachmentHandler_Reorder(t *testing.T) {
+	t.Run("Successfully reorder", func(t *testing.T) {
+		mockService := new(MockAttachmentService)
+		auditService := &MockAuditService{}
+		app := setupAttachmentTestApp(NewAttachmentHandler(mockService, auditService))
+
+		mockService.On("Reorder", attachment.OwnerBlog, uint(3), []uint{2, 1}).
+			Return([]attachment.Attachment{{ID: 2, SortOrder: 0}, {ID: 1, SortOrder: 1}}, nil).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPatch, "/api/v1/blogs/3/attachments/reorder", map[string]interface{}{"ids": []uint{2, 1}}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Len(t, auditService.entries, 2)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Fail with incomplete order", func(t *testing.T) {
+		mockService := new(MockAttachmentService)
+		app := setupAttachmentTestApp(NewAttachmentHandler(mockService, &MockAuditService{}))
+
+		mockService.On("Reorder", attachment.OwnerBlog, uint(3), []uint{2}).
+			Return(nil, service.ErrInvalidAttachmentOrder).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPatch, "/api/v1/blogs/3/attachments/reorder", map[string]interface{}{"ids": []uint{2}}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestAttachmentHandler_Delete(t *testing.T) {
+	mockService := new(MockAttachmentService)
+	auditService := &MockAuditService{}
+	app := setupAttachmentTestApp(NewAttachmentHandler(mockService, auditService))
+
+	mockService.On("Delete", attachment.OwnerPressRelease, uint(7), uint(2)).
+		Return(&attachment.Attachment{ID: 2, URL: "http://localhost/kit.pdf"}, nil).Once()
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/api/v1/press-releases/7/attachments/2", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Len(t, auditService.entries, 1)
+	assert.Equal(t, audit.ActionAttachmentDelete, auditService.entries[0].Action)
+	mockService.AssertExpectations(t)
+}
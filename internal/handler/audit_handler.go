@@ -1,13 +1,13 @@
 package handler
 
 import (
-	"math"
 	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/healthcare-market-research/backend/internal/domain/audit"
 	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/healthcare-market-research/backend/pkg/pagination"
 	"github.com/healthcare-market-research/backend/pkg/response"
 )
 
@@ -37,25 +37,24 @@ func NewAuditHandler(auditService service.AuditService) *AuditHandler {
 // @Param start_date query string false "Filter by start date (RFC3339 format)"
 // @Param end_date query string false "Filter by end date (RFC3339 format)"
 // @Param ip_address query string false "Filter by IP address"
+// @Param cursor query string false "Opaque cursor from meta.cursor of the previous page; page is ignored when set"
+// @Param count query bool false "Set to false to skip computing total and total_pages"
 // @Success 200 {object} response.Response{data=[]audit.AuditLogResponse}
 // @Failure 401 {object} response.Response{error=string}
 // @Failure 403 {object} response.Response{error=string}
 // @Router /api/v1/audit-logs [get]
 func (h *AuditHandler) GetAll(c *fiber.Ctx) error {
 	// Parse pagination parameters
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "20"))
-
-	if page < 1 {
-		page = 1
-	}
-	if limit < 1 || limit > 100 {
-		limit = 20
+	params, err := parsePagination(c)
+	if err != nil {
+		return response.BadRequest(c, err.Error())
 	}
 
 	filters := audit.AuditLogFilters{
-		Page:  page,
-		Limit: limit,
+		Page:      params.Page,
+		Limit:     params.Limit,
+		Cursor:    params.Cursor,
+		SkipCount: params.SkipCount,
 	}
 
 	// Parse optional filters
@@ -107,14 +106,13 @@ func (h *AuditHandler) GetAll(c *fiber.Ctx) error {
 		return response.InternalError(c, "Failed to retrieve audit logs")
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(limit)))
+	var nextCursor string
+	if len(logs) > 0 {
+		last := logs[len(logs)-1]
+		nextCursor = pagination.Next(len(logs), params.Limit, last.CreatedAt, last.ID)
+	}
 
-	return response.SuccessWithMeta(c, logs, &response.Meta{
-		Page:       page,
-		Limit:      limit,
-		Total:      total,
-		TotalPages: totalPages,
-	})
+	return response.SuccessWithMeta(c, logs, paginationMeta(params, total, nextCursor))
 }
 
 // GetByID godoc
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http/httptest"
+	"net/textproto"
 	"testing"
 
 	"github.com/gofiber/fiber/v2"
@@ -14,6 +16,17 @@ import (
 	"github.com/healthcare-market-research/backend/pkg/response"
 )
 
+// createJPEGPart adds an image part with a JPEG content type and magic
+// bytes, enough to pass upload validation
+func createJPEGPart(writer *multipart.Writer, filename string) io.Writer {
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="image"; filename="%s"`, filename))
+	header.Set("Content-Type", "image/jpeg")
+	part, _ := writer.CreatePart(header)
+	part.Write([]byte{0xFF, 0xD8, 0xFF, 0xE0})
+	return part
+}
+
 // Mock AuthorService for testing
 type mockAuthorService struct {
 	getAllFunc      func(page, limit int, search string) ([]author.Author, int64, error)
@@ -102,7 +115,7 @@ func TestAuthorHandler_UploadImage_Success(t *testing.T) {
 	// Create multipart form with image file
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
-	part, _ := writer.CreateFormFile("image", "test.jpg")
+	part := createJPEGPart(writer, "test.jpg")
 	part.Write([]byte("fake image content"))
 	writer.Close()
 
@@ -135,7 +148,7 @@ func TestAuthorHandler_UploadImage_InvalidID(t *testing.T) {
 
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
-	part, _ := writer.CreateFormFile("image", "test.jpg")
+	part := createJPEGPart(writer, "test.jpg")
 	part.Write([]byte("fake image content"))
 	writer.Close()
 
@@ -193,7 +206,7 @@ func TestAuthorHandler_UploadImage_AuthorNotFound(t *testing.T) {
 
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
-	part, _ := writer.CreateFormFile("image", "test.jpg")
+	part := createJPEGPart(writer, "test.jpg")
 	part.Write([]byte("fake image content"))
 	writer.Close()
 
@@ -223,7 +236,7 @@ func TestAuthorHandler_UploadImage_ServiceError(t *testing.T) {
 
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
-	part, _ := writer.CreateFormFile("image", "test.jpg")
+	part := createJPEGPart(writer, "test.jpg")
 	part.Write([]byte("fake image content"))
 	writer.Close()
 
@@ -430,7 +443,7 @@ func TestAuthorHandler_UploadImage_MultipleFiles(t *testing.T) {
 	writer := multipart.NewWriter(body)
 
 	// Add first image file
-	part1, _ := writer.CreateFormFile("image", "test1.jpg")
+	part1 := createJPEGPart(writer, "test1.jpg")
 	part1.Write([]byte("fake image content 1"))
 
 	// Add second file (different field name)
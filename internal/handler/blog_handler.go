@@ -1,22 +1,29 @@
 package handler
 
 import (
+	"errors"
 	"math"
 	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/healthcare-market-research/backend/internal/domain/blog"
+	"github.com/healthcare-market-research/backend/internal/domain/seo"
+	"github.com/healthcare-market-research/backend/internal/domain/tag"
+	"github.com/healthcare-market-research/backend/internal/repository"
 	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/healthcare-market-research/backend/pkg/pagination"
 	"github.com/healthcare-market-research/backend/pkg/response"
 )
 
 type BlogHandler struct {
 	service service.BlogService
+	jsonld  *seo.JSONLDBuilder
+	related service.RelatedService
 }
 
-func NewBlogHandler(service service.BlogService) *BlogHandler {
-	return &BlogHandler{service: service}
+func NewBlogHandler(service service.BlogService, jsonld *seo.JSONLDBuilder, related service.RelatedService) *BlogHandler {
+	return &BlogHandler{service: service, jsonld: jsonld, related: related}
 }
 
 // Create godoc
@@ -52,24 +59,22 @@ func (h *BlogHandler) Create(c *fiber.Ctx) error {
 // @Produce json
 // @Param status query string false "Filter by status: draft, review, published"
 // @Param categoryId query int false "Filter by category ID"
-// @Param tags query string false "Filter by tags (comma-separated)"
+// @Param tags query string false "Filter by tag names or slugs (comma-separated); matches items with any of them"
 // @Param authorId query int false "Filter by author ID"
 // @Param location query string false "Filter by location"
 // @Param search query string false "Search in title, excerpt, content"
 // @Param page query int false "Page number (default: 1, min: 1)"
 // @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Param cursor query string false "Opaque cursor from the previous page; page is ignored when set (not available with search)"
+// @Param count query bool false "Set to false to skip computing total and totalPages"
 // @Success 200 {object} blog.BlogListResponse "List of blogs with pagination"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid cursor"
 // @Failure 500 {object} response.Response{error=string} "Internal server error"
 // @Router /api/v1/blogs [get]
 func (h *BlogHandler) GetAll(c *fiber.Ctx) error {
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "20"))
-
-	if page < 1 {
-		page = 1
-	}
-	if limit < 1 || limit > 100 {
-		limit = 20
+	params, err := parsePagination(c)
+	if err != nil {
+		return response.BadRequest(c, err.Error())
 	}
 
 	query := blog.GetBlogsQuery{
@@ -80,8 +85,15 @@ func (h *BlogHandler) GetAll(c *fiber.Ctx) error {
 		Location:   c.Query("location", ""),
 		Search:     c.Query("search", ""),
 		Deleted:    c.Query("deleted", ""),
-		Page:       page,
-		Limit:      limit,
+		Page:       params.Page,
+		Limit:      params.Limit,
+		Cursor:     params.Cursor,
+		SkipCount:  params.SkipCount,
+	}
+
+	// Search results are ranked by relevance, which has no stable keyset
+	if query.Search != "" && query.Cursor != nil {
+		return response.BadRequest(c, "Cursor pagination is not supported with search")
 	}
 
 	blogs, total, err := h.service.GetAll(query)
@@ -89,15 +101,24 @@ func (h *BlogHandler) GetAll(c *fiber.Ctx) error {
 		return response.InternalError(c, "Failed to fetch blogs")
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(limit)))
+	resp := blog.BlogListResponse{
+		Blogs: blogs,
+		Limit: params.Limit,
+	}
+	if params.Cursor == nil {
+		resp.Page = params.Page
+	}
+	if total >= 0 {
+		totalPages := int(math.Ceil(float64(total) / float64(params.Limit)))
+		resp.Total = &total
+		resp.TotalPages = &totalPages
+	}
+	if query.Search == "" && len(blogs) > 0 {
+		last := blogs[len(blogs)-1]
+		resp.Cursor = pagination.Next(len(blogs), params.Limit, last.CreatedAt, last.ID)
+	}
 
-	return c.JSON(blog.BlogListResponse{
-		Blogs:      blogs,
-		Total:      total,
-		Page:       page,
-		Limit:      limit,
-		TotalPages: totalPages,
-	})
+	return c.JSON(resp)
 }
 
 // GetByID godoc
@@ -128,17 +149,19 @@ func (h *BlogHandler) GetByID(c *fiber.Ctx) error {
 		return response.NotFound(c, "Blog not found")
 	}
 
+	setETag(c, b.Version)
 	return c.JSON(blog.BlogResponse{Blog: *b})
 }
 
 // GetBySlug godoc
 // @Summary Get blog by slug
-// @Description Get a single blog post by slug
+// @Description Get a single blog post by slug, with its schema.org BlogPosting in jsonLd. related lists up to six published reports, blogs and press releases: curated ones first, then suggestions.
 // @Tags Blogs
 // @Accept json
 // @Produce json
 // @Param slug path string true "Blog slug"
 // @Success 200 {object} blog.BlogResponse "Blog details"
+// @Failure 301 {object} response.Response{data=redirect.Target,error=string} "Slug was retired - Location points at the current URL"
 // @Failure 400 {object} response.Response{error=string} "Bad request - invalid slug"
 // @Failure 404 {object} response.Response{error=string} "Blog not found"
 // @Failure 500 {object} response.Response{error=string} "Internal server error"
@@ -151,23 +174,35 @@ func (h *BlogHandler) GetBySlug(c *fiber.Ctx) error {
 
 	b, err := h.service.GetBySlug(slug)
 	if err != nil {
+		var moved *service.SlugMovedError
+		if errors.As(err, &moved) {
+			return redirectTo(c, moved.Target)
+		}
 		return response.NotFound(c, "Blog not found")
 	}
 
-	return c.JSON(blog.BlogResponse{Blog: *b})
+	setETag(c, b.Version)
+	resp := blog.BlogResponse{Blog: *b, Related: relatedItems(h.related, tag.ContentBlog, b.ID)}
+	if h.jsonld != nil {
+		resp.JSONLD = h.jsonld.Blog(b)
+	}
+	return c.JSON(resp)
 }
 
 // Update godoc
 // @Summary Update blog
-// @Description Update an existing blog post
+// @Description Update an existing blog post. Send the version being edited as an If-Match header (the ETag from a read) or a version field; a stale version gets 409 with the current copy.
 // @Tags Blogs
 // @Accept json
 // @Produce json
 // @Param id path int true "Blog ID"
+// @Param If-Match header string false "ETag of the blog version being edited"
 // @Param blog body blog.UpdateBlogRequest true "Blog update data"
 // @Success 200 {object} blog.BlogResponse "Blog updated successfully"
 // @Failure 400 {object} response.Response{error=string} "Bad request - invalid input or validation error"
 // @Failure 404 {object} response.Response{error=string} "Blog not found"
+// @Failure 409 {object} response.Response{data=blog.Blog,error=string} "Blog was modified by another user"
+// @Failure 428 {object} response.Response{error=string} "If-Match header or version field is required"
 // @Failure 500 {object} response.Response{error=string} "Internal server error"
 // @Router /api/v1/blogs/{id} [put]
 func (h *BlogHandler) Update(c *fiber.Ctx) error {
@@ -186,14 +221,33 @@ func (h *BlogHandler) Update(c *fiber.Ctx) error {
 		return response.BadRequest(c, "Invalid request body: "+err.Error())
 	}
 
+	// The edit must name the version it was based on
+	version, ok := requestVersion(c, req.Version)
+	if !ok {
+		return response.BadRequest(c, "Invalid If-Match header")
+	}
+	if version == nil {
+		return versionRequired(c)
+	}
+	req.Version = version
+
 	b, err := h.service.Update(uint(id), &req)
 	if err != nil {
 		if err.Error() == "record not found" {
 			return response.NotFound(c, "Blog not found")
 		}
+		if errors.Is(err, repository.ErrVersionConflict) {
+			current, err := h.service.GetByID(uint(id))
+			if err != nil {
+				return response.InternalError(c, "Failed to load current blog")
+			}
+			setETag(c, current.Version)
+			return response.Conflict(c, "Blog was modified by another user", current)
+		}
 		return response.BadRequest(c, err.Error())
 	}
 
+	setETag(c, b.Version)
 	return c.JSON(blog.BlogResponse{Blog: *b})
 }
 
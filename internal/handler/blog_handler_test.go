@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/blog"
+	"github.com/healthcare-market-research/backend/internal/domain/seo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockBlogService is a mock implementation of BlogService
+type MockBlogService struct {
+	mock.Mock
+}
+
+func (m *MockBlogService) Create(req *blog.CreateBlogRequest) (*blog.Blog, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*blog.Blog), args.Error(1)
+}
+
+func (m *MockBlogService) GetAll(query blog.GetBlogsQuery) ([]blog.Blog, int64, error) {
+	args := m.Called(query)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]blog.Blog), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockBlogService) GetByID(id uint) (*blog.Blog, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*blog.Blog), args.Error(1)
+}
+
+func (m *MockBlogService) GetBySlug(slug string) (*blog.Blog, error) {
+	args := m.Called(slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*blog.Blog), args.Error(1)
+}
+
+func (m *MockBlogService) Update(id uint, req *blog.UpdateBlogRequest) (*blog.Blog, error) {
+	args := m.Called(id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*blog.Blog), args.Error(1)
+}
+
+func (m *MockBlogService) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockBlogService) SoftDelete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockBlogService) Restore(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockBlogService) SubmitForReview(id uint) (*blog.Blog, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*blog.Blog), args.Error(1)
+}
+
+func (m *MockBlogService) Publish(id uint) (*blog.Blog, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*blog.Blog), args.Error(1)
+}
+
+func (m *MockBlogService) Unpublish(id uint) (*blog.Blog, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*blog.Blog), args.Error(1)
+}
+
+func (m *MockBlogService) SchedulePublish(id uint, publishDate time.Time) (*blog.Blog, error) {
+	args := m.Called(id, publishDate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*blog.Blog), args.Error(1)
+}
+
+func (m *MockBlogService) CancelScheduledPublish(id uint) (*blog.Blog, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*blog.Blog), args.Error(1)
+}
+
+// jsonLDBody is the part of a blog or press release read that holds its
+// structured data
+type jsonLDBody struct {
+	JSONLD struct {
+		Context string                   `json:"@context"`
+		Graph   []map[string]interface{} `json:"@graph"`
+	} `json:"jsonLd"`
+}
+
+func TestBlogHandler_GetBySlug_JSONLD(t *testing.T) {
+	mockService := new(MockBlogService)
+	app := fiber.New()
+	h := NewBlogHandler(mockService, seo.NewJSONLDBuilder("https://example.com", "HMR"), nil)
+	app.Get("/api/v1/blogs/slug/:slug", h.GetBySlug)
+	app.Get("/api/v1/blogs/:id", h.GetByID)
+
+	post := &blog.Blog{ID: 4, Slug: "wearables-2026", Title: "Wearables in 2026", Excerpt: "What changed"}
+	mockService.On("GetBySlug", "wearables-2026").Return(post, nil).Once()
+	mockService.On("GetByID", uint(4)).Return(post, nil).Once()
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/blogs/slug/wearables-2026", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result jsonLDBody
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	assert.NoError(t, json.Unmarshal(bodyBytes, &result))
+	assert.Equal(t, seo.SchemaContext, result.JSONLD.Context)
+	assert.Len(t, result.JSONLD.Graph, 1)
+	assert.Equal(t, "BlogPosting", result.JSONLD.Graph[0]["@type"])
+	assert.Equal(t, "https://example.com/blogs/wearables-2026", result.JSONLD.Graph[0]["url"])
+
+	// The admin read by ID has no structured data
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/blogs/4", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	bodyBytes, _ = io.ReadAll(resp.Body)
+	assert.NotContains(t, string(bodyBytes), "@context")
+	mockService.AssertExpectations(t)
+}
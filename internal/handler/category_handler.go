@@ -1,34 +1,52 @@
 package handler
 
 import (
+	"errors"
 	"math"
 	"strconv"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/domain/category"
+	"github.com/healthcare-market-research/backend/internal/middleware"
 	"github.com/healthcare-market-research/backend/internal/service"
 	"github.com/healthcare-market-research/backend/pkg/response"
+	"github.com/healthcare-market-research/backend/pkg/validation"
 )
 
 type CategoryHandler struct {
-	service service.CategoryService
+	service      service.CategoryService
+	auditService service.AuditService
 }
 
-func NewCategoryHandler(service service.CategoryService) *CategoryHandler {
-	return &CategoryHandler{service: service}
+func NewCategoryHandler(service service.CategoryService, auditService service.AuditService) *CategoryHandler {
+	return &CategoryHandler{
+		service:      service,
+		auditService: auditService,
+	}
 }
 
 // GetAll godoc
 // @Summary Get all categories
-// @Description Get a paginated list of all active healthcare report categories
+// @Description Get a paginated list of all active healthcare report categories. Pass tree=true to get every active category nested under its parent instead.
 // @Tags Categories
 // @Accept json
 // @Produce json
+// @Param tree query bool false "Return the full category hierarchy as a tree (pagination is ignored)"
 // @Param page query int false "Page number (default: 1, min: 1)"
 // @Param limit query int false "Items per page (default: 20, max: 100)"
 // @Success 200 {object} response.Response{data=[]category.Category,meta=response.Meta} "List of categories with pagination metadata"
 // @Failure 500 {object} response.Response{error=string} "Internal server error"
 // @Router /api/v1/categories [get]
 func (h *CategoryHandler) GetAll(c *fiber.Ctx) error {
+	if c.Query("tree") == "true" {
+		tree, err := h.service.GetTree()
+		if err != nil {
+			return response.InternalError(c, "Failed to fetch categories")
+		}
+		return response.Success(c, tree)
+	}
+
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 	limit, _ := strconv.Atoi(c.Query("limit", "20"))
 
@@ -58,12 +76,13 @@ func (h *CategoryHandler) GetAll(c *fiber.Ctx) error {
 
 // GetBySlug godoc
 // @Summary Get category by slug
-// @Description Get a single healthcare report category by its unique slug
+// @Description Get a single healthcare report category by its unique slug, including its direct subcategories
 // @Tags Categories
 // @Accept json
 // @Produce json
 // @Param slug path string true "Category slug"
 // @Success 200 {object} response.Response{data=category.Category} "Category details"
+// @Failure 301 {object} response.Response{data=redirect.Target,error=string} "Slug was retired - Location points at the current URL"
 // @Failure 400 {object} response.Response{error=string} "Bad request - slug is required"
 // @Failure 404 {object} response.Response{error=string} "Category not found"
 // @Router /api/v1/categories/{slug} [get]
@@ -75,8 +94,323 @@ func (h *CategoryHandler) GetBySlug(c *fiber.Ctx) error {
 
 	category, err := h.service.GetBySlug(slug)
 	if err != nil {
+		var moved *service.SlugMovedError
+		if errors.As(err, &moved) {
+			return redirectTo(c, moved.Target)
+		}
 		return response.NotFound(c, "Category not found")
 	}
 
 	return response.Success(c, category)
 }
+
+// Create godoc
+// @Summary Create category
+// @Description Create a new category. Set parent_id to nest it under an existing category. The slug is generated from the name when omitted. Requires admin or editor role.
+// @Tags Categories
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param category body category.CreateCategoryRequest true "Category data"
+// @Success 201 {object} response.Response{data=category.Category} "Created category"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid input or slug already in use"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/categories [post]
+func (h *CategoryHandler) Create(c *fiber.Ctx) error {
+	var req category.CreateCategoryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body: "+err.Error())
+	}
+
+	if req.Name == "" {
+		return response.BadRequest(c, "Name is required")
+	}
+
+	cat, err := h.service.Create(&req)
+	if err != nil {
+		return h.handleWriteError(c, err, "Failed to create category")
+	}
+
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionCategoryCreate)
+	entry.EntityType = audit.EntityCategory
+	entry.EntityID = &cat.ID
+	h.auditService.LogAsync(entry)
+
+	return c.Status(fiber.StatusCreated).JSON(response.Response{
+		Success: true,
+		Data:    cat,
+	})
+}
+
+// Update godoc
+// @Summary Update category
+// @Description Update an existing category by ID. Supports partial updates, moving the category under a new parent (parent_id) or back to the root (clear_parent). Requires admin or editor role.
+// @Tags Categories
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Category ID"
+// @Param category body category.UpdateCategoryRequest true "Fields to update"
+// @Success 200 {object} response.Response{data=category.Category} "Updated category"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid input, slug in use or hierarchy cycle"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Category not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/categories/{id} [put]
+func (h *CategoryHandler) Update(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid category ID")
+	}
+
+	var req category.UpdateCategoryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body: "+err.Error())
+	}
+
+	before, err := h.service.GetByID(uint(id))
+	if err != nil {
+		return response.NotFound(c, "Category not found")
+	}
+	snapshot := *before
+
+	cat, err := h.service.Update(uint(id), &req)
+	if err != nil {
+		return h.handleWriteError(c, err, "Failed to update category")
+	}
+
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionCategoryUpdate)
+	entry.EntityType = audit.EntityCategory
+	entry.EntityID = &cat.ID
+	entry.Changes = categoryChanges(&snapshot, cat)
+	h.auditService.LogAsync(entry)
+
+	return response.Success(c, cat)
+}
+
+// Deactivate godoc
+// @Summary Deactivate category
+// @Description Deactivate a category so it no longer appears in public listings. Categories with active subcategories must have them moved or deactivated first. Requires admin or editor role.
+// @Tags Categories
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Category ID"
+// @Success 200 {object} response.Response{data=category.Category} "Deactivated category"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid ID or category has active subcategories"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Category not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/categories/{id}/deactivate [patch]
+func (h *CategoryHandler) Deactivate(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid category ID")
+	}
+
+	cat, err := h.service.Deactivate(uint(id))
+	if err != nil {
+		return h.handleWriteError(c, err, "Failed to deactivate category")
+	}
+
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionCategoryDelete)
+	entry.EntityType = audit.EntityCategory
+	entry.EntityID = &cat.ID
+	entry.Changes = audit.Changes{
+		"is_active": {Old: true, New: false},
+	}
+	h.auditService.LogAsync(entry)
+
+	return response.Success(c, cat)
+}
+
+// UploadImage godoc
+// @Summary Upload category image
+// @Description Upload a category image (JPEG, PNG, WebP or GIF, max 10MB). Replaces the current image; thumb, card, og (1200x630) and full variants are rendered in the original format and WebP and returned in variants. Requires admin or editor role.
+// @Tags Categories
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Category ID"
+// @Param image formData file true "Image file"
+// @Success 200 {object} response.Response{data=category.Category} "Category with its new image"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid ID or image"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Category not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/categories/{id}/image [post]
+func (h *CategoryHandler) UploadImage(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid category ID")
+	}
+
+	file, err := c.FormFile("image")
+	if err != nil {
+		return response.BadRequest(c, "No image file provided")
+	}
+	if err := validation.ValidateImageFile(file); err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+
+	cat, err := h.service.UploadImage(uint(id), file)
+	if err != nil {
+		return h.handleWriteError(c, err, "Failed to upload image")
+	}
+
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionCategoryUpdate)
+	entry.EntityType = audit.EntityCategory
+	entry.EntityID = &cat.ID
+	entry.Changes = audit.Changes{
+		"image_url": {New: cat.ImageURL},
+	}
+	h.auditService.LogAsync(entry)
+
+	return response.Success(c, cat)
+}
+
+// DeleteImage godoc
+// @Summary Delete category image
+// @Description Remove the category image. Uploaded images are deleted from storage along with their variants. Requires admin or editor role.
+// @Tags Categories
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Category ID"
+// @Success 200 {object} response.Response{data=category.Category} "Category without an image"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid ID or category has no image"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Category not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/categories/{id}/image [delete]
+func (h *CategoryHandler) DeleteImage(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid category ID")
+	}
+
+	cat, err := h.service.DeleteImage(uint(id))
+	if err != nil {
+		return h.handleWriteError(c, err, "Failed to delete image")
+	}
+
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionCategoryUpdate)
+	entry.EntityType = audit.EntityCategory
+	entry.EntityID = &cat.ID
+	entry.Changes = audit.Changes{
+		"image_url": {New: ""},
+	}
+	h.auditService.LogAsync(entry)
+
+	return response.Success(c, cat)
+}
+
+// Reorder godoc
+// @Summary Reorder categories
+// @Description Set the sort order of several categories at once. An item moves its category under parent_id, or to the root level with clear_parent; without either the parent stays. Requires admin or editor role.
+// @Tags Categories
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body category.ReorderCategoriesRequest true "New positions"
+// @Success 200 {object} response.Response{data=[]category.Category} "Updated category tree"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid input or hierarchy cycle"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Category not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/categories/reorder [patch]
+func (h *CategoryHandler) Reorder(c *fiber.Ctx) error {
+	var req category.ReorderCategoriesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body: "+err.Error())
+	}
+
+	if len(req.Items) == 0 {
+		return response.BadRequest(c, "At least one item is required")
+	}
+
+	if err := h.service.Reorder(req.Items); err != nil {
+		return h.handleWriteError(c, err, "Failed to reorder categories")
+	}
+
+	auditCtx := middleware.GetAuditContext(c)
+	for i := range req.Items {
+		item := req.Items[i]
+		entry := middleware.NewAuditEntry(auditCtx, audit.ActionCategoryUpdate)
+		entry.EntityType = audit.EntityCategory
+		entry.EntityID = &item.ID
+		entry.Changes = audit.Changes{
+			"sort_order": {Old: nil, New: item.SortOrder},
+		}
+		if item.MovesParent() {
+			entry.Changes["parent_id"] = audit.FieldChange{Old: nil, New: item.ParentID}
+		}
+		h.auditService.LogAsync(entry)
+	}
+
+	tree, err := h.service.GetTree()
+	if err != nil {
+		return response.InternalError(c, "Categories reordered but failed to load tree")
+	}
+
+	return response.Success(c, tree)
+}
+
+// handleWriteError maps category service errors to HTTP responses
+func (h *CategoryHandler) handleWriteError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, service.ErrCategoryNotFound):
+		return response.NotFound(c, err.Error())
+	case errors.Is(err, service.ErrCategorySlugTaken),
+		errors.Is(err, service.ErrCategoryCycle),
+		errors.Is(err, service.ErrCategoryHasChildren),
+		errors.Is(err, service.ErrInvalidCategoryData),
+		errors.Is(err, service.ErrCategoryHasNoImage):
+		return response.BadRequest(c, err.Error())
+	default:
+		return response.InternalError(c, fallback)
+	}
+}
+
+// categoryChanges builds the audit diff between two versions of a category
+func categoryChanges(before, after *category.Category) audit.Changes {
+	changes := audit.Changes{}
+	if before.Name != after.Name {
+		changes["name"] = audit.FieldChange{Old: before.Name, New: after.Name}
+	}
+	if before.Slug != after.Slug {
+		changes["slug"] = audit.FieldChange{Old: before.Slug, New: after.Slug}
+	}
+	if before.Description != after.Description {
+		changes["description"] = audit.FieldChange{Old: before.Description, New: after.Description}
+	}
+	if before.ImageURL != after.ImageURL {
+		changes["image_url"] = audit.FieldChange{Old: before.ImageURL, New: after.ImageURL}
+	}
+	if before.SortOrder != after.SortOrder {
+		changes["sort_order"] = audit.FieldChange{Old: before.SortOrder, New: after.SortOrder}
+	}
+	if before.IsActive != after.IsActive {
+		changes["is_active"] = audit.FieldChange{Old: before.IsActive, New: after.IsActive}
+	}
+	if !sameParent(before.ParentID, after.ParentID) {
+		changes["parent_id"] = audit.FieldChange{Old: before.ParentID, New: after.ParentID}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return changes
+}
+
+func sameParent(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
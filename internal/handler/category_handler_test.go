@@ -0,0 +1,380 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/domain/category"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/healthcare-market-research/backend/pkg/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCategoryService is a mock implementation of CategoryService
+type MockCategoryService struct {
+	mock.Mock
+}
+
+func (m *MockCategoryService) GetAll(page, limit int) ([]category.Category, int64, error) {
+	args := m.Called(page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]category.Category), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockCategoryService) GetTree() ([]category.Category, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]category.Category), args.Error(1)
+}
+
+func (m *MockCategoryService) GetBySlug(slug string) (*category.Category, error) {
+	args := m.Called(slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*category.Category), args.Error(1)
+}
+
+func (m *MockCategoryService) GetByID(id uint) (*category.Category, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*category.Category), args.Error(1)
+}
+
+func (m *MockCategoryService) Create(req *category.CreateCategoryRequest) (*category.Category, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*category.Category), args.Error(1)
+}
+
+func (m *MockCategoryService) Update(id uint, req *category.UpdateCategoryRequest) (*category.Category, error) {
+	args := m.Called(id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*category.Category), args.Error(1)
+}
+
+func (m *MockCategoryService) Deactivate(id uint) (*category.Category, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*category.Category), args.Error(1)
+}
+
+func (m *MockCategoryService) Reorder(items []category.ReorderItem) error {
+	args := m.Called(items)
+	return args.Error(0)
+}
+
+func (m *MockCategoryService) UploadImage(id uint, file *multipart.FileHeader) (*category.Category, error) {
+	args := m.Called(id, file)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*category.Category), args.Error(1)
+}
+
+func (m *MockCategoryService) DeleteImage(id uint) (*category.Category, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*category.Category), args.Error(1)
+}
+
+// MockAuditService records audit entries instead of persisting them
+type MockAuditService struct {
+	mock.Mock
+	entries []*audit.AuditEntry
+}
+
+func (m *MockAuditService) Log(entry *audit.AuditEntry) error {
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+func (m *MockAuditService) LogAsync(entry *audit.AuditEntry) {
+	m.entries = append(m.entries, entry)
+}
+
+func (m *MockAuditService) GetByID(id uint) (*audit.AuditLogResponse, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*audit.AuditLogResponse), args.Error(1)
+}
+
+func (m *MockAuditService) GetAll(filters audit.AuditLogFilters) ([]audit.AuditLogResponse, int64, error) {
+	args := m.Called(filters)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]audit.AuditLogResponse), args.Get(1).(int64), args.Error(2)
+}
+
+func setupCategoryTestApp(handler *CategoryHandler) *fiber.App {
+	app := fiber.New()
+
+	app.Get("/api/v1/categories", handler.GetAll)
+	app.Get("/api/v1/categories/:slug", handler.GetBySlug)
+	app.Post("/api/v1/categories", handler.Create)
+	app.Patch("/api/v1/categories/reorder", handler.Reorder)
+	app.Put("/api/v1/categories/:id", handler.Update)
+	app.Patch("/api/v1/categories/:id/deactivate", handler.Deactivate)
+	app.Delete("/api/v1/categories/:id/image", handler.DeleteImage)
+
+	return app
+}
+
+func jsonRequest(method, url string, body interface{}) *http.Request {
+	payload, _ := json.Marshal(body)
+	req := httptest.NewRequest(method, url, bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func TestCategoryHandler_GetAll_Tree(t *testing.T) {
+	mockService := new(MockCategoryService)
+	handler := NewCategoryHandler(mockService, &MockAuditService{})
+	app := setupCategoryTestApp(handler)
+
+	parentID := uint(1)
+	tree := []category.Category{
+		{ID: 1, Name: "Diagnostics", Slug: "diagnostics", Children: []category.Category{
+			{ID: 2, ParentID: &parentID, Name: "In-Vitro", Slug: "in-vitro"},
+		}},
+	}
+	mockService.On("GetTree").Return(tree, nil).Once()
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/categories?tree=true", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Success bool                `json:"success"`
+		Data    []category.Category `json:"data"`
+	}
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	json.Unmarshal(bodyBytes, &result)
+
+	assert.True(t, result.Success)
+	assert.Len(t, result.Data, 1)
+	assert.Len(t, result.Data[0].Children, 1)
+	assert.Equal(t, "in-vitro", result.Data[0].Children[0].Slug)
+	mockService.AssertExpectations(t)
+}
+
+func TestCategoryHandler_Create(t *testing.T) {
+	t.Run("Successfully create category and log audit entry", func(t *testing.T) {
+		mockService := new(MockCategoryService)
+		auditService := &MockAuditService{}
+		handler := NewCategoryHandler(mockService, auditService)
+		app := setupCategoryTestApp(handler)
+
+		parentID := uint(1)
+		created := &category.Category{ID: 3, ParentID: &parentID, Name: "Molecular", Slug: "molecular", IsActive: true}
+		mockService.On("Create", mock.MatchedBy(func(req *category.CreateCategoryRequest) bool {
+			return req.Name == "Molecular" && req.ParentID != nil && *req.ParentID == 1
+		})).Return(created, nil).Once()
+
+		req := jsonRequest(http.MethodPost, "/api/v1/categories", map[string]interface{}{
+			"name":      "Molecular",
+			"parent_id": 1,
+		})
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+		assert.Len(t, auditService.entries, 1)
+		assert.Equal(t, audit.ActionCategoryCreate, auditService.entries[0].Action)
+		assert.Equal(t, audit.EntityCategory, auditService.entries[0].EntityType)
+		assert.Equal(t, uint(3), *auditService.entries[0].EntityID)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Fail when name is missing", func(t *testing.T) {
+		mockService := new(MockCategoryService)
+		handler := NewCategoryHandler(mockService, &MockAuditService{})
+		app := setupCategoryTestApp(handler)
+
+		req := jsonRequest(http.MethodPost, "/api/v1/categories", map[string]interface{}{"slug": "x"})
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		mockService.AssertNotCalled(t, "Create", mock.Anything)
+	})
+
+	t.Run("Fail when slug is taken", func(t *testing.T) {
+		mockService := new(MockCategoryService)
+		auditService := &MockAuditService{}
+		handler := NewCategoryHandler(mockService, auditService)
+		app := setupCategoryTestApp(handler)
+
+		mockService.On("Create", mock.Anything).Return(nil, service.ErrCategorySlugTaken).Once()
+
+		req := jsonRequest(http.MethodPost, "/api/v1/categories", map[string]interface{}{"name": "Diagnostics"})
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		assert.Empty(t, auditService.entries)
+	})
+}
+
+func TestCategoryHandler_Update(t *testing.T) {
+	t.Run("Record changed fields in audit log", func(t *testing.T) {
+		mockService := new(MockCategoryService)
+		auditService := &MockAuditService{}
+		handler := NewCategoryHandler(mockService, auditService)
+		app := setupCategoryTestApp(handler)
+
+		mockService.On("GetByID", uint(2)).Return(&category.Category{ID: 2, Name: "In Vitro", Slug: "in-vitro", IsActive: true}, nil).Once()
+		mockService.On("Update", uint(2), mock.Anything).Return(&category.Category{ID: 2, Name: "In-Vitro Diagnostics", Slug: "in-vitro", IsActive: true}, nil).Once()
+
+		req := jsonRequest(http.MethodPut, "/api/v1/categories/2", map[string]interface{}{"name": "In-Vitro Diagnostics"})
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		assert.Len(t, auditService.entries, 1)
+		entry := auditService.entries[0]
+		assert.Equal(t, audit.ActionCategoryUpdate, entry.Action)
+		assert.Contains(t, entry.Changes, "name")
+		assert.NotContains(t, entry.Changes, "slug")
+	})
+
+	t.Run("Reject moving category beneath its descendant", func(t *testing.T) {
+		mockService := new(MockCategoryService)
+		handler := NewCategoryHandler(mockService, &MockAuditService{})
+		app := setupCategoryTestApp(handler)
+
+		mockService.On("GetByID", uint(1)).Return(&category.Category{ID: 1, Name: "Diagnostics"}, nil).Once()
+		mockService.On("Update", uint(1), mock.Anything).Return(nil, service.ErrCategoryCycle).Once()
+
+		req := jsonRequest(http.MethodPut, "/api/v1/categories/1", map[string]interface{}{"parent_id": 3})
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("Return 404 for unknown category", func(t *testing.T) {
+		mockService := new(MockCategoryService)
+		handler := NewCategoryHandler(mockService, &MockAuditService{})
+		app := setupCategoryTestApp(handler)
+
+		mockService.On("GetByID", uint(99)).Return(nil, service.ErrCategoryNotFound).Once()
+
+		req := jsonRequest(http.MethodPut, "/api/v1/categories/99", map[string]interface{}{"name": "Anything"})
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestCategoryHandler_Deactivate(t *testing.T) {
+	t.Run("Successfully deactivate category", func(t *testing.T) {
+		mockService := new(MockCategoryService)
+		auditService := &MockAuditService{}
+		handler := NewCategoryHandler(mockService, auditService)
+		app := setupCategoryTestApp(handler)
+
+		mockService.On("Deactivate", uint(3)).Return(&category.Category{ID: 3, IsActive: false}, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodPatch, "/api/v1/categories/3/deactivate", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Len(t, auditService.entries, 1)
+		assert.Equal(t, audit.ActionCategoryDelete, auditService.entries[0].Action)
+	})
+
+	t.Run("Fail when category has active subcategories", func(t *testing.T) {
+		mockService := new(MockCategoryService)
+		handler := NewCategoryHandler(mockService, &MockAuditService{})
+		app := setupCategoryTestApp(handler)
+
+		mockService.On("Deactivate", uint(1)).Return(nil, service.ErrCategoryHasChildren).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodPatch, "/api/v1/categories/1/deactivate", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+
+		var result response.Response
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(bodyBytes, &result)
+		assert.Equal(t, service.ErrCategoryHasChildren.Error(), result.Error)
+	})
+}
+
+func TestCategoryHandler_Reorder(t *testing.T) {
+	mockService := new(MockCategoryService)
+	auditService := &MockAuditService{}
+	handler := NewCategoryHandler(mockService, auditService)
+	app := setupCategoryTestApp(handler)
+
+	mockService.On("Reorder", mock.MatchedBy(func(items []category.ReorderItem) bool {
+		return len(items) == 2 && items[0].ID == 2 && items[1].SortOrder == 1
+	})).Return(nil).Once()
+	mockService.On("GetTree").Return([]category.Category{}, nil).Once()
+
+	req := jsonRequest(http.MethodPatch, "/api/v1/categories/reorder", map[string]interface{}{
+		"items": []map[string]interface{}{
+			{"id": 2, "sort_order": 0},
+			{"id": 3, "sort_order": 1},
+		},
+	})
+	resp, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Len(t, auditService.entries, 2)
+	// Neither item names a parent, so neither moves
+	assert.NotContains(t, auditService.entries[0].Changes, "parent_id")
+	mockService.AssertExpectations(t)
+}
+
+func TestCategoryHandler_DeleteImage(t *testing.T) {
+	t.Run("Successfully delete image", func(t *testing.T) {
+		mockService := new(MockCategoryService)
+		auditService := &MockAuditService{}
+		handler := NewCategoryHandler(mockService, auditService)
+		app := setupCategoryTestApp(handler)
+
+		mockService.On("DeleteImage", uint(3)).Return(&category.Category{ID: 3}, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/api/v1/categories/3/image", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Len(t, auditService.entries, 1)
+		assert.Equal(t, audit.ActionCategoryUpdate, auditService.entries[0].Action)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Fail when category has no image", func(t *testing.T) {
+		mockService := new(MockCategoryService)
+		handler := NewCategoryHandler(mockService, &MockAuditService{})
+		app := setupCategoryTestApp(handler)
+
+		mockService.On("DeleteImage", uint(4)).Return(nil, service.ErrCategoryHasNoImage).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/api/v1/categories/4/image", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
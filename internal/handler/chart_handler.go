@@ -0,0 +1,343 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/middleware"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/healthcare-market-research/backend/pkg/response"
+)
+
+type ChartHandler struct {
+	service      service.ChartService
+	auditService service.AuditService
+}
+
+func NewChartHandler(service service.ChartService, auditService service.AuditService) *ChartHandler {
+	return &ChartHandler{
+		service:      service,
+		auditService: auditService,
+	}
+}
+
+// List godoc
+// @Summary List report charts
+// @Description Get every chart of a report in display order, inactive ones included. Requires admin or editor role.
+// @Tags Charts
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Report ID"
+// @Success 200 {object} response.Response{data=[]report.ChartMetadata} "Charts"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid ID"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Report not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/reports/{id}/charts [get]
+func (h *ChartHandler) List(c *fiber.Ctx) error {
+	reportID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid report ID")
+	}
+
+	charts, err := h.service.List(uint(reportID))
+	if err != nil {
+		return h.handleError(c, err, "Failed to fetch charts")
+	}
+
+	return response.Success(c, charts)
+}
+
+// Get godoc
+// @Summary Get report chart
+// @Description Get a chart of a report with its data series. Requires admin or editor role.
+// @Tags Charts
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Report ID"
+// @Param chartId path int true "Chart ID"
+// @Success 200 {object} response.Response{data=report.ChartMetadata} "Chart"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid ID"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Chart not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/reports/{id}/charts/{chartId} [get]
+func (h *ChartHandler) Get(c *fiber.Ctx) error {
+	reportID, chartID, msg := parseChartParams(c)
+	if msg != "" {
+		return response.BadRequest(c, msg)
+	}
+
+	chart, err := h.service.Get(reportID, chartID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to fetch chart")
+	}
+
+	return response.Success(c, chart)
+}
+
+// Create godoc
+// @Summary Create report chart
+// @Description Add a chart to a report. Data holds the labels and one value per label for every series, plus optional units and source. ImageID links an uploaded image of the same report, shown when the chart can't be drawn. Charts are appended after the existing ones unless an order is given. Requires admin or editor role.
+// @Tags Charts
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Report ID"
+// @Param request body report.CreateChartRequest true "Chart"
+// @Success 201 {object} response.Response{data=report.ChartMetadata} "Chart created"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid input"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Report not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/reports/{id}/charts [post]
+func (h *ChartHandler) Create(c *fiber.Ctx) error {
+	reportID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid report ID")
+	}
+
+	var req report.CreateChartRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body: "+err.Error())
+	}
+	if msg := validateChartTitle(req.Title); msg != "" {
+		return response.BadRequest(c, msg)
+	}
+	if req.ChartType == "" {
+		return response.BadRequest(c, "chart_type is required")
+	}
+
+	chart, err := h.service.Create(uint(reportID), &req)
+	if err != nil {
+		return h.handleError(c, err, "Failed to create chart")
+	}
+
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionChartCreate)
+	entry.EntityType = audit.EntityChart
+	entry.EntityID = &chart.ID
+	entry.Changes = audit.Changes{
+		"report_id":   {New: chart.ReportID},
+		"title":       {New: chart.Title},
+		"chart_type":  {New: chart.ChartType},
+		"data_points": {New: chart.DataPoints},
+	}
+	h.auditService.LogAsync(entry)
+
+	return c.Status(fiber.StatusCreated).JSON(response.Response{
+		Success: true,
+		Data:    chart,
+	})
+}
+
+// Update godoc
+// @Summary Update report chart
+// @Description Update a chart. Only the fields given are changed; data replaces the whole series payload. Set clear_image to unlink the fallback image. Requires admin or editor role.
+// @Tags Charts
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Report ID"
+// @Param chartId path int true "Chart ID"
+// @Param request body report.UpdateChartRequest true "Fields to update"
+// @Success 200 {object} response.Response{data=report.ChartMetadata} "Updated chart"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid input"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Chart not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/reports/{id}/charts/{chartId} [put]
+func (h *ChartHandler) Update(c *fiber.Ctx) error {
+	reportID, chartID, msg := parseChartParams(c)
+	if msg != "" {
+		return response.BadRequest(c, msg)
+	}
+
+	var req report.UpdateChartRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body: "+err.Error())
+	}
+	if req.Title != nil {
+		if msg := validateChartTitle(*req.Title); msg != "" {
+			return response.BadRequest(c, msg)
+		}
+	}
+	if req.ClearImage && req.ImageID != nil {
+		return response.BadRequest(c, "Set either image_id or clear_image, not both")
+	}
+
+	chart, err := h.service.Update(reportID, chartID, &req)
+	if err != nil {
+		return h.handleError(c, err, "Failed to update chart")
+	}
+
+	changes := audit.Changes{}
+	if req.Title != nil {
+		changes["title"] = audit.FieldChange{New: chart.Title}
+	}
+	if req.ChartType != nil {
+		changes["chart_type"] = audit.FieldChange{New: chart.ChartType}
+	}
+	if req.Description != nil {
+		changes["description"] = audit.FieldChange{New: chart.Description}
+	}
+	if req.Data != nil {
+		changes["data_points"] = audit.FieldChange{New: chart.DataPoints}
+	}
+	if req.ImageID != nil || req.ClearImage {
+		changes["image_id"] = audit.FieldChange{New: chart.ImageID}
+	}
+	if req.Order != nil {
+		changes["order"] = audit.FieldChange{New: chart.Order}
+	}
+	if req.IsActive != nil {
+		changes["is_active"] = audit.FieldChange{New: chart.IsActive}
+	}
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionChartUpdate)
+	entry.EntityType = audit.EntityChart
+	entry.EntityID = &chart.ID
+	entry.Changes = changes
+	h.auditService.LogAsync(entry)
+
+	return response.Success(c, chart)
+}
+
+// Delete godoc
+// @Summary Delete report chart
+// @Description Remove a chart from a report. A linked fallback image is kept. Requires admin or editor role.
+// @Tags Charts
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Report ID"
+// @Param chartId path int true "Chart ID"
+// @Success 200 {object} response.Response{data=map[string]string} "Chart deleted"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid ID"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Chart not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/reports/{id}/charts/{chartId} [delete]
+func (h *ChartHandler) Delete(c *fiber.Ctx) error {
+	reportID, chartID, msg := parseChartParams(c)
+	if msg != "" {
+		return response.BadRequest(c, msg)
+	}
+
+	chart, err := h.service.Delete(reportID, chartID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to delete chart")
+	}
+
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionChartDelete)
+	entry.EntityType = audit.EntityChart
+	entry.EntityID = &chart.ID
+	entry.Changes = audit.Changes{
+		"title": {Old: chart.Title, New: nil},
+	}
+	h.auditService.LogAsync(entry)
+
+	return response.Success(c, fiber.Map{
+		"message": "Chart deleted successfully",
+	})
+}
+
+// Export godoc
+// @Summary Export chart data
+// @Description Download a chart's data as CSV (one row per label, one column per series) or JSON. Requires admin or editor role.
+// @Tags Charts
+// @Security BearerAuth
+// @Produce text/csv
+// @Produce json
+// @Param id path int true "Report ID"
+// @Param chartId path int true "Chart ID"
+// @Param format query string false "Export format" Enums(csv, json) default(csv)
+// @Success 200 {file} file "Chart data"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid format or chart has no data"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Chart not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/reports/{id}/charts/{chartId}/export [get]
+func (h *ChartHandler) Export(c *fiber.Ctx) error {
+	reportID, chartID, msg := parseChartParams(c)
+	if msg != "" {
+		return response.BadRequest(c, msg)
+	}
+
+	format := strings.ToLower(c.Query("format", "csv"))
+	if format != "csv" && format != "json" {
+		return response.BadRequest(c, "format must be csv or json")
+	}
+
+	chart, err := h.service.Get(reportID, chartID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to export chart")
+	}
+	if chart.Data == nil {
+		return response.BadRequest(c, "Chart has no data to export")
+	}
+
+	c.Attachment(fmt.Sprintf("chart-%d.%s", chart.ID, format))
+	if format == "json" {
+		return c.JSON(report.ChartExport{
+			ID:        chart.ID,
+			ReportID:  chart.ReportID,
+			Title:     chart.Title,
+			ChartType: chart.ChartType,
+			Data:      chart.Data,
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := chart.Data.WriteCSV(&buf); err != nil {
+		return response.InternalError(c, "Failed to export chart")
+	}
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	return c.Send(buf.Bytes())
+}
+
+func (h *ChartHandler) handleError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, service.ErrChartReportNotFound):
+		return response.NotFound(c, "Report not found")
+	case errors.Is(err, service.ErrChartNotFound):
+		return response.NotFound(c, "Chart not found")
+	case errors.Is(err, service.ErrInvalidChartType),
+		errors.Is(err, service.ErrInvalidChartData),
+		errors.Is(err, service.ErrInvalidChartImage):
+		return response.BadRequest(c, err.Error())
+	default:
+		return response.InternalError(c, fallback)
+	}
+}
+
+func validateChartTitle(title string) string {
+	title = strings.TrimSpace(title)
+	if len(title) < 2 || len(title) > 500 {
+		return "Title must be between 2 and 500 characters"
+	}
+	return ""
+}
+
+// parseChartParams reads the report and chart IDs, returning a message for
+// the first invalid one
+func parseChartParams(c *fiber.Ctx) (uint, uint, string) {
+	reportID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return 0, 0, "Invalid report ID"
+	}
+	chartID, err := strconv.ParseUint(c.Params("chartId"), 10, 32)
+	if err != nil {
+		return 0, 0, "Invalid chart ID"
+	}
+	return uint(reportID), uint(chartID), ""
+}
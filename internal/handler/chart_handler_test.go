@@ -0,0 +1,250 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockChartService is a mock implementation of ChartService
+type MockChartService struct {
+	mock.Mock
+}
+
+func (m *MockChartService) List(reportID uint) ([]report.ChartMetadata, error) {
+	args := m.Called(reportID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]report.ChartMetadata), args.Error(1)
+}
+
+func (m *MockChartService) Get(reportID, chartID uint) (*report.ChartMetadata, error) {
+	args := m.Called(reportID, chartID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*report.ChartMetadata), args.Error(1)
+}
+
+func (m *MockChartService) Create(reportID uint, req *report.CreateChartRequest) (*report.ChartMetadata, error) {
+	args := m.Called(reportID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*report.ChartMetadata), args.Error(1)
+}
+
+func (m *MockChartService) Update(reportID, chartID uint, req *report.UpdateChartRequest) (*report.ChartMetadata, error) {
+	args := m.Called(reportID, chartID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*report.ChartMetadata), args.Error(1)
+}
+
+func (m *MockChartService) Delete(reportID, chartID uint) (*report.ChartMetadata, error) {
+	args := m.Called(reportID, chartID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*report.ChartMetadata), args.Error(1)
+}
+
+func setupChartTestApp(handler *ChartHandler) *fiber.App {
+	app := fiber.New()
+	app.Get("/api/v1/reports/:id/charts", handler.List)
+	app.Post("/api/v1/reports/:id/charts", handler.Create)
+	app.Get("/api/v1/reports/:id/charts/:chartId", handler.Get)
+	app.Put("/api/v1/reports/:id/charts/:chartId", handler.Update)
+	app.Delete("/api/v1/reports/:id/charts/:chartId", handler.Delete)
+	app.Get("/api/v1/reports/:id/charts/:chartId/export", handler.Export)
+	return app
+}
+
+func testChartData() *report.ChartData {
+	v1, v2 := 10.5, 12.0
+	return &report.ChartData{
+		Labels: []string{"2024", "2025"},
+		Series: []report.ChartSeries{{Name: "Global", Values: []*float64{&v1, &v2}}},
+		Units:  "USD Billion",
+	}
+}
+
+func TestChartHandler_Create(t *testing.T) {
+	t.Run("Successfully create chart", func(t *testing.T) {
+		mockService := new(MockChartService)
+		auditService := &MockAuditService{}
+		app := setupChartTestApp(NewChartHandler(mockService, auditService))
+
+		mockService.On("Create", uint(4), mock.MatchedBy(func(req *report.CreateChartRequest) bool {
+			return req.ChartType == report.ChartLine && req.Data != nil && len(req.Data.Series) == 1
+		})).Return(&report.ChartMetadata{ID: 9, ReportID: 4, Title: "Market size", ChartType: report.ChartLine, DataPoints: 2}, nil).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPost, "/api/v1/reports/4/charts", map[string]interface{}{
+			"title":      "Market size",
+			"chart_type": "line",
+			"data":       testChartData(),
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+		assert.Len(t, auditService.entries, 1)
+		assert.Equal(t, audit.ActionChartCreate, auditService.entries[0].Action)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Fail without title", func(t *testing.T) {
+		mockService := new(MockChartService)
+		app := setupChartTestApp(NewChartHandler(mockService, &MockAuditService{}))
+
+		resp, err := app.Test(jsonRequest(http.MethodPost, "/api/v1/reports/4/charts", map[string]interface{}{"chart_type": "bar"}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		mockService.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("Fail with image of another report", func(t *testing.T) {
+		mockService := new(MockChartService)
+		app := setupChartTestApp(NewChartHandler(mockService, &MockAuditService{}))
+
+		mockService.On("Create", uint(4), mock.Anything).Return(nil, service.ErrInvalidChartImage).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPost, "/api/v1/reports/4/charts", map[string]interface{}{
+			"title": "Market size", "chart_type": "bar", "image_id": 30,
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("Fail when report is missing", func(t *testing.T) {
+		mockService := new(MockChartService)
+		app := setupChartTestApp(NewChartHandler(mockService, &MockAuditService{}))
+
+		mockService.On("Create", uint(99), mock.Anything).Return(nil, service.ErrChartReportNotFound).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPost, "/api/v1/reports/99/charts", map[string]interface{}{
+			"title": "Market size", "chart_type": "bar",
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestChartHandler_Update(t *testing.T) {
+	t.Run("Successfully clear image", func(t *testing.T) {
+		mockService := new(MockChartService)
+		auditService := &MockAuditService{}
+		app := setupChartTestApp(NewChartHandler(mockService, auditService))
+
+		mockService.On("Update", uint(4), uint(9), &report.UpdateChartRequest{ClearImage: true}).
+			Return(&report.ChartMetadata{ID: 9, ReportID: 4}, nil).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPut, "/api/v1/reports/4/charts/9", map[string]interface{}{"clear_image": true}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Len(t, auditService.entries, 1)
+		assert.Contains(t, auditService.entries[0].Changes, "image_id")
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Fail with image and clear_image", func(t *testing.T) {
+		mockService := new(MockChartService)
+		app := setupChartTestApp(NewChartHandler(mockService, &MockAuditService{}))
+
+		resp, err := app.Test(jsonRequest(http.MethodPut, "/api/v1/reports/4/charts/9",
+			map[string]interface{}{"clear_image": true, "image_id": 3}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		mockService.AssertNotCalled(t, "Update")
+	})
+
+	t.Run("Fail when chart belongs to another report", func(t *testing.T) {
+		mockService := new(MockChartService)
+		app := setupChartTestApp(NewChartHandler(mockService, &MockAuditService{}))
+
+		mockService.On("Update", uint(4), uint(10), mock.Anything).Return(nil, service.ErrChartNotFound).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPut, "/api/v1/reports/4/charts/10", map[string]interface{}{"title": "Share"}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestChartHandler_Delete(t *testing.T) {
+	mockService := new(MockChartService)
+	auditService := &MockAuditService{}
+	app := setupChartTestApp(NewChartHandler(mockService, auditService))
+
+	mockService.On("Delete", uint(4), uint(9)).Return(&report.ChartMetadata{ID: 9, Title: "Market size"}, nil).Once()
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/api/v1/reports/4/charts/9", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Len(t, auditService.entries, 1)
+	assert.Equal(t, audit.ActionChartDelete, auditService.entries[0].Action)
+	mockService.AssertExpectations(t)
+}
+
+func TestChartHandler_Export(t *testing.T) {
+	chart := &report.ChartMetadata{ID: 9, ReportID: 4, Title: "Market size", ChartType: report.ChartBar, Data: testChartData()}
+
+	t.Run("Export CSV by default", func(t *testing.T) {
+		mockService := new(MockChartService)
+		app := setupChartTestApp(NewChartHandler(mockService, &MockAuditService{}))
+		mockService.On("Get", uint(4), uint(9)).Return(chart, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports/4/charts/9/export", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Contains(t, resp.Header.Get("Content-Type"), "text/csv")
+		assert.Contains(t, resp.Header.Get("Content-Disposition"), "chart-9.csv")
+
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "label,Global\n2024,10.5\n2025,12\n", string(body))
+	})
+
+	t.Run("Export JSON", func(t *testing.T) {
+		mockService := new(MockChartService)
+		app := setupChartTestApp(NewChartHandler(mockService, &MockAuditService{}))
+		mockService.On("Get", uint(4), uint(9)).Return(chart, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports/4/charts/9/export?format=json", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Contains(t, resp.Header.Get("Content-Disposition"), "chart-9.json")
+
+		var export report.ChartExport
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&export))
+		assert.Equal(t, "USD Billion", export.Data.Units)
+		assert.Equal(t, []string{"2024", "2025"}, export.Data.Labels)
+	})
+
+	t.Run("Fail with unknown format", func(t *testing.T) {
+		mockService := new(MockChartService)
+		app := setupChartTestApp(NewChartHandler(mockService, &MockAuditService{}))
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports/4/charts/9/export?format=xlsx", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		mockService.AssertNotCalled(t, "Get")
+	})
+
+	t.Run("Fail when chart has no data", func(t *testing.T) {
+		mockService := new(MockChartService)
+		app := setupChartTestApp(NewChartHandler(mockService, &MockAuditService{}))
+		mockService.On("Get", uint(4), uint(8)).Return(&report.ChartMetadata{ID: 8, ReportID: 4}, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports/4/charts/8/export", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
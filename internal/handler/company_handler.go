@@ -0,0 +1,459 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/domain/company"
+	"github.com/healthcare-market-research/backend/internal/middleware"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/healthcare-market-research/backend/pkg/response"
+	"github.com/healthcare-market-research/backend/pkg/validation"
+)
+
+type CompanyHandler struct {
+	service      service.CompanyService
+	auditService service.AuditService
+}
+
+func NewCompanyHandler(service service.CompanyService, auditService service.AuditService) *CompanyHandler {
+	return &CompanyHandler{
+		service:      service,
+		auditService: auditService,
+	}
+}
+
+// GetAll godoc
+// @Summary Get all companies
+// @Description Get a paginated list of companies in the key player directory, ordered by name. Pass search to match names and aliases.
+// @Tags Companies
+// @Accept json
+// @Produce json
+// @Param search query string false "Match company names and aliases"
+// @Param page query int false "Page number (default: 1, min: 1)"
+// @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Success 200 {object} response.Response{data=[]company.Company,meta=response.Meta} "List of companies with pagination metadata"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/companies [get]
+func (h *CompanyHandler) GetAll(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	companies, total, err := h.service.GetAll(c.Query("search"), page, limit)
+	if err != nil {
+		return response.InternalError(c, "Failed to fetch companies")
+	}
+
+	meta := &response.Meta{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+	}
+
+	return response.SuccessWithMeta(c, companies, meta)
+}
+
+// GetBySlug godoc
+// @Summary Get company by slug
+// @Description Get a company with every published report it is a key player in, along with its market share and rank in each
+// @Tags Companies
+// @Accept json
+// @Produce json
+// @Param slug path string true "Company slug"
+// @Success 200 {object} response.Response{data=company.CompanyDetail} "Company and its reports"
+// @Failure 301 {object} response.Response{data=redirect.Target,error=string} "Slug was retired or merged - Location points at the current URL"
+// @Failure 400 {object} response.Response{error=string} "Bad request - slug is required"
+// @Failure 404 {object} response.Response{error=string} "Company not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/companies/{slug} [get]
+func (h *CompanyHandler) GetBySlug(c *fiber.Ctx) error {
+	slug := c.Params("slug")
+	if slug == "" {
+		return response.BadRequest(c, "Slug is required")
+	}
+
+	detail, err := h.service.GetBySlug(slug)
+	if err != nil {
+		var moved *service.SlugMovedError
+		if errors.As(err, &moved) {
+			return redirectTo(c, moved.Target)
+		}
+		if errors.Is(err, service.ErrCompanyNotFound) {
+			return response.NotFound(c, "Company not found")
+		}
+		return response.InternalError(c, "Failed to fetch company")
+	}
+
+	return response.Success(c, detail)
+}
+
+// Create godoc
+// @Summary Create company
+// @Description Add a company to the directory. The slug is generated from the name when omitted. The name and aliases must not match another company's; hq_country is an ISO 3166-1 alpha-2 code. Requires admin or editor role.
+// @Tags Companies
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param company body company.CreateCompanyRequest true "Company data"
+// @Success 201 {object} response.Response{data=company.Company} "Created company"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid input, slug or name already in use"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/companies [post]
+func (h *CompanyHandler) Create(c *fiber.Ctx) error {
+	var req company.CreateCompanyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body: "+err.Error())
+	}
+
+	if req.Name == "" {
+		return response.BadRequest(c, "Name is required")
+	}
+
+	created, err := h.service.Create(&req)
+	if err != nil {
+		return h.handleError(c, err, "Failed to create company")
+	}
+
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionCompanyCreate)
+	entry.EntityType = audit.EntityCompany
+	entry.EntityID = &created.ID
+	h.auditService.LogAsync(entry)
+
+	return c.Status(fiber.StatusCreated).JSON(response.Response{
+		Success: true,
+		Data:    created,
+	})
+}
+
+// Update godoc
+// @Summary Update company
+// @Description Update a company by ID. Supports partial updates; aliases replace the current list. A new slug keeps the old one working through a redirect. Requires admin or editor role.
+// @Tags Companies
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Company ID"
+// @Param company body company.UpdateCompanyRequest true "Fields to update"
+// @Success 200 {object} response.Response{data=company.Company} "Updated company"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid input, slug or name already in use"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Company not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/companies/{id} [put]
+func (h *CompanyHandler) Update(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid company ID")
+	}
+
+	var req company.UpdateCompanyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body: "+err.Error())
+	}
+
+	before, err := h.service.GetByID(uint(id))
+	if err != nil {
+		return response.NotFound(c, "Company not found")
+	}
+	snapshot := *before
+
+	updated, err := h.service.Update(uint(id), &req)
+	if err != nil {
+		return h.handleError(c, err, "Failed to update company")
+	}
+
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionCompanyUpdate)
+	entry.EntityType = audit.EntityCompany
+	entry.EntityID = &updated.ID
+	entry.Changes = companyChanges(&snapshot, updated)
+	h.auditService.LogAsync(entry)
+
+	return response.Success(c, updated)
+}
+
+// Delete godoc
+// @Summary Delete company
+// @Description Delete a company no report links to. Companies that are key players in reports should be merged into another company instead. Requires admin role.
+// @Tags Companies
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Company ID"
+// @Success 200 {object} response.Response{data=company.Company} "Deleted company"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid ID"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin role"
+// @Failure 404 {object} response.Response{error=string} "Company not found"
+// @Failure 409 {object} response.Response{error=string} "Company is a key player in reports"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/companies/{id} [delete]
+func (h *CompanyHandler) Delete(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid company ID")
+	}
+
+	deleted, err := h.service.Delete(uint(id))
+	if err != nil {
+		return h.handleError(c, err, "Failed to delete company")
+	}
+
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionCompanyDelete)
+	entry.EntityType = audit.EntityCompany
+	entry.EntityID = &deleted.ID
+	entry.Changes = audit.Changes{
+		"name": {Old: deleted.Name, New: nil},
+	}
+	h.auditService.LogAsync(entry)
+
+	return response.Success(c, deleted)
+}
+
+// UploadLogo godoc
+// @Summary Upload company logo
+// @Description Upload a company logo (JPEG, PNG, WebP or GIF, max 10MB). Replaces the current logo; thumb, card, og (1200x630) and full variants are rendered in the original format and WebP and returned in variants. Requires admin or editor role.
+// @Tags Companies
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Company ID"
+// @Param image formData file true "Logo file"
+// @Success 200 {object} response.Response{data=company.Company} "Company with its new logo"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid ID or image"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Company not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/companies/{id}/logo [post]
+func (h *CompanyHandler) UploadLogo(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid company ID")
+	}
+
+	file, err := c.FormFile("image")
+	if err != nil {
+		return response.BadRequest(c, "No image file provided")
+	}
+	if err := validation.ValidateImageFile(file); err != nil {
+		return response.BadRequest(c, err.Error())
+	}
+
+	updated, err := h.service.UploadLogo(uint(id), file)
+	if err != nil {
+		return h.handleError(c, err, "Failed to upload logo")
+	}
+
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionCompanyUpdate)
+	entry.EntityType = audit.EntityCompany
+	entry.EntityID = &updated.ID
+	entry.Changes = audit.Changes{
+		"logo_url": {New: updated.LogoURL},
+	}
+	h.auditService.LogAsync(entry)
+
+	return response.Success(c, updated)
+}
+
+// DeleteLogo godoc
+// @Summary Delete company logo
+// @Description Remove the company logo. Uploaded logos are deleted from storage along with their variants. Requires admin or editor role.
+// @Tags Companies
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Company ID"
+// @Success 200 {object} response.Response{data=company.Company} "Company without a logo"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid ID or company has no logo"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Company not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/companies/{id}/logo [delete]
+func (h *CompanyHandler) DeleteLogo(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid company ID")
+	}
+
+	updated, err := h.service.DeleteLogo(uint(id))
+	if err != nil {
+		return h.handleError(c, err, "Failed to delete logo")
+	}
+
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionCompanyUpdate)
+	entry.EntityType = audit.EntityCompany
+	entry.EntityID = &updated.ID
+	entry.Changes = audit.Changes{
+		"logo_url": {New: ""},
+	}
+	h.auditService.LogAsync(entry)
+
+	return response.Success(c, updated)
+}
+
+// Merge godoc
+// @Summary Merge duplicate companies
+// @Description Merge the source companies into the company in the path. Their names become aliases, empty fields are filled from them, every report key player linked to them is relinked and their slugs redirect to the target. The sources are deleted. Requires admin role.
+// @Tags Companies
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Target company ID"
+// @Param request body company.MergeCompaniesRequest true "Companies to merge into the target"
+// @Success 200 {object} response.Response{data=company.MergeResult} "Merged company"
+// @Failure 400 {object} response.Response{error=string} "Bad request - no sources, target among sources or merged names clash"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin role"
+// @Failure 404 {object} response.Response{error=string} "Target or source company not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/companies/{id}/merge [post]
+func (h *CompanyHandler) Merge(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid company ID")
+	}
+
+	var req company.MergeCompaniesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body: "+err.Error())
+	}
+	if len(req.SourceIDs) == 0 {
+		return response.BadRequest(c, "source_ids is required")
+	}
+
+	result, err := h.service.Merge(uint(id), req.SourceIDs)
+	if err != nil {
+		return h.handleError(c, err, "Failed to merge companies")
+	}
+
+	auditCtx := middleware.GetAuditContext(c)
+	entry := middleware.NewAuditEntry(auditCtx, audit.ActionCompanyMerge)
+	entry.EntityType = audit.EntityCompany
+	entry.EntityID = &result.Company.ID
+	entry.Changes = audit.Changes{
+		"merged_ids":      {New: result.MergedIDs},
+		"aliases":         {New: result.Company.Aliases},
+		"reports_updated": {New: result.ReportsUpdated},
+	}
+	h.auditService.LogAsync(entry)
+
+	for i := range result.MergedIDs {
+		mergedID := result.MergedIDs[i]
+		deleted := middleware.NewAuditEntry(auditCtx, audit.ActionCompanyDelete)
+		deleted.EntityType = audit.EntityCompany
+		deleted.EntityID = &mergedID
+		deleted.Changes = audit.Changes{
+			"merged_into": {New: result.Company.ID},
+		}
+		h.auditService.LogAsync(deleted)
+	}
+
+	return response.Success(c, result)
+}
+
+// Import godoc
+// @Summary Import companies from key players
+// @Description Seed the directory from report key players. Each unlinked player is matched to a company by name or alias, ignoring case, punctuation and legal suffixes such as Inc or GmbH; unmatched names become new companies. Players already linked are left alone, so the import can be run again. Pass dry_run=true to see what would change without writing. Requires admin role.
+// @Tags Companies
+// @Security BearerAuth
+// @Produce json
+// @Param dry_run query bool false "Report what would change without writing"
+// @Success 200 {object} response.Response{data=company.ImportResult} "Import summary"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin role"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/companies/import [post]
+func (h *CompanyHandler) Import(c *fiber.Ctx) error {
+	dryRun := c.QueryBool("dry_run", false)
+
+	result, err := h.service.Import(dryRun)
+	if err != nil {
+		return response.InternalError(c, "Failed to import companies")
+	}
+
+	if !dryRun {
+		entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionCompanyImport)
+		entry.EntityType = audit.EntityCompany
+		entry.Changes = audit.Changes{
+			"companies_created": {New: len(result.Created)},
+			"players_linked":    {New: result.PlayersLinked},
+			"reports_updated":   {New: result.ReportsUpdated},
+		}
+		h.auditService.LogAsync(entry)
+	}
+
+	return response.Success(c, result)
+}
+
+// handleError maps company service errors to HTTP responses
+func (h *CompanyHandler) handleError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, service.ErrCompanyNotFound):
+		return response.NotFound(c, err.Error())
+	case errors.Is(err, service.ErrCompanyInUse):
+		return response.Error(c, fiber.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrCompanySlugTaken),
+		errors.Is(err, service.ErrCompanyNameTaken),
+		errors.Is(err, service.ErrInvalidCompanyData),
+		errors.Is(err, service.ErrCompanyHasNoLogo),
+		errors.Is(err, service.ErrInvalidMerge):
+		return response.BadRequest(c, err.Error())
+	default:
+		return response.InternalError(c, fallback)
+	}
+}
+
+// companyChanges builds the audit diff between two versions of a company
+func companyChanges(before, after *company.Company) audit.Changes {
+	changes := audit.Changes{}
+	if before.Name != after.Name {
+		changes["name"] = audit.FieldChange{Old: before.Name, New: after.Name}
+	}
+	if before.Slug != after.Slug {
+		changes["slug"] = audit.FieldChange{Old: before.Slug, New: after.Slug}
+	}
+	if !sameNames(before.Aliases, after.Aliases) {
+		changes["aliases"] = audit.FieldChange{Old: before.Aliases, New: after.Aliases}
+	}
+	if before.Website != after.Website {
+		changes["website"] = audit.FieldChange{Old: before.Website, New: after.Website}
+	}
+	if before.HQCountry != after.HQCountry {
+		changes["hq_country"] = audit.FieldChange{Old: before.HQCountry, New: after.HQCountry}
+	}
+	if before.Description != after.Description {
+		changes["description"] = audit.FieldChange{Old: before.Description, New: after.Description}
+	}
+	if before.LogoURL != after.LogoURL {
+		changes["logo_url"] = audit.FieldChange{Old: before.LogoURL, New: after.LogoURL}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return changes
+}
+
+func sameNames(a, b company.Names) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
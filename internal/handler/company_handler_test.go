@@ -0,0 +1,300 @@
+package handler
+
+import (
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/domain/company"
+	"github.com/healthcare-market-research/backend/internal/domain/redirect"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/healthcare-market-research/backend/pkg/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCompanyService is a mock implementation of CompanyService
+type MockCompanyService struct {
+	mock.Mock
+}
+
+func (m *MockCompanyService) GetAll(search string, page, limit int) ([]company.Company, int64, error) {
+	args := m.Called(search, page, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]company.Company), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockCompanyService) GetBySlug(slug string) (*company.CompanyDetail, error) {
+	args := m.Called(slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*company.CompanyDetail), args.Error(1)
+}
+
+func (m *MockCompanyService) GetByID(id uint) (*company.Company, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*company.Company), args.Error(1)
+}
+
+func (m *MockCompanyService) Create(req *company.CreateCompanyRequest) (*company.Company, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*company.Company), args.Error(1)
+}
+
+func (m *MockCompanyService) Update(id uint, req *company.UpdateCompanyRequest) (*company.Company, error) {
+	args := m.Called(id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*company.Company), args.Error(1)
+}
+
+func (m *MockCompanyService) Delete(id uint) (*company.Company, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*company.Company), args.Error(1)
+}
+
+func (m *MockCompanyService) UploadLogo(id uint, file *multipart.FileHeader) (*company.Company, error) {
+	args := m.Called(id, file)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*company.Company), args.Error(1)
+}
+
+func (m *MockCompanyService) DeleteLogo(id uint) (*company.Company, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*company.Company), args.Error(1)
+}
+
+func (m *MockCompanyService) Merge(targetID uint, sourceIDs []uint) (*company.MergeResult, error) {
+	args := m.Called(targetID, sourceIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*company.MergeResult), args.Error(1)
+}
+
+func (m *MockCompanyService) Import(dryRun bool) (*company.ImportResult, error) {
+	args := m.Called(dryRun)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*company.ImportResult), args.Error(1)
+}
+
+func setupCompanyTestApp(handler *CompanyHandler) *fiber.App {
+	app := fiber.New()
+	app.Get("/api/v1/companies", handler.GetAll)
+	app.Get("/api/v1/companies/:slug", handler.GetBySlug)
+	app.Post("/api/v1/companies", handler.Create)
+	app.Post("/api/v1/companies/import", handler.Import)
+	app.Put("/api/v1/companies/:id", handler.Update)
+	app.Delete("/api/v1/companies/:id", handler.Delete)
+	app.Post("/api/v1/companies/:id/merge", handler.Merge)
+	app.Delete("/api/v1/companies/:id/logo", handler.DeleteLogo)
+	return app
+}
+
+func TestCompanyHandler_GetBySlug(t *testing.T) {
+	t.Run("Lists the company's reports", func(t *testing.T) {
+		mockService := new(MockCompanyService)
+		app := setupCompanyTestApp(NewCompanyHandler(mockService, &MockAuditService{}))
+
+		mockService.On("GetBySlug", "siemens-healthineers").Return(&company.CompanyDetail{
+			Company: company.Company{ID: 1, Name: "Siemens Healthineers", Slug: "siemens-healthineers"},
+			Reports: []company.CompanyReport{{ReportID: 12, Title: "Medical Imaging Market", MarketShare: "18%", Rank: 1}},
+		}, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/companies/siemens-healthineers", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Data company.CompanyDetail `json:"data"`
+		}
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Equal(t, "Siemens Healthineers", body.Data.Name)
+		assert.Len(t, body.Data.Reports, 1)
+		assert.Equal(t, "18%", body.Data.Reports[0].MarketShare)
+	})
+
+	t.Run("Redirects a merged slug", func(t *testing.T) {
+		mockService := new(MockCompanyService)
+		app := setupCompanyTestApp(NewCompanyHandler(mockService, &MockAuditService{}))
+
+		target := &redirect.Target{
+			EntityType: redirect.EntityCompany,
+			EntityID:   1,
+			OldSlug:    "siemens-healthcare",
+			Slug:       "siemens-healthineers",
+			Location:   redirect.Location(redirect.EntityCompany, "siemens-healthineers"),
+		}
+		mockService.On("GetBySlug", "siemens-healthcare").Return(nil, &service.SlugMovedError{Target: target}).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/companies/siemens-healthcare", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusMovedPermanently, resp.StatusCode)
+		assert.Equal(t, "/api/v1/companies/siemens-healthineers", resp.Header.Get("Location"))
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		mockService := new(MockCompanyService)
+		app := setupCompanyTestApp(NewCompanyHandler(mockService, &MockAuditService{}))
+
+		mockService.On("GetBySlug", "missing").Return(nil, service.ErrCompanyNotFound).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/companies/missing", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestCompanyHandler_Create(t *testing.T) {
+	t.Run("Successfully create company", func(t *testing.T) {
+		mockService := new(MockCompanyService)
+		auditService := &MockAuditService{}
+		app := setupCompanyTestApp(NewCompanyHandler(mockService, auditService))
+
+		mockService.On("Create", mock.MatchedBy(func(req *company.CreateCompanyRequest) bool {
+			return req.Name == "Medtronic" && req.HQCountry == "IE" && len(req.Aliases) == 1
+		})).Return(&company.Company{ID: 3, Name: "Medtronic", Slug: "medtronic"}, nil).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPost, "/api/v1/companies", map[string]interface{}{
+			"name":       "Medtronic",
+			"aliases":    []string{"Medtronic plc"},
+			"hq_country": "IE",
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+		assert.Len(t, auditService.entries, 1)
+		assert.Equal(t, audit.ActionCompanyCreate, auditService.entries[0].Action)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Fail when name is taken", func(t *testing.T) {
+		mockService := new(MockCompanyService)
+		app := setupCompanyTestApp(NewCompanyHandler(mockService, &MockAuditService{}))
+
+		mockService.On("Create", mock.Anything).Return(nil, service.ErrCompanyNameTaken).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPost, "/api/v1/companies", map[string]interface{}{"name": "Medtronic plc"}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestCompanyHandler_Delete_InUse(t *testing.T) {
+	mockService := new(MockCompanyService)
+	auditService := &MockAuditService{}
+	app := setupCompanyTestApp(NewCompanyHandler(mockService, auditService))
+
+	mockService.On("Delete", uint(3)).Return(nil, service.ErrCompanyInUse).Once()
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/api/v1/companies/3", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	assert.Empty(t, auditService.entries)
+}
+
+func TestCompanyHandler_Merge(t *testing.T) {
+	t.Run("Successfully merge duplicates", func(t *testing.T) {
+		mockService := new(MockCompanyService)
+		auditService := &MockAuditService{}
+		app := setupCompanyTestApp(NewCompanyHandler(mockService, auditService))
+
+		mockService.On("Merge", uint(1), []uint{2, 5}).Return(&company.MergeResult{
+			Company:        &company.Company{ID: 1, Name: "Siemens Healthineers", Aliases: company.Names{"Siemens Healthcare"}},
+			MergedIDs:      []uint{2, 5},
+			ReportsUpdated: 4,
+		}, nil).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPost, "/api/v1/companies/1/merge", map[string]interface{}{"source_ids": []uint{2, 5}}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		// One entry for the target and one for each merged company
+		assert.Len(t, auditService.entries, 3)
+		assert.Equal(t, audit.ActionCompanyMerge, auditService.entries[0].Action)
+		assert.Equal(t, audit.ActionCompanyDelete, auditService.entries[1].Action)
+		assert.Equal(t, uint(2), *auditService.entries[1].EntityID)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Fail without sources", func(t *testing.T) {
+		mockService := new(MockCompanyService)
+		app := setupCompanyTestApp(NewCompanyHandler(mockService, &MockAuditService{}))
+
+		resp, err := app.Test(jsonRequest(http.MethodPost, "/api/v1/companies/1/merge", map[string]interface{}{}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		mockService.AssertNotCalled(t, "Merge")
+	})
+
+	t.Run("Fail merging into itself", func(t *testing.T) {
+		mockService := new(MockCompanyService)
+		app := setupCompanyTestApp(NewCompanyHandler(mockService, &MockAuditService{}))
+
+		mockService.On("Merge", uint(1), []uint{1}).Return(nil, service.ErrInvalidMerge).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPost, "/api/v1/companies/1/merge", map[string]interface{}{"source_ids": []uint{1}}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestCompanyHandler_Import(t *testing.T) {
+	t.Run("Dry run is not audited", func(t *testing.T) {
+		mockService := new(MockCompanyService)
+		auditService := &MockAuditService{}
+		app := setupCompanyTestApp(NewCompanyHandler(mockService, auditService))
+
+		mockService.On("Import", true).Return(&company.ImportResult{
+			DryRun:        true,
+			Created:       []company.ImportedCompany{{Name: "Philips", Slug: "philips", Players: 2}},
+			PlayersLinked: 2,
+		}, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/api/v1/companies/import?dry_run=true", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Empty(t, auditService.entries)
+
+		var body response.Response
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.True(t, body.Success)
+	})
+
+	t.Run("Import is audited", func(t *testing.T) {
+		mockService := new(MockCompanyService)
+		auditService := &MockAuditService{}
+		app := setupCompanyTestApp(NewCompanyHandler(mockService, auditService))
+
+		mockService.On("Import", false).Return(&company.ImportResult{ReportsUpdated: 3}, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/api/v1/companies/import", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Len(t, auditService.entries, 1)
+		assert.Equal(t, audit.ActionCompanyImport, auditService.entries[0].Action)
+	})
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/pkg/response"
+)
+
+// setETag exposes a resource's optimistic lock version as a strong ETag
+func setETag(c *fiber.Ctx, version int) {
+	c.Set(fiber.HeaderETag, strconv.Quote(strconv.Itoa(version)))
+}
+
+// requestVersion returns the version an update is based on. The If-Match header
+// takes precedence over the version field in the body. ok is false when the
+// header is present but not a single version ETag.
+func requestVersion(c *fiber.Ctx, bodyVersion *int) (version *int, ok bool) {
+	header := strings.TrimSpace(c.Get(fiber.HeaderIfMatch))
+	if header == "" {
+		return bodyVersion, true
+	}
+
+	tag := strings.Trim(strings.TrimPrefix(header, "W/"), `"`)
+	n, err := strconv.Atoi(tag)
+	if err != nil || n < 1 {
+		return nil, false
+	}
+	return &n, true
+}
+
+// versionRequired responds when an update carries neither If-Match nor a version
+func versionRequired(c *fiber.Ctx) error {
+	return response.Error(c, fiber.StatusPreconditionRequired, "Updates require an If-Match header or a version field")
+}
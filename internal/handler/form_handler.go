@@ -7,6 +7,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/healthcare-market-research/backend/internal/domain/form"
 	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/healthcare-market-research/backend/pkg/pagination"
 	"github.com/healthcare-market-research/backend/pkg/response"
 )
 
@@ -69,18 +70,16 @@ func (h *FormHandler) Create(c *fiber.Ctx) error {
 // @Param limit query int false "Items per page (default: 20, max: 100)"
 // @Param sortBy query string false "Sort field: createdAt, company, name (default: createdAt)"
 // @Param sortOrder query string false "Sort order: asc, desc (default: desc)"
+// @Param cursor query string false "Opaque cursor from meta.cursor of the previous page (createdAt sort only); page is ignored when set"
+// @Param count query bool false "Set to false to skip computing total and total_pages"
 // @Success 200 {object} response.Response{data=[]form.FormSubmission,meta=response.Meta} "List of submissions with pagination"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid cursor"
 // @Failure 500 {object} response.Response{error=string} "Internal server error"
 // @Router /api/v1/forms/submissions [get]
 func (h *FormHandler) GetAll(c *fiber.Ctx) error {
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "20"))
-
-	if page < 1 {
-		page = 1
-	}
-	if limit < 1 || limit > 100 {
-		limit = 20
+	params, err := parsePagination(c)
+	if err != nil {
+		return response.BadRequest(c, err.Error())
 	}
 
 	query := form.GetSubmissionsQuery{
@@ -89,10 +88,18 @@ func (h *FormHandler) GetAll(c *fiber.Ctx) error {
 		DateFrom:  c.Query("dateFrom", ""),
 		DateTo:    c.Query("dateTo", ""),
 		Search:    c.Query("search", ""),
-		Page:      page,
-		Limit:     limit,
+		Page:      params.Page,
+		Limit:     params.Limit,
 		SortBy:    c.Query("sortBy", ""),
 		SortOrder: c.Query("sortOrder", ""),
+		Cursor:    params.Cursor,
+		SkipCount: params.SkipCount,
+	}
+
+	// Keyset cursors are built from (created_at, id), so other sort fields can't use them
+	keysetSort := query.SortBy == "" || query.SortBy == "createdAt"
+	if query.Cursor != nil && !keysetSort {
+		return response.BadRequest(c, "Cursor pagination is only supported when sorting by createdAt")
 	}
 
 	submissions, total, err := h.service.GetAll(query)
@@ -100,16 +107,13 @@ func (h *FormHandler) GetAll(c *fiber.Ctx) error {
 		return response.InternalError(c, "Failed to fetch submissions")
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(limit)))
-
-	meta := &response.Meta{
-		Page:       page,
-		Limit:      limit,
-		Total:      total,
-		TotalPages: totalPages,
+	var nextCursor string
+	if keysetSort && len(submissions) > 0 {
+		last := submissions[len(submissions)-1]
+		nextCursor = pagination.Next(len(submissions), params.Limit, last.CreatedAt, last.ID)
 	}
 
-	return response.SuccessWithMeta(c, submissions, meta)
+	return response.SuccessWithMeta(c, submissions, paginationMeta(params, total, nextCursor))
 }
 
 // GetByID godoc
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/healthcare-market-research/backend/pkg/response"
+)
+
+type GeographyHandler struct {
+	service service.GeographyService
+}
+
+func NewGeographyHandler(service service.GeographyService) *GeographyHandler {
+	return &GeographyHandler{service: service}
+}
+
+// GetAll godoc
+// @Summary Get the geography taxonomy
+// @Description Get every geography reports can be tagged with: Global, its regions and their countries (keyed by ISO 3166-1 alpha-2 code). Pass tree=true to get them nested as Global > Region > Country.
+// @Tags Geographies
+// @Accept json
+// @Produce json
+// @Param tree query bool false "Return the geographies nested under their parents"
+// @Success 200 {object} response.Response{data=[]geography.Geography} "List of geographies"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/geographies [get]
+func (h *GeographyHandler) GetAll(c *fiber.Ctx) error {
+	if c.Query("tree") == "true" {
+		tree, err := h.service.GetTree()
+		if err != nil {
+			return response.InternalError(c, "Failed to fetch geographies")
+		}
+		return response.Success(c, tree)
+	}
+
+	geographies, err := h.service.GetAll()
+	if err != nil {
+		return response.InternalError(c, "Failed to fetch geographies")
+	}
+
+	return response.Success(c, geographies)
+}
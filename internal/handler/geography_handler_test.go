@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/geography"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockGeographyService is a mock implementation of GeographyService
+type MockGeographyService struct {
+	mock.Mock
+}
+
+func (m *MockGeographyService) GetAll() ([]geography.Geography, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]geography.Geography), args.Error(1)
+}
+
+func (m *MockGeographyService) GetTree() ([]geography.Geography, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]geography.Geography), args.Error(1)
+}
+
+func (m *MockGeographyService) Taxonomy() (*geography.Taxonomy, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*geography.Taxonomy), args.Error(1)
+}
+
+func setupGeographyTestApp(handler *GeographyHandler) *fiber.App {
+	app := fiber.New()
+	app.Get("/api/v1/geographies", handler.GetAll)
+	return app
+}
+
+func TestGeographyHandler_GetAll(t *testing.T) {
+	mockService := new(MockGeographyService)
+	app := setupGeographyTestApp(NewGeographyHandler(mockService))
+
+	nam := "NAM"
+	mockService.On("GetAll").Return([]geography.Geography{
+		{Code: "NAM", Name: "North America", Level: geography.LevelRegion},
+		{Code: "US", Name: "United States", Level: geography.LevelCountry, ParentCode: &nam},
+	}, nil).Once()
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/geographies", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data []geography.Geography `json:"data"`
+	}
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	json.Unmarshal(bodyBytes, &result)
+
+	assert.Len(t, result.Data, 2)
+	assert.Equal(t, "US", result.Data[1].Code)
+	assert.Equal(t, "NAM", *result.Data[1].ParentCode)
+	mockService.AssertExpectations(t)
+}
+
+func TestGeographyHandler_GetAll_Tree(t *testing.T) {
+	mockService := new(MockGeographyService)
+	app := setupGeographyTestApp(NewGeographyHandler(mockService))
+
+	mockService.On("GetTree").Return([]geography.Geography{
+		{Code: "GLOBAL", Name: "Global", Level: geography.LevelGlobal, Children: []geography.Geography{
+			{Code: "EUR", Name: "Europe", Level: geography.LevelRegion},
+		}},
+	}, nil).Once()
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/geographies?tree=true", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data []geography.Geography `json:"data"`
+	}
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	json.Unmarshal(bodyBytes, &result)
+
+	assert.Len(t, result.Data, 1)
+	assert.Len(t, result.Data[0].Children, 1)
+	assert.Equal(t, "Europe", result.Data[0].Children[0].Name)
+	mockService.AssertExpectations(t)
+}
+
+func TestGeographyHandler_GetAll_Error(t *testing.T) {
+	mockService := new(MockGeographyService)
+	app := setupGeographyTestApp(NewGeographyHandler(mockService))
+
+	mockService.On("GetAll").Return(nil, errors.New("db down")).Once()
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/geographies", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+}
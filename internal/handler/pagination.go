@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/pkg/pagination"
+	"github.com/healthcare-market-research/backend/pkg/response"
+)
+
+var errInvalidCursor = errors.New("Invalid cursor")
+
+// parsePagination reads page and limit along with the keyset options: ?cursor= continues
+// after a previous page and ?count=false skips the total count
+func parsePagination(c *fiber.Ctx) (pagination.Params, error) {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	params := pagination.Params{
+		Page:      page,
+		Limit:     limit,
+		SkipCount: c.Query("count") == "false",
+	}
+
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.Limit < 1 || params.Limit > 100 {
+		params.Limit = 20
+	}
+
+	if raw := c.Query("cursor"); raw != "" {
+		cursor, err := pagination.Decode(raw)
+		if err != nil {
+			return params, errInvalidCursor
+		}
+		params.Cursor = cursor
+	}
+
+	return params, nil
+}
+
+// paginationMeta builds response metadata. Page is omitted for keyset requests and
+// the totals are omitted when counting was skipped (total < 0).
+func paginationMeta(params pagination.Params, total int64, nextCursor string) *response.Meta {
+	meta := &response.Meta{
+		Limit:  params.Limit,
+		Cursor: nextCursor,
+	}
+	if params.Cursor == nil {
+		meta.Page = params.Page
+	}
+	if total >= 0 {
+		meta.Total = total
+		meta.TotalPages = int(math.Ceil(float64(total) / float64(params.Limit)))
+	}
+	return meta
+}
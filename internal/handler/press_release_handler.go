@@ -1,22 +1,29 @@
 package handler
 
 import (
+	"errors"
 	"math"
 	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/healthcare-market-research/backend/internal/domain/press_release"
+	"github.com/healthcare-market-research/backend/internal/domain/seo"
+	"github.com/healthcare-market-research/backend/internal/domain/tag"
+	"github.com/healthcare-market-research/backend/internal/repository"
 	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/healthcare-market-research/backend/pkg/pagination"
 	"github.com/healthcare-market-research/backend/pkg/response"
 )
 
 type PressReleaseHandler struct {
 	service service.PressReleaseService
+	jsonld  *seo.JSONLDBuilder
+	related service.RelatedService
 }
 
-func NewPressReleaseHandler(service service.PressReleaseService) *PressReleaseHandler {
-	return &PressReleaseHandler{service: service}
+func NewPressReleaseHandler(service service.PressReleaseService, jsonld *seo.JSONLDBuilder, related service.RelatedService) *PressReleaseHandler {
+	return &PressReleaseHandler{service: service, jsonld: jsonld, related: related}
 }
 
 // Create godoc
@@ -52,24 +59,22 @@ func (h *PressReleaseHandler) Create(c *fiber.Ctx) error {
 // @Produce json
 // @Param status query string false "Filter by status: draft, review, published"
 // @Param categoryId query int false "Filter by category ID"
-// @Param tags query string false "Filter by tags (comma-separated)"
+// @Param tags query string false "Filter by tag names or slugs (comma-separated); matches items with any of them"
 // @Param authorId query int false "Filter by author ID"
 // @Param location query string false "Filter by location"
 // @Param search query string false "Search in title, excerpt, content"
 // @Param page query int false "Page number (default: 1, min: 1)"
 // @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Param cursor query string false "Opaque cursor from the previous page; page is ignored when set (not available with search)"
+// @Param count query bool false "Set to false to skip computing total and totalPages"
 // @Success 200 {object} press_release.PressReleaseListResponse "List of press releases with pagination"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid cursor"
 // @Failure 500 {object} response.Response{error=string} "Internal server error"
 // @Router /api/v1/press-releases [get]
 func (h *PressReleaseHandler) GetAll(c *fiber.Ctx) error {
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "20"))
-
-	if page < 1 {
-		page = 1
-	}
-	if limit < 1 || limit > 100 {
-		limit = 20
+	params, err := parsePagination(c)
+	if err != nil {
+		return response.BadRequest(c, err.Error())
 	}
 
 	query := press_release.GetPressReleasesQuery{
@@ -80,8 +85,15 @@ func (h *PressReleaseHandler) GetAll(c *fiber.Ctx) error {
 		Location:   c.Query("location", ""),
 		Search:     c.Query("search", ""),
 		Deleted:    c.Query("deleted", ""),
-		Page:       page,
-		Limit:      limit,
+		Page:       params.Page,
+		Limit:      params.Limit,
+		Cursor:     params.Cursor,
+		SkipCount:  params.SkipCount,
+	}
+
+	// Search results are ranked by relevance, which has no stable keyset
+	if query.Search != "" && query.Cursor != nil {
+		return response.BadRequest(c, "Cursor pagination is not supported with search")
 	}
 
 	pressReleases, total, err := h.service.GetAll(query)
@@ -89,15 +101,24 @@ func (h *PressReleaseHandler) GetAll(c *fiber.Ctx) error {
 		return response.InternalError(c, "Failed to fetch press releases")
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(limit)))
-
-	return c.JSON(press_release.PressReleaseListResponse{
+	resp := press_release.PressReleaseListResponse{
 		PressReleases: pressReleases,
-		Total:         total,
-		Page:          page,
-		Limit:         limit,
-		TotalPages:    totalPages,
-	})
+		Limit:         params.Limit,
+	}
+	if params.Cursor == nil {
+		resp.Page = params.Page
+	}
+	if total >= 0 {
+		totalPages := int(math.Ceil(float64(total) / float64(params.Limit)))
+		resp.Total = &total
+		resp.TotalPages = &totalPages
+	}
+	if query.Search == "" && len(pressReleases) > 0 {
+		last := pressReleases[len(pressReleases)-1]
+		resp.Cursor = pagination.Next(len(pressReleases), params.Limit, last.CreatedAt, last.ID)
+	}
+
+	return c.JSON(resp)
 }
 
 // GetByID godoc
@@ -128,17 +149,19 @@ func (h *PressReleaseHandler) GetByID(c *fiber.Ctx) error {
 		return response.NotFound(c, "Press release not found")
 	}
 
+	setETag(c, pr.Version)
 	return c.JSON(press_release.PressReleaseResponse{PressRelease: *pr})
 }
 
 // GetBySlug godoc
 // @Summary Get press release by slug
-// @Description Get a single press release by slug
+// @Description Get a single press release by slug, with its schema.org NewsArticle in jsonLd. related lists up to six published reports, blogs and press releases: curated ones first, then suggestions.
 // @Tags PressReleases
 // @Accept json
 // @Produce json
 // @Param slug path string true "Press release slug"
 // @Success 200 {object} press_release.PressReleaseResponse "Press release details"
+// @Failure 301 {object} response.Response{data=redirect.Target,error=string} "Slug was retired - Location points at the current URL"
 // @Failure 400 {object} response.Response{error=string} "Bad request - invalid slug"
 // @Failure 404 {object} response.Response{error=string} "Press release not found"
 // @Failure 500 {object} response.Response{error=string} "Internal server error"
@@ -151,23 +174,35 @@ func (h *PressReleaseHandler) GetBySlug(c *fiber.Ctx) error {
 
 	pr, err := h.service.GetBySlug(slug)
 	if err != nil {
+		var moved *service.SlugMovedError
+		if errors.As(err, &moved) {
+			return redirectTo(c, moved.Target)
+		}
 		return response.NotFound(c, "Press release not found")
 	}
 
-	return c.JSON(press_release.PressReleaseResponse{PressRelease: *pr})
+	setETag(c, pr.Version)
+	resp := press_release.PressReleaseResponse{PressRelease: *pr, Related: relatedItems(h.related, tag.ContentPressRelease, pr.ID)}
+	if h.jsonld != nil {
+		resp.JSONLD = h.jsonld.PressRelease(pr)
+	}
+	return c.JSON(resp)
 }
 
 // Update godoc
 // @Summary Update press release
-// @Description Update an existing press release
+// @Description Update an existing press release. Send the version being edited as an If-Match header (the ETag from a read) or a version field; a stale version gets 409 with the current copy.
 // @Tags PressReleases
 // @Accept json
 // @Produce json
 // @Param id path int true "Press release ID"
+// @Param If-Match header string false "ETag of the press release version being edited"
 // @Param pressRelease body press_release.UpdatePressReleaseRequest true "Press release update data"
 // @Success 200 {object} press_release.PressReleaseResponse "Press release updated successfully"
 // @Failure 400 {object} response.Response{error=string} "Bad request - invalid input or validation error"
 // @Failure 404 {object} response.Response{error=string} "Press release not found"
+// @Failure 409 {object} response.Response{data=press_release.PressRelease,error=string} "Press release was modified by another user"
+// @Failure 428 {object} response.Response{error=string} "If-Match header or version field is required"
 // @Failure 500 {object} response.Response{error=string} "Internal server error"
 // @Router /api/v1/press-releases/{id} [put]
 func (h *PressReleaseHandler) Update(c *fiber.Ctx) error {
@@ -186,14 +221,33 @@ func (h *PressReleaseHandler) Update(c *fiber.Ctx) error {
 		return response.BadRequest(c, "Invalid request body: "+err.Error())
 	}
 
+	// The edit must name the version it was based on
+	version, ok := requestVersion(c, req.Version)
+	if !ok {
+		return response.BadRequest(c, "Invalid If-Match header")
+	}
+	if version == nil {
+		return versionRequired(c)
+	}
+	req.Version = version
+
 	pr, err := h.service.Update(uint(id), &req)
 	if err != nil {
 		if err.Error() == "record not found" {
 			return response.NotFound(c, "Press release not found")
 		}
+		if errors.Is(err, repository.ErrVersionConflict) {
+			current, err := h.service.GetByID(uint(id))
+			if err != nil {
+				return response.InternalError(c, "Failed to load current press release")
+			}
+			setETag(c, current.Version)
+			return response.Conflict(c, "Press release was modified by another user", current)
+		}
 		return response.BadRequest(c, err.Error())
 	}
 
+	setETag(c, pr.Version)
 	return c.JSON(press_release.PressReleaseResponse{PressRelease: *pr})
 }
 
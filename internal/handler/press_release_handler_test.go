@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/press_release"
+	"github.com/healthcare-market-research/backend/internal/domain/seo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPressReleaseService is a mock implementation of PressReleaseService
+type MockPressReleaseService struct {
+	mock.Mock
+}
+
+func (m *MockPressReleaseService) Create(req *press_release.CreatePressReleaseRequest) (*press_release.PressRelease, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*press_release.PressRelease), args.Error(1)
+}
+
+func (m *MockPressReleaseService) GetAll(query press_release.GetPressReleasesQuery) ([]press_release.PressRelease, int64, error) {
+	args := m.Called(query)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]press_release.PressRelease), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockPressReleaseService) GetByID(id uint) (*press_release.PressRelease, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*press_release.PressRelease), args.Error(1)
+}
+
+func (m *MockPressReleaseService) GetBySlug(slug string) (*press_release.PressRelease, error) {
+	args := m.Called(slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*press_release.PressRelease), args.Error(1)
+}
+
+func (m *MockPressReleaseService) Update(id uint, req *press_release.UpdatePressReleaseRequest) (*press_release.PressRelease, error) {
+	args := m.Called(id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*press_release.PressRelease), args.Error(1)
+}
+
+func (m *MockPressReleaseService) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockPressReleaseService) SoftDelete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockPressReleaseService) Restore(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockPressReleaseService) SubmitForReview(id uint) (*press_release.PressRelease, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*press_release.PressRelease), args.Error(1)
+}
+
+func (m *MockPressReleaseService) Publish(id uint) (*press_release.PressRelease, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*press_release.PressRelease), args.Error(1)
+}
+
+func (m *MockPressReleaseService) Unpublish(id uint) (*press_release.PressRelease, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*press_release.PressRelease), args.Error(1)
+}
+
+func (m *MockPressReleaseService) SchedulePublish(id uint, publishDate time.Time) (*press_release.PressRelease, error) {
+	args := m.Called(id, publishDate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*press_release.PressRelease), args.Error(1)
+}
+
+func (m *MockPressReleaseService) CancelScheduledPublish(id uint) (*press_release.PressRelease, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*press_release.PressRelease), args.Error(1)
+}
+
+func TestPressReleaseHandler_GetBySlug_JSONLD(t *testing.T) {
+	mockService := new(MockPressReleaseService)
+	app := fiber.New()
+	h := NewPressReleaseHandler(mockService, seo.NewJSONLDBuilder("https://example.com", "HMR"), nil)
+	app.Get("/api/v1/press-releases/slug/:slug", h.GetBySlug)
+	app.Get("/api/v1/press-releases/:id", h.GetByID)
+
+	pr := &press_release.PressRelease{ID: 9, Slug: "series-b", Title: "HMR raises Series B", Location: "Boston, MA"}
+	mockService.On("GetBySlug", "series-b").Return(pr, nil).Once()
+	mockService.On("GetByID", uint(9)).Return(pr, nil).Once()
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/press-releases/slug/series-b", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result jsonLDBody
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	assert.NoError(t, json.Unmarshal(bodyBytes, &result))
+	assert.Equal(t, seo.SchemaContext, result.JSONLD.Context)
+	assert.Len(t, result.JSONLD.Graph, 1)
+	assert.Equal(t, "NewsArticle", result.JSONLD.Graph[0]["@type"])
+	assert.Equal(t, "Boston, MA", result.JSONLD.Graph[0]["dateline"])
+
+	// The admin read by ID has no structured data
+	resp, err = app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/press-releases/9", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	bodyBytes, _ = io.ReadAll(resp.Body)
+	assert.NotContains(t, string(bodyBytes), "@context")
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,307 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/domain/redirect"
+	"github.com/healthcare-market-research/backend/internal/middleware"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/healthcare-market-research/backend/pkg/response"
+)
+
+type RedirectHandler struct {
+	service      service.RedirectService
+	auditService service.AuditService
+}
+
+func NewRedirectHandler(service service.RedirectService, auditService service.AuditService) *RedirectHandler {
+	return &RedirectHandler{
+		service:      service,
+		auditService: auditService,
+	}
+}
+
+// redirectTo answers a lookup of a retired slug with a 301 to the current one
+func redirectTo(c *fiber.Ctx, target *redirect.Target) error {
+	return response.MovedPermanently(c, target.Location, target)
+}
+
+// Resolve godoc
+// @Summary Resolve a retired slug
+// @Description Look up where an old report, blog, press release or category slug points now. Answers 301 with a Location header and the target in the body.
+// @Tags Redirects
+// @Accept json
+// @Produce json
+// @Param type query string true "Entity type: report, blog, press_release, category"
+// @Param slug query string true "Old slug"
+// @Success 301 {object} response.Response{data=redirect.Target,error=string} "Location points at the current URL"
+// @Failure 400 {object} response.Response{error=string} "Bad request - missing or invalid parameters"
+// @Failure 404 {object} response.Response{error=string} "No redirect for this slug"
+// @Router /api/v1/redirects/resolve [get]
+func (h *RedirectHandler) Resolve(c *fiber.Ctx) error {
+	entityType := c.Query("type")
+	slug := c.Query("slug")
+	if entityType == "" || slug == "" {
+		return response.BadRequest(c, "type and slug are required")
+	}
+
+	target, err := h.service.Resolve(entityType, slug)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidRedirect) {
+			return response.BadRequest(c, err.Error())
+		}
+		return response.NotFound(c, "No redirect found for this slug")
+	}
+
+	return redirectTo(c, target)
+}
+
+// GetAll godoc
+// @Summary List redirects
+// @Description Get a paginated list of slug redirects, newest first. Requires admin or editor role.
+// @Tags Redirects
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param entity_type query string false "Filter by entity type"
+// @Param entity_id query int false "Filter by target entity ID"
+// @Param search query string false "Filter by old slug (substring match)"
+// @Param page query int false "Page number (default: 1, min: 1)"
+// @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Success 200 {object} response.Response{data=[]redirect.SlugRedirect,meta=response.Meta} "List of redirects"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid entity type"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/redirects [get]
+func (h *RedirectHandler) GetAll(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	filters := redirect.RedirectFilters{
+		EntityType: c.Query("entity_type"),
+		Search:     c.Query("search"),
+		Page:       page,
+		Limit:      limit,
+	}
+
+	if entityIDStr := c.Query("entity_id"); entityIDStr != "" {
+		if id, err := strconv.ParseUint(entityIDStr, 10, 32); err == nil {
+			val := uint(id)
+			filters.EntityID = &val
+		}
+	}
+
+	redirects, total, err := h.service.GetAll(filters)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidRedirect) {
+			return response.BadRequest(c, err.Error())
+		}
+		return response.InternalError(c, "Failed to fetch redirects")
+	}
+
+	meta := &response.Meta{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+	}
+
+	return response.SuccessWithMeta(c, redirects, meta)
+}
+
+// GetByID godoc
+// @Summary Get redirect
+// @Description Get a single slug redirect with the slug it currently resolves to. Requires admin or editor role.
+// @Tags Redirects
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Redirect ID"
+// @Success 200 {object} response.Response{data=redirect.SlugRedirect} "Redirect details"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid ID"
+// @Failure 404 {object} response.Response{error=string} "Redirect not found"
+// @Router /api/v1/redirects/{id} [get]
+func (h *RedirectHandler) GetByID(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid redirect ID")
+	}
+
+	rd, err := h.service.GetByID(uint(id))
+	if err != nil {
+		return h.handleWriteError(c, err, "Failed to fetch redirect")
+	}
+
+	return response.Success(c, rd)
+}
+
+// Create godoc
+// @Summary Create redirect
+// @Description Add a manual redirect from an old slug to an existing report, blog, press release or category. The old slug must not belong to a live entity. Requires admin or editor role.
+// @Tags Redirects
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param redirect body redirect.CreateRedirectRequest true "Redirect data"
+// @Success 201 {object} response.Response{data=redirect.SlugRedirect} "Created redirect"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid input, slug in use or target missing"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 409 {object} response.Response{error=string} "A redirect for this slug already exists"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/redirects [post]
+func (h *RedirectHandler) Create(c *fiber.Ctx) error {
+	var req redirect.CreateRedirectRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body: "+err.Error())
+	}
+
+	currentUser, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		return response.Unauthorized(c, "User not authenticated")
+	}
+
+	rd, err := h.service.Create(&req, currentUser.ID)
+	if err != nil {
+		return h.handleWriteError(c, err, "Failed to create redirect")
+	}
+
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionRedirectCreate)
+	entry.EntityType = audit.EntityRedirect
+	entry.EntityID = &rd.ID
+	entry.Changes = audit.Changes{
+		"entity_type": {Old: nil, New: rd.EntityType},
+		"old_slug":    {Old: nil, New: rd.OldSlug},
+		"entity_id":   {Old: nil, New: rd.EntityID},
+	}
+	h.auditService.LogAsync(entry)
+
+	return c.Status(fiber.StatusCreated).JSON(response.Response{
+		Success: true,
+		Data:    rd,
+	})
+}
+
+// Update godoc
+// @Summary Update redirect
+// @Description Change the old slug or the target of a redirect. Requires admin or editor role.
+// @Tags Redirects
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Redirect ID"
+// @Param redirect body redirect.UpdateRedirectRequest true "Fields to update"
+// @Success 200 {object} response.Response{data=redirect.SlugRedirect} "Updated redirect"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid input, slug in use or target missing"
+// @Failure 404 {object} response.Response{error=string} "Redirect not found"
+// @Failure 409 {object} response.Response{error=string} "A redirect for this slug already exists"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/redirects/{id} [put]
+func (h *RedirectHandler) Update(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid redirect ID")
+	}
+
+	var req redirect.UpdateRedirectRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body: "+err.Error())
+	}
+
+	before, err := h.service.GetByID(uint(id))
+	if err != nil {
+		return h.handleWriteError(c, err, "Failed to update redirect")
+	}
+	snapshot := *before
+
+	rd, err := h.service.Update(uint(id), &req)
+	if err != nil {
+		return h.handleWriteError(c, err, "Failed to update redirect")
+	}
+
+	changes := audit.Changes{}
+	if snapshot.OldSlug != rd.OldSlug {
+		changes["old_slug"] = audit.FieldChange{Old: snapshot.OldSlug, New: rd.OldSlug}
+	}
+	if snapshot.EntityID != rd.EntityID {
+		changes["entity_id"] = audit.FieldChange{Old: snapshot.EntityID, New: rd.EntityID}
+	}
+
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionRedirectUpdate)
+	entry.EntityType = audit.EntityRedirect
+	entry.EntityID = &rd.ID
+	entry.Changes = changes
+	h.auditService.LogAsync(entry)
+
+	return response.Success(c, rd)
+}
+
+// Delete godoc
+// @Summary Delete redirect
+// @Description Remove a redirect; the old slug then returns 404. Requires admin or editor role.
+// @Tags Redirects
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Redirect ID"
+// @Success 200 {object} response.Response{data=map[string]string} "Redirect deleted"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid ID"
+// @Failure 404 {object} response.Response{error=string} "Redirect not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/redirects/{id} [delete]
+func (h *RedirectHandler) Delete(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid redirect ID")
+	}
+
+	rd, err := h.service.GetByID(uint(id))
+	if err != nil {
+		return h.handleWriteError(c, err, "Failed to delete redirect")
+	}
+
+	if err := h.service.Delete(uint(id)); err != nil {
+		return h.handleWriteError(c, err, "Failed to delete redirect")
+	}
+
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionRedirectDelete)
+	entry.EntityType = audit.EntityRedirect
+	entry.EntityID = &rd.ID
+	entry.Changes = audit.Changes{
+		"old_slug":  {Old: rd.OldSlug, New: nil},
+		"entity_id": {Old: rd.EntityID, New: nil},
+	}
+	h.auditService.LogAsync(entry)
+
+	return response.Success(c, fiber.Map{
+		"message": "Redirect deleted successfully",
+	})
+}
+
+// handleWriteError maps redirect service errors to HTTP responses
+func (h *RedirectHandler) handleWriteError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, service.ErrRedirectNotFound):
+		return response.NotFound(c, err.Error())
+	case errors.Is(err, service.ErrRedirectExists):
+		return response.Error(c, fiber.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrInvalidRedirect),
+		errors.Is(err, service.ErrRedirectSlugInUse),
+		errors.Is(err, service.ErrRedirectTargetNotFound):
+		return response.BadRequest(c, err.Error())
+	default:
+		return response.InternalError(c, fallback)
+	}
+}
@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/domain/redirect"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockRedirectService is a mock implementation of RedirectService
+type MockRedirectService struct {
+	mock.Mock
+}
+
+func (m *MockRedirectService) Resolve(entityType, slug string) (*redirect.Target, error) {
+	args := m.Called(entityType, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*redirect.Target), args.Error(1)
+}
+
+func (m *MockRedirectService) GetAll(filters redirect.RedirectFilters) ([]redirect.SlugRedirect, int64, error) {
+	args := m.Called(filters)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]redirect.SlugRedirect), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRedirectService) GetByID(id uint) (*redirect.SlugRedirect, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*redirect.SlugRedirect), args.Error(1)
+}
+
+func (m *MockRedirectService) Create(req *redirect.CreateRedirectRequest, userID uint) (*redirect.SlugRedirect, error) {
+	args := m.Called(req, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*redirect.SlugRedirect), args.Error(1)
+}
+
+func (m *MockRedirectService) Update(id uint, req *redirect.UpdateRedirectRequest) (*redirect.SlugRedirect, error) {
+	args := m.Called(id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*redirect.SlugRedirect), args.Error(1)
+}
+
+func (m *MockRedirectService) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func setupRedirectTestApp(handler *RedirectHandler) *fiber.App {
+	app := fiber.New()
+
+	app.Get("/api/v1/redirects/resolve", handler.Resolve)
+	app.Post("/api/v1/redirects", withTestEditor, handler.Create)
+	app.Delete("/api/v1/redirects/:id", withTestEditor, handler.Delete)
+
+	return app
+}
+
+func TestRedirectHandler_Resolve(t *testing.T) {
+	t.Run("Known slug answers 301 with the current location", func(t *testing.T) {
+		mockService := new(MockRedirectService)
+		app := setupRedirectTestApp(NewRedirectHandler(mockService, &MockAuditService{}))
+
+		target := &redirect.Target{
+			EntityType: redirect.EntityBlog,
+			EntityID:   4,
+			OldSlug:    "old-title",
+			Slug:       "new-title",
+			Location:   "/api/v1/blogs/slug/new-title",
+		}
+		mockService.On("Resolve", "blog", "old-title").Return(target, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/redirects/resolve?type=blog&slug=old-title", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusMovedPermanently, resp.StatusCode)
+		assert.Equal(t, "/api/v1/blogs/slug/new-title", resp.Header.Get(fiber.HeaderLocation))
+
+		body, _ := io.ReadAll(resp.Body)
+		var result struct {
+			Data redirect.Target `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, "new-title", result.Data.Slug)
+
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Unknown slug returns 404", func(t *testing.T) {
+		mockService := new(MockRedirectService)
+		app := setupRedirectTestApp(NewRedirectHandler(mockService, &MockAuditService{}))
+
+		mockService.On("Resolve", "report", "missing").Return(nil, gorm.ErrRecordNotFound).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/redirects/resolve?type=report&slug=missing", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("Missing parameters return 400", func(t *testing.T) {
+		mockService := new(MockRedirectService)
+		app := setupRedirectTestApp(NewRedirectHandler(mockService, &MockAuditService{}))
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/redirects/resolve?slug=old-title", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		mockService.AssertNotCalled(t, "Resolve", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRedirectHandler_Create(t *testing.T) {
+	t.Run("Create manual redirect and log audit entry", func(t *testing.T) {
+		mockService := new(MockRedirectService)
+		auditService := &MockAuditService{}
+		app := setupRedirectTestApp(NewRedirectHandler(mockService, auditService))
+
+		created := &redirect.SlugRedirect{
+			ID:          3,
+			EntityType:  redirect.EntityReport,
+			OldSlug:     "legacy-oncology-report",
+			EntityID:    5,
+			IsManual:    true,
+			CurrentSlug: "oncology-market-2025",
+		}
+		mockService.On("Create", mock.MatchedBy(func(req *redirect.CreateRedirectRequest) bool {
+			return req.EntityType == "report" && req.OldSlug == "legacy-oncology-report" && req.EntityID == 5
+		}), uint(7)).Return(created, nil).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPost, "/api/v1/redirects", map[string]interface{}{
+			"entity_type": "report",
+			"old_slug":    "legacy-oncology-report",
+			"entity_id":   5,
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+		assert.Len(t, auditService.entries, 1)
+		entry := auditService.entries[0]
+		assert.Equal(t, audit.ActionRedirectCreate, entry.Action)
+		assert.Equal(t, audit.EntityRedirect, entry.EntityType)
+		assert.Equal(t, "legacy-oncology-report", entry.Changes["old_slug"].New)
+
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Duplicate slug returns 409", func(t *testing.T) {
+		mockService := new(MockRedirectService)
+		auditService := &MockAuditService{}
+		app := setupRedirectTestApp(NewRedirectHandler(mockService, auditService))
+
+		mockService.On("Create", mock.Anything, uint(7)).Return(nil, service.ErrRedirectExists).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPost, "/api/v1/redirects", map[string]interface{}{
+			"entity_type": "report",
+			"old_slug":    "legacy-oncology-report",
+			"entity_id":   5,
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+		assert.Empty(t, auditService.entries)
+	})
+
+	t.Run("Live slug returns 400", func(t *testing.T) {
+		mockService := new(MockRedirectService)
+		app := setupRedirectTestApp(NewRedirectHandler(mockService, &MockAuditService{}))
+
+		mockService.On("Create", mock.Anything, uint(7)).Return(nil, service.ErrRedirectSlugInUse).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPost, "/api/v1/redirects", map[string]interface{}{
+			"entity_type": "category",
+			"old_slug":    "diagnostics",
+			"entity_id":   2,
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestRedirectHandler_Delete(t *testing.T) {
+	mockService := new(MockRedirectService)
+	auditService := &MockAuditService{}
+	app := setupRedirectTestApp(NewRedirectHandler(mockService, auditService))
+
+	mockService.On("GetByID", uint(3)).Return(&redirect.SlugRedirect{ID: 3, EntityType: "blog", OldSlug: "old-title", EntityID: 4}, nil).Once()
+	mockService.On("Delete", uint(3)).Return(nil).Once()
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/api/v1/redirects/3", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	assert.Len(t, auditService.entries, 1)
+	assert.Equal(t, audit.ActionRedirectDelete, auditService.entries[0].Action)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestCategoryHandler_GetBySlug_Redirect(t *testing.T) {
+	mockService := new(MockCategoryService)
+	app := setupCategoryTestApp(NewCategoryHandler(mockService, &MockAuditService{}))
+
+	moved := &service.SlugMovedError{Target: &redirect.Target{
+		EntityType: redirect.EntityCategory,
+		EntityID:   2,
+		OldSlug:    "in-vitro",
+		Slug:       "in-vitro-diagnostics",
+		Location:   "/api/v1/categories/in-vitro-diagnostics",
+	}}
+	mockService.On("GetBySlug", "in-vitro").Return(nil, moved).Once()
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/categories/in-vitro", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusMovedPermanently, resp.StatusCode)
+	assert.Equal(t, "/api/v1/categories/in-vitro-diagnostics", resp.Header.Get(fiber.HeaderLocation))
+}
@@ -0,0 +1,289 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/domain/related"
+	"github.com/healthcare-market-research/backend/internal/middleware"
+	"github.com/healthcare-market-research/backend/internal/repository"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/healthcare-market-research/backend/pkg/logger"
+	"github.com/healthcare-market-research/backend/pkg/response"
+)
+
+type RelatedHandler struct {
+	service      service.RelatedService
+	auditService service.AuditService
+}
+
+func NewRelatedHandler(service service.RelatedService, auditService service.AuditService) *RelatedHandler {
+	return &RelatedHandler{
+		service:      service,
+		auditService: auditService,
+	}
+}
+
+// GetAll godoc
+// @Summary List content relations
+// @Description List relations between reports, blogs and press releases with both items, best scoring first. Pass status=suggested for the review queue, or type and id for the relations of one item. Requires admin or editor role.
+// @Tags Related
+// @Security BearerAuth
+// @Produce json
+// @Param status query string false "curated, suggested, accepted or rejected"
+// @Param type query string false "Content type of the item to list relations for: report, blog or press_release (with id)"
+// @Param id query int false "ID of the item to list relations for (with type)"
+// @Param page query int false "Page number (default: 1, min: 1)"
+// @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Success 200 {object} response.Response{data=[]related.RelationDetail,meta=response.Meta} "Relations with pagination metadata"
+// @Failure 400 {object} response.Response{error=string} "Bad request - unknown status or item"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/related [get]
+func (h *RelatedHandler) GetAll(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	filters := repository.RelationFilters{
+		Status: c.Query("status"),
+		Page:   page,
+		Limit:  limit,
+	}
+	if contentType, idStr := c.Query("type"), c.Query("id"); contentType != "" || idStr != "" {
+		id, err := strconv.ParseUint(idStr, 10, 32)
+		if err != nil {
+			return response.BadRequest(c, "type and id must be given together")
+		}
+		filters.Content = &related.Ref{Type: contentType, ID: uint(id)}
+	}
+
+	relations, total, err := h.service.GetAll(filters)
+	if err != nil {
+		return h.handleError(c, err, "Failed to fetch relations")
+	}
+
+	meta := &response.Meta{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+	}
+
+	return response.SuccessWithMeta(c, relations, meta)
+}
+
+// Create godoc
+// @Summary Relate two content items
+// @Description Link two reports, blogs or press releases; the relation shows on both. A pending or rejected suggestion for the pair becomes the curated relation. Requires admin or editor role.
+// @Tags Related
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param relation body related.CreateRelationRequest true "Items to relate"
+// @Success 201 {object} response.Response{data=related.RelationDetail} "Created relation"
+// @Failure 400 {object} response.Response{error=string} "Bad request - unknown item or item related to itself"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 409 {object} response.Response{error=string} "Items are already related"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/related [post]
+func (h *RelatedHandler) Create(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req related.CreateRelationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body: "+err.Error())
+	}
+
+	created, err := h.service.Create(&req, userID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to create relation")
+	}
+
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionRelationCreate)
+	entry.EntityType = audit.EntityRelation
+	entry.EntityID = &created.ID
+	entry.Changes = relationChanges(&created.Relation)
+	h.auditService.LogAsync(entry)
+
+	return c.Status(fiber.StatusCreated).JSON(response.Response{
+		Success: true,
+		Data:    created,
+	})
+}
+
+// Accept godoc
+// @Summary Accept a related content suggestion
+// @Description Keep a suggestion so it is listed with the curated relations. Rejected suggestions can be accepted too. Requires admin or editor role.
+// @Tags Related
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Relation ID"
+// @Success 200 {object} response.Response{data=related.Relation} "Accepted relation"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid ID or curated relation"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Relation not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/related/{id}/accept [patch]
+func (h *RelatedHandler) Accept(c *fiber.Ctx) error {
+	return h.review(c, h.service.Accept, audit.ActionRelationAccept, "Failed to accept relation")
+}
+
+// Reject godoc
+// @Summary Reject a related content suggestion
+// @Description Dismiss a suggestion. It is no longer shown and the pair is never suggested again. Accepted suggestions can be rejected too. Requires admin or editor role.
+// @Tags Related
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Relation ID"
+// @Success 200 {object} response.Response{data=related.Relation} "Rejected relation"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid ID or curated relation"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Relation not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/related/{id}/reject [patch]
+func (h *RelatedHandler) Reject(c *fiber.Ctx) error {
+	return h.review(c, h.service.Reject, audit.ActionRelationReject, "Failed to reject relation")
+}
+
+func (h *RelatedHandler) review(c *fiber.Ctx, review func(id, userID uint) (*related.Relation, error), action, fallback string) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid relation ID")
+	}
+
+	rel, err := review(uint(id), userID)
+	if err != nil {
+		return h.handleError(c, err, fallback)
+	}
+
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), action)
+	entry.EntityType = audit.EntityRelation
+	entry.EntityID = &rel.ID
+	entry.Changes = relationChanges(rel)
+	h.auditService.LogAsync(entry)
+
+	return response.Success(c, rel)
+}
+
+// Delete godoc
+// @Summary Delete content relation
+// @Description Remove a relation. A deleted suggestion may be suggested again by the next refresh; reject it to keep it away. Requires admin or editor role.
+// @Tags Related
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Relation ID"
+// @Success 200 {object} response.Response{data=related.Relation} "Deleted relation"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid ID"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Relation not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/related/{id} [delete]
+func (h *RelatedHandler) Delete(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid relation ID")
+	}
+
+	deleted, err := h.service.Delete(uint(id))
+	if err != nil {
+		return h.handleError(c, err, "Failed to delete relation")
+	}
+
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionRelationDelete)
+	entry.EntityType = audit.EntityRelation
+	entry.EntityID = &deleted.ID
+	entry.Changes = relationChanges(deleted)
+	h.auditService.LogAsync(entry)
+
+	return response.Success(c, deleted)
+}
+
+// Refresh godoc
+// @Summary Refresh related content suggestions
+// @Description Recompute suggestions from shared category, tags, geography, key players and text similarity across published content. Suggestions no longer made are removed; curated, accepted and rejected relations are kept. Also runs in the background every RELATED_REFRESH_INTERVAL. Requires admin role.
+// @Tags Related
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} response.Response{data=related.RefreshResult} "Refresh summary"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin role"
+// @Failure 409 {object} response.Response{error=string} "A refresh is already running"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/related/refresh [post]
+func (h *RelatedHandler) Refresh(c *fiber.Ctx) error {
+	result, err := h.service.Refresh()
+	if err != nil {
+		return h.handleError(c, err, "Failed to refresh suggestions")
+	}
+
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionRelationRefresh)
+	entry.EntityType = audit.EntityRelation
+	entry.Changes = audit.Changes{
+		"added":   {New: result.Added},
+		"removed": {New: result.Removed},
+	}
+	h.auditService.LogAsync(entry)
+
+	return response.Success(c, result)
+}
+
+// handleError maps related service errors to HTTP responses
+func (h *RelatedHandler) handleError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, service.ErrRelationNotFound):
+		return response.NotFound(c, err.Error())
+	case errors.Is(err, service.ErrInvalidRelation):
+		return response.BadRequest(c, err.Error())
+	case errors.Is(err, service.ErrRelationExists),
+		errors.Is(err, service.ErrRelatedRefreshRunning):
+		return response.Error(c, fiber.StatusConflict, err.Error())
+	default:
+		return response.InternalError(c, fallback)
+	}
+}
+
+// relatedItems returns the items shown as related to a content item on its
+// public read. A failed lookup leaves them out rather than failing the read.
+func relatedItems(relatedService service.RelatedService, contentType string, id uint) []related.Item {
+	if relatedService == nil {
+		return nil
+	}
+	items, err := relatedService.GetRelated(contentType, id)
+	if err != nil {
+		logger.Warn("Failed to load related content", "type", contentType, "id", id, "error", err)
+		return nil
+	}
+	return items
+}
+
+// relationChanges records which items a relation links and its status
+func relationChanges(rel *related.Relation) audit.Changes {
+	return audit.Changes{
+		"source": {New: rel.Source().String()},
+		"target": {New: rel.Target().String()},
+		"status": {New: rel.Status},
+	}
+}
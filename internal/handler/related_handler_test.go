@@ -0,0 +1,265 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/domain/related"
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/domain/tag"
+	"github.com/healthcare-market-research/backend/internal/repository"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/healthcare-market-research/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRelatedService is a mock implementation of RelatedService
+type MockRelatedService struct {
+	mock.Mock
+}
+
+func (m *MockRelatedService) GetRelated(contentType string, id uint) ([]related.Item, error) {
+	args := m.Called(contentType, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]related.Item), args.Error(1)
+}
+
+func (m *MockRelatedService) GetAll(filters repository.RelationFilters) ([]related.RelationDetail, int64, error) {
+	args := m.Called(filters)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]related.RelationDetail), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRelatedService) Create(req *related.CreateRelationRequest, userID uint) (*related.RelationDetail, error) {
+	args := m.Called(req, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*related.RelationDetail), args.Error(1)
+}
+
+func (m *MockRelatedService) Accept(id, userID uint) (*related.Relation, error) {
+	args := m.Called(id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*related.Relation), args.Error(1)
+}
+
+func (m *MockRelatedService) Reject(id, userID uint) (*related.Relation, error) {
+	args := m.Called(id, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*related.Relation), args.Error(1)
+}
+
+func (m *MockRelatedService) Delete(id uint) (*related.Relation, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*related.Relation), args.Error(1)
+}
+
+func (m *MockRelatedService) Refresh() (*related.RefreshResult, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*related.RefreshResult), args.Error(1)
+}
+
+func (m *MockRelatedService) Start(ctx context.Context) {}
+
+func (m *MockRelatedService) Stop() {}
+
+func setupRelatedTestApp(handler *RelatedHandler) *fiber.App {
+	app := fiber.New()
+	app.Use(withTestEditor)
+	app.Get("/api/v1/related", handler.GetAll)
+	app.Post("/api/v1/related", handler.Create)
+	app.Post("/api/v1/related/refresh", handler.Refresh)
+	app.Patch("/api/v1/related/:id/accept", handler.Accept)
+	app.Patch("/api/v1/related/:id/reject", handler.Reject)
+	app.Delete("/api/v1/related/:id", handler.Delete)
+	return app
+}
+
+func TestRelatedHandler_GetAll(t *testing.T) {
+	t.Run("Lists the relations of one item", func(t *testing.T) {
+		mockService := new(MockRelatedService)
+		app := setupRelatedTestApp(NewRelatedHandler(mockService, &MockAuditService{}))
+
+		mockService.On("GetAll", repository.RelationFilters{
+			Status:  related.StatusSuggested,
+			Content: &related.Ref{Type: tag.ContentReport, ID: 12},
+			Page:    1,
+			Limit:   20,
+		}).Return([]related.RelationDetail{}, int64(0), nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/related?status=suggested&type=report&id=12", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Fail with a type but no id", func(t *testing.T) {
+		mockService := new(MockRelatedService)
+		app := setupRelatedTestApp(NewRelatedHandler(mockService, &MockAuditService{}))
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/related?type=report", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		mockService.AssertNotCalled(t, "GetAll")
+	})
+}
+
+func TestRelatedHandler_Create(t *testing.T) {
+	t.Run("Successfully relate a press release to its report", func(t *testing.T) {
+		mockService := new(MockRelatedService)
+		auditService := &MockAuditService{}
+		app := setupRelatedTestApp(NewRelatedHandler(mockService, auditService))
+
+		detail := &related.RelationDetail{Relation: related.Relation{ID: 3, Status: related.StatusCurated}}
+		detail.SetPair(related.Ref{Type: tag.ContentPressRelease, ID: 7}, related.Ref{Type: tag.ContentReport, ID: 12})
+		mockService.On("Create", mock.MatchedBy(func(req *related.CreateRelationRequest) bool {
+			return req.SourceType == tag.ContentPressRelease && req.SourceID == 7 && req.TargetID == 12
+		}), uint(7)).Return(detail, nil).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPost, "/api/v1/related", map[string]interface{}{
+			"source_type": "press_release",
+			"source_id":   7,
+			"target_type": "report",
+			"target_id":   12,
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+		assert.Len(t, auditService.entries, 1)
+		assert.Equal(t, audit.ActionRelationCreate, auditService.entries[0].Action)
+		assert.Equal(t, "press_release:7", auditService.entries[0].Changes["source"].New)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Fail when already related", func(t *testing.T) {
+		mockService := new(MockRelatedService)
+		app := setupRelatedTestApp(NewRelatedHandler(mockService, &MockAuditService{}))
+
+		mockService.On("Create", mock.Anything, uint(7)).Return(nil, service.ErrRelationExists).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPost, "/api/v1/related", map[string]interface{}{
+			"source_type": "blog", "source_id": 4, "target_type": "report", "target_id": 12,
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	})
+}
+
+func TestRelatedHandler_Review(t *testing.T) {
+	t.Run("Accept is audited", func(t *testing.T) {
+		mockService := new(MockRelatedService)
+		auditService := &MockAuditService{}
+		app := setupRelatedTestApp(NewRelatedHandler(mockService, auditService))
+
+		mockService.On("Accept", uint(9), uint(7)).Return(&related.Relation{ID: 9, Status: related.StatusAccepted}, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodPatch, "/api/v1/related/9/accept", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Len(t, auditService.entries, 1)
+		assert.Equal(t, audit.ActionRelationAccept, auditService.entries[0].Action)
+	})
+
+	t.Run("Fail rejecting a curated relation", func(t *testing.T) {
+		mockService := new(MockRelatedService)
+		auditService := &MockAuditService{}
+		app := setupRelatedTestApp(NewRelatedHandler(mockService, auditService))
+
+		mockService.On("Reject", uint(3), uint(7)).
+			Return(nil, errors.Join(service.ErrInvalidRelation, errors.New("curated relations are not reviewed"))).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodPatch, "/api/v1/related/3/reject", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		assert.Empty(t, auditService.entries)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		mockService := new(MockRelatedService)
+		app := setupRelatedTestApp(NewRelatedHandler(mockService, &MockAuditService{}))
+
+		mockService.On("Reject", uint(99), uint(7)).Return(nil, service.ErrRelationNotFound).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodPatch, "/api/v1/related/99/reject", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestRelatedHandler_Refresh_Running(t *testing.T) {
+	mockService := new(MockRelatedService)
+	app := setupRelatedTestApp(NewRelatedHandler(mockService, &MockAuditService{}))
+
+	mockService.On("Refresh").Return(nil, service.ErrRelatedRefreshRunning).Once()
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/api/v1/related/refresh", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+}
+
+func TestReportHandler_GetBySlug_Related(t *testing.T) {
+	t.Run("Lists related content", func(t *testing.T) {
+		mockService := new(MockReportService)
+		relatedService := new(MockRelatedService)
+		app := fiber.New()
+		app.Get("/api/v1/reports/:slug", NewReportHandler(mockService, nil, nil, nil, relatedService).GetBySlug)
+
+		mockService.On("GetBySlug", "medical-imaging-market").Return(&report.ReportWithRelations{
+			Report: report.Report{ID: 12, Slug: "medical-imaging-market"},
+		}, nil).Once()
+		relatedService.On("GetRelated", tag.ContentReport, uint(12)).Return([]related.Item{
+			{Type: tag.ContentPressRelease, ID: 7, Title: "Imaging market to double by 2030", Slug: "imaging-market-to-double", Curated: true},
+			{Type: tag.ContentBlog, ID: 4, Title: "What AI means for radiology", Slug: "ai-radiology"},
+		}, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports/medical-imaging-market", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Data report.ReportWithRelations `json:"data"`
+		}
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Len(t, body.Data.Related, 2)
+		assert.True(t, body.Data.Related[0].Curated)
+		assert.Equal(t, tag.ContentBlog, body.Data.Related[1].Type)
+	})
+
+	t.Run("A failed lookup leaves related out", func(t *testing.T) {
+		logger.Init("test")
+		mockService := new(MockReportService)
+		relatedService := new(MockRelatedService)
+		app := fiber.New()
+		app.Get("/api/v1/reports/:slug", NewReportHandler(mockService, nil, nil, nil, relatedService).GetBySlug)
+
+		mockService.On("GetBySlug", "medical-imaging-market").Return(&report.ReportWithRelations{
+			Report: report.Report{ID: 12, Slug: "medical-imaging-market"},
+		}, nil).Once()
+		relatedService.On("GetRelated", tag.ContentReport, uint(12)).Return(nil, errors.New("connection refused")).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports/medical-imaging-market", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	})
+}
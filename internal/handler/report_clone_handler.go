@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/middleware"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/healthcare-market-research/backend/pkg/response"
+)
+
+// Clone godoc
+// @Summary Clone a report
+// @Description Create a draft copy of a report in the same category, with its sections, FAQs, key players, market metrics, pricing, geography and tags. Workflow state, publish date and internal notes are not copied, and neither are charts. The title defaults to the original's with " (Copy)" and the slug to the original's with "-copy", numbered if taken. Set include_images to copy the gallery images too. Requires admin or editor role.
+// @Tags Reports
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Report ID"
+// @Param request body report.CloneRequest false "Title, slug and whether to copy images"
+// @Success 201 {object} response.Response{data=report.CloneResult} "Cloned draft"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid input, or the original's data no longer validates"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Report not found"
+// @Failure 409 {object} response.Response{error=string} "Slug already in use"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/reports/{id}/clone [post]
+func (h *ReportHandler) Clone(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid report ID")
+	}
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req report.CloneRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return response.BadRequest(c, "Invalid request body: "+err.Error())
+		}
+	}
+
+	result, err := h.service.Clone(uint(id), &req, userID)
+	if err != nil {
+		switch {
+		case err.Error() == "record not found":
+			return response.NotFound(c, "Report not found")
+		case errors.Is(err, service.ErrReportSlugTaken):
+			return response.Error(c, fiber.StatusConflict, err.Error())
+		case errors.Is(err, service.ErrInvalidClone), errors.Is(err, service.ErrInvalidMarketMetrics),
+			errors.Is(err, service.ErrInvalidKeyPlayers), errors.Is(err, service.ErrInvalidGeography),
+			errors.Is(err, service.ErrInvalidTags):
+			return response.BadRequest(c, err.Error())
+		default:
+			return response.InternalError(c, "Failed to clone report")
+		}
+	}
+
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionReportClone)
+	entry.EntityType = audit.EntityReport
+	entry.EntityID = &result.Report.ID
+	entry.Changes = audit.Changes{
+		"cloned_from": {Old: nil, New: result.ClonedFrom},
+		"slug":        {Old: nil, New: result.Report.Slug},
+	}
+	if result.Images > 0 {
+		entry.Changes["images"] = audit.FieldChange{Old: nil, New: result.Images}
+	}
+	h.auditService.LogAsync(entry)
+
+	return c.Status(fiber.StatusCreated).JSON(response.Response{
+		Success: true,
+		Data:    result,
+	})
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func setupReportCloneTestApp(handler *ReportHandler) *fiber.App {
+	app := fiber.New()
+	app.Use(withTestEditor)
+	app.Post("/api/v1/reports", handler.Create)
+	app.Post("/api/v1/reports/:id/clone", handler.Clone)
+	return app
+}
+
+func TestReportHandler_Clone(t *testing.T) {
+	t.Run("Clone with images is audited", func(t *testing.T) {
+		mockService := new(MockReportService)
+		auditService := &MockAuditService{}
+		app := setupReportCloneTestApp(NewReportHandler(mockService, nil, auditService, nil, nil))
+
+		mockService.On("Clone", uint(12), &report.CloneRequest{IncludeImages: true}, uint(7)).Return(&report.CloneResult{
+			Report:     &report.Report{ID: 40, Slug: "cardiac-monitoring-market-copy", Status: report.StatusDraft},
+			ClonedFrom: 12,
+			Images:     3,
+		}, nil).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPost, "/api/v1/reports/12/clone", map[string]interface{}{"include_images": true}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+		assert.Len(t, auditService.entries, 1)
+		entry := auditService.entries[0]
+		assert.Equal(t, audit.ActionReportClone, entry.Action)
+		assert.Equal(t, uint(40), *entry.EntityID)
+		assert.Equal(t, uint(12), entry.Changes["cloned_from"].New)
+		assert.Equal(t, 3, entry.Changes["images"].New)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("An empty body clones with defaults", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupReportCloneTestApp(NewReportHandler(mockService, nil, &MockAuditService{}, nil, nil))
+
+		mockService.On("Clone", uint(12), &report.CloneRequest{}, uint(7)).Return(&report.CloneResult{
+			Report: &report.Report{ID: 41}, ClonedFrom: 12,
+		}, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/api/v1/reports/12/clone", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Fail when the slug is taken", func(t *testing.T) {
+		mockService := new(MockReportService)
+		auditService := &MockAuditService{}
+		app := setupReportCloneTestApp(NewReportHandler(mockService, nil, auditService, nil, nil))
+
+		mockService.On("Clone", uint(12), mock.Anything, uint(7)).
+			Return(nil, fmt.Errorf("%w: cardiac-monitoring-europe", service.ErrReportSlugTaken)).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPost, "/api/v1/reports/12/clone", map[string]interface{}{"slug": "cardiac-monitoring-europe"}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+		assert.Empty(t, auditService.entries)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupReportCloneTestApp(NewReportHandler(mockService, nil, &MockAuditService{}, nil, nil))
+
+		mockService.On("Clone", uint(99), mock.Anything, uint(7)).Return(nil, gorm.ErrRecordNotFound).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/api/v1/reports/99/clone", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestReportHandler_Create_FromTemplate(t *testing.T) {
+	body := map[string]interface{}{
+		"title":       "Cardiac Monitoring Market",
+		"slug":        "cardiac-monitoring-market",
+		"category_id": 4,
+		"summary":     "Cardiac monitoring devices and services across hospitals and homes.",
+		"templateId":  3,
+	}
+
+	t.Run("The template fills in geography", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupReportCloneTestApp(NewReportHandler(mockService, nil, &MockAuditService{}, nil, nil))
+
+		mockService.On("ApplyTemplate", uint(3), mock.AnythingOfType("*report.Report")).Run(func(args mock.Arguments) {
+			rep := args.Get(1).(*report.Report)
+			rep.Geography = report.StringSlice{"Global"}
+			rep.Sections.TableOfContents = "1. Introduction"
+		}).Return(nil).Once()
+		mockService.On("Create", mock.MatchedBy(func(rep *report.Report) bool {
+			return rep.Title == "Cardiac Monitoring Market" && len(rep.Geography) == 1 &&
+				rep.Sections.TableOfContents == "1. Introduction"
+		}), uint(7)).Return(nil).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPost, "/api/v1/reports", body))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Fail with an unknown template", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupReportCloneTestApp(NewReportHandler(mockService, nil, &MockAuditService{}, nil, nil))
+
+		mockService.On("ApplyTemplate", uint(3), mock.Anything).Return(service.ErrTemplateNotFound).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPost, "/api/v1/reports", body))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		mockService.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/healthcare-market-research/backend/pkg/response"
+)
+
+// Compare godoc
+// @Summary Compare reports
+// @Description Compare 2 to 5 published reports side by side. reports holds the reports in the order asked for, without admin fields; every other list lines up with it. metrics compares market size, forecast size (both in USD millions at fixed reference rates) and CAGR. key_players splits companies into those several reports list and those only one does. geography lists every geography a report names and whether each report lists it or a wider region holding it.
+// @Tags Reports
+// @Produce json
+// @Param ids query string true "Comma-separated report IDs, e.g. 12,31"
+// @Success 200 {object} response.Response{data=report.Comparison} "Reports compared side by side"
+// @Failure 400 {object} response.Response{error=string} "Bad request - ids missing, invalid, or fewer than 2 or more than 5"
+// @Failure 404 {object} response.Response{error=string} "A report was not found or is not published"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/reports/compare [get]
+func (h *ReportHandler) Compare(c *fiber.Ctx) error {
+	param := c.Query("ids")
+	if param == "" {
+		return response.BadRequest(c, "ids is required")
+	}
+
+	var ids []uint
+	for _, value := range strings.Split(param, ",") {
+		id, err := strconv.ParseUint(strings.TrimSpace(value), 10, 32)
+		if err != nil || id == 0 {
+			return response.BadRequest(c, "Invalid report ID: "+value)
+		}
+		ids = append(ids, uint(id))
+	}
+
+	comparison, err := h.service.Compare(ids)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidComparison):
+			return response.BadRequest(c, err.Error())
+		case errors.Is(err, service.ErrComparedReportNotFound):
+			return response.NotFound(c, err.Error())
+		default:
+			return response.InternalError(c, "Failed to compare reports")
+		}
+	}
+
+	// Compared reports are public whoever asks
+	comparison.Reports = stripAdminFields(comparison.Reports)
+	return response.Success(c, comparison)
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupReportCompareTestApp(handler *ReportHandler) *fiber.App {
+	app := fiber.New()
+	app.Get("/api/v1/reports/compare", handler.Compare)
+	return app
+}
+
+func TestReportHandler_Compare(t *testing.T) {
+	t.Run("Compares reports without admin fields", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupReportCompareTestApp(NewReportHandler(mockService, nil, nil, nil, nil))
+
+		editor := uint(7)
+		mockService.On("Compare", []uint{12, 31}).Return(&report.Comparison{
+			Reports: []report.Report{
+				{ID: 12, Title: "Cardiac Monitoring Market", CreatedBy: &editor, InternalNotes: "pricing under review"},
+				{ID: 31, Title: "Remote Patient Monitoring Market", ReviewerID: &editor},
+			},
+		}, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports/compare?ids=12,%2031", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Data struct {
+				Reports []map[string]interface{} `json:"reports"`
+			} `json:"data"`
+		}
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Len(t, body.Data.Reports, 2)
+		for _, rep := range body.Data.Reports {
+			assert.NotContains(t, rep, "created_by")
+			assert.NotContains(t, rep, "internal_notes")
+			assert.NotContains(t, rep, "reviewer_id")
+		}
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Fail with an invalid ID", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupReportCompareTestApp(NewReportHandler(mockService, nil, nil, nil, nil))
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports/compare?ids=12,cardiac", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		mockService.AssertNotCalled(t, "Compare", mock.Anything)
+	})
+
+	t.Run("Fail with too few reports", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupReportCompareTestApp(NewReportHandler(mockService, nil, nil, nil, nil))
+
+		mockService.On("Compare", []uint{12}).
+			Return(nil, fmt.Errorf("%w: compare between 2 and 5 different reports", service.ErrInvalidComparison)).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports/compare?ids=12", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("Unpublished report returns 404", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupReportCompareTestApp(NewReportHandler(mockService, nil, nil, nil, nil))
+
+		mockService.On("Compare", []uint{12, 40}).
+			Return(nil, fmt.Errorf("%w: 40", service.ErrComparedReportNotFound)).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports/compare?ids=12,40", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+}
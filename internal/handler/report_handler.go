@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"math"
 	"strconv"
 	"strings"
@@ -8,21 +9,30 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/domain/seo"
+	"github.com/healthcare-market-research/backend/internal/domain/tag"
 	"github.com/healthcare-market-research/backend/internal/domain/user"
 	"github.com/healthcare-market-research/backend/internal/repository"
 	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/healthcare-market-research/backend/pkg/pagination"
 	"github.com/healthcare-market-research/backend/pkg/response"
 )
 
 type ReportHandler struct {
-	service    service.ReportService
-	authorRepo repository.AuthorRepository
+	service      service.ReportService
+	authorRepo   repository.AuthorRepository
+	auditService service.AuditService
+	jsonld       *seo.JSONLDBuilder
+	related      service.RelatedService
 }
 
-func NewReportHandler(service service.ReportService, authorRepo repository.AuthorRepository) *ReportHandler {
+func NewReportHandler(service service.ReportService, authorRepo repository.AuthorRepository, auditService service.AuditService, jsonld *seo.JSONLDBuilder, related service.RelatedService) *ReportHandler {
 	return &ReportHandler{
-		service:    service,
-		authorRepo: authorRepo,
+		service:      service,
+		authorRepo:   authorRepo,
+		auditService: auditService,
+		jsonld:       jsonld,
+		related:      related,
 	}
 }
 
@@ -47,6 +57,13 @@ func stripAdminFields(reports []report.Report) []report.Report {
 		reports[i].CreatedBy = nil
 		reports[i].UpdatedBy = nil
 		reports[i].InternalNotes = ""
+		reports[i].ReviewerID = nil
+		reports[i].SubmittedBy = nil
+		reports[i].SubmittedAt = nil
+		reports[i].ReviewedBy = nil
+		reports[i].ReviewedAt = nil
+		reports[i].RejectionReason = ""
+		reports[i].MetricsUnparsed = false
 	}
 	return reports
 }
@@ -72,10 +89,22 @@ func getUserID(c *fiber.Ctx) (uint, error) {
 // @Produce json
 // @Param page query int false "Page number (default: 1, min: 1)"
 // @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Param cursor query string false "Opaque cursor from meta.cursor of the previous page (not with sort_by or search); page is ignored when set"
+// @Param count query bool false "Set to false to skip computing total and total_pages"
 // @Param status query string false "Filter by status (draft or published)"
 // @Param category query string false "Filter by category slug"
-// @Param geography query string false "Filter by geography (comma-separated, e.g., 'North America,Europe')"
-// @Param search query string false "Search in title, summary, and description"
+// @Param geography query string false "Filter by geography names, aliases or ISO codes (comma-separated, e.g., 'North America,DE'). A region also matches reports tagged with any of its countries."
+// @Param tags query string false "Filter by tag names or slugs (comma-separated); matches reports with any of them"
+// @Param search query string false "Full-text search in title, summary, description and sections"
+// @Param price_band query string false "Filter by price band (under-2000, 2000-3999, 4000-5999, 6000-plus)"
+// @Param forecast_year query int false "Filter by market metrics forecast year"
+// @Param market_size_min query number false "Minimum current market size in USD millions"
+// @Param market_size_max query number false "Maximum current market size in USD millions"
+// @Param cagr_min query number false "Minimum CAGR in percent"
+// @Param cagr_max query number false "Maximum CAGR in percent"
+// @Param sort_by query string false "Sort by market_size, forecast_size or cagr (reports without the value go last)"
+// @Param sort_order query string false "Sort direction: asc or desc (default: desc)"
+// @Param facets query bool false "Return facet counts for category, geography, price band and forecast year. When true, data is {reports, facets}."
 // @Param deleted query string false "Admin only: Show deleted reports (true/false, default: false)"
 // @Param created_by query int false "Admin only: Filter by creator user ID"
 // @Param updated_by query int false "Admin only: Filter by last updater user ID"
@@ -85,26 +114,69 @@ func getUserID(c *fiber.Ctx) (uint, error) {
 // @Param updated_before query string false "Admin only: Filter by updated date (ISO 8601)"
 // @Param published_after query string false "Admin only: Filter by published date (ISO 8601)"
 // @Param published_before query string false "Admin only: Filter by published date (ISO 8601)"
+// @Param metrics_unparsed query bool false "Admin only: Show only reports whose market metrics could not be parsed"
 // @Success 200 {object} response.Response{data=[]report.Report,meta=response.Meta} "List of reports with pagination metadata. Admin fields (created_by, updated_by, internal_notes) are included only for authenticated admin/editor users."
+// @Success 200 {object} response.Response{data=report.ReportListWithFacets,meta=response.Meta} "Reports with facet counts (when facets=true)"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid cursor, price band, forecast year, range or sort"
 // @Failure 500 {object} response.Response{error=string} "Internal server error"
 // @Router /api/v1/reports [get]
 func (h *ReportHandler) GetAll(c *fiber.Ctx) error {
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "20"))
-
-	if page < 1 {
-		page = 1
-	}
-	if limit < 1 || limit > 100 {
-		limit = 20
+	params, err := parsePagination(c)
+	if err != nil {
+		return response.BadRequest(c, err.Error())
 	}
+	page, limit := params.Page, params.Limit
 
 	// Check if any filters are provided
 	status := c.Query("status")
 	category := c.Query("category")
 	geographyParam := c.Query("geography")
+	tagsParam := c.Query("tags")
 	search := c.Query("search")
+	priceBand := c.Query("price_band")
 	deletedParam := c.Query("deleted")
+	withFacets := c.QueryBool("facets")
+
+	if priceBand != "" {
+		if _, ok := report.FindPriceBand(priceBand); !ok {
+			return response.BadRequest(c, "Invalid price_band")
+		}
+	}
+
+	var forecastYear *int
+	if yearStr := c.Query("forecast_year"); yearStr != "" {
+		year, err := strconv.Atoi(yearStr)
+		if err != nil || year < 1900 || year > 2200 {
+			return response.BadRequest(c, "Invalid forecast_year")
+		}
+		forecastYear = &year
+	}
+
+	marketSizeMin, msg := queryFloat(c, "market_size_min")
+	if msg != "" {
+		return response.BadRequest(c, msg)
+	}
+	marketSizeMax, msg := queryFloat(c, "market_size_max")
+	if msg != "" {
+		return response.BadRequest(c, msg)
+	}
+	cagrMin, msg := queryFloat(c, "cagr_min")
+	if msg != "" {
+		return response.BadRequest(c, msg)
+	}
+	cagrMax, msg := queryFloat(c, "cagr_max")
+	if msg != "" {
+		return response.BadRequest(c, msg)
+	}
+
+	sortBy := c.Query("sort_by")
+	if _, ok := repository.ReportSorts[sortBy]; sortBy != "" && !ok {
+		return response.BadRequest(c, "sort_by must be market_size, forecast_size or cagr")
+	}
+	sortOrder := c.Query("sort_order")
+	if sortOrder != "" && sortOrder != "asc" && sortOrder != "desc" {
+		return response.BadRequest(c, "sort_order must be asc or desc")
+	}
 
 	// Admin filters
 	var createdBy *uint
@@ -159,17 +231,27 @@ func (h *ReportHandler) GetAll(c *fiber.Ctx) error {
 	}
 
 	var reports []report.Report
+	var facets *report.ReportFacets
 	var total int64
-	var err error
+	var nextCursor string
 
 	// Parse deleted parameter
 	showDeleted := deletedParam == "true"
+	metricsUnparsed := c.Query("metrics_unparsed") == "true"
 
 	// If any filters are provided, use the filtered endpoint
-	hasFilters := status != "" || category != "" || geographyParam != "" || search != "" ||
+	hasFilters := status != "" || category != "" || geographyParam != "" || tagsParam != "" || search != "" ||
+		priceBand != "" || forecastYear != nil || withFacets ||
+		marketSizeMin != nil || marketSizeMax != nil || cagrMin != nil || cagrMax != nil || sortBy != "" ||
 		createdBy != nil || updatedBy != nil ||
 		createdAfter != nil || createdBefore != nil || updatedAfter != nil || updatedBefore != nil ||
-		publishedAfter != nil || publishedBefore != nil || showDeleted
+		publishedAfter != nil || publishedBefore != nil || showDeleted || metricsUnparsed
+
+	// Cursors follow the newest-first order; other orders page by number
+	keyset := sortBy == "" && search == ""
+	if params.Cursor != nil && !keyset {
+		return response.BadRequest(c, "Cursor pagination is not supported with sort_by or search")
+	}
 
 	if hasFilters {
 		// Parse geography into array
@@ -182,11 +264,25 @@ func (h *ReportHandler) GetAll(c *fiber.Ctx) error {
 			}
 		}
 
+		var tags []string
+		if tagsParam != "" {
+			tags = strings.Split(tagsParam, ",")
+		}
+
 		filters := repository.ReportFilters{
 			Status:          status,
 			Category:        category,
 			Geography:       geography,
+			Tags:            tags,
 			Search:          search,
+			PriceBand:       priceBand,
+			ForecastYear:    forecastYear,
+			MarketSizeMin:   marketSizeMin,
+			MarketSizeMax:   marketSizeMax,
+			CAGRMin:         cagrMin,
+			CAGRMax:         cagrMax,
+			SortBy:          sortBy,
+			SortOrder:       sortOrder,
 			CreatedBy:       createdBy,
 			UpdatedBy:       updatedBy,
 			CreatedAfter:    createdAfter,
@@ -196,14 +292,26 @@ func (h *ReportHandler) GetAll(c *fiber.Ctx) error {
 			PublishedAfter:  publishedAfter,
 			PublishedBefore: publishedBefore,
 			ShowDeleted:     showDeleted,
+			MetricsUnparsed: metricsUnparsed,
 			Page:            page,
 			Limit:           limit,
+			Cursor:          params.Cursor,
+			SkipCount:       params.SkipCount,
 		}
 
 		reports, total, err = h.service.GetAllWithFilters(filters)
+
+		if err == nil && withFacets {
+			facets, err = h.service.GetFacets(filters)
+		}
 	} else {
 		// No filters, use the default GetAll
-		reports, total, err = h.service.GetAll(page, limit)
+		reports, total, err = h.service.GetAll(params)
+	}
+
+	if err == nil && keyset && len(reports) > 0 {
+		last := reports[len(reports)-1]
+		nextCursor = pagination.Next(len(reports), limit, last.CreatedAt, last.ID)
 	}
 
 	if err != nil {
@@ -216,13 +324,13 @@ func (h *ReportHandler) GetAll(c *fiber.Ctx) error {
 		responseData = stripAdminFields(reports)
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(limit)))
+	meta := paginationMeta(params, total, nextCursor)
 
-	meta := &response.Meta{
-		Page:       page,
-		Limit:      limit,
-		Total:      total,
-		TotalPages: totalPages,
+	if facets != nil {
+		return response.SuccessWithMeta(c, report.ReportListWithFacets{
+			Reports: responseData,
+			Facets:  *facets,
+		}, meta)
 	}
 
 	return response.SuccessWithMeta(c, responseData, meta)
@@ -230,12 +338,13 @@ func (h *ReportHandler) GetAll(c *fiber.Ctx) error {
 
 // GetBySlug godoc
 // @Summary Get report by slug or ID
-// @Description Get a single healthcare market research report by its unique slug or numeric ID. If the parameter is numeric, it will be treated as an ID; otherwise, it will be treated as a slug.
+// @Description Get a single healthcare market research report by its unique slug or numeric ID. If the parameter is numeric, it will be treated as an ID; otherwise, it will be treated as a slug. The ETag header carries the report version for conditional updates. jsonLd holds the report's schema.org Product/Dataset (with offers and authors) and FAQPage. related lists up to six published blogs, press releases and reports: curated ones first, then suggestions.
 // @Tags Reports
 // @Accept json
 // @Produce json
 // @Param slug path string true "Report slug or ID"
 // @Success 200 {object} response.Response{data=report.ReportWithRelations} "Report details with relations"
+// @Failure 301 {object} response.Response{data=redirect.Target,error=string} "Slug was retired - Location points at the current URL"
 // @Failure 400 {object} response.Response{error=string} "Bad request - slug or ID is required"
 // @Failure 404 {object} response.Response{error=string} "Report not found"
 // @Router /api/v1/reports/{slug} [get]
@@ -252,29 +361,49 @@ func (h *ReportHandler) GetBySlug(c *fiber.Ctx) error {
 		if err != nil {
 			return response.NotFound(c, "Report not found")
 		}
+		setETag(c, report.Version)
+		h.attachReportJSONLD(report)
+		report.Related = relatedItems(h.related, tag.ContentReport, report.ID)
 		return response.Success(c, report)
 	}
 
 	// It's a slug
 	report, err := h.service.GetBySlug(param)
 	if err != nil {
+		var moved *service.SlugMovedError
+		if errors.As(err, &moved) {
+			return redirectTo(c, moved.Target)
+		}
 		return response.NotFound(c, "Report not found")
 	}
 
+	setETag(c, report.Version)
+	h.attachReportJSONLD(report)
+	report.Related = relatedItems(h.related, tag.ContentReport, report.ID)
 	return response.Success(c, report)
 }
 
+// attachReportJSONLD adds the report's structured data to a public read
+func (h *ReportHandler) attachReportJSONLD(rep *report.ReportWithRelations) {
+	if h.jsonld != nil {
+		rep.JSONLD = h.jsonld.Report(rep)
+	}
+}
+
 // GetByCategorySlug godoc
 // @Summary Get reports by category slug
-// @Description Get a paginated list of reports belonging to a specific category
+// @Description Get a paginated list of reports belonging to a specific category, optionally including reports from its subcategories
 // @Tags Reports
 // @Accept json
 // @Produce json
 // @Param slug path string true "Category slug"
+// @Param include_descendants query bool false "Include reports from all subcategories (default: false)"
 // @Param page query int false "Page number (default: 1, min: 1)"
 // @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Param cursor query string false "Opaque cursor from meta.cursor of the previous page; page is ignored when set"
+// @Param count query bool false "Set to false to skip computing total and total_pages"
 // @Success 200 {object} response.Response{data=[]report.ReportWithRelations,meta=response.Meta} "List of reports in category with pagination metadata"
-// @Failure 400 {object} response.Response{error=string} "Bad request - category slug is required"
+// @Failure 400 {object} response.Response{error=string} "Bad request - category slug is required or cursor is invalid"
 // @Failure 500 {object} response.Response{error=string} "Internal server error"
 // @Router /api/v1/categories/{slug}/reports [get]
 func (h *ReportHandler) GetByCategorySlug(c *fiber.Ctx) error {
@@ -283,31 +412,25 @@ func (h *ReportHandler) GetByCategorySlug(c *fiber.Ctx) error {
 		return response.BadRequest(c, "Category slug is required")
 	}
 
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "20"))
-
-	if page < 1 {
-		page = 1
-	}
-	if limit < 1 || limit > 100 {
-		limit = 20
+	params, err := parsePagination(c)
+	if err != nil {
+		return response.BadRequest(c, err.Error())
 	}
 
-	reports, total, err := h.service.GetByCategorySlug(slug, page, limit)
+	includeDescendants := c.Query("include_descendants") == "true"
+
+	reports, total, err := h.service.GetByCategorySlug(slug, includeDescendants, params)
 	if err != nil {
 		return response.InternalError(c, "Failed to fetch reports")
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(limit)))
-
-	meta := &response.Meta{
-		Page:       page,
-		Limit:      limit,
-		Total:      total,
-		TotalPages: totalPages,
+	var nextCursor string
+	if len(reports) > 0 {
+		last := reports[len(reports)-1]
+		nextCursor = pagination.Next(len(reports), params.Limit, last.CreatedAt, last.ID)
 	}
 
-	return response.SuccessWithMeta(c, reports, meta)
+	return response.SuccessWithMeta(c, reports, paginationMeta(params, total, nextCursor))
 }
 
 // GetByAuthorID godoc
@@ -317,8 +440,10 @@ func (h *ReportHandler) GetByCategorySlug(c *fiber.Ctx) error {
 // @Param id path int true "Author ID"
 // @Param page query int false "Page number (default: 1)"
 // @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Param cursor query string false "Opaque cursor from meta.cursor of the previous page; page is ignored when set"
+// @Param count query bool false "Set to false to skip computing total and total_pages"
 // @Success 200 {object} response.Response{data=[]report.Report,meta=response.Meta}
-// @Failure 400 {object} response.Response "Invalid author ID"
+// @Failure 400 {object} response.Response "Invalid author ID or cursor"
 // @Failure 404 {object} response.Response "Author not found"
 // @Failure 500 {object} response.Response "Internal server error"
 // @Router /api/v1/reports/author/{id} [get]
@@ -335,20 +460,13 @@ func (h *ReportHandler) GetByAuthorID(c *fiber.Ctx) error {
 		return response.NotFound(c, "Author not found")
 	}
 
-	// Parse pagination parameters
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "20"))
-
-	// Validate and normalize pagination
-	if page < 1 {
-		page = 1
-	}
-	if limit < 1 || limit > 100 {
-		limit = 20
+	params, err := parsePagination(c)
+	if err != nil {
+		return response.BadRequest(c, err.Error())
 	}
 
 	// Fetch reports
-	reports, total, err := h.service.GetByAuthorID(uint(authorID), page, limit)
+	reports, total, err := h.service.GetByAuthorID(uint(authorID), params)
 	if err != nil {
 		return response.InternalError(c, "Failed to fetch reports")
 	}
@@ -359,74 +477,25 @@ func (h *ReportHandler) GetByAuthorID(c *fiber.Ctx) error {
 		responseData = stripAdminFields(reports)
 	}
 
-	// Calculate pagination metadata
-	totalPages := int(math.Ceil(float64(total) / float64(limit)))
-	meta := &response.Meta{
-		Page:       page,
-		Limit:      limit,
-		Total:      total,
-		TotalPages: totalPages,
+	var nextCursor string
+	if len(reports) > 0 {
+		last := reports[len(reports)-1]
+		nextCursor = pagination.Next(len(reports), params.Limit, last.CreatedAt, last.ID)
 	}
 
-	return response.SuccessWithMeta(c, responseData, meta)
-}
-
-// Search godoc
-// @Summary Search reports
-// @Description Search for healthcare market research reports by query text (searches in title, description, and summary)
-// @Tags Reports
-// @Accept json
-// @Produce json
-// @Param q query string true "Search query text"
-// @Param page query int false "Page number (default: 1, min: 1)"
-// @Param limit query int false "Items per page (default: 20, max: 100)"
-// @Success 200 {object} response.Response{data=[]report.ReportWithRelations,meta=response.Meta} "Search results with pagination metadata"
-// @Failure 400 {object} response.Response{error=string} "Bad request - search query is required"
-// @Failure 500 {object} response.Response{error=string} "Internal server error"
-// @Router /api/v1/search [get]
-func (h *ReportHandler) Search(c *fiber.Ctx) error {
-	query := c.Query("q")
-	if query == "" {
-		return response.BadRequest(c, "Search query is required")
-	}
-
-	page, _ := strconv.Atoi(c.Query("page", "1"))
-	limit, _ := strconv.Atoi(c.Query("limit", "20"))
-
-	if page < 1 {
-		page = 1
-	}
-	if limit < 1 || limit > 100 {
-		limit = 20
-	}
-
-	reports, total, err := h.service.Search(query, page, limit)
-	if err != nil {
-		return response.InternalError(c, "Failed to search reports")
-	}
-
-	totalPages := int(math.Ceil(float64(total) / float64(limit)))
-
-	meta := &response.Meta{
-		Page:       page,
-		Limit:      limit,
-		Total:      total,
-		TotalPages: totalPages,
-	}
-
-	return response.SuccessWithMeta(c, reports, meta)
+	return response.SuccessWithMeta(c, responseData, paginationMeta(params, total, nextCursor))
 }
 
 // Create godoc
 // @Summary Create a new report
-// @Description Create a new healthcare market research report. Automatically sets created_by and updated_by to the authenticated user. Requires admin or editor role.
+// @Description Create a new healthcare market research report. Automatically sets created_by and updated_by to the authenticated user. Reports are always created as drafts and move through the editorial workflow endpoints. Market metrics strings are parsed into currentValue, forecastValue and cagrPercent; unreadable values, or a CAGR more than 0.5 points off the rate implied by the current and forecast values, are rejected. Send templateId to start from a report template: its table of contents, FAQs, geography, pricing and category fill whatever the report leaves empty. Requires admin or editor role.
 // @Tags Reports
 // @Security BearerAuth
 // @Accept json
 // @Produce json
-// @Param report body report.Report true "Report data"
+// @Param report body report.CreateReportRequest true "Report data, optionally with templateId"
 // @Success 201 {object} response.Response{data=report.Report} "Created report with auto-populated admin tracking fields"
-// @Failure 400 {object} response.Response{error=string} "Bad request - invalid input"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid input or unknown template"
 // @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
 // @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
 // @Failure 500 {object} response.Response{error=string} "Internal server error"
@@ -438,26 +507,25 @@ func (h *ReportHandler) Create(c *fiber.Ctx) error {
 		return err
 	}
 
-	var req report.Report
-	if err := c.BodyParser(&req); err != nil {
+	var body report.CreateReportRequest
+	if err := c.BodyParser(&body); err != nil {
 		return response.BadRequest(c, "Invalid request body: "+err.Error())
 	}
+	req := body.Report
 
-	// Validate required fields based on frontend expectations
-	if req.Title == "" {
-		return response.BadRequest(c, "Title is required (minimum 10 characters)")
-	}
-	if req.Slug == "" {
-		return response.BadRequest(c, "Slug is required")
-	}
-	if req.CategoryID == 0 {
-		return response.BadRequest(c, "Category ID is required")
-	}
-	if req.Summary == "" {
-		return response.BadRequest(c, "Summary is required (minimum 50 characters)")
+	// The template fills in what the report leaves out, before the checks below
+	if body.TemplateID != nil {
+		if err := h.service.ApplyTemplate(*body.TemplateID, &req); err != nil {
+			if errors.Is(err, service.ErrTemplateNotFound) {
+				return response.BadRequest(c, "Report template not found")
+			}
+			return response.InternalError(c, "Failed to apply report template")
+		}
 	}
-	if len(req.Geography) == 0 {
-		return response.BadRequest(c, "At least one geography is required")
+
+	// Validate required fields based on frontend expectations
+	if err := req.CheckRequired(); err != nil {
+		return response.BadRequest(c, err.Error())
 	}
 
 	// Set default values if not provided
@@ -466,6 +534,10 @@ func (h *ReportHandler) Create(c *fiber.Ctx) error {
 	}
 
 	if err := h.service.Create(&req, userID); err != nil {
+		if errors.Is(err, service.ErrInvalidMarketMetrics) || errors.Is(err, service.ErrInvalidKeyPlayers) ||
+			errors.Is(err, service.ErrInvalidGeography) || errors.Is(err, service.ErrInvalidTags) {
+			return response.BadRequest(c, err.Error())
+		}
 		return response.InternalError(c, "Failed to create report")
 	}
 
@@ -477,18 +549,21 @@ func (h *ReportHandler) Create(c *fiber.Ctx) error {
 
 // Update godoc
 // @Summary Update a report
-// @Description Update an existing healthcare market research report by ID. Automatically updates updated_by field. Creates version history when status changes to 'published'. Requires admin or editor role.
+// @Description Update an existing healthcare market research report by ID. Automatically updates updated_by field. Every save is recorded in the report's version history. The status cannot be changed here; use the workflow endpoints. The version the edit is based on must be sent as an If-Match header (the ETag from a read) or a version field; a stale version gets 409 with the current report. Market metrics are parsed and checked as on create once they change; metrics sent back as stored are kept, so reports flagged as unparsed can still be saved. Requires admin or editor role.
 // @Tags Reports
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param id path int true "Report ID"
+// @Param If-Match header string false "ETag of the report version being edited"
 // @Param report body report.Report true "Updated report data"
 // @Success 200 {object} response.Response{data=report.Report} "Updated report with auto-updated admin tracking fields"
 // @Failure 400 {object} response.Response{error=string} "Bad request - invalid input"
 // @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
 // @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
 // @Failure 404 {object} response.Response{error=string} "Report not found"
+// @Failure 409 {object} response.Response{data=report.ReportWithRelations,error=string} "Report was modified by another user"
+// @Failure 428 {object} response.Response{error=string} "If-Match header or version field is required"
 // @Failure 500 {object} response.Response{error=string} "Internal server error"
 // @Router /api/v1/reports/{id} [put]
 func (h *ReportHandler) Update(c *fiber.Ctx) error {
@@ -513,33 +588,69 @@ func (h *ReportHandler) Update(c *fiber.Ctx) error {
 	}
 
 	// Validate required fields
-	if req.Title == "" {
-		return response.BadRequest(c, "Title is required (minimum 10 characters)")
-	}
-	if req.Slug == "" {
-		return response.BadRequest(c, "Slug is required")
+	if err := req.CheckRequired(); err != nil {
+		return response.BadRequest(c, err.Error())
 	}
-	if req.CategoryID == 0 {
-		return response.BadRequest(c, "Category ID is required")
+
+	// The edit must name the version it was based on
+	var bodyVersion *int
+	if req.Version > 0 {
+		bodyVersion = &req.Version
 	}
-	if req.Summary == "" {
-		return response.BadRequest(c, "Summary is required (minimum 50 characters)")
+	version, ok := requestVersion(c, bodyVersion)
+	if !ok {
+		return response.BadRequest(c, "Invalid If-Match header")
 	}
-	if len(req.Geography) == 0 {
-		return response.BadRequest(c, "At least one geography is required")
+	if version == nil {
+		return versionRequired(c)
 	}
+	req.Version = *version
 
 	// Pass user ID to service for version history
 	if err := h.service.Update(uint(id), &req, currentUser.ID); err != nil {
 		if err.Error() == "record not found" {
 			return response.NotFound(c, "Report not found")
 		}
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return h.reportConflict(c, uint(id))
+		}
+		if errors.Is(err, service.ErrStatusChangeNotAllowed) || errors.Is(err, service.ErrInvalidMarketMetrics) ||
+			errors.Is(err, service.ErrInvalidKeyPlayers) || errors.Is(err, service.ErrInvalidGeography) ||
+			errors.Is(err, service.ErrInvalidTags) {
+			return response.BadRequest(c, err.Error())
+		}
 		return response.InternalError(c, "Failed to update report")
 	}
 
+	setETag(c, req.Version)
 	return response.Success(c, req)
 }
 
+// queryFloat reads an optional numeric query parameter, returning a message
+// when it is not a number
+func queryFloat(c *fiber.Ctx, key string) (*float64, string) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, ""
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil, "Invalid " + key
+	}
+	return &v, ""
+}
+
+// reportConflict answers a stale update with the current server copy
+func (h *ReportHandler) reportConflict(c *fiber.Ctx, id uint) error {
+	current, err := h.service.GetByID(id)
+	if err != nil {
+		return response.InternalError(c, "Failed to load current report")
+	}
+
+	setETag(c, current.Version)
+	return response.Conflict(c, "Report was modified by another user", current)
+}
+
 // Delete godoc
 // @Summary Hard delete a report (permanent)
 // @Description Permanently delete a healthcare market research report by ID. This action cannot be undone. The report and all associated data (images, versions, charts) will be permanently removed. Requires admin role.
@@ -0,0 +1,673 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/author"
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/domain/seo"
+	"github.com/healthcare-market-research/backend/internal/repository"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/healthcare-market-research/backend/pkg/pagination"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockReportService is a mock implementation of ReportService
+type MockReportService struct {
+	mock.Mock
+}
+
+func (m *MockReportService) GetAll(params pagination.Params) ([]report.Report, int64, error) {
+	args := m.Called(params)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]report.Report), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockReportService) GetAllWithFilters(filters repository.ReportFilters) ([]report.Report, int64, error) {
+	args := m.Called(filters)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]report.Report), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockReportService) GetFacets(filters repository.ReportFilters) (*report.ReportFacets, error) {
+	args := m.Called(filters)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*report.ReportFacets), args.Error(1)
+}
+
+func (m *MockReportService) GetByID(id uint) (*report.ReportWithRelations, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*report.ReportWithRelations), args.Error(1)
+}
+
+func (m *MockReportService) GetBySlug(slug string) (*report.ReportWithRelations, error) {
+	args := m.Called(slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*report.ReportWithRelations), args.Error(1)
+}
+
+func (m *MockReportService) GetByCategorySlug(categorySlug string, includeDescendants bool, params pagination.Params) ([]report.Report, int64, error) {
+	args := m.Called(categorySlug, includeDescendants, params)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]report.Report), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockReportService) GetByAuthorID(authorID uint, params pagination.Params) ([]report.Report, int64, error) {
+	args := m.Called(authorID, params)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]report.Report), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockReportService) Search(query string, page, limit int) ([]report.Report, int64, error) {
+	args := m.Called(query, page, limit)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]report.Report), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockReportService) Compare(ids []uint) (*report.Comparison, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*report.Comparison), args.Error(1)
+}
+
+func (m *MockReportService) Clone(id uint, req *report.CloneRequest, userID uint) (*report.CloneResult, error) {
+	args := m.Called(id, req, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*report.CloneResult), args.Error(1)
+}
+
+func (m *MockReportService) ApplyTemplate(templateID uint, rep *report.Report) error {
+	args := m.Called(templateID, rep)
+	return args.Error(0)
+}
+
+func (m *MockReportService) Create(rep *report.Report, userID uint) error {
+	args := m.Called(rep, userID)
+	return args.Error(0)
+}
+
+func (m *MockReportService) Validate(rep *report.Report) error {
+	args := m.Called(rep)
+	return args.Error(0)
+}
+
+func (m *MockReportService) Update(id uint, rep *report.Report, userID uint) error {
+	args := m.Called(id, rep, userID)
+	return args.Error(0)
+}
+
+func (m *MockReportService) Delete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockReportService) SoftDelete(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockReportService) Restore(id uint) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockReportService) SchedulePublish(id uint, publishDate time.Time) (*report.Report, error) {
+	args := m.Called(id, publishDate)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*report.Report), args.Error(1)
+}
+
+func (m *MockReportService) CancelScheduledPublish(id uint) (*report.Report, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*report.Report), args.Error(1)
+}
+
+func (m *MockReportService) ListVersions(reportID uint) ([]report.ReportVersion, error) {
+	args := m.Called(reportID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]report.ReportVersion), args.Error(1)
+}
+
+func (m *MockReportService) GetVersion(reportID uint, versionNumber int) (*report.ReportVersion, error) {
+	args := m.Called(reportID, versionNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*report.ReportVersion), args.Error(1)
+}
+
+func (m *MockReportService) DiffVersions(reportID uint, fromVersion, toVersion int) (*report.VersionDiff, error) {
+	args := m.Called(reportID, fromVersion, toVersion)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*report.VersionDiff), args.Error(1)
+}
+
+func (m *MockReportService) Rollback(reportID uint, versionNumber int, userID uint) (*report.RollbackResult, error) {
+	args := m.Called(reportID, versionNumber, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*report.RollbackResult), args.Error(1)
+}
+
+func (m *MockReportService) Transition(id uint, action string, actor report.Actor, note string) (*report.Report, error) {
+	args := m.Called(id, action, actor, note)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*report.Report), args.Error(1)
+}
+
+func (m *MockReportService) AssignReviewer(id uint, reviewerID *uint, actor report.Actor) (*report.Report, error) {
+	args := m.Called(id, reviewerID, actor)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*report.Report), args.Error(1)
+}
+
+func (m *MockReportService) AddReviewComment(id uint, actor report.Actor, body string) (*report.ReviewComment, error) {
+	args := m.Called(id, actor, body)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*report.ReviewComment), args.Error(1)
+}
+
+func (m *MockReportService) GetReviewComments(id uint) ([]report.ReviewComment, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]report.ReviewComment), args.Error(1)
+}
+
+func (m *MockReportService) PublishScheduled(now time.Time) ([]report.Report, error) {
+	args := m.Called(now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]report.Report), args.Error(1)
+}
+
+// stubAuthorRepository finds every author
+type stubAuthorRepository struct {
+	repository.AuthorRepository
+}
+
+func (r *stubAuthorRepository) GetByID(id uint) (*author.Author, error) {
+	return &author.Author{ID: id}, nil
+}
+
+func setupReportTestApp(handler *ReportHandler) *fiber.App {
+	app := fiber.New()
+	app.Get("/api/v1/reports", handler.GetAll)
+	app.Get("/api/v1/categories/:slug/reports", handler.GetByCategorySlug)
+	return app
+}
+
+func TestReportHandler_GetAll_Facets(t *testing.T) {
+	t.Run("Return reports with facet buckets", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupReportTestApp(NewReportHandler(mockService, nil, nil, nil, nil))
+
+		year := 2032
+		expectedFilters := repository.ReportFilters{
+			Status:       "published",
+			Geography:    []string{"Europe", "Asia Pacific"},
+			PriceBand:    "2000-3999",
+			ForecastYear: &year,
+			Page:         1,
+			Limit:        20,
+		}
+		reports := []report.Report{{ID: 1, Title: "Oncology Market", InternalNotes: "secret"}}
+		facets := &report.ReportFacets{
+			Category:     []report.FacetBucket{{Value: "oncology", Label: "Oncology", Count: 1}},
+			Geography:    []report.FacetBucket{{Value: "Europe", Count: 1}, {Value: "North America", Count: 4}},
+			PriceBand:    []report.FacetBucket{{Value: "2000-3999", Label: "$2,000 - $3,999", Count: 1}},
+			ForecastYear: []report.FacetBucket{{Value: "2032", Count: 1}},
+		}
+		mockService.On("GetAllWithFilters", expectedFilters).Return(reports, int64(1), nil).Once()
+		mockService.On("GetFacets", expectedFilters).Return(facets, nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet,
+			"/api/v1/reports?status=published&geography=Europe,%20Asia%20Pacific&price_band=2000-3999&forecast_year=2032&facets=true", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Data report.ReportListWithFacets `json:"data"`
+		}
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(bodyBytes, &body)
+
+		assert.Len(t, body.Data.Reports, 1)
+		assert.Empty(t, body.Data.Reports[0].InternalNotes)
+		assert.Len(t, body.Data.Facets.Geography, 2)
+		assert.Equal(t, "2032", body.Data.Facets.ForecastYear[0].Value)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Keep plain list when facets are not requested", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupReportTestApp(NewReportHandler(mockService, nil, nil, nil, nil))
+
+		mockService.On("GetAllWithFilters", mock.MatchedBy(func(f repository.ReportFilters) bool {
+			return f.PriceBand == "6000-plus"
+		})).Return([]report.Report{{ID: 2}}, int64(1), nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports?price_band=6000-plus", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Data []report.Report `json:"data"`
+		}
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		assert.NoError(t, json.Unmarshal(bodyBytes, &body))
+		assert.Len(t, body.Data, 1)
+		mockService.AssertNotCalled(t, "GetFacets", mock.Anything)
+	})
+
+	t.Run("Reject unknown price band", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupReportTestApp(NewReportHandler(mockService, nil, nil, nil, nil))
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports?price_band=cheap", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("Reject non-numeric forecast year", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupReportTestApp(NewReportHandler(mockService, nil, nil, nil, nil))
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports?forecast_year=soon", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestReportHandler_GetAll_MarketMetrics(t *testing.T) {
+	t.Run("Filter and sort by market size and CAGR", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupReportTestApp(NewReportHandler(mockService, nil, nil, nil, nil))
+
+		minSize, maxCAGR := 500.0, 12.5
+		expectedFilters := repository.ReportFilters{
+			MarketSizeMin: &minSize,
+			CAGRMax:       &maxCAGR,
+			SortBy:        "market_size",
+			SortOrder:     "asc",
+			Page:          1,
+			Limit:         20,
+		}
+		mockService.On("GetAllWithFilters", expectedFilters).
+			Return([]report.Report{{ID: 3, MetricsUnparsed: true}}, int64(1), nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet,
+			"/api/v1/reports?market_size_min=500&cagr_max=12.5&sort_by=market_size&sort_order=asc", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Data []report.Report `json:"data"`
+		}
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		assert.NoError(t, json.Unmarshal(bodyBytes, &body))
+		assert.False(t, body.Data[0].MetricsUnparsed, "admin flag is stripped for the public")
+		mockService.AssertExpectations(t)
+	})
+
+	for _, query := range []string{"market_size_min=big", "cagr_min=NaN", "sort_by=price", "sort_by=cagr&sort_order=up"} {
+		t.Run("Reject "+query, func(t *testing.T) {
+			mockService := new(MockReportService)
+			app := setupReportTestApp(NewReportHandler(mockService, nil, nil, nil, nil))
+
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports?"+query, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		})
+	}
+}
+
+func TestReportHandler_GetAll_Cursor(t *testing.T) {
+	pagination.SetSigningKey("test-secret")
+
+	t.Run("Return next cursor for a full page", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupReportTestApp(NewReportHandler(mockService, nil, nil, nil, nil))
+
+		createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+		reports := []report.Report{{ID: 9, CreatedAt: createdAt.Add(time.Hour)}, {ID: 8, CreatedAt: createdAt}}
+		mockService.On("GetAll", pagination.Params{Page: 1, Limit: 2}).Return(reports, int64(5), nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports?limit=2", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Meta struct {
+				Page       int    `json:"page"`
+				Total      int64  `json:"total"`
+				TotalPages int    `json:"total_pages"`
+				Cursor     string `json:"cursor"`
+			} `json:"meta"`
+		}
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(bodyBytes, &body)
+
+		assert.Equal(t, 1, body.Meta.Page)
+		assert.Equal(t, int64(5), body.Meta.Total)
+		assert.Equal(t, 3, body.Meta.TotalPages)
+
+		cursor, err := pagination.Decode(body.Meta.Cursor)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(8), cursor.ID)
+		assert.True(t, createdAt.Equal(cursor.CreatedAt))
+	})
+
+	t.Run("Follow cursor without counting", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupReportTestApp(NewReportHandler(mockService, nil, nil, nil, nil))
+
+		createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+		encoded := pagination.Encode(pagination.Cursor{CreatedAt: createdAt, ID: 8})
+
+		mockService.On("GetAll", mock.MatchedBy(func(p pagination.Params) bool {
+			return p.Cursor != nil && p.Cursor.ID == 8 && p.SkipCount && p.Limit == 2
+		})).Return([]report.Report{{ID: 7, CreatedAt: createdAt}}, int64(-1), nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports?limit=2&count=false&cursor="+encoded, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Meta map[string]interface{} `json:"meta"`
+		}
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(bodyBytes, &body)
+
+		assert.NotContains(t, body.Meta, "total")
+		assert.NotContains(t, body.Meta, "page")
+		assert.NotContains(t, body.Meta, "cursor", "partial page ends the listing")
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Reject tampered cursor", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupReportTestApp(NewReportHandler(mockService, nil, nil, nil, nil))
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports?cursor=bm9wZQ.bm9wZQ", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		mockService.AssertNotCalled(t, "GetAll", mock.Anything)
+	})
+
+	t.Run("Filtered listing follows cursor without counting", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupReportTestApp(NewReportHandler(mockService, nil, nil, nil, nil))
+
+		createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+		encoded := pagination.Encode(pagination.Cursor{CreatedAt: createdAt, ID: 8})
+		mockService.On("GetAllWithFilters", mock.MatchedBy(func(f repository.ReportFilters) bool {
+			return f.Status == "published" && f.Cursor != nil && f.Cursor.ID == 8 && f.SkipCount
+		})).Return([]report.Report{{ID: 6, CreatedAt: createdAt}, {ID: 5, CreatedAt: createdAt}}, int64(-1), nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports?status=published&limit=2&count=false&cursor="+encoded, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Meta map[string]interface{} `json:"meta"`
+		}
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(bodyBytes, &body)
+
+		assert.NotContains(t, body.Meta, "total")
+		cursor, err := pagination.Decode(body.Meta["cursor"].(string))
+		assert.NoError(t, err)
+		assert.Equal(t, uint(5), cursor.ID)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Reject cursor with sort_by or search", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupReportTestApp(NewReportHandler(mockService, nil, nil, nil, nil))
+
+		encoded := pagination.Encode(pagination.Cursor{CreatedAt: time.Now(), ID: 3})
+		for _, query := range []string{"sort_by=cagr", "search=oncology"} {
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports?"+query+"&cursor="+encoded, nil))
+			assert.NoError(t, err)
+			assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		}
+		mockService.AssertNotCalled(t, "GetAllWithFilters", mock.Anything)
+	})
+
+	t.Run("Author listing passes cursor through", func(t *testing.T) {
+		mockService := new(MockReportService)
+		handler := NewReportHandler(mockService, &stubAuthorRepository{}, nil, nil, nil)
+		app := fiber.New()
+		app.Get("/api/v1/reports/author/:id", handler.GetByAuthorID)
+
+		encoded := pagination.Encode(pagination.Cursor{CreatedAt: time.Now(), ID: 3})
+		mockService.On("GetByAuthorID", uint(4), mock.MatchedBy(func(p pagination.Params) bool {
+			return p.Cursor != nil && p.Cursor.ID == 3 && p.SkipCount
+		})).Return([]report.Report{}, int64(-1), nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports/author/4?count=false&cursor="+encoded, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Category listing passes cursor through", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupReportTestApp(NewReportHandler(mockService, nil, nil, nil, nil))
+
+		encoded := pagination.Encode(pagination.Cursor{CreatedAt: time.Now(), ID: 3})
+		mockService.On("GetByCategorySlug", "oncology", true, mock.MatchedBy(func(p pagination.Params) bool {
+			return p.Cursor != nil && p.Cursor.ID == 3
+		})).Return([]report.Report{}, int64(0), nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/categories/oncology/reports?include_descendants=true&cursor="+encoded, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestReportHandler_Update_Concurrency(t *testing.T) {
+	setupApp := func(handler *ReportHandler) *fiber.App {
+		app := fiber.New()
+		app.Use(withTestEditor)
+		app.Get("/api/v1/reports/:slug", handler.GetBySlug)
+		app.Put("/api/v1/reports/:id", handler.Update)
+		return app
+	}
+	validBody := map[string]interface{}{
+		"title":       "Oncology Market Report",
+		"slug":        "oncology-market",
+		"category_id": 1,
+		"summary":     "Summary of the oncology market",
+		"geography":   []string{"Global"},
+	}
+
+	t.Run("Read returns the version as ETag", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupApp(NewReportHandler(mockService, nil, nil, nil, nil))
+
+		current := &report.ReportWithRelations{Report: report.Report{ID: 5, Version: 4}}
+		mockService.On("GetBySlug", "oncology-market").Return(current, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports/oncology-market", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, `"4"`, resp.Header.Get(fiber.HeaderETag))
+	})
+
+	t.Run("Update without a version returns 428", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupApp(NewReportHandler(mockService, nil, nil, nil, nil))
+
+		resp, err := app.Test(jsonRequest(http.MethodPut, "/api/v1/reports/5", validBody))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusPreconditionRequired, resp.StatusCode)
+		mockService.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("If-Match takes precedence over the body version", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupApp(NewReportHandler(mockService, nil, nil, nil, nil))
+
+		mockService.On("Update", uint(5), mock.MatchedBy(func(r *report.Report) bool {
+			return r.Version == 4
+		}), uint(7)).Run(func(args mock.Arguments) {
+			args.Get(1).(*report.Report).Version = 5
+		}).Return(nil).Once()
+
+		body := map[string]interface{}{"version": 2}
+		for k, v := range validBody {
+			body[k] = v
+		}
+		req := jsonRequest(http.MethodPut, "/api/v1/reports/5", body)
+		req.Header.Set(fiber.HeaderIfMatch, `W/"4"`)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, `"5"`, resp.Header.Get(fiber.HeaderETag))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Stale version returns 409 with the current copy", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupApp(NewReportHandler(mockService, nil, nil, nil, nil))
+
+		mockService.On("Update", uint(5), mock.Anything, uint(7)).Return(repository.ErrVersionConflict).Once()
+		current := &report.ReportWithRelations{Report: report.Report{ID: 5, Title: "Saved by someone else", Version: 6}}
+		mockService.On("GetByID", uint(5)).Return(current, nil).Once()
+
+		body := map[string]interface{}{"version": 3}
+		for k, v := range validBody {
+			body[k] = v
+		}
+		resp, err := app.Test(jsonRequest(http.MethodPut, "/api/v1/reports/5", body))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+		assert.Equal(t, `"6"`, resp.Header.Get(fiber.HeaderETag))
+
+		var result struct {
+			Data  report.Report `json:"data"`
+			Error string        `json:"error"`
+		}
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		assert.NoError(t, json.Unmarshal(bodyBytes, &result))
+		assert.Equal(t, "Saved by someone else", result.Data.Title)
+		assert.Equal(t, 6, result.Data.Version)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Malformed If-Match returns 400", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupApp(NewReportHandler(mockService, nil, nil, nil, nil))
+
+		req := jsonRequest(http.MethodPut, "/api/v1/reports/5", validBody)
+		req.Header.Set(fiber.HeaderIfMatch, `"abc"`)
+
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("Unreadable market metrics return 400", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupApp(NewReportHandler(mockService, nil, nil, nil, nil))
+
+		mockService.On("Update", uint(5), mock.Anything, uint(7)).
+			Return(fmt.Errorf("%w: cagr: cannot read \"high\" as a CAGR", service.ErrInvalidMarketMetrics)).Once()
+
+		body := map[string]interface{}{"version": 3, "market_metrics": map[string]interface{}{"cagr": "high"}}
+		for k, v := range validBody {
+			body[k] = v
+		}
+		resp, err := app.Test(jsonRequest(http.MethodPut, "/api/v1/reports/5", body))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestReportHandler_GetBySlug_JSONLD(t *testing.T) {
+	mockService := new(MockReportService)
+	app := fiber.New()
+	h := NewReportHandler(mockService, nil, nil, seo.NewJSONLDBuilder("https://example.com", "HMR"), nil)
+	app.Get("/api/v1/reports/:slug", h.GetBySlug)
+
+	rep := &report.ReportWithRelations{Report: report.Report{
+		ID:       5,
+		Slug:     "cardiology-devices",
+		Title:    "Cardiology Devices Market",
+		Price:    2500,
+		Currency: "USD",
+		FAQs:     report.FAQs{{Question: "Who leads?", Answer: "Acme"}},
+	}}
+	mockService.On("GetBySlug", "cardiology-devices").Return(rep, nil).Once()
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports/cardiology-devices", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	var result struct {
+		Data struct {
+			JSONLD struct {
+				Context string                   `json:"@context"`
+				Graph   []map[string]interface{} `json:"@graph"`
+			} `json:"jsonLd"`
+		} `json:"data"`
+	}
+	bodyBytes, _ := io.ReadAll(resp.Body)
+	assert.NoError(t, json.Unmarshal(bodyBytes, &result))
+	assert.Equal(t, seo.SchemaContext, result.Data.JSONLD.Context)
+	assert.Len(t, result.Data.JSONLD.Graph, 2)
+	assert.Equal(t, "https://example.com/reports/cardiology-devices", result.Data.JSONLD.Graph[0]["url"])
+	assert.Equal(t, "FAQPage", result.Data.JSONLD.Graph[1]["@type"])
+}
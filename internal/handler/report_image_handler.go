@@ -1,10 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"strconv"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/report"
 	"github.com/healthcare-market-research/backend/internal/service"
 	"github.com/healthcare-market-research/backend/pkg/response"
 	"github.com/healthcare-market-research/backend/pkg/validation"
@@ -18,15 +20,9 @@ func NewReportImageHandler(service service.ReportImageService) *ReportImageHandl
 	return &ReportImageHandler{service: service}
 }
 
-// UpdateImageMetadataRequest represents the request body for updating image metadata
-type UpdateImageMetadataRequest struct {
-	Title    *string `json:"title,omitempty"`
-	IsActive *bool   `json:"is_active,omitempty"`
-}
-
 // UploadImage godoc
 // @Summary Upload report image
-// @Description Upload an image (chart, graph, diagram) for a report. Admin/editor only.
+// @Description Upload an image (chart, graph, diagram) for a report. New images are added after the existing ones. Uploading content the report already has returns the existing image with 200 instead of storing it again, reactivating it if it was deleted. Admin/editor only.
 // @Tags Report Images
 // @Security BearerAuth
 // @Accept multipart/form-data
@@ -34,6 +30,9 @@ type UpdateImageMetadataRequest struct {
 // @Param reportId path int true "Report ID"
 // @Param image formData file true "Image file (max 10MB, allowed types: JPEG, PNG, WebP, GIF)"
 // @Param title formData string false "Image title (optional, max 255 chars)"
+// @Param alt_text formData string false "Alt text for screen readers (optional, max 255 chars)"
+// @Param caption formData string false "Caption (optional, max 500 chars)"
+// @Success 200 {object} response.Response{data=report.ReportImage} "Image with the same content already on the report"
 // @Success 201 {object} response.Response{data=report.ReportImage} "Image uploaded successfully"
 // @Failure 400 {object} response.Response{error=string} "Bad request - invalid input or validation error"
 // @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
@@ -65,16 +64,23 @@ func (h *ReportImageHandler) UploadImage(c *fiber.Ctx) error {
 		return response.BadRequest(c, err.Error())
 	}
 
-	// Get optional title from form
-	title := strings.TrimSpace(c.FormValue("title"))
+	// Get optional title, alt text and caption from form
+	req := report.UploadImageRequest{
+		Title:   strings.TrimSpace(c.FormValue("title")),
+		AltText: strings.TrimSpace(c.FormValue("alt_text")),
+		Caption: strings.TrimSpace(c.FormValue("caption")),
+	}
 
 	// Validate title length if provided
-	if title != "" && (len(title) < 2 || len(title) > 255) {
+	if req.Title != "" && (len(req.Title) < 2 || len(req.Title) > 255) {
 		return response.BadRequest(c, "Title must be between 2 and 255 characters")
 	}
+	if msg := validateImageText(&req.AltText, &req.Caption); msg != "" {
+		return response.BadRequest(c, msg)
+	}
 
 	// Upload image
-	image, err := h.service.UploadImage(uint(reportID), file, title, userID)
+	image, created, err := h.service.UploadImage(uint(reportID), file, &req, userID)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return response.NotFound(c, "Report not found")
@@ -82,6 +88,9 @@ func (h *ReportImageHandler) UploadImage(c *fiber.Ctx) error {
 		return response.InternalError(c, "Failed to upload image: "+err.Error())
 	}
 
+	if !created {
+		return response.Success(c, image)
+	}
 	return c.Status(fiber.StatusCreated).JSON(response.Response{
 		Success: true,
 		Data:    image,
@@ -157,13 +166,13 @@ func (h *ReportImageHandler) GetByID(c *fiber.Ctx) error {
 
 // UpdateMetadata godoc
 // @Summary Update report image metadata
-// @Description Update image title and/or is_active status. Supports partial updates. Admin/editor only.
+// @Description Update image title, alt text, caption, sort order and/or is_active status. Supports partial updates. Admin/editor only.
 // @Tags Report Images
 // @Security BearerAuth
 // @Accept json
 // @Produce json
 // @Param imageId path int true "Image ID"
-// @Param metadata body UpdateImageMetadataRequest true "Metadata to update (all fields are optional for partial updates)"
+// @Param metadata body report.UpdateImageMetadataRequest true "Metadata to update (all fields are optional for partial updates)"
 // @Success 200 {object} response.Response{data=report.ReportImage} "Updated image"
 // @Failure 400 {object} response.Response{error=string} "Bad request - invalid input or validation error"
 // @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
@@ -179,7 +188,7 @@ func (h *ReportImageHandler) UpdateMetadata(c *fiber.Ctx) error {
 	}
 
 	// Parse request body
-	var req UpdateImageMetadataRequest
+	var req report.UpdateImageMetadataRequest
 	if err := c.BodyParser(&req); err != nil {
 		return response.BadRequest(c, "Invalid request body: "+err.Error())
 	}
@@ -192,9 +201,15 @@ func (h *ReportImageHandler) UpdateMetadata(c *fiber.Ctx) error {
 		}
 		req.Title = &title
 	}
+	if msg := validateImageText(req.AltText, req.Caption); msg != "" {
+		return response.BadRequest(c, msg)
+	}
+	if req.SortOrder != nil && *req.SortOrder < 0 {
+		return response.BadRequest(c, "Sort order must not be negative")
+	}
 
 	// Update metadata
-	image, err := h.service.UpdateImageMetadata(uint(imageID), req.Title, req.IsActive)
+	image, err := h.service.UpdateImageMetadata(uint(imageID), &req)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			return response.NotFound(c, "Image not found")
@@ -205,6 +220,45 @@ func (h *ReportImageHandler) UpdateMetadata(c *fiber.Ctx) error {
 	return response.Success(c, image)
 }
 
+// ReorderImages godoc
+// @Summary Reorder report images
+// @Description Set the display order of a report's active images. The IDs must list every active image exactly once; inactive images move after them. Admin/editor only.
+// @Tags Report Images
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param reportId path int true "Report ID"
+// @Param request body report.ReorderImagesRequest true "Active image IDs in their new order"
+// @Success 200 {object} response.Response{data=[]report.ReportImage} "Active images in their new order"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid or incomplete order"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/reports/{reportId}/images/reorder [patch]
+func (h *ReportImageHandler) ReorderImages(c *fiber.Ctx) error {
+	// Parse report ID
+	reportID, err := strconv.ParseUint(c.Params("reportId"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid report ID")
+	}
+
+	// Parse request body
+	var req report.ReorderImagesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body: "+err.Error())
+	}
+
+	images, err := h.service.ReorderImages(uint(reportID), req.IDs)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidImageOrder) {
+			return response.BadRequest(c, err.Error())
+		}
+		return response.InternalError(c, "Failed to reorder images: "+err.Error())
+	}
+
+	return response.Success(c, images)
+}
+
 // DeleteImage godoc
 // @Summary Delete report image
 // @Description Soft delete an image (sets is_active=false). Admin/editor only.
@@ -239,3 +293,21 @@ func (h *ReportImageHandler) DeleteImage(c *fiber.Ctx) error {
 		"message": "Image deleted successfully",
 	})
 }
+
+// validateImageText trims the alt text and caption of report images and
+// attachments when given and returns a message if either is too long
+func validateImageText(altText, caption *string) string {
+	if altText != nil {
+		*altText = strings.TrimSpace(*altText)
+		if len(*altText) > 255 {
+			return "Alt text must be at most 255 characters"
+		}
+	}
+	if caption != nil {
+		*caption = strings.TrimSpace(*caption)
+		if len(*caption) > 500 {
+			return "Caption must be at most 500 characters"
+		}
+	}
+	return ""
+}
@@ -4,14 +4,17 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/textproto"
 	"testing"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/service"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -21,20 +24,28 @@ type MockReportImageService struct {
 	mock.Mock
 }
 
-func (m *MockReportImageService) UploadImage(reportID uint, file *multipart.FileHeader, title string, uploadedBy uint) (*report.ReportImage, error) {
-	args := m.Called(reportID, file, title, uploadedBy)
+func (m *MockReportImageService) UploadImage(reportID uint, file *multipart.FileHeader, req *report.UploadImageRequest, uploadedBy uint) (*report.ReportImage, bool, error) {
+	args := m.Called(reportID, file, req, uploadedBy)
+	if args.Get(0) == nil {
+		return nil, false, args.Error(2)
+	}
+	return args.Get(0).(*report.ReportImage), args.Bool(1), args.Error(2)
+}
+
+func (m *MockReportImageService) UpdateImageMetadata(imageID uint, req *report.UpdateImageMetadataRequest) (*report.ReportImage, error) {
+	args := m.Called(imageID, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*report.ReportImage), args.Error(1)
 }
 
-func (m *MockReportImageService) UpdateImageMetadata(imageID uint, title *string, isActive *bool) (*report.ReportImage, error) {
-	args := m.Called(imageID, title, isActive)
+func (m *MockReportImageService) ReorderImages(reportID uint, ids []uint) ([]report.ReportImage, error) {
+	args := m.Called(reportID, ids)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*report.ReportImage), args.Error(1)
+	return args.Get(0).([]report.ReportImage), args.Error(1)
 }
 
 func (m *MockReportImageService) DeleteImage(imageID uint) error {
@@ -61,15 +72,16 @@ func (m *MockReportImageService) GetImageByID(imageID uint) (*report.ReportImage
 func setupReportImageTestApp(handler *ReportImageHandler) *fiber.App {
 	app := fiber.New()
 
-	// Setup middleware to set user_id
+	// Setup middleware to set the user the handler reads
 	app.Use(func(c *fiber.Ctx) error {
-		c.Locals("user_id", uint(5))
+		c.Locals("userID", uint(5))
 		return c.Next()
 	})
 
 	app.Post("/api/v1/reports/:reportId/images", handler.UploadImage)
 	app.Get("/api/v1/reports/:reportId/images", handler.ListImages)
 	app.Get("/api/v1/reports/images/:imageId", handler.GetByID)
+	app.Patch("/api/v1/reports/:reportId/images/reorder", handler.ReorderImages)
 	app.Patch("/api/v1/reports/images/:imageId", handler.UpdateMetadata)
 	app.Delete("/api/v1/reports/images/:imageId", handler.DeleteImage)
 
@@ -77,12 +89,15 @@ func setupReportImageTestApp(handler *ReportImageHandler) *fiber.App {
 }
 
 // Helper to create multipart form with file
-func createMultipartRequest(filename string, content string, extraFields map[string]string) (*http.Request, string) {
+func createMultipartRequest(url, filename string, content string, extraFields map[string]string) (*http.Request, string) {
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
 
-	// Add file with valid PNG header to pass validation
-	part, _ := writer.CreateFormFile("image", filename)
+	// Add file with valid PNG header and content type to pass validation
+	partHeader := make(textproto.MIMEHeader)
+	partHeader.Set("Content-Disposition", fmt.Sprintf(`form-data; name="image"; filename="%s"`, filename))
+	partHeader.Set("Content-Type", "image/png")
+	part, _ := writer.CreatePart(partHeader)
 	// Write a minimal valid PNG header
 	pngHeader := []byte{0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A}
 	part.Write(pngHeader)
@@ -94,7 +109,7 @@ func createMultipartRequest(filename string, content string, extraFields map[str
 	}
 
 	writer.Close()
-	return httptest.NewRequest(http.MethodPost, "/api/v1/reports/1/images", body), writer.FormDataContentType()
+	return httptest.NewRequest(http.MethodPost, url, body), writer.FormDataContentType()
 }
 
 func TestReportImageHandler_UploadImage(t *testing.T) {
@@ -113,9 +128,9 @@ func TestReportImageHandler_UploadImage(t *testing.T) {
 			UploadedBy: &userID,
 		}
 
-		mockService.On("UploadImage", uint(1), mock.Anything, "Test Chart", uint(5)).Return(expectedImage, nil).Once()
+		mockService.On("UploadImage", uint(1), mock.Anything, &report.UploadImageRequest{Title: "Test Chart"}, uint(5)).Return(expectedImage, true, nil).Once()
 
-		req, contentType := createMultipartRequest("chart.png", "fake image content", map[string]string{"title": "Test Chart"})
+		req, contentType := createMultipartRequest("/api/v1/reports/1/images", "chart.png", "fake image content", map[string]string{"title": "Test Chart"})
 		req.Header.Set("Content-Type", contentType)
 
 		resp, err := app.Test(req)
@@ -146,9 +161,9 @@ func TestReportImageHandler_UploadImage(t *testing.T) {
 			UploadedBy: &userID,
 		}
 
-		mockService.On("UploadImage", uint(1), mock.Anything, "", uint(5)).Return(expectedImage, nil).Once()
+		mockService.On("UploadImage", uint(1), mock.Anything, &report.UploadImageRequest{}, uint(5)).Return(expectedImage, true, nil).Once()
 
-		req, contentType := createMultipartRequest("chart.png", "fake image content", map[string]string{})
+		req, contentType := createMultipartRequest("/api/v1/reports/1/images", "chart.png", "fake image content", map[string]string{})
 		req.Header.Set("Content-Type", contentType)
 
 		resp, err := app.Test(req)
@@ -160,8 +175,7 @@ func TestReportImageHandler_UploadImage(t *testing.T) {
 	})
 
 	t.Run("Return 400 for invalid report ID", func(t *testing.T) {
-		req, contentType := createMultipartRequest("chart.png", "fake image content", map[string]string{})
-		req.URL.Path = "/api/v1/reports/invalid/images"
+		req, contentType := createMultipartRequest("/api/v1/reports/invalid/images", "chart.png", "fake image content", map[string]string{})
 		req.Header.Set("Content-Type", contentType)
 
 		resp, err := app.Test(req)
@@ -171,10 +185,9 @@ func TestReportImageHandler_UploadImage(t *testing.T) {
 	})
 
 	t.Run("Return 404 when report not found", func(t *testing.T) {
-		mockService.On("UploadImage", uint(999), mock.Anything, "", uint(5)).Return(nil, errors.New("report not found")).Once()
+		mockService.On("UploadImage", uint(999), mock.Anything, &report.UploadImageRequest{}, uint(5)).Return(nil, false, errors.New("report not found")).Once()
 
-		req, contentType := createMultipartRequest("chart.png", "fake image content", map[string]string{})
-		req.URL.Path = "/api/v1/reports/999/images"
+		req, contentType := createMultipartRequest("/api/v1/reports/999/images", "chart.png", "fake image content", map[string]string{})
 		req.Header.Set("Content-Type", contentType)
 
 		resp, err := app.Test(req)
@@ -186,7 +199,7 @@ func TestReportImageHandler_UploadImage(t *testing.T) {
 	})
 
 	t.Run("Return 400 for title too short", func(t *testing.T) {
-		req, contentType := createMultipartRequest("chart.png", "fake image content", map[string]string{"title": "A"})
+		req, contentType := createMultipartRequest("/api/v1/reports/1/images", "chart.png", "fake image content", map[string]string{"title": "A"})
 		req.Header.Set("Content-Type", contentType)
 
 		resp, err := app.Test(req)
@@ -340,9 +353,9 @@ func TestReportImageHandler_UpdateMetadata(t *testing.T) {
 			UploadedBy: &userID,
 		}
 
-		mockService.On("UpdateImageMetadata", uint(1), &newTitle, (*bool)(nil)).Return(expectedImage, nil).Once()
+		mockService.On("UpdateImageMetadata", uint(1), &report.UpdateImageMetadataRequest{Title: &newTitle}).Return(expectedImage, nil).Once()
 
-		reqBody := UpdateImageMetadataRequest{
+		reqBody := report.UpdateImageMetadataRequest{
 			Title: &newTitle,
 		}
 		bodyBytes, _ := json.Marshal(reqBody)
@@ -377,9 +390,9 @@ func TestReportImageHandler_UpdateMetadata(t *testing.T) {
 			UploadedBy: &userID,
 		}
 
-		mockService.On("UpdateImageMetadata", uint(1), (*string)(nil), &isActive).Return(expectedImage, nil).Once()
+		mockService.On("UpdateImageMetadata", uint(1), &report.UpdateImageMetadataRequest{IsActive: &isActive}).Return(expectedImage, nil).Once()
 
-		reqBody := UpdateImageMetadataRequest{
+		reqBody := report.UpdateImageMetadataRequest{
 			IsActive: &isActive,
 		}
 		bodyBytes, _ := json.Marshal(reqBody)
@@ -396,9 +409,9 @@ func TestReportImageHandler_UpdateMetadata(t *testing.T) {
 
 	t.Run("Return 404 for non-existent image", func(t *testing.T) {
 		newTitle := "Updated Title"
-		mockService.On("UpdateImageMetadata", uint(999), &newTitle, (*bool)(nil)).Return(nil, errors.New("image not found")).Once()
+		mockService.On("UpdateImageMetadata", uint(999), &report.UpdateImageMetadataRequest{Title: &newTitle}).Return(nil, errors.New("image not found")).Once()
 
-		reqBody := UpdateImageMetadataRequest{
+		reqBody := report.UpdateImageMetadataRequest{
 			Title: &newTitle,
 		}
 		bodyBytes, _ := json.Marshal(reqBody)
@@ -415,7 +428,7 @@ func TestReportImageHandler_UpdateMetadata(t *testing.T) {
 
 	t.Run("Return 400 for title too short", func(t *testing.T) {
 		shortTitle := "A"
-		reqBody := UpdateImageMetadataRequest{
+		reqBody := report.UpdateImageMetadataRequest{
 			Title: &shortTitle,
 		}
 		bodyBytes, _ := json.Marshal(reqBody)
@@ -435,6 +448,106 @@ func TestReportImageHandler_UpdateMetadata(t *testing.T) {
 	})
 }
 
+func TestReportImageHandler_UpdateMetadata_AltTextAndOrder(t *testing.T) {
+	mockService := new(MockReportImageService)
+	handler := NewReportImageHandler(mockService)
+	app := setupReportImageTestApp(handler)
+
+	t.Run("Successfully update alt text, caption and sort order", func(t *testing.T) {
+		altText := "Bar chart of market size"
+		caption := "Source: analyst estimates"
+		sortOrder := 2
+		expectedImage := &report.ReportImage{ID: 1, ReportID: 1, AltText: altText, Caption: caption, SortOrder: sortOrder, IsActive: true}
+
+		mockService.On("UpdateImageMetadata", uint(1), &report.UpdateImageMetadataRequest{
+			AltText: &altText, Caption: &caption, SortOrder: &sortOrder,
+		}).Return(expectedImage, nil).Once()
+
+		bodyBytes, _ := json.Marshal(map[string]interface{}{
+			"alt_text": "  " + altText + " ", "caption": caption, "sort_order": sortOrder,
+		})
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/reports/images/1", bytes.NewReader(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Return 400 for alt text too long", func(t *testing.T) {
+		bodyBytes, _ := json.Marshal(map[string]interface{}{"alt_text": string(bytes.Repeat([]byte("a"), 256))})
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/reports/images/1", bytes.NewReader(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("Return 400 for negative sort order", func(t *testing.T) {
+		bodyBytes, _ := json.Marshal(map[string]interface{}{"sort_order": -1})
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/reports/images/1", bytes.NewReader(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestReportImageHandler_ReorderImages(t *testing.T) {
+	mockService := new(MockReportImageService)
+	handler := NewReportImageHandler(mockService)
+	app := setupReportImageTestApp(handler)
+
+	t.Run("Successfully reorder images", func(t *testing.T) {
+		expectedImages := []report.ReportImage{
+			{ID: 3, ReportID: 1, SortOrder: 0, IsActive: true},
+			{ID: 1, ReportID: 1, SortOrder: 1, IsActive: true},
+		}
+		mockService.On("ReorderImages", uint(1), []uint{3, 1}).Return(expectedImages, nil).Once()
+
+		bodyBytes, _ := json.Marshal(report.ReorderImagesRequest{IDs: []uint{3, 1}})
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/reports/1/images/reorder", bytes.NewReader(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		body, _ := io.ReadAll(resp.Body)
+		var response map[string]interface{}
+		json.Unmarshal(body, &response)
+
+		data := response["data"].([]interface{})
+		assert.Len(t, data, 2)
+		assert.Equal(t, float64(3), data[0].(map[string]interface{})["id"].(float64))
+
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Return 400 for incomplete order", func(t *testing.T) {
+		mockService.On("ReorderImages", uint(1), []uint{3}).Return(nil, service.ErrInvalidImageOrder).Once()
+
+		bodyBytes, _ := json.Marshal(report.ReorderImagesRequest{IDs: []uint{3}})
+		req := httptest.NewRequest(http.MethodPatch, "/api/v1/reports/1/images/reorder", bytes.NewReader(bodyBytes))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+		mockService.AssertExpectations(t)
+	})
+}
+
 func TestReportImageHandler_DeleteImage(t *testing.T) {
 	mockService := new(MockReportImageService)
 	handler := NewReportImageHandler(mockService)
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/middleware"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/healthcare-market-research/backend/pkg/response"
+)
+
+type ReportImportHandler struct {
+	service      service.ReportImportService
+	auditService service.AuditService
+}
+
+func NewReportImportHandler(service service.ReportImportService, auditService service.AuditService) *ReportImportHandler {
+	return &ReportImportHandler{
+		service:      service,
+		auditService: auditService,
+	}
+}
+
+// Import godoc
+// @Summary Import reports
+// @Description Create or update reports from a CSV or NDJSON file, matching existing reports by slug. CSV columns are the report's JSON field names, plus category and authors, which take names (a category's slug works too) resolved to IDs; list cells separate values with ";", and blank cells leave the field as it is. NDJSON lines are reports as POST /api/v1/reports takes them, with optional category and authors names. Every row goes through the checks of POST /api/v1/reports and is saved on its own, so failed rows are listed with their errors while the rest are saved. New reports are drafts; updated reports keep their status. Pass dry_run=true to check the file without writing, and errors=csv to download the failed rows as CSV instead of the summary. Requires admin or editor role.
+// @Tags Reports
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Produce text/csv
+// @Param file formData file true "CSV or NDJSON file"
+// @Param format query string false "csv or ndjson; defaults to the file extension (.csv, .ndjson, .jsonl)"
+// @Param dry_run query bool false "Check every row without writing"
+// @Param errors query string false "csv to download the failed rows"
+// @Success 200 {object} response.Response{data=report.ImportResult} "Import summary with the outcome of every row"
+// @Failure 400 {object} response.Response{error=string} "Bad request - missing file, unknown format or unreadable file"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/reports/import [post]
+func (h *ReportImportHandler) Import(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	output := strings.ToLower(c.Query("errors"))
+	if output != "" && output != "csv" {
+		return response.BadRequest(c, "errors must be csv")
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		return response.BadRequest(c, "A CSV or NDJSON file is required in the file field")
+	}
+
+	format := strings.ToLower(c.Query("format"))
+	if format == "" {
+		format = report.ImportFormatFor(file.Filename)
+	}
+	if format != report.ImportFormatCSV && format != report.ImportFormatNDJSON {
+		return response.BadRequest(c, "format must be csv or ndjson")
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return response.BadRequest(c, "Failed to read file")
+	}
+	defer src.Close()
+
+	dryRun := c.QueryBool("dry_run", false)
+	result, err := h.service.Import(src, format, dryRun, userID)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidImport) {
+			return response.BadRequest(c, err.Error())
+		}
+		return response.InternalError(c, "Failed to import reports")
+	}
+
+	if !dryRun && result.Created+result.Updated > 0 {
+		entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionReportImport)
+		entry.EntityType = audit.EntityReport
+		entry.Changes = audit.Changes{
+			"file":    {New: file.Filename},
+			"created": {New: result.Created},
+			"updated": {New: result.Updated},
+			"failed":  {New: result.Failed},
+		}
+		h.auditService.LogAsync(entry)
+	}
+
+	if output == "csv" {
+		var buf bytes.Buffer
+		if err := result.WriteErrorsCSV(&buf); err != nil {
+			return response.InternalError(c, "Failed to write import errors")
+		}
+		c.Attachment("report-import-errors.csv")
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		return c.Send(buf.Bytes())
+	}
+
+	return response.Success(c, result)
+}
@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockReportImportService is a mock implementation of ReportImportService
+type MockReportImportService struct {
+	mock.Mock
+}
+
+func (m *MockReportImportService) Import(file io.Reader, format string, dryRun bool, userID uint) (*report.ImportResult, error) {
+	args := m.Called(file, format, dryRun, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*report.ImportResult), args.Error(1)
+}
+
+func setupReportImportTestApp(handler *ReportImportHandler) *fiber.App {
+	app := fiber.New()
+	app.Use(withTestEditor)
+	app.Post("/api/v1/reports/import", handler.Import)
+	return app
+}
+
+func importResult(dryRun bool) *report.ImportResult {
+	result := &report.ImportResult{DryRun: dryRun}
+	result.Add(report.ImportRowResult{Row: 2, Slug: "cardiac-monitoring-market", Action: report.ImportCreate, ReportID: 40})
+	result.Add(report.ImportRowResult{Row: 3, Slug: "dental-implants-market", Action: report.ImportUpdate, ReportID: 12})
+	result.Add(report.ImportRowResult{Row: 4, Slug: "wound-care-market", Error: `unknown category "Wound Care"`})
+	return result
+}
+
+var importCSV = []byte("slug,title\ncardiac-monitoring-market,Cardiac Monitoring Market\n")
+
+func TestReportImportHandler_Import(t *testing.T) {
+	t.Run("Dry run is not audited", func(t *testing.T) {
+		mockService := new(MockReportImportService)
+		auditService := &MockAuditService{}
+		app := setupReportImportTestApp(NewReportImportHandler(mockService, auditService))
+
+		mockService.On("Import", mock.Anything, report.ImportFormatCSV, true, uint(7)).Return(importResult(true), nil).Once()
+
+		resp, err := app.Test(multipartRequest("/api/v1/reports/import?dry_run=true", "reports.csv", importCSV, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Empty(t, auditService.entries)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("A real run is audited with its counts", func(t *testing.T) {
+		mockService := new(MockReportImportService)
+		auditService := &MockAuditService{}
+		app := setupReportImportTestApp(NewReportImportHandler(mockService, auditService))
+
+		mockService.On("Import", mock.Anything, report.ImportFormatNDJSON, false, uint(7)).Return(importResult(false), nil).Once()
+
+		resp, err := app.Test(multipartRequest("/api/v1/reports/import", "reports.jsonl", []byte(`{"slug":"a"}`), nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		assert.Len(t, auditService.entries, 1)
+		entry := auditService.entries[0]
+		assert.Equal(t, audit.ActionReportImport, entry.Action)
+		assert.Equal(t, 1, entry.Changes["created"].New)
+		assert.Equal(t, 1, entry.Changes["updated"].New)
+		assert.Equal(t, 1, entry.Changes["failed"].New)
+	})
+
+	t.Run("Download the failed rows", func(t *testing.T) {
+		mockService := new(MockReportImportService)
+		app := setupReportImportTestApp(NewReportImportHandler(mockService, &MockAuditService{}))
+
+		mockService.On("Import", mock.Anything, report.ImportFormatCSV, true, uint(7)).Return(importResult(true), nil).Once()
+
+		resp, err := app.Test(multipartRequest("/api/v1/reports/import?dry_run=true&errors=csv", "reports.csv", importCSV, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Contains(t, resp.Header.Get(fiber.HeaderContentType), "text/csv")
+		assert.Contains(t, resp.Header.Get(fiber.HeaderContentDisposition), "report-import-errors.csv")
+
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "row,slug,error\n4,wound-care-market,\"unknown category \"\"Wound Care\"\"\"\n", string(body))
+	})
+
+	t.Run("The format can be given when the extension says nothing", func(t *testing.T) {
+		mockService := new(MockReportImportService)
+		app := setupReportImportTestApp(NewReportImportHandler(mockService, &MockAuditService{}))
+
+		mockService.On("Import", mock.Anything, report.ImportFormatCSV, false, uint(7)).Return(&report.ImportResult{}, nil).Once()
+
+		resp, err := app.Test(multipartRequest("/api/v1/reports/import?format=csv", "export.txt", importCSV, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Fail with an unknown format", func(t *testing.T) {
+		mockService := new(MockReportImportService)
+		app := setupReportImportTestApp(NewReportImportHandler(mockService, &MockAuditService{}))
+
+		resp, err := app.Test(multipartRequest("/api/v1/reports/import", "reports.xlsx", importCSV, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+		mockService.AssertNotCalled(t, "Import", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Fail with an unreadable file", func(t *testing.T) {
+		mockService := new(MockReportImportService)
+		app := setupReportImportTestApp(NewReportImportHandler(mockService, &MockAuditService{}))
+
+		mockService.On("Import", mock.Anything, report.ImportFormatCSV, false, uint(7)).
+			Return(nil, fmt.Errorf(`%w: unknown column "colour"`, service.ErrInvalidImport)).Once()
+
+		resp, err := app.Test(multipartRequest("/api/v1/reports/import", "reports.csv", []byte("slug,colour\n"), nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
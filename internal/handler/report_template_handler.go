@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/middleware"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/healthcare-market-research/backend/pkg/response"
+)
+
+type ReportTemplateHandler struct {
+	service      service.ReportTemplateService
+	auditService service.AuditService
+}
+
+func NewReportTemplateHandler(service service.ReportTemplateService, auditService service.AuditService) *ReportTemplateHandler {
+	return &ReportTemplateHandler{
+		service:      service,
+		auditService: auditService,
+	}
+}
+
+// GetAll godoc
+// @Summary List report templates
+// @Description List the saved report templates by name. Requires admin or editor role.
+// @Tags Report Templates
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} response.Response{data=[]report.ReportTemplate} "Report templates"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/report-templates [get]
+func (h *ReportTemplateHandler) GetAll(c *fiber.Ctx) error {
+	templates, err := h.service.GetAll()
+	if err != nil {
+		return response.InternalError(c, "Failed to fetch report templates")
+	}
+	return response.Success(c, templates)
+}
+
+// GetByID godoc
+// @Summary Get report template
+// @Description Get a report template by ID. Requires admin or editor role.
+// @Tags Report Templates
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Template ID"
+// @Success 200 {object} response.Response{data=report.ReportTemplate} "Report template"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid ID"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Report template not found"
+// @Router /api/v1/report-templates/{id} [get]
+func (h *ReportTemplateHandler) GetByID(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid template ID")
+	}
+
+	t, err := h.service.GetByID(uint(id))
+	if err != nil {
+		return h.handleError(c, err, "Failed to fetch report template")
+	}
+	return response.Success(c, t)
+}
+
+// Create godoc
+// @Summary Create report template
+// @Description Save a report template: a table of contents skeleton, standard FAQs, default geography, pricing and category. Names are unique ignoring case, and geography is checked against the taxonomy as on reports. Create a report from it by sending templateId to POST /api/v1/reports. Requires admin or editor role.
+// @Tags Report Templates
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param template body report.ReportTemplateRequest true "Template data"
+// @Success 201 {object} response.Response{data=report.ReportTemplate} "Created template"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid input or name already in use"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/report-templates [post]
+func (h *ReportTemplateHandler) Create(c *fiber.Ctx) error {
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req report.ReportTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body: "+err.Error())
+	}
+
+	created, err := h.service.Create(&req, userID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to create report template")
+	}
+
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionReportTemplateCreate)
+	entry.EntityType = audit.EntityReportTemplate
+	entry.EntityID = &created.ID
+	h.auditService.LogAsync(entry)
+
+	return c.Status(fiber.StatusCreated).JSON(response.Response{
+		Success: true,
+		Data:    created,
+	})
+}
+
+// Update godoc
+// @Summary Update report template
+// @Description Replace a report template by ID. Reports already created from it are not changed. Requires admin or editor role.
+// @Tags Report Templates
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Template ID"
+// @Param template body report.ReportTemplateRequest true "Template data"
+// @Success 200 {object} response.Response{data=report.ReportTemplate} "Updated template"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid input or name already in use"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Report template not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/report-templates/{id} [put]
+func (h *ReportTemplateHandler) Update(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid template ID")
+	}
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return err
+	}
+
+	var req report.ReportTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body: "+err.Error())
+	}
+
+	before, err := h.service.GetByID(uint(id))
+	if err != nil {
+		return h.handleError(c, err, "Failed to update report template")
+	}
+	snapshot := *before
+
+	updated, err := h.service.Update(uint(id), &req, userID)
+	if err != nil {
+		return h.handleError(c, err, "Failed to update report template")
+	}
+
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionReportTemplateUpdate)
+	entry.EntityType = audit.EntityReportTemplate
+	entry.EntityID = &updated.ID
+	if snapshot.Name != updated.Name {
+		entry.Changes = audit.Changes{"name": {Old: snapshot.Name, New: updated.Name}}
+	}
+	h.auditService.LogAsync(entry)
+
+	return response.Success(c, updated)
+}
+
+// Delete godoc
+// @Summary Delete report template
+// @Description Delete a report template. Reports created from it are not changed. Requires admin role.
+// @Tags Report Templates
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Template ID"
+// @Success 200 {object} response.Response{data=report.ReportTemplate} "Deleted template"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid ID"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin role"
+// @Failure 404 {object} response.Response{error=string} "Report template not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/report-templates/{id} [delete]
+func (h *ReportTemplateHandler) Delete(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid template ID")
+	}
+
+	deleted, err := h.service.Delete(uint(id))
+	if err != nil {
+		return h.handleError(c, err, "Failed to delete report template")
+	}
+
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionReportTemplateDelete)
+	entry.EntityType = audit.EntityReportTemplate
+	entry.EntityID = &deleted.ID
+	entry.Changes = audit.Changes{
+		"name": {Old: deleted.Name, New: nil},
+	}
+	h.auditService.LogAsync(entry)
+
+	return response.Success(c, deleted)
+}
+
+// handleError maps report template errors to responses
+func (h *ReportTemplateHandler) handleError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, service.ErrTemplateNotFound):
+		return response.NotFound(c, "Report template not found")
+	case errors.Is(err, service.ErrInvalidTemplate), errors.Is(err, service.ErrTemplateNameTaken):
+		return response.BadRequest(c, err.Error())
+	default:
+		return response.InternalError(c, fallback)
+	}
+}
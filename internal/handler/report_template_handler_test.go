@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockReportTemplateService is a mock implementation of ReportTemplateService
+type MockReportTemplateService struct {
+	mock.Mock
+}
+
+func (m *MockReportTemplateService) GetAll() ([]report.ReportTemplate, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]report.ReportTemplate), args.Error(1)
+}
+
+func (m *MockReportTemplateService) GetByID(id uint) (*report.ReportTemplate, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*report.ReportTemplate), args.Error(1)
+}
+
+func (m *MockReportTemplateService) Create(req *report.ReportTemplateRequest, userID uint) (*report.ReportTemplate, error) {
+	args := m.Called(req, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*report.ReportTemplate), args.Error(1)
+}
+
+func (m *MockReportTemplateService) Update(id uint, req *report.ReportTemplateRequest, userID uint) (*report.ReportTemplate, error) {
+	args := m.Called(id, req, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*report.ReportTemplate), args.Error(1)
+}
+
+func (m *MockReportTemplateService) Delete(id uint) (*report.ReportTemplate, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*report.ReportTemplate), args.Error(1)
+}
+
+func setupReportTemplateTestApp(handler *ReportTemplateHandler) *fiber.App {
+	app := fiber.New()
+	app.Use(withTestEditor)
+	app.Get("/api/v1/report-templates", handler.GetAll)
+	app.Get("/api/v1/report-templates/:id", handler.GetByID)
+	app.Post("/api/v1/report-templates", handler.Create)
+	app.Put("/api/v1/report-templates/:id", handler.Update)
+	app.Delete("/api/v1/report-templates/:id", handler.Delete)
+	return app
+}
+
+func TestReportTemplateHandler_Create(t *testing.T) {
+	t.Run("Successfully create a template", func(t *testing.T) {
+		mockService := new(MockReportTemplateService)
+		auditService := &MockAuditService{}
+		app := setupReportTemplateTestApp(NewReportTemplateHandler(mockService, auditService))
+
+		mockService.On("Create", mock.MatchedBy(func(req *report.ReportTemplateRequest) bool {
+			return req.Name == "Medical Devices - Global" && len(req.FAQs) == 1 && req.Price == 3999
+		}), uint(7)).Return(&report.ReportTemplate{ID: 3, Name: "Medical Devices - Global"}, nil).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPost, "/api/v1/report-templates", map[string]interface{}{
+			"name":              "Medical Devices - Global",
+			"table_of_contents": "1. Introduction\n2. Market Overview",
+			"faqs":              []map[string]string{{"question": "What is covered?", "answer": "Devices and services."}},
+			"geography":         []string{"Global"},
+			"price":             3999,
+		}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+		assert.Len(t, auditService.entries, 1)
+		assert.Equal(t, audit.ActionReportTemplateCreate, auditService.entries[0].Action)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Fail when the name is taken", func(t *testing.T) {
+		mockService := new(MockReportTemplateService)
+		app := setupReportTemplateTestApp(NewReportTemplateHandler(mockService, &MockAuditService{}))
+
+		mockService.On("Create", mock.Anything, uint(7)).Return(nil, service.ErrTemplateNameTaken).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPost, "/api/v1/report-templates", map[string]interface{}{"name": "Medical Devices - Global"}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestReportTemplateHandler_Update(t *testing.T) {
+	mockService := new(MockReportTemplateService)
+	auditService := &MockAuditService{}
+	app := setupReportTemplateTestApp(NewReportTemplateHandler(mockService, auditService))
+
+	mockService.On("GetByID", uint(3)).Return(&report.ReportTemplate{ID: 3, Name: "Devices"}, nil).Once()
+	mockService.On("Update", uint(3), mock.Anything, uint(7)).
+		Return(&report.ReportTemplate{ID: 3, Name: "Medical Devices - Global"}, nil).Once()
+
+	resp, err := app.Test(jsonRequest(http.MethodPut, "/api/v1/report-templates/3", map[string]interface{}{"name": "Medical Devices - Global"}))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Len(t, auditService.entries, 1)
+	assert.Equal(t, "Devices", auditService.entries[0].Changes["name"].Old)
+}
+
+func TestReportTemplateHandler_Delete_NotFound(t *testing.T) {
+	mockService := new(MockReportTemplateService)
+	auditService := &MockAuditService{}
+	app := setupReportTemplateTestApp(NewReportTemplateHandler(mockService, auditService))
+
+	mockService.On("Delete", uint(99)).Return(nil, service.ErrTemplateNotFound).Once()
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/api/v1/report-templates/99", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	assert.Empty(t, auditService.entries)
+}
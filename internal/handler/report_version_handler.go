@@ -0,0 +1,199 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/middleware"
+	"github.com/healthcare-market-research/backend/internal/repository"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/healthcare-market-research/backend/pkg/response"
+)
+
+// parseVersionNumber reads a positive version number from a route or query value
+func parseVersionNumber(value string) (int, bool) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, false
+	}
+	return n, true
+}
+
+// handleVersionError maps version history errors to responses
+func handleVersionError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case err.Error() == "record not found":
+		return response.NotFound(c, "Report not found")
+	case errors.Is(err, service.ErrReportVersionNotFound):
+		return response.NotFound(c, "Report version not found")
+	case errors.Is(err, repository.ErrVersionConflict):
+		return response.Error(c, fiber.StatusConflict, "Report was modified by another user, please retry")
+	case errors.Is(err, service.ErrVersionSnapshotMissing),
+		errors.Is(err, service.ErrRollbackToCurrentVersion):
+		return response.BadRequest(c, err.Error())
+	default:
+		return response.InternalError(c, fallback)
+	}
+}
+
+// ListVersions godoc
+// @Summary List report versions
+// @Description List the version history of a report, newest first. Full snapshots are omitted; fetch a single version to see its payload. Requires admin or editor role.
+// @Tags Reports
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Report ID"
+// @Success 200 {object} response.Response{data=[]report.ReportVersion} "Report versions"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid ID"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Report not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/reports/{id}/versions [get]
+func (h *ReportHandler) ListVersions(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid report ID")
+	}
+
+	versions, err := h.service.ListVersions(uint(id))
+	if err != nil {
+		return handleVersionError(c, err, "Failed to fetch report versions")
+	}
+
+	return response.Success(c, versions)
+}
+
+// GetVersion godoc
+// @Summary Get a report version
+// @Description Get a single report version including the full report payload saved with it. Requires admin or editor role.
+// @Tags Reports
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Report ID"
+// @Param version path int true "Version number"
+// @Success 200 {object} response.Response{data=report.ReportVersion} "Report version"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid ID or version"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Report version not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/reports/{id}/versions/{version} [get]
+func (h *ReportHandler) GetVersion(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid report ID")
+	}
+
+	versionNumber, ok := parseVersionNumber(c.Params("version"))
+	if !ok {
+		return response.BadRequest(c, "Invalid version number")
+	}
+
+	version, err := h.service.GetVersion(uint(id), versionNumber)
+	if err != nil {
+		return handleVersionError(c, err, "Failed to fetch report version")
+	}
+
+	return response.Success(c, version)
+}
+
+// DiffVersions godoc
+// @Summary Compare two report versions
+// @Description Show the field-level differences between any two versions of a report. Requires admin or editor role.
+// @Tags Reports
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Report ID"
+// @Param from query int true "Base version number"
+// @Param to query int true "Version number to compare against the base"
+// @Success 200 {object} response.Response{data=report.VersionDiff} "Changed fields"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid ID or versions"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Report version not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/reports/{id}/versions/diff [get]
+func (h *ReportHandler) DiffVersions(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid report ID")
+	}
+
+	from, ok := parseVersionNumber(c.Query("from"))
+	if !ok {
+		return response.BadRequest(c, "Query parameter 'from' must be a version number")
+	}
+	to, ok := parseVersionNumber(c.Query("to"))
+	if !ok {
+		return response.BadRequest(c, "Query parameter 'to' must be a version number")
+	}
+
+	diff, err := h.service.DiffVersions(uint(id), from, to)
+	if err != nil {
+		return handleVersionError(c, err, "Failed to compare report versions")
+	}
+
+	return response.Success(c, diff)
+}
+
+// RollbackVersion godoc
+// @Summary Roll a report back to a version
+// @Description Restore the report content saved in an earlier version. The slug and publishing state are kept. The rollback is recorded as a new version and in the audit log. Requires admin or editor role.
+// @Tags Reports
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Report ID"
+// @Param version path int true "Version number to restore"
+// @Success 200 {object} response.Response{data=report.RollbackResult} "Restored report, the new version and the fields that changed"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid version or nothing to restore"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Report or version not found"
+// @Failure 409 {object} response.Response{error=string} "Report was modified during the rollback"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/reports/{id}/versions/{version}/rollback [post]
+func (h *ReportHandler) RollbackVersion(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid report ID")
+	}
+
+	versionNumber, ok := parseVersionNumber(c.Params("version"))
+	if !ok {
+		return response.BadRequest(c, "Invalid version number")
+	}
+
+	userID, err := getUserID(c)
+	if err != nil {
+		return response.Unauthorized(c, "User not authenticated")
+	}
+
+	result, err := h.service.Rollback(uint(id), versionNumber, userID)
+	if err != nil {
+		return handleVersionError(c, err, "Failed to roll back report")
+	}
+
+	reportID := result.Report.ID
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionReportRollback)
+	entry.EntityType = audit.EntityReport
+	entry.EntityID = &reportID
+	entry.Changes = versionChanges(result.Changes)
+	entry.Changes["restored_from_version"] = audit.FieldChange{Old: nil, New: versionNumber}
+	h.auditService.LogAsync(entry)
+
+	return response.Success(c, result)
+}
+
+// versionChanges converts a version diff into audit log changes
+func versionChanges(diffs []report.FieldDiff) audit.Changes {
+	changes := make(audit.Changes, len(diffs)+1)
+	for _, d := range diffs {
+		changes[d.Field] = audit.FieldChange{Old: json.RawMessage(d.Old), New: json.RawMessage(d.New)}
+	}
+	return changes
+}
@@ -0,0 +1,206 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/domain/user"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// withTestEditor stands in for the auth middleware
+func withTestEditor(c *fiber.Ctx) error {
+	c.Locals("user", &user.User{ID: 7, Email: "editor@example.com", Role: "editor"})
+	return c.Next()
+}
+
+func setupReportVersionTestApp(handler *ReportHandler) *fiber.App {
+	app := fiber.New()
+	app.Use(withTestEditor)
+
+	app.Get("/api/v1/reports/:id/versions", handler.ListVersions)
+	app.Get("/api/v1/reports/:id/versions/diff", handler.DiffVersions)
+	app.Get("/api/v1/reports/:id/versions/:version", handler.GetVersion)
+	app.Post("/api/v1/reports/:id/versions/:version/rollback", handler.RollbackVersion)
+
+	return app
+}
+
+func TestReportHandler_ListVersions(t *testing.T) {
+	mockService := new(MockReportService)
+	app := setupReportVersionTestApp(NewReportHandler(mockService, nil, &MockAuditService{}, nil, nil))
+
+	versions := []report.ReportVersion{
+		{ID: 2, ReportID: 5, VersionNumber: 2, ChangeType: report.VersionChangeUpdate},
+		{ID: 1, ReportID: 5, VersionNumber: 1, ChangeType: report.VersionChangeCreate},
+	}
+	mockService.On("ListVersions", uint(5)).Return(versions, nil).Once()
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports/5/versions", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result struct {
+		Data []report.ReportVersion `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(body, &result))
+	assert.Len(t, result.Data, 2)
+	assert.Equal(t, 2, result.Data[0].VersionNumber)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestReportHandler_GetVersion(t *testing.T) {
+	t.Run("Return the version with its snapshot", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupReportVersionTestApp(NewReportHandler(mockService, nil, &MockAuditService{}, nil, nil))
+
+		version := &report.ReportVersion{
+			ReportID:      5,
+			VersionNumber: 3,
+			Snapshot:      &report.ReportSnapshot{Title: "Oncology Market", Price: 3999},
+		}
+		mockService.On("GetVersion", uint(5), 3).Return(version, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports/5/versions/3", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		body, _ := io.ReadAll(resp.Body)
+		var result struct {
+			Data report.ReportVersion `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Equal(t, 3999.0, result.Data.Snapshot.Price)
+
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Unknown version returns 404", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupReportVersionTestApp(NewReportHandler(mockService, nil, &MockAuditService{}, nil, nil))
+
+		mockService.On("GetVersion", uint(5), 9).Return(nil, service.ErrReportVersionNotFound).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports/5/versions/9", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("Invalid version number returns 400", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupReportVersionTestApp(NewReportHandler(mockService, nil, &MockAuditService{}, nil, nil))
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports/5/versions/0", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		mockService.AssertNotCalled(t, "GetVersion", mock.Anything, mock.Anything)
+	})
+}
+
+func TestReportHandler_DiffVersions(t *testing.T) {
+	t.Run("Return changed fields", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupReportVersionTestApp(NewReportHandler(mockService, nil, &MockAuditService{}, nil, nil))
+
+		diff := &report.VersionDiff{
+			ReportID:    5,
+			FromVersion: 1,
+			ToVersion:   4,
+			Changes: []report.FieldDiff{
+				{Field: "price", Old: json.RawMessage("2999"), New: json.RawMessage("3999")},
+			},
+		}
+		mockService.On("DiffVersions", uint(5), 1, 4).Return(diff, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports/5/versions/diff?from=1&to=4", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		body, _ := io.ReadAll(resp.Body)
+		var result struct {
+			Data report.VersionDiff `json:"data"`
+		}
+		assert.NoError(t, json.Unmarshal(body, &result))
+		assert.Len(t, result.Data.Changes, 1)
+		assert.Equal(t, "price", result.Data.Changes[0].Field)
+		assert.JSONEq(t, "2999", string(result.Data.Changes[0].Old))
+
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Missing version parameters return 400", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupReportVersionTestApp(NewReportHandler(mockService, nil, &MockAuditService{}, nil, nil))
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/reports/5/versions/diff?from=1", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		mockService.AssertNotCalled(t, "DiffVersions", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestReportHandler_RollbackVersion(t *testing.T) {
+	t.Run("Rollback creates a new version and an audit entry", func(t *testing.T) {
+		mockService := new(MockReportService)
+		auditService := &MockAuditService{}
+		app := setupReportVersionTestApp(NewReportHandler(mockService, nil, auditService, nil, nil))
+
+		result := &report.RollbackResult{
+			Report:  &report.Report{ID: 5, Title: "Oncology Market", Price: 2999},
+			Version: &report.ReportVersion{ReportID: 5, VersionNumber: 6, ChangeType: report.VersionChangeRollback},
+			Changes: []report.FieldDiff{
+				{Field: "price", Old: json.RawMessage("3999"), New: json.RawMessage("2999")},
+			},
+		}
+		mockService.On("Rollback", uint(5), 2, uint(7)).Return(result, nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/api/v1/reports/5/versions/2/rollback", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		assert.Len(t, auditService.entries, 1)
+		entry := auditService.entries[0]
+		assert.Equal(t, audit.ActionReportRollback, entry.Action)
+		assert.Equal(t, audit.EntityReport, entry.EntityType)
+		assert.Equal(t, uint(5), *entry.EntityID)
+		assert.Contains(t, entry.Changes, "price")
+		assert.Equal(t, 2, entry.Changes["restored_from_version"].New)
+
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Version without snapshot returns 400", func(t *testing.T) {
+		mockService := new(MockReportService)
+		auditService := &MockAuditService{}
+		app := setupReportVersionTestApp(NewReportHandler(mockService, nil, auditService, nil, nil))
+
+		mockService.On("Rollback", uint(5), 1, uint(7)).Return(nil, service.ErrVersionSnapshotMissing).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/api/v1/reports/5/versions/1/rollback", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Empty(t, auditService.entries)
+	})
+
+	t.Run("Unexpected error returns 500", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupReportVersionTestApp(NewReportHandler(mockService, nil, &MockAuditService{}, nil, nil))
+
+		mockService.On("Rollback", uint(5), 2, uint(7)).Return(nil, errors.New("connection reset")).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/api/v1/reports/5/versions/2/rollback", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	})
+}
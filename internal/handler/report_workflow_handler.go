@@ -0,0 +1,345 @@
+package handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/domain/user"
+	"github.com/healthcare-market-research/backend/internal/middleware"
+	"github.com/healthcare-market-research/backend/internal/repository"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/healthcare-market-research/backend/pkg/response"
+)
+
+// workflowAuditActions maps each workflow action to its audit log action
+var workflowAuditActions = map[string]string{
+	report.WorkflowSubmit:  audit.ActionReportSubmitReview,
+	report.WorkflowApprove: audit.ActionReportApprove,
+	report.WorkflowReject:  audit.ActionReportReject,
+	report.WorkflowPublish: audit.ActionReportPublish,
+	report.WorkflowArchive: audit.ActionReportArchive,
+	report.WorkflowReopen:  audit.ActionReportReopen,
+}
+
+// getActor returns the authenticated user as a workflow actor
+func getActor(c *fiber.Ctx) (report.Actor, bool) {
+	currentUser, ok := c.Locals("user").(*user.User)
+	if !ok || currentUser == nil {
+		return report.Actor{}, false
+	}
+	return report.Actor{UserID: currentUser.ID, Role: currentUser.Role}, true
+}
+
+// handleWorkflowError maps editorial workflow errors to responses
+func handleWorkflowError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case err.Error() == "record not found":
+		return response.NotFound(c, "Report not found")
+	case errors.Is(err, service.ErrTransitionForbidden),
+		errors.Is(err, service.ErrSelfReview),
+		errors.Is(err, service.ErrAssignForbidden):
+		return response.Forbidden(c, err.Error())
+	case errors.Is(err, service.ErrInvalidTransition),
+		errors.Is(err, repository.ErrVersionConflict):
+		return response.Error(c, fiber.StatusConflict, err.Error())
+	case errors.Is(err, service.ErrUnknownTransition),
+		errors.Is(err, service.ErrNoteRequired),
+		errors.Is(err, service.ErrInvalidReviewer),
+		errors.Is(err, service.ErrEmptyReviewComment):
+		return response.BadRequest(c, err.Error())
+	default:
+		return response.InternalError(c, fallback)
+	}
+}
+
+// transitionReport runs a workflow action and records it in the audit log
+func (h *ReportHandler) transitionReport(c *fiber.Ctx, action string) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid report ID")
+	}
+
+	actor, ok := getActor(c)
+	if !ok {
+		return response.Unauthorized(c, "User not authenticated")
+	}
+
+	var req report.TransitionRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return response.BadRequest(c, "Invalid request body: "+err.Error())
+		}
+	}
+
+	previous, err := h.service.GetByID(uint(id))
+	if err != nil {
+		return response.NotFound(c, "Report not found")
+	}
+
+	rep, err := h.service.Transition(uint(id), action, actor, req.Comment)
+	if err != nil {
+		return handleWorkflowError(c, err, "Failed to update report status")
+	}
+
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), workflowAuditActions[action])
+	entry.EntityType = audit.EntityReport
+	entry.EntityID = &rep.ID
+	entry.Changes = audit.Changes{
+		"status": {Old: previous.Status, New: rep.Status},
+	}
+	if req.Comment != "" {
+		entry.Changes["comment"] = audit.FieldChange{Old: nil, New: req.Comment}
+	}
+	h.auditService.LogAsync(entry)
+
+	setETag(c, rep.Version)
+	return response.Success(c, rep)
+}
+
+// SubmitForReview godoc
+// @Summary Submit report for review
+// @Description Move a draft report to review. An optional comment is stored with the submission. Requires admin or editor role.
+// @Tags Reports
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Report ID"
+// @Param request body report.TransitionRequest false "Optional comment"
+// @Success 200 {object} response.Response{data=report.Report} "Report in review"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid ID"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - role cannot perform this action"
+// @Failure 404 {object} response.Response{error=string} "Report not found"
+// @Failure 409 {object} response.Response{error=string} "Report is not a draft"
+// @Router /api/v1/reports/{id}/submit-review [patch]
+func (h *ReportHandler) SubmitForReview(c *fiber.Ctx) error {
+	return h.transitionReport(c, report.WorkflowSubmit)
+}
+
+// Approve godoc
+// @Summary Approve report
+// @Description Approve a report in review so it can be published. Allowed for admins and the assigned reviewer, unless they submitted the report.
+// @Tags Reports
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Report ID"
+// @Param request body report.TransitionRequest false "Optional reviewer comment"
+// @Success 200 {object} response.Response{data=report.Report} "Approved report"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid ID"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - not an admin or the assigned reviewer, or the submitter"
+// @Failure 404 {object} response.Response{error=string} "Report not found"
+// @Failure 409 {object} response.Response{error=string} "Report is not in review"
+// @Router /api/v1/reports/{id}/approve [patch]
+func (h *ReportHandler) Approve(c *fiber.Ctx) error {
+	return h.transitionReport(c, report.WorkflowApprove)
+}
+
+// Reject godoc
+// @Summary Reject report
+// @Description Send a report in review back to draft. The comment is required and stored as the rejection reason. Allowed for admins and the assigned reviewer, unless they submitted the report.
+// @Tags Reports
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Report ID"
+// @Param request body report.TransitionRequest true "Rejection reason"
+// @Success 200 {object} response.Response{data=report.Report} "Rejected report, back in draft"
+// @Failure 400 {object} response.Response{error=string} "Bad request - reason missing"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - not an admin or the assigned reviewer, or the submitter"
+// @Failure 404 {object} response.Response{error=string} "Report not found"
+// @Failure 409 {object} response.Response{error=string} "Report is not in review"
+// @Router /api/v1/reports/{id}/reject [patch]
+func (h *ReportHandler) Reject(c *fiber.Ctx) error {
+	return h.transitionReport(c, report.WorkflowReject)
+}
+
+// Publish godoc
+// @Summary Publish report
+// @Description Publish an approved report. Sets the publish date if it is not already set and records the published content in the version history. Requires admin or editor role.
+// @Tags Reports
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Report ID"
+// @Param request body report.TransitionRequest false "Optional comment"
+// @Success 200 {object} response.Response{data=report.Report} "Published report"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid ID"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - role cannot perform this action"
+// @Failure 404 {object} response.Response{error=string} "Report not found"
+// @Failure 409 {object} response.Response{error=string} "Report is not approved"
+// @Router /api/v1/reports/{id}/publish [patch]
+func (h *ReportHandler) Publish(c *fiber.Ctx) error {
+	return h.transitionReport(c, report.WorkflowPublish)
+}
+
+// Archive godoc
+// @Summary Archive report
+// @Description Take a published report off the site and archive it. Requires admin role.
+// @Tags Reports
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Report ID"
+// @Param request body report.TransitionRequest false "Optional comment"
+// @Success 200 {object} response.Response{data=report.Report} "Archived report"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid ID"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin role"
+// @Failure 404 {object} response.Response{error=string} "Report not found"
+// @Failure 409 {object} response.Response{error=string} "Report is not published"
+// @Router /api/v1/reports/{id}/archive [patch]
+func (h *ReportHandler) Archive(c *fiber.Ctx) error {
+	return h.transitionReport(c, report.WorkflowArchive)
+}
+
+// Reopen godoc
+// @Summary Reopen report as draft
+// @Description Move an approved or archived report back to draft for further editing. Requires admin or editor role.
+// @Tags Reports
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Report ID"
+// @Param request body report.TransitionRequest false "Optional comment"
+// @Success 200 {object} response.Response{data=report.Report} "Report back in draft"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid ID"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - role cannot perform this action"
+// @Failure 404 {object} response.Response{error=string} "Report not found"
+// @Failure 409 {object} response.Response{error=string} "Report is not approved or archived"
+// @Router /api/v1/reports/{id}/reopen [patch]
+func (h *ReportHandler) Reopen(c *fiber.Ctx) error {
+	return h.transitionReport(c, report.WorkflowReopen)
+}
+
+// AssignReviewer godoc
+// @Summary Assign report reviewer
+// @Description Assign the admin or editor responsible for reviewing a report. The user who submitted the report cannot review it. Send null to clear the assignment. Requires admin role.
+// @Tags Reports
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Report ID"
+// @Param request body report.AssignReviewerRequest true "Reviewer user ID"
+// @Success 200 {object} response.Response{data=report.Report} "Report with reviewer assigned"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid reviewer"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin role"
+// @Failure 404 {object} response.Response{error=string} "Report not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/reports/{id}/reviewer [patch]
+func (h *ReportHandler) AssignReviewer(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid report ID")
+	}
+
+	actor, ok := getActor(c)
+	if !ok {
+		return response.Unauthorized(c, "User not authenticated")
+	}
+
+	var req report.AssignReviewerRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body: "+err.Error())
+	}
+
+	previous, err := h.service.GetByID(uint(id))
+	if err != nil {
+		return response.NotFound(c, "Report not found")
+	}
+
+	rep, err := h.service.AssignReviewer(uint(id), req.ReviewerID, actor)
+	if err != nil {
+		return handleWorkflowError(c, err, "Failed to assign reviewer")
+	}
+
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionReportAssignReviewer)
+	entry.EntityType = audit.EntityReport
+	entry.EntityID = &rep.ID
+	entry.Changes = audit.Changes{
+		"reviewer_id": {Old: previous.ReviewerID, New: rep.ReviewerID},
+	}
+	h.auditService.LogAsync(entry)
+
+	setETag(c, rep.Version)
+	return response.Success(c, rep)
+}
+
+// GetReviewComments godoc
+// @Summary List review comments
+// @Description List reviewer and editor comments on a report, oldest first. Comments left with a workflow action (such as a rejection) include that action. Requires admin or editor role.
+// @Tags Reports
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Report ID"
+// @Success 200 {object} response.Response{data=[]report.ReviewComment} "Review comments"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid ID"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Report not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/reports/{id}/comments [get]
+func (h *ReportHandler) GetReviewComments(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid report ID")
+	}
+
+	comments, err := h.service.GetReviewComments(uint(id))
+	if err != nil {
+		return handleWorkflowError(c, err, "Failed to fetch review comments")
+	}
+
+	return response.Success(c, comments)
+}
+
+// AddReviewComment godoc
+// @Summary Add review comment
+// @Description Add a reviewer or editor comment to a report. Requires admin or editor role.
+// @Tags Reports
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Report ID"
+// @Param request body report.CreateReviewCommentRequest true "Comment"
+// @Success 201 {object} response.Response{data=report.ReviewComment} "Created comment"
+// @Failure 400 {object} response.Response{error=string} "Bad request - empty comment"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Report not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/reports/{id}/comments [post]
+func (h *ReportHandler) AddReviewComment(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid report ID")
+	}
+
+	actor, ok := getActor(c)
+	if !ok {
+		return response.Unauthorized(c, "User not authenticated")
+	}
+
+	var req report.CreateReviewCommentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body: "+err.Error())
+	}
+
+	comment, err := h.service.AddReviewComment(uint(id), actor, req.Body)
+	if err != nil {
+		return handleWorkflowError(c, err, "Failed to add review comment")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(response.Response{
+		Success: true,
+		Data:    comment,
+	})
+}
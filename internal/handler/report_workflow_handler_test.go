@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func setupReportWorkflowTestApp(handler *ReportHandler) *fiber.App {
+	app := fiber.New()
+	app.Use(withTestEditor)
+
+	app.Patch("/api/v1/reports/:id/submit-review", handler.SubmitForReview)
+	app.Patch("/api/v1/reports/:id/approve", handler.Approve)
+	app.Patch("/api/v1/reports/:id/reject", handler.Reject)
+	app.Patch("/api/v1/reports/:id/reviewer", handler.AssignReviewer)
+	app.Post("/api/v1/reports/:id/comments", handler.AddReviewComment)
+
+	return app
+}
+
+var testEditorActor = report.Actor{UserID: 7, Role: "editor"}
+
+func TestReportHandler_SubmitForReview(t *testing.T) {
+	mockService := new(MockReportService)
+	auditService := &MockAuditService{}
+	app := setupReportWorkflowTestApp(NewReportHandler(mockService, nil, auditService, nil, nil))
+
+	mockService.On("GetByID", uint(5)).Return(&report.ReportWithRelations{Report: report.Report{ID: 5, Status: report.StatusDraft}}, nil).Once()
+	mockService.On("Transition", uint(5), report.WorkflowSubmit, testEditorActor, "Ready for a look").
+		Return(&report.Report{ID: 5, Status: report.StatusReview, Version: 3}, nil).Once()
+
+	resp, err := app.Test(jsonRequest(http.MethodPatch, "/api/v1/reports/5/submit-review", map[string]string{"comment": "Ready for a look"}))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, `"3"`, resp.Header.Get(fiber.HeaderETag))
+
+	assert.Len(t, auditService.entries, 1)
+	entry := auditService.entries[0]
+	assert.Equal(t, audit.ActionReportSubmitReview, entry.Action)
+	assert.Equal(t, audit.EntityReport, entry.EntityType)
+	assert.Equal(t, report.StatusDraft, entry.Changes["status"].Old)
+	assert.Equal(t, report.StatusReview, entry.Changes["status"].New)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestReportHandler_Approve_Forbidden(t *testing.T) {
+	mockService := new(MockReportService)
+	auditService := &MockAuditService{}
+	app := setupReportWorkflowTestApp(NewReportHandler(mockService, nil, auditService, nil, nil))
+
+	mockService.On("GetByID", uint(5)).Return(&report.ReportWithRelations{Report: report.Report{ID: 5, Status: report.StatusReview}}, nil).Once()
+	mockService.On("Transition", uint(5), report.WorkflowApprove, testEditorActor, "").
+		Return(nil, service.ErrTransitionForbidden).Once()
+
+	resp, err := app.Test(jsonRequest(http.MethodPatch, "/api/v1/reports/5/approve", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	assert.Empty(t, auditService.entries)
+}
+
+func TestReportHandler_Reject(t *testing.T) {
+	t.Run("Missing reason returns 400", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupReportWorkflowTestApp(NewReportHandler(mockService, nil, &MockAuditService{}, nil, nil))
+
+		mockService.On("GetByID", uint(5)).Return(&report.ReportWithRelations{Report: report.Report{ID: 5, Status: report.StatusReview}}, nil).Once()
+		mockService.On("Transition", uint(5), report.WorkflowReject, testEditorActor, "").
+			Return(nil, service.ErrNoteRequired).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPatch, "/api/v1/reports/5/reject", map[string]string{}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("Wrong status returns 409", func(t *testing.T) {
+		mockService := new(MockReportService)
+		app := setupReportWorkflowTestApp(NewReportHandler(mockService, nil, &MockAuditService{}, nil, nil))
+
+		mockService.On("GetByID", uint(5)).Return(&report.ReportWithRelations{Report: report.Report{ID: 5, Status: report.StatusDraft}}, nil).Once()
+		mockService.On("Transition", uint(5), report.WorkflowReject, testEditorActor, "Needs sources").
+			Return(nil, fmt.Errorf("%w: cannot reject a report that is draft", service.ErrInvalidTransition)).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPatch, "/api/v1/reports/5/reject", map[string]string{"comment": "Needs sources"}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusConflict, resp.StatusCode)
+	})
+}
+
+func TestReportHandler_AssignReviewer(t *testing.T) {
+	mockService := new(MockReportService)
+	auditService := &MockAuditService{}
+	app := setupReportWorkflowTestApp(NewReportHandler(mockService, nil, auditService, nil, nil))
+
+	reviewerID := uint(12)
+	mockService.On("GetByID", uint(5)).Return(&report.ReportWithRelations{Report: report.Report{ID: 5}}, nil).Once()
+	mockService.On("AssignReviewer", uint(5), mock.MatchedBy(func(id *uint) bool {
+		return id != nil && *id == reviewerID
+	}), testEditorActor).Return(&report.Report{ID: 5, ReviewerID: &reviewerID}, nil).Once()
+
+	resp, err := app.Test(jsonRequest(http.MethodPatch, "/api/v1/reports/5/reviewer", map[string]uint{"reviewer_id": reviewerID}))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	assert.Len(t, auditService.entries, 1)
+	assert.Equal(t, audit.ActionReportAssignReviewer, auditService.entries[0].Action)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestReportHandler_AssignReviewer_Forbidden(t *testing.T) {
+	mockService := new(MockReportService)
+	auditService := &MockAuditService{}
+	app := setupReportWorkflowTestApp(NewReportHandler(mockService, nil, auditService, nil, nil))
+
+	mockService.On("GetByID", uint(5)).Return(&report.ReportWithRelations{Report: report.Report{ID: 5}}, nil).Once()
+	mockService.On("AssignReviewer", uint(5), mock.Anything, testEditorActor).Return(nil, service.ErrAssignForbidden).Once()
+
+	resp, err := app.Test(jsonRequest(http.MethodPatch, "/api/v1/reports/5/reviewer", map[string]uint{"reviewer_id": 7}))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+	assert.Empty(t, auditService.entries)
+}
+
+func TestReportHandler_Approve_BySubmitter(t *testing.T) {
+	mockService := new(MockReportService)
+	app := setupReportWorkflowTestApp(NewReportHandler(mockService, nil, &MockAuditService{}, nil, nil))
+
+	mockService.On("GetByID", uint(5)).Return(&report.ReportWithRelations{Report: report.Report{ID: 5, Status: report.StatusReview}}, nil).Once()
+	mockService.On("Transition", uint(5), report.WorkflowApprove, testEditorActor, "").Return(nil, service.ErrSelfReview).Once()
+
+	resp, err := app.Test(jsonRequest(http.MethodPatch, "/api/v1/reports/5/approve", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode)
+}
+
+func TestReportHandler_AddReviewComment(t *testing.T) {
+	mockService := new(MockReportService)
+	app := setupReportWorkflowTestApp(NewReportHandler(mockService, nil, &MockAuditService{}, nil, nil))
+
+	mockService.On("AddReviewComment", uint(5), testEditorActor, "Check the CAGR figure").
+		Return(&report.ReviewComment{ID: 1, ReportID: 5, UserID: 7, Body: "Check the CAGR figure"}, nil).Once()
+
+	resp, err := app.Test(jsonRequest(http.MethodPost, "/api/v1/reports/5/comments", map[string]string{"body": "Check the CAGR figure"}))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusCreated, resp.StatusCode)
+
+	body, _ := io.ReadAll(resp.Body)
+	var result struct {
+		Data report.ReviewComment `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(body, &result))
+	assert.Equal(t, "Check the CAGR figure", result.Data.Body)
+
+	mockService.AssertExpectations(t)
+}
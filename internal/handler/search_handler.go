@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/search"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/healthcare-market-research/backend/pkg/response"
+)
+
+type SearchHandler struct {
+	service service.SearchService
+}
+
+func NewSearchHandler(service service.SearchService) *SearchHandler {
+	return &SearchHandler{service: service}
+}
+
+// Search godoc
+// @Summary Search published content
+// @Description Full-text search across published reports, blogs and press releases. Results are ranked by relevance (title > summary > description > body) and include highlighted snippets. Facet counts per content type are always computed across all types.
+// @Tags Search
+// @Accept json
+// @Produce json
+// @Param q query string true "Search query text (supports quoted phrases, OR and -exclusions)"
+// @Param type query string false "Comma-separated content types to return (report, blog, press_release)"
+// @Param page query int false "Page number (default: 1, min: 1)"
+// @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Success 200 {object} response.Response{data=search.SearchResponse,meta=response.Meta} "Ranked search results with type facets"
+// @Failure 400 {object} response.Response{error=string} "Bad request - search query is required or type is invalid"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/search [get]
+func (h *SearchHandler) Search(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	query := search.Query{
+		Q:     c.Query("q"),
+		Page:  page,
+		Limit: limit,
+	}
+
+	if typeParam := c.Query("type"); typeParam != "" {
+		for _, t := range strings.Split(typeParam, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				query.Types = append(query.Types, search.ContentType(t))
+			}
+		}
+	}
+
+	result, total, err := h.service.Search(query)
+	if err != nil {
+		if errors.Is(err, service.ErrEmptySearchQuery) || errors.Is(err, service.ErrInvalidSearchType) {
+			return response.BadRequest(c, err.Error())
+		}
+		return response.InternalError(c, "Failed to search content")
+	}
+
+	totalPages := int(math.Ceil(float64(total) / float64(limit)))
+
+	meta := &response.Meta{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+
+	return response.SuccessWithMeta(c, result, meta)
+}
@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/search"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSearchService is a mock implementation of SearchService
+type MockSearchService struct {
+	mock.Mock
+}
+
+func (m *MockSearchService) Search(query search.Query) (*search.SearchResponse, int64, error) {
+	args := m.Called(query)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).(*search.SearchResponse), args.Get(1).(int64), args.Error(2)
+}
+
+func setupSearchTestApp(handler *SearchHandler) *fiber.App {
+	app := fiber.New()
+	app.Get("/api/v1/search", handler.Search)
+	return app
+}
+
+func TestSearchHandler_Search(t *testing.T) {
+	t.Run("Return ranked results with type facets", func(t *testing.T) {
+		mockService := new(MockSearchService)
+		app := setupSearchTestApp(NewSearchHandler(mockService))
+
+		result := &search.SearchResponse{
+			Results: []search.Result{
+				{Type: search.TypeReport, ID: 7, Title: "Oncology Market", Slug: "oncology-market", Snippet: "<mark>oncology</mark> drugs", Rank: 0.9},
+			},
+			Facets: search.Facets{Type: []search.TypeFacet{
+				{Type: search.TypeReport, Count: 1},
+				{Type: search.TypeBlog, Count: 3},
+				{Type: search.TypePressRelease, Count: 0},
+			}},
+		}
+		mockService.On("Search", search.Query{
+			Q:     "oncology",
+			Types: []search.ContentType{search.TypeReport},
+			Page:  2,
+			Limit: 10,
+		}).Return(result, int64(11), nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/search?q=oncology&type=report&page=2&limit=10", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Data search.SearchResponse `json:"data"`
+			Meta struct {
+				Total      int64 `json:"total"`
+				TotalPages int   `json:"total_pages"`
+			} `json:"meta"`
+		}
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		json.Unmarshal(bodyBytes, &body)
+
+		assert.Len(t, body.Data.Results, 1)
+		assert.Equal(t, "<mark>oncology</mark> drugs", body.Data.Results[0].Snippet)
+		assert.Len(t, body.Data.Facets.Type, 3)
+		assert.Equal(t, int64(11), body.Meta.Total)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Parse multiple types", func(t *testing.T) {
+		mockService := new(MockSearchService)
+		app := setupSearchTestApp(NewSearchHandler(mockService))
+
+		mockService.On("Search", mock.MatchedBy(func(q search.Query) bool {
+			return len(q.Types) == 2 && q.Types[0] == search.TypeBlog && q.Types[1] == search.TypePressRelease
+		})).Return(&search.SearchResponse{Results: []search.Result{}}, int64(0), nil).Once()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/search?q=fda&type=blog,%20press_release", nil)
+		resp, err := app.Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Reject missing query", func(t *testing.T) {
+		mockService := new(MockSearchService)
+		app := setupSearchTestApp(NewSearchHandler(mockService))
+
+		mockService.On("Search", mock.Anything).Return(nil, int64(0), service.ErrEmptySearchQuery).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/search", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("Reject unknown type", func(t *testing.T) {
+		mockService := new(MockSearchService)
+		app := setupSearchTestApp(NewSearchHandler(mockService))
+
+		mockService.On("Search", mock.Anything).Return(nil, int64(0), service.ErrInvalidSearchType).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/search?q=fda&type=video", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("Hide internal errors", func(t *testing.T) {
+		mockService := new(MockSearchService)
+		app := setupSearchTestApp(NewSearchHandler(mockService))
+
+		mockService.On("Search", mock.Anything).Return(nil, int64(0), errors.New("syntax error in tsquery")).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/search?q=fda", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusInternalServerError, resp.StatusCode)
+	})
+}
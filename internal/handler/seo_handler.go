@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/seo"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/healthcare-market-research/backend/pkg/response"
+)
+
+// seoCacheControl lets the site and crawlers cache documents for as long as the API does
+const seoCacheControl = "public, max-age=900"
+
+var feedContentTypes = map[string]string{
+	seo.FormatRSS:  "application/rss+xml; charset=utf-8",
+	seo.FormatAtom: "application/atom+xml; charset=utf-8",
+}
+
+type SEOHandler struct {
+	service service.SEOService
+}
+
+func NewSEOHandler(service service.SEOService) *SEOHandler {
+	return &SEOHandler{service: service}
+}
+
+// SitemapIndex godoc
+// @Summary Sitemap index
+// @Description Sitemap index listing one child sitemap per page of reports, blogs, press releases, categories and authors. Only published, non-deleted content that is not dated in the future is listed.
+// @Tags SEO
+// @Produce xml
+// @Success 200 {string} string "Sitemap index XML"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /sitemap.xml [get]
+func (h *SEOHandler) SitemapIndex(c *fiber.Ctx) error {
+	doc, err := h.service.SitemapIndex()
+	if err != nil {
+		return response.InternalError(c, "Failed to build sitemap index")
+	}
+
+	return sendDocument(c, fiber.MIMEApplicationXMLCharsetUTF8, doc)
+}
+
+// Sitemap godoc
+// @Summary Child sitemap
+// @Description A single sitemap file such as reports.xml, or reports-2.xml for sections with more than 50,000 pages. lastmod is the page's last update.
+// @Tags SEO
+// @Produce xml
+// @Param name path string true "Sitemap file name, e.g. reports.xml, blogs.xml, press-releases.xml, categories.xml, authors.xml"
+// @Success 200 {string} string "Sitemap XML"
+// @Failure 404 {object} response.Response{error=string} "Sitemap not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /sitemaps/{name} [get]
+func (h *SEOHandler) Sitemap(c *fiber.Ctx) error {
+	doc, err := h.service.Sitemap(c.Params("name"))
+	if err != nil {
+		if errors.Is(err, service.ErrSitemapNotFound) {
+			return response.NotFound(c, "Sitemap not found")
+		}
+		return response.InternalError(c, "Failed to build sitemap")
+	}
+
+	return sendDocument(c, fiber.MIMEApplicationXMLCharsetUTF8, doc)
+}
+
+// Feed godoc
+// @Summary Content feed
+// @Description RSS 2.0 or Atom feed of the 50 most recent published blogs or press releases, optionally limited to a category and its subcategories
+// @Tags SEO
+// @Produce xml
+// @Param section path string true "Feed section: blogs or press-releases"
+// @Param format path string true "Feed format: rss or atom"
+// @Param category query string false "Category slug"
+// @Success 200 {string} string "Feed XML"
+// @Failure 404 {object} response.Response{error=string} "Feed or category not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /feeds/{section}.{format} [get]
+func (h *SEOHandler) Feed(c *fiber.Ctx) error {
+	format := c.Params("format")
+
+	doc, err := h.service.Feed(seo.Section(c.Params("section")), format, c.Query("category"))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrFeedNotFound):
+			return response.NotFound(c, "Feed not found")
+		case errors.Is(err, service.ErrCategoryNotFound):
+			return response.NotFound(c, "Category not found")
+		default:
+			return response.InternalError(c, "Failed to build feed")
+		}
+	}
+
+	return sendDocument(c, feedContentTypes[format], doc)
+}
+
+// Robots godoc
+// @Summary robots.txt
+// @Description robots.txt for the public site, pointing crawlers at the sitemap index
+// @Tags SEO
+// @Produce plain
+// @Success 200 {string} string "robots.txt"
+// @Router /robots.txt [get]
+func (h *SEOHandler) Robots(c *fiber.Ctx) error {
+	return sendDocument(c, fiber.MIMETextPlainCharsetUTF8, []byte(h.service.Robots()))
+}
+
+func sendDocument(c *fiber.Ctx, contentType string, doc []byte) error {
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderCacheControl, seoCacheControl)
+	return c.Send(doc)
+}
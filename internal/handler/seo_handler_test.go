@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/seo"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSEOService is a mock implementation of SEOService
+type MockSEOService struct {
+	mock.Mock
+}
+
+func (m *MockSEOService) SitemapIndex() ([]byte, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockSEOService) Sitemap(name string) ([]byte, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockSEOService) Feed(section seo.Section, format, categorySlug string) ([]byte, error) {
+	args := m.Called(section, format, categorySlug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockSEOService) Robots() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func setupSEOTestApp(handler *SEOHandler) *fiber.App {
+	app := fiber.New()
+
+	app.Get("/robots.txt", handler.Robots)
+	app.Get("/sitemap.xml", handler.SitemapIndex)
+	app.Get("/sitemaps/:name", handler.Sitemap)
+	app.Get("/feeds/:section.:format", handler.Feed)
+
+	return app
+}
+
+func TestSEOHandler_Sitemap(t *testing.T) {
+	t.Run("Serves the sitemap as XML", func(t *testing.T) {
+		mockService := new(MockSEOService)
+		app := setupSEOTestApp(NewSEOHandler(mockService))
+
+		mockService.On("Sitemap", "reports-2.xml").Return([]byte("<urlset></urlset>"), nil)
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/sitemaps/reports-2.xml", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, fiber.MIMEApplicationXMLCharsetUTF8, resp.Header.Get(fiber.HeaderContentType))
+		assert.NotEmpty(t, resp.Header.Get(fiber.HeaderCacheControl))
+
+		body, _ := io.ReadAll(resp.Body)
+		assert.Equal(t, "<urlset></urlset>", string(body))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Unknown sitemap is 404", func(t *testing.T) {
+		mockService := new(MockSEOService)
+		app := setupSEOTestApp(NewSEOHandler(mockService))
+
+		mockService.On("Sitemap", "users.xml").Return(nil, service.ErrSitemapNotFound)
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/sitemaps/users.xml", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestSEOHandler_Feed(t *testing.T) {
+	t.Run("Atom feed for a category", func(t *testing.T) {
+		mockService := new(MockSEOService)
+		app := setupSEOTestApp(NewSEOHandler(mockService))
+
+		mockService.On("Feed", seo.SectionPressReleases, seo.FormatAtom, "oncology").Return([]byte("<feed></feed>"), nil)
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/feeds/press-releases.atom?category=oncology", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Equal(t, "application/atom+xml; charset=utf-8", resp.Header.Get(fiber.HeaderContentType))
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Unknown category is 404", func(t *testing.T) {
+		mockService := new(MockSEOService)
+		app := setupSEOTestApp(NewSEOHandler(mockService))
+
+		mockService.On("Feed", seo.SectionBlogs, seo.FormatRSS, "missing").Return(nil, service.ErrCategoryNotFound)
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/feeds/blogs.rss?category=missing", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestSEOHandler_Robots(t *testing.T) {
+	mockService := new(MockSEOService)
+	app := setupSEOTestApp(NewSEOHandler(mockService))
+
+	mockService.On("Robots").Return("User-agent: *\nAllow: /\n\nSitemap: https://example.com/sitemap.xml\n")
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/robots.txt", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	assert.Equal(t, fiber.MIMETextPlainCharsetUTF8, resp.Header.Get(fiber.HeaderContentType))
+
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), "Sitemap: https://example.com/sitemap.xml")
+}
@@ -0,0 +1,321 @@
+package handler
+
+import (
+	"errors"
+	"math"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/domain/tag"
+	"github.com/healthcare-market-research/backend/internal/middleware"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/healthcare-market-research/backend/pkg/response"
+)
+
+type TagHandler struct {
+	service      service.TagService
+	auditService service.AuditService
+}
+
+func NewTagHandler(service service.TagService, auditService service.AuditService) *TagHandler {
+	return &TagHandler{
+		service:      service,
+		auditService: auditService,
+	}
+}
+
+// GetAll godoc
+// @Summary Get all tags
+// @Description Get a paginated list of tags ordered by name, each with the number of published reports, blogs and press releases carrying it. Pass search to match tag names.
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param search query string false "Match tag names"
+// @Param page query int false "Page number (default: 1, min: 1)"
+// @Param limit query int false "Items per page (default: 50, max: 200)"
+// @Success 200 {object} response.Response{data=[]tag.TagWithUsage,meta=response.Meta} "List of tags with usage counts and pagination metadata"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/tags [get]
+func (h *TagHandler) GetAll(c *fiber.Ctx) error {
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "50"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 200 {
+		limit = 50
+	}
+
+	tags, total, err := h.service.GetAll(c.Query("search"), page, limit)
+	if err != nil {
+		return response.InternalError(c, "Failed to fetch tags")
+	}
+
+	meta := &response.Meta{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+	}
+
+	return response.SuccessWithMeta(c, tags, meta)
+}
+
+// GetBySlug godoc
+// @Summary Get tag by slug
+// @Description Get a tag with its usage counts and a page of the published reports, blogs and press releases carrying it, newest first. Pass type to list one content type only.
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param slug path string true "Tag slug"
+// @Param type query string false "Only list content of this type: report, blog or press_release"
+// @Param page query int false "Page number (default: 1, min: 1)"
+// @Param limit query int false "Items per page (default: 20, max: 100)"
+// @Success 200 {object} response.Response{data=tag.TagDetail,meta=response.Meta} "Tag and its content with pagination metadata"
+// @Failure 301 {object} response.Response{data=redirect.Target,error=string} "Slug was renamed or merged - Location points at the current URL"
+// @Failure 400 {object} response.Response{error=string} "Bad request - slug is required or unknown type"
+// @Failure 404 {object} response.Response{error=string} "Tag not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/tags/{slug} [get]
+func (h *TagHandler) GetBySlug(c *fiber.Ctx) error {
+	slug := c.Params("slug")
+	if slug == "" {
+		return response.BadRequest(c, "Slug is required")
+	}
+
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+	limit, _ := strconv.Atoi(c.Query("limit", "20"))
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	detail, total, err := h.service.GetBySlug(slug, c.Query("type"), page, limit)
+	if err != nil {
+		var moved *service.SlugMovedError
+		if errors.As(err, &moved) {
+			return redirectTo(c, moved.Target)
+		}
+		return h.handleError(c, err, "Failed to fetch tag")
+	}
+
+	meta := &response.Meta{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+	}
+
+	return response.SuccessWithMeta(c, detail, meta)
+}
+
+// Create godoc
+// @Summary Create tag
+// @Description Create a tag. The slug is generated from the name when omitted. Names are unique ignoring case and cannot contain commas. Tags are also created on the fly when content is saved with new tag names. Requires admin or editor role.
+// @Tags Tags
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param tag body tag.CreateTagRequest true "Tag data"
+// @Success 201 {object} response.Response{data=tag.Tag} "Created tag"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid input, slug or name already in use"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/tags [post]
+func (h *TagHandler) Create(c *fiber.Ctx) error {
+	var req tag.CreateTagRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body: "+err.Error())
+	}
+
+	if req.Name == "" {
+		return response.BadRequest(c, "Name is required")
+	}
+
+	created, err := h.service.Create(&req)
+	if err != nil {
+		return h.handleError(c, err, "Failed to create tag")
+	}
+
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionTagCreate)
+	entry.EntityType = audit.EntityTag
+	entry.EntityID = &created.ID
+	h.auditService.LogAsync(entry)
+
+	return c.Status(fiber.StatusCreated).JSON(response.Response{
+		Success: true,
+		Data:    created,
+	})
+}
+
+// Update godoc
+// @Summary Rename tag
+// @Description Rename a tag by ID. The slug follows the name unless one is given, and the old slug keeps working through a redirect. The new name is written to every report, blog and press release carrying the tag. Requires admin or editor role.
+// @Tags Tags
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Tag ID"
+// @Param tag body tag.UpdateTagRequest true "Fields to update"
+// @Success 200 {object} response.Response{data=tag.Tag} "Updated tag"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid input, slug or name already in use"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin or editor role"
+// @Failure 404 {object} response.Response{error=string} "Tag not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/tags/{id} [put]
+func (h *TagHandler) Update(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid tag ID")
+	}
+
+	var req tag.UpdateTagRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body: "+err.Error())
+	}
+
+	before, err := h.service.GetByID(uint(id))
+	if err != nil {
+		return response.NotFound(c, "Tag not found")
+	}
+	snapshot := *before
+
+	updated, err := h.service.Update(uint(id), &req)
+	if err != nil {
+		return h.handleError(c, err, "Failed to update tag")
+	}
+
+	changes := audit.Changes{}
+	if snapshot.Name != updated.Name {
+		changes["name"] = audit.FieldChange{Old: snapshot.Name, New: updated.Name}
+	}
+	if snapshot.Slug != updated.Slug {
+		changes["slug"] = audit.FieldChange{Old: snapshot.Slug, New: updated.Slug}
+	}
+	if len(changes) > 0 {
+		entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionTagUpdate)
+		entry.EntityType = audit.EntityTag
+		entry.EntityID = &updated.ID
+		entry.Changes = changes
+		h.auditService.LogAsync(entry)
+	}
+
+	return response.Success(c, updated)
+}
+
+// Delete godoc
+// @Summary Delete tag
+// @Description Delete a tag and remove it from every report, blog and press release carrying it. Requires admin role.
+// @Tags Tags
+// @Security BearerAuth
+// @Produce json
+// @Param id path int true "Tag ID"
+// @Success 200 {object} response.Response{data=tag.Tag} "Deleted tag"
+// @Failure 400 {object} response.Response{error=string} "Bad request - invalid ID"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin role"
+// @Failure 404 {object} response.Response{error=string} "Tag not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/tags/{id} [delete]
+func (h *TagHandler) Delete(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid tag ID")
+	}
+
+	deleted, err := h.service.Delete(uint(id))
+	if err != nil {
+		return h.handleError(c, err, "Failed to delete tag")
+	}
+
+	entry := middleware.NewAuditEntry(middleware.GetAuditContext(c), audit.ActionTagDelete)
+	entry.EntityType = audit.EntityTag
+	entry.EntityID = &deleted.ID
+	entry.Changes = audit.Changes{
+		"name": {Old: deleted.Name, New: nil},
+	}
+	h.auditService.LogAsync(entry)
+
+	return response.Success(c, deleted)
+}
+
+// Merge godoc
+// @Summary Merge duplicate tags
+// @Description Merge the source tags into the tag in the path. Content carrying a source carries the target instead, in the source's place, and the sources' slugs redirect to the target. The sources are deleted. Requires admin role.
+// @Tags Tags
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param id path int true "Target tag ID"
+// @Param request body tag.MergeTagsRequest true "Tags to merge into the target"
+// @Success 200 {object} response.Response{data=tag.MergeResult} "Merged tag"
+// @Failure 400 {object} response.Response{error=string} "Bad request - no sources or target among sources"
+// @Failure 401 {object} response.Response{error=string} "Unauthorized - authentication required"
+// @Failure 403 {object} response.Response{error=string} "Forbidden - requires admin role"
+// @Failure 404 {object} response.Response{error=string} "Target or source tag not found"
+// @Failure 500 {object} response.Response{error=string} "Internal server error"
+// @Router /api/v1/tags/{id}/merge [post]
+func (h *TagHandler) Merge(c *fiber.Ctx) error {
+	id, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return response.BadRequest(c, "Invalid tag ID")
+	}
+
+	var req tag.MergeTagsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return response.BadRequest(c, "Invalid request body: "+err.Error())
+	}
+	if len(req.SourceIDs) == 0 {
+		return response.BadRequest(c, "source_ids is required")
+	}
+
+	result, err := h.service.Merge(uint(id), req.SourceIDs)
+	if err != nil {
+		return h.handleError(c, err, "Failed to merge tags")
+	}
+
+	auditCtx := middleware.GetAuditContext(c)
+	entry := middleware.NewAuditEntry(auditCtx, audit.ActionTagMerge)
+	entry.EntityType = audit.EntityTag
+	entry.EntityID = &result.Tag.ID
+	entry.Changes = audit.Changes{
+		"merged_ids":      {New: result.MergedIDs},
+		"content_updated": {New: result.ContentUpdated},
+	}
+	h.auditService.LogAsync(entry)
+
+	for i := range result.MergedIDs {
+		mergedID := result.MergedIDs[i]
+		deleted := middleware.NewAuditEntry(auditCtx, audit.ActionTagDelete)
+		deleted.EntityType = audit.EntityTag
+		deleted.EntityID = &mergedID
+		deleted.Changes = audit.Changes{
+			"merged_into": {New: result.Tag.ID},
+		}
+		h.auditService.LogAsync(deleted)
+	}
+
+	return response.Success(c, result)
+}
+
+// handleError maps tag service errors to HTTP responses
+func (h *TagHandler) handleError(c *fiber.Ctx, err error, fallback string) error {
+	switch {
+	case errors.Is(err, service.ErrTagNotFound):
+		return response.NotFound(c, err.Error())
+	case errors.Is(err, service.ErrTagSlugTaken),
+		errors.Is(err, service.ErrTagNameTaken),
+		errors.Is(err, service.ErrInvalidTagData),
+		errors.Is(err, service.ErrInvalidTagMerge):
+		return response.BadRequest(c, err.Error())
+	default:
+		return response.InternalError(c, fallback)
+	}
+}
@@ -0,0 +1,232 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/domain/redirect"
+	"github.com/healthcare-market-research/backend/internal/domain/tag"
+	"github.com/healthcare-market-research/backend/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockTagService is a mock implementation of TagService
+type MockTagService struct {
+	mock.Mock
+}
+
+func (m *MockTagService) GetAll(search string, page, limit int) ([]tag.TagWithUsage, int64, error) {
+	args := m.Called(search, page, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]tag.TagWithUsage), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockTagService) GetBySlug(slug, contentType string, page, limit int) (*tag.TagDetail, int64, error) {
+	args := m.Called(slug, contentType, page, limit)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).(*tag.TagDetail), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockTagService) GetByID(id uint) (*tag.Tag, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tag.Tag), args.Error(1)
+}
+
+func (m *MockTagService) Create(req *tag.CreateTagRequest) (*tag.Tag, error) {
+	args := m.Called(req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tag.Tag), args.Error(1)
+}
+
+func (m *MockTagService) Update(id uint, req *tag.UpdateTagRequest) (*tag.Tag, error) {
+	args := m.Called(id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tag.Tag), args.Error(1)
+}
+
+func (m *MockTagService) Delete(id uint) (*tag.Tag, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tag.Tag), args.Error(1)
+}
+
+func (m *MockTagService) Merge(targetID uint, sourceIDs []uint) (*tag.MergeResult, error) {
+	args := m.Called(targetID, sourceIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*tag.MergeResult), args.Error(1)
+}
+
+func setupTagTestApp(handler *TagHandler) *fiber.App {
+	app := fiber.New()
+	app.Get("/api/v1/tags", handler.GetAll)
+	app.Get("/api/v1/tags/:slug", handler.GetBySlug)
+	app.Post("/api/v1/tags", handler.Create)
+	app.Put("/api/v1/tags/:id", handler.Update)
+	app.Delete("/api/v1/tags/:id", handler.Delete)
+	app.Post("/api/v1/tags/:id/merge", handler.Merge)
+	return app
+}
+
+func TestTagHandler_GetBySlug(t *testing.T) {
+	t.Run("Lists tagged content across types", func(t *testing.T) {
+		mockService := new(MockTagService)
+		app := setupTagTestApp(NewTagHandler(mockService, &MockAuditService{}))
+
+		detail := &tag.TagDetail{
+			TagWithUsage: tag.TagWithUsage{
+				Tag:   tag.Tag{ID: 1, Name: "Digital Health", Slug: "digital-health"},
+				Usage: tag.Usage{Reports: 1, Blogs: 1, Total: 2},
+			},
+			Content: []tag.TaggedContent{
+				{Type: tag.ContentBlog, ID: 4, Title: "Telehealth after the pandemic", Slug: "telehealth-after-the-pandemic"},
+				{Type: tag.ContentReport, ID: 12, Title: "Digital Health Market", Slug: "digital-health-market"},
+			},
+		}
+		mockService.On("GetBySlug", "digital-health", "", 1, 20).Return(detail, int64(2), nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/tags/digital-health", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		var body struct {
+			Data tag.TagDetail `json:"data"`
+		}
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		assert.Equal(t, "Digital Health", body.Data.Name)
+		assert.Equal(t, int64(2), body.Data.Usage.Total)
+		assert.Len(t, body.Data.Content, 2)
+		assert.Equal(t, tag.ContentBlog, body.Data.Content[0].Type)
+	})
+
+	t.Run("Filters by content type", func(t *testing.T) {
+		mockService := new(MockTagService)
+		app := setupTagTestApp(NewTagHandler(mockService, &MockAuditService{}))
+
+		mockService.On("GetBySlug", "digital-health", tag.ContentReport, 2, 10).
+			Return(&tag.TagDetail{}, int64(11), nil).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/tags/digital-health?type=report&page=2&limit=10", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Redirects a renamed slug", func(t *testing.T) {
+		mockService := new(MockTagService)
+		app := setupTagTestApp(NewTagHandler(mockService, &MockAuditService{}))
+
+		target := &redirect.Target{
+			EntityType: redirect.EntityTag,
+			EntityID:   1,
+			OldSlug:    "ehealth",
+			Slug:       "digital-health",
+			Location:   redirect.Location(redirect.EntityTag, "digital-health"),
+		}
+		mockService.On("GetBySlug", "ehealth", "", 1, 20).Return(nil, int64(0), &service.SlugMovedError{Target: target}).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/tags/ehealth", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusMovedPermanently, resp.StatusCode)
+		assert.Equal(t, "/api/v1/tags/digital-health", resp.Header.Get("Location"))
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		mockService := new(MockTagService)
+		app := setupTagTestApp(NewTagHandler(mockService, &MockAuditService{}))
+
+		mockService.On("GetBySlug", "missing", "", 1, 20).Return(nil, int64(0), service.ErrTagNotFound).Once()
+
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/tags/missing", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+	})
+}
+
+func TestTagHandler_Update(t *testing.T) {
+	t.Run("Rename is audited", func(t *testing.T) {
+		mockService := new(MockTagService)
+		auditService := &MockAuditService{}
+		app := setupTagTestApp(NewTagHandler(mockService, auditService))
+
+		mockService.On("GetByID", uint(1)).Return(&tag.Tag{ID: 1, Name: "eHealth", Slug: "ehealth"}, nil).Once()
+		mockService.On("Update", uint(1), mock.MatchedBy(func(req *tag.UpdateTagRequest) bool {
+			return req.Name != nil && *req.Name == "Digital Health"
+		})).Return(&tag.Tag{ID: 1, Name: "Digital Health", Slug: "digital-health"}, nil).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPut, "/api/v1/tags/1", map[string]interface{}{"name": "Digital Health"}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+		assert.Len(t, auditService.entries, 1)
+		assert.Equal(t, audit.ActionTagUpdate, auditService.entries[0].Action)
+		assert.Contains(t, auditService.entries[0].Changes, "name")
+		assert.Contains(t, auditService.entries[0].Changes, "slug")
+	})
+
+	t.Run("Fail when name is taken", func(t *testing.T) {
+		mockService := new(MockTagService)
+		app := setupTagTestApp(NewTagHandler(mockService, &MockAuditService{}))
+
+		mockService.On("GetByID", uint(1)).Return(&tag.Tag{ID: 1, Name: "eHealth", Slug: "ehealth"}, nil).Once()
+		mockService.On("Update", uint(1), mock.Anything).Return(nil, service.ErrTagNameTaken).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPut, "/api/v1/tags/1", map[string]interface{}{"name": "Digital Health"}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestTagHandler_Merge(t *testing.T) {
+	t.Run("Successfully merge duplicates", func(t *testing.T) {
+		mockService := new(MockTagService)
+		auditService := &MockAuditService{}
+		app := setupTagTestApp(NewTagHandler(mockService, auditService))
+
+		mockService.On("Merge", uint(1), []uint{2, 5}).Return(&tag.MergeResult{
+			Tag:            &tag.Tag{ID: 1, Name: "Digital Health", Slug: "digital-health"},
+			MergedIDs:      []uint{2, 5},
+			ContentUpdated: 6,
+		}, nil).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPost, "/api/v1/tags/1/merge", map[string]interface{}{"source_ids": []uint{2, 5}}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+		// One entry for the target and one for each merged tag
+		assert.Len(t, auditService.entries, 3)
+		assert.Equal(t, audit.ActionTagMerge, auditService.entries[0].Action)
+		assert.Equal(t, audit.ActionTagDelete, auditService.entries[2].Action)
+		assert.Equal(t, uint(5), *auditService.entries[2].EntityID)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Fail merging into itself", func(t *testing.T) {
+		mockService := new(MockTagService)
+		app := setupTagTestApp(NewTagHandler(mockService, &MockAuditService{}))
+
+		mockService.On("Merge", uint(1), []uint{1}).Return(nil, service.ErrInvalidTagMerge).Once()
+
+		resp, err := app.Test(jsonRequest(http.MethodPost, "/api/v1/tags/1/merge", map[string]interface{}{"source_ids": []uint{1}}))
+		assert.NoError(t, err)
+		assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+	})
+}
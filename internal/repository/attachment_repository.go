@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/healthcare-market-research/backend/internal/domain/attachment"
+	"gorm.io/gorm"
+)
+
+type AttachmentRepository interface {
+	Create(a *attachment.Attachment) error
+	FindByID(id uint) (*attachment.Attachment, error)
+	FindByOwner(ownerType string, ownerID uint) ([]attachment.Attachment, error)
+	Update(a *attachment.Attachment) error
+	Delete(id uint) error
+	NextSortOrder(ownerType string, ownerID uint) (int, error)
+	Reorder(ownerType string, ownerID uint, ids []uint) error
+	OwnerExists(ownerType string, ownerID uint) (bool, error)
+}
+
+type attachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) AttachmentRepository {
+	return &attachmentRepository{db: db}
+}
+
+// orderAttachments is the Preload condition that lists attachments in their
+// display order
+func orderAttachments(db *gorm.DB) *gorm.DB {
+	return db.Order("sort_order ASC, id ASC")
+}
+
+// Create inserts the attachment. A featured attachment takes over from the
+// owner's current featured image.
+func (r *attachmentRepository) Create(a *attachment.Attachment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := clearFeatured(tx, a); err != nil {
+			return err
+		}
+		return tx.Create(a).Error
+	})
+}
+
+func (r *attachmentRepository) FindByID(id uint) (*attachment.Attachment, error) {
+	var a attachment.Attachment
+	if err := r.db.First(&a, id).Error; err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *attachmentRepository) FindByOwner(ownerType string, ownerID uint) ([]attachment.Attachment, error) {
+	return findOwnerAttachments(r.db, ownerType, ownerID)
+}
+
+func findOwnerAttachments(db *gorm.DB, ownerType string, ownerID uint) ([]attachment.Attachment, error) {
+	var attachments []attachment.Attachment
+	err := orderAttachments(db.Where("owner_type = ? AND owner_id = ?", ownerType, ownerID)).
+		Find(&attachments).Error
+	return attachments, err
+}
+
+func (r *attachmentRepository) Update(a *attachment.Attachment) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := clearFeatured(tx, a); err != nil {
+			return err
+		}
+		return tx.Save(a).Error
+	})
+}
+
+func (r *attachmentRepository) Delete(id uint) error {
+	return r.db.Delete(&attachment.Attachment{}, id).Error
+}
+
+// NextSortOrder returns the position after the owner's last attachment
+func (r *attachmentRepository) NextSortOrder(ownerType string, ownerID uint) (int, error) {
+	var next int
+	err := r.db.Model(&attachment.Attachment{}).
+		Where("owner_type = ? AND owner_id = ?", ownerType, ownerID).
+		Select("COALESCE(MAX(sort_order) + 1, 0)").
+		Scan(&next).Error
+	return next, err
+}
+
+// Reorder sets each attachment's sort order to its position in ids in one
+// transaction
+func (r *attachmentRepository) Reorder(ownerType string, ownerID uint, ids []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i, id := range ids {
+			if err := tx.Model(&attachment.Attachment{}).
+				Where("id = ? AND owner_type = ? AND owner_id = ?", id, ownerType, ownerID).
+				Update("sort_order", i).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// OwnerExists reports whether the blog, press release or category exists.
+// Soft-deleted blogs and press releases do not count.
+func (r *attachmentRepository) OwnerExists(ownerType string, ownerID uint) (bool, error) {
+	var query *gorm.DB
+	switch ownerType {
+	case attachment.OwnerBlog:
+		query = r.db.Table("blogs").Where("id = ? AND deleted_at IS NULL", ownerID)
+	case attachment.OwnerPressRelease:
+		query = r.db.Table("press_releases").Where("id = ? AND deleted_at IS NULL", ownerID)
+	case attachment.OwnerCategory:
+		query = r.db.Table("categories").Where("id = ?", ownerID)
+	default:
+		return false, fmt.Errorf("unknown attachment owner type %q", ownerType)
+	}
+
+	var count int64
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+// clearFeatured unfeatures the owner's other attachments when a is featured,
+// keeping at most one featured image per owner
+func clearFeatured(tx *gorm.DB, a *attachment.Attachment) error {
+	if !a.IsFeatured {
+		return nil
+	}
+	return tx.Model(&attachment.Attachment{}).
+		Where("owner_type = ? AND owner_id = ? AND is_featured = ? AND id <> ?", a.OwnerType, a.OwnerID, true, a.ID).
+		Update("is_featured", false).Error
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/pkg/pagination"
 	"gorm.io/gorm"
 )
 
@@ -69,14 +70,20 @@ func (r *auditRepository) GetAll(filters audit.AuditLogFilters) ([]audit.AuditLo
 		query = query.Where("ip_address = ?", filters.IPAddress)
 	}
 
-	// Get total count before pagination
-	if err := query.Count(&total).Error; err != nil {
+	// Get total count before pagination (skipped when the caller doesn't need it)
+	if filters.SkipCount {
+		total = -1
+	} else if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	// Apply pagination
+	// Apply pagination: keyset when a cursor is given, offset otherwise
 	offset := (filters.Page - 1) * filters.Limit
-	err := query.Order("created_at DESC").
+	if filters.Cursor != nil {
+		query = query.Where(pagination.KeysetCondition("", true), filters.Cursor.CreatedAt, filters.Cursor.ID)
+		offset = 0
+	}
+	err := query.Order("created_at DESC, id DESC").
 		Offset(offset).
 		Limit(filters.Limit).
 		Find(&logs).Error
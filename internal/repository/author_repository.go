@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"strings"
+
 	"github.com/healthcare-market-research/backend/internal/domain/author"
 	"gorm.io/gorm"
 )
@@ -9,6 +11,7 @@ type AuthorRepository interface {
 	GetAll(page, limit int, search string) ([]author.Author, int64, error)
 	GetByID(id uint) (*author.Author, error)
 	GetByIDs(ids []uint) ([]author.Author, error)
+	GetByNames(names []string) ([]author.Author, error)
 	Create(author *author.Author) error
 	Update(author *author.Author) error
 	Delete(id uint) error
@@ -74,6 +77,26 @@ func (r *authorRepository) GetByIDs(ids []uint) ([]author.Author, error) {
 	return authors, nil
 }
 
+// GetByNames returns the authors named any of names, ignoring case
+func (r *authorRepository) GetByNames(names []string) ([]author.Author, error) {
+	if len(names) == 0 {
+		return []author.Author{}, nil
+	}
+
+	keys := make([]string, len(names))
+	for i, name := range names {
+		keys[i] = strings.ToLower(strings.TrimSpace(name))
+	}
+
+	var authors []author.Author
+	err := r.db.Where("LOWER(name) IN ?", keys).Order("id ASC").Find(&authors).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return authors, nil
+}
+
 func (r *authorRepository) Create(auth *author.Author) error {
 	return r.db.Create(auth).Error
 }
@@ -4,8 +4,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/healthcare-market-research/backend/internal/domain/attachment"
 	"github.com/healthcare-market-research/backend/internal/domain/blog"
+	"github.com/healthcare-market-research/backend/internal/domain/tag"
+	"github.com/healthcare-market-research/backend/pkg/pagination"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type BlogRepository interface {
@@ -13,7 +17,7 @@ type BlogRepository interface {
 	GetAll(query blog.GetBlogsQuery) ([]blog.Blog, int64, error)
 	GetByID(id uint) (*blog.Blog, error)
 	GetBySlug(slug string) (*blog.Blog, error)
-	Update(id uint, updates map[string]interface{}) error
+	Update(id uint, version int, updates map[string]interface{}) error
 	Delete(id uint) error
 	SoftDelete(id uint) error
 	Restore(id uint) error
@@ -53,21 +57,9 @@ func (r *blogRepository) GetAll(query blog.GetBlogsQuery) ([]blog.Blog, int64, e
 	}
 
 	if query.Tags != "" {
-		// Split comma-separated tags and search for any of them
-		tags := strings.Split(query.Tags, ",")
-		tagConditions := make([]string, 0, len(tags))
-		tagValues := make([]interface{}, 0, len(tags))
-
-		for _, tag := range tags {
-			tag = strings.TrimSpace(tag)
-			if tag != "" {
-				tagConditions = append(tagConditions, "tags LIKE ?")
-				tagValues = append(tagValues, "%"+tag+"%")
-			}
-		}
-
-		if len(tagConditions) > 0 {
-			db = db.Where(strings.Join(tagConditions, " OR "), tagValues...)
+		// Any of the comma-separated tags, by name or slug
+		if condition, args := tagFilter(tag.ContentBlog, "blogs.id", strings.Split(query.Tags, ",")); condition != "" {
+			db = db.Where(condition, args...)
 		}
 	}
 
@@ -80,8 +72,7 @@ func (r *blogRepository) GetAll(query blog.GetBlogsQuery) ([]blog.Blog, int64, e
 	}
 
 	if query.Search != "" {
-		searchPattern := "%" + query.Search + "%"
-		db = db.Where("title ILIKE ? OR excerpt ILIKE ? OR content ILIKE ?", searchPattern, searchPattern, searchPattern)
+		db = db.Where("search_vector @@ "+tsQueryExpr, query.Search)
 	}
 
 	// Apply soft delete filter
@@ -91,14 +82,30 @@ func (r *blogRepository) GetAll(query blog.GetBlogsQuery) ([]blog.Blog, int64, e
 		db = db.Where("deleted_at IS NULL")
 	}
 
-	// Count total before pagination
-	if err := db.Count(&total).Error; err != nil {
+	// Count total before pagination (skipped when the caller doesn't need it)
+	if query.SkipCount {
+		total = -1
+	} else if err := db.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	// Apply pagination
+	// Apply pagination: keyset when a cursor is given, offset otherwise
 	offset := (query.Page - 1) * query.Limit
-	db = db.Order("created_at DESC").Offset(offset).Limit(query.Limit)
+	if query.Cursor != nil {
+		db = db.Where(pagination.KeysetCondition("", true), query.Cursor.CreatedAt, query.Cursor.ID)
+		offset = 0
+	}
+	if query.Search != "" {
+		// Most relevant first when searching
+		db = db.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "ts_rank(search_vector, " + tsQueryExpr + ") DESC, created_at DESC",
+			Vars:               []interface{}{query.Search},
+			WithoutParentheses: true,
+		}})
+	} else {
+		db = db.Order("created_at DESC, id DESC")
+	}
+	db = db.Offset(offset).Limit(query.Limit)
 
 	// Fetch blogs with author and category details
 	if err := db.Preload("Author").Preload("Category").Find(&blogs).Error; err != nil {
@@ -110,22 +117,25 @@ func (r *blogRepository) GetAll(query blog.GetBlogsQuery) ([]blog.Blog, int64, e
 
 func (r *blogRepository) GetByID(id uint) (*blog.Blog, error) {
 	var b blog.Blog
-	if err := r.db.Preload("Author").Preload("Category").Where("deleted_at IS NULL").First(&b, id).Error; err != nil {
+	if err := r.db.Preload("Author").Preload("Category").Preload("Attachments", orderAttachments).Where("deleted_at IS NULL").First(&b, id).Error; err != nil {
 		return nil, err
 	}
+	b.FeaturedImage = attachment.Featured(b.Attachments)
 	return &b, nil
 }
 
 func (r *blogRepository) GetBySlug(slug string) (*blog.Blog, error) {
 	var b blog.Blog
-	if err := r.db.Preload("Author").Preload("Category").Where("slug = ? AND deleted_at IS NULL", slug).First(&b).Error; err != nil {
+	if err := r.db.Preload("Author").Preload("Category").Preload("Attachments", orderAttachments).Where("slug = ? AND deleted_at IS NULL", slug).First(&b).Error; err != nil {
 		return nil, err
 	}
+	b.FeaturedImage = attachment.Featured(b.Attachments)
 	return &b, nil
 }
 
-func (r *blogRepository) Update(id uint, updates map[string]interface{}) error {
-	return r.db.Model(&blog.Blog{}).Where("id = ?", id).Updates(updates).Error
+// Update applies updates only if the row is still at the given version
+func (r *blogRepository) Update(id uint, version int, updates map[string]interface{}) error {
+	return updateVersioned(r.db, &blog.Blog{}, id, version, updates)
 }
 
 func (r *blogRepository) Delete(id uint) error {
@@ -133,15 +143,15 @@ func (r *blogRepository) Delete(id uint) error {
 }
 
 func (r *blogRepository) SubmitForReview(id uint) error {
-	return r.db.Model(&blog.Blog{}).Where("id = ?", id).Update("status", blog.StatusReview).Error
+	return r.db.Model(&blog.Blog{}).Where("id = ?", id).Updates(map[string]interface{}{"status": blog.StatusReview, "version": bumpVersion}).Error
 }
 
 func (r *blogRepository) Publish(id uint) error {
-	return r.db.Model(&blog.Blog{}).Where("id = ?", id).Update("status", blog.StatusPublished).Error
+	return r.db.Model(&blog.Blog{}).Where("id = ?", id).Updates(map[string]interface{}{"status": blog.StatusPublished, "version": bumpVersion}).Error
 }
 
 func (r *blogRepository) Unpublish(id uint) error {
-	return r.db.Model(&blog.Blog{}).Where("id = ?", id).Update("status", blog.StatusDraft).Error
+	return r.db.Model(&blog.Blog{}).Where("id = ?", id).Updates(map[string]interface{}{"status": blog.StatusDraft, "version": bumpVersion}).Error
 }
 
 func (r *blogRepository) SoftDelete(id uint) error {
@@ -159,6 +169,7 @@ func (r *blogRepository) PublishScheduled(now time.Time) error {
 		Updates(map[string]interface{}{
 			"status":                    blog.StatusPublished,
 			"scheduled_publish_enabled": false,
+			"version":                   bumpVersion,
 		}).Error
 }
 
@@ -168,11 +179,12 @@ func (r *blogRepository) SchedulePublish(id uint, publishDate time.Time) error {
 		Updates(map[string]interface{}{
 			"publish_date":              publishDate,
 			"scheduled_publish_enabled": true,
+			"version":                   bumpVersion,
 		}).Error
 }
 
 func (r *blogRepository) CancelScheduledPublish(id uint) error {
 	return r.db.Model(&blog.Blog{}).
 		Where("id = ?", id).
-		Update("scheduled_publish_enabled", false).Error
+		Updates(map[string]interface{}{"scheduled_publish_enabled": false, "version": bumpVersion}).Error
 }
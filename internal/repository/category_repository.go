@@ -1,14 +1,26 @@
 package repository
 
 import (
+	"github.com/healthcare-market-research/backend/internal/domain/attachment"
 	"github.com/healthcare-market-research/backend/internal/domain/category"
 	"gorm.io/gorm"
 )
 
 type CategoryRepository interface {
 	GetAll(page, limit int) ([]category.Category, int64, error)
+	GetAllActive() ([]category.Category, error)
+	GetAllIncludingInactive() ([]category.Category, error)
 	GetBySlug(slug string) (*category.Category, error)
 	GetByID(id uint) (*category.Category, error)
+	GetByIDIncludingInactive(id uint) (*category.Category, error)
+	GetChildren(parentID uint) ([]category.Category, error)
+	GetDescendantIDs(id uint) ([]uint, error)
+	GetDescendantIDsIncludingInactive(id uint) ([]uint, error)
+	SlugExists(slug string, excludeID uint) (bool, error)
+	Create(cat *category.Category) error
+	Update(cat *category.Category) error
+	Deactivate(id uint) error
+	Reorder(items []category.ReorderItem) error
 }
 
 type categoryRepository struct {
@@ -33,7 +45,7 @@ func (r *categoryRepository) GetAll(page, limit int) ([]category.Category, int64
 
 	// Raw SQL for fetching categories
 	querySQL := `
-		SELECT id, name, slug, description, is_active, created_at, updated_at
+		SELECT id, parent_id, name, slug, description, image_url, image_variants, sort_order, is_active, created_at, updated_at
 		FROM categories
 		WHERE is_active = true
 		ORDER BY name ASC
@@ -45,21 +57,60 @@ func (r *categoryRepository) GetAll(page, limit int) ([]category.Category, int64
 	return categories, total, err
 }
 
+// GetAllActive returns every active category ordered for tree building
+func (r *categoryRepository) GetAllActive() ([]category.Category, error) {
+	var categories []category.Category
+
+	querySQL := `
+		SELECT id, parent_id, name, slug, description, image_url, image_variants, sort_order, is_active, created_at, updated_at
+		FROM categories
+		WHERE is_active = true
+		ORDER BY sort_order ASC, name ASC
+	`
+
+	err := r.db.Raw(querySQL).Scan(&categories).Error
+	return categories, err
+}
+
+// GetAllIncludingInactive returns every category, active or not, for checks
+// that have to see the whole hierarchy
+func (r *categoryRepository) GetAllIncludingInactive() ([]category.Category, error) {
+	var categories []category.Category
+
+	querySQL := `
+		SELECT id, parent_id, name, slug, description, image_url, image_variants, sort_order, is_active, created_at, updated_at
+		FROM categories
+		ORDER BY sort_order ASC, name ASC
+	`
+
+	err := r.db.Raw(querySQL).Scan(&categories).Error
+	return categories, err
+}
+
 func (r *categoryRepository) GetBySlug(slug string) (*category.Category, error) {
 	var cat category.Category
 
 	// Use raw SQL for better performance
 	querySQL := `
-		SELECT id, name, slug, description, is_active, created_at, updated_at
+		SELECT id, parent_id, name, slug, description, image_url, image_variants, sort_order, is_active, created_at, updated_at
 		FROM categories
 		WHERE slug = ? AND is_active = true
 		LIMIT 1
 	`
 
-	err := r.db.Raw(querySQL, slug).Scan(&cat).Error
+	result := r.db.Raw(querySQL, slug).Scan(&cat)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	attachments, err := findOwnerAttachments(r.db, attachment.OwnerCategory, cat.ID)
 	if err != nil {
 		return nil, err
 	}
+	cat.Attachments = attachments
 
 	return &cat, nil
 }
@@ -69,16 +120,119 @@ func (r *categoryRepository) GetByID(id uint) (*category.Category, error) {
 
 	// Use raw SQL for better performance
 	querySQL := `
-		SELECT id, name, slug, description, is_active, created_at, updated_at
+		SELECT id, parent_id, name, slug, description, image_url, image_variants, sort_order, is_active, created_at, updated_at
 		FROM categories
 		WHERE id = ? AND is_active = true
 		LIMIT 1
 	`
 
-	err := r.db.Raw(querySQL, id).Scan(&cat).Error
-	if err != nil {
-		return nil, err
+	result := r.db.Raw(querySQL, id).Scan(&cat)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
 	}
 
 	return &cat, nil
 }
+
+// GetByIDIncludingInactive is used by admin write paths that may act on deactivated categories
+func (r *categoryRepository) GetByIDIncludingInactive(id uint) (*category.Category, error) {
+	var cat category.Category
+	if err := r.db.First(&cat, id).Error; err != nil {
+		return nil, err
+	}
+	return &cat, nil
+}
+
+func (r *categoryRepository) GetChildren(parentID uint) ([]category.Category, error) {
+	var categories []category.Category
+
+	querySQL := `
+		SELECT id, parent_id, name, slug, description, image_url, image_variants, sort_order, is_active, created_at, updated_at
+		FROM categories
+		WHERE parent_id = ? AND is_active = true
+		ORDER BY sort_order ASC, name ASC
+	`
+
+	err := r.db.Raw(querySQL, parentID).Scan(&categories).Error
+	return categories, err
+}
+
+// GetDescendantIDs returns the IDs of all active categories below the given one (excluding itself)
+func (r *categoryRepository) GetDescendantIDs(id uint) ([]uint, error) {
+	var ids []uint
+
+	querySQL := `
+		WITH RECURSIVE tree AS (
+			SELECT id FROM categories WHERE parent_id = ? AND is_active = true
+			UNION
+			SELECT c.id FROM categories c
+			INNER JOIN tree t ON c.parent_id = t.id
+			WHERE c.is_active = true
+		)
+		SELECT id FROM tree
+	`
+
+	err := r.db.Raw(querySQL, id).Scan(&ids).Error
+	return ids, err
+}
+
+// GetDescendantIDsIncludingInactive returns the IDs of all categories below the
+// given one (excluding itself), walking through inactive ones too
+func (r *categoryRepository) GetDescendantIDsIncludingInactive(id uint) ([]uint, error) {
+	var ids []uint
+
+	querySQL := `
+		WITH RECURSIVE tree AS (
+			SELECT id FROM categories WHERE parent_id = ?
+			UNION
+			SELECT c.id FROM categories c
+			INNER JOIN tree t ON c.parent_id = t.id
+		)
+		SELECT id FROM tree
+	`
+
+	err := r.db.Raw(querySQL, id).Scan(&ids).Error
+	return ids, err
+}
+
+func (r *categoryRepository) SlugExists(slug string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&category.Category{}).
+		Where("slug = ? AND id <> ?", slug, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *categoryRepository) Create(cat *category.Category) error {
+	return r.db.Create(cat).Error
+}
+
+func (r *categoryRepository) Update(cat *category.Category) error {
+	return r.db.Save(cat).Error
+}
+
+func (r *categoryRepository) Deactivate(id uint) error {
+	return r.db.Model(&category.Category{}).Where("id = ?", id).Update("is_active", false).Error
+}
+
+// Reorder updates sort order, and parent where the item moves it, for
+// several categories in one transaction
+func (r *categoryRepository) Reorder(items []category.ReorderItem) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, item := range items {
+			updates := map[string]interface{}{"sort_order": item.SortOrder}
+			if item.MovesParent() {
+				updates["parent_id"] = item.ParentID
+			}
+			if err := tx.Model(&category.Category{}).
+				Where("id = ?", item.ID).
+				Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"gorm.io/gorm"
+)
+
+// chartSelectSQL reads charts with the URL and alt text of their fallback
+// image, which is left out while the image is inactive
+const chartSelectSQL = `
+	SELECT c.id, c.report_id, c.title, c.chart_type, c.description,
+	       c.data_points, c.data, c.image_id,
+	       COALESCE(ri.image_url, '') AS image_url,
+	       COALESCE(ri.alt_text, '') AS image_alt_text,
+	       c."order", c.is_active, c.created_at, c.updated_at
+	FROM chart_metadata c
+	LEFT JOIN report_images ri ON ri.id = c.image_id AND ri.is_active = true
+`
+
+type ChartRepository interface {
+	// FindByReportID returns every chart of a report, inactive ones included
+	FindByReportID(reportID uint) ([]report.ChartMetadata, error)
+	FindByID(id uint) (*report.ChartMetadata, error)
+	Create(chart *report.ChartMetadata) error
+	Update(chart *report.ChartMetadata) error
+	Delete(id uint) error
+	NextOrder(reportID uint) (int, error)
+}
+
+type chartRepository struct {
+	db *gorm.DB
+}
+
+func NewChartRepository(db *gorm.DB) ChartRepository {
+	return &chartRepository{db: db}
+}
+
+func (r *chartRepository) FindByReportID(reportID uint) ([]report.ChartMetadata, error) {
+	var charts []report.ChartMetadata
+	err := r.db.Raw(chartSelectSQL+`WHERE c.report_id = ? ORDER BY c."order" ASC, c.id ASC`, reportID).
+		Scan(&charts).Error
+	return charts, err
+}
+
+func (r *chartRepository) FindByID(id uint) (*report.ChartMetadata, error) {
+	var chart report.ChartMetadata
+	res := r.db.Raw(chartSelectSQL+`WHERE c.id = ?`, id).Scan(&chart)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &chart, nil
+}
+
+func (r *chartRepository) Create(chart *report.ChartMetadata) error {
+	if err := r.db.Create(chart).Error; err != nil {
+		return err
+	}
+	// GORM writes the column default in place of a false is_active
+	if !chart.IsActive {
+		return r.db.Model(chart).Update("is_active", false).Error
+	}
+	return nil
+}
+
+func (r *chartRepository) Update(chart *report.ChartMetadata) error {
+	return r.db.Save(chart).Error
+}
+
+func (r *chartRepository) Delete(id uint) error {
+	return r.db.Delete(&report.ChartMetadata{}, id).Error
+}
+
+// NextOrder returns the position after the report's last chart
+func (r *chartRepository) NextOrder(reportID uint) (int, error) {
+	var next int
+	err := r.db.Model(&report.ChartMetadata{}).
+		Where("report_id = ?", reportID).
+		Select(`COALESCE(MAX("order") + 1, 0)`).
+		Scan(&next).Error
+	return next, err
+}
@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/healthcare-market-research/backend/internal/domain/company"
+	"github.com/healthcare-market-research/backend/internal/domain/redirect"
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"gorm.io/gorm"
+)
+
+// ReportKeyPlayers is the key player list of one report
+type ReportKeyPlayers struct {
+	ID         uint
+	KeyPlayers report.KeyPlayers
+}
+
+type CompanyRepository interface {
+	GetAll(search string, page, limit int) ([]company.Company, int64, error)
+	ListAll() ([]company.Company, error)
+	GetByID(id uint) (*company.Company, error)
+	GetByIDs(ids []uint) ([]company.Company, error)
+	GetBySlug(slug string) (*company.Company, error)
+	FindByNameKeys(keys []string, excludeID uint) ([]company.Company, error)
+	SlugExists(slug string, excludeID uint) (bool, error)
+	Create(c *company.Company) error
+	Update(c *company.Company) error
+	Delete(id uint) error
+	GetReports(companyID uint) ([]company.CompanyReport, error)
+	CountReferences(companyID uint) (int64, error)
+	Merge(target *company.Company, sourceIDs []uint) (int, error)
+	ListKeyPlayers() ([]ReportKeyPlayers, error)
+	UpdateKeyPlayers(reportID uint, players report.KeyPlayers) error
+}
+
+type companyRepository struct {
+	db *gorm.DB
+}
+
+func NewCompanyRepository(db *gorm.DB) CompanyRepository {
+	return &companyRepository{db: db}
+}
+
+// companyContainment matches key_players arrays with a player linked to the company
+func companyContainment(companyID uint) string {
+	return fmt.Sprintf(`[{"companyId":%d}]`, companyID)
+}
+
+// GetAll lists companies by name, optionally matching a search term against
+// names and aliases
+func (r *companyRepository) GetAll(search string, page, limit int) ([]company.Company, int64, error) {
+	var companies []company.Company
+	var total int64
+
+	query := r.db.Model(&company.Company{})
+	if search != "" {
+		pattern := "%" + search + "%"
+		query = query.Where(
+			"name ILIKE ? OR EXISTS (SELECT 1 FROM jsonb_array_elements_text(aliases) a WHERE a ILIKE ?)",
+			pattern, pattern,
+		)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("name ASC, id ASC").Limit(limit).Offset(offset).Find(&companies).Error
+	return companies, total, err
+}
+
+// ListAll returns the whole directory, oldest first
+func (r *companyRepository) ListAll() ([]company.Company, error) {
+	var companies []company.Company
+	err := r.db.Order("id ASC").Find(&companies).Error
+	return companies, err
+}
+
+func (r *companyRepository) GetByID(id uint) (*company.Company, error) {
+	var c company.Company
+	if err := r.db.First(&c, id).Error; err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *companyRepository) GetByIDs(ids []uint) ([]company.Company, error) {
+	var companies []company.Company
+	if len(ids) == 0 {
+		return companies, nil
+	}
+	err := r.db.Where("id IN ?", ids).Order("id ASC").Find(&companies).Error
+	return companies, err
+}
+
+func (r *companyRepository) GetBySlug(slug string) (*company.Company, error) {
+	var c company.Company
+	if err := r.db.Where("slug = ?", slug).First(&c).Error; err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// FindByNameKeys returns companies whose name or an alias has one of the
+// matching keys
+func (r *companyRepository) FindByNameKeys(keys []string, excludeID uint) ([]company.Company, error) {
+	var companies []company.Company
+	if len(keys) == 0 {
+		return companies, nil
+	}
+	err := r.db.
+		Where("id <> ? AND EXISTS (SELECT 1 FROM jsonb_array_elements_text(name_keys) k WHERE k IN ?)", excludeID, keys).
+		Order("id ASC").
+		Find(&companies).Error
+	return companies, err
+}
+
+func (r *companyRepository) SlugExists(slug string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&company.Company{}).
+		Where("slug = ? AND id <> ?", slug, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *companyRepository) Create(c *company.Company) error {
+	return r.db.Create(c).Error
+}
+
+func (r *companyRepository) Update(c *company.Company) error {
+	return r.db.Save(c).Error
+}
+
+func (r *companyRepository) Delete(id uint) error {
+	return r.db.Delete(&company.Company{}, id).Error
+}
+
+// GetReports lists the live published reports a company is a key player in,
+// newest first
+func (r *companyRepository) GetReports(companyID uint) ([]company.CompanyReport, error) {
+	var reports []company.CompanyReport
+
+	querySQL := `
+		SELECT r.id AS report_id, r.title, r.slug, r.publish_date,
+			COALESCE(p.player->>'marketShare', '') AS market_share,
+			COALESCE((p.player->>'rank')::int, 0) AS rank
+		FROM reports r
+		CROSS JOIN LATERAL jsonb_array_elements(
+			CASE WHEN jsonb_typeof(r.key_players) = 'array' THEN r.key_players ELSE '[]'::jsonb END
+		) AS p(player)
+		WHERE r.key_players @> ?::jsonb
+			AND (p.player->>'companyId')::bigint = ?
+			AND r.status = 'published' AND r.deleted_at IS NULL
+			AND (r.publish_date IS NULL OR r.publish_date <= ?)
+		ORDER BY r.publish_date DESC NULLS LAST, r.id DESC
+	`
+
+	err := r.db.Raw(querySQL, companyContainment(companyID), companyID, time.Now()).Scan(&reports).Error
+	return reports, err
+}
+
+// CountReferences counts reports linking to the company, including drafts
+// and soft-deleted reports that could be restored
+func (r *companyRepository) CountReferences(companyID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&report.Report{}).
+		Where("key_players @> ?::jsonb", companyContainment(companyID)).
+		Count(&count).Error
+	return count, err
+}
+
+// Merge saves the target, relinks every report's key players from the
+// sources to it, moves the sources' slug redirects over and deletes the
+// sources, all in one transaction. It returns the number of reports changed.
+func (r *companyRepository) Merge(target *company.Company, sourceIDs []uint) (int, error) {
+	updated := 0
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(target).Error; err != nil {
+			return err
+		}
+
+		var rows []ReportKeyPlayers
+		query := tx.Model(&report.Report{}).Select("id, key_players")
+		for _, id := range sourceIDs {
+			query = query.Or("key_players @> ?::jsonb", companyContainment(id))
+		}
+		if err := query.Scan(&rows).Error; err != nil {
+			return err
+		}
+
+		for _, row := range rows {
+			players, changed := row.KeyPlayers.ReplaceCompany(sourceIDs, target.ID)
+			if !changed {
+				continue
+			}
+			if err := updateKeyPlayers(tx, row.ID, players); err != nil {
+				return err
+			}
+			updated++
+		}
+
+		if err := tx.Model(&redirect.SlugRedirect{}).
+			Where("entity_type = ? AND entity_id IN ?", redirect.EntityCompany, sourceIDs).
+			Updates(map[string]interface{}{"entity_id": target.ID, "updated_at": time.Now()}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&company.Company{}, sourceIDs).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return updated, nil
+}
+
+// ListKeyPlayers returns the key players of every report that has any,
+// including drafts and soft-deleted reports
+func (r *companyRepository) ListKeyPlayers() ([]ReportKeyPlayers, error) {
+	var rows []ReportKeyPlayers
+	err := r.db.Model(&report.Report{}).
+		Select("id, key_players").
+		Where("jsonb_typeof(key_players) = 'array' AND jsonb_array_length(key_players) > 0").
+		Order("id ASC").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// UpdateKeyPlayers replaces a report's key players outside the editing flow.
+// The version is bumped so an editor holding the old list gets a conflict
+// instead of overwriting the links.
+func (r *companyRepository) UpdateKeyPlayers(reportID uint, players report.KeyPlayers) error {
+	return updateKeyPlayers(r.db, reportID, players)
+}
+
+func updateKeyPlayers(db *gorm.DB, reportID uint, players report.KeyPlayers) error {
+	return db.Model(&report.Report{}).
+		Where("id = ?", reportID).
+		Updates(map[string]interface{}{
+			"key_players": players,
+			"version":     bumpVersion,
+			"updated_at":  time.Now(),
+		}).Error
+}
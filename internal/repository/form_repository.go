@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/healthcare-market-research/backend/internal/domain/form"
+	"github.com/healthcare-market-research/backend/pkg/pagination"
 	"gorm.io/gorm"
 )
 
@@ -72,8 +73,10 @@ func (r *formRepository) GetAll(query form.GetSubmissionsQuery) ([]form.FormSubm
 		)
 	}
 
-	// Get total count
-	if err := dbQuery.Count(&total).Error; err != nil {
+	// Get total count (skipped when the caller doesn't need it)
+	if query.SkipCount {
+		total = -1
+	} else if err := dbQuery.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -97,7 +100,14 @@ func (r *formRepository) GetAll(query form.GetSubmissionsQuery) ([]form.FormSubm
 		sortOrder = "ASC"
 	}
 
-	orderClause := fmt.Sprintf("%s %s", sortBy, sortOrder)
+	orderClause := fmt.Sprintf("%s %s, id %s", sortBy, sortOrder, sortOrder)
+
+	// Keyset pagination continues after the cursor in the current sort direction.
+	// The service only accepts cursors when sorting by created_at.
+	if query.Cursor != nil {
+		dbQuery = dbQuery.Where(pagination.KeysetCondition("", sortOrder == "DESC"), query.Cursor.CreatedAt, query.Cursor.ID)
+		offset = 0
+	}
 
 	// Get paginated results
 	err := dbQuery.Order(orderClause).
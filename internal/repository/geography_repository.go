@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"github.com/healthcare-market-research/backend/internal/domain/geography"
+	"gorm.io/gorm"
+)
+
+type GeographyRepository interface {
+	GetAll() ([]geography.Geography, error)
+}
+
+type geographyRepository struct {
+	db *gorm.DB
+}
+
+func NewGeographyRepository(db *gorm.DB) GeographyRepository {
+	return &geographyRepository{db: db}
+}
+
+// GetAll returns the whole taxonomy ordered by name
+func (r *geographyRepository) GetAll() ([]geography.Geography, error) {
+	var geographies []geography.Geography
+	err := r.db.Order("name ASC").Find(&geographies).Error
+	return geographies, err
+}
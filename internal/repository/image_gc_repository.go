@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/domain/media"
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"gorm.io/gorm"
+)
+
+// ImageGCRepository backs the orphaned image garbage collector
+type ImageGCRepository interface {
+	// FindReferences returns every image saved on a report gallery image,
+	// author, category or attachment
+	FindReferences() ([]media.ImageReference, error)
+	FindOrphanedImages() ([]media.OrphanedImage, error)
+	// ReplaceOrphanedImages stores the orphans found by the latest run,
+	// forgetting images that are referenced again or gone
+	ReplaceOrphanedImages(orphans []media.OrphanedImage) error
+	DeleteReportImage(id uint) error
+}
+
+type imageGCRepository struct {
+	db *gorm.DB
+}
+
+func NewImageGCRepository(db *gorm.DB) ImageGCRepository {
+	return &imageGCRepository{db: db}
+}
+
+// imageReferenceRow is the shape every image query selects into
+type imageReferenceRow struct {
+	ID        uint
+	ImageURL  string
+	Variants  media.Variants
+	IsActive  bool
+	UpdatedAt time.Time
+}
+
+// imageSource is a table holding stored image URLs and their variants
+type imageSource struct {
+	entityType string
+	table      string
+	url        string
+	variants   string
+	active     string
+	// image selects the rows whose file is an image rather than a document
+	image string
+}
+
+// imageSources lists where report galleries, authors, categories,
+// attachments and company logos keep their images
+var imageSources = []imageSource{
+	{audit.EntityReportImage, "report_images", "image_url", "variants", "is_active", "TRUE"},
+	{audit.EntityAuthor, "authors", "image_url", "image_variants", "TRUE", "TRUE"},
+	{audit.EntityCategory, "categories", "image_url", "image_variants", "TRUE", "TRUE"},
+	{audit.EntityAttachment, "attachments", "url", "variants", "TRUE", "kind = 'image'"},
+	{audit.EntityCompany, "companies", "logo_url", "logo_variants", "TRUE", "TRUE"},
+}
+
+// FindReferences reads the images of every image source. Categories keep
+// their image while deactivated, so only report images can be inactive.
+// Attachment documents share the image storage unless it is Cloudflare
+// Images, so they are listed too.
+func (r *imageGCRepository) FindReferences() ([]media.ImageReference, error) {
+	var refs []media.ImageReference
+	for _, source := range imageSources {
+		var rows []imageReferenceRow
+		err := r.db.Table(source.table).
+			Select("id, COALESCE(" + source.url + ", '') AS image_url, " + source.variants + " AS variants, " +
+				source.active + " AS is_active, updated_at").
+			Where("COALESCE(" + source.url + ", '') <> '' OR " + source.variants + " IS NOT NULL").
+			Find(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			refs = append(refs, media.ImageReference{
+				EntityType: source.entityType,
+				EntityID:   row.ID,
+				ImageURL:   row.ImageURL,
+				Variants:   row.Variants,
+				Active:     row.IsActive,
+				UpdatedAt:  row.UpdatedAt,
+			})
+		}
+	}
+	return refs, nil
+}
+
+func (r *imageGCRepository) FindOrphanedImages() ([]media.OrphanedImage, error) {
+	var orphans []media.OrphanedImage
+	err := r.db.Find(&orphans).Error
+	return orphans, err
+}
+
+func (r *imageGCRepository) ReplaceOrphanedImages(orphans []media.OrphanedImage) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM orphaned_images").Error; err != nil {
+			return err
+		}
+		if len(orphans) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(orphans, 500).Error
+	})
+}
+
+// DeleteReportImage removes a deactivated report image whose files the
+// collector deleted, so it can no longer be reactivated
+func (r *imageGCRepository) DeleteReportImage(id uint) error {
+	return r.db.Where("is_active = ?", false).Delete(&report.ReportImage{}, id).Error
+}
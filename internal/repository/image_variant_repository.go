@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/healthcare-market-research/backend/internal/domain/media"
+	"gorm.io/gorm"
+)
+
+// ImageVariantRepository backs the backfill of variants for images stored
+// before variants were rendered on upload
+type ImageVariantRepository interface {
+	// FindMissingVariants returns the images saved without variants
+	FindMissingVariants() ([]media.ImageReference, error)
+	// SetVariants saves the variants rendered for ref, unless its record
+	// has since changed image or got variants. It reports whether they were
+	// saved.
+	SetVariants(ref media.ImageReference, variants media.Variants) (bool, error)
+}
+
+type imageVariantRepository struct {
+	db *gorm.DB
+}
+
+func NewImageVariantRepository(db *gorm.DB) ImageVariantRepository {
+	return &imageVariantRepository{db: db}
+}
+
+func (r *imageVariantRepository) FindMissingVariants() ([]media.ImageReference, error) {
+	var refs []media.ImageReference
+	for _, source := range imageSources {
+		var rows []imageReferenceRow
+		err := r.db.Table(source.table).
+			Select("id, " + source.url + " AS image_url, " + source.active + " AS is_active, updated_at").
+			Where("COALESCE(" + source.url + ", '') <> '' AND " + source.variants + " IS NULL AND " + source.image).
+			Order("id").
+			Find(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			refs = append(refs, media.ImageReference{
+				EntityType: source.entityType,
+				EntityID:   row.ID,
+				ImageURL:   row.ImageURL,
+				Active:     row.IsActive,
+				UpdatedAt:  row.UpdatedAt,
+			})
+		}
+	}
+	return refs, nil
+}
+
+// SetVariants leaves updated_at alone: the variants are derived from the
+// image the record already had
+func (r *imageVariantRepository) SetVariants(ref media.ImageReference, variants media.Variants) (bool, error) {
+	for _, source := range imageSources {
+		if source.entityType != ref.EntityType {
+			continue
+		}
+		result := r.db.Table(source.table).
+			Where("id = ? AND "+source.url+" = ? AND "+source.variants+" IS NULL", ref.EntityID, ref.ImageURL).
+			UpdateColumn(source.variants, variants)
+		return result.RowsAffected > 0, result.Error
+	}
+	return false, fmt.Errorf("unknown image entity type %q", ref.EntityType)
+}
@@ -4,8 +4,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/healthcare-market-research/backend/internal/domain/attachment"
 	"github.com/healthcare-market-research/backend/internal/domain/press_release"
+	"github.com/healthcare-market-research/backend/internal/domain/tag"
+	"github.com/healthcare-market-research/backend/pkg/pagination"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PressReleaseRepository interface {
@@ -13,7 +17,7 @@ type PressReleaseRepository interface {
 	GetAll(query press_release.GetPressReleasesQuery) ([]press_release.PressRelease, int64, error)
 	GetByID(id uint) (*press_release.PressRelease, error)
 	GetBySlug(slug string) (*press_release.PressRelease, error)
-	Update(id uint, updates map[string]interface{}) error
+	Update(id uint, version int, updates map[string]interface{}) error
 	Delete(id uint) error
 	SoftDelete(id uint) error
 	Restore(id uint) error
@@ -53,21 +57,9 @@ func (r *pressReleaseRepository) GetAll(query press_release.GetPressReleasesQuer
 	}
 
 	if query.Tags != "" {
-		// Split comma-separated tags and search for any of them
-		tags := strings.Split(query.Tags, ",")
-		tagConditions := make([]string, 0, len(tags))
-		tagValues := make([]interface{}, 0, len(tags))
-
-		for _, tag := range tags {
-			tag = strings.TrimSpace(tag)
-			if tag != "" {
-				tagConditions = append(tagConditions, "tags LIKE ?")
-				tagValues = append(tagValues, "%"+tag+"%")
-			}
-		}
-
-		if len(tagConditions) > 0 {
-			db = db.Where(strings.Join(tagConditions, " OR "), tagValues...)
+		// Any of the comma-separated tags, by name or slug
+		if condition, args := tagFilter(tag.ContentPressRelease, "press_releases.id", strings.Split(query.Tags, ",")); condition != "" {
+			db = db.Where(condition, args...)
 		}
 	}
 
@@ -80,8 +72,7 @@ func (r *pressReleaseRepository) GetAll(query press_release.GetPressReleasesQuer
 	}
 
 	if query.Search != "" {
-		searchPattern := "%" + query.Search + "%"
-		db = db.Where("title ILIKE ? OR excerpt ILIKE ? OR content ILIKE ?", searchPattern, searchPattern, searchPattern)
+		db = db.Where("search_vector @@ "+tsQueryExpr, query.Search)
 	}
 
 	// Apply soft delete filter
@@ -91,14 +82,30 @@ func (r *pressReleaseRepository) GetAll(query press_release.GetPressReleasesQuer
 		db = db.Where("deleted_at IS NULL")
 	}
 
-	// Count total before pagination
-	if err := db.Count(&total).Error; err != nil {
+	// Count total before pagination (skipped when the caller doesn't need it)
+	if query.SkipCount {
+		total = -1
+	} else if err := db.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	// Apply pagination
+	// Apply pagination: keyset when a cursor is given, offset otherwise
 	offset := (query.Page - 1) * query.Limit
-	db = db.Order("created_at DESC").Offset(offset).Limit(query.Limit)
+	if query.Cursor != nil {
+		db = db.Where(pagination.KeysetCondition("", true), query.Cursor.CreatedAt, query.Cursor.ID)
+		offset = 0
+	}
+	if query.Search != "" {
+		// Most relevant first when searching
+		db = db.Order(clause.OrderBy{Expression: clause.Expr{
+			SQL:                "ts_rank(search_vector, " + tsQueryExpr + ") DESC, created_at DESC",
+			Vars:               []interface{}{query.Search},
+			WithoutParentheses: true,
+		}})
+	} else {
+		db = db.Order("created_at DESC, id DESC")
+	}
+	db = db.Offset(offset).Limit(query.Limit)
 
 	// Fetch press releases with author and category details
 	if err := db.Preload("Author").Preload("Category").Find(&pressReleases).Error; err != nil {
@@ -110,22 +117,25 @@ func (r *pressReleaseRepository) GetAll(query press_release.GetPressReleasesQuer
 
 func (r *pressReleaseRepository) GetByID(id uint) (*press_release.PressRelease, error) {
 	var pr press_release.PressRelease
-	if err := r.db.Preload("Author").Preload("Category").Where("deleted_at IS NULL").First(&pr, id).Error; err != nil {
+	if err := r.db.Preload("Author").Preload("Category").Preload("Attachments", orderAttachments).Where("deleted_at IS NULL").First(&pr, id).Error; err != nil {
 		return nil, err
 	}
+	pr.FeaturedImage = attachment.Featured(pr.Attachments)
 	return &pr, nil
 }
 
 func (r *pressReleaseRepository) GetBySlug(slug string) (*press_release.PressRelease, error) {
 	var pr press_release.PressRelease
-	if err := r.db.Preload("Author").Preload("Category").Where("slug = ? AND deleted_at IS NULL", slug).First(&pr).Error; err != nil {
+	if err := r.db.Preload("Author").Preload("Category").Preload("Attachments", orderAttachments).Where("slug = ? AND deleted_at IS NULL", slug).First(&pr).Error; err != nil {
 		return nil, err
 	}
+	pr.FeaturedImage = attachment.Featured(pr.Attachments)
 	return &pr, nil
 }
 
-func (r *pressReleaseRepository) Update(id uint, updates map[string]interface{}) error {
-	return r.db.Model(&press_release.PressRelease{}).Where("id = ?", id).Updates(updates).Error
+// Update applies updates only if the row is still at the given version
+func (r *pressReleaseRepository) Update(id uint, version int, updates map[string]interface{}) error {
+	return updateVersioned(r.db, &press_release.PressRelease{}, id, version, updates)
 }
 
 func (r *pressReleaseRepository) Delete(id uint) error {
@@ -133,15 +143,15 @@ func (r *pressReleaseRepository) Delete(id uint) error {
 }
 
 func (r *pressReleaseRepository) SubmitForReview(id uint) error {
-	return r.db.Model(&press_release.PressRelease{}).Where("id = ?", id).Update("status", press_release.StatusReview).Error
+	return r.db.Model(&press_release.PressRelease{}).Where("id = ?", id).Updates(map[string]interface{}{"status": press_release.StatusReview, "version": bumpVersion}).Error
 }
 
 func (r *pressReleaseRepository) Publish(id uint) error {
-	return r.db.Model(&press_release.PressRelease{}).Where("id = ?", id).Update("status", press_release.StatusPublished).Error
+	return r.db.Model(&press_release.PressRelease{}).Where("id = ?", id).Updates(map[string]interface{}{"status": press_release.StatusPublished, "version": bumpVersion}).Error
 }
 
 func (r *pressReleaseRepository) Unpublish(id uint) error {
-	return r.db.Model(&press_release.PressRelease{}).Where("id = ?", id).Update("status", press_release.StatusDraft).Error
+	return r.db.Model(&press_release.PressRelease{}).Where("id = ?", id).Updates(map[string]interface{}{"status": press_release.StatusDraft, "version": bumpVersion}).Error
 }
 
 func (r *pressReleaseRepository) SoftDelete(id uint) error {
@@ -159,6 +169,7 @@ func (r *pressReleaseRepository) PublishScheduled(now time.Time) error {
 		Updates(map[string]interface{}{
 			"status":                    press_release.StatusPublished,
 			"scheduled_publish_enabled": false,
+			"version":                   bumpVersion,
 		}).Error
 }
 
@@ -168,11 +179,12 @@ func (r *pressReleaseRepository) SchedulePublish(id uint, publishDate time.Time)
 		Updates(map[string]interface{}{
 			"publish_date":              publishDate,
 			"scheduled_publish_enabled": true,
+			"version":                   bumpVersion,
 		}).Error
 }
 
 func (r *pressReleaseRepository) CancelScheduledPublish(id uint) error {
 	return r.db.Model(&press_release.PressRelease{}).
 		Where("id = ?", id).
-		Update("scheduled_publish_enabled", false).Error
+		Updates(map[string]interface{}{"scheduled_publish_enabled": false, "version": bumpVersion}).Error
 }
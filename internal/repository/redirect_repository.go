@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/healthcare-market-research/backend/internal/domain/redirect"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RedirectRepository interface {
+	Record(entityType string, entityID uint, oldSlug, newSlug string) error
+	FindBySlug(entityType, slug string) (*redirect.SlugRedirect, error)
+	CurrentSlug(entityType string, entityID uint) (string, error)
+	SlugInUse(entityType, slug string) (bool, error)
+	GetAll(filters redirect.RedirectFilters) ([]redirect.SlugRedirect, int64, error)
+	GetByID(id uint) (*redirect.SlugRedirect, error)
+	Create(r *redirect.SlugRedirect) error
+	Update(r *redirect.SlugRedirect) error
+	Delete(id uint) error
+}
+
+type redirectRepository struct {
+	db *gorm.DB
+}
+
+func NewRedirectRepository(db *gorm.DB) RedirectRepository {
+	return &redirectRepository{db: db}
+}
+
+// liveSlugQueries selects the slug of an entity that can currently be served
+var liveSlugQueries = map[string]string{
+	redirect.EntityReport:       "SELECT slug FROM reports WHERE id = ? AND deleted_at IS NULL",
+	redirect.EntityBlog:         "SELECT slug FROM blogs WHERE id = ? AND deleted_at IS NULL",
+	redirect.EntityPressRelease: "SELECT slug FROM press_releases WHERE id = ? AND deleted_at IS NULL",
+	redirect.EntityCategory:     "SELECT slug FROM categories WHERE id = ? AND is_active = true",
+	redirect.EntityCompany:      "SELECT slug FROM companies WHERE id = ?",
+	redirect.EntityTag:          "SELECT slug FROM tags WHERE id = ?",
+}
+
+// slugTables names the table holding each entity type's slugs
+var slugTables = map[string]string{
+	redirect.EntityReport:       "reports",
+	redirect.EntityBlog:         "blogs",
+	redirect.EntityPressRelease: "press_releases",
+	redirect.EntityCategory:     "categories",
+	redirect.EntityCompany:      "companies",
+	redirect.EntityTag:          "tags",
+}
+
+// Record keeps oldSlug pointing at the entity after a rename. A redirect for
+// newSlug is dropped because the slug is live again.
+func (r *redirectRepository) Record(entityType string, entityID uint, oldSlug, newSlug string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("entity_type = ? AND old_slug = ?", entityType, newSlug).
+			Delete(&redirect.SlugRedirect{}).Error; err != nil {
+			return err
+		}
+
+		rd := &redirect.SlugRedirect{EntityType: entityType, OldSlug: oldSlug, EntityID: entityID}
+		return tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "entity_type"}, {Name: "old_slug"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"entity_id":  entityID,
+				"is_manual":  false,
+				"updated_at": time.Now(),
+			}),
+		}).Create(rd).Error
+	})
+}
+
+func (r *redirectRepository) FindBySlug(entityType, slug string) (*redirect.SlugRedirect, error) {
+	var rd redirect.SlugRedirect
+	if err := r.db.Where("entity_type = ? AND old_slug = ?", entityType, slug).First(&rd).Error; err != nil {
+		return nil, err
+	}
+	return &rd, nil
+}
+
+// CurrentSlug returns the slug of a live entity, or gorm.ErrRecordNotFound
+// when it was deleted or deactivated
+func (r *redirectRepository) CurrentSlug(entityType string, entityID uint) (string, error) {
+	query, ok := liveSlugQueries[entityType]
+	if !ok {
+		return "", gorm.ErrRecordNotFound
+	}
+
+	var slugs []string
+	if err := r.db.Raw(query, entityID).Scan(&slugs).Error; err != nil {
+		return "", err
+	}
+	if len(slugs) == 0 {
+		return "", gorm.ErrRecordNotFound
+	}
+
+	return slugs[0], nil
+}
+
+// SlugInUse reports whether an entity currently owns the slug
+func (r *redirectRepository) SlugInUse(entityType, slug string) (bool, error) {
+	table, ok := slugTables[entityType]
+	if !ok {
+		return false, nil
+	}
+
+	var count int64
+	err := r.db.Table(table).Where("slug = ?", slug).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *redirectRepository) GetAll(filters redirect.RedirectFilters) ([]redirect.SlugRedirect, int64, error) {
+	var redirects []redirect.SlugRedirect
+	var total int64
+
+	query := r.db.Model(&redirect.SlugRedirect{})
+
+	if filters.EntityType != "" {
+		query = query.Where("entity_type = ?", filters.EntityType)
+	}
+	if filters.EntityID != nil {
+		query = query.Where("entity_id = ?", *filters.EntityID)
+	}
+	if filters.Search != "" {
+		query = query.Where("old_slug ILIKE ?", "%"+filters.Search+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (filters.Page - 1) * filters.Limit
+	err := query.Order("created_at DESC, id DESC").
+		Offset(offset).
+		Limit(filters.Limit).
+		Find(&redirects).Error
+
+	return redirects, total, err
+}
+
+func (r *redirectRepository) GetByID(id uint) (*redirect.SlugRedirect, error) {
+	var rd redirect.SlugRedirect
+	if err := r.db.First(&rd, id).Error; err != nil {
+		return nil, err
+	}
+	return &rd, nil
+}
+
+func (r *redirectRepository) Create(rd *redirect.SlugRedirect) error {
+	return r.db.Create(rd).Error
+}
+
+func (r *redirectRepository) Update(rd *redirect.SlugRedirect) error {
+	return r.db.Save(rd).Error
+}
+
+func (r *redirectRepository) Delete(id uint) error {
+	return r.db.Delete(&redirect.SlugRedirect{}, id).Error
+}
@@ -0,0 +1,317 @@
+package repository
+
+import (
+	"strings"
+	"time"
+
+	"github.com/healthcare-market-research/backend/internal/domain/related"
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/domain/tag"
+	"gorm.io/gorm"
+)
+
+// RelationFilters narrows the relations listed for review
+type RelationFilters struct {
+	Status string
+	// Content lists the relations of one item only
+	Content *related.Ref
+	Page    int
+	Limit   int
+}
+
+type RelatedRepository interface {
+	GetRelated(ref related.Ref, limit int) ([]related.Item, error)
+	GetAll(filters RelationFilters) ([]related.Relation, int64, error)
+	GetByID(id uint) (*related.Relation, error)
+	GetByPair(a, b related.Ref) (*related.Relation, error)
+	Create(rel *related.Relation) error
+	Update(rel *related.Relation) error
+	Delete(id uint) error
+	Items(refs []related.Ref) (map[related.Ref]related.Item, error)
+	Profiles() ([]related.Profile, error)
+	ReplaceSuggestions(suggestions []related.Relation) (added, removed int, err error)
+}
+
+type relatedRepository struct {
+	db *gorm.DB
+}
+
+func NewRelatedRepository(db *gorm.DB) RelatedRepository {
+	return &relatedRepository{db: db}
+}
+
+// contentTables maps each content type to its table and the column shown as
+// its excerpt
+var contentTables = map[string]struct{ table, excerpt string }{
+	tag.ContentReport:       {"reports", "left(summary, 300)"},
+	tag.ContentBlog:         {"blogs", "excerpt"},
+	tag.ContentPressRelease: {"press_releases", "excerpt"},
+}
+
+// GetRelated returns the published items related to one item: curated and
+// accepted relations first, then suggestions by score
+func (r *relatedRepository) GetRelated(ref related.Ref, limit int) ([]related.Item, error) {
+	var items []related.Item
+
+	content, args := publishedContent()
+	querySQL := `
+		WITH links AS (
+			SELECT
+				CASE WHEN source_type = ? AND source_id = ? THEN target_type ELSE source_type END AS type,
+				CASE WHEN source_type = ? AND source_id = ? THEN target_id ELSE source_id END AS id,
+				status IN ('curated', 'accepted') AS curated,
+				score, created_at
+			FROM content_relations
+			WHERE status <> 'rejected'
+				AND ((source_type = ? AND source_id = ?) OR (target_type = ? AND target_id = ?))
+		)
+		SELECT c.type, c.id, c.title, c.slug, c.excerpt, c.publish_date, l.curated
+		FROM links l JOIN (` + content + `) c ON c.type = l.type AND c.id = l.id
+		ORDER BY l.curated DESC, l.score DESC, l.created_at ASC
+		LIMIT ?`
+
+	params := []interface{}{ref.Type, ref.ID, ref.Type, ref.ID, ref.Type, ref.ID, ref.Type, ref.ID}
+	params = append(params, args...)
+	params = append(params, limit)
+
+	err := r.db.Raw(querySQL, params...).Scan(&items).Error
+	return items, err
+}
+
+// GetAll lists relations for review, best scoring first
+func (r *relatedRepository) GetAll(filters RelationFilters) ([]related.Relation, int64, error) {
+	var relations []related.Relation
+	var total int64
+
+	query := r.db.Model(&related.Relation{})
+	if filters.Status != "" {
+		query = query.Where("status = ?", filters.Status)
+	}
+	if ref := filters.Content; ref != nil {
+		query = query.Where("(source_type = ? AND source_id = ?) OR (target_type = ? AND target_id = ?)",
+			ref.Type, ref.ID, ref.Type, ref.ID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (filters.Page - 1) * filters.Limit
+	err := query.Order("score DESC, id ASC").Limit(filters.Limit).Offset(offset).Find(&relations).Error
+	return relations, total, err
+}
+
+func (r *relatedRepository) GetByID(id uint) (*related.Relation, error) {
+	var rel related.Relation
+	if err := r.db.First(&rel, id).Error; err != nil {
+		return nil, err
+	}
+	return &rel, nil
+}
+
+// GetByPair finds the relation between two items, in either order
+func (r *relatedRepository) GetByPair(a, b related.Ref) (*related.Relation, error) {
+	source, target := related.Pair(a, b)
+	var rel related.Relation
+	err := r.db.Where("source_type = ? AND source_id = ? AND target_type = ? AND target_id = ?",
+		source.Type, source.ID, target.Type, target.ID).First(&rel).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rel, nil
+}
+
+func (r *relatedRepository) Create(rel *related.Relation) error {
+	return r.db.Create(rel).Error
+}
+
+func (r *relatedRepository) Update(rel *related.Relation) error {
+	return r.db.Save(rel).Error
+}
+
+func (r *relatedRepository) Delete(id uint) error {
+	return r.db.Delete(&related.Relation{}, id).Error
+}
+
+// Items summarizes the items, whatever their status. Deleted items are left
+// out of the map.
+func (r *relatedRepository) Items(refs []related.Ref) (map[related.Ref]related.Item, error) {
+	ids := map[string][]uint{}
+	for _, ref := range refs {
+		ids[ref.Type] = append(ids[ref.Type], ref.ID)
+	}
+
+	var selects []string
+	var args []interface{}
+	for _, contentType := range tag.ContentTypes {
+		if len(ids[contentType]) == 0 {
+			continue
+		}
+		t := contentTables[contentType]
+		selects = append(selects, "SELECT '"+contentType+"' AS type, id, title, slug, "+t.excerpt+" AS excerpt, publish_date"+
+			" FROM "+t.table+" WHERE id IN ? AND deleted_at IS NULL")
+		args = append(args, ids[contentType])
+	}
+
+	items := map[related.Ref]related.Item{}
+	if len(selects) == 0 {
+		return items, nil
+	}
+
+	var rows []related.Item
+	if err := r.db.Raw(strings.Join(selects, " UNION ALL "), args...).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, item := range rows {
+		items[item.Ref()] = item
+	}
+	return items, nil
+}
+
+// Profiles loads what the suggestion engine compares about every live,
+// published item
+func (r *relatedRepository) Profiles() ([]related.Profile, error) {
+	tagIDs := map[related.Ref][]uint{}
+	var links []tag.ContentTag
+	if err := r.db.Order("content_type, content_id, position").Find(&links).Error; err != nil {
+		return nil, err
+	}
+	for _, link := range links {
+		ref := related.Ref{Type: link.ContentType, ID: link.ContentID}
+		tagIDs[ref] = append(tagIDs[ref], link.TagID)
+	}
+
+	var profiles []related.Profile
+
+	var reports []struct {
+		ID         uint
+		CategoryID uint
+		Geography  report.StringSlice
+		KeyPlayers report.KeyPlayers
+		Title      string
+		Summary    string
+	}
+	now := time.Now()
+	err := r.db.Table("reports t").
+		Select("id, category_id, geography, key_players, title, summary").
+		Where(liveContent, now).
+		Scan(&reports).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, rep := range reports {
+		ref := related.Ref{Type: tag.ContentReport, ID: rep.ID}
+		var companies []uint
+		for _, player := range rep.KeyPlayers {
+			if player.CompanyID != nil {
+				companies = append(companies, *player.CompanyID)
+			}
+		}
+		profiles = append(profiles, related.NewProfile(ref, rep.CategoryID, tagIDs[ref], rep.Geography, companies, rep.Title, rep.Summary))
+	}
+
+	for _, contentType := range []string{tag.ContentBlog, tag.ContentPressRelease} {
+		var rows []struct {
+			ID         uint
+			CategoryID uint
+			Title      string
+			Excerpt    string
+		}
+		err := r.db.Table(contentTables[contentType].table+" t").
+			Select("id, category_id, title, excerpt").
+			Where(liveContent, now).
+			Scan(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			ref := related.Ref{Type: contentType, ID: row.ID}
+			profiles = append(profiles, related.NewProfile(ref, row.CategoryID, tagIDs[ref], nil, nil, row.Title, row.Excerpt))
+		}
+	}
+
+	return profiles, nil
+}
+
+// ReplaceSuggestions makes the suggested relations match a fresh run of the
+// engine. Suggestions no longer made are removed, new ones added and scores
+// updated; curated, accepted and rejected relations stay as they are, and
+// pairs that have one are not suggested again.
+func (r *relatedRepository) ReplaceSuggestions(suggestions []related.Relation) (added, removed int, err error) {
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		var existing []related.Relation
+		err := tx.Select("id, source_type, source_id, target_type, target_id, status, score").Find(&existing).Error
+		if err != nil {
+			return err
+		}
+		byPair := make(map[[2]related.Ref]*related.Relation, len(existing))
+		for i := range existing {
+			rel := &existing[i]
+			byPair[[2]related.Ref{rel.Source(), rel.Target()}] = rel
+		}
+
+		now := time.Now()
+		var create []related.Relation
+		kept := map[uint]bool{}
+		for _, s := range suggestions {
+			current, ok := byPair[[2]related.Ref{s.Source(), s.Target()}]
+			if !ok {
+				create = append(create, s)
+				continue
+			}
+			kept[current.ID] = true
+
+			// Accepted relations keep their score current so they sort well
+			reviewable := current.Status == related.StatusSuggested || current.Status == related.StatusAccepted
+			if !reviewable || current.Score == s.Score {
+				continue
+			}
+			err := tx.Model(&related.Relation{}).Where("id = ?", current.ID).
+				Updates(map[string]interface{}{"score": s.Score, "reasons": s.Reasons, "updated_at": now}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		var stale []uint
+		for _, rel := range existing {
+			if rel.Status == related.StatusSuggested && !kept[rel.ID] {
+				stale = append(stale, rel.ID)
+			}
+		}
+		for start := 0; start < len(stale); start += 1000 {
+			end := min(start+1000, len(stale))
+			if err := tx.Where("id IN ?", stale[start:end]).Delete(&related.Relation{}).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(create) > 0 {
+			if err := tx.CreateInBatches(create, 500).Error; err != nil {
+				return err
+			}
+		}
+
+		added, removed = len(create), len(stale)
+		return nil
+	})
+	return added, removed, err
+}
+
+// publishedContent selects every live, published report, blog and press
+// release as type, id, title, slug, excerpt and publish_date
+func publishedContent() (string, []interface{}) {
+	now := time.Now()
+	return `
+		SELECT 'report' AS type, id, title, slug, left(summary, 300) AS excerpt, publish_date
+		FROM reports t
+		WHERE ` + liveContent + `
+		UNION ALL
+		SELECT 'blog', id, title, slug, excerpt, publish_date
+		FROM blogs t
+		WHERE ` + liveContent + `
+		UNION ALL
+		SELECT 'press_release', id, title, slug, excerpt, publish_date
+		FROM press_releases t
+		WHERE ` + liveContent, []interface{}{now, now, now}
+}
@@ -12,10 +12,16 @@ type ReportImageRepository interface {
 	FindByID(id uint) (*report.ReportImage, error)
 	FindByReportID(reportID uint) ([]report.ReportImage, error)
 	FindActiveByReportID(reportID uint) ([]report.ReportImage, error)
+	FindByContentHash(reportID uint, hash string) (*report.ReportImage, error)
+	NextSortOrder(reportID uint) (int, error)
+	Reorder(reportID uint, ids []uint) error
 	Update(image *report.ReportImage) error
 	SoftDelete(id uint) error
 	CountByReportID(reportID uint) (int64, error)
 	CountActiveByReportID(reportID uint) (int64, error)
+	// SharedImageURLs lists the image URLs of a report that images of other
+	// reports, such as its clones, also use
+	SharedImageURLs(reportID uint) ([]string, error)
 }
 
 type reportImageRepository struct {
@@ -27,7 +33,19 @@ func NewReportImageRepository(db *gorm.DB) ReportImageRepository {
 }
 
 func (r *reportImageRepository) Create(image *report.ReportImage) error {
-	return r.db.Create(image).Error
+	// GORM leaves a false is_active out of the insert, so the column default
+	// of true would apply; store it explicitly
+	active := image.IsActive
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(image).Error; err != nil {
+			return err
+		}
+		if !active {
+			image.IsActive = false
+			return tx.Model(image).Update("is_active", false).Error
+		}
+		return nil
+	})
 }
 
 func (r *reportImageRepository) FindByID(id uint) (*report.ReportImage, error) {
@@ -42,7 +60,7 @@ func (r *reportImageRepository) FindByID(id uint) (*report.ReportImage, error) {
 func (r *reportImageRepository) FindByReportID(reportID uint) ([]report.ReportImage, error) {
 	var images []report.ReportImage
 	err := r.db.Where("report_id = ?", reportID).
-		Order("created_at DESC").
+		Order("sort_order ASC, id ASC").
 		Find(&images).Error
 	return images, err
 }
@@ -50,11 +68,48 @@ func (r *reportImageRepository) FindByReportID(reportID uint) ([]report.ReportIm
 func (r *reportImageRepository) FindActiveByReportID(reportID uint) ([]report.ReportImage, error) {
 	var images []report.ReportImage
 	err := r.db.Where("report_id = ? AND is_active = ?", reportID, true).
-		Order("created_at DESC").
+		Order("sort_order ASC, id ASC").
 		Find(&images).Error
 	return images, err
 }
 
+func (r *reportImageRepository) FindByContentHash(reportID uint, hash string) (*report.ReportImage, error) {
+	var image report.ReportImage
+	err := r.db.Where("report_id = ? AND content_hash = ?", reportID, hash).First(&image).Error
+	if err != nil {
+		return nil, err
+	}
+	return &image, nil
+}
+
+// NextSortOrder returns the position after the report's last image
+func (r *reportImageRepository) NextSortOrder(reportID uint) (int, error) {
+	var next int
+	err := r.db.Model(&report.ReportImage{}).
+		Where("report_id = ?", reportID).
+		Select("COALESCE(MAX(sort_order) + 1, 0)").
+		Scan(&next).Error
+	return next, err
+}
+
+// Reorder sets each image's sort order to its index in ids
+func (r *reportImageRepository) Reorder(reportID uint, ids []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i, id := range ids {
+			err := tx.Model(&report.ReportImage{}).
+				Where("id = ? AND report_id = ?", id, reportID).
+				Updates(map[string]interface{}{
+					"sort_order": i,
+					"updated_at": time.Now(),
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 func (r *reportImageRepository) Update(image *report.ReportImage) error {
 	return r.db.Save(image).Error
 }
@@ -83,3 +138,13 @@ func (r *reportImageRepository) CountActiveByReportID(reportID uint) (int64, err
 		Count(&count).Error
 	return count, err
 }
+
+func (r *reportImageRepository) SharedImageURLs(reportID uint) ([]string, error) {
+	var urls []string
+	err := r.db.Model(&report.ReportImage{}).
+		Distinct("image_url").
+		Where("report_id = ? AND image_url IN (?)", reportID,
+			r.db.Model(&report.ReportImage{}).Select("image_url").Where("report_id <> ?", reportID)).
+		Pluck("image_url", &urls).Error
+	return urls, err
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/healthcare-market-research/backend/internal/domain/report"
@@ -22,10 +23,15 @@ func setupReportImageTestDB(t *testing.T) *gorm.DB {
 }
 
 func createTestReport(t *testing.T, db *gorm.DB) uint {
+	var count int64
+	require.NoError(t, db.Model(&report.Report{}).Count(&count).Error)
+
 	testReport := &report.Report{
 		Title:       "Test Report",
+		Slug:        fmt.Sprintf("test-report-%d", count+1),
 		Description: "Test Description",
 		Status:      report.StatusDraft,
+		Tags:        report.StringSlice{},
 	}
 	err := db.Create(testReport).Error
 	require.NoError(t, err)
@@ -126,10 +132,10 @@ func TestReportImageRepository_FindByReportID(t *testing.T) {
 		results, err := repo.FindByReportID(reportID)
 		require.NoError(t, err)
 		assert.Len(t, results, 3)
-		// Should be ordered by created_at DESC (newest first)
-		assert.Equal(t, "Image 3", results[0].Title)
+		// Should be ordered by sort_order, then upload order
+		assert.Equal(t, "Image 1", results[0].Title)
 		assert.Equal(t, "Image 2", results[1].Title)
-		assert.Equal(t, "Image 1", results[2].Title)
+		assert.Equal(t, "Image 3", results[2].Title)
 	})
 
 	t.Run("Return empty array for report with no images", func(t *testing.T) {
@@ -181,9 +187,9 @@ func TestReportImageRepository_FindActiveByReportID(t *testing.T) {
 		assert.Len(t, results, 2)
 		assert.True(t, results[0].IsActive)
 		assert.True(t, results[1].IsActive)
-		// Should be ordered by created_at DESC
-		assert.Equal(t, "Active Image 2", results[0].Title)
-		assert.Equal(t, "Active Image 1", results[1].Title)
+		// Should be ordered by sort_order, then upload order
+		assert.Equal(t, "Active Image 1", results[0].Title)
+		assert.Equal(t, "Active Image 2", results[1].Title)
 	})
 
 	t.Run("Return empty array when no active images", func(t *testing.T) {
@@ -379,3 +385,26 @@ func TestReportImageRepository_CountActiveByReportID(t *testing.T) {
 		assert.Equal(t, int64(0), count)
 	})
 }
+
+func TestReportImageRepository_SharedImageURLs(t *testing.T) {
+	db := setupReportImageTestDB(t)
+	repo := NewReportImageRepository(db)
+	originalID := createTestReport(t, db)
+	cloneID := createTestReport(t, db)
+
+	for _, image := range []report.ReportImage{
+		{ReportID: originalID, ImageURL: "https://example.com/shared.png", IsActive: true},
+		{ReportID: originalID, ImageURL: "https://example.com/own.png", IsActive: true},
+		{ReportID: cloneID, ImageURL: "https://example.com/shared.png", IsActive: true},
+	} {
+		require.NoError(t, repo.Create(&image))
+	}
+
+	urls, err := repo.SharedImageURLs(originalID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/shared.png"}, urls)
+
+	urls, err = repo.SharedImageURLs(createTestReport(t, db))
+	require.NoError(t, err)
+	assert.Empty(t, urls)
+}
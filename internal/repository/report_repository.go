@@ -2,22 +2,36 @@ package repository
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/domain/tag"
+	"github.com/healthcare-market-research/backend/pkg/pagination"
 	"gorm.io/gorm"
 )
 
 // ReportFilters contains all possible filter options for reports
 type ReportFilters struct {
 	// Public filters
-	Status      string   // 'draft' or 'published'
-	Category    string   // Category slug
-	Geography   []string // Array of geography strings
-	Search      string   // Full-text search query
-	Page        int
-	Limit       int
+	Status        string   // 'draft' or 'published'
+	Category      string   // Category slug
+	Geography     []string // Array of geography strings
+	Tags          []string // Tag names or slugs; matches reports carrying any of them
+	Search        string   // Full-text search query
+	PriceBand     string   // Price band key (see report.PriceBands)
+	ForecastYear  *int     // MarketMetrics.ForecastYear
+	MarketSizeMin *float64 // Current market size in USD millions
+	MarketSizeMax *float64
+	CAGRMin       *float64 // CAGR in percent
+	CAGRMax       *float64
+	SortBy        string // One of ReportSorts; empty sorts by relevance or newest first
+	SortOrder     string // "asc" or "desc" (default)
+	Page          int
+	Limit         int
+	Cursor        *pagination.Cursor // Keyset pagination; only with the default order
+	SkipCount     bool               // Skip the COUNT(*) query; total is reported as -1
 
 	// Admin filters
 	CreatedBy       *uint      // Filter by creator user ID
@@ -28,18 +42,37 @@ type ReportFilters struct {
 	UpdatedBefore   *time.Time
 	PublishedAfter  *time.Time
 	PublishedBefore *time.Time
-	IncludeDrafts   bool       // For admin, show drafts
-	ShowDeleted     bool       // For admin, show only deleted reports
+	IncludeDrafts   bool // For admin, show drafts
+	ShowDeleted     bool // For admin, show only deleted reports
+	MetricsUnparsed bool // For admin, show only reports whose market metrics couldn't be parsed
+}
+
+// Numeric market metrics, matching the expression indexes from migration 037
+const (
+	marketSizeExpr   = "((r.market_metrics->'currentValue'->>'usdMillions')::numeric)"
+	forecastSizeExpr = "((r.market_metrics->'forecastValue'->>'usdMillions')::numeric)"
+	cagrExpr         = "((r.market_metrics->>'cagrPercent')::numeric)"
+)
+
+// ReportSorts maps the sort_by values reports can be listed by to their SQL
+var ReportSorts = map[string]string{
+	"market_size":   marketSizeExpr,
+	"forecast_size": forecastSizeExpr,
+	"cagr":          cagrExpr,
 }
 
 type ReportRepository interface {
-	GetAll(page, limit int) ([]report.Report, int64, error)
+	GetAll(params pagination.Params) ([]report.Report, int64, error)
 	GetAllWithFilters(filters ReportFilters) ([]report.Report, int64, error)
+	GetFacets(filters ReportFilters) (*report.ReportFacets, error)
 	GetBySlug(slug string) (*report.ReportWithRelations, error)
 	GetByID(id uint) (*report.Report, error)
 	GetByIDWithRelations(id uint) (*report.ReportWithRelations, error)
-	GetByCategorySlug(categorySlug string, page, limit int) ([]report.Report, int64, error)
-	GetByAuthorID(authorID uint, page, limit int) ([]report.Report, int64, error)
+	GetPublishedByIDs(ids []uint) ([]report.Report, error)
+	GetBySlugs(slugs []string) ([]report.Report, error)
+	SlugExists(slug string, excludeID uint) (bool, error)
+	GetByCategorySlug(categorySlug string, includeDescendants bool, params pagination.Params) ([]report.Report, int64, error)
+	GetByAuthorID(authorID uint, params pagination.Params) ([]report.Report, int64, error)
 	Search(query string, page, limit int) ([]report.Report, int64, error)
 	GetChartsByReportID(reportID uint) ([]report.ChartMetadata, error)
 	Create(report *report.Report) error
@@ -50,9 +83,15 @@ type ReportRepository interface {
 	// Version history methods
 	CreateVersion(version *report.ReportVersion) error
 	GetVersionsByReportID(reportID uint) ([]report.ReportVersion, error)
+	GetVersion(reportID uint, versionNumber int) (*report.ReportVersion, error)
 	GetLatestVersionNumber(reportID uint) (int, error)
+
+	// Editorial workflow methods
+	UpdateWorkflow(id uint, fromStatus string, updates map[string]interface{}) error
+	CreateReviewComment(comment *report.ReviewComment) error
+	GetReviewComments(reportID uint) ([]report.ReviewComment, error)
 	// Scheduled publishing methods
-	PublishScheduled(now time.Time) error
+	GetScheduledDue(now time.Time) ([]report.Report, error)
 	SchedulePublish(id uint, publishDate time.Time) error
 	CancelScheduledPublish(id uint) error
 }
@@ -69,42 +108,122 @@ func NewReportRepository(db *gorm.DB, authorRepo AuthorRepository) ReportReposit
 	}
 }
 
-func (r *reportRepository) GetAll(page, limit int) ([]report.Report, int64, error) {
+func (r *reportRepository) GetAll(params pagination.Params) ([]report.Report, int64, error) {
+	// Exclude soft-deleted reports
+	return r.listReports("", "r.deleted_at IS NULL", nil, params)
+}
+
+// listReports runs a paginated report listing. The optional cte is prepended to both
+// queries and where may reference reports as r and categories as c. Results are ordered
+// by (created_at, id) descending so that offset pages and keyset pages line up.
+func (r *reportRepository) listReports(cte, where string, whereArgs []interface{}, params pagination.Params) ([]report.Report, int64, error) {
 	var reports []report.Report
-	var total int64
+	total := int64(-1)
 
-	offset := (page - 1) * limit
+	fromClause := `
+		FROM reports r
+		LEFT JOIN categories c ON r.category_id = c.id
+	`
 
-	// Exclude soft-deleted reports
-	countSQL := "SELECT COUNT(*) FROM reports WHERE deleted_at IS NULL"
-	if err := r.db.Raw(countSQL).Scan(&total).Error; err != nil {
-		return nil, 0, err
+	if !params.SkipCount {
+		countSQL := cte + " SELECT COUNT(*) " + fromClause + " WHERE " + where
+		if err := r.db.Raw(countSQL, whereArgs...).Scan(&total).Error; err != nil {
+			return nil, 0, err
+		}
 	}
 
-	querySQL := `
+	args := append([]interface{}{}, whereArgs...)
+	if params.Cursor != nil {
+		where += " AND " + pagination.KeysetCondition("r.", true)
+		args = append(args, params.Cursor.CreatedAt, params.Cursor.ID)
+	}
+
+	querySQL := cte + `
 		SELECT r.*, c.name as category_name
-		FROM reports r
-		LEFT JOIN categories c ON r.category_id = c.id
-		WHERE r.deleted_at IS NULL
-		ORDER BY COALESCE(r.id) DESC
+	` + fromClause + `
+		WHERE ` + where + `
+		ORDER BY r.created_at DESC, r.id DESC
 		LIMIT ? OFFSET ?
 	`
 
-	err := r.db.Raw(querySQL, limit, offset).Scan(&reports).Error
+	args = append(args, params.Limit, params.Offset())
+	err := r.db.Raw(querySQL, args...).Scan(&reports).Error
 
 	return reports, total, err
 }
 
 func (r *reportRepository) GetAllWithFilters(filters ReportFilters) ([]report.Report, int64, error) {
 	var reports []report.Report
-	var total int64
+	total := int64(-1)
+
+	params := pagination.Params{Page: filters.Page, Limit: filters.Limit, Cursor: filters.Cursor}
 
-	offset := (filters.Page - 1) * filters.Limit
+	conditions, args := buildReportFilterConditions(filters, "")
 
-	// Build WHERE clause dynamically
+	whereClause := strings.Join(conditions, " AND ")
+
+	// Count query
+	if !filters.SkipCount {
+		countSQL := fmt.Sprintf(`
+			SELECT COUNT(*)
+			FROM reports r
+			LEFT JOIN categories c ON r.category_id = c.id
+			WHERE %s
+		`, whereClause)
+
+		if err := r.db.Raw(countSQL, args...).Scan(&total).Error; err != nil {
+			return nil, 0, err
+		}
+	}
+
+	// Rank by relevance when searching, otherwise newest first like the
+	// unfiltered list, so cursors work the same. Reports without the sorted
+	// metric go last either way.
+	orderClause := "r.created_at DESC, r.id DESC"
+	if filters.Cursor != nil {
+		whereClause += " AND " + pagination.KeysetCondition("r.", true)
+		args = append(args, filters.Cursor.CreatedAt, filters.Cursor.ID)
+	}
+	if expr, ok := ReportSorts[filters.SortBy]; ok {
+		direction := "DESC"
+		if filters.SortOrder == "asc" {
+			direction = "ASC"
+		}
+		orderClause = fmt.Sprintf("%s %s NULLS LAST, r.id DESC", expr, direction)
+	} else if filters.Search != "" {
+		orderClause = "ts_rank(r.search_vector, " + tsQueryExpr + ") DESC, r.id DESC"
+		args = append(args, filters.Search)
+	}
+
+	// Fetch query
+	querySQL := fmt.Sprintf(`
+		SELECT r.*, c.name as category_name
+		FROM reports r
+		LEFT JOIN categories c ON r.category_id = c.id
+		WHERE %s
+		ORDER BY %s
+		LIMIT ? OFFSET ?
+	`, whereClause, orderClause)
+
+	args = append(args, params.Limit, params.Offset())
+	err := r.db.Raw(querySQL, args...).Scan(&reports).Error
+
+	return reports, total, err
+}
+
+// Facet dimensions; a facet's own filter is skipped when counting its buckets
+const (
+	facetCategory     = "category"
+	facetGeography    = "geography"
+	facetPriceBand    = "price_band"
+	facetForecastYear = "forecast_year"
+)
+
+// buildReportFilterConditions translates filters into SQL conditions against reports r
+// (joined with categories c). The filter belonging to the skip facet is left out.
+func buildReportFilterConditions(filters ReportFilters, skip string) ([]string, []interface{}) {
 	var conditions []string
 	var args []interface{}
-	var countArgs []interface{}
 
 	// Handle deleted reports filter
 	if filters.ShowDeleted {
@@ -119,110 +238,223 @@ func (r *reportRepository) GetAllWithFilters(filters ReportFilters) ([]report.Re
 	if filters.Status != "" {
 		conditions = append(conditions, "r.status = ?")
 		args = append(args, filters.Status)
-		countArgs = append(countArgs, filters.Status)
 	}
 
 	// Category filter
-	if filters.Category != "" {
+	if filters.Category != "" && skip != facetCategory {
 		conditions = append(conditions, "c.slug = ?")
 		args = append(args, filters.Category)
-		countArgs = append(countArgs, filters.Category)
 	}
 
 	// Geography filter - check if any of the provided geographies are in the report's geography JSON array
-	if len(filters.Geography) > 0 {
+	if len(filters.Geography) > 0 && skip != facetGeography {
 		geographyConditions := make([]string, len(filters.Geography))
 		for i, geo := range filters.Geography {
-			geographyConditions[i] = "r.geography::jsonb ? ?"
+			geographyConditions[i] = "r.geography::jsonb @> jsonb_build_array(?::text)"
 			args = append(args, geo)
-			countArgs = append(countArgs, geo)
 		}
 		conditions = append(conditions, fmt.Sprintf("(%s)", strings.Join(geographyConditions, " OR ")))
 	}
 
-	// Search filter
+	// Tag filter
+	if len(filters.Tags) > 0 {
+		if condition, tagArgs := tagFilter(tag.ContentReport, "r.id", filters.Tags); condition != "" {
+			conditions = append(conditions, condition)
+			args = append(args, tagArgs...)
+		}
+	}
+
+	// Price band filter
+	if filters.PriceBand != "" && skip != facetPriceBand {
+		if band, ok := report.FindPriceBand(filters.PriceBand); ok {
+			cond, bandArgs := priceBandCondition(band)
+			conditions = append(conditions, cond)
+			args = append(args, bandArgs...)
+		}
+	}
+
+	// Forecast year filter
+	if filters.ForecastYear != nil && skip != facetForecastYear {
+		conditions = append(conditions, "r.market_metrics->>'forecastYear' = ?")
+		args = append(args, strconv.Itoa(*filters.ForecastYear))
+	}
+
+	// Market size and CAGR ranges
+	if filters.MarketSizeMin != nil {
+		conditions = append(conditions, marketSizeExpr+" >= ?")
+		args = append(args, *filters.MarketSizeMin)
+	}
+	if filters.MarketSizeMax != nil {
+		conditions = append(conditions, marketSizeExpr+" <= ?")
+		args = append(args, *filters.MarketSizeMax)
+	}
+	if filters.CAGRMin != nil {
+		conditions = append(conditions, cagrExpr+" >= ?")
+		args = append(args, *filters.CAGRMin)
+	}
+	if filters.CAGRMax != nil {
+		conditions = append(conditions, cagrExpr+" <= ?")
+		args = append(args, *filters.CAGRMax)
+	}
+
+	// Search filter (full-text, see search_vector)
 	if filters.Search != "" {
-		searchPattern := "%" + filters.Search + "%"
-		conditions = append(conditions, "(r.title ILIKE ? OR r.summary ILIKE ? OR r.description ILIKE ?)")
-		args = append(args, searchPattern, searchPattern, searchPattern)
-		countArgs = append(countArgs, searchPattern, searchPattern, searchPattern)
+		conditions = append(conditions, "r.search_vector @@ "+tsQueryExpr)
+		args = append(args, filters.Search)
 	}
 
 	// Admin filters
 	if filters.CreatedBy != nil {
 		conditions = append(conditions, "r.created_by = ?")
 		args = append(args, *filters.CreatedBy)
-		countArgs = append(countArgs, *filters.CreatedBy)
 	}
 
 	if filters.UpdatedBy != nil {
 		conditions = append(conditions, "r.updated_by = ?")
 		args = append(args, *filters.UpdatedBy)
-		countArgs = append(countArgs, *filters.UpdatedBy)
 	}
 
 	// Date range filters
 	if filters.CreatedAfter != nil {
 		conditions = append(conditions, "r.created_at >= ?")
 		args = append(args, *filters.CreatedAfter)
-		countArgs = append(countArgs, *filters.CreatedAfter)
 	}
 	if filters.CreatedBefore != nil {
 		conditions = append(conditions, "r.created_at <= ?")
 		args = append(args, *filters.CreatedBefore)
-		countArgs = append(countArgs, *filters.CreatedBefore)
 	}
 
 	if filters.UpdatedAfter != nil {
 		conditions = append(conditions, "r.updated_at >= ?")
 		args = append(args, *filters.UpdatedAfter)
-		countArgs = append(countArgs, *filters.UpdatedAfter)
 	}
 	if filters.UpdatedBefore != nil {
 		conditions = append(conditions, "r.updated_at <= ?")
 		args = append(args, *filters.UpdatedBefore)
-		countArgs = append(countArgs, *filters.UpdatedBefore)
 	}
 
 	if filters.PublishedAfter != nil {
 		conditions = append(conditions, "r.publish_date >= ?")
 		args = append(args, *filters.PublishedAfter)
-		countArgs = append(countArgs, *filters.PublishedAfter)
 	}
 	if filters.PublishedBefore != nil {
 		conditions = append(conditions, "r.publish_date <= ?")
 		args = append(args, *filters.PublishedBefore)
-		countArgs = append(countArgs, *filters.PublishedBefore)
 	}
 
-	whereClause := strings.Join(conditions, " AND ")
+	if filters.MetricsUnparsed {
+		conditions = append(conditions, "r.metrics_unparsed = true")
+	}
 
-	// Count query
-	countSQL := fmt.Sprintf(`
-		SELECT COUNT(*)
+	return conditions, args
+}
+
+// priceBandCondition returns the SQL condition matching reports priced within the band
+func priceBandCondition(band report.PriceBand) (string, []interface{}) {
+	var parts []string
+	var args []interface{}
+	if band.Min != nil {
+		parts = append(parts, "r.price >= ?")
+		args = append(args, *band.Min)
+	}
+	if band.Max != nil {
+		parts = append(parts, "r.price < ?")
+		args = append(args, *band.Max)
+	}
+	if len(parts) == 0 {
+		return "TRUE", nil
+	}
+	return "(" + strings.Join(parts, " AND ") + ")", args
+}
+
+// GetFacets returns bucket counts for category, geography, price band and forecast year.
+// Each dimension is counted with every active filter applied except its own, so selecting
+// one value doesn't hide the alternatives in the same dimension.
+func (r *reportRepository) GetFacets(filters ReportFilters) (*report.ReportFacets, error) {
+	facets := &report.ReportFacets{
+		Category:     []report.FacetBucket{},
+		Geography:    []report.FacetBucket{},
+		PriceBand:    []report.FacetBucket{},
+		ForecastYear: []report.FacetBucket{},
+	}
+
+	// Category facet
+	conditions, args := buildReportFilterConditions(filters, facetCategory)
+	categorySQL := fmt.Sprintf(`
+		SELECT c.slug AS value, c.name AS label, COUNT(*) AS count
 		FROM reports r
-		LEFT JOIN categories c ON r.category_id = c.id
+		INNER JOIN categories c ON r.category_id = c.id
 		WHERE %s
-	`, whereClause)
+		GROUP BY c.slug, c.name
+		ORDER BY count DESC, c.name ASC
+	`, strings.Join(conditions, " AND "))
+	if err := r.db.Raw(categorySQL, args...).Scan(&facets.Category).Error; err != nil {
+		return nil, err
+	}
 
-	if err := r.db.Raw(countSQL, countArgs...).Scan(&total).Error; err != nil {
-		return nil, 0, err
+	// Geography facet - one bucket per value in the jsonb array
+	conditions, args = buildReportFilterConditions(filters, facetGeography)
+	geographySQL := fmt.Sprintf(`
+		SELECT g.value AS value, COUNT(*) AS count
+		FROM reports r
+		LEFT JOIN categories c ON r.category_id = c.id
+		CROSS JOIN LATERAL jsonb_array_elements_text(r.geography::jsonb) AS g(value)
+		WHERE %s
+		GROUP BY g.value
+		ORDER BY count DESC, g.value ASC
+	`, strings.Join(conditions, " AND "))
+	if err := r.db.Raw(geographySQL, args...).Scan(&facets.Geography).Error; err != nil {
+		return nil, err
 	}
 
-	// Fetch query
-	querySQL := fmt.Sprintf(`
-		SELECT r.*, c.name as category_name
+	// Price band facet - every band is returned, including empty ones
+	conditions, args = buildReportFilterConditions(filters, facetPriceBand)
+	bandColumns := make([]string, len(report.PriceBands))
+	var bandArgs []interface{}
+	for i, band := range report.PriceBands {
+		cond, condArgs := priceBandCondition(band)
+		bandColumns[i] = fmt.Sprintf("COUNT(*) FILTER (WHERE %s)", cond)
+		bandArgs = append(bandArgs, condArgs...)
+	}
+	priceSQL := fmt.Sprintf(`
+		SELECT %s
 		FROM reports r
 		LEFT JOIN categories c ON r.category_id = c.id
 		WHERE %s
-		ORDER BY COALESCE(r.id) DESC
-		LIMIT ? OFFSET ?
-	`, whereClause)
+	`, strings.Join(bandColumns, ", "), strings.Join(conditions, " AND "))
 
-	args = append(args, filters.Limit, offset)
-	err := r.db.Raw(querySQL, args...).Scan(&reports).Error
+	bandCounts := make([]int64, len(report.PriceBands))
+	bandDest := make([]interface{}, len(bandCounts))
+	for i := range bandCounts {
+		bandDest[i] = &bandCounts[i]
+	}
+	if err := r.db.Raw(priceSQL, append(bandArgs, args...)...).Row().Scan(bandDest...); err != nil {
+		return nil, err
+	}
+	for i, band := range report.PriceBands {
+		facets.PriceBand = append(facets.PriceBand, report.FacetBucket{
+			Value: band.Key,
+			Label: band.Label,
+			Count: bandCounts[i],
+		})
+	}
 
-	return reports, total, err
+	// Forecast year facet
+	conditions, args = buildReportFilterConditions(filters, facetForecastYear)
+	yearSQL := fmt.Sprintf(`
+		SELECT r.market_metrics->>'forecastYear' AS value, COUNT(*) AS count
+		FROM reports r
+		LEFT JOIN categories c ON r.category_id = c.id
+		WHERE %s
+		  AND COALESCE(r.market_metrics->>'forecastYear', '0') NOT IN ('', '0')
+		GROUP BY 1
+		ORDER BY 1 ASC
+	`, strings.Join(conditions, " AND "))
+	if err := r.db.Raw(yearSQL, args...).Scan(&facets.ForecastYear).Error; err != nil {
+		return nil, err
+	}
+
+	return facets, nil
 }
 
 func (r *reportRepository) GetBySlug(slug string) (*report.ReportWithRelations, error) {
@@ -236,9 +468,12 @@ func (r *reportRepository) GetBySlug(slug string) (*report.ReportWithRelations,
 		WHERE r.slug = $1 AND r.deleted_at IS NULL
 	`
 
-	err := r.db.Raw(querySQL, slug).Scan(&result).Error
-	if err != nil {
-		return nil, err
+	res := r.db.Raw(querySQL, slug).Scan(&result)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
 	}
 
 	// Get authors
@@ -258,67 +493,41 @@ func (r *reportRepository) GetBySlug(slug string) (*report.ReportWithRelations,
 	return &result, nil
 }
 
-func (r *reportRepository) GetByCategorySlug(categorySlug string, page, limit int) ([]report.Report, int64, error) {
-	var reports []report.Report
-	var total int64
-
-	offset := (page - 1) * limit
-
-	countSQL := `
-		SELECT COUNT(*)
-		FROM reports r
-		INNER JOIN categories c ON r.category_id = c.id
-		WHERE c.slug = ? AND c.is_active = true AND r.deleted_at IS NULL
-	`
-	if err := r.db.Raw(countSQL, categorySlug).Scan(&total).Error; err != nil {
-		return nil, 0, err
+func (r *reportRepository) GetByCategorySlug(categorySlug string, includeDescendants bool, params pagination.Params) ([]report.Report, int64, error) {
+	if includeDescendants {
+		return r.getByCategoryTree(categorySlug, params)
 	}
 
-	querySQL := `
-		SELECT r.*, c.name as category_name
-		FROM reports r
-		INNER JOIN categories c ON r.category_id = c.id
-		WHERE c.slug = ? AND c.is_active = true AND r.deleted_at IS NULL
-		ORDER BY COALESCE(r.id) DESC
-		LIMIT ? OFFSET ?
-	`
+	return r.listReports("",
+		"c.slug = ? AND c.is_active = true AND r.deleted_at IS NULL",
+		[]interface{}{categorySlug}, params)
+}
 
-	err := r.db.Raw(querySQL, categorySlug, limit, offset).Scan(&reports).Error
+// getByCategoryTree returns reports from the category and all of its active descendants
+func (r *reportRepository) getByCategoryTree(categorySlug string, params pagination.Params) ([]report.Report, int64, error) {
+	treeCTE := `
+		WITH RECURSIVE category_tree AS (
+			SELECT id FROM categories WHERE slug = ? AND is_active = true
+			UNION
+			SELECT c.id FROM categories c
+			INNER JOIN category_tree t ON c.parent_id = t.id
+			WHERE c.is_active = true
+		)
+	`
 
-	return reports, total, err
+	return r.listReports(treeCTE,
+		"r.category_id IN (SELECT id FROM category_tree) AND r.deleted_at IS NULL",
+		[]interface{}{categorySlug}, params)
 }
 
-func (r *reportRepository) GetByAuthorID(authorID uint, page, limit int) ([]report.Report, int64, error) {
-	var reports []report.Report
-	var total int64
-	offset := (page - 1) * limit
-
+func (r *reportRepository) GetByAuthorID(authorID uint, params pagination.Params) ([]report.Report, int64, error) {
 	// Use @> (contains) operator instead of ? operator to avoid GORM conflicts
 	// Build the JSONB array string to check if author_ids contains the authorID
 	authorIDStr := fmt.Sprintf("[%d]", authorID)
 
-	// Count query - JSONB containment check, exclude soft-deleted
-	countSQL := `
-		SELECT COUNT(*)
-		FROM reports r
-		WHERE r.author_ids::jsonb @> ?::jsonb AND r.deleted_at IS NULL
-	`
-	if err := r.db.Raw(countSQL, authorIDStr).Scan(&total).Error; err != nil {
-		return nil, 0, err
-	}
-
-	// Data query with category join and pagination, exclude soft-deleted
-	querySQL := `
-		SELECT r.*, c.name as category_name
-		FROM reports r
-		LEFT JOIN categories c ON r.category_id = c.id
-		WHERE r.author_ids::jsonb @> ?::jsonb AND r.deleted_at IS NULL
-		ORDER BY COALESCE(r.id) DESC
-		LIMIT ? OFFSET ?
-	`
-	err := r.db.Raw(querySQL, authorIDStr, limit, offset).Scan(&reports).Error
-
-	return reports, total, err
+	// JSONB containment check, exclude soft-deleted
+	return r.listReports("", "r.author_ids::jsonb @> ?::jsonb AND r.deleted_at IS NULL",
+		[]interface{}{authorIDStr}, params)
 }
 
 func (r *reportRepository) Search(query string, page, limit int) ([]report.Report, int64, error) {
@@ -326,15 +535,13 @@ func (r *reportRepository) Search(query string, page, limit int) ([]report.Repor
 	var total int64
 
 	offset := (page - 1) * limit
-	searchPattern := "%" + query + "%"
 
 	countSQL := `
 		SELECT COUNT(*)
 		FROM reports r
-		LEFT JOIN categories c ON r.category_id = c.id
-		WHERE (r.title ILIKE ? OR r.description ILIKE ? OR r.summary ILIKE ?) AND r.deleted_at IS NULL
+		WHERE r.search_vector @@ ` + tsQueryExpr + ` AND r.deleted_at IS NULL
 	`
-	if err := r.db.Raw(countSQL, searchPattern, searchPattern, searchPattern).Scan(&total).Error; err != nil {
+	if err := r.db.Raw(countSQL, query).Scan(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -342,12 +549,13 @@ func (r *reportRepository) Search(query string, page, limit int) ([]report.Repor
 		SELECT r.*, c.name as category_name
 		FROM reports r
 		LEFT JOIN categories c ON r.category_id = c.id
-		WHERE (r.title ILIKE ? OR r.description ILIKE ? OR r.summary ILIKE ?) AND r.deleted_at IS NULL
-		ORDER BY COALESCE(r.id) DESC
+		CROSS JOIN (SELECT ` + tsQueryExpr + ` AS query) q
+		WHERE r.search_vector @@ q.query AND r.deleted_at IS NULL
+		ORDER BY ts_rank(r.search_vector, q.query) DESC, r.id DESC
 		LIMIT ? OFFSET ?
 	`
 
-	err := r.db.Raw(querySQL, searchPattern, searchPattern, searchPattern, limit, offset).Scan(&reports).Error
+	err := r.db.Raw(querySQL, query, limit, offset).Scan(&reports).Error
 
 	return reports, total, err
 }
@@ -356,12 +564,9 @@ func (r *reportRepository) GetChartsByReportID(reportID uint) ([]report.ChartMet
 	var charts []report.ChartMetadata
 
 	// Use raw SQL for better performance
-	querySQL := `
-		SELECT id, report_id, title, chart_type, description,
-		       data_points, "order", is_active, created_at, updated_at
-		FROM chart_metadata
-		WHERE report_id = ? AND is_active = true
-		ORDER BY "order" ASC
+	querySQL := chartSelectSQL + `
+		WHERE c.report_id = ? AND c.is_active = true
+		ORDER BY c."order" ASC, c.id ASC
 	`
 
 	err := r.db.Raw(querySQL, reportID).Scan(&charts).Error
@@ -372,8 +577,32 @@ func (r *reportRepository) Create(rep *report.Report) error {
 	return r.db.Create(rep).Error
 }
 
+// workflowColumns are owned by the editorial workflow and never written by Update
+var workflowColumns = []string{
+	"status", "reviewer_id", "submitted_by", "submitted_at",
+	"reviewed_by", "reviewed_at", "rejection_reason",
+}
+
+// Update writes the full report only if it is still at rep.Version, which is
+// advanced on success. Creation and workflow fields are never overwritten.
 func (r *reportRepository) Update(rep *report.Report) error {
-	return r.db.Save(rep).Error
+	expected := rep.Version
+	rep.Version = expected + 1
+
+	result := r.db.Model(rep).
+		Where("version = ?", expected).
+		Select("*").
+		Omit(append([]string{"created_at", "created_by"}, workflowColumns...)...).
+		Updates(rep)
+	if result.Error != nil {
+		rep.Version = expected
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		rep.Version = expected
+		return versionMiss(r.db, &report.Report{}, rep.ID)
+	}
+	return nil
 }
 
 func (r *reportRepository) Delete(id uint) error {
@@ -422,20 +651,70 @@ func (r *reportRepository) GetByIDWithRelations(id uint) (*report.ReportWithRela
 	return &result, nil
 }
 
+// GetPublishedByIDs returns the published reports among ids, in no
+// particular order
+func (r *reportRepository) GetPublishedByIDs(ids []uint) ([]report.Report, error) {
+	var reports []report.Report
+
+	querySQL := `
+		SELECT r.*, c.name as category_name
+		FROM reports r
+		INNER JOIN categories c ON r.category_id = c.id AND c.is_active = true
+		WHERE r.id IN ? AND r.status = ? AND r.deleted_at IS NULL
+	`
+
+	err := r.db.Raw(querySQL, ids, report.StatusPublished).Scan(&reports).Error
+	return reports, err
+}
+
+// GetBySlugs returns the reports with any of slugs, whatever their status,
+// soft-deleted ones included
+func (r *reportRepository) GetBySlugs(slugs []string) ([]report.Report, error) {
+	if len(slugs) == 0 {
+		return []report.Report{}, nil
+	}
+
+	var reports []report.Report
+	err := r.db.Where("slug IN ?", slugs).Find(&reports).Error
+	return reports, err
+}
+
+// SlugExists counts soft-deleted reports too, since they keep their slug
+func (r *reportRepository) SlugExists(slug string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&report.Report{}).
+		Where("slug = ? AND id <> ?", slug, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
 // Version history methods
 
 func (r *reportRepository) CreateVersion(version *report.ReportVersion) error {
 	return r.db.Create(version).Error
 }
 
+// GetVersionsByReportID lists a report's versions, newest first. The full
+// snapshot is omitted to keep listings light; use GetVersion to load it.
 func (r *reportRepository) GetVersionsByReportID(reportID uint) ([]report.ReportVersion, error) {
 	var versions []report.ReportVersion
-	err := r.db.Where("report_id = ?", reportID).
+	err := r.db.Omit("snapshot").
+		Where("report_id = ?", reportID).
 		Order("version_number DESC").
 		Find(&versions).Error
 	return versions, err
 }
 
+func (r *reportRepository) GetVersion(reportID uint, versionNumber int) (*report.ReportVersion, error) {
+	var version report.ReportVersion
+	err := r.db.Where("report_id = ? AND version_number = ?", reportID, versionNumber).
+		First(&version).Error
+	if err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
 func (r *reportRepository) GetLatestVersionNumber(reportID uint) (int, error) {
 	var maxVersion int
 	err := r.db.Model(&report.ReportVersion{}).
@@ -445,6 +724,37 @@ func (r *reportRepository) GetLatestVersionNumber(reportID uint) (int, error) {
 	return maxVersion, err
 }
 
+// Editorial workflow methods
+
+// UpdateWorkflow applies a workflow change only if the report is still in fromStatus,
+// so two concurrent transitions cannot both succeed
+func (r *reportRepository) UpdateWorkflow(id uint, fromStatus string, updates map[string]interface{}) error {
+	updates["version"] = bumpVersion
+
+	result := r.db.Model(&report.Report{}).
+		Where("id = ? AND status = ?", id, fromStatus).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return versionMiss(r.db, &report.Report{}, id)
+	}
+	return nil
+}
+
+func (r *reportRepository) CreateReviewComment(comment *report.ReviewComment) error {
+	return r.db.Create(comment).Error
+}
+
+func (r *reportRepository) GetReviewComments(reportID uint) ([]report.ReviewComment, error) {
+	var comments []report.ReviewComment
+	err := r.db.Where("report_id = ?", reportID).
+		Order("created_at ASC, id ASC").
+		Find(&comments).Error
+	return comments, err
+}
+
 func (r *reportRepository) SoftDelete(id uint) error {
 	now := time.Now()
 	return r.db.Model(&report.Report{}).Where("id = ?", id).Update("deleted_at", now).Error
@@ -454,14 +764,14 @@ func (r *reportRepository) Restore(id uint) error {
 	return r.db.Model(&report.Report{}).Where("id = ?", id).Update("deleted_at", nil).Error
 }
 
-func (r *reportRepository) PublishScheduled(now time.Time) error {
-	return r.db.Model(&report.Report{}).
-		Where("scheduled_publish_enabled = ? AND status != ? AND publish_date <= ?",
-			true, "published", now).
-		Updates(map[string]interface{}{
-			"status":                    "published",
-			"scheduled_publish_enabled": false,
-		}).Error
+// GetScheduledDue returns the approved reports scheduled to publish by now
+func (r *reportRepository) GetScheduledDue(now time.Time) ([]report.Report, error) {
+	var reports []report.Report
+	err := r.db.Where("scheduled_publish_enabled = ? AND status = ? AND publish_date <= ? AND deleted_at IS NULL",
+		true, report.StatusApproved, now).
+		Order("publish_date ASC, id ASC").
+		Find(&reports).Error
+	return reports, err
 }
 
 func (r *reportRepository) SchedulePublish(id uint, publishDate time.Time) error {
@@ -470,11 +780,12 @@ func (r *reportRepository) SchedulePublish(id uint, publishDate time.Time) error
 		Updates(map[string]interface{}{
 			"publish_date":              publishDate,
 			"scheduled_publish_enabled": true,
+			"version":                   bumpVersion,
 		}).Error
 }
 
 func (r *reportRepository) CancelScheduledPublish(id uint) error {
 	return r.db.Model(&report.Report{}).
 		Where("id = ?", id).
-		Update("scheduled_publish_enabled", false).Error
+		Updates(map[string]interface{}{"scheduled_publish_enabled": false, "version": bumpVersion}).Error
 }
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"gorm.io/gorm"
+)
+
+type ReportTemplateRepository interface {
+	GetAll() ([]report.ReportTemplate, error)
+	GetByID(id uint) (*report.ReportTemplate, error)
+	NameExists(name string, excludeID uint) (bool, error)
+	Create(t *report.ReportTemplate) error
+	Update(t *report.ReportTemplate) error
+	Delete(id uint) error
+}
+
+type reportTemplateRepository struct {
+	db *gorm.DB
+}
+
+func NewReportTemplateRepository(db *gorm.DB) ReportTemplateRepository {
+	return &reportTemplateRepository{db: db}
+}
+
+// GetAll lists templates by name. There are few enough not to page them.
+func (r *reportTemplateRepository) GetAll() ([]report.ReportTemplate, error) {
+	var templates []report.ReportTemplate
+	err := r.db.Order("lower(name) ASC, id ASC").Find(&templates).Error
+	return templates, err
+}
+
+func (r *reportTemplateRepository) GetByID(id uint) (*report.ReportTemplate, error) {
+	var t report.ReportTemplate
+	if err := r.db.First(&t, id).Error; err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// NameExists compares names ignoring case
+func (r *reportTemplateRepository) NameExists(name string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&report.ReportTemplate{}).
+		Where("lower(name) = lower(?) AND id <> ?", name, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *reportTemplateRepository) Create(t *report.ReportTemplate) error {
+	return r.db.Create(t).Error
+}
+
+func (r *reportTemplateRepository) Update(t *report.ReportTemplate) error {
+	return r.db.Save(t).Error
+}
+
+func (r *reportTemplateRepository) Delete(id uint) error {
+	return r.db.Delete(&report.ReportTemplate{}, id).Error
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/healthcare-market-research/backend/internal/domain/search"
+	"gorm.io/gorm"
+)
+
+// tsQueryExpr parses user input with web-search syntax (quoted phrases, OR, -exclusions).
+// It must use the same text search configuration as the search_vector columns.
+const tsQueryExpr = "websearch_to_tsquery('english', ?)"
+
+// headlineOptions controls the ts_headline snippet returned with each result
+const headlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=\" ... \""
+
+// SearchRepository defines the interface for cross-content full-text search
+type SearchRepository interface {
+	Search(query search.Query) ([]search.Result, int64, error)
+	CountByType(q string) ([]search.TypeFacet, error)
+}
+
+type searchRepository struct {
+	db *gorm.DB
+}
+
+// NewSearchRepository creates a new search repository instance
+func NewSearchRepository(db *gorm.DB) SearchRepository {
+	return &searchRepository{db: db}
+}
+
+// searchSubquery returns the SELECT for a single content type. Only published,
+// non-deleted content is searchable. Every subquery exposes the same columns so
+// they can be combined with UNION ALL.
+func searchSubquery(t search.ContentType) string {
+	var table, snippetSource string
+	switch t {
+	case search.TypeReport:
+		table = "reports"
+		snippetSource = "concat_ws(' ', t.summary, t.description)"
+	case search.TypeBlog:
+		table = "blogs"
+		snippetSource = "concat_ws(' ', t.excerpt, t.content)"
+	case search.TypePressRelease:
+		table = "press_releases"
+		snippetSource = "concat_ws(' ', t.excerpt, t.content)"
+	default:
+		return ""
+	}
+
+	return fmt.Sprintf(`
+		SELECT '%s' AS type, t.id, t.title, t.slug,
+		       %s AS snippet_source,
+		       c.name AS category_name,
+		       ts_rank(t.search_vector, q.query) AS rank,
+		       t.publish_date, t.created_at
+		FROM %s t
+		CROSS JOIN (SELECT %s AS query) q
+		LEFT JOIN categories c ON t.category_id = c.id
+		WHERE t.search_vector @@ q.query
+		  AND t.status = 'published' AND t.deleted_at IS NULL
+	`, t, snippetSource, table, tsQueryExpr)
+}
+
+// unionSubqueries builds a UNION ALL over the requested types along with its bind args
+func unionSubqueries(q string, types []search.ContentType) (string, []interface{}) {
+	if len(types) == 0 {
+		types = search.AllTypes
+	}
+
+	parts := make([]string, 0, len(types))
+	args := make([]interface{}, 0, len(types))
+	for _, t := range types {
+		sub := searchSubquery(t)
+		if sub == "" {
+			continue
+		}
+		parts = append(parts, sub)
+		args = append(args, q)
+	}
+
+	return strings.Join(parts, " UNION ALL "), args
+}
+
+func (r *searchRepository) Search(query search.Query) ([]search.Result, int64, error) {
+	var results []search.Result
+	var total int64
+
+	union, args := unionSubqueries(query.Q, query.Types)
+	if union == "" {
+		return []search.Result{}, 0, nil
+	}
+
+	countSQL := fmt.Sprintf(`SELECT COUNT(*) FROM (%s) results`, union)
+	if err := r.db.Raw(countSQL, args...).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// Headlines are expensive, so they are generated only for the page being returned
+	offset := (query.Page - 1) * query.Limit
+	querySQL := fmt.Sprintf(`
+		SELECT page.type, page.id, page.title, page.slug,
+		       ts_headline('english', page.snippet_source, %s, '%s') AS snippet,
+		       page.category_name, page.rank, page.publish_date, page.created_at
+		FROM (
+			SELECT * FROM (%s) results
+			ORDER BY rank DESC, created_at DESC, id DESC
+			LIMIT ? OFFSET ?
+		) page
+		ORDER BY page.rank DESC, page.created_at DESC, page.id DESC
+	`, tsQueryExpr, headlineOptions, union)
+
+	args = append([]interface{}{query.Q}, args...)
+	args = append(args, query.Limit, offset)
+	if err := r.db.Raw(querySQL, args...).Scan(&results).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return results, total, nil
+}
+
+// CountByType returns the number of matches for every content type, regardless of
+// any type filter applied to the result list
+func (r *searchRepository) CountByType(q string) ([]search.TypeFacet, error) {
+	union, args := unionSubqueries(q, search.AllTypes)
+
+	var rows []search.TypeFacet
+	countSQL := fmt.Sprintf(`SELECT type, COUNT(*) AS count FROM (%s) results GROUP BY type`, union)
+	if err := r.db.Raw(countSQL, args...).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[search.ContentType]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Type] = row.Count
+	}
+
+	facets := make([]search.TypeFacet, 0, len(search.AllTypes))
+	for _, t := range search.AllTypes {
+		facets = append(facets, search.TypeFacet{Type: t, Count: counts[t]})
+	}
+
+	return facets, nil
+}
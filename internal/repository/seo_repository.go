@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/healthcare-market-research/backend/internal/domain/seo"
+	"gorm.io/gorm"
+)
+
+// SEORepository reads the published pages listed in sitemaps and feeds
+type SEORepository interface {
+	SectionStats(now time.Time) ([]seo.SectionStats, error)
+	SitemapEntries(section seo.Section, now time.Time, offset, limit int) ([]seo.Entry, error)
+	FeedItems(section seo.Section, categoryIDs []uint, now time.Time, limit int) ([]seo.FeedItem, error)
+}
+
+type seoRepository struct {
+	db *gorm.DB
+}
+
+// NewSEORepository creates a new SEO repository instance
+func NewSEORepository(db *gorm.DB) SEORepository {
+	return &seoRepository{db: db}
+}
+
+// liveContent filters published, non-deleted content that is not dated in the future
+const liveContent = "t.status = 'published' AND t.deleted_at IS NULL AND (t.publish_date IS NULL OR t.publish_date <= ?)"
+
+// pageSource selects the public pages of a section from a table aliased as t
+type pageSource struct {
+	from  string
+	where string
+	args  []interface{}
+}
+
+// sectionSource returns where a section's pages come from. Reports also need an
+// active category, as on the report page itself.
+func sectionSource(section seo.Section, now time.Time) (pageSource, bool) {
+	switch section {
+	case seo.SectionReports:
+		return pageSource{
+			from:  "reports t INNER JOIN categories rc ON t.category_id = rc.id AND rc.is_active = true",
+			where: liveContent,
+			args:  []interface{}{now},
+		}, true
+	case seo.SectionBlogs:
+		return pageSource{from: "blogs t", where: liveContent, args: []interface{}{now}}, true
+	case seo.SectionPressReleases:
+		return pageSource{from: "press_releases t", where: liveContent, args: []interface{}{now}}, true
+	case seo.SectionCategories:
+		return pageSource{from: "categories t", where: "t.is_active = true"}, true
+	case seo.SectionAuthors:
+		return pageSource{from: "authors t", where: "true"}, true
+	}
+	return pageSource{}, false
+}
+
+func (r *seoRepository) SectionStats(now time.Time) ([]seo.SectionStats, error) {
+	stats := make([]seo.SectionStats, 0, len(seo.AllSections))
+
+	for _, section := range seo.AllSections {
+		src, _ := sectionSource(section, now)
+
+		var row struct {
+			Count   int64
+			LastMod *time.Time
+		}
+		querySQL := fmt.Sprintf(`SELECT COUNT(*) AS count, MAX(t.updated_at) AS last_mod FROM %s WHERE %s`, src.from, src.where)
+		if err := r.db.Raw(querySQL, src.args...).Scan(&row).Error; err != nil {
+			return nil, err
+		}
+
+		stats = append(stats, seo.SectionStats{Section: section, Count: row.Count, LastMod: row.LastMod})
+	}
+
+	return stats, nil
+}
+
+func (r *seoRepository) SitemapEntries(section seo.Section, now time.Time, offset, limit int) ([]seo.Entry, error) {
+	src, ok := sectionSource(section, now)
+	if !ok {
+		return []seo.Entry{}, nil
+	}
+
+	var entries []seo.Entry
+	querySQL := fmt.Sprintf(`
+		SELECT t.id, %s AS slug, t.updated_at
+		FROM %s
+		WHERE %s
+		ORDER BY t.id ASC
+		LIMIT ? OFFSET ?
+	`, slugColumn(section), src.from, src.where)
+
+	args := append(src.args, limit, offset)
+	err := r.db.Raw(querySQL, args...).Scan(&entries).Error
+	return entries, err
+}
+
+// slugColumn selects the slug of a section's rows; authors are addressed by ID
+func slugColumn(section seo.Section) string {
+	if section == seo.SectionAuthors {
+		return "''"
+	}
+	return "t.slug"
+}
+
+// FeedItems returns the newest posts of a section, optionally limited to a set
+// of categories
+func (r *seoRepository) FeedItems(section seo.Section, categoryIDs []uint, now time.Time, limit int) ([]seo.FeedItem, error) {
+	if !section.HasFeed() {
+		return []seo.FeedItem{}, nil
+	}
+	src, _ := sectionSource(section, now)
+	args := src.args
+
+	categoryFilter := ""
+	if len(categoryIDs) > 0 {
+		categoryFilter = "AND t.category_id IN ?"
+		args = append(args, categoryIDs)
+	}
+
+	var items []seo.FeedItem
+	querySQL := fmt.Sprintf(`
+		SELECT t.id, t.title, t.slug, t.excerpt, t.publish_date, t.updated_at,
+		       a.name AS author_name, c.name AS category_name
+		FROM %s
+		LEFT JOIN authors a ON t.author_id = a.id
+		LEFT JOIN categories c ON t.category_id = c.id
+		WHERE %s %s
+		ORDER BY COALESCE(t.publish_date, t.created_at) DESC, t.id DESC
+		LIMIT ?
+	`, src.from, src.where, categoryFilter)
+
+	args = append(args, limit)
+	err := r.db.Raw(querySQL, args...).Scan(&items).Error
+	return items, err
+}
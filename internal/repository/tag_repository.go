@@ -0,0 +1,383 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/healthcare-market-research/backend/internal/domain/redirect"
+	"github.com/healthcare-market-research/backend/internal/domain/tag"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TagRepository interface {
+	GetAll(search string, page, limit int) ([]tag.Tag, int64, error)
+	GetByID(id uint) (*tag.Tag, error)
+	GetByIDs(ids []uint) ([]tag.Tag, error)
+	GetBySlug(slug string) (*tag.Tag, error)
+	FindByNames(lowerNames, slugs []string) ([]tag.Tag, error)
+	SlugExists(slug string, excludeID uint) (bool, error)
+	NameExists(name string, excludeID uint) (bool, error)
+	Create(t *tag.Tag) error
+	Update(t *tag.Tag) (int, error)
+	Delete(id uint) (int, error)
+	Merge(target *tag.Tag, sourceIDs []uint) (int, error)
+	CountUsage(tagIDs []uint) (map[uint]tag.Usage, error)
+	GetContent(tagID uint, contentType string, page, limit int) ([]tag.TaggedContent, int64, error)
+	SetContentTags(contentType string, contentID uint, tagIDs []uint) error
+}
+
+type tagRepository struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &tagRepository{db: db}
+}
+
+// GetAll lists tags by name, optionally matching a search term
+func (r *tagRepository) GetAll(search string, page, limit int) ([]tag.Tag, int64, error) {
+	var tags []tag.Tag
+	var total int64
+
+	query := r.db.Model(&tag.Tag{})
+	if search != "" {
+		query = query.Where("name ILIKE ?", "%"+search+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	err := query.Order("lower(name) ASC, id ASC").Limit(limit).Offset(offset).Find(&tags).Error
+	return tags, total, err
+}
+
+func (r *tagRepository) GetByID(id uint) (*tag.Tag, error) {
+	var t tag.Tag
+	if err := r.db.First(&t, id).Error; err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *tagRepository) GetByIDs(ids []uint) ([]tag.Tag, error) {
+	var tags []tag.Tag
+	if len(ids) == 0 {
+		return tags, nil
+	}
+	err := r.db.Where("id IN ?", ids).Order("id ASC").Find(&tags).Error
+	return tags, err
+}
+
+func (r *tagRepository) GetBySlug(slug string) (*tag.Tag, error) {
+	var t tag.Tag
+	if err := r.db.Where("slug = ?", slug).First(&t).Error; err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// FindByNames returns the tags with one of the lower-cased names or slugs
+func (r *tagRepository) FindByNames(lowerNames, slugs []string) ([]tag.Tag, error) {
+	var tags []tag.Tag
+	if len(lowerNames) == 0 || len(slugs) == 0 {
+		return tags, nil
+	}
+	err := r.db.
+		Where("lower(name) IN ? OR slug IN ?", lowerNames, slugs).
+		Order("id ASC").
+		Find(&tags).Error
+	return tags, err
+}
+
+func (r *tagRepository) SlugExists(slug string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&tag.Tag{}).
+		Where("slug = ? AND id <> ?", slug, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// NameExists reports whether another tag has the name, ignoring case
+func (r *tagRepository) NameExists(name string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&tag.Tag{}).
+		Where("lower(name) = lower(?) AND id <> ?", name, excludeID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *tagRepository) Create(t *tag.Tag) error {
+	return r.db.Create(t).Error
+}
+
+// Update saves the tag and rewrites the tag names kept on the content it is
+// attached to. It returns the number of content items changed.
+func (r *tagRepository) Update(t *tag.Tag) (int, error) {
+	updated := 0
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		refs, err := contentRefs(tx, []uint{t.ID})
+		if err != nil {
+			return err
+		}
+		if err := tx.Save(t).Error; err != nil {
+			return err
+		}
+		updated, err = refreshContentTagNames(tx, refs)
+		return err
+	})
+	return updated, err
+}
+
+// Delete removes the tag from all content and deletes it. It returns the
+// number of content items changed.
+func (r *tagRepository) Delete(id uint) (int, error) {
+	updated := 0
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		refs, err := contentRefs(tx, []uint{id})
+		if err != nil {
+			return err
+		}
+		// content_tags rows go with the tag
+		if err := tx.Delete(&tag.Tag{}, id).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("entity_type = ? AND entity_id = ?", redirect.EntityTag, id).
+			Delete(&redirect.SlugRedirect{}).Error; err != nil {
+			return err
+		}
+		updated, err = refreshContentTagNames(tx, refs)
+		return err
+	})
+	return updated, err
+}
+
+// Merge saves the target, attaches it wherever a source was attached (at the
+// source's position), moves the sources' slug redirects over and deletes the
+// sources, all in one transaction. It returns the number of content items
+// changed.
+func (r *tagRepository) Merge(target *tag.Tag, sourceIDs []uint) (int, error) {
+	updated := 0
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		refs, err := contentRefs(tx, sourceIDs)
+		if err != nil {
+			return err
+		}
+
+		if err := tx.Save(target).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec(`
+			INSERT INTO content_tags (tag_id, content_type, content_id, position, created_at)
+			SELECT ?, content_type, content_id, MIN(position), ?
+			FROM content_tags
+			WHERE tag_id IN ?
+			GROUP BY content_type, content_id
+			ON CONFLICT (content_type, content_id, tag_id)
+				DO UPDATE SET position = LEAST(content_tags.position, EXCLUDED.position)
+		`, target.ID, time.Now(), sourceIDs).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Model(&redirect.SlugRedirect{}).
+			Where("entity_type = ? AND entity_id IN ?", redirect.EntityTag, sourceIDs).
+			Updates(map[string]interface{}{"entity_id": target.ID, "updated_at": time.Now()}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Delete(&tag.Tag{}, sourceIDs).Error; err != nil {
+			return err
+		}
+
+		updated, err = refreshContentTagNames(tx, refs)
+		return err
+	})
+	return updated, err
+}
+
+// CountUsage counts the published content carrying each tag
+func (r *tagRepository) CountUsage(tagIDs []uint) (map[uint]tag.Usage, error) {
+	usage := make(map[uint]tag.Usage, len(tagIDs))
+	if len(tagIDs) == 0 {
+		return usage, nil
+	}
+
+	source, args := publishedTaggedContent(tagIDs, "")
+	var rows []struct {
+		TagID uint
+		Type  string
+		Count int64
+	}
+	querySQL := "SELECT tag_id, type, COUNT(*) AS count FROM (" + source + ") c GROUP BY tag_id, type"
+	if err := r.db.Raw(querySQL, args...).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		u := usage[row.TagID]
+		u.Add(row.Type, row.Count)
+		usage[row.TagID] = u
+	}
+	return usage, nil
+}
+
+// GetContent lists the published content carrying a tag, newest first,
+// optionally of one type only
+func (r *tagRepository) GetContent(tagID uint, contentType string, page, limit int) ([]tag.TaggedContent, int64, error) {
+	var content []tag.TaggedContent
+	var total int64
+
+	source, args := publishedTaggedContent([]uint{tagID}, contentType)
+
+	if err := r.db.Raw("SELECT COUNT(*) FROM ("+source+") c", args...).Scan(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	querySQL := "SELECT type, id, title, slug, excerpt, publish_date FROM (" + source + ") c" +
+		" ORDER BY publish_date DESC NULLS LAST, type ASC, id DESC LIMIT ? OFFSET ?"
+	err := r.db.Raw(querySQL, append(args, limit, offset)...).Scan(&content).Error
+	return content, total, err
+}
+
+// SetContentTags replaces the tags of one content item, keeping their order.
+// The caller writes the item's own copy of the names.
+func (r *tagRepository) SetContentTags(contentType string, contentID uint, tagIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("content_type = ? AND content_id = ?", contentType, contentID).
+			Delete(&tag.ContentTag{}).Error; err != nil {
+			return err
+		}
+		if len(tagIDs) == 0 {
+			return nil
+		}
+
+		links := make([]tag.ContentTag, len(tagIDs))
+		for i, id := range tagIDs {
+			links[i] = tag.ContentTag{TagID: id, ContentType: contentType, ContentID: contentID, Position: i}
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error
+	})
+}
+
+// contentRefs returns the content the tags are attached to
+func contentRefs(db *gorm.DB, tagIDs []uint) ([]tag.ContentRef, error) {
+	var refs []tag.ContentRef
+	err := db.Model(&tag.ContentTag{}).
+		Distinct("content_type", "content_id").
+		Where("tag_id IN ?", tagIDs).
+		Scan(&refs).Error
+	return refs, err
+}
+
+// tagNameCopies describes the copy of its tag names each content type keeps
+var tagNameCopies = map[string]struct {
+	table string
+	names string // aggregates the names of the rows of content_tags ct joined to tags t
+	empty string
+}{
+	tag.ContentReport:       {"reports", "jsonb_agg(t.name ORDER BY ct.position, t.name)", "'[]'::jsonb"},
+	tag.ContentBlog:         {"blogs", "string_agg(t.name, ', ' ORDER BY ct.position, t.name)", "''"},
+	tag.ContentPressRelease: {"press_releases", "string_agg(t.name, ', ' ORDER BY ct.position, t.name)", "''"},
+}
+
+// refreshContentTagNames rewrites the tag names kept on the content from
+// content_tags. Rows whose names change get a new version, so an editor
+// holding the old names gets a conflict instead of writing them back.
+func refreshContentTagNames(db *gorm.DB, refs []tag.ContentRef) (int, error) {
+	ids := map[string][]uint{}
+	for _, ref := range refs {
+		ids[ref.ContentType] = append(ids[ref.ContentType], ref.ContentID)
+	}
+
+	updated := 0
+	for _, contentType := range tag.ContentTypes {
+		if len(ids[contentType]) == 0 {
+			continue
+		}
+		c := tagNameCopies[contentType]
+		querySQL := fmt.Sprintf(`
+			UPDATE %[1]s AS target
+			SET tags = x.names, version = target.version + 1, updated_at = ?
+			FROM (
+				SELECT s.id, coalesce((
+					SELECT %[2]s
+					FROM content_tags ct JOIN tags t ON t.id = ct.tag_id
+					WHERE ct.content_type = ? AND ct.content_id = s.id
+				), %[3]s) AS names
+				FROM %[1]s s
+				WHERE s.id IN ?
+			) x
+			WHERE target.id = x.id AND target.tags IS DISTINCT FROM x.names
+		`, c.table, c.names, c.empty)
+
+		result := db.Exec(querySQL, time.Now(), contentType, ids[contentType])
+		if result.Error != nil {
+			return 0, result.Error
+		}
+		updated += int(result.RowsAffected)
+	}
+	return updated, nil
+}
+
+// publishedTaggedContent builds a query for the live, published content
+// carrying any of the tags, as tag_id, type, id, title, slug, excerpt and
+// publish_date rows
+func publishedTaggedContent(tagIDs []uint, contentType string) (string, []interface{}) {
+	parts := map[string]string{
+		tag.ContentReport: `
+			SELECT ct.tag_id, 'report' AS type, r.id, r.title, r.slug, left(r.summary, 300) AS excerpt, r.publish_date
+			FROM content_tags ct JOIN reports r ON r.id = ct.content_id
+			WHERE ct.content_type = 'report' AND ct.tag_id IN ?
+				AND r.status = 'published' AND r.deleted_at IS NULL
+				AND (r.publish_date IS NULL OR r.publish_date <= ?)`,
+		tag.ContentBlog: `
+			SELECT ct.tag_id, 'blog' AS type, b.id, b.title, b.slug, b.excerpt, b.publish_date
+			FROM content_tags ct JOIN blogs b ON b.id = ct.content_id
+			WHERE ct.content_type = 'blog' AND ct.tag_id IN ?
+				AND b.status = 'published' AND b.deleted_at IS NULL`,
+		tag.ContentPressRelease: `
+			SELECT ct.tag_id, 'press_release' AS type, p.id, p.title, p.slug, p.excerpt, p.publish_date
+			FROM content_tags ct JOIN press_releases p ON p.id = ct.content_id
+			WHERE ct.content_type = 'press_release' AND ct.tag_id IN ?
+				AND p.status = 'published' AND p.deleted_at IS NULL`,
+	}
+
+	var selects []string
+	var args []interface{}
+	for _, t := range tag.ContentTypes {
+		if contentType != "" && t != contentType {
+			continue
+		}
+		selects = append(selects, parts[t])
+		args = append(args, tagIDs)
+		if t == tag.ContentReport {
+			args = append(args, time.Now())
+		}
+	}
+	return strings.Join(selects, "\nUNION ALL\n"), args
+}
+
+// tagFilter matches content of the type carrying any of the tags, given by
+// name or slug. idColumn is the content's ID column in the outer query.
+func tagFilter(contentType, idColumn string, tags []string) (string, []interface{}) {
+	values := make([]string, 0, len(tags))
+	for _, t := range tags {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			values = append(values, t)
+		}
+	}
+	if len(values) == 0 {
+		return "", nil
+	}
+
+	condition := fmt.Sprintf(`EXISTS (
+		SELECT 1 FROM content_tags ct JOIN tags t ON t.id = ct.tag_id
+		WHERE ct.content_type = ? AND ct.content_id = %s AND (lower(t.name) IN ? OR t.slug IN ?)
+	)`, idColumn)
+	return condition, []interface{}{contentType, values, values}
+}
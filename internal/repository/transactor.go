@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+)
+
+// Tx holds repositories bound to one database transaction
+type Tx struct {
+	Reports       ReportRepository
+	Blogs         BlogRepository
+	PressReleases PressReleaseRepository
+	Tags          TagRepository
+}
+
+// Transactor runs work spanning several repository calls in one transaction
+type Transactor interface {
+	// Transaction calls fn with repositories bound to a new transaction. It
+	// commits when fn returns nil and rolls back otherwise.
+	Transaction(fn func(tx *Tx) error) error
+}
+
+type transactor struct {
+	db *gorm.DB
+}
+
+func NewTransactor(db *gorm.DB) Transactor {
+	return &transactor{db: db}
+}
+
+func (t *transactor) Transaction(fn func(tx *Tx) error) error {
+	return t.db.Transaction(func(db *gorm.DB) error {
+		return fn(&Tx{
+			Reports:       NewReportRepository(db, NewAuthorRepository(db)),
+			Blogs:         NewBlogRepository(db),
+			PressReleases: NewPressReleaseRepository(db),
+			Tags:          NewTagRepository(db),
+		})
+	})
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// ErrVersionConflict is returned when an optimistic update finds that the row
+// was changed after the caller read it
+var ErrVersionConflict = errors.New("record was modified by another request")
+
+// bumpVersion advances a row's optimistic lock version. Every write to a
+// versioned table should include it so stale edits are detected.
+var bumpVersion = gorm.Expr("version + 1")
+
+// updateVersioned applies updates only if the row is still at the expected
+// version. It returns gorm.ErrRecordNotFound for a missing row and
+// ErrVersionConflict for a stale one.
+func updateVersioned(db *gorm.DB, model interface{}, id uint, version int, updates map[string]interface{}) error {
+	updates["version"] = bumpVersion
+
+	result := db.Model(model).Where("id = ? AND version = ?", id, version).Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return versionMiss(db, model, id)
+	}
+	return nil
+}
+
+// versionMiss explains why a versioned update touched no rows
+func versionMiss(db *gorm.DB, model interface{}, id uint) error {
+	var count int64
+	if err := db.Model(model).Where("id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return ErrVersionConflict
+}
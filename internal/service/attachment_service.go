@@ -0,0 +1,246 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+
+	"github.com/healthcare-market-research/backend/internal/cache"
+	"github.com/healthcare-market-research/backend/internal/domain/attachment"
+	"github.com/healthcare-market-research/backend/internal/repository"
+	"github.com/healthcare-market-research/backend/internal/storage"
+	"github.com/healthcare-market-research/backend/pkg/validation"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAttachmentNotFound      = errors.New("attachment not found")
+	ErrAttachmentOwnerNotFound = errors.New("attachment owner not found")
+	ErrDocumentsNotAllowed     = errors.New("only press releases accept document attachments")
+	ErrOnlyImagesFeatured      = errors.New("only image attachments can be featured")
+	ErrInvalidAttachmentOrder  = errors.New("reorder must list every attachment exactly once")
+)
+
+// AttachmentService manages the images and documents attached to blogs,
+// press releases and categories
+type AttachmentService interface {
+	List(ownerType string, ownerID uint) ([]attachment.Attachment, error)
+	Upload(ownerType string, ownerID uint, file *multipart.FileHeader, req *attachment.UploadAttachmentRequest, uploadedBy *uint) (*attachment.Attachment, error)
+	Update(ownerType string, ownerID, id uint, req *attachment.UpdateAttachmentRequest) (*attachment.Attachment, error)
+	Reorder(ownerType string, ownerID uint, ids []uint) ([]attachment.Attachment, error)
+	Delete(ownerType string, ownerID, id uint) (*attachment.Attachment, error)
+}
+
+type attachmentService struct {
+	repo            repository.AttachmentRepository
+	imageStorage    storage.ImageStorage
+	documentStorage storage.ImageStorage
+}
+
+func NewAttachmentService(repo repository.AttachmentRepository, imageStorage, documentStorage storage.ImageStorage) AttachmentService {
+	return &attachmentService{
+		repo:            repo,
+		imageStorage:    imageStorage,
+		documentStorage: documentStorage,
+	}
+}
+
+func (s *attachmentService) List(ownerType string, ownerID uint) ([]attachment.Attachment, error) {
+	if err := s.checkOwner(ownerType, ownerID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByOwner(ownerType, ownerID)
+}
+
+// Upload stores an image, with its variants, or a PDF and appends it to the
+// owner's attachments. Files are removed again if the record cannot be saved.
+func (s *attachmentService) Upload(ownerType string, ownerID uint, file *multipart.FileHeader, req *attachment.UploadAttachmentRequest, uploadedBy *uint) (*attachment.Attachment, error) {
+	if err := s.checkOwner(ownerType, ownerID); err != nil {
+		return nil, err
+	}
+
+	kind := attachment.KindImage
+	if validation.IsDocumentFile(file) {
+		kind = attachment.KindDocument
+		if ownerType != attachment.OwnerPressRelease {
+			return nil, ErrDocumentsNotAllowed
+		}
+		if req.IsFeatured {
+			return nil, ErrOnlyImagesFeatured
+		}
+	}
+
+	sortOrder, err := s.repo.NextSortOrder(ownerType, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &attachment.Attachment{
+		OwnerType:  ownerType,
+		OwnerID:    ownerID,
+		Kind:       kind,
+		FileName:   filepath.Base(file.Filename),
+		MimeType:   mime.TypeByExtension(strings.ToLower(filepath.Ext(file.Filename))),
+		ByteSize:   file.Size,
+		Caption:    strings.TrimSpace(req.Caption),
+		AltText:    strings.TrimSpace(req.AltText),
+		SortOrder:  sortOrder,
+		IsFeatured: req.IsFeatured,
+		UploadedBy: uploadedBy,
+	}
+
+	metadata := map[string]string{
+		"owner_type": ownerType,
+		"owner_id":   fmt.Sprintf("%d", ownerID),
+		"type":       "attachment",
+	}
+	if kind == attachment.KindImage {
+		a.URL, a.Variants, err = uploadImageWithVariants(s.imageStorage, file, metadata)
+	} else {
+		a.URL, err = storage.UploadFile(s.documentStorage, file, metadata)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload attachment: %w", err)
+	}
+
+	if err := s.repo.Create(a); err != nil {
+		log.Printf("Warning: Rolling back attachment upload for %s %d", ownerType, ownerID)
+		s.deleteFiles(a)
+		return nil, fmt.Errorf("failed to create attachment record: %w", err)
+	}
+
+	invalidateAttachmentOwner(ownerType)
+	return a, nil
+}
+
+// Update edits the caption and alt text and can make the attachment the
+// owner's featured image
+func (s *attachmentService) Update(ownerType string, ownerID, id uint, req *attachment.UpdateAttachmentRequest) (*attachment.Attachment, error) {
+	a, err := s.find(ownerType, ownerID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Caption != nil {
+		a.Caption = strings.TrimSpace(*req.Caption)
+	}
+	if req.AltText != nil {
+		a.AltText = strings.TrimSpace(*req.AltText)
+	}
+	if req.IsFeatured != nil {
+		if *req.IsFeatured && a.Kind != attachment.KindImage {
+			return nil, ErrOnlyImagesFeatured
+		}
+		a.IsFeatured = *req.IsFeatured
+	}
+
+	if err := s.repo.Update(a); err != nil {
+		return nil, err
+	}
+
+	invalidateAttachmentOwner(ownerType)
+	return a, nil
+}
+
+// Reorder puts the owner's attachments in the order of ids, which must name
+// each of them exactly once
+func (s *attachmentService) Reorder(ownerType string, ownerID uint, ids []uint) ([]attachment.Attachment, error) {
+	if err := s.checkOwner(ownerType, ownerID); err != nil {
+		return nil, err
+	}
+
+	current, err := s.repo.FindByOwner(ownerType, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) != len(current) {
+		return nil, ErrInvalidAttachmentOrder
+	}
+	remaining := make(map[uint]bool, len(current))
+	for _, a := range current {
+		remaining[a.ID] = true
+	}
+	for _, id := range ids {
+		if !remaining[id] {
+			return nil, ErrInvalidAttachmentOrder
+		}
+		delete(remaining, id)
+	}
+
+	if err := s.repo.Reorder(ownerType, ownerID, ids); err != nil {
+		return nil, err
+	}
+
+	invalidateAttachmentOwner(ownerType)
+	return s.repo.FindByOwner(ownerType, ownerID)
+}
+
+// Delete removes the attachment and its files. Storage failures are only
+// logged; the image garbage collector reclaims anything left behind.
+func (s *attachmentService) Delete(ownerType string, ownerID, id uint) (*attachment.Attachment, error) {
+	a, err := s.find(ownerType, ownerID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Delete(a.ID); err != nil {
+		return nil, err
+	}
+	s.deleteFiles(a)
+
+	invalidateAttachmentOwner(ownerType)
+	return a, nil
+}
+
+func (s *attachmentService) checkOwner(ownerType string, ownerID uint) error {
+	exists, err := s.repo.OwnerExists(ownerType, ownerID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrAttachmentOwnerNotFound
+	}
+	return nil
+}
+
+// find loads an attachment, treating attachments of other owners as missing
+func (s *attachmentService) find(ownerType string, ownerID, id uint) (*attachment.Attachment, error) {
+	a, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+	if a.OwnerType != ownerType || a.OwnerID != ownerID {
+		return nil, ErrAttachmentNotFound
+	}
+	return a, nil
+}
+
+func (s *attachmentService) deleteFiles(a *attachment.Attachment) {
+	if a.Kind == attachment.KindDocument {
+		if err := s.documentStorage.Delete(a.URL); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			log.Printf("Warning: Failed to delete attachment %s: %v", a.URL, err)
+		}
+		return
+	}
+	deleteImageWithVariants(s.imageStorage, a.URL, a.Variants)
+}
+
+// invalidateAttachmentOwner drops cached single blogs, press releases or
+// categories, which embed their attachments
+func invalidateAttachmentOwner(ownerType string) {
+	switch ownerType {
+	case attachment.OwnerBlog:
+		cache.DeletePattern("blog:*")
+	case attachment.OwnerPressRelease:
+		cache.DeletePattern("press_release:*")
+	case attachment.OwnerCategory:
+		cache.DeletePattern("category:*")
+	}
+}
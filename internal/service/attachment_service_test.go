@@ -0,0 +1,112 @@
+package service
+
+import (
+	"mime/multipart"
+	"testing"
+
+	"github.com/healthcare-market-research/backend/internal/domain/attachment"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// Mock AttachmentRepository for testing
+type mockAttachmentRepository struct {
+	attachments []attachment.Attachment
+	owners      map[string]bool
+	reordered   []uint
+}
+
+func (m *mockAttachmentRepository) Create(a *attachment.Attachment) error {
+	a.ID = uint(len(m.attachments) + 1)
+	m.attachments = append(m.attachments, *a)
+	return nil
+}
+
+func (m *mockAttachmentRepository) FindByID(id uint) (*attachment.Attachment, error) {
+	for i := range m.attachments {
+		if m.attachments[i].ID == id {
+			a := m.attachments[i]
+			return &a, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *mockAttachmentRepository) FindByOwner(ownerType string, ownerID uint) ([]attachment.Attachment, error) {
+	var owned []attachment.Attachment
+	for _, a := range m.attachments {
+		if a.OwnerType == ownerType && a.OwnerID == ownerID {
+			owned = append(owned, a)
+		}
+	}
+	return owned, nil
+}
+
+func (m *mockAttachmentRepository) Update(a *attachment.Attachment) error {
+	return nil
+}
+
+func (m *mockAttachmentRepository) Delete(id uint) error {
+	return nil
+}
+
+func (m *mockAttachmentRepository) NextSortOrder(ownerType string, ownerID uint) (int, error) {
+	return 0, nil
+}
+
+func (m *mockAttachmentRepository) Reorder(ownerType string, ownerID uint, ids []uint) error {
+	m.reordered = ids
+	return nil
+}
+
+func (m *mockAttachmentRepository) OwnerExists(ownerType string, ownerID uint) (bool, error) {
+	return m.owners[ownerType], nil
+}
+
+func newAttachmentTestService() (*attachmentService, *mockAttachmentRepository) {
+	repo := &mockAttachmentRepository{
+		owners: map[string]bool{attachment.OwnerBlog: true, attachment.OwnerPressRelease: true},
+		attachments: []attachment.Attachment{
+			{ID: 1, OwnerType: attachment.OwnerBlog, OwnerID: 3, Kind: attachment.KindImage},
+			{ID: 2, OwnerType: attachment.OwnerBlog, OwnerID: 3, Kind: attachment.KindImage},
+			{ID: 3, OwnerType: attachment.OwnerPressRelease, OwnerID: 3, Kind: attachment.KindDocument},
+		},
+	}
+	return NewAttachmentService(repo, nil, nil).(*attachmentService), repo
+}
+
+func TestAttachmentService_Upload_Rules(t *testing.T) {
+	s, _ := newAttachmentTestService()
+	pdf := &multipart.FileHeader{Filename: "kit.pdf", Size: 10}
+
+	_, err := s.Upload(attachment.OwnerBlog, 3, pdf, &attachment.UploadAttachmentRequest{}, nil)
+	assert.ErrorIs(t, err, ErrDocumentsNotAllowed)
+
+	_, err = s.Upload(attachment.OwnerPressRelease, 3, pdf, &attachment.UploadAttachmentRequest{IsFeatured: true}, nil)
+	assert.ErrorIs(t, err, ErrOnlyImagesFeatured)
+
+	_, err = s.Upload(attachment.OwnerCategory, 3, pdf, &attachment.UploadAttachmentRequest{}, nil)
+	assert.ErrorIs(t, err, ErrAttachmentOwnerNotFound)
+}
+
+func TestAttachmentService_Update_Rules(t *testing.T) {
+	s, _ := newAttachmentTestService()
+	featured := true
+
+	// Attachments are only reachable through their own owner
+	_, err := s.Update(attachment.OwnerPressRelease, 3, 1, &attachment.UpdateAttachmentRequest{})
+	assert.ErrorIs(t, err, ErrAttachmentNotFound)
+
+	_, err = s.Update(attachment.OwnerPressRelease, 3, 3, &attachment.UpdateAttachmentRequest{IsFeatured: &featured})
+	assert.ErrorIs(t, err, ErrOnlyImagesFeatured)
+}
+
+func TestAttachmentService_Reorder_RequiresEveryAttachment(t *testing.T) {
+	s, repo := newAttachmentTestService()
+
+	for _, ids := range [][]uint{{1}, {1, 1}, {1, 3}, {1, 2, 3}} {
+		_, err := s.Reorder(attachment.OwnerBlog, 3, ids)
+		assert.ErrorIs(t, err, ErrInvalidAttachmentOrder, "%v", ids)
+	}
+	assert.Nil(t, repo.reordered)
+}
@@ -1,14 +1,16 @@
 package service
 
 import (
+	"errors"
 	"fmt"
-	"log"
 	"mime/multipart"
 	"time"
 
 	"github.com/healthcare-market-research/backend/internal/cache"
 	"github.com/healthcare-market-research/backend/internal/domain/author"
+	"github.com/healthcare-market-research/backend/internal/domain/seo"
 	"github.com/healthcare-market-research/backend/internal/repository"
+	"github.com/healthcare-market-research/backend/internal/storage"
 )
 
 type AuthorService interface {
@@ -22,14 +24,14 @@ type AuthorService interface {
 }
 
 type authorService struct {
-	repo           repository.AuthorRepository
-	cloudflareService CloudflareImagesService
+	repo         repository.AuthorRepository
+	imageStorage storage.ImageStorage
 }
 
-func NewAuthorService(repo repository.AuthorRepository, cloudflareService CloudflareImagesService) AuthorService {
+func NewAuthorService(repo repository.AuthorRepository, imageStorage storage.ImageStorage) AuthorService {
 	return &authorService{
-		repo:           repo,
-		cloudflareService: cloudflareService,
+		repo:         repo,
+		imageStorage: imageStorage,
 	}
 }
 
@@ -90,6 +92,7 @@ func (s *authorService) Create(auth *author.Author) error {
 
 	// Invalidate list caches
 	cache.DeletePattern("authors:list:*")
+	invalidateSEOCache(seo.SectionAuthors, seo.SectionBlogs, seo.SectionPressReleases)
 
 	return nil
 }
@@ -109,6 +112,7 @@ func (s *authorService) Update(id uint, auth *author.Author) error {
 
 	// Invalidate caches
 	cache.DeletePattern("authors:list:*")
+	invalidateSEOCache(seo.SectionAuthors, seo.SectionBlogs, seo.SectionPressReleases)
 	cache.Delete(fmt.Sprintf("author:id:%d", id))
 
 	return nil
@@ -131,12 +135,10 @@ func (s *authorService) Delete(id uint) error {
 		return err
 	}
 
-	// Delete image from Cloudflare if exists (before deleting author)
+	// Delete image from storage if exists (before deleting author); errors are
+	// logged but don't fail deletion
 	if auth.ImageURL != "" {
-		if err := s.cloudflareService.Delete(auth.ImageURL); err != nil {
-			// Log error but don't fail deletion
-			log.Printf("Warning: Failed to delete image from Cloudflare for author %d: %v", id, err)
-		}
+		deleteImageWithVariants(s.imageStorage, auth.ImageURL, auth.ImageVariants)
 	}
 
 	err = s.repo.Delete(id)
@@ -146,6 +148,7 @@ func (s *authorService) Delete(id uint) error {
 
 	// Invalidate caches
 	cache.DeletePattern("authors:list:*")
+	invalidateSEOCache(seo.SectionAuthors, seo.SectionBlogs, seo.SectionPressReleases)
 	cache.Delete(fmt.Sprintf("author:id:%d", id))
 
 	return nil
@@ -159,36 +162,34 @@ func (s *authorService) UploadImage(authorID uint, file *multipart.FileHeader) (
 		return nil, err
 	}
 
-	// Delete old image from Cloudflare if exists
-	if auth.ImageURL != "" {
-		if err := s.cloudflareService.Delete(auth.ImageURL); err != nil {
-			// Log error but continue with upload
-			log.Printf("Warning: Failed to delete old image from Cloudflare for author %d: %v", authorID, err)
-		}
-	}
-
-	// Upload new image to Cloudflare
+	// Upload new image to storage along with its variants
 	metadata := map[string]string{
 		"author_id": fmt.Sprintf("%d", authorID),
 		"type":      "author_profile",
 	}
-	imageURL, err := s.cloudflareService.Upload(file, metadata)
+	imageURL, variants, err := uploadImageWithVariants(s.imageStorage, file, metadata)
 	if err != nil {
 		return nil, fmt.Errorf("failed to upload image: %w", err)
 	}
 
 	// Update author with new image URL
+	oldURL, oldVariants := auth.ImageURL, auth.ImageVariants
 	auth.ImageURL = imageURL
+	auth.ImageVariants = variants
 	if err := s.repo.Update(auth); err != nil {
 		// Rollback: delete the uploaded image
-		if deleteErr := s.cloudflareService.Delete(imageURL); deleteErr != nil {
-			log.Printf("Warning: Failed to rollback image upload for author %d: %v", authorID, deleteErr)
-		}
+		deleteImageWithVariants(s.imageStorage, imageURL, variants)
 		return nil, fmt.Errorf("failed to update author: %w", err)
 	}
 
+	// Delete old image from storage if exists, now that nothing points at it
+	if oldURL != "" {
+		deleteImageWithVariants(s.imageStorage, oldURL, oldVariants)
+	}
+
 	// Invalidate caches
 	cache.DeletePattern("authors:list:*")
+	invalidateSEOCache(seo.SectionAuthors, seo.SectionBlogs, seo.SectionPressReleases)
 	cache.Delete(fmt.Sprintf("author:id:%d", authorID))
 
 	return auth, nil
@@ -206,19 +207,23 @@ func (s *authorService) DeleteImage(authorID uint) error {
 		return fmt.Errorf("author has no image to delete")
 	}
 
-	// Delete image from Cloudflare
-	if err := s.cloudflareService.Delete(auth.ImageURL); err != nil {
-		return fmt.Errorf("failed to delete image from Cloudflare: %w", err)
+	// Delete image from storage; an image that is already gone only needs
+	// its URL cleared
+	if err := s.imageStorage.Delete(auth.ImageURL); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("failed to delete image from storage: %w", err)
 	}
+	deleteImageFiles(s.imageStorage, auth.ImageVariants.URLs())
 
 	// Clear image URL in database
 	auth.ImageURL = ""
+	auth.ImageVariants = nil
 	if err := s.repo.Update(auth); err != nil {
 		return fmt.Errorf("failed to update author: %w", err)
 	}
 
 	// Invalidate caches
 	cache.DeletePattern("authors:list:*")
+	invalidateSEOCache(seo.SectionAuthors, seo.SectionBlogs, seo.SectionPressReleases)
 	cache.Delete(fmt.Sprintf("author:id:%d", authorID))
 
 	return nil
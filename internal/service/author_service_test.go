@@ -1,26 +1,25 @@
 package service
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
-	"mime/multipart"
-	"net/http/httptest"
+	"io"
 	"testing"
 
 	"github.com/healthcare-market-research/backend/internal/domain/author"
+	"github.com/healthcare-market-research/backend/internal/storage"
 )
 
-// Mock CloudflareImagesService for testing
+// Mock ImageStorage for testing
 type mockCloudflareService struct {
-	uploadFunc      func(file *multipart.FileHeader, metadata map[string]string) (string, error)
-	deleteFunc      func(imageURL string) error
-	extractIDFunc   func(imageURL string) (string, error)
+	uploadFunc func(filename string, metadata map[string]string) (string, error)
+	deleteFunc func(imageURL string) error
+	statFunc   func(imageURL string) (*storage.ImageInfo, error)
 }
 
-func (m *mockCloudflareService) Upload(file *multipart.FileHeader, metadata map[string]string) (string, error) {
+func (m *mockCloudflareService) Upload(filename string, body io.Reader, metadata map[string]string) (string, error) {
 	if m.uploadFunc != nil {
-		return m.uploadFunc(file, metadata)
+		return m.uploadFunc(filename, metadata)
 	}
 	return "https://imagedelivery.net/test/image-id-123/public", nil
 }
@@ -32,13 +31,29 @@ func (m *mockCloudflareService) Delete(imageURL string) error {
 	return nil
 }
 
-func (m *mockCloudflareService) ExtractImageID(imageURL string) (string, error) {
-	if m.extractIDFunc != nil {
-		return m.extractIDFunc(imageURL)
-	}
+func (m *mockCloudflareService) URL(key string) string {
+	return "https://imagedelivery.net/test/" + key + "/public"
+}
+
+func (m *mockCloudflareService) Key(imageURL string) (string, error) {
 	return "image-id-123", nil
 }
 
+func (m *mockCloudflareService) Stat(imageURL string) (*storage.ImageInfo, error) {
+	if m.statFunc != nil {
+		return m.statFunc(imageURL)
+	}
+	return &storage.ImageInfo{Key: "image-id-123", URL: imageURL}, nil
+}
+
+func (m *mockCloudflareService) Open(imageURL string) (io.ReadCloser, error) {
+	return nil, storage.ErrNotFound
+}
+
+func (m *mockCloudflareService) List(fn func(*storage.ImageInfo) error) error {
+	return nil
+}
+
 // Mock AuthorRepository for testing
 type mockAuthorRepository struct {
 	getByIDFunc             func(id uint) (*author.Author, error)
@@ -75,6 +90,14 @@ func (m *mockAuthorRepository) IsReferencedInReports(id uint) (bool, error) {
 	return false, nil
 }
 
+func (m *mockAuthorRepository) GetByIDs(ids []uint) ([]author.Author, error) {
+	return nil, nil
+}
+
+func (m *mockAuthorRepository) GetByNames(names []string) ([]author.Author, error) {
+	return nil, nil
+}
+
 func (m *mockAuthorRepository) GetAll(page, limit int, search string) ([]author.Author, int64, error) {
 	return nil, 0, nil
 }
@@ -83,21 +106,6 @@ func (m *mockAuthorRepository) Create(author *author.Author) error {
 	return nil
 }
 
-// Helper to create a test file header
-func createTestFileHeader(filename string, content string) *multipart.FileHeader {
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-	part, _ := writer.CreateFormFile("file", filename)
-	part.Write([]byte(content))
-	writer.Close()
-
-	req := httptest.NewRequest("POST", "/", body)
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-	req.ParseMultipartForm(10 << 20)
-	_, fileHeader, _ := req.FormFile("file")
-	return fileHeader
-}
-
 func TestAuthorService_UploadImage_Success(t *testing.T) {
 	mockRepo := &mockAuthorRepository{
 		getByIDFunc: func(id uint) (*author.Author, error) {
@@ -116,7 +124,7 @@ func TestAuthorService_UploadImage_Success(t *testing.T) {
 	}
 
 	mockCloudflare := &mockCloudflareService{
-		uploadFunc: func(file *multipart.FileHeader, metadata map[string]string) (string, error) {
+		uploadFunc: func(filename string, metadata map[string]string) (string, error) {
 			if metadata["author_id"] != "1" {
 				t.Errorf("Expected author_id metadata to be '1', got '%s'", metadata["author_id"])
 			}
@@ -125,7 +133,7 @@ func TestAuthorService_UploadImage_Success(t *testing.T) {
 	}
 
 	service := NewAuthorService(mockRepo, mockCloudflare)
-	fileHeader := createTestFileHeader("test.jpg", "fake image content")
+	fileHeader := pngFileHeader(t, 40, 40)
 
 	updatedAuthor, err := service.UploadImage(1, fileHeader)
 	if err != nil {
@@ -162,13 +170,13 @@ func TestAuthorService_UploadImage_ReplaceExisting(t *testing.T) {
 			deleteCalled = true
 			return nil
 		},
-		uploadFunc: func(file *multipart.FileHeader, metadata map[string]string) (string, error) {
+		uploadFunc: func(filename string, metadata map[string]string) (string, error) {
 			return "https://imagedelivery.net/test/new-image-id/public", nil
 		},
 	}
 
 	service := NewAuthorService(mockRepo, mockCloudflare)
-	fileHeader := createTestFileHeader("test.jpg", "fake image content")
+	fileHeader := pngFileHeader(t, 40, 40)
 
 	_, err := service.UploadImage(1, fileHeader)
 	if err != nil {
@@ -190,7 +198,7 @@ func TestAuthorService_UploadImage_AuthorNotFound(t *testing.T) {
 	mockCloudflare := &mockCloudflareService{}
 
 	service := NewAuthorService(mockRepo, mockCloudflare)
-	fileHeader := createTestFileHeader("test.jpg", "fake image content")
+	fileHeader := pngFileHeader(t, 40, 40)
 
 	_, err := service.UploadImage(999, fileHeader)
 	if err == nil {
@@ -206,13 +214,13 @@ func TestAuthorService_UploadImage_CloudflareUploadFails(t *testing.T) {
 	}
 
 	mockCloudflare := &mockCloudflareService{
-		uploadFunc: func(file *multipart.FileHeader, metadata map[string]string) (string, error) {
+		uploadFunc: func(filename string, metadata map[string]string) (string, error) {
 			return "", errors.New("cloudflare API error")
 		},
 	}
 
 	service := NewAuthorService(mockRepo, mockCloudflare)
-	fileHeader := createTestFileHeader("test.jpg", "fake image content")
+	fileHeader := pngFileHeader(t, 40, 40)
 
 	_, err := service.UploadImage(1, fileHeader)
 	if err == nil {
@@ -237,7 +245,7 @@ func TestAuthorService_UploadImage_DatabaseUpdateFails_Rollback(t *testing.T) {
 	}
 
 	mockCloudflare := &mockCloudflareService{
-		uploadFunc: func(file *multipart.FileHeader, metadata map[string]string) (string, error) {
+		uploadFunc: func(filename string, metadata map[string]string) (string, error) {
 			return uploadedImageURL, nil
 		},
 		deleteFunc: func(imageURL string) error {
@@ -250,7 +258,7 @@ func TestAuthorService_UploadImage_DatabaseUpdateFails_Rollback(t *testing.T) {
 	}
 
 	service := NewAuthorService(mockRepo, mockCloudflare)
-	fileHeader := createTestFileHeader("test.jpg", "fake image content")
+	fileHeader := pngFileHeader(t, 40, 40)
 
 	_, err := service.UploadImage(1, fileHeader)
 	if err == nil {
@@ -7,6 +7,9 @@ import (
 
 	"github.com/healthcare-market-research/backend/internal/cache"
 	"github.com/healthcare-market-research/backend/internal/domain/blog"
+	"github.com/healthcare-market-research/backend/internal/domain/redirect"
+	"github.com/healthcare-market-research/backend/internal/domain/seo"
+	"github.com/healthcare-market-research/backend/internal/domain/tag"
 	"github.com/healthcare-market-research/backend/internal/repository"
 	"github.com/gosimple/slug"
 )
@@ -28,11 +31,13 @@ type BlogService interface {
 }
 
 type blogService struct {
-	repo repository.BlogRepository
+	repo      repository.BlogRepository
+	redirects repository.RedirectRepository
+	tx        repository.Transactor
 }
 
-func NewBlogService(repo repository.BlogRepository) BlogService {
-	return &blogService{repo: repo}
+func NewBlogService(repo repository.BlogRepository, redirects repository.RedirectRepository, tx repository.Transactor) BlogService {
+	return &blogService{repo: repo, redirects: redirects, tx: tx}
 }
 
 func (s *blogService) Create(req *blog.CreateBlogRequest) (*blog.Blog, error) {
@@ -57,7 +62,6 @@ func (s *blogService) Create(req *blog.CreateBlogRequest) (*blog.Blog, error) {
 		Excerpt:     req.Excerpt,
 		Content:     req.Content,
 		CategoryID:  req.CategoryID,
-		Tags:        req.Tags,
 		AuthorID:    req.AuthorID,
 		Status:      req.Status,
 		PublishDate: &publishDate,
@@ -69,12 +73,25 @@ func (s *blogService) Create(req *blog.CreateBlogRequest) (*blog.Blog, error) {
 		b.Metadata = *req.Metadata
 	}
 
-	if err := s.repo.Create(b); err != nil {
+	// Tags are created and linked with the content, so a failed save leaves none behind
+	err = s.tx.Transaction(func(tx *repository.Tx) error {
+		tags, err := resolveTags(tx.Tags, tag.Split(req.Tags))
+		if err != nil {
+			return err
+		}
+		b.Tags = tag.Join(tag.Names(tags))
+		if err := tx.Blogs.Create(b); err != nil {
+			return err
+		}
+		return setContentTags(tx.Tags, tag.ContentBlog, b.ID, tags)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	// Invalidate caches
 	cache.DeletePattern("blogs:*")
+	invalidateSEOCache(seo.SectionBlogs)
 
 	return b, nil
 }
@@ -82,7 +99,8 @@ func (s *blogService) Create(req *blog.CreateBlogRequest) (*blog.Blog, error) {
 func (s *blogService) GetAll(query blog.GetBlogsQuery) ([]blog.Blog, int64, error) {
 	// Only cache non-filtered, non-search queries
 	shouldCache := query.Status == "" && query.CategoryID == "" && query.Tags == "" &&
-		query.AuthorID == "" && query.Location == "" && query.Search == ""
+		query.AuthorID == "" && query.Location == "" && query.Search == "" &&
+		query.Cursor == nil && !query.SkipCount
 
 	if shouldCache {
 		cacheKey := fmt.Sprintf("blogs:list:%d:%d", query.Page, query.Limit)
@@ -139,26 +157,33 @@ func (s *blogService) GetBySlug(slug string) (*blog.Blog, error) {
 	})
 
 	if err != nil {
-		return nil, err
+		// A retired slug answers with where the blog lives now
+		return nil, slugMoved(s.redirects, redirect.EntityBlog, slug, err)
 	}
 
 	return &b, nil
 }
 
 func (s *blogService) Update(id uint, req *blog.UpdateBlogRequest) (*blog.Blog, error) {
+	if req.Version == nil {
+		return nil, ErrVersionRequired
+	}
+
 	// Check if blog exists
-	_, err := s.repo.GetByID(id)
+	existing, err := s.repo.GetByID(id)
 	if err != nil {
 		return nil, err
 	}
 
 	// Build updates map
 	updates := make(map[string]interface{})
+	newSlug := existing.Slug
 
 	if req.Title != nil {
 		updates["title"] = *req.Title
 		// Update slug if title changed
-		updates["slug"] = slug.Make(*req.Title)
+		newSlug = slug.Make(*req.Title)
+		updates["slug"] = newSlug
 	}
 
 	if req.Excerpt != nil {
@@ -173,10 +198,6 @@ func (s *blogService) Update(id uint, req *blog.UpdateBlogRequest) (*blog.Blog,
 		updates["category_id"] = *req.CategoryID
 	}
 
-	if req.Tags != nil {
-		updates["tags"] = *req.Tags
-	}
-
 	if req.AuthorID != nil {
 		updates["author_id"] = *req.AuthorID
 	}
@@ -205,13 +226,37 @@ func (s *blogService) Update(id uint, req *blog.UpdateBlogRequest) (*blog.Blog,
 		updates["metadata"] = *req.Metadata
 	}
 
-	// Update blog
-	if err := s.repo.Update(id, updates); err != nil {
+	// Update blog only if nobody saved it since the client read it
+	err = s.tx.Transaction(func(tx *repository.Tx) error {
+		var tags []tag.Tag
+		if req.Tags != nil {
+			tags, err = resolveTags(tx.Tags, tag.Split(*req.Tags))
+			if err != nil {
+				return err
+			}
+			updates["tags"] = tag.Join(tag.Names(tags))
+		}
+		if err := tx.Blogs.Update(id, *req.Version, updates); err != nil {
+			return err
+		}
+		if req.Tags == nil {
+			return nil
+		}
+		return setContentTags(tx.Tags, tag.ContentBlog, id, tags)
+	})
+	if err != nil {
 		return nil, err
 	}
 
+	// Old links keep working through a redirect
+	if newSlug != existing.Slug {
+		recordSlugChange(s.redirects, redirect.EntityBlog, id, existing.Slug, newSlug)
+		cache.Delete(fmt.Sprintf("blog:slug:%s", existing.Slug))
+	}
+
 	// Invalidate caches
 	cache.DeletePattern("blogs:*")
+	invalidateSEOCache(seo.SectionBlogs)
 	cache.Delete(fmt.Sprintf("blog:id:%d", id))
 
 	// Fetch and return updated blog
@@ -231,6 +276,7 @@ func (s *blogService) Delete(id uint) error {
 
 	// Invalidate caches
 	cache.DeletePattern("blogs:*")
+	invalidateSEOCache(seo.SectionBlogs)
 	cache.Delete(fmt.Sprintf("blog:id:%d", id))
 
 	return nil
@@ -257,6 +303,7 @@ func (s *blogService) SubmitForReview(id uint) (*blog.Blog, error) {
 
 	// Invalidate caches
 	cache.DeletePattern("blogs:*")
+	invalidateSEOCache(seo.SectionBlogs)
 	cache.Delete(fmt.Sprintf("blog:id:%d", id))
 
 	// Fetch and return updated blog
@@ -281,6 +328,7 @@ func (s *blogService) Publish(id uint) (*blog.Blog, error) {
 
 	// Invalidate caches
 	cache.DeletePattern("blogs:*")
+	invalidateSEOCache(seo.SectionBlogs)
 	cache.Delete(fmt.Sprintf("blog:id:%d", id))
 
 	// Fetch and return updated blog
@@ -305,6 +353,7 @@ func (s *blogService) Unpublish(id uint) (*blog.Blog, error) {
 
 	// Invalidate caches
 	cache.DeletePattern("blogs:*")
+	invalidateSEOCache(seo.SectionBlogs)
 	cache.Delete(fmt.Sprintf("blog:id:%d", id))
 
 	// Fetch and return updated blog
@@ -324,6 +373,7 @@ func (s *blogService) SoftDelete(id uint) error {
 
 	// Invalidate caches
 	cache.DeletePattern("blogs:*")
+	invalidateSEOCache(seo.SectionBlogs)
 	cache.Delete(fmt.Sprintf("blog:id:%d", id))
 
 	return nil
@@ -336,6 +386,7 @@ func (s *blogService) Restore(id uint) error {
 
 	// Invalidate caches
 	cache.DeletePattern("blogs:*")
+	invalidateSEOCache(seo.SectionBlogs)
 
 	return nil
 }
@@ -364,6 +415,7 @@ func (s *blogService) SchedulePublish(id uint, publishDate time.Time) (*blog.Blo
 
 	// Invalidate caches
 	cache.DeletePattern("blogs:*")
+	invalidateSEOCache(seo.SectionBlogs)
 	cache.Delete(fmt.Sprintf("blog:id:%d", id))
 
 	return s.repo.GetByID(id)
@@ -383,6 +435,7 @@ func (s *blogService) CancelScheduledPublish(id uint) (*blog.Blog, error) {
 
 	// Invalidate caches
 	cache.DeletePattern("blogs:*")
+	invalidateSEOCache(seo.SectionBlogs)
 	cache.Delete(fmt.Sprintf("blog:id:%d", id))
 
 	return s.repo.GetByID(id)
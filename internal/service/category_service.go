@@ -1,25 +1,51 @@
 package service
 
 import (
+	"errors"
 	"fmt"
+	"mime/multipart"
+	"strings"
 	"time"
 
+	"github.com/gosimple/slug"
 	"github.com/healthcare-market-research/backend/internal/cache"
 	"github.com/healthcare-market-research/backend/internal/domain/category"
+	"github.com/healthcare-market-research/backend/internal/domain/media"
+	"github.com/healthcare-market-research/backend/internal/domain/redirect"
 	"github.com/healthcare-market-research/backend/internal/repository"
+	"github.com/healthcare-market-research/backend/internal/storage"
+)
+
+var (
+	ErrCategoryNotFound    = errors.New("category not found")
+	ErrCategorySlugTaken   = errors.New("category slug already in use")
+	ErrCategoryCycle       = errors.New("category cannot be moved beneath itself or one of its descendants")
+	ErrCategoryHasChildren = errors.New("category has active subcategories")
+	ErrInvalidCategoryData = errors.New("invalid category data")
+	ErrCategoryHasNoImage  = errors.New("category has no image to delete")
 )
 
 type CategoryService interface {
 	GetAll(page, limit int) ([]category.Category, int64, error)
+	GetTree() ([]category.Category, error)
 	GetBySlug(slug string) (*category.Category, error)
+	GetByID(id uint) (*category.Category, error)
+	Create(req *category.CreateCategoryRequest) (*category.Category, error)
+	Update(id uint, req *category.UpdateCategoryRequest) (*category.Category, error)
+	Deactivate(id uint) (*category.Category, error)
+	Reorder(items []category.ReorderItem) error
+	UploadImage(id uint, file *multipart.FileHeader) (*category.Category, error)
+	DeleteImage(id uint) (*category.Category, error)
 }
 
 type categoryService struct {
-	repo repository.CategoryRepository
+	repo         repository.CategoryRepository
+	redirects    repository.RedirectRepository
+	imageStorage storage.ImageStorage
 }
 
-func NewCategoryService(repo repository.CategoryRepository) CategoryService {
-	return &categoryService{repo: repo}
+func NewCategoryService(repo repository.CategoryRepository, redirects repository.RedirectRepository, imageStorage storage.ImageStorage) CategoryService {
+	return &categoryService{repo: repo, redirects: redirects, imageStorage: imageStorage}
 }
 
 func (s *categoryService) GetAll(page, limit int) ([]category.Category, int64, error) {
@@ -53,6 +79,27 @@ func (s *categoryService) GetAll(page, limit int) ([]category.Category, int64, e
 	return res.Categories, res.Total, nil
 }
 
+// GetTree returns all active categories nested under their parents
+func (s *categoryService) GetTree() ([]category.Category, error) {
+	cacheKey := "categories:tree"
+
+	var tree []category.Category
+
+	err := cache.GetOrSet(cacheKey, &tree, 10*time.Minute, func() (interface{}, error) {
+		categories, err := s.repo.GetAllActive()
+		if err != nil {
+			return nil, err
+		}
+		return category.BuildTree(categories), nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tree, nil
+}
+
 func (s *categoryService) GetBySlug(slug string) (*category.Category, error) {
 	cacheKey := fmt.Sprintf("category:slug:%s", slug)
 
@@ -60,12 +107,366 @@ func (s *categoryService) GetBySlug(slug string) (*category.Category, error) {
 
 	// Use singleflight-protected cache-aside pattern
 	err := cache.GetOrSet(cacheKey, &cat, 30*time.Minute, func() (interface{}, error) {
-		return s.repo.GetBySlug(slug)
+		c, err := s.repo.GetBySlug(slug)
+		if err != nil {
+			return nil, err
+		}
+
+		// Include direct subcategories so callers can render the next level
+		children, err := s.repo.GetChildren(c.ID)
+		if err != nil {
+			return nil, err
+		}
+		c.Children = children
+
+		return c, nil
 	})
 
 	if err != nil {
-		return nil, err
+		// A retired slug answers with where the category lives now
+		return nil, slugMoved(s.redirects, redirect.EntityCategory, slug, err)
 	}
 
 	return &cat, nil
 }
+
+func (s *categoryService) GetByID(id uint) (*category.Category, error) {
+	cat, err := s.repo.GetByIDIncludingInactive(id)
+	if err != nil {
+		return nil, ErrCategoryNotFound
+	}
+	return cat, nil
+}
+
+func (s *categoryService) Create(req *category.CreateCategoryRequest) (*category.Category, error) {
+	name := strings.TrimSpace(req.Name)
+	if len(name) < 2 {
+		return nil, fmt.Errorf("%w: name must be at least 2 characters", ErrInvalidCategoryData)
+	}
+
+	catSlug := strings.TrimSpace(req.Slug)
+	if catSlug == "" {
+		catSlug = slug.Make(name)
+	} else {
+		catSlug = slug.Make(catSlug)
+	}
+
+	taken, err := s.repo.SlugExists(catSlug, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check slug availability: %w", err)
+	}
+	if taken {
+		return nil, ErrCategorySlugTaken
+	}
+
+	if req.ParentID != nil {
+		if _, err := s.repo.GetByID(*req.ParentID); err != nil {
+			return nil, fmt.Errorf("%w: parent category %d does not exist", ErrInvalidCategoryData, *req.ParentID)
+		}
+	}
+
+	cat := &category.Category{
+		ParentID:    req.ParentID,
+		Name:        name,
+		Slug:        catSlug,
+		Description: req.Description,
+		ImageURL:    req.ImageURL,
+		SortOrder:   req.SortOrder,
+		IsActive:    true,
+	}
+
+	if err := s.repo.Create(cat); err != nil {
+		return nil, err
+	}
+
+	s.invalidateCaches()
+
+	return cat, nil
+}
+
+func (s *categoryService) Update(id uint, req *category.UpdateCategoryRequest) (*category.Category, error) {
+	cat, err := s.repo.GetByIDIncludingInactive(id)
+	if err != nil {
+		return nil, ErrCategoryNotFound
+	}
+	oldSlug := cat.Slug
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if len(name) < 2 {
+			return nil, fmt.Errorf("%w: name must be at least 2 characters", ErrInvalidCategoryData)
+		}
+		cat.Name = name
+	}
+
+	if req.Slug != nil {
+		newSlug := slug.Make(*req.Slug)
+		if newSlug == "" {
+			return nil, fmt.Errorf("%w: slug cannot be empty", ErrInvalidCategoryData)
+		}
+		if newSlug != cat.Slug {
+			taken, err := s.repo.SlugExists(newSlug, id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check slug availability: %w", err)
+			}
+			if taken {
+				return nil, ErrCategorySlugTaken
+			}
+			cat.Slug = newSlug
+		}
+	}
+
+	if req.Description != nil {
+		cat.Description = *req.Description
+	}
+
+	// A linked image replaces an uploaded one, whose files are removed once
+	// the category no longer points at them
+	var replacedImage string
+	var replacedVariants media.Variants
+	if req.ImageURL != nil && *req.ImageURL != cat.ImageURL {
+		if cat.ImageVariants != nil {
+			replacedImage, replacedVariants = cat.ImageURL, cat.ImageVariants
+		}
+		cat.ImageURL = *req.ImageURL
+		cat.ImageVariants = nil
+	}
+
+	if req.SortOrder != nil {
+		cat.SortOrder = *req.SortOrder
+	}
+
+	if req.ClearParent {
+		cat.ParentID = nil
+	} else if req.ParentID != nil {
+		if err := s.validateParent(id, *req.ParentID); err != nil {
+			return nil, err
+		}
+		cat.ParentID = req.ParentID
+	}
+
+	if req.IsActive != nil {
+		if !*req.IsActive && cat.IsActive {
+			if err := s.ensureNoActiveChildren(id); err != nil {
+				return nil, err
+			}
+		}
+		cat.IsActive = *req.IsActive
+	}
+
+	if err := s.repo.Update(cat); err != nil {
+		return nil, err
+	}
+
+	// Old links keep working through a redirect
+	recordSlugChange(s.redirects, redirect.EntityCategory, id, oldSlug, cat.Slug)
+
+	if replacedImage != "" {
+		deleteImageWithVariants(s.imageStorage, replacedImage, replacedVariants)
+	}
+
+	s.invalidateCaches()
+	cache.Delete(fmt.Sprintf("category:slug:%s", oldSlug))
+
+	return cat, nil
+}
+
+// UploadImage stores a category image and its variants, replacing the
+// current image
+func (s *categoryService) UploadImage(id uint, file *multipart.FileHeader) (*category.Category, error) {
+	cat, err := s.repo.GetByIDIncludingInactive(id)
+	if err != nil {
+		return nil, ErrCategoryNotFound
+	}
+
+	metadata := map[string]string{
+		"category_id": fmt.Sprintf("%d", id),
+		"type":        "category_image",
+	}
+	imageURL, variants, err := uploadImageWithVariants(s.imageStorage, file, metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload image: %w", err)
+	}
+
+	oldURL, oldVariants := cat.ImageURL, cat.ImageVariants
+	cat.ImageURL = imageURL
+	cat.ImageVariants = variants
+	if err := s.repo.Update(cat); err != nil {
+		deleteImageWithVariants(s.imageStorage, imageURL, variants)
+		return nil, fmt.Errorf("failed to update category: %w", err)
+	}
+
+	// Only uploaded images have variants; linked ones are not ours to delete
+	if oldVariants != nil {
+		deleteImageWithVariants(s.imageStorage, oldURL, oldVariants)
+	}
+
+	s.invalidateCaches()
+
+	return cat, nil
+}
+
+// DeleteImage clears the category image, deleting it from storage if it was uploaded
+func (s *categoryService) DeleteImage(id uint) (*category.Category, error) {
+	cat, err := s.repo.GetByIDIncludingInactive(id)
+	if err != nil {
+		return nil, ErrCategoryNotFound
+	}
+	if cat.ImageURL == "" {
+		return nil, ErrCategoryHasNoImage
+	}
+
+	oldURL, oldVariants := cat.ImageURL, cat.ImageVariants
+	cat.ImageURL = ""
+	cat.ImageVariants = nil
+	if err := s.repo.Update(cat); err != nil {
+		return nil, err
+	}
+
+	if oldVariants != nil {
+		deleteImageWithVariants(s.imageStorage, oldURL, oldVariants)
+	}
+
+	s.invalidateCaches()
+
+	return cat, nil
+}
+
+func (s *categoryService) Deactivate(id uint) (*category.Category, error) {
+	cat, err := s.repo.GetByIDIncludingInactive(id)
+	if err != nil {
+		return nil, ErrCategoryNotFound
+	}
+
+	if err := s.ensureNoActiveChildren(id); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Deactivate(id); err != nil {
+		return nil, err
+	}
+	cat.IsActive = false
+
+	s.invalidateCaches()
+
+	return cat, nil
+}
+
+func (s *categoryService) Reorder(items []category.ReorderItem) error {
+	if len(items) == 0 {
+		return fmt.Errorf("%w: at least one item is required", ErrInvalidCategoryData)
+	}
+
+	all, err := s.repo.GetAllIncludingInactive()
+	if err != nil {
+		return fmt.Errorf("failed to load categories: %w", err)
+	}
+
+	// parents is the hierarchy as it will be once every item has moved, so a
+	// cycle formed by several items together is caught too. Inactive
+	// categories keep their parent_id, so they are part of it
+	parents := make(map[uint]*uint, len(all))
+	for _, c := range all {
+		parents[c.ID] = c.ParentID
+	}
+
+	seen := make(map[uint]bool, len(items))
+	for _, item := range items {
+		if seen[item.ID] {
+			return fmt.Errorf("%w: category %d is listed more than once", ErrInvalidCategoryData, item.ID)
+		}
+		seen[item.ID] = true
+
+		cat, err := s.repo.GetByIDIncludingInactive(item.ID)
+		if err != nil {
+			return fmt.Errorf("%w: category %d does not exist", ErrCategoryNotFound, item.ID)
+		}
+		parents[item.ID] = cat.ParentID
+
+		switch {
+		case item.ParentID != nil && item.ClearParent:
+			return fmt.Errorf("%w: category %d sets both parent_id and clear_parent", ErrInvalidCategoryData, item.ID)
+		case item.ClearParent:
+			parents[item.ID] = nil
+		case item.ParentID != nil:
+			if *item.ParentID == item.ID {
+				return ErrCategoryCycle
+			}
+			if _, err := s.repo.GetByID(*item.ParentID); err != nil {
+				return fmt.Errorf("%w: parent category %d does not exist", ErrInvalidCategoryData, *item.ParentID)
+			}
+			parents[item.ID] = item.ParentID
+		}
+	}
+
+	for _, item := range items {
+		if item.ParentID != nil && hasCycle(parents, item.ID) {
+			return ErrCategoryCycle
+		}
+	}
+
+	if err := s.repo.Reorder(items); err != nil {
+		return err
+	}
+
+	s.invalidateCaches()
+
+	return nil
+}
+
+// hasCycle reports whether following parents up from id comes back to a
+// category already passed
+func hasCycle(parents map[uint]*uint, id uint) bool {
+	visited := map[uint]bool{id: true}
+	for parent := parents[id]; parent != nil; parent = parents[*parent] {
+		if visited[*parent] {
+			return true
+		}
+		visited[*parent] = true
+	}
+	return false
+}
+
+// validateParent makes sure parentID exists and is not the category itself or one of its descendants
+func (s *categoryService) validateParent(id, parentID uint) error {
+	if parentID == id {
+		return ErrCategoryCycle
+	}
+
+	if _, err := s.repo.GetByID(parentID); err != nil {
+		return fmt.Errorf("%w: parent category %d does not exist", ErrInvalidCategoryData, parentID)
+	}
+
+	// an inactive category still links its children to the tree, so the walk
+	// has to pass through it
+	descendants, err := s.repo.GetDescendantIDsIncludingInactive(id)
+	if err != nil {
+		return fmt.Errorf("failed to load subcategories: %w", err)
+	}
+	for _, d := range descendants {
+		if d == parentID {
+			return ErrCategoryCycle
+		}
+	}
+
+	return nil
+}
+
+func (s *categoryService) ensureNoActiveChildren(id uint) error {
+	children, err := s.repo.GetChildren(id)
+	if err != nil {
+		return fmt.Errorf("failed to load subcategories: %w", err)
+	}
+	if len(children) > 0 {
+		return ErrCategoryHasChildren
+	}
+	return nil
+}
+
+func (s *categoryService) invalidateCaches() {
+	cache.DeletePattern("categories:*")
+	cache.DeletePattern("category:*")
+	cache.DeletePattern("reports:category:*")
+	// Category names and visibility show up in every sitemap and feed
+	cache.DeletePattern("seo:*")
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/healthcare-market-research/backend/internal/domain/category"
+	"github.com/healthcare-market-research/backend/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// Mock CategoryRepository for testing
+type mockCategoryRepository struct {
+	repository.CategoryRepository
+	categories []category.Category
+	reordered  []category.ReorderItem
+}
+
+func (m *mockCategoryRepository) GetAllActive() ([]category.Category, error) {
+	var active []category.Category
+	for _, c := range m.categories {
+		if c.IsActive {
+			active = append(active, c)
+		}
+	}
+	return active, nil
+}
+
+func (m *mockCategoryRepository) GetAllIncludingInactive() ([]category.Category, error) {
+	return m.categories, nil
+}
+
+func (m *mockCategoryRepository) GetByID(id uint) (*category.Category, error) {
+	c, err := m.GetByIDIncludingInactive(id)
+	if err != nil || !c.IsActive {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return c, nil
+}
+
+func (m *mockCategoryRepository) GetByIDIncludingInactive(id uint) (*category.Category, error) {
+	for i := range m.categories {
+		if m.categories[i].ID == id {
+			c := m.categories[i]
+			return &c, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *mockCategoryRepository) GetDescendantIDsIncludingInactive(id uint) ([]uint, error) {
+	var ids []uint
+	for queue := []uint{id}; len(queue) > 0; queue = queue[1:] {
+		for _, c := range m.categories {
+			if c.ParentID != nil && *c.ParentID == queue[0] {
+				ids = append(ids, c.ID)
+				queue = append(queue, c.ID)
+			}
+		}
+	}
+	return ids, nil
+}
+
+func (m *mockCategoryRepository) Reorder(items []category.ReorderItem) error {
+	m.reordered = items
+	return nil
+}
+
+func uintPtr(v uint) *uint {
+	return &v
+}
+
+// newCategoryTestService has roots 1 and 2, with 3 under 1 and 4 under 3
+func newCategoryTestService() (CategoryService, *mockCategoryRepository) {
+	repo := &mockCategoryRepository{categories: []category.Category{
+		{ID: 1, Name: "Medical Devices", IsActive: true},
+		{ID: 2, Name: "Pharmaceuticals", IsActive: true},
+		{ID: 3, Name: "Cardiology", ParentID: uintPtr(1), IsActive: true},
+		{ID: 4, Name: "Stents", ParentID: uintPtr(3), IsActive: true},
+	}}
+	return NewCategoryService(repo, nil, nil), repo
+}
+
+func TestCategoryService_Reorder(t *testing.T) {
+	t.Run("Move a category under another parent", func(t *testing.T) {
+		s, repo := newCategoryTestService()
+
+		require.NoError(t, s.Reorder([]category.ReorderItem{{ID: 3, ParentID: uintPtr(2), SortOrder: 1}}))
+		assert.Len(t, repo.reordered, 1)
+	})
+
+	t.Run("Reject a category under its own descendant", func(t *testing.T) {
+		s, repo := newCategoryTestService()
+
+		err := s.Reorder([]category.ReorderItem{{ID: 1, ParentID: uintPtr(4)}})
+		assert.ErrorIs(t, err, ErrCategoryCycle)
+		assert.Nil(t, repo.reordered)
+	})
+
+	t.Run("Reject a cycle the items only form together", func(t *testing.T) {
+		s, repo := newCategoryTestService()
+
+		err := s.Reorder([]category.ReorderItem{
+			{ID: 1, ParentID: uintPtr(2)},
+			{ID: 2, ParentID: uintPtr(1)},
+		})
+		assert.ErrorIs(t, err, ErrCategoryCycle)
+		assert.Nil(t, repo.reordered)
+	})
+
+	t.Run("Allow a move that undoes an old nesting in the same batch", func(t *testing.T) {
+		s, _ := newCategoryTestService()
+
+		// 3 leaves 1 first, so 1 can go under 3
+		err := s.Reorder([]category.ReorderItem{
+			{ID: 3, ClearParent: true},
+			{ID: 1, ParentID: uintPtr(3)},
+		})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Reject parent_id together with clear_parent", func(t *testing.T) {
+		s, _ := newCategoryTestService()
+
+		err := s.Reorder([]category.ReorderItem{{ID: 3, ParentID: uintPtr(2), ClearParent: true}})
+		assert.ErrorIs(t, err, ErrInvalidCategoryData)
+	})
+}
+
+// newInactiveMiddleTestService has 1 -> 2 (inactive) -> 3
+func newInactiveMiddleTestService() (CategoryService, *mockCategoryRepository) {
+	repo := &mockCategoryRepository{categories: []category.Category{
+		{ID: 1, Name: "Medical Devices", IsActive: true},
+		{ID: 2, Name: "Cardiology", ParentID: uintPtr(1)},
+		{ID: 3, Name: "Stents", ParentID: uintPtr(2), IsActive: true},
+	}}
+	return NewCategoryService(repo, nil, nil), repo
+}
+
+func TestCategoryService_Update_RejectsCycleThroughInactiveCategory(t *testing.T) {
+	s, _ := newInactiveMiddleTestService()
+
+	_, err := s.Update(1, &category.UpdateCategoryRequest{ParentID: uintPtr(3)})
+	assert.ErrorIs(t, err, ErrCategoryCycle)
+}
+
+func TestCategoryService_Reorder_RejectsCycleThroughInactiveCategory(t *testing.T) {
+	s, repo := newInactiveMiddleTestService()
+
+	err := s.Reorder([]category.ReorderItem{{ID: 1, ParentID: uintPtr(3)}})
+	assert.ErrorIs(t, err, ErrCategoryCycle)
+	assert.Nil(t, repo.reordered)
+}
+
+func TestReorderItem_MovesParent(t *testing.T) {
+	assert.False(t, category.ReorderItem{ID: 3, SortOrder: 2}.MovesParent())
+	assert.True(t, category.ReorderItem{ID: 3, ParentID: uintPtr(2)}.MovesParent())
+	assert.True(t, category.ReorderItem{ID: 3, ClearParent: true}.MovesParent())
+}
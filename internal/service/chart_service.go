@@ -0,0 +1,231 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/healthcare-market-research/backend/internal/cache"
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrChartNotFound       = errors.New("chart not found")
+	ErrChartReportNotFound = errors.New("report not found")
+	ErrInvalidChartType    = fmt.Errorf("chart type must be one of: %s", strings.Join(report.ChartTypes, ", "))
+	ErrInvalidChartImage   = errors.New("chart image must be an image of the same report")
+	ErrInvalidChartData    = errors.New("invalid chart data")
+)
+
+// ChartService manages the charts of a report and their data series
+type ChartService interface {
+	List(reportID uint) ([]report.ChartMetadata, error)
+	Get(reportID, chartID uint) (*report.ChartMetadata, error)
+	Create(reportID uint, req *report.CreateChartRequest) (*report.ChartMetadata, error)
+	Update(reportID, chartID uint, req *report.UpdateChartRequest) (*report.ChartMetadata, error)
+	Delete(reportID, chartID uint) (*report.ChartMetadata, error)
+}
+
+type chartService struct {
+	chartRepo       repository.ChartRepository
+	reportRepo      repository.ReportRepository
+	reportImageRepo repository.ReportImageRepository
+}
+
+func NewChartService(
+	chartRepo repository.ChartRepository,
+	reportRepo repository.ReportRepository,
+	reportImageRepo repository.ReportImageRepository,
+) ChartService {
+	return &chartService{
+		chartRepo:       chartRepo,
+		reportRepo:      reportRepo,
+		reportImageRepo: reportImageRepo,
+	}
+}
+
+func (s *chartService) List(reportID uint) ([]report.ChartMetadata, error) {
+	if _, err := s.getReport(reportID); err != nil {
+		return nil, err
+	}
+	return s.chartRepo.FindByReportID(reportID)
+}
+
+func (s *chartService) Get(reportID, chartID uint) (*report.ChartMetadata, error) {
+	chart, err := s.chartRepo.FindByID(chartID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrChartNotFound
+		}
+		return nil, err
+	}
+	// Charts are only reachable through their own report
+	if chart.ReportID != reportID {
+		return nil, ErrChartNotFound
+	}
+	return chart, nil
+}
+
+func (s *chartService) Create(reportID uint, req *report.CreateChartRequest) (*report.ChartMetadata, error) {
+	rep, err := s.getReport(reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !report.IsValidChartType(req.ChartType) {
+		return nil, ErrInvalidChartType
+	}
+	if err := s.checkData(req.Data); err != nil {
+		return nil, err
+	}
+	if err := s.checkImage(reportID, req.ImageID); err != nil {
+		return nil, err
+	}
+
+	chart := &report.ChartMetadata{
+		ReportID:    reportID,
+		Title:       strings.TrimSpace(req.Title),
+		ChartType:   req.ChartType,
+		Description: req.Description,
+		Data:        req.Data,
+		ImageID:     req.ImageID,
+		IsActive:    true,
+	}
+	if req.Data != nil {
+		chart.DataPoints = req.Data.Points()
+	}
+	if req.IsActive != nil {
+		chart.IsActive = *req.IsActive
+	}
+	if req.Order != nil {
+		chart.Order = *req.Order
+	} else {
+		chart.Order, err = s.chartRepo.NextOrder(reportID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.chartRepo.Create(chart); err != nil {
+		return nil, fmt.Errorf("failed to create chart: %w", err)
+	}
+
+	invalidateReportCharts(rep)
+	return s.chartRepo.FindByID(chart.ID)
+}
+
+func (s *chartService) Update(reportID, chartID uint, req *report.UpdateChartRequest) (*report.ChartMetadata, error) {
+	rep, err := s.getReport(reportID)
+	if err != nil {
+		return nil, err
+	}
+	chart, err := s.Get(reportID, chartID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Title != nil {
+		chart.Title = strings.TrimSpace(*req.Title)
+	}
+	if req.ChartType != nil {
+		if !report.IsValidChartType(*req.ChartType) {
+			return nil, ErrInvalidChartType
+		}
+		chart.ChartType = *req.ChartType
+	}
+	if req.Description != nil {
+		chart.Description = *req.Description
+	}
+	if req.Data != nil {
+		if err := s.checkData(req.Data); err != nil {
+			return nil, err
+		}
+		chart.Data = req.Data
+		chart.DataPoints = req.Data.Points()
+	}
+	if req.ClearImage {
+		chart.ImageID = nil
+	} else if req.ImageID != nil {
+		if err := s.checkImage(reportID, req.ImageID); err != nil {
+			return nil, err
+		}
+		chart.ImageID = req.ImageID
+	}
+	if req.Order != nil {
+		chart.Order = *req.Order
+	}
+	if req.IsActive != nil {
+		chart.IsActive = *req.IsActive
+	}
+
+	if err := s.chartRepo.Update(chart); err != nil {
+		return nil, fmt.Errorf("failed to update chart: %w", err)
+	}
+
+	invalidateReportCharts(rep)
+	return s.chartRepo.FindByID(chart.ID)
+}
+
+func (s *chartService) Delete(reportID, chartID uint) (*report.ChartMetadata, error) {
+	rep, err := s.getReport(reportID)
+	if err != nil {
+		return nil, err
+	}
+	chart, err := s.Get(reportID, chartID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.chartRepo.Delete(chart.ID); err != nil {
+		return nil, fmt.Errorf("failed to delete chart: %w", err)
+	}
+
+	invalidateReportCharts(rep)
+	return chart, nil
+}
+
+func (s *chartService) getReport(reportID uint) (*report.Report, error) {
+	rep, err := s.reportRepo.GetByID(reportID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrChartReportNotFound
+		}
+		return nil, err
+	}
+	return rep, nil
+}
+
+func (s *chartService) checkData(data *report.ChartData) error {
+	if data == nil {
+		return nil
+	}
+	if err := data.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidChartData, err)
+	}
+	return nil
+}
+
+// checkImage makes sure a fallback image belongs to the chart's report
+func (s *chartService) checkImage(reportID uint, imageID *uint) error {
+	if imageID == nil {
+		return nil
+	}
+	image, err := s.reportImageRepo.FindByID(*imageID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidChartImage
+		}
+		return err
+	}
+	if image.ReportID != reportID {
+		return ErrInvalidChartImage
+	}
+	return nil
+}
+
+// invalidateReportCharts drops the cached report, which embeds its charts
+func invalidateReportCharts(rep *report.Report) {
+	cache.Delete(fmt.Sprintf("report:slug:%s", rep.Slug))
+}
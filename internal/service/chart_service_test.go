@@ -0,0 +1,127 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// Mock ChartRepository for testing
+type mockChartRepository struct {
+	charts  []report.ChartMetadata
+	created []report.ChartMetadata
+}
+
+func (m *mockChartRepository) FindByReportID(reportID uint) ([]report.ChartMetadata, error) {
+	var charts []report.ChartMetadata
+	for _, c := range m.charts {
+		if c.ReportID == reportID {
+			charts = append(charts, c)
+		}
+	}
+	return charts, nil
+}
+
+func (m *mockChartRepository) FindByID(id uint) (*report.ChartMetadata, error) {
+	for i := range m.charts {
+		if m.charts[i].ID == id {
+			c := m.charts[i]
+			return &c, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *mockChartRepository) Create(chart *report.ChartMetadata) error {
+	m.created = append(m.created, *chart)
+	return nil
+}
+
+func (m *mockChartRepository) Update(chart *report.ChartMetadata) error {
+	return nil
+}
+
+func (m *mockChartRepository) Delete(id uint) error {
+	return nil
+}
+
+func (m *mockChartRepository) NextOrder(reportID uint) (int, error) {
+	return 0, nil
+}
+
+func newChartTestService() (*chartService, *mockChartRepository) {
+	repo := &mockChartRepository{
+		charts: []report.ChartMetadata{
+			{ID: 1, ReportID: 4, Title: "Market size", ChartType: report.ChartBar},
+			{ID: 2, ReportID: 5, Title: "Share", ChartType: report.ChartPie},
+		},
+	}
+	images := &mockReportImageRepository{
+		findByIDFunc: func(id uint) (*report.ReportImage, error) {
+			if id == 30 {
+				return &report.ReportImage{ID: 30, ReportID: 5}, nil
+			}
+			return nil, gorm.ErrRecordNotFound
+		},
+	}
+	reports := &mockReportRepository{
+		getByIDFunc: func(id uint) (*report.Report, error) {
+			if id == 99 {
+				return nil, gorm.ErrRecordNotFound
+			}
+			return &report.Report{ID: id, Slug: "test-report"}, nil
+		},
+	}
+	return NewChartService(repo, reports, images).(*chartService), repo
+}
+
+func TestChartService_Get_OtherReport(t *testing.T) {
+	s, _ := newChartTestService()
+
+	chart, err := s.Get(4, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Market size", chart.Title)
+
+	// Charts are only reachable through their own report
+	_, err = s.Get(4, 2)
+	assert.ErrorIs(t, err, ErrChartNotFound)
+}
+
+func TestChartService_Create_Rules(t *testing.T) {
+	s, repo := newChartTestService()
+	imageID := uint(30)
+	missingImageID := uint(31)
+
+	_, err := s.Create(99, &report.CreateChartRequest{Title: "Size", ChartType: report.ChartBar})
+	assert.ErrorIs(t, err, ErrChartReportNotFound)
+
+	_, err = s.Create(4, &report.CreateChartRequest{Title: "Size", ChartType: "radar"})
+	assert.ErrorIs(t, err, ErrInvalidChartType)
+
+	_, err = s.Create(4, &report.CreateChartRequest{Title: "Size", ChartType: report.ChartBar, Data: &report.ChartData{}})
+	assert.ErrorIs(t, err, ErrInvalidChartData)
+
+	_, err = s.Create(4, &report.CreateChartRequest{Title: "Size", ChartType: report.ChartBar, ImageID: &imageID})
+	assert.ErrorIs(t, err, ErrInvalidChartImage)
+
+	_, err = s.Create(4, &report.CreateChartRequest{Title: "Size", ChartType: report.ChartBar, ImageID: &missingImageID})
+	assert.ErrorIs(t, err, ErrInvalidChartImage)
+
+	assert.Empty(t, repo.created)
+}
+
+func TestChartService_Update_Rules(t *testing.T) {
+	s, _ := newChartTestService()
+	radar := "radar"
+
+	_, err := s.Update(4, 2, &report.UpdateChartRequest{})
+	assert.ErrorIs(t, err, ErrChartNotFound)
+
+	_, err = s.Update(4, 1, &report.UpdateChartRequest{ChartType: &radar})
+	assert.ErrorIs(t, err, ErrInvalidChartType)
+
+	_, err = s.Update(4, 1, &report.UpdateChartRequest{Data: &report.ChartData{Labels: []string{"2024"}}})
+	assert.ErrorIs(t, err, ErrInvalidChartData)
+}
@@ -1,239 +0,0 @@
-package service
-
-import (
-	"bytes"
-	"io"
-	"mime/multipart"
-	"net/http"
-	"net/http/httptest"
-	"strings"
-	"testing"
-
-	"github.com/healthcare-market-research/backend/internal/config"
-)
-
-func TestCloudflareImagesService_ExtractImageID(t *testing.T) {
-	cfg := &config.CloudflareConfig{
-		AccountID:   "test-account",
-		APIToken:    "test-token",
-		DeliveryURL: "https://imagedelivery.net/test-hash",
-	}
-
-	service := NewCloudflareImagesService(cfg)
-
-	tests := []struct {
-		name      string
-		imageURL  string
-		wantID    string
-		wantError bool
-	}{
-		{
-			name:      "Valid URL",
-			imageURL:  "https://imagedelivery.net/test-hash/2cdc28f0-017a-49c4-9ed7-87056c83901/public",
-			wantID:    "2cdc28f0-017a-49c4-9ed7-87056c83901",
-			wantError: false,
-		},
-		{
-			name:      "Empty URL",
-			imageURL:  "",
-			wantID:    "",
-			wantError: true,
-		},
-		{
-			name:      "Invalid URL - wrong prefix",
-			imageURL:  "https://example.com/image.jpg",
-			wantID:    "",
-			wantError: true,
-		},
-		{
-			name:      "Invalid URL - missing image ID",
-			imageURL:  "https://imagedelivery.net/test-hash/public",
-			wantID:    "",
-			wantError: true,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			gotID, err := service.ExtractImageID(tt.imageURL)
-			if (err != nil) != tt.wantError {
-				t.Errorf("ExtractImageID() error = %v, wantError %v", err, tt.wantError)
-				return
-			}
-			if gotID != tt.wantID {
-				t.Errorf("ExtractImageID() gotID = %v, want %v", gotID, tt.wantID)
-			}
-		})
-	}
-}
-
-func TestCloudflareImagesService_Upload(t *testing.T) {
-	// Create a mock HTTP server
-	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Verify request method and headers
-		if r.Method != "POST" {
-			t.Errorf("Expected POST request, got %s", r.Method)
-		}
-		if !strings.Contains(r.Header.Get("Authorization"), "Bearer test-token") {
-			t.Errorf("Expected Authorization header with Bearer token")
-		}
-
-		// Verify multipart form
-		err := r.ParseMultipartForm(10 << 20)
-		if err != nil {
-			t.Errorf("Failed to parse multipart form: %v", err)
-		}
-
-		// Return mock success response
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte(`{
-			"success": true,
-			"result": {
-				"id": "test-image-id-123",
-				"filename": "test.jpg",
-				"variants": ["https://imagedelivery.net/test-hash/test-image-id-123/public"]
-			}
-		}`))
-	}))
-	defer mockServer.Close()
-
-	// Note: In a real test, we'd use dependency injection to replace the HTTP client
-	// For now, this test demonstrates the structure
-
-	cfg := &config.CloudflareConfig{
-		AccountID:   "test-account",
-		APIToken:    "test-token",
-		DeliveryURL: "https://imagedelivery.net/test-hash",
-	}
-
-	// Create a test file
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
-	part, _ := writer.CreateFormFile("file", "test.jpg")
-	part.Write([]byte("fake image content"))
-	writer.Close()
-
-	// Create multipart.FileHeader
-	req := httptest.NewRequest("POST", "/", body)
-	req.Header.Set("Content-Type", writer.FormDataContentType())
-	_, fileHeader, _ := req.FormFile("file")
-
-	service := NewCloudflareImagesService(cfg)
-
-	// Note: This test will fail in isolation because it tries to connect to real Cloudflare API
-	// In a production environment, we'd use interface-based design and mock the HTTP client
-	t.Log("Upload test requires HTTP client mocking - skipping actual upload")
-	_ = service
-	_ = fileHeader
-}
-
-func TestCloudflareImagesService_Delete(t *testing.T) {
-	// Create a mock HTTP server
-	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Verify request method and headers
-		if r.Method != "DELETE" {
-			t.Errorf("Expected DELETE request, got %s", r.Method)
-		}
-		if !strings.Contains(r.Header.Get("Authorization"), "Bearer test-token") {
-			t.Errorf("Expected Authorization header with Bearer token")
-		}
-
-		// Return mock success response
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte(`{
-			"success": true
-		}`))
-	}))
-	defer mockServer.Close()
-
-	cfg := &config.CloudflareConfig{
-		AccountID:   "test-account",
-		APIToken:    "test-token",
-		DeliveryURL: "https://imagedelivery.net/test-hash",
-	}
-
-	service := NewCloudflareImagesService(cfg)
-
-	// Note: This test will fail in isolation because it tries to connect to real Cloudflare API
-	// In a production environment, we'd use interface-based design and mock the HTTP client
-	t.Log("Delete test requires HTTP client mocking - skipping actual delete")
-	_ = service
-	_ = mockServer
-}
-
-func TestCloudflareImagesService_ExtractImageID_EdgeCases(t *testing.T) {
-	cfg := &config.CloudflareConfig{
-		AccountID:   "test-account",
-		APIToken:    "test-token",
-		DeliveryURL: "https://imagedelivery.net/test-hash",
-	}
-
-	service := NewCloudflareImagesService(cfg)
-
-	tests := []struct {
-		name      string
-		imageURL  string
-		wantError bool
-	}{
-		{
-			name:      "URL with trailing slash",
-			imageURL:  "https://imagedelivery.net/test-hash/image-id-123/public/",
-			wantError: false,
-		},
-		{
-			name:      "URL without /public suffix",
-			imageURL:  "https://imagedelivery.net/test-hash/image-id-123",
-			wantError: false,
-		},
-		{
-			name:      "URL with different variant",
-			imageURL:  "https://imagedelivery.net/test-hash/image-id-123/thumbnail",
-			wantError: false,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			_, err := service.ExtractImageID(tt.imageURL)
-			if (err != nil) != tt.wantError {
-				t.Errorf("ExtractImageID() error = %v, wantError %v", err, tt.wantError)
-			}
-		})
-	}
-}
-
-func TestCloudflareImagesService_Upload_ErrorResponse(t *testing.T) {
-	// Test helper to create a file header
-	createFileHeader := func(filename string, content string) *multipart.FileHeader {
-		body := &bytes.Buffer{}
-		writer := multipart.NewWriter(body)
-		part, _ := writer.CreateFormFile("file", filename)
-		io.WriteString(part, content)
-		writer.Close()
-
-		req := httptest.NewRequest("POST", "/", body)
-		req.Header.Set("Content-Type", writer.FormDataContentType())
-		req.ParseMultipartForm(10 << 20)
-		_, fh, _ := req.FormFile("file")
-		return fh
-	}
-
-	cfg := &config.CloudflareConfig{
-		AccountID:   "test-account",
-		APIToken:    "test-token",
-		DeliveryURL: "https://imagedelivery.net/test-hash",
-	}
-
-	service := NewCloudflareImagesService(cfg)
-
-	// Note: This is a structure test - actual HTTP testing would require mocking
-	fileHeader := createFileHeader("test.jpg", "fake image data")
-	metadata := map[string]string{"author_id": "123"}
-
-	t.Log("Upload error test requires HTTP client mocking - test structure validated")
-	_ = service
-	_ = fileHeader
-	_ = metadata
-}
@@ -0,0 +1,525 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"strings"
+
+	"github.com/gosimple/slug"
+	"github.com/healthcare-market-research/backend/internal/cache"
+	"github.com/healthcare-market-research/backend/internal/domain/company"
+	"github.com/healthcare-market-research/backend/internal/domain/media"
+	"github.com/healthcare-market-research/backend/internal/domain/redirect"
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/repository"
+	"github.com/healthcare-market-research/backend/internal/storage"
+	"github.com/healthcare-market-research/backend/pkg/validation"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrCompanyNotFound    = errors.New("company not found")
+	ErrCompanySlugTaken   = errors.New("company slug already in use")
+	ErrCompanyNameTaken   = errors.New("company name already in use")
+	ErrCompanyInUse       = errors.New("company is a key player in reports")
+	ErrInvalidCompanyData = errors.New("invalid company data")
+	ErrCompanyHasNoLogo   = errors.New("company has no logo to delete")
+	ErrInvalidMerge       = errors.New("invalid company merge")
+)
+
+type CompanyService interface {
+	GetAll(search string, page, limit int) ([]company.Company, int64, error)
+	GetBySlug(slug string) (*company.CompanyDetail, error)
+	GetByID(id uint) (*company.Company, error)
+	Create(req *company.CreateCompanyRequest) (*company.Company, error)
+	Update(id uint, req *company.UpdateCompanyRequest) (*company.Company, error)
+	Delete(id uint) (*company.Company, error)
+	UploadLogo(id uint, file *multipart.FileHeader) (*company.Company, error)
+	DeleteLogo(id uint) (*company.Company, error)
+	Merge(targetID uint, sourceIDs []uint) (*company.MergeResult, error)
+	Import(dryRun bool) (*company.ImportResult, error)
+}
+
+type companyService struct {
+	repo         repository.CompanyRepository
+	redirects    repository.RedirectRepository
+	imageStorage storage.ImageStorage
+}
+
+func NewCompanyService(repo repository.CompanyRepository, redirects repository.RedirectRepository, imageStorage storage.ImageStorage) CompanyService {
+	return &companyService{repo: repo, redirects: redirects, imageStorage: imageStorage}
+}
+
+func (s *companyService) GetAll(search string, page, limit int) ([]company.Company, int64, error) {
+	// The directory is edited alongside reports, so it is not cached
+	return s.repo.GetAll(strings.TrimSpace(search), page, limit)
+}
+
+// GetBySlug returns a company with every published report it appears in
+func (s *companyService) GetBySlug(slug string) (*company.CompanyDetail, error) {
+	c, err := s.repo.GetBySlug(slug)
+	if err != nil {
+		// A retired or merged slug answers with where the company lives now
+		err = slugMoved(s.redirects, redirect.EntityCompany, slug, err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCompanyNotFound
+		}
+		return nil, err
+	}
+
+	reports, err := s.repo.GetReports(c.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load company reports: %w", err)
+	}
+	if reports == nil {
+		reports = []company.CompanyReport{}
+	}
+
+	return &company.CompanyDetail{Company: *c, Reports: reports}, nil
+}
+
+func (s *companyService) GetByID(id uint) (*company.Company, error) {
+	c, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, ErrCompanyNotFound
+	}
+	return c, nil
+}
+
+func (s *companyService) Create(req *company.CreateCompanyRequest) (*company.Company, error) {
+	name := strings.TrimSpace(req.Name)
+	if len(name) < 2 {
+		return nil, fmt.Errorf("%w: name must be at least 2 characters", ErrInvalidCompanyData)
+	}
+
+	companySlug := strings.TrimSpace(req.Slug)
+	if companySlug == "" {
+		companySlug = name
+	}
+	companySlug = slug.Make(companySlug)
+	if companySlug == "" {
+		return nil, fmt.Errorf("%w: slug cannot be empty", ErrInvalidCompanyData)
+	}
+
+	taken, err := s.repo.SlugExists(companySlug, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check slug availability: %w", err)
+	}
+	if taken {
+		return nil, ErrCompanySlugTaken
+	}
+
+	c := &company.Company{
+		Name:        name,
+		Slug:        companySlug,
+		Aliases:     company.CleanAliases(name, req.Aliases),
+		Website:     strings.TrimSpace(req.Website),
+		HQCountry:   strings.ToUpper(strings.TrimSpace(req.HQCountry)),
+		Description: req.Description,
+		LogoURL:     req.LogoURL,
+	}
+	if err := s.validate(c); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (s *companyService) Update(id uint, req *company.UpdateCompanyRequest) (*company.Company, error) {
+	c, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, ErrCompanyNotFound
+	}
+	oldSlug := c.Slug
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if len(name) < 2 {
+			return nil, fmt.Errorf("%w: name must be at least 2 characters", ErrInvalidCompanyData)
+		}
+		c.Name = name
+	}
+
+	if req.Slug != nil {
+		newSlug := slug.Make(*req.Slug)
+		if newSlug == "" {
+			return nil, fmt.Errorf("%w: slug cannot be empty", ErrInvalidCompanyData)
+		}
+		if newSlug != c.Slug {
+			taken, err := s.repo.SlugExists(newSlug, id)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check slug availability: %w", err)
+			}
+			if taken {
+				return nil, ErrCompanySlugTaken
+			}
+			c.Slug = newSlug
+		}
+	}
+
+	aliases := []string(c.Aliases)
+	if req.Aliases != nil {
+		aliases = *req.Aliases
+	}
+	c.Aliases = company.CleanAliases(c.Name, aliases)
+
+	if req.Website != nil {
+		c.Website = strings.TrimSpace(*req.Website)
+	}
+	if req.HQCountry != nil {
+		c.HQCountry = strings.ToUpper(strings.TrimSpace(*req.HQCountry))
+	}
+	if req.Description != nil {
+		c.Description = *req.Description
+	}
+
+	// A linked logo replaces an uploaded one, whose files are removed once
+	// the company no longer points at them
+	var replacedLogo string
+	var replacedVariants media.Variants
+	if req.LogoURL != nil && *req.LogoURL != c.LogoURL {
+		if c.LogoVariants != nil {
+			replacedLogo, replacedVariants = c.LogoURL, c.LogoVariants
+		}
+		c.LogoURL = *req.LogoURL
+		c.LogoVariants = nil
+	}
+
+	if err := s.validate(c); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(c); err != nil {
+		return nil, err
+	}
+
+	// Old links keep working through a redirect
+	recordSlugChange(s.redirects, redirect.EntityCompany, id, oldSlug, c.Slug)
+
+	if replacedLogo != "" {
+		deleteImageWithVariants(s.imageStorage, replacedLogo, replacedVariants)
+	}
+
+	return c, nil
+}
+
+// Delete removes a company no report links to. Duplicates that are in use
+// are merged instead.
+func (s *companyService) Delete(id uint) (*company.Company, error) {
+	c, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, ErrCompanyNotFound
+	}
+
+	count, err := s.repo.CountReferences(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check company references: %w", err)
+	}
+	if count > 0 {
+		return nil, fmt.Errorf("%w: linked from %d report(s); merge it into another company instead", ErrCompanyInUse, count)
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		return nil, err
+	}
+
+	if c.LogoVariants != nil {
+		deleteImageWithVariants(s.imageStorage, c.LogoURL, c.LogoVariants)
+	}
+
+	return c, nil
+}
+
+// UploadLogo stores a company logo and its variants, replacing the current logo
+func (s *companyService) UploadLogo(id uint, file *multipart.FileHeader) (*company.Company, error) {
+	c, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, ErrCompanyNotFound
+	}
+
+	metadata := map[string]string{
+		"company_id": fmt.Sprintf("%d", id),
+		"type":       "company_logo",
+	}
+	logoURL, variants, err := uploadImageWithVariants(s.imageStorage, file, metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload logo: %w", err)
+	}
+
+	oldURL, oldVariants := c.LogoURL, c.LogoVariants
+	c.LogoURL = logoURL
+	c.LogoVariants = variants
+	if err := s.repo.Update(c); err != nil {
+		deleteImageWithVariants(s.imageStorage, logoURL, variants)
+		return nil, fmt.Errorf("failed to update company: %w", err)
+	}
+
+	// Only uploaded logos have variants; linked ones are not ours to delete
+	if oldVariants != nil {
+		deleteImageWithVariants(s.imageStorage, oldURL, oldVariants)
+	}
+
+	return c, nil
+}
+
+// DeleteLogo clears the company logo, deleting it from storage if it was uploaded
+func (s *companyService) DeleteLogo(id uint) (*company.Company, error) {
+	c, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, ErrCompanyNotFound
+	}
+	if c.LogoURL == "" {
+		return nil, ErrCompanyHasNoLogo
+	}
+
+	oldURL, oldVariants := c.LogoURL, c.LogoVariants
+	c.LogoURL = ""
+	c.LogoVariants = nil
+	if err := s.repo.Update(c); err != nil {
+		return nil, err
+	}
+
+	if oldVariants != nil {
+		deleteImageWithVariants(s.imageStorage, oldURL, oldVariants)
+	}
+
+	return c, nil
+}
+
+// Merge folds duplicate companies into the target. The sources' names become
+// aliases of the target, empty fields are filled from the sources in order,
+// reports are relinked and the sources' slugs redirect to the target.
+func (s *companyService) Merge(targetID uint, sourceIDs []uint) (*company.MergeResult, error) {
+	target, err := s.repo.GetByID(targetID)
+	if err != nil {
+		return nil, ErrCompanyNotFound
+	}
+
+	ids := uniqueIDs(sourceIDs)
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%w: at least one source company is required", ErrInvalidMerge)
+	}
+	for _, id := range ids {
+		if id == targetID {
+			return nil, fmt.Errorf("%w: a company cannot be merged into itself", ErrInvalidMerge)
+		}
+	}
+
+	sources, err := s.repo.GetByIDs(ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load source companies: %w", err)
+	}
+	if len(sources) != len(ids) {
+		return nil, fmt.Errorf("%w: source company does not exist", ErrCompanyNotFound)
+	}
+
+	aliases := []string(target.Aliases)
+	var discardedLogos []company.Company
+	for _, src := range sources {
+		aliases = append(aliases, src.Name)
+		aliases = append(aliases, src.Aliases...)
+
+		if target.Website == "" {
+			target.Website = src.Website
+		}
+		if target.HQCountry == "" {
+			target.HQCountry = src.HQCountry
+		}
+		if target.Description == "" {
+			target.Description = src.Description
+		}
+		if target.LogoURL == "" && src.LogoURL != "" {
+			target.LogoURL, target.LogoVariants = src.LogoURL, src.LogoVariants
+		} else if src.LogoVariants != nil {
+			discardedLogos = append(discardedLogos, src)
+		}
+	}
+	target.Aliases = company.CleanAliases(target.Name, aliases)
+	target.NameKeys = target.Keys()
+
+	updated, err := s.repo.Merge(target, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge companies: %w", err)
+	}
+
+	for _, src := range sources {
+		recordSlugChange(s.redirects, redirect.EntityCompany, target.ID, src.Slug, target.Slug)
+	}
+	for _, src := range discardedLogos {
+		deleteImageWithVariants(s.imageStorage, src.LogoURL, src.LogoVariants)
+	}
+
+	if updated > 0 {
+		invalidateKeyPlayerCaches()
+	}
+
+	return &company.MergeResult{Company: target, MergedIDs: ids, ReportsUpdated: updated}, nil
+}
+
+// Import seeds the directory from report key players. Each unlinked player is
+// matched to a company by name or alias, or a new company is created for it.
+// Linked players are left alone, so the import can be run again as reports
+// are added. With dryRun nothing is written.
+func (s *companyService) Import(dryRun bool) (*company.ImportResult, error) {
+	rows, err := s.repo.ListKeyPlayers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load key players: %w", err)
+	}
+	existing, err := s.repo.ListAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load companies: %w", err)
+	}
+
+	byKey := map[string]*company.Company{}
+	for i := range existing {
+		for _, key := range existing[i].NameKeys {
+			if _, ok := byKey[key]; !ok {
+				byKey[key] = &existing[i]
+			}
+		}
+	}
+
+	result := &company.ImportResult{DryRun: dryRun, Created: []company.ImportedCompany{}}
+	created := map[string]int{}
+	slugs := map[string]bool{}
+
+	for _, row := range rows {
+		players := make(report.KeyPlayers, len(row.KeyPlayers))
+		copy(players, row.KeyPlayers)
+		changed := false
+
+		for i := range players {
+			if players[i].CompanyID != nil {
+				continue
+			}
+			key := company.NameKey(players[i].Name)
+			if key == "" {
+				continue
+			}
+
+			c, ok := byKey[key]
+			if idx, isNew := created[key]; isNew {
+				result.Created[idx].Players++
+			} else if ok {
+				result.PlayersMatched++
+			} else {
+				c, err = s.importCompany(strings.TrimSpace(players[i].Name), slugs, dryRun)
+				if err != nil {
+					return nil, err
+				}
+				byKey[key] = c
+				created[key] = len(result.Created)
+				result.Created = append(result.Created, company.ImportedCompany{ID: c.ID, Name: c.Name, Slug: c.Slug, Players: 1})
+			}
+
+			id := c.ID
+			players[i].CompanyID = &id
+			result.PlayersLinked++
+			changed = true
+		}
+
+		if !changed {
+			continue
+		}
+		if !dryRun {
+			if err := s.repo.UpdateKeyPlayers(row.ID, players); err != nil {
+				return nil, fmt.Errorf("failed to link key players of report %d: %w", row.ID, err)
+			}
+		}
+		result.ReportsUpdated++
+	}
+
+	if !dryRun && result.ReportsUpdated > 0 {
+		invalidateKeyPlayerCaches()
+	}
+
+	return result, nil
+}
+
+// importCompany creates a directory entry for a key player name under the
+// first free slug
+func (s *companyService) importCompany(name string, slugs map[string]bool, dryRun bool) (*company.Company, error) {
+	base := slug.Make(name)
+	if base == "" {
+		base = "company"
+	}
+
+	candidate := base
+	for n := 2; ; n++ {
+		taken := slugs[candidate]
+		if !taken {
+			var err error
+			taken, err = s.repo.SlugExists(candidate, 0)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check slug availability: %w", err)
+			}
+		}
+		if !taken {
+			break
+		}
+		candidate = fmt.Sprintf("%s-%d", base, n)
+	}
+	slugs[candidate] = true
+
+	c := &company.Company{Name: name, Slug: candidate, Aliases: company.Names{}}
+	c.NameKeys = c.Keys()
+	if dryRun {
+		return c, nil
+	}
+	if err := s.repo.Create(c); err != nil {
+		return nil, fmt.Errorf("failed to create company %q: %w", name, err)
+	}
+	return c, nil
+}
+
+// validate checks the fields shared by create and update and makes sure no
+// other company already answers to the name or an alias
+func (s *companyService) validate(c *company.Company) error {
+	if c.Website != "" {
+		if err := validation.ValidateURL(c.Website); err != nil {
+			return fmt.Errorf("%w: website: %v", ErrInvalidCompanyData, err)
+		}
+	}
+	if c.HQCountry != "" && !company.ValidCountryCode(c.HQCountry) {
+		return fmt.Errorf("%w: hq_country must be an ISO 3166-1 alpha-2 code", ErrInvalidCompanyData)
+	}
+
+	c.NameKeys = c.Keys()
+	if len(c.NameKeys) == 0 {
+		return fmt.Errorf("%w: name must contain letters or digits", ErrInvalidCompanyData)
+	}
+
+	others, err := s.repo.FindByNameKeys(c.NameKeys, c.ID)
+	if err != nil {
+		return fmt.Errorf("failed to check company names: %w", err)
+	}
+	if len(others) > 0 {
+		return fmt.Errorf("%w: %s (%s) already uses this name or alias", ErrCompanyNameTaken, others[0].Name, others[0].Slug)
+	}
+	return nil
+}
+
+// invalidateKeyPlayerCaches drops cached reports after their key players
+// were relinked
+func invalidateKeyPlayerCaches() {
+	cache.DeletePattern("report:slug:*")
+}
+
+// uniqueIDs drops zero and repeated IDs, keeping the order
+func uniqueIDs(ids []uint) []uint {
+	seen := map[uint]bool{}
+	unique := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if id == 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
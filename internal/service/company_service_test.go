@@ -0,0 +1,161 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/healthcare-market-research/backend/internal/domain/company"
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// Mock CompanyRepository for testing
+type mockCompanyRepository struct {
+	repository.CompanyRepository
+	companies []company.Company
+	players   []repository.ReportKeyPlayers
+	created   []company.Company
+	updated   map[uint]report.KeyPlayers
+}
+
+func (m *mockCompanyRepository) ListAll() ([]company.Company, error) {
+	return m.companies, nil
+}
+
+func (m *mockCompanyRepository) GetByID(id uint) (*company.Company, error) {
+	for i := range m.companies {
+		if m.companies[i].ID == id {
+			c := m.companies[i]
+			return &c, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *mockCompanyRepository) GetByIDs(ids []uint) ([]company.Company, error) {
+	var found []company.Company
+	for _, id := range ids {
+		if c, err := m.GetByID(id); err == nil {
+			found = append(found, *c)
+		}
+	}
+	return found, nil
+}
+
+func (m *mockCompanyRepository) FindByNameKeys(keys []string, excludeID uint) ([]company.Company, error) {
+	var found []company.Company
+	for _, c := range m.companies {
+		if c.ID == excludeID {
+			continue
+		}
+		for _, key := range keys {
+			if c.Keys()[0] == key {
+				found = append(found, c)
+				break
+			}
+		}
+	}
+	return found, nil
+}
+
+func (m *mockCompanyRepository) SlugExists(slug string, excludeID uint) (bool, error) {
+	for _, c := range m.companies {
+		if c.Slug == slug && c.ID != excludeID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *mockCompanyRepository) Create(c *company.Company) error {
+	m.created = append(m.created, *c)
+	return nil
+}
+
+func (m *mockCompanyRepository) ListKeyPlayers() ([]repository.ReportKeyPlayers, error) {
+	return m.players, nil
+}
+
+func (m *mockCompanyRepository) UpdateKeyPlayers(reportID uint, players report.KeyPlayers) error {
+	if m.updated == nil {
+		m.updated = map[uint]report.KeyPlayers{}
+	}
+	m.updated[reportID] = players
+	return nil
+}
+
+func TestCompanyService_Import_DryRun(t *testing.T) {
+	linked := uint(1)
+	repo := &mockCompanyRepository{
+		companies: []company.Company{
+			{ID: 1, Name: "Siemens Healthineers", Slug: "siemens-healthineers", NameKeys: company.Names{"siemens healthineers", "siemens healthcare"}},
+			{ID: 2, Name: "Philips Healthcare", Slug: "philips"},
+		},
+		players: []repository.ReportKeyPlayers{
+			{ID: 10, KeyPlayers: report.KeyPlayers{
+				{CompanyID: &linked, Name: "Siemens Healthineers"},
+				{Name: "Philips"},
+				{Name: "Siemens Healthcare GmbH"},
+			}},
+			{ID: 11, KeyPlayers: report.KeyPlayers{{Name: "Philips, Inc."}, {Name: ""}}},
+		},
+	}
+	s := NewCompanyService(repo, nil, nil)
+
+	result, err := s.Import(true)
+	require.NoError(t, err)
+
+	assert.True(t, result.DryRun)
+	// "Philips" is new; its slug is taken by Philips Healthcare
+	require.Len(t, result.Created, 1)
+	assert.Equal(t, "Philips", result.Created[0].Name)
+	assert.Equal(t, "philips-2", result.Created[0].Slug)
+	assert.Equal(t, 2, result.Created[0].Players)
+	assert.Equal(t, 1, result.PlayersMatched)
+	assert.Equal(t, 3, result.PlayersLinked)
+	assert.Equal(t, 2, result.ReportsUpdated)
+
+	// Nothing is written on a dry run
+	assert.Empty(t, repo.created)
+	assert.Empty(t, repo.updated)
+	assert.Nil(t, repo.players[0].KeyPlayers[1].CompanyID)
+}
+
+func TestCompanyService_Merge_Rules(t *testing.T) {
+	repo := &mockCompanyRepository{
+		companies: []company.Company{
+			{ID: 1, Name: "Siemens Healthineers", Slug: "siemens-healthineers"},
+			{ID: 2, Name: "Siemens Healthcare", Slug: "siemens-healthcare"},
+		},
+	}
+	s := NewCompanyService(repo, nil, nil)
+
+	_, err := s.Merge(9, []uint{2})
+	assert.ErrorIs(t, err, ErrCompanyNotFound)
+
+	_, err = s.Merge(1, nil)
+	assert.ErrorIs(t, err, ErrInvalidMerge)
+
+	_, err = s.Merge(1, []uint{2, 1})
+	assert.ErrorIs(t, err, ErrInvalidMerge)
+
+	_, err = s.Merge(1, []uint{2, 7})
+	assert.ErrorIs(t, err, ErrCompanyNotFound)
+}
+
+func TestCompanyService_Create_NameTaken(t *testing.T) {
+	repo := &mockCompanyRepository{
+		companies: []company.Company{{ID: 1, Name: "Medtronic", Slug: "medtronic"}},
+	}
+	s := NewCompanyService(repo, nil, nil)
+
+	_, err := s.Create(&company.CreateCompanyRequest{Name: "Medtronic plc", Slug: "medtronic-plc"})
+	assert.ErrorIs(t, err, ErrCompanyNameTaken)
+
+	_, err = s.Create(&company.CreateCompanyRequest{Name: "Covidien", HQCountry: "Ireland"})
+	assert.ErrorIs(t, err, ErrInvalidCompanyData)
+
+	assert.Empty(t, repo.created)
+}
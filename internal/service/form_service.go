@@ -104,7 +104,8 @@ func (s *formService) validateFormData(category form.FormCategory, data form.For
 func (s *formService) GetAll(query form.GetSubmissionsQuery) ([]form.FormSubmission, int64, error) {
 	// Only cache non-filtered, non-search queries
 	shouldCache := query.Category == "" && query.Status == "" && query.DateFrom == "" &&
-		query.DateTo == "" && query.Search == "" && query.SortBy == "" && query.SortOrder == ""
+		query.DateTo == "" && query.Search == "" && query.SortBy == "" && query.SortOrder == "" &&
+		query.Cursor == nil && !query.SkipCount
 
 	if shouldCache {
 		cacheKey := fmt.Sprintf("forms:list:%d:%d", query.Page, query.Limit)
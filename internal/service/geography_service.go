@@ -0,0 +1,56 @@
+package service
+
+import (
+	"time"
+
+	"github.com/healthcare-market-research/backend/internal/cache"
+	"github.com/healthcare-market-research/backend/internal/domain/geography"
+	"github.com/healthcare-market-research/backend/internal/repository"
+)
+
+type GeographyService interface {
+	GetAll() ([]geography.Geography, error)
+	GetTree() ([]geography.Geography, error)
+	Taxonomy() (*geography.Taxonomy, error)
+}
+
+type geographyService struct {
+	repo repository.GeographyRepository
+}
+
+func NewGeographyService(repo repository.GeographyRepository) GeographyService {
+	return &geographyService{repo: repo}
+}
+
+// GetAll returns every geography. The taxonomy only changes with migrations,
+// so it is cached for an hour.
+func (s *geographyService) GetAll() ([]geography.Geography, error) {
+	var geographies []geography.Geography
+
+	err := cache.GetOrSet("geographies:all", &geographies, time.Hour, func() (interface{}, error) {
+		return s.repo.GetAll()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return geographies, nil
+}
+
+// GetTree returns the taxonomy nested as Global > Region > Country
+func (s *geographyService) GetTree() ([]geography.Geography, error) {
+	t, err := s.Taxonomy()
+	if err != nil {
+		return nil, err
+	}
+	return t.Tree(), nil
+}
+
+// Taxonomy returns the geographies indexed for resolving report values
+func (s *geographyService) Taxonomy() (*geography.Taxonomy, error) {
+	geographies, err := s.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return geography.NewTaxonomy(geographies), nil
+}
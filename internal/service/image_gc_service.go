@@ -0,0 +1,297 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/healthcare-market-research/backend/internal/config"
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/domain/media"
+	"github.com/healthcare-market-research/backend/internal/repository"
+	"github.com/healthcare-market-research/backend/internal/storage"
+	"github.com/healthcare-market-research/backend/pkg/logger"
+)
+
+var ErrImageGCRunning = errors.New("image garbage collection is already running")
+
+// imageGCActor is recorded as the user of the collector's audit entries
+const imageGCActor = "system:image-gc"
+
+// ImageGCService finds stored images that no record uses and deletes them
+// once they have been orphaned for longer than the retention window
+type ImageGCService interface {
+	Run(dryRun bool) (*media.GCReport, error)
+	Start(ctx context.Context)
+	Stop()
+}
+
+type imageGCService struct {
+	repo         repository.ImageGCRepository
+	imageStorage storage.ImageStorage
+	auditService AuditService
+	config       *config.ImageGCConfig
+	now          func() time.Time
+	running      sync.Mutex
+	ticker       *time.Ticker
+	stopCh       chan struct{}
+}
+
+func NewImageGCService(
+	repo repository.ImageGCRepository,
+	imageStorage storage.ImageStorage,
+	auditService AuditService,
+	cfg *config.ImageGCConfig,
+) ImageGCService {
+	return &imageGCService{
+		repo:         repo,
+		imageStorage: imageStorage,
+		auditService: auditService,
+		config:       cfg,
+		now:          time.Now,
+		stopCh:       make(chan struct{}),
+	}
+}
+
+// Start runs the collector every configured interval
+func (s *imageGCService) Start(ctx context.Context) {
+	s.ticker = time.NewTicker(s.config.Interval)
+	logger.Info("Image garbage collector started", "interval", s.config.Interval, "retention", s.config.Retention, "dry_run", s.config.DryRun)
+
+	go func() {
+		for {
+			select {
+			case <-s.ticker.C:
+				if _, err := s.Run(s.config.DryRun); err != nil && !errors.Is(err, ErrImageGCRunning) {
+					logger.Error("Image garbage collection failed", "error", err)
+				}
+			case <-s.stopCh:
+				logger.Info("Image garbage collector stopped")
+				return
+			case <-ctx.Done():
+				logger.Info("Image garbage collector context cancelled")
+				return
+			}
+		}
+	}()
+}
+
+func (s *imageGCService) Stop() {
+	if s.ticker != nil {
+		s.ticker.Stop()
+	}
+	close(s.stopCh)
+}
+
+// Run compares the stored images with the ones saved on records. Orphans are
+// always reported and remembered so the retention window counts from when
+// they were first found; unless dryRun is set, due ones are deleted.
+func (s *imageGCService) Run(dryRun bool) (*media.GCReport, error) {
+	if !s.running.TryLock() {
+		return nil, ErrImageGCRunning
+	}
+	defer s.running.Unlock()
+
+	now := s.now()
+	report := &media.GCReport{
+		DryRun:    dryRun,
+		Cutoff:    now.Add(-s.config.Retention),
+		StartedAt: now,
+		Orphans:   []media.Orphan{},
+	}
+
+	refs, err := s.repo.FindReferences()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load image references: %w", err)
+	}
+	known, err := s.repo.FindOrphanedImages()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load orphaned images: %w", err)
+	}
+	firstSeen := make(map[string]time.Time, len(known))
+	for _, orphan := range known {
+		firstSeen[orphan.Key] = orphan.FirstSeenAt
+	}
+
+	// Keys used by active records, or by inactive ones still inside the
+	// retention window, are kept. URLs from other storages (linked images or
+	// a previous driver) never match a stored key and are skipped.
+	keep := map[string]bool{}
+	inactive := map[string]*media.ImageReference{}
+	var expired []*media.ImageReference
+	for i := range refs {
+		ref := &refs[i]
+		stale := !ref.Active && ref.UpdatedAt.Before(report.Cutoff)
+		if stale {
+			expired = append(expired, ref)
+		}
+		for _, imageURL := range ref.URLs() {
+			key, err := s.imageStorage.Key(imageURL)
+			if err != nil {
+				continue
+			}
+			if stale {
+				inactive[key] = ref
+			} else {
+				keep[key] = true
+			}
+		}
+	}
+
+	err = s.imageStorage.List(func(info *storage.ImageInfo) error {
+		report.Scanned++
+		if keep[info.Key] {
+			report.Referenced++
+			return nil
+		}
+
+		orphan := media.Orphan{
+			Key:          info.Key,
+			URL:          info.URL,
+			Size:         info.Size,
+			LastModified: info.LastModified,
+			Reason:       media.OrphanUnreferenced,
+			OrphanedAt:   now,
+		}
+		if seen, ok := firstSeen[info.Key]; ok {
+			orphan.OrphanedAt = seen
+		}
+		if ref, ok := inactive[info.Key]; ok {
+			id := ref.EntityID
+			orphan.Reason = media.OrphanInactive
+			orphan.EntityType = ref.EntityType
+			orphan.EntityID = &id
+			orphan.OrphanedAt = ref.UpdatedAt
+		}
+		orphan.Due = orphan.OrphanedAt.Before(report.Cutoff)
+
+		report.Orphans = append(report.Orphans, orphan)
+		report.OrphanSize += orphan.Size
+		if orphan.Due {
+			report.Due++
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stored images: %w", err)
+	}
+
+	if !dryRun {
+		s.deleteDue(report, expired, keep)
+	}
+
+	// Remember the orphans still stored so the next run keeps counting
+	// from when they were first found
+	var remaining []media.OrphanedImage
+	for _, orphan := range report.Orphans {
+		if orphan.Deleted || orphan.Reason != media.OrphanUnreferenced {
+			continue
+		}
+		remaining = append(remaining, media.OrphanedImage{
+			Key:         orphan.Key,
+			URL:         orphan.URL,
+			Size:        orphan.Size,
+			FirstSeenAt: orphan.OrphanedAt,
+			LastSeenAt:  now,
+		})
+	}
+	if err := s.repo.ReplaceOrphanedImages(remaining); err != nil {
+		return nil, fmt.Errorf("failed to save orphaned images: %w", err)
+	}
+
+	report.FinishedAt = s.now()
+	logger.Info("Image garbage collection finished",
+		"dry_run", dryRun,
+		"scanned", report.Scanned,
+		"orphans", len(report.Orphans),
+		"due", report.Due,
+		"deleted", report.Deleted,
+		"failed", report.Failed,
+		"purged", report.Purged,
+	)
+	return report, nil
+}
+
+// deleteDue deletes the due orphans, then the inactive report images whose
+// files are all gone
+func (s *imageGCService) deleteDue(report *media.GCReport, expired []*media.ImageReference, keep map[string]bool) {
+	failed := map[string]bool{}
+	for i := range report.Orphans {
+		orphan := &report.Orphans[i]
+		if !orphan.Due {
+			continue
+		}
+
+		err := s.imageStorage.Delete(orphan.URL)
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			orphan.Error = err.Error()
+			failed[orphan.Key] = true
+			report.Failed++
+			logger.Error("Failed to delete orphaned image", "url", orphan.URL, "error", err)
+		} else {
+			orphan.Deleted = true
+			report.Deleted++
+		}
+
+		entityType := orphan.EntityType
+		if entityType == "" {
+			entityType = audit.EntityImage
+		}
+		s.audit(entityType, orphan.EntityID, audit.Changes{
+			"url":    {Old: orphan.URL, New: nil},
+			"reason": {Old: nil, New: orphan.Reason},
+		}, err)
+	}
+
+	for _, ref := range expired {
+		if !s.filesDeleted(ref, keep, failed) {
+			continue
+		}
+		if err := s.repo.DeleteReportImage(ref.EntityID); err != nil {
+			logger.Error("Failed to delete inactive report image", "id", ref.EntityID, "error", err)
+			continue
+		}
+		report.Purged++
+
+		id := ref.EntityID
+		s.audit(ref.EntityType, &id, audit.Changes{
+			"image_url": {Old: ref.ImageURL, New: nil},
+			"reason":    {Old: nil, New: media.OrphanInactive},
+		}, nil)
+	}
+}
+
+// filesDeleted reports whether an expired reference no longer has files in
+// this storage. Images from another storage are never purged since their
+// files could not be deleted.
+func (s *imageGCService) filesDeleted(ref *media.ImageReference, keep, failed map[string]bool) bool {
+	for _, imageURL := range ref.URLs() {
+		key, err := s.imageStorage.Key(imageURL)
+		if err != nil || keep[key] || failed[key] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *imageGCService) audit(entityType string, entityID *uint, changes audit.Changes, err error) {
+	entry := &audit.AuditEntry{
+		UserEmail:  imageGCActor,
+		Action:     audit.ActionImageGCDelete,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Changes:    changes,
+		Status:     audit.StatusSuccess,
+	}
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		entry.Status = audit.StatusFailure
+		entry.ErrorMessage = err.Error()
+	}
+
+	// Logged synchronously so a one-off run never exits with entries queued
+	if err := s.auditService.Log(entry); err != nil {
+		logger.Error("Failed to audit image deletion", "error", err)
+	}
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/healthcare-market-research/backend/internal/config"
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/domain/media"
+	"github.com/healthcare-market-research/backend/internal/storage"
+	"github.com/healthcare-market-research/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Mock ImageGCRepository for testing
+type mockImageGCRepository struct {
+	references    []media.ImageReference
+	orphaned      []media.OrphanedImage
+	deletedImages []uint
+}
+
+func (m *mockImageGCRepository) FindReferences() ([]media.ImageReference, error) {
+	return m.references, nil
+}
+
+func (m *mockImageGCRepository) FindOrphanedImages() ([]media.OrphanedImage, error) {
+	return m.orphaned, nil
+}
+
+func (m *mockImageGCRepository) ReplaceOrphanedImages(orphans []media.OrphanedImage) error {
+	m.orphaned = orphans
+	return nil
+}
+
+func (m *mockImageGCRepository) DeleteReportImage(id uint) error {
+	m.deletedImages = append(m.deletedImages, id)
+	return nil
+}
+
+// recordingAuditService keeps synchronously logged entries
+type recordingAuditService struct {
+	AuditService
+	entries []*audit.AuditEntry
+}
+
+func (r *recordingAuditService) Log(entry *audit.AuditEntry) error {
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+type imageGCFixture struct {
+	store storage.ImageStorage
+	repo  *mockImageGCRepository
+	audit *recordingAuditService
+	gc    *imageGCService
+	now   time.Time
+	urls  map[string]string
+}
+
+// newImageGCFixture stores one image per name and references some of them:
+// an author image with a variant, an unreferenced image first seen long ago,
+// a fresh unreferenced one, and report images deactivated long ago and
+// recently
+func newImageGCFixture(t *testing.T) *imageGCFixture {
+	t.Helper()
+	logger.Init("test")
+
+	store, err := storage.NewLocalStorage(&config.LocalStorageConfig{Dir: t.TempDir(), BaseURL: "http://localhost:8081/uploads"})
+	require.NoError(t, err)
+
+	urls := map[string]string{}
+	for _, name := range []string{"author", "author-thumb", "old-orphan", "new-orphan", "stale-inactive", "recent-inactive"} {
+		imageURL, err := store.Upload(name+".png", strings.NewReader(name), nil)
+		require.NoError(t, err)
+		urls[name] = imageURL
+	}
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	oldKey, err := store.Key(urls["old-orphan"])
+	require.NoError(t, err)
+
+	repo := &mockImageGCRepository{
+		references: []media.ImageReference{
+			{EntityType: audit.EntityAuthor, EntityID: 1, ImageURL: urls["author"], Active: true,
+				Variants: media.Variants{media.VariantThumb: {URL: urls["author-thumb"], WebP: urls["author-thumb"]}}},
+			{EntityType: audit.EntityCategory, EntityID: 2, ImageURL: "https://cdn.example.com/linked.png", Active: true},
+			{EntityType: audit.EntityReportImage, EntityID: 3, ImageURL: urls["stale-inactive"], UpdatedAt: now.AddDate(0, 0, -45)},
+			{EntityType: audit.EntityReportImage, EntityID: 4, ImageURL: urls["recent-inactive"], UpdatedAt: now.AddDate(0, 0, -2)},
+		},
+		orphaned: []media.OrphanedImage{
+			{Key: oldKey, URL: urls["old-orphan"], FirstSeenAt: now.AddDate(0, 0, -31)},
+		},
+	}
+	auditService := &recordingAuditService{}
+
+	gc := NewImageGCService(repo, store, auditService, &config.ImageGCConfig{Retention: 30 * 24 * time.Hour}).(*imageGCService)
+	gc.now = func() time.Time { return now }
+
+	return &imageGCFixture{store: store, repo: repo, audit: auditService, gc: gc, now: now, urls: urls}
+}
+
+func (f *imageGCFixture) exists(t *testing.T, name string) bool {
+	t.Helper()
+	_, err := f.store.Stat(f.urls[name])
+	if err != nil {
+		require.ErrorIs(t, err, storage.ErrNotFound)
+		return false
+	}
+	return true
+}
+
+func orphansByURL(report *media.GCReport) map[string]media.Orphan {
+	orphans := map[string]media.Orphan{}
+	for _, orphan := range report.Orphans {
+		orphans[orphan.URL] = orphan
+	}
+	return orphans
+}
+
+func TestImageGCService_DryRun(t *testing.T) {
+	f := newImageGCFixture(t)
+
+	report, err := f.gc.Run(true)
+	require.NoError(t, err)
+
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 6, report.Scanned)
+	assert.Equal(t, 3, report.Referenced)
+	assert.Len(t, report.Orphans, 3)
+	assert.Equal(t, 2, report.Due)
+	assert.Zero(t, report.Deleted)
+
+	orphans := orphansByURL(report)
+	assert.True(t, orphans[f.urls["old-orphan"]].Due)
+	assert.False(t, orphans[f.urls["new-orphan"]].Due)
+	assert.Equal(t, f.now, orphans[f.urls["new-orphan"]].OrphanedAt)
+	stale := orphans[f.urls["stale-inactive"]]
+	assert.Equal(t, media.OrphanInactive, stale.Reason)
+	assert.Equal(t, uint(3), *stale.EntityID)
+	assert.True(t, stale.Due)
+
+	for _, name := range []string{"author", "author-thumb", "old-orphan", "new-orphan", "stale-inactive", "recent-inactive"} {
+		assert.True(t, f.exists(t, name), name)
+	}
+	assert.Empty(t, f.audit.entries)
+	assert.Empty(t, f.repo.deletedImages)
+
+	// Sightings are kept even in dry runs, preserving when each was first seen
+	require.Len(t, f.repo.orphaned, 2)
+	for _, orphan := range f.repo.orphaned {
+		if orphan.URL == f.urls["old-orphan"] {
+			assert.Equal(t, f.now.AddDate(0, 0, -31), orphan.FirstSeenAt)
+		} else {
+			assert.Equal(t, f.urls["new-orphan"], orphan.URL)
+			assert.Equal(t, f.now, orphan.FirstSeenAt)
+		}
+	}
+}
+
+func TestImageGCService_DeletesDueOrphans(t *testing.T) {
+	f := newImageGCFixture(t)
+
+	report, err := f.gc.Run(false)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, report.Deleted)
+	assert.Zero(t, report.Failed)
+	assert.Equal(t, 1, report.Purged)
+
+	assert.False(t, f.exists(t, "old-orphan"))
+	assert.False(t, f.exists(t, "stale-inactive"))
+	for _, name := range []string{"author", "author-thumb", "new-orphan", "recent-inactive"} {
+		assert.True(t, f.exists(t, name), name)
+	}
+	assert.Equal(t, []uint{3}, f.repo.deletedImages)
+
+	require.Len(t, f.audit.entries, 3)
+	for _, entry := range f.audit.entries {
+		assert.Equal(t, audit.ActionImageGCDelete, entry.Action)
+		assert.Equal(t, audit.StatusSuccess, entry.Status)
+		assert.Equal(t, imageGCActor, entry.UserEmail)
+	}
+
+	// Only the fresh orphan is left to track
+	require.Len(t, f.repo.orphaned, 1)
+	assert.Equal(t, f.urls["new-orphan"], f.repo.orphaned[0].URL)
+}
+
+func TestImageGCService_ForgetsReferencedImages(t *testing.T) {
+	f := newImageGCFixture(t)
+
+	// The old orphan is used again, so its sighting is dropped
+	f.repo.references = append(f.repo.references, media.ImageReference{
+		EntityType: audit.EntityAuthor, EntityID: 9, ImageURL: f.urls["old-orphan"], Active: true,
+	})
+
+	report, err := f.gc.Run(false)
+	require.NoError(t, err)
+
+	assert.True(t, f.exists(t, "old-orphan"))
+	assert.Equal(t, 1, report.Deleted)
+	for _, orphan := range f.repo.orphaned {
+		assert.NotEqual(t, f.urls["old-orphan"], orphan.URL)
+	}
+}
+
+func TestImageGCService_RejectsOverlappingRuns(t *testing.T) {
+	f := newImageGCFixture(t)
+
+	f.gc.running.Lock()
+	defer f.gc.running.Unlock()
+
+	_, err := f.gc.Run(true)
+	assert.ErrorIs(t, err, ErrImageGCRunning)
+}
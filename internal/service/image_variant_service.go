@@ -0,0 +1,118 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/healthcare-market-research/backend/internal/domain/media"
+	"github.com/healthcare-market-research/backend/internal/repository"
+	"github.com/healthcare-market-research/backend/internal/storage"
+	"github.com/healthcare-market-research/backend/pkg/imaging"
+)
+
+// ImageVariantService renders the variants of images stored before variants
+// were rendered on upload
+type ImageVariantService interface {
+	Backfill(dryRun bool) (*media.VariantBackfillReport, error)
+}
+
+type imageVariantService struct {
+	repo         repository.ImageVariantRepository
+	imageStorage storage.ImageStorage
+	now          func() time.Time
+}
+
+func NewImageVariantService(repo repository.ImageVariantRepository, imageStorage storage.ImageStorage) ImageVariantService {
+	return &imageVariantService{
+		repo:         repo,
+		imageStorage: imageStorage,
+		now:          time.Now,
+	}
+}
+
+// Backfill lists the images saved without variants and, unless dryRun is
+// set, renders and saves them. Images outside the configured storage are
+// skipped. A failed image is reported and the run moves on.
+func (s *imageVariantService) Backfill(dryRun bool) (*media.VariantBackfillReport, error) {
+	report := &media.VariantBackfillReport{
+		DryRun:    dryRun,
+		StartedAt: s.now(),
+		Images:    []media.VariantBackfill{},
+	}
+
+	refs, err := s.repo.FindMissingVariants()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load images without variants: %w", err)
+	}
+
+	for _, ref := range refs {
+		entry := media.VariantBackfill{
+			EntityType: ref.EntityType,
+			EntityID:   ref.EntityID,
+			URL:        ref.ImageURL,
+		}
+		if _, err := s.imageStorage.Key(ref.ImageURL); err != nil {
+			entry.Skipped = true
+		} else if !dryRun {
+			entry.Saved, err = s.backfill(ref)
+			if err != nil {
+				entry.Error = err.Error()
+			} else if !entry.Saved {
+				// The record changed image or got variants meanwhile
+				entry.Skipped = true
+			}
+		}
+
+		switch {
+		case entry.Error != "":
+			report.Failed++
+		case entry.Skipped:
+			report.Skipped++
+		case entry.Saved:
+			report.Saved++
+		}
+		report.Images = append(report.Images, entry)
+	}
+
+	report.FinishedAt = s.now()
+	return report, nil
+}
+
+// backfill renders and saves the variants of one image, removing them again
+// if they could not be saved
+func (s *imageVariantService) backfill(ref media.ImageReference) (bool, error) {
+	src, err := s.imageStorage.Open(ref.ImageURL)
+	if errors.Is(err, storage.ErrNotFound) {
+		return false, fmt.Errorf("image is missing from storage")
+	}
+	if err != nil {
+		return false, err
+	}
+	data, err := io.ReadAll(src)
+	src.Close()
+	if err != nil {
+		return false, fmt.Errorf("failed to read image: %w", err)
+	}
+
+	img, format, err := imaging.Decode(data)
+	if err != nil {
+		return false, fmt.Errorf("failed to process image: %w", err)
+	}
+
+	variants, err := renderVariants(s.imageStorage, img, format, map[string]string{
+		"entity_type": ref.EntityType,
+		"entity_id":   fmt.Sprintf("%d", ref.EntityID),
+		"type":        "variant_backfill",
+	})
+	if err != nil {
+		return false, err
+	}
+
+	saved, err := s.repo.SetVariants(ref, variants)
+	if err != nil || !saved {
+		deleteImageFiles(s.imageStorage, variants.URLs())
+	}
+	return saved, err
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/domain/media"
+	"github.com/healthcare-market-research/backend/internal/storage"
+	"github.com/healthcare-market-research/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Mock ImageVariantRepository for testing
+type mockImageVariantRepository struct {
+	missing []media.ImageReference
+	// changed records no longer hold the image they were listed with
+	changed map[uint]bool
+	saved   map[uint]media.Variants
+}
+
+func (m *mockImageVariantRepository) FindMissingVariants() ([]media.ImageReference, error) {
+	return m.missing, nil
+}
+
+func (m *mockImageVariantRepository) SetVariants(ref media.ImageReference, variants media.Variants) (bool, error) {
+	if m.changed[ref.EntityID] {
+		return false, nil
+	}
+	m.saved[ref.EntityID] = variants
+	return true, nil
+}
+
+func TestImageVariantService_Backfill(t *testing.T) {
+	logger.Init("test")
+	store := newLocalTestStorage(t)
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 800, 600))))
+	stored, err := store.Upload("author.png", bytes.NewReader(buf.Bytes()), nil)
+	require.NoError(t, err)
+	corrupt, err := store.Upload("broken.png", bytes.NewReader([]byte("not an image")), nil)
+	require.NoError(t, err)
+
+	newRepo := func() *mockImageVariantRepository {
+		return &mockImageVariantRepository{
+			missing: []media.ImageReference{
+				{EntityType: audit.EntityAuthor, EntityID: 1, ImageURL: stored},
+				{EntityType: audit.EntityAuthor, EntityID: 2, ImageURL: "https://images.example.org/linked.png"},
+				{EntityType: audit.EntityCategory, EntityID: 3, ImageURL: corrupt},
+				{EntityType: audit.EntityCategory, EntityID: 4, ImageURL: stored},
+			},
+			changed: map[uint]bool{4: true},
+			saved:   map[uint]media.Variants{},
+		}
+	}
+	countFiles := func() int {
+		n := 0
+		require.NoError(t, store.List(func(*storage.ImageInfo) error { n++; return nil }))
+		return n
+	}
+
+	t.Run("Dry run only lists", func(t *testing.T) {
+		repo := newRepo()
+		report, err := NewImageVariantService(repo, store).Backfill(true)
+		require.NoError(t, err)
+		assert.Len(t, report.Images, 4)
+		assert.Equal(t, 1, report.Skipped)
+		assert.Empty(t, repo.saved)
+		assert.Equal(t, 2, countFiles())
+	})
+
+	t.Run("Render and save missing variants", func(t *testing.T) {
+		repo := newRepo()
+		report, err := NewImageVariantService(repo, store).Backfill(false)
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.Saved)
+		assert.Equal(t, 2, report.Skipped, "linked image and changed record")
+		assert.Equal(t, 1, report.Failed)
+		assert.NotEmpty(t, report.Images[2].Error)
+
+		variants := repo.saved[1]
+		require.Len(t, variants, len(media.VariantSpecs))
+		assert.Equal(t, 320, variants[media.VariantThumb].Width)
+		assert.Equal(t, 240, variants[media.VariantThumb].Height)
+		for _, url := range variants.URLs() {
+			_, err := store.Stat(url)
+			assert.NoError(t, err)
+		}
+
+		// Renders for the changed record are removed again
+		assert.Equal(t, 2+len(variants.URLs()), countFiles())
+	})
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"io"
+	"log"
+	"mime/multipart"
+	"sync"
+
+	"github.com/healthcare-market-research/backend/internal/domain/media"
+	"github.com/healthcare-market-research/backend/internal/storage"
+	"github.com/healthcare-market-research/backend/pkg/imaging"
+	"golang.org/x/sync/errgroup"
+)
+
+// decodedImage is an uploaded image read into memory and decoded
+type decodedImage struct {
+	filename string
+	data     []byte
+	img      image.Image
+	format   string
+}
+
+// readImage reads and decodes a multipart image upload
+func readImage(file *multipart.FileHeader) (*decodedImage, error) {
+	src, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	data, err := io.ReadAll(src)
+	src.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	img, format, err := imaging.Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process image: %w", err)
+	}
+	return &decodedImage{filename: file.Filename, data: data, img: img, format: format}, nil
+}
+
+// contentHash is the hex SHA-256 of the original bytes
+func (d *decodedImage) contentHash() string {
+	sum := sha256.Sum256(d.data)
+	return hex.EncodeToString(sum[:])
+}
+
+// uploadImageWithVariants stores the original upload and renders every
+// media.VariantSpec from it, in the original format and as WebP. If anything
+// fails, files already stored are removed again.
+func uploadImageWithVariants(store storage.ImageStorage, file *multipart.FileHeader, metadata map[string]string) (string, media.Variants, error) {
+	decoded, err := readImage(file)
+	if err != nil {
+		return "", nil, err
+	}
+	return uploadDecodedImage(store, decoded, metadata)
+}
+
+// uploadDecodedImage is uploadImageWithVariants for an image already read
+// with readImage
+func uploadDecodedImage(store storage.ImageStorage, decoded *decodedImage, metadata map[string]string) (string, media.Variants, error) {
+	imageURL, err := store.Upload(decoded.filename, bytes.NewReader(decoded.data), metadata)
+	if err != nil {
+		return "", nil, err
+	}
+
+	variants, err := renderVariants(store, decoded.img, decoded.format, metadata)
+	if err != nil {
+		deleteImageFiles(store, []string{imageURL})
+		return "", nil, err
+	}
+
+	return imageURL, variants, nil
+}
+
+// renderVariants renders and stores every media.VariantSpec of img, in format
+// and as WebP. If anything fails, the variants already stored are removed
+// again.
+func renderVariants(store storage.ImageStorage, img image.Image, format string, metadata map[string]string) (media.Variants, error) {
+	var (
+		mu       sync.Mutex
+		uploaded []string
+		variants = make(media.Variants, len(media.VariantSpecs))
+	)
+
+	// Renders are CPU bound and uploads network bound, so run variants side by side
+	var g errgroup.Group
+	for _, spec := range media.VariantSpecs {
+		g.Go(func() error {
+			var resized image.Image
+			if spec.Crop {
+				resized = imaging.Cover(img, spec.Width, spec.Height)
+			} else {
+				resized = imaging.Fit(img, spec.Width, spec.Height)
+			}
+
+			variant := media.Variant{
+				Width:  resized.Bounds().Dx(),
+				Height: resized.Bounds().Dy(),
+			}
+			for _, f := range variantFormats(format) {
+				encoded, err := imaging.Encode(resized, f)
+				if err != nil {
+					return err
+				}
+
+				url, err := store.Upload(spec.Name+imaging.Extension(f), bytes.NewReader(encoded), variantMetadata(metadata, spec.Name))
+				if err != nil {
+					return fmt.Errorf("failed to upload %s variant: %w", spec.Name, err)
+				}
+
+				mu.Lock()
+				uploaded = append(uploaded, url)
+				mu.Unlock()
+
+				if f == imaging.FormatWebP {
+					variant.WebP = url
+				} else {
+					variant.URL = url
+				}
+			}
+			if variant.URL == "" {
+				variant.URL = variant.WebP
+			}
+
+			mu.Lock()
+			variants[spec.Name] = variant
+			mu.Unlock()
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		deleteImageFiles(store, uploaded)
+		return nil, err
+	}
+
+	return variants, nil
+}
+
+// deleteImageWithVariants removes an image and its renditions from storage,
+// logging rather than failing on errors
+func deleteImageWithVariants(store storage.ImageStorage, imageURL string, variants media.Variants) {
+	deleteImageFiles(store, append([]string{imageURL}, variants.URLs()...))
+}
+
+func deleteImageFiles(store storage.ImageStorage, urls []string) {
+	for _, url := range urls {
+		if url == "" {
+			continue
+		}
+		if err := store.Delete(url); err != nil {
+			log.Printf("Warning: Failed to delete image from storage: %s, error: %v", url, err)
+		}
+	}
+}
+
+// variantFormats lists the formats a variant is rendered in: the original
+// format (GIFs become PNG) and WebP
+func variantFormats(format string) []string {
+	if format == imaging.FormatWebP {
+		return []string{imaging.FormatWebP}
+	}
+	return []string{format, imaging.FormatWebP}
+}
+
+func variantMetadata(metadata map[string]string, variant string) map[string]string {
+	m := make(map[string]string, len(metadata)+1)
+	for k, v := range metadata {
+		m[k] = v
+	}
+	m["variant"] = variant
+	return m
+}
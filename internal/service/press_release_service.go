@@ -7,6 +7,9 @@ import (
 
 	"github.com/healthcare-market-research/backend/internal/cache"
 	"github.com/healthcare-market-research/backend/internal/domain/press_release"
+	"github.com/healthcare-market-research/backend/internal/domain/redirect"
+	"github.com/healthcare-market-research/backend/internal/domain/seo"
+	"github.com/healthcare-market-research/backend/internal/domain/tag"
 	"github.com/healthcare-market-research/backend/internal/repository"
 	"github.com/gosimple/slug"
 )
@@ -28,11 +31,13 @@ type PressReleaseService interface {
 }
 
 type pressReleaseService struct {
-	repo repository.PressReleaseRepository
+	repo      repository.PressReleaseRepository
+	redirects repository.RedirectRepository
+	tx        repository.Transactor
 }
 
-func NewPressReleaseService(repo repository.PressReleaseRepository) PressReleaseService {
-	return &pressReleaseService{repo: repo}
+func NewPressReleaseService(repo repository.PressReleaseRepository, redirects repository.RedirectRepository, tx repository.Transactor) PressReleaseService {
+	return &pressReleaseService{repo: repo, redirects: redirects, tx: tx}
 }
 
 func (s *pressReleaseService) Create(req *press_release.CreatePressReleaseRequest) (*press_release.PressRelease, error) {
@@ -57,7 +62,6 @@ func (s *pressReleaseService) Create(req *press_release.CreatePressReleaseReques
 		Excerpt:     req.Excerpt,
 		Content:     req.Content,
 		CategoryID:  req.CategoryID,
-		Tags:        req.Tags,
 		AuthorID:    req.AuthorID,
 		Status:      req.Status,
 		PublishDate: &publishDate,
@@ -69,12 +73,25 @@ func (s *pressReleaseService) Create(req *press_release.CreatePressReleaseReques
 		pr.Metadata = *req.Metadata
 	}
 
-	if err := s.repo.Create(pr); err != nil {
+	// Tags are created and linked with the content, so a failed save leaves none behind
+	err = s.tx.Transaction(func(tx *repository.Tx) error {
+		tags, err := resolveTags(tx.Tags, tag.Split(req.Tags))
+		if err != nil {
+			return err
+		}
+		pr.Tags = tag.Join(tag.Names(tags))
+		if err := tx.PressReleases.Create(pr); err != nil {
+			return err
+		}
+		return setContentTags(tx.Tags, tag.ContentPressRelease, pr.ID, tags)
+	})
+	if err != nil {
 		return nil, err
 	}
 
 	// Invalidate caches
 	cache.DeletePattern("press_releases:*")
+	invalidateSEOCache(seo.SectionPressReleases)
 
 	return pr, nil
 }
@@ -82,7 +99,8 @@ func (s *pressReleaseService) Create(req *press_release.CreatePressReleaseReques
 func (s *pressReleaseService) GetAll(query press_release.GetPressReleasesQuery) ([]press_release.PressRelease, int64, error) {
 	// Only cache non-filtered, non-search queries
 	shouldCache := query.Status == "" && query.CategoryID == "" && query.Tags == "" &&
-		query.AuthorID == "" && query.Location == "" && query.Search == ""
+		query.AuthorID == "" && query.Location == "" && query.Search == "" &&
+		query.Cursor == nil && !query.SkipCount
 
 	if shouldCache {
 		cacheKey := fmt.Sprintf("press_releases:list:%d:%d", query.Page, query.Limit)
@@ -139,26 +157,33 @@ func (s *pressReleaseService) GetBySlug(slug string) (*press_release.PressReleas
 	})
 
 	if err != nil {
-		return nil, err
+		// A retired slug answers with where the press release lives now
+		return nil, slugMoved(s.redirects, redirect.EntityPressRelease, slug, err)
 	}
 
 	return &pr, nil
 }
 
 func (s *pressReleaseService) Update(id uint, req *press_release.UpdatePressReleaseRequest) (*press_release.PressRelease, error) {
+	if req.Version == nil {
+		return nil, ErrVersionRequired
+	}
+
 	// Check if press release exists
-	_, err := s.repo.GetByID(id)
+	existing, err := s.repo.GetByID(id)
 	if err != nil {
 		return nil, err
 	}
 
 	// Build updates map
 	updates := make(map[string]interface{})
+	newSlug := existing.Slug
 
 	if req.Title != nil {
 		updates["title"] = *req.Title
 		// Update slug if title changed
-		updates["slug"] = slug.Make(*req.Title)
+		newSlug = slug.Make(*req.Title)
+		updates["slug"] = newSlug
 	}
 
 	if req.Excerpt != nil {
@@ -173,10 +198,6 @@ func (s *pressReleaseService) Update(id uint, req *press_release.UpdatePressRele
 		updates["category_id"] = *req.CategoryID
 	}
 
-	if req.Tags != nil {
-		updates["tags"] = *req.Tags
-	}
-
 	if req.AuthorID != nil {
 		updates["author_id"] = *req.AuthorID
 	}
@@ -205,13 +226,37 @@ func (s *pressReleaseService) Update(id uint, req *press_release.UpdatePressRele
 		updates["metadata"] = *req.Metadata
 	}
 
-	// Update press release
-	if err := s.repo.Update(id, updates); err != nil {
+	// Update press release and its tags together
+	err = s.tx.Transaction(func(tx *repository.Tx) error {
+		var tags []tag.Tag
+		if req.Tags != nil {
+			tags, err = resolveTags(tx.Tags, tag.Split(*req.Tags))
+			if err != nil {
+				return err
+			}
+			updates["tags"] = tag.Join(tag.Names(tags))
+		}
+		if err := tx.PressReleases.Update(id, *req.Version, updates); err != nil {
+			return err
+		}
+		if req.Tags == nil {
+			return nil
+		}
+		return setContentTags(tx.Tags, tag.ContentPressRelease, id, tags)
+	})
+	if err != nil {
 		return nil, err
 	}
 
+	// Old links keep working through a redirect
+	if newSlug != existing.Slug {
+		recordSlugChange(s.redirects, redirect.EntityPressRelease, id, existing.Slug, newSlug)
+		cache.Delete(fmt.Sprintf("press_release:slug:%s", existing.Slug))
+	}
+
 	// Invalidate caches
 	cache.DeletePattern("press_releases:*")
+	invalidateSEOCache(seo.SectionPressReleases)
 	cache.Delete(fmt.Sprintf("press_release:id:%d", id))
 
 	// Fetch and return updated press release
@@ -231,6 +276,7 @@ func (s *pressReleaseService) Delete(id uint) error {
 
 	// Invalidate caches
 	cache.DeletePattern("press_releases:*")
+	invalidateSEOCache(seo.SectionPressReleases)
 	cache.Delete(fmt.Sprintf("press_release:id:%d", id))
 
 	return nil
@@ -257,6 +303,7 @@ func (s *pressReleaseService) SubmitForReview(id uint) (*press_release.PressRele
 
 	// Invalidate caches
 	cache.DeletePattern("press_releases:*")
+	invalidateSEOCache(seo.SectionPressReleases)
 	cache.Delete(fmt.Sprintf("press_release:id:%d", id))
 
 	// Fetch and return updated press release
@@ -281,6 +328,7 @@ func (s *pressReleaseService) Publish(id uint) (*press_release.PressRelease, err
 
 	// Invalidate caches
 	cache.DeletePattern("press_releases:*")
+	invalidateSEOCache(seo.SectionPressReleases)
 	cache.Delete(fmt.Sprintf("press_release:id:%d", id))
 
 	// Fetch and return updated press release
@@ -305,6 +353,7 @@ func (s *pressReleaseService) Unpublish(id uint) (*press_release.PressRelease, e
 
 	// Invalidate caches
 	cache.DeletePattern("press_releases:*")
+	invalidateSEOCache(seo.SectionPressReleases)
 	cache.Delete(fmt.Sprintf("press_release:id:%d", id))
 
 	// Fetch and return updated press release
@@ -324,6 +373,7 @@ func (s *pressReleaseService) SoftDelete(id uint) error {
 
 	// Invalidate caches
 	cache.DeletePattern("press_releases:*")
+	invalidateSEOCache(seo.SectionPressReleases)
 	cache.Delete(fmt.Sprintf("press_release:id:%d", id))
 
 	return nil
@@ -336,6 +386,7 @@ func (s *pressReleaseService) Restore(id uint) error {
 
 	// Invalidate caches
 	cache.DeletePattern("press_releases:*")
+	invalidateSEOCache(seo.SectionPressReleases)
 
 	return nil
 }
@@ -364,6 +415,7 @@ func (s *pressReleaseService) SchedulePublish(id uint, publishDate time.Time) (*
 
 	// Invalidate caches
 	cache.DeletePattern("press_releases:*")
+	invalidateSEOCache(seo.SectionPressReleases)
 	cache.Delete(fmt.Sprintf("press_release:id:%d", id))
 
 	return s.repo.GetByID(id)
@@ -383,6 +435,7 @@ func (s *pressReleaseService) CancelScheduledPublish(id uint) (*press_release.Pr
 
 	// Invalidate caches
 	cache.DeletePattern("press_releases:*")
+	invalidateSEOCache(seo.SectionPressReleases)
 	cache.Delete(fmt.Sprintf("press_release:id:%d", id))
 
 	return s.repo.GetByID(id)
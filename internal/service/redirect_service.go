@@ -0,0 +1,284 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gosimple/slug"
+	"github.com/healthcare-market-research/backend/internal/cache"
+	"github.com/healthcare-market-research/backend/internal/domain/redirect"
+	"github.com/healthcare-market-research/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrRedirectNotFound       = errors.New("redirect not found")
+	ErrInvalidRedirect        = errors.New("invalid redirect data")
+	ErrRedirectSlugInUse      = errors.New("slug is in use and cannot be redirected")
+	ErrRedirectExists         = errors.New("a redirect for this slug already exists")
+	ErrRedirectTargetNotFound = errors.New("redirect target not found")
+)
+
+// SlugMovedError is returned by slug lookups that missed but matched a
+// redirect. Target says where the content lives now.
+type SlugMovedError struct {
+	Target *redirect.Target
+}
+
+func (e *SlugMovedError) Error() string {
+	return fmt.Sprintf("%s %q moved to %q", e.Target.EntityType, e.Target.OldSlug, e.Target.Slug)
+}
+
+type RedirectService interface {
+	Resolve(entityType, slug string) (*redirect.Target, error)
+	GetAll(filters redirect.RedirectFilters) ([]redirect.SlugRedirect, int64, error)
+	GetByID(id uint) (*redirect.SlugRedirect, error)
+	Create(req *redirect.CreateRedirectRequest, userID uint) (*redirect.SlugRedirect, error)
+	Update(id uint, req *redirect.UpdateRedirectRequest) (*redirect.SlugRedirect, error)
+	Delete(id uint) error
+}
+
+type redirectService struct {
+	repo repository.RedirectRepository
+}
+
+func NewRedirectService(repo repository.RedirectRepository) RedirectService {
+	return &redirectService{repo: repo}
+}
+
+func (s *redirectService) Resolve(entityType, slug string) (*redirect.Target, error) {
+	if !redirect.ValidEntityType(entityType) {
+		return nil, fmt.Errorf("%w: unknown entity type %q", ErrInvalidRedirect, entityType)
+	}
+	return resolveRedirect(s.repo, entityType, slug)
+}
+
+func (s *redirectService) GetAll(filters redirect.RedirectFilters) ([]redirect.SlugRedirect, int64, error) {
+	if filters.EntityType != "" && !redirect.ValidEntityType(filters.EntityType) {
+		return nil, 0, fmt.Errorf("%w: unknown entity type %q", ErrInvalidRedirect, filters.EntityType)
+	}
+
+	redirects, total, err := s.repo.GetAll(filters)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range redirects {
+		// A deleted target leaves the slug empty; the redirect then resolves to 404
+		redirects[i].CurrentSlug, _ = s.repo.CurrentSlug(redirects[i].EntityType, redirects[i].EntityID)
+	}
+
+	return redirects, total, nil
+}
+
+func (s *redirectService) GetByID(id uint) (*redirect.SlugRedirect, error) {
+	rd, err := s.repo.GetByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRedirectNotFound
+		}
+		return nil, err
+	}
+
+	rd.CurrentSlug, _ = s.repo.CurrentSlug(rd.EntityType, rd.EntityID)
+	return rd, nil
+}
+
+func (s *redirectService) Create(req *redirect.CreateRedirectRequest, userID uint) (*redirect.SlugRedirect, error) {
+	if !redirect.ValidEntityType(req.EntityType) {
+		return nil, fmt.Errorf("%w: entity_type must be one of %s", ErrInvalidRedirect, strings.Join(redirect.EntityTypes, ", "))
+	}
+
+	oldSlug, err := s.validateOldSlug(req.EntityType, req.OldSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.FindBySlug(req.EntityType, oldSlug); err == nil {
+		return nil, ErrRedirectExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	if err := s.validateTarget(req.EntityType, req.EntityID); err != nil {
+		return nil, err
+	}
+
+	rd := &redirect.SlugRedirect{
+		EntityType: req.EntityType,
+		OldSlug:    oldSlug,
+		EntityID:   req.EntityID,
+		IsManual:   true,
+		CreatedBy:  &userID,
+	}
+	if err := s.repo.Create(rd); err != nil {
+		return nil, err
+	}
+
+	invalidateRedirectCache(rd.EntityType, rd.OldSlug)
+
+	rd.CurrentSlug, _ = s.repo.CurrentSlug(rd.EntityType, rd.EntityID)
+	return rd, nil
+}
+
+func (s *redirectService) Update(id uint, req *redirect.UpdateRedirectRequest) (*redirect.SlugRedirect, error) {
+	rd, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	previousSlug := rd.OldSlug
+
+	if req.OldSlug != nil {
+		oldSlug, err := s.validateOldSlug(rd.EntityType, *req.OldSlug)
+		if err != nil {
+			return nil, err
+		}
+		if oldSlug != rd.OldSlug {
+			if _, err := s.repo.FindBySlug(rd.EntityType, oldSlug); err == nil {
+				return nil, ErrRedirectExists
+			} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, err
+			}
+			rd.OldSlug = oldSlug
+		}
+	}
+
+	if req.EntityID != nil {
+		if err := s.validateTarget(rd.EntityType, *req.EntityID); err != nil {
+			return nil, err
+		}
+		rd.EntityID = *req.EntityID
+	}
+
+	rd.IsManual = true
+	if err := s.repo.Update(rd); err != nil {
+		return nil, err
+	}
+
+	invalidateRedirectCache(rd.EntityType, previousSlug)
+	invalidateRedirectCache(rd.EntityType, rd.OldSlug)
+
+	rd.CurrentSlug, _ = s.repo.CurrentSlug(rd.EntityType, rd.EntityID)
+	return rd, nil
+}
+
+func (s *redirectService) Delete(id uint) error {
+	rd, err := s.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		return err
+	}
+
+	invalidateRedirectCache(rd.EntityType, rd.OldSlug)
+
+	return nil
+}
+
+// validateOldSlug normalizes the slug and makes sure no live entity owns it,
+// since live slugs are always served before redirects are consulted
+func (s *redirectService) validateOldSlug(entityType, oldSlug string) (string, error) {
+	normalized := slug.Make(oldSlug)
+	if normalized == "" {
+		return "", fmt.Errorf("%w: old_slug is required", ErrInvalidRedirect)
+	}
+
+	inUse, err := s.repo.SlugInUse(entityType, normalized)
+	if err != nil {
+		return "", err
+	}
+	if inUse {
+		return "", ErrRedirectSlugInUse
+	}
+
+	return normalized, nil
+}
+
+func (s *redirectService) validateTarget(entityType string, entityID uint) error {
+	if entityID == 0 {
+		return fmt.Errorf("%w: entity_id is required", ErrInvalidRedirect)
+	}
+
+	if _, err := s.repo.CurrentSlug(entityType, entityID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrRedirectTargetNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// resolveRedirect looks up where a retired slug points now. It returns
+// gorm.ErrRecordNotFound when there is no redirect or its target is gone.
+func resolveRedirect(repo repository.RedirectRepository, entityType, slug string) (*redirect.Target, error) {
+	cacheKey := fmt.Sprintf("redirect:%s:%s", entityType, slug)
+
+	var target redirect.Target
+
+	err := cache.GetOrSet(cacheKey, &target, 10*time.Minute, func() (interface{}, error) {
+		rd, err := repo.FindBySlug(entityType, slug)
+		if err != nil {
+			return nil, err
+		}
+
+		current, err := repo.CurrentSlug(entityType, rd.EntityID)
+		if err != nil {
+			return nil, err
+		}
+
+		return &redirect.Target{
+			EntityType: entityType,
+			EntityID:   rd.EntityID,
+			OldSlug:    slug,
+			Slug:       current,
+			Location:   redirect.Location(entityType, current),
+		}, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &target, nil
+}
+
+// slugMoved turns a failed slug lookup into a SlugMovedError when the slug
+// has a redirect. Any other outcome returns the original error.
+func slugMoved(repo repository.RedirectRepository, entityType, slug string, lookupErr error) error {
+	if !errors.Is(lookupErr, gorm.ErrRecordNotFound) {
+		return lookupErr
+	}
+
+	target, err := resolveRedirect(repo, entityType, slug)
+	if err != nil {
+		return lookupErr
+	}
+
+	return &SlugMovedError{Target: target}
+}
+
+// recordSlugChange keeps the old slug reachable after a rename
+func recordSlugChange(repo repository.RedirectRepository, entityType string, entityID uint, oldSlug, newSlug string) {
+	if oldSlug == "" || oldSlug == newSlug {
+		return
+	}
+
+	if err := repo.Record(entityType, entityID, oldSlug, newSlug); err != nil {
+		// Log error but don't fail the update
+		log.Printf("Warning: could not record slug redirect: %v", err)
+	}
+
+	// Redirects are resolved to the current slug, so every cached
+	// resolution for the entity type may now be stale
+	cache.DeletePattern(fmt.Sprintf("redirect:%s:*", entityType))
+}
+
+func invalidateRedirectCache(entityType, slug string) {
+	cache.Delete(fmt.Sprintf("redirect:%s:%s", entityType, slug))
+}
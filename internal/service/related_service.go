@@ -0,0 +1,302 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/healthcare-market-research/backend/internal/cache"
+	"github.com/healthcare-market-research/backend/internal/config"
+	"github.com/healthcare-market-research/backend/internal/domain/related"
+	"github.com/healthcare-market-research/backend/internal/repository"
+	"github.com/healthcare-market-research/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrRelationNotFound      = errors.New("relation not found")
+	ErrRelationExists        = errors.New("items are already related")
+	ErrInvalidRelation       = errors.New("invalid relation")
+	ErrRelatedRefreshRunning = errors.New("related content suggestions are already being refreshed")
+)
+
+// relatedLimit is how many related items are shown with a content item
+const relatedLimit = 6
+
+// RelatedService links reports, blogs and press releases to each other,
+// through relations editors make and suggestions they review
+type RelatedService interface {
+	// GetRelated returns the published items shown as related to one item
+	GetRelated(contentType string, id uint) ([]related.Item, error)
+
+	GetAll(filters repository.RelationFilters) ([]related.RelationDetail, int64, error)
+	Create(req *related.CreateRelationRequest, userID uint) (*related.RelationDetail, error)
+	Accept(id, userID uint) (*related.Relation, error)
+	Reject(id, userID uint) (*related.Relation, error)
+	Delete(id uint) (*related.Relation, error)
+
+	// Refresh recomputes the suggestions; Start runs it every configured
+	// interval
+	Refresh() (*related.RefreshResult, error)
+	Start(ctx context.Context)
+	Stop()
+}
+
+type relatedService struct {
+	repo    repository.RelatedRepository
+	config  *config.RelatedConfig
+	running sync.Mutex
+	ticker  *time.Ticker
+	stopCh  chan struct{}
+}
+
+func NewRelatedService(repo repository.RelatedRepository, cfg *config.RelatedConfig) RelatedService {
+	return &relatedService{
+		repo:   repo,
+		config: cfg,
+		stopCh: make(chan struct{}),
+	}
+}
+
+func (s *relatedService) GetRelated(contentType string, id uint) ([]related.Item, error) {
+	cacheKey := fmt.Sprintf("related:%s:%d", contentType, id)
+
+	var items []related.Item
+	err := cache.GetOrSet(cacheKey, &items, 10*time.Minute, func() (interface{}, error) {
+		found, err := s.repo.GetRelated(related.Ref{Type: contentType, ID: id}, relatedLimit)
+		if found == nil {
+			found = []related.Item{}
+		}
+		return found, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (s *relatedService) GetAll(filters repository.RelationFilters) ([]related.RelationDetail, int64, error) {
+	if filters.Status != "" && !related.ValidStatus(filters.Status) {
+		return nil, 0, fmt.Errorf("%w: unknown status %q", ErrInvalidRelation, filters.Status)
+	}
+	if filters.Content != nil && !filters.Content.Valid() {
+		return nil, 0, fmt.Errorf("%w: unknown content %s", ErrInvalidRelation, filters.Content)
+	}
+
+	relations, total, err := s.repo.GetAll(filters)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	details, err := s.withItems(relations)
+	if err != nil {
+		return nil, 0, err
+	}
+	return details, total, nil
+}
+
+// Create relates two items. A suggestion or rejected suggestion for the pair
+// becomes the curated relation.
+func (s *relatedService) Create(req *related.CreateRelationRequest, userID uint) (*related.RelationDetail, error) {
+	a := related.Ref{Type: req.SourceType, ID: req.SourceID}
+	b := related.Ref{Type: req.TargetType, ID: req.TargetID}
+	if !a.Valid() || !b.Valid() {
+		return nil, fmt.Errorf("%w: items must be a report, blog or press_release with an ID", ErrInvalidRelation)
+	}
+	if a == b {
+		return nil, fmt.Errorf("%w: an item cannot be related to itself", ErrInvalidRelation)
+	}
+
+	items, err := s.repo.Items([]related.Ref{a, b})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load items: %w", err)
+	}
+	for _, ref := range []related.Ref{a, b} {
+		if _, ok := items[ref]; !ok {
+			return nil, fmt.Errorf("%w: %s does not exist", ErrInvalidRelation, ref)
+		}
+	}
+
+	now := time.Now()
+	rel, err := s.repo.GetByPair(a, b)
+	switch {
+	case err == nil:
+		if rel.Status == related.StatusCurated || rel.Status == related.StatusAccepted {
+			return nil, ErrRelationExists
+		}
+		rel.Status = related.StatusCurated
+		rel.ReviewedBy = &userID
+		rel.ReviewedAt = &now
+		if err := s.repo.Update(rel); err != nil {
+			return nil, err
+		}
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		rel = &related.Relation{Status: related.StatusCurated, CreatedBy: &userID}
+		rel.SetPair(a, b)
+		if err := s.repo.Create(rel); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, err
+	}
+
+	invalidateRelatedCaches()
+
+	details, err := s.withItems([]related.Relation{*rel})
+	if err != nil {
+		return nil, err
+	}
+	return &details[0], nil
+}
+
+// Accept keeps a suggestion, so it is listed with the curated relations
+func (s *relatedService) Accept(id, userID uint) (*related.Relation, error) {
+	return s.review(id, userID, related.StatusAccepted)
+}
+
+// Reject dismisses a suggestion for good
+func (s *relatedService) Reject(id, userID uint) (*related.Relation, error) {
+	return s.review(id, userID, related.StatusRejected)
+}
+
+func (s *relatedService) review(id, userID uint, status string) (*related.Relation, error) {
+	rel, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, ErrRelationNotFound
+	}
+	if rel.Status == related.StatusCurated {
+		return nil, fmt.Errorf("%w: curated relations are not reviewed; delete them instead", ErrInvalidRelation)
+	}
+	if rel.Status == status {
+		return rel, nil
+	}
+
+	now := time.Now()
+	rel.Status = status
+	rel.ReviewedBy = &userID
+	rel.ReviewedAt = &now
+	if err := s.repo.Update(rel); err != nil {
+		return nil, err
+	}
+
+	invalidateRelatedCaches()
+	return rel, nil
+}
+
+// Delete removes a relation. A deleted suggestion can be suggested again;
+// reject it to keep it away.
+func (s *relatedService) Delete(id uint) (*related.Relation, error) {
+	rel, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, ErrRelationNotFound
+	}
+	if err := s.repo.Delete(id); err != nil {
+		return nil, err
+	}
+
+	invalidateRelatedCaches()
+	return rel, nil
+}
+
+func (s *relatedService) Refresh() (*related.RefreshResult, error) {
+	if !s.running.TryLock() {
+		return nil, ErrRelatedRefreshRunning
+	}
+	defer s.running.Unlock()
+
+	result := &related.RefreshResult{StartedAt: time.Now()}
+
+	profiles, err := s.repo.Profiles()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load content: %w", err)
+	}
+	suggestions := related.Suggest(profiles, related.MaxSuggestions, related.MinScore)
+
+	added, removed, err := s.repo.ReplaceSuggestions(suggestions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save suggestions: %w", err)
+	}
+
+	// Scores may have changed the order even when no pair did
+	invalidateRelatedCaches()
+
+	result.Items = len(profiles)
+	result.Suggested = len(suggestions)
+	result.Added = added
+	result.Removed = removed
+	result.Duration = time.Since(result.StartedAt).Round(time.Millisecond).String()
+	return result, nil
+}
+
+// Start refreshes the suggestions now and then every configured interval
+func (s *relatedService) Start(ctx context.Context) {
+	s.ticker = time.NewTicker(s.config.Interval)
+	logger.Info("Related content refresh started", "interval", s.config.Interval)
+
+	refresh := func() {
+		result, err := s.Refresh()
+		if err != nil {
+			if !errors.Is(err, ErrRelatedRefreshRunning) {
+				logger.Error("Related content refresh failed", "error", err)
+			}
+			return
+		}
+		logger.Info("Related content suggestions refreshed",
+			"items", result.Items, "suggested", result.Suggested, "added", result.Added, "removed", result.Removed)
+	}
+
+	go func() {
+		refresh()
+		for {
+			select {
+			case <-s.ticker.C:
+				refresh()
+			case <-s.stopCh:
+				logger.Info("Related content refresh stopped")
+				return
+			case <-ctx.Done():
+				logger.Info("Related content refresh context cancelled")
+				return
+			}
+		}
+	}()
+}
+
+func (s *relatedService) Stop() {
+	if s.ticker != nil {
+		s.ticker.Stop()
+	}
+	close(s.stopCh)
+}
+
+// withItems attaches the items each relation links
+func (s *relatedService) withItems(relations []related.Relation) ([]related.RelationDetail, error) {
+	refs := make([]related.Ref, 0, 2*len(relations))
+	for i := range relations {
+		refs = append(refs, relations[i].Source(), relations[i].Target())
+	}
+	items, err := s.repo.Items(refs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load items: %w", err)
+	}
+
+	details := make([]related.RelationDetail, len(relations))
+	for i, rel := range relations {
+		details[i] = related.RelationDetail{Relation: rel}
+		if item, ok := items[rel.Source()]; ok {
+			details[i].Source = &item
+		}
+		if item, ok := items[rel.Target()]; ok {
+			details[i].Target = &item
+		}
+	}
+	return details, nil
+}
+
+// invalidateRelatedCaches clears the cached related items of every content
+// item, since a relation shows on both of its ends
+func invalidateRelatedCaches() {
+	cache.DeletePattern("related:*")
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gosimple/slug"
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrReportSlugTaken = errors.New("report slug already in use")
+	ErrInvalidClone    = errors.New("invalid clone")
+)
+
+// Clone creates a draft from an existing report. The copy goes through
+// Create, so it gets the same checks, tags and first version as any new
+// report. Gallery images are copied when asked for; the copies point at the
+// same files, which the image collector keeps and Delete leaves in place
+// while any report uses them.
+func (s *reportService) Clone(id uint, req *report.CloneRequest, userID uint) (*report.CloneResult, error) {
+	original, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if original.DeletedAt != nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	clone := original.Clone()
+
+	clone.Title = strings.TrimSpace(req.Title)
+	if clone.Title == "" {
+		clone.Title = original.Title + " (Copy)"
+	}
+
+	if strings.TrimSpace(req.Slug) != "" {
+		clone.Slug = slug.Make(req.Slug)
+		if clone.Slug == "" {
+			return nil, fmt.Errorf("%w: slug cannot be empty", ErrInvalidClone)
+		}
+		taken, err := s.repo.SlugExists(clone.Slug, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check slug availability: %w", err)
+		}
+		if taken {
+			return nil, fmt.Errorf("%w: %s", ErrReportSlugTaken, clone.Slug)
+		}
+	} else {
+		clone.Slug, err = s.freeSlug(original.Slug + "-copy")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.Create(&clone, userID); err != nil {
+		return nil, err
+	}
+
+	result := &report.CloneResult{Report: &clone, ClonedFrom: original.ID}
+	if req.IncludeImages {
+		result.Images = s.copyImages(original.ID, clone.ID)
+	}
+	return result, nil
+}
+
+// freeSlug returns base, or base with the first number from 2 up that makes
+// it unused
+func (s *reportService) freeSlug(base string) (string, error) {
+	candidate := base
+	for n := 2; ; n++ {
+		taken, err := s.repo.SlugExists(candidate, 0)
+		if err != nil {
+			return "", fmt.Errorf("failed to check slug availability: %w", err)
+		}
+		if !taken {
+			return candidate, nil
+		}
+		candidate = fmt.Sprintf("%s-%d", base, n)
+	}
+}
+
+// copyImages copies the active gallery images of one report to another and
+// returns how many were copied. The clone already exists, so a failure is
+// logged rather than undoing it.
+func (s *reportService) copyImages(fromID, toID uint) int {
+	images, err := s.reportImageRepo.FindActiveByReportID(fromID)
+	if err != nil {
+		log.Printf("Warning: could not load images of report %d to clone: %v", fromID, err)
+		return 0
+	}
+
+	copied := 0
+	for _, image := range images {
+		image.ID = 0
+		image.ReportID = toID
+		image.Report = nil
+		image.CreatedAt, image.UpdatedAt = time.Time{}, time.Time{}
+		if err := s.reportImageRepo.Create(&image); err != nil {
+			log.Printf("Warning: could not copy image to cloned report %d: %v", toID, err)
+			continue
+		}
+		copied++
+	}
+	return copied
+}
+
+// ApplyTemplate fills what a new report leaves empty from a saved template
+func (s *reportService) ApplyTemplate(templateID uint, rep *report.Report) error {
+	t, err := s.templates.GetByID(templateID)
+	if err != nil {
+		return ErrTemplateNotFound
+	}
+	t.Apply(rep)
+	return nil
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/repository"
+	"github.com/healthcare-market-research/backend/internal/storage"
+	"github.com/healthcare-market-research/backend/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cloneReportRepository adds creating, slug checks and deleting to the
+// in-memory workflow repository
+type cloneReportRepository struct {
+	*workflowReportRepository
+	images *memoryReportImageRepository
+	nextID uint
+}
+
+func (m *cloneReportRepository) Create(rep *report.Report) error {
+	m.nextID++
+	rep.ID = m.nextID
+	copied := *rep
+	m.reports[rep.ID] = &copied
+	return nil
+}
+
+func (m *cloneReportRepository) SlugExists(slug string, excludeID uint) (bool, error) {
+	for id, rep := range m.reports {
+		if rep.Slug == slug && id != excludeID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Delete removes the report's images with it, like the foreign key cascade
+func (m *cloneReportRepository) Delete(id uint) error {
+	delete(m.reports, id)
+	kept := m.images.images[:0]
+	for _, image := range m.images.images {
+		if image.ReportID != id {
+			kept = append(kept, image)
+		}
+	}
+	m.images.images = kept
+	return nil
+}
+
+// memoryReportImageRepository keeps gallery images in memory
+type memoryReportImageRepository struct {
+	repository.ReportImageRepository
+	images []report.ReportImage
+}
+
+func (m *memoryReportImageRepository) Create(image *report.ReportImage) error {
+	image.ID = uint(len(m.images) + 1)
+	m.images = append(m.images, *image)
+	return nil
+}
+
+func (m *memoryReportImageRepository) FindByReportID(reportID uint) ([]report.ReportImage, error) {
+	var images []report.ReportImage
+	for _, image := range m.images {
+		if image.ReportID == reportID {
+			images = append(images, image)
+		}
+	}
+	return images, nil
+}
+
+func (m *memoryReportImageRepository) FindActiveByReportID(reportID uint) ([]report.ReportImage, error) {
+	return m.FindByReportID(reportID)
+}
+
+func (m *memoryReportImageRepository) SharedImageURLs(reportID uint) ([]string, error) {
+	var urls []string
+	for _, image := range m.images {
+		if image.ReportID == reportID {
+			continue
+		}
+		for _, own := range m.images {
+			if own.ReportID == reportID && own.ImageURL == image.ImageURL {
+				urls = append(urls, image.ImageURL)
+				break
+			}
+		}
+	}
+	return urls, nil
+}
+
+// noopTagRepository links tags without storing them
+type noopTagRepository struct {
+	repository.TagRepository
+}
+
+func (noopTagRepository) SetContentTags(contentType string, contentID uint, tagIDs []uint) error {
+	return nil
+}
+
+func TestReportService_Delete_KeepsImagesSharedWithClone(t *testing.T) {
+	logger.Init("test")
+	store := newLocalTestStorage(t)
+
+	images := &memoryReportImageRepository{}
+	repo := &cloneReportRepository{
+		workflowReportRepository: &workflowReportRepository{reports: map[uint]*report.Report{}},
+		images:                   images,
+		nextID:                   1000,
+	}
+	repo.reports[1] = &report.Report{
+		ID:        1,
+		Title:     "Cardiac Monitoring Market",
+		Slug:      "cardiac-monitoring-market",
+		Summary:   "Cardiac monitoring devices across hospital and home settings.",
+		Geography: report.StringSlice{"United States"},
+		Tags:      report.StringSlice{},
+	}
+
+	imageURL, variants, err := uploadImageWithVariants(store, pngFileHeader(t, 400, 300), nil)
+	require.NoError(t, err)
+	require.NoError(t, images.Create(&report.ReportImage{ReportID: 1, ImageURL: imageURL, Variants: variants, IsActive: true}))
+
+	s := &reportService{
+		repo:            repo,
+		reportImageRepo: images,
+		imageStorage:    store,
+		geographies:     stubGeographyService{},
+		tx:              mockTransactor{&repository.Tx{Reports: repo, Tags: noopTagRepository{}}},
+	}
+
+	result, err := s.Clone(1, &report.CloneRequest{IncludeImages: true}, 7)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Images)
+
+	require.NoError(t, s.Delete(1))
+
+	// The clone's gallery still has its files
+	for _, url := range append(variants.URLs(), imageURL) {
+		_, err := store.Stat(url)
+		assert.NoError(t, err, url)
+	}
+
+	// Deleting the last report using them removes them
+	require.NoError(t, s.Delete(result.Report.ID))
+	_, err = store.Stat(imageURL)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
@@ -1,82 +1,139 @@
 package service
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"mime/multipart"
 
 	"github.com/healthcare-market-research/backend/internal/domain/report"
 	"github.com/healthcare-market-research/backend/internal/repository"
+	"github.com/healthcare-market-research/backend/internal/storage"
+	"gorm.io/gorm"
 )
 
+var ErrInvalidImageOrder = errors.New("reorder must list every active image of the report exactly once")
+
 type ReportImageService interface {
-	UploadImage(reportID uint, file *multipart.FileHeader, title string, uploadedBy uint) (*report.ReportImage, error)
-	UpdateImageMetadata(imageID uint, title *string, isActive *bool) (*report.ReportImage, error)
+	// UploadImage stores an image for a report. An image with the same content
+	// already on the report is returned instead, with created set to false.
+	UploadImage(reportID uint, file *multipart.FileHeader, req *report.UploadImageRequest, uploadedBy uint) (image *report.ReportImage, created bool, err error)
+	UpdateImageMetadata(imageID uint, req *report.UpdateImageMetadataRequest) (*report.ReportImage, error)
+	ReorderImages(reportID uint, ids []uint) ([]report.ReportImage, error)
 	DeleteImage(imageID uint) error
 	GetImagesByReport(reportID uint, activeOnly bool) ([]report.ReportImage, error)
 	GetImageByID(imageID uint) (*report.ReportImage, error)
 }
 
 type reportImageService struct {
-	reportImageRepo   repository.ReportImageRepository
-	reportRepo        repository.ReportRepository
-	cloudflareService CloudflareImagesService
+	reportImageRepo repository.ReportImageRepository
+	reportRepo      repository.ReportRepository
+	imageStorage    storage.ImageStorage
 }
 
 func NewReportImageService(
 	reportImageRepo repository.ReportImageRepository,
 	reportRepo repository.ReportRepository,
-	cloudflareService CloudflareImagesService,
+	imageStorage storage.ImageStorage,
 ) ReportImageService {
 	return &reportImageService{
-		reportImageRepo:   reportImageRepo,
-		reportRepo:        reportRepo,
-		cloudflareService: cloudflareService,
+		reportImageRepo: reportImageRepo,
+		reportRepo:      reportRepo,
+		imageStorage:    imageStorage,
 	}
 }
 
 // UploadImage uploads an image for a report with rollback on failure
-func (s *reportImageService) UploadImage(reportID uint, file *multipart.FileHeader, title string, uploadedBy uint) (*report.ReportImage, error) {
+func (s *reportImageService) UploadImage(reportID uint, file *multipart.FileHeader, req *report.UploadImageRequest, uploadedBy uint) (*report.ReportImage, bool, error) {
 	// Validate that the report exists
 	_, err := s.reportRepo.GetByID(reportID)
 	if err != nil {
-		return nil, fmt.Errorf("report not found: %w", err)
+		return nil, false, fmt.Errorf("report not found: %w", err)
+	}
+
+	decoded, err := readImage(file)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// The same content is kept once per report; uploading a hidden image
+	// again brings it back at the end
+	hash := decoded.contentHash()
+	existing, err := s.reportImageRepo.FindByContentHash(reportID, hash)
+	if err == nil {
+		if !existing.IsActive {
+			if err := s.restoreImage(existing); err != nil {
+				return nil, false, err
+			}
+		}
+		return existing, false, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, fmt.Errorf("failed to check for duplicate image: %w", err)
+	}
+
+	sortOrder, err := s.reportImageRepo.NextSortOrder(reportID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to determine image position: %w", err)
 	}
 
-	// Upload to Cloudflare with metadata
+	// Upload to storage with metadata, rendering the variants alongside
 	metadata := map[string]string{
 		"report_id":   fmt.Sprintf("%d", reportID),
 		"type":        "report_image",
 		"uploaded_by": fmt.Sprintf("%d", uploadedBy),
 	}
 
-	imageURL, err := s.cloudflareService.Upload(file, metadata)
+	imageURL, variants, err := uploadDecodedImage(s.imageStorage, decoded, metadata)
 	if err != nil {
-		return nil, fmt.Errorf("failed to upload image: %w", err)
+		return nil, false, fmt.Errorf("failed to upload image: %w", err)
 	}
 
 	// Create database record
+	bounds := decoded.img.Bounds()
 	image := &report.ReportImage{
-		ReportID:   reportID,
-		ImageURL:   imageURL,
-		Title:      title,
-		IsActive:   true,
-		UploadedBy: &uploadedBy,
+		ReportID:    reportID,
+		ImageURL:    imageURL,
+		Variants:    variants,
+		Title:       req.Title,
+		AltText:     req.AltText,
+		Caption:     req.Caption,
+		SortOrder:   sortOrder,
+		Width:       bounds.Dx(),
+		Height:      bounds.Dy(),
+		ByteSize:    int64(len(decoded.data)),
+		ContentHash: hash,
+		MimeType:    "image/" + decoded.format,
+		IsActive:    true,
+		UploadedBy:  &uploadedBy,
 	}
 
 	if err := s.reportImageRepo.Create(image); err != nil {
-		// Rollback: delete the uploaded image from Cloudflare
-		if deleteErr := s.cloudflareService.Delete(imageURL); deleteErr != nil {
-			log.Printf("Warning: Failed to rollback image upload for report %d: %v", reportID, deleteErr)
-		}
-		return nil, fmt.Errorf("failed to create image record: %w", err)
+		// Rollback: delete the uploaded image from storage
+		log.Printf("Warning: Rolling back image upload for report %d", reportID)
+		deleteImageWithVariants(s.imageStorage, imageURL, variants)
+		return nil, false, fmt.Errorf("failed to create image record: %w", err)
 	}
 
-	return image, nil
+	return image, true, nil
+}
+
+// restoreImage reactivates a hidden image and moves it after the others
+func (s *reportImageService) restoreImage(image *report.ReportImage) error {
+	sortOrder, err := s.reportImageRepo.NextSortOrder(image.ReportID)
+	if err != nil {
+		return fmt.Errorf("failed to determine image position: %w", err)
+	}
+	image.IsActive = true
+	image.SortOrder = sortOrder
+	if err := s.reportImageRepo.Update(image); err != nil {
+		return fmt.Errorf("failed to restore image: %w", err)
+	}
+	return nil
 }
 
-// UpdateImageMetadata updates image title and/or is_active status (partial updates supported)
-func (s *reportImageService) UpdateImageMetadata(imageID uint, title *string, isActive *bool) (*report.ReportImage, error) {
+// UpdateImageMetadata updates the text, position and is_active status of an image (partial updates supported)
+func (s *reportImageService) UpdateImageMetadata(imageID uint, req *report.UpdateImageMetadataRequest) (*report.ReportImage, error) {
 	// Get the existing image
 	image, err := s.reportImageRepo.FindByID(imageID)
 	if err != nil {
@@ -84,12 +141,24 @@ func (s *reportImageService) UpdateImageMetadata(imageID uint, title *string, is
 	}
 
 	// Apply partial updates
-	if title != nil {
-		image.Title = *title
+	if req.Title != nil {
+		image.Title = *req.Title
 	}
 
-	if isActive != nil {
-		image.IsActive = *isActive
+	if req.AltText != nil {
+		image.AltText = *req.AltText
+	}
+
+	if req.Caption != nil {
+		image.Caption = *req.Caption
+	}
+
+	if req.SortOrder != nil {
+		image.SortOrder = *req.SortOrder
+	}
+
+	if req.IsActive != nil {
+		image.IsActive = *req.IsActive
 	}
 
 	// Update in database
@@ -100,7 +169,42 @@ func (s *reportImageService) UpdateImageMetadata(imageID uint, title *string, is
 	return image, nil
 }
 
-// DeleteImage performs soft delete (sets is_active=false, keeps Cloudflare image)
+// ReorderImages puts a report's active images in the order of ids, which
+// must name each of them exactly once. Inactive images move after them.
+func (s *reportImageService) ReorderImages(reportID uint, ids []uint) ([]report.ReportImage, error) {
+	images, err := s.reportImageRepo.FindByReportID(reportID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch images: %w", err)
+	}
+
+	active := map[uint]bool{}
+	var inactive []uint
+	for _, image := range images {
+		if image.IsActive {
+			active[image.ID] = true
+		} else {
+			inactive = append(inactive, image.ID)
+		}
+	}
+	if len(ids) != len(active) {
+		return nil, ErrInvalidImageOrder
+	}
+	for _, id := range ids {
+		if !active[id] {
+			return nil, ErrInvalidImageOrder
+		}
+		delete(active, id)
+	}
+
+	order := append(append([]uint{}, ids...), inactive...)
+	if err := s.reportImageRepo.Reorder(reportID, order); err != nil {
+		return nil, fmt.Errorf("failed to reorder images: %w", err)
+	}
+
+	return s.reportImageRepo.FindActiveByReportID(reportID)
+}
+
+// DeleteImage performs soft delete (sets is_active=false, keeps the stored image)
 func (s *reportImageService) DeleteImage(imageID uint) error {
 	// Verify image exists
 	_, err := s.reportImageRepo.FindByID(imageID)
@@ -113,7 +217,7 @@ func (s *reportImageService) DeleteImage(imageID uint) error {
 		return fmt.Errorf("failed to delete image: %w", err)
 	}
 
-	// Note: We don't delete from Cloudflare to allow potential restore
+	// Note: We don't delete from storage to allow potential restore
 	return nil
 }
 
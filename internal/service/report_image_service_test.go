@@ -1,10 +1,22 @@
 package service
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/healthcare-market-research/backend/internal/config"
 	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/repository"
+	"github.com/healthcare-market-research/backend/internal/storage"
+	"github.com/healthcare-market-research/backend/pkg/pagination"
+	"gorm.io/gorm"
 )
 
 // Mock ReportImageRepository for testing
@@ -13,10 +25,13 @@ type mockReportImageRepository struct {
 	findByIDFunc              func(id uint) (*report.ReportImage, error)
 	findByReportIDFunc        func(reportID uint) ([]report.ReportImage, error)
 	findActiveByReportIDFunc  func(reportID uint) ([]report.ReportImage, error)
+	findByContentHashFunc     func(reportID uint, hash string) (*report.ReportImage, error)
+	reorderFunc               func(reportID uint, ids []uint) error
 	updateFunc                func(image *report.ReportImage) error
 	softDeleteFunc            func(id uint) error
 	countByReportIDFunc       func(reportID uint) (int64, error)
 	countActiveByReportIDFunc func(reportID uint) (int64, error)
+	sharedImageURLsFunc       func(reportID uint) ([]string, error)
 }
 
 func (m *mockReportImageRepository) Create(image *report.ReportImage) error {
@@ -56,6 +71,24 @@ func (m *mockReportImageRepository) FindActiveByReportID(reportID uint) ([]repor
 	return []report.ReportImage{}, nil
 }
 
+func (m *mockReportImageRepository) FindByContentHash(reportID uint, hash string) (*report.ReportImage, error) {
+	if m.findByContentHashFunc != nil {
+		return m.findByContentHashFunc(reportID, hash)
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *mockReportImageRepository) NextSortOrder(reportID uint) (int, error) {
+	return 0, nil
+}
+
+func (m *mockReportImageRepository) Reorder(reportID uint, ids []uint) error {
+	if m.reorderFunc != nil {
+		return m.reorderFunc(reportID, ids)
+	}
+	return nil
+}
+
 func (m *mockReportImageRepository) Update(image *report.ReportImage) error {
 	if m.updateFunc != nil {
 		return m.updateFunc(image)
@@ -84,6 +117,13 @@ func (m *mockReportImageRepository) CountActiveByReportID(reportID uint) (int64,
 	return 0, nil
 }
 
+func (m *mockReportImageRepository) SharedImageURLs(reportID uint) ([]string, error) {
+	if m.sharedImageURLsFunc != nil {
+		return m.sharedImageURLsFunc(reportID)
+	}
+	return nil, nil
+}
+
 // Mock ReportRepository for testing
 type mockReportRepository struct {
 	getByIDFunc func(id uint) (*report.Report, error)
@@ -96,14 +136,18 @@ func (m *mockReportRepository) GetByID(id uint) (*report.Report, error) {
 	return &report.Report{ID: id, Title: "Test Report"}, nil
 }
 
-func (m *mockReportRepository) GetAll(page, limit int) ([]report.Report, int64, error) {
+func (m *mockReportRepository) GetAll(params pagination.Params) ([]report.Report, int64, error) {
 	return nil, 0, nil
 }
 
-func (m *mockReportRepository) GetAllWithFilters(filters interface{}) ([]report.Report, int64, error) {
+func (m *mockReportRepository) GetAllWithFilters(filters repository.ReportFilters) ([]report.Report, int64, error) {
 	return nil, 0, nil
 }
 
+func (m *mockReportRepository) GetFacets(filters repository.ReportFilters) (*report.ReportFacets, error) {
+	return nil, nil
+}
+
 func (m *mockReportRepository) GetBySlug(slug string) (*report.ReportWithRelations, error) {
 	return nil, nil
 }
@@ -112,7 +156,23 @@ func (m *mockReportRepository) GetByIDWithRelations(id uint) (*report.ReportWith
 	return nil, nil
 }
 
-func (m *mockReportRepository) GetByCategorySlug(categorySlug string, page, limit int) ([]report.Report, int64, error) {
+func (m *mockReportRepository) GetPublishedByIDs(ids []uint) ([]report.Report, error) {
+	return nil, nil
+}
+
+func (m *mockReportRepository) GetBySlugs(slugs []string) ([]report.Report, error) {
+	return nil, nil
+}
+
+func (m *mockReportRepository) SlugExists(slug string, excludeID uint) (bool, error) {
+	return false, nil
+}
+
+func (m *mockReportRepository) GetByCategorySlug(categorySlug string, includeDescendants bool, params pagination.Params) ([]report.Report, int64, error) {
+	return nil, 0, nil
+}
+
+func (m *mockReportRepository) GetByAuthorID(authorID uint, params pagination.Params) ([]report.Report, int64, error) {
 	return nil, 0, nil
 }
 
@@ -136,6 +196,54 @@ func (m *mockReportRepository) Delete(id uint) error {
 	return nil
 }
 
+func (m *mockReportRepository) SoftDelete(id uint) error {
+	return nil
+}
+
+func (m *mockReportRepository) Restore(id uint) error {
+	return nil
+}
+
+func (m *mockReportRepository) CreateVersion(version *report.ReportVersion) error {
+	return nil
+}
+
+func (m *mockReportRepository) GetVersionsByReportID(reportID uint) ([]report.ReportVersion, error) {
+	return nil, nil
+}
+
+func (m *mockReportRepository) GetVersion(reportID uint, versionNumber int) (*report.ReportVersion, error) {
+	return nil, nil
+}
+
+func (m *mockReportRepository) GetLatestVersionNumber(reportID uint) (int, error) {
+	return 0, nil
+}
+
+func (m *mockReportRepository) UpdateWorkflow(id uint, fromStatus string, updates map[string]interface{}) error {
+	return nil
+}
+
+func (m *mockReportRepository) CreateReviewComment(comment *report.ReviewComment) error {
+	return nil
+}
+
+func (m *mockReportRepository) GetReviewComments(reportID uint) ([]report.ReviewComment, error) {
+	return nil, nil
+}
+
+func (m *mockReportRepository) GetScheduledDue(now time.Time) ([]report.Report, error) {
+	return nil, nil
+}
+
+func (m *mockReportRepository) SchedulePublish(id uint, publishDate time.Time) error {
+	return nil
+}
+
+func (m *mockReportRepository) CancelScheduledPublish(id uint) error {
+	return nil
+}
+
 func TestReportImageService_UploadImage_Success(t *testing.T) {
 	uploadedImageURL := "https://imagedelivery.net/test/new-image-id/public"
 	userID := uint(5)
@@ -169,7 +277,7 @@ func TestReportImageService_UploadImage_Success(t *testing.T) {
 	}
 
 	mockCloudflare := &mockCloudflareService{
-		uploadFunc: func(file interface{}, metadata map[string]string) (string, error) {
+		uploadFunc: func(filename string, metadata map[string]string) (string, error) {
 			if metadata["report_id"] != "1" {
 				t.Errorf("Expected report_id metadata '1', got '%s'", metadata["report_id"])
 			}
@@ -184,9 +292,9 @@ func TestReportImageService_UploadImage_Success(t *testing.T) {
 	}
 
 	service := NewReportImageService(mockReportImageRepo, mockReportRepo, mockCloudflare)
-	fileHeader := createTestFileHeader("chart.png", "fake image content")
+	fileHeader := pngFileHeader(t, 40, 20)
 
-	image, err := service.UploadImage(1, fileHeader, "Test Chart", userID)
+	image, _, err := service.UploadImage(1, fileHeader, &report.UploadImageRequest{Title: "Test Chart"}, userID)
 	if err != nil {
 		t.Errorf("UploadImage() error = %v, want nil", err)
 	}
@@ -215,15 +323,15 @@ func TestReportImageService_UploadImage_WithoutTitle(t *testing.T) {
 
 	mockReportRepo := &mockReportRepository{}
 	mockCloudflare := &mockCloudflareService{
-		uploadFunc: func(file interface{}, metadata map[string]string) (string, error) {
+		uploadFunc: func(filename string, metadata map[string]string) (string, error) {
 			return uploadedImageURL, nil
 		},
 	}
 
 	service := NewReportImageService(mockReportImageRepo, mockReportRepo, mockCloudflare)
-	fileHeader := createTestFileHeader("chart.png", "fake image content")
+	fileHeader := pngFileHeader(t, 40, 20)
 
-	image, err := service.UploadImage(1, fileHeader, "", userID)
+	image, _, err := service.UploadImage(1, fileHeader, &report.UploadImageRequest{}, userID)
 	if err != nil {
 		t.Errorf("UploadImage() error = %v, want nil", err)
 	}
@@ -243,9 +351,9 @@ func TestReportImageService_UploadImage_ReportNotFound(t *testing.T) {
 	mockCloudflare := &mockCloudflareService{}
 
 	service := NewReportImageService(mockReportImageRepo, mockReportRepo, mockCloudflare)
-	fileHeader := createTestFileHeader("chart.png", "fake image content")
+	fileHeader := pngFileHeader(t, 40, 20)
 
-	_, err := service.UploadImage(999, fileHeader, "Test Chart", 5)
+	_, _, err := service.UploadImage(999, fileHeader, &report.UploadImageRequest{Title: "Test Chart"}, 5)
 	if err == nil {
 		t.Error("Expected error for non-existent report, got nil")
 	}
@@ -259,15 +367,15 @@ func TestReportImageService_UploadImage_CloudflareUploadFails(t *testing.T) {
 		},
 	}
 	mockCloudflare := &mockCloudflareService{
-		uploadFunc: func(file interface{}, metadata map[string]string) (string, error) {
+		uploadFunc: func(filename string, metadata map[string]string) (string, error) {
 			return "", errors.New("cloudflare API error")
 		},
 	}
 
 	service := NewReportImageService(mockReportImageRepo, mockReportRepo, mockCloudflare)
-	fileHeader := createTestFileHeader("chart.png", "fake image content")
+	fileHeader := pngFileHeader(t, 40, 20)
 
-	_, err := service.UploadImage(1, fileHeader, "Test Chart", 5)
+	_, _, err := service.UploadImage(1, fileHeader, &report.UploadImageRequest{Title: "Test Chart"}, 5)
 	if err == nil {
 		t.Error("Expected error when Cloudflare upload fails, got nil")
 	}
@@ -290,7 +398,7 @@ func TestReportImageService_UploadImage_DatabaseCreateFails_Rollback(t *testing.
 	}
 
 	mockCloudflare := &mockCloudflareService{
-		uploadFunc: func(file interface{}, metadata map[string]string) (string, error) {
+		uploadFunc: func(filename string, metadata map[string]string) (string, error) {
 			return uploadedImageURL, nil
 		},
 		deleteFunc: func(imageURL string) error {
@@ -303,9 +411,9 @@ func TestReportImageService_UploadImage_DatabaseCreateFails_Rollback(t *testing.
 	}
 
 	service := NewReportImageService(mockReportImageRepo, mockReportRepo, mockCloudflare)
-	fileHeader := createTestFileHeader("chart.png", "fake image content")
+	fileHeader := pngFileHeader(t, 40, 20)
 
-	_, err := service.UploadImage(1, fileHeader, "Test Chart", 5)
+	_, _, err := service.UploadImage(1, fileHeader, &report.UploadImageRequest{Title: "Test Chart"}, 5)
 	if err == nil {
 		t.Error("Expected error when database create fails, got nil")
 	}
@@ -347,7 +455,7 @@ func TestReportImageService_UpdateImageMetadata_Success(t *testing.T) {
 
 	service := NewReportImageService(mockReportImageRepo, mockReportRepo, mockCloudflare)
 
-	updatedImage, err := service.UpdateImageMetadata(1, &newTitle, &isActive)
+	updatedImage, err := service.UpdateImageMetadata(1, &report.UpdateImageMetadataRequest{Title: &newTitle, IsActive: &isActive})
 	if err != nil {
 		t.Errorf("UpdateImageMetadata() error = %v, want nil", err)
 	}
@@ -391,7 +499,7 @@ func TestReportImageService_UpdateImageMetadata_PartialUpdate_TitleOnly(t *testi
 
 	service := NewReportImageService(mockReportImageRepo, mockReportRepo, mockCloudflare)
 
-	updatedImage, err := service.UpdateImageMetadata(1, &newTitle, nil)
+	updatedImage, err := service.UpdateImageMetadata(1, &report.UpdateImageMetadataRequest{Title: &newTitle})
 	if err != nil {
 		t.Errorf("UpdateImageMetadata() error = %v, want nil", err)
 	}
@@ -417,7 +525,7 @@ func TestReportImageService_UpdateImageMetadata_ImageNotFound(t *testing.T) {
 	service := NewReportImageService(mockReportImageRepo, mockReportRepo, mockCloudflare)
 
 	newTitle := "Updated Title"
-	_, err := service.UpdateImageMetadata(999, &newTitle, nil)
+	_, err := service.UpdateImageMetadata(999, &report.UpdateImageMetadataRequest{Title: &newTitle})
 	if err == nil {
 		t.Error("Expected error for non-existent image, got nil")
 	}
@@ -590,3 +698,173 @@ func TestReportImageService_GetImageByID_NotFound(t *testing.T) {
 		t.Error("Expected error for non-existent image, got nil")
 	}
 }
+
+// pngFileHeader wraps a generated width x height PNG in a multipart file header
+func pngFileHeader(t *testing.T, width, height int) *multipart.FileHeader {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatalf("failed to encode PNG: %v", err)
+	}
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("image", "chart.png")
+	part.Write(buf.Bytes())
+	writer.Close()
+
+	req := httptest.NewRequest("POST", "/", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.ParseMultipartForm(10 << 20)
+	_, fileHeader, _ := req.FormFile("image")
+	return fileHeader
+}
+
+func newLocalTestStorage(t *testing.T) storage.ImageStorage {
+	t.Helper()
+	store, err := storage.NewLocalStorage(&config.LocalStorageConfig{Dir: t.TempDir(), BaseURL: "http://localhost:8081/uploads"})
+	if err != nil {
+		t.Fatalf("failed to create local storage: %v", err)
+	}
+	return store
+}
+
+func countStoredImages(t *testing.T, store storage.ImageStorage) int {
+	t.Helper()
+	count := 0
+	if err := store.List(func(*storage.ImageInfo) error { count++; return nil }); err != nil {
+		t.Fatalf("failed to list images: %v", err)
+	}
+	return count
+}
+
+func TestReportImageService_UploadImage_RecordsFileDetails(t *testing.T) {
+	var created *report.ReportImage
+	mockReportImageRepo := &mockReportImageRepository{
+		createFunc: func(image *report.ReportImage) error {
+			image.ID = 1
+			created = image
+			return nil
+		},
+	}
+
+	service := NewReportImageService(mockReportImageRepo, &mockReportRepository{}, newLocalTestStorage(t))
+	fileHeader := pngFileHeader(t, 40, 20)
+
+	image, isNew, err := service.UploadImage(1, fileHeader, &report.UploadImageRequest{AltText: "Empty chart", Caption: "Source: test"}, 5)
+	if err != nil {
+		t.Fatalf("UploadImage() error = %v, want nil", err)
+	}
+	if !isNew || created == nil {
+		t.Fatal("Expected a new image record")
+	}
+
+	if image.Width != 40 || image.Height != 20 {
+		t.Errorf("Expected 40x20, got %dx%d", image.Width, image.Height)
+	}
+	if image.ByteSize != fileHeader.Size {
+		t.Errorf("Expected byte size %d, got %d", fileHeader.Size, image.ByteSize)
+	}
+	if image.MimeType != "image/png" {
+		t.Errorf("Expected mime type image/png, got %s", image.MimeType)
+	}
+	if len(image.ContentHash) != 64 {
+		t.Errorf("Expected hex SHA-256 content hash, got %q", image.ContentHash)
+	}
+	if image.AltText != "Empty chart" || image.Caption != "Source: test" {
+		t.Errorf("Expected alt text and caption to be saved, got %q and %q", image.AltText, image.Caption)
+	}
+}
+
+func TestReportImageService_UploadImage_DeduplicatesContent(t *testing.T) {
+	store := newLocalTestStorage(t)
+	var existing *report.ReportImage
+	var updated *report.ReportImage
+
+	mockReportImageRepo := &mockReportImageRepository{
+		findByContentHashFunc: func(reportID uint, hash string) (*report.ReportImage, error) {
+			if existing == nil || existing.ContentHash != hash {
+				return nil, gorm.ErrRecordNotFound
+			}
+			return existing, nil
+		},
+		createFunc: func(image *report.ReportImage) error {
+			image.ID = 1
+			existing = image
+			return nil
+		},
+		updateFunc: func(image *report.ReportImage) error {
+			updated = image
+			return nil
+		},
+	}
+
+	service := NewReportImageService(mockReportImageRepo, &mockReportRepository{}, store)
+
+	first, isNew, err := service.UploadImage(1, pngFileHeader(t, 30, 30), &report.UploadImageRequest{}, 5)
+	if err != nil || !isNew {
+		t.Fatalf("UploadImage() = %v, %v; want a new image", isNew, err)
+	}
+	stored := countStoredImages(t, store)
+
+	// Same content again: the existing image comes back and nothing is stored
+	second, isNew, err := service.UploadImage(1, pngFileHeader(t, 30, 30), &report.UploadImageRequest{}, 5)
+	if err != nil {
+		t.Fatalf("UploadImage() error = %v, want nil", err)
+	}
+	if isNew || second.ID != first.ID {
+		t.Errorf("Expected existing image %d, got %d (new: %v)", first.ID, second.ID, isNew)
+	}
+	if got := countStoredImages(t, store); got != stored {
+		t.Errorf("Expected %d stored files, got %d", stored, got)
+	}
+	if updated != nil {
+		t.Error("Expected an active duplicate to be left unchanged")
+	}
+
+	// A deleted duplicate is reactivated
+	existing.IsActive = false
+	third, _, err := service.UploadImage(1, pngFileHeader(t, 30, 30), &report.UploadImageRequest{}, 5)
+	if err != nil {
+		t.Fatalf("UploadImage() error = %v, want nil", err)
+	}
+	if !third.IsActive || updated == nil {
+		t.Error("Expected the deleted duplicate to be reactivated")
+	}
+}
+
+func TestReportImageService_ReorderImages(t *testing.T) {
+	var reordered []uint
+	mockReportImageRepo := &mockReportImageRepository{
+		findByReportIDFunc: func(reportID uint) ([]report.ReportImage, error) {
+			return []report.ReportImage{
+				{ID: 1, ReportID: reportID, IsActive: true},
+				{ID: 2, ReportID: reportID, IsActive: false},
+				{ID: 3, ReportID: reportID, IsActive: true},
+			}, nil
+		},
+		reorderFunc: func(reportID uint, ids []uint) error {
+			reordered = ids
+			return nil
+		},
+	}
+
+	service := NewReportImageService(mockReportImageRepo, &mockReportRepository{}, &mockCloudflareService{})
+
+	for _, ids := range [][]uint{{3}, {3, 3}, {3, 2}, {3, 1, 2}} {
+		if _, err := service.ReorderImages(1, ids); !errors.Is(err, ErrInvalidImageOrder) {
+			t.Errorf("ReorderImages(%v) error = %v, want ErrInvalidImageOrder", ids, err)
+		}
+	}
+	if reordered != nil {
+		t.Fatal("Expected invalid orders to be rejected before saving")
+	}
+
+	if _, err := service.ReorderImages(1, []uint{3, 1}); err != nil {
+		t.Fatalf("ReorderImages() error = %v, want nil", err)
+	}
+	// Inactive images keep their place after the active ones
+	if want := []uint{3, 1, 2}; fmt.Sprint(reordered) != fmt.Sprint(want) {
+		t.Errorf("Expected order %v, got %v", want, reordered)
+	}
+}
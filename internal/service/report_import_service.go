@@ -0,0 +1,213 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/repository"
+)
+
+var ErrInvalidImport = errors.New("invalid import file")
+
+type ReportImportService interface {
+	// Import creates or updates a report for every row of a CSV or NDJSON
+	// file, matching reports by slug. Rows are checked and saved one at a
+	// time: a failed row is reported and the others still go through. With
+	// dryRun every row is checked and nothing is written.
+	Import(file io.Reader, format string, dryRun bool, userID uint) (*report.ImportResult, error)
+}
+
+type reportImportService struct {
+	reports    ReportService
+	repo       repository.ReportRepository
+	categories repository.CategoryRepository
+	authors    repository.AuthorRepository
+}
+
+func NewReportImportService(reports ReportService, repo repository.ReportRepository, categories repository.CategoryRepository, authors repository.AuthorRepository) ReportImportService {
+	return &reportImportService{
+		reports:    reports,
+		repo:       repo,
+		categories: categories,
+		authors:    authors,
+	}
+}
+
+// importLookup holds what the rows of one import refer to, loaded up front
+type importLookup struct {
+	categories  map[string][]uint // By lowercase name and slug
+	categoryIDs map[uint]bool
+	authors     map[string][]uint // By lowercase name
+	existing    map[string]*report.Report
+	rows        map[string]int // First row of each slug
+}
+
+func (s *reportImportService) Import(file io.Reader, format string, dryRun bool, userID uint) (*report.ImportResult, error) {
+	rows, err := report.ParseImport(file, format)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImport, err)
+	}
+
+	lookup, err := s.load(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &report.ImportResult{DryRun: dryRun, Rows: make([]report.ImportRowResult, 0, len(rows))}
+	for i := range rows {
+		result.Add(s.importRow(&rows[i], lookup, dryRun, userID))
+	}
+	return result, nil
+}
+
+// load fetches the categories, authors and existing reports the rows name
+func (s *reportImportService) load(rows []report.ImportRow) (*importLookup, error) {
+	lookup := &importLookup{
+		categories:  map[string][]uint{},
+		categoryIDs: map[uint]bool{},
+		authors:     map[string][]uint{},
+		existing:    map[string]*report.Report{},
+		rows:        map[string]int{},
+	}
+
+	categories, err := s.categories.GetAllActive()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load categories: %w", err)
+	}
+	for _, c := range categories {
+		lookup.categoryIDs[c.ID] = true
+		lookup.addCategory(strings.ToLower(c.Name), c.ID)
+		lookup.addCategory(strings.ToLower(c.Slug), c.ID)
+	}
+
+	var slugs, names []string
+	for _, row := range rows {
+		if row.Slug != "" {
+			slugs = append(slugs, row.Slug)
+		}
+		names = append(names, row.Authors...)
+	}
+
+	authors, err := s.authors.GetByNames(names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load authors: %w", err)
+	}
+	for _, a := range authors {
+		key := strings.ToLower(strings.TrimSpace(a.Name))
+		lookup.authors[key] = append(lookup.authors[key], a.ID)
+	}
+
+	existing, err := s.repo.GetBySlugs(slugs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load reports: %w", err)
+	}
+	for i := range existing {
+		lookup.existing[existing[i].Slug] = &existing[i]
+	}
+
+	return lookup, nil
+}
+
+func (l *importLookup) addCategory(key string, id uint) {
+	for _, known := range l.categories[key] {
+		if known == id {
+			return
+		}
+	}
+	l.categories[key] = append(l.categories[key], id)
+}
+
+// importRow checks one row and, unless dryRun, saves it
+func (s *reportImportService) importRow(row *report.ImportRow, lookup *importLookup, dryRun bool, userID uint) report.ImportRowResult {
+	result := report.ImportRowResult{Row: row.Row, Slug: row.Slug}
+	fail := func(err error) report.ImportRowResult {
+		result.Action = ""
+		result.Error = err.Error()
+		return result
+	}
+
+	if row.Err != nil {
+		return fail(row.Err)
+	}
+	if row.Slug != "" {
+		if first, ok := lookup.rows[row.Slug]; ok {
+			return fail(fmt.Errorf("slug already imported by row %d", first))
+		}
+		lookup.rows[row.Slug] = row.Row
+	}
+
+	rep := &report.Report{}
+	result.Action = report.ImportCreate
+	if existing, ok := lookup.existing[row.Slug]; ok {
+		if existing.DeletedAt != nil {
+			return fail(errors.New("slug belongs to a deleted report; restore it before importing"))
+		}
+		rep = existing
+		result.Action = report.ImportUpdate
+		result.ReportID = existing.ID
+	}
+
+	categoryID := rep.CategoryID
+	if err := row.Apply(rep); err != nil {
+		return fail(err)
+	}
+	if err := lookup.resolve(row, rep, categoryID); err != nil {
+		return fail(err)
+	}
+	if err := s.reports.Validate(rep); err != nil {
+		return fail(err)
+	}
+	if dryRun {
+		return result
+	}
+
+	if result.Action == report.ImportUpdate {
+		if err := s.reports.Update(rep.ID, rep, userID); err != nil {
+			return fail(err)
+		}
+		return result
+	}
+	if err := s.reports.Create(rep, userID); err != nil {
+		return fail(err)
+	}
+	result.ReportID = rep.ID
+	return result
+}
+
+// resolve sets the category and author IDs the row names. A category ID set
+// directly must be an active category unless it is the one stored already.
+func (l *importLookup) resolve(row *report.ImportRow, rep *report.Report, storedCategoryID uint) error {
+	if row.Category != "" {
+		ids := l.categories[strings.ToLower(row.Category)]
+		switch len(ids) {
+		case 0:
+			return fmt.Errorf("unknown category %q", row.Category)
+		case 1:
+			rep.CategoryID = ids[0]
+		default:
+			return fmt.Errorf("category %q matches more than one category; use its slug", row.Category)
+		}
+	} else if rep.CategoryID != 0 && rep.CategoryID != storedCategoryID && !l.categoryIDs[rep.CategoryID] {
+		return fmt.Errorf("category %d does not exist", rep.CategoryID)
+	}
+
+	if len(row.Authors) > 0 {
+		ids := make(report.UintSlice, 0, len(row.Authors))
+		for _, name := range row.Authors {
+			found := l.authors[strings.ToLower(strings.TrimSpace(name))]
+			switch len(found) {
+			case 0:
+				return fmt.Errorf("unknown author %q", name)
+			case 1:
+				ids = append(ids, found[0])
+			default:
+				return fmt.Errorf("author %q matches more than one author; use author_ids", name)
+			}
+		}
+		rep.AuthorIDs = ids
+	}
+	return nil
+}
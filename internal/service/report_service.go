@@ -4,47 +4,106 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/healthcare-market-research/backend/internal/cache"
+	"github.com/healthcare-market-research/backend/internal/domain/company"
+	"github.com/healthcare-market-research/backend/internal/domain/redirect"
 	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/domain/seo"
+	"github.com/healthcare-market-research/backend/internal/domain/tag"
 	"github.com/healthcare-market-research/backend/internal/repository"
+	"github.com/healthcare-market-research/backend/internal/storage"
+	"github.com/healthcare-market-research/backend/pkg/pagination"
+	"gorm.io/gorm"
 )
 
 type ReportService interface {
-	GetAll(page, limit int) ([]report.Report, int64, error)
+	GetAll(params pagination.Params) ([]report.Report, int64, error)
 	GetAllWithFilters(filters repository.ReportFilters) ([]report.Report, int64, error)
+	GetFacets(filters repository.ReportFilters) (*report.ReportFacets, error)
 	GetByID(id uint) (*report.ReportWithRelations, error)
 	GetBySlug(slug string) (*report.ReportWithRelations, error)
-	GetByCategorySlug(categorySlug string, page, limit int) ([]report.Report, int64, error)
-	GetByAuthorID(authorID uint, page, limit int) ([]report.Report, int64, error)
+	GetByCategorySlug(categorySlug string, includeDescendants bool, params pagination.Params) ([]report.Report, int64, error)
+	GetByAuthorID(authorID uint, params pagination.Params) ([]report.Report, int64, error)
 	Search(query string, page, limit int) ([]report.Report, int64, error)
+	Compare(ids []uint) (*report.Comparison, error)
 	Create(rep *report.Report, userID uint) error
+	// Validate checks a report as saving it through the API would, without
+	// writing anything. A report with an ID is checked as an update.
+	Validate(rep *report.Report) error
+	Clone(id uint, req *report.CloneRequest, userID uint) (*report.CloneResult, error)
+	ApplyTemplate(templateID uint, rep *report.Report) error
 	Update(id uint, rep *report.Report, userID uint) error
 	Delete(id uint) error
 	SoftDelete(id uint) error
 	Restore(id uint) error
 	SchedulePublish(id uint, publishDate time.Time) (*report.Report, error)
 	CancelScheduledPublish(id uint) (*report.Report, error)
+
+	// Version history
+	ListVersions(reportID uint) ([]report.ReportVersion, error)
+	GetVersion(reportID uint, versionNumber int) (*report.ReportVersion, error)
+	DiffVersions(reportID uint, fromVersion, toVersion int) (*report.VersionDiff, error)
+	Rollback(reportID uint, versionNumber int, userID uint) (*report.RollbackResult, error)
+
+	// Editorial workflow
+	Transition(id uint, action string, actor report.Actor, note string) (*report.Report, error)
+	AssignReviewer(id uint, reviewerID *uint, actor report.Actor) (*report.Report, error)
+	AddReviewComment(id uint, actor report.Actor, body string) (*report.ReviewComment, error)
+	GetReviewComments(id uint) ([]report.ReviewComment, error)
+	PublishScheduled(now time.Time) ([]report.Report, error)
 }
 
+var (
+	// ErrVersionRequired is returned when an update does not say which version
+	// of the content it was based on
+	ErrVersionRequired = errors.New("version is required: send an If-Match header or a version field")
+
+	ErrStatusChangeNotAllowed = errors.New("report status can only be changed through the workflow endpoints")
+
+	ErrReportVersionNotFound    = errors.New("report version not found")
+	ErrVersionSnapshotMissing   = errors.New("report version has no full snapshot and cannot be restored")
+	ErrRollbackToCurrentVersion = errors.New("report already matches this version")
+
+	ErrInvalidMarketMetrics = errors.New("invalid market metrics")
+	ErrInvalidKeyPlayers    = errors.New("invalid key players")
+	ErrInvalidGeography     = errors.New("invalid geography")
+
+	ErrInvalidComparison      = errors.New("invalid comparison")
+	ErrComparedReportNotFound = errors.New("report not found or not published")
+)
+
 type reportService struct {
-	repo              repository.ReportRepository
-	reportImageRepo   repository.ReportImageRepository
-	cloudflareService CloudflareImagesService
+	repo            repository.ReportRepository
+	reportImageRepo repository.ReportImageRepository
+	imageStorage    storage.ImageStorage
+	userRepo        repository.UserRepository
+	redirects       repository.RedirectRepository
+	companies       repository.CompanyRepository
+	geographies     GeographyService
+	templates       repository.ReportTemplateRepository
+	tx              repository.Transactor
 }
 
-func NewReportService(repo repository.ReportRepository, reportImageRepo repository.ReportImageRepository, cloudflareService CloudflareImagesService) ReportService {
+func NewReportService(repo repository.ReportRepository, reportImageRepo repository.ReportImageRepository, imageStorage storage.ImageStorage, userRepo repository.UserRepository, redirects repository.RedirectRepository, companies repository.CompanyRepository, geographies GeographyService, templates repository.ReportTemplateRepository, tx repository.Transactor) ReportService {
 	return &reportService{
-		repo:              repo,
-		reportImageRepo:   reportImageRepo,
-		cloudflareService: cloudflareService,
+		repo:            repo,
+		reportImageRepo: reportImageRepo,
+		imageStorage:    imageStorage,
+		userRepo:        userRepo,
+		redirects:       redirects,
+		companies:       companies,
+		geographies:     geographies,
+		templates:       templates,
+		tx:              tx,
 	}
 }
 
-func (s *reportService) GetAll(page, limit int) ([]report.Report, int64, error) {
+func (s *reportService) GetAll(params pagination.Params) ([]report.Report, int64, error) {
 	// Always fetch fresh data from database (no caching)
-	return s.repo.GetAll(page, limit)
+	return s.repo.GetAll(params)
 }
 
 func (s *reportService) GetByID(id uint) (*report.ReportWithRelations, error) {
@@ -54,24 +113,100 @@ func (s *reportService) GetByID(id uint) (*report.ReportWithRelations, error) {
 
 func (s *reportService) GetBySlug(slug string) (*report.ReportWithRelations, error) {
 	// Always fetch fresh data from database (no caching)
-	return s.repo.GetBySlug(slug)
+	rep, err := s.repo.GetBySlug(slug)
+	if err != nil {
+		// A retired slug answers with where the report lives now
+		return nil, slugMoved(s.redirects, redirect.EntityReport, slug, err)
+	}
+	return rep, nil
 }
 
-func (s *reportService) GetByCategorySlug(categorySlug string, page, limit int) ([]report.Report, int64, error) {
+// Compare lines up published reports side by side, in the order of ids
+func (s *reportService) Compare(ids []uint) (*report.Comparison, error) {
+	var unique []uint
+	seen := map[uint]bool{}
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) < report.MinCompared || len(unique) > report.MaxCompared {
+		return nil, fmt.Errorf("%w: compare between %d and %d different reports", ErrInvalidComparison, report.MinCompared, report.MaxCompared)
+	}
+
+	found, err := s.repo.GetPublishedByIDs(unique)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uint]report.Report, len(found))
+	for _, rep := range found {
+		byID[rep.ID] = rep
+	}
+
+	reports := make([]report.Report, 0, len(unique))
+	var missing []string
+	for _, id := range unique {
+		rep, ok := byID[id]
+		if !ok {
+			missing = append(missing, fmt.Sprint(id))
+			continue
+		}
+		reports = append(reports, rep)
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s", ErrComparedReportNotFound, strings.Join(missing, ", "))
+	}
+
+	taxonomy, err := s.geographies.Taxonomy()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load geographies: %w", err)
+	}
+	return report.Compare(reports, taxonomy), nil
+}
+
+func (s *reportService) GetByCategorySlug(categorySlug string, includeDescendants bool, params pagination.Params) ([]report.Report, int64, error) {
 	// Always fetch fresh data from database (no caching)
-	return s.repo.GetByCategorySlug(categorySlug, page, limit)
+	return s.repo.GetByCategorySlug(categorySlug, includeDescendants, params)
 }
 
-func (s *reportService) GetByAuthorID(authorID uint, page, limit int) ([]report.Report, int64, error) {
+func (s *reportService) GetByAuthorID(authorID uint, params pagination.Params) ([]report.Report, int64, error) {
 	// No caching for filtered queries (matches blog/press release pattern)
-	return s.repo.GetByAuthorID(authorID, page, limit)
+	return s.repo.GetByAuthorID(authorID, params)
 }
 
 func (s *reportService) GetAllWithFilters(filters repository.ReportFilters) ([]report.Report, int64, error) {
+	if err := s.expandGeographyFilter(&filters); err != nil {
+		return nil, 0, err
+	}
+
 	// Don't cache filtered results due to high variability
 	return s.repo.GetAllWithFilters(filters)
 }
 
+func (s *reportService) GetFacets(filters repository.ReportFilters) (*report.ReportFacets, error) {
+	if err := s.expandGeographyFilter(&filters); err != nil {
+		return nil, err
+	}
+
+	// Facets depend on the active filters, so they are not cached either
+	return s.repo.GetFacets(filters)
+}
+
+// expandGeographyFilter widens a geography filter to the countries of any
+// region it names
+func (s *reportService) expandGeographyFilter(filters *repository.ReportFilters) error {
+	if len(filters.Geography) == 0 {
+		return nil
+	}
+	taxonomy, err := s.geographies.Taxonomy()
+	if err != nil {
+		return fmt.Errorf("failed to load geographies: %w", err)
+	}
+	filters.Geography = taxonomy.Expand(filters.Geography)
+	return nil
+}
+
 func (s *reportService) Search(query string, page, limit int) ([]report.Report, int64, error) {
 	// Search queries are not cached due to high variability
 	return s.repo.Search(query, page, limit)
@@ -81,8 +216,39 @@ func (s *reportService) Create(rep *report.Report, userID uint) error {
 	// Set user tracking fields
 	rep.CreatedBy = &userID
 	rep.UpdatedBy = &userID
+	rep.Version = 1
+
+	// Reports always start as drafts; status changes go through the workflow
+	rep.Status = report.StatusDraft
+	rep.ReviewerID = nil
+	rep.SubmittedBy = nil
+	rep.SubmittedAt = nil
+	rep.ReviewedBy = nil
+	rep.ReviewedAt = nil
+	rep.RejectionReason = ""
+
+	if err := s.normalize(rep, nil); err != nil {
+		return err
+	}
 
-	err := s.repo.Create(rep)
+	// The first version and the tags are written with the report so neither
+	// is missing, and no tags are created for a report that was not saved
+	err := s.tx.Transaction(func(tx *repository.Tx) error {
+		tags, err := resolveReportTags(tx.Tags, rep, report.StringSlice{})
+		if err != nil {
+			return err
+		}
+		if err := tx.Reports.Create(rep); err != nil {
+			return err
+		}
+		if _, err := s.recordVersion(tx.Reports, rep, userID, report.VersionChangeCreate, nil); err != nil {
+			return err
+		}
+		if tags == nil {
+			return nil
+		}
+		return setContentTags(tx.Tags, tag.ContentReport, rep.ID, tags)
+	})
 	if err != nil {
 		return err
 	}
@@ -90,6 +256,7 @@ func (s *reportService) Create(rep *report.Report, userID uint) error {
 	// Invalidate all report list caches
 	cache.DeletePattern("reports:list:*")
 	cache.DeletePattern("reports:total")
+	invalidateSEOCache(seo.SectionReports)
 
 	// Invalidate category-specific caches if category is set
 	if rep.CategoryID > 0 {
@@ -99,6 +266,27 @@ func (s *reportService) Create(rep *report.Report, userID uint) error {
 	return nil
 }
 
+func (s *reportService) Validate(rep *report.Report) error {
+	if err := rep.CheckRequired(); err != nil {
+		return err
+	}
+	var stored *report.Report
+	if rep.ID != 0 {
+		existing, err := s.repo.GetByID(rep.ID)
+		if err != nil {
+			return err
+		}
+		stored = existing
+	}
+	if err := s.normalize(rep, stored); err != nil {
+		return err
+	}
+	if rep.Tags != nil {
+		return checkTagNames(tag.Clean(rep.Tags))
+	}
+	return nil
+}
+
 func (s *reportService) Update(id uint, rep *report.Report, userID uint) error {
 	// Get existing report to check slug and status
 	existing, err := s.repo.GetByID(id)
@@ -106,65 +294,69 @@ func (s *reportService) Update(id uint, rep *report.Report, userID uint) error {
 		return err
 	}
 
+	if rep.Version == 0 {
+		return ErrVersionRequired
+	}
+
+	// Status only changes through the workflow transitions
+	if rep.Status != "" && rep.Status != existing.Status {
+		return ErrStatusChangeNotAllowed
+	}
+
 	rep.ID = id
 
 	// Set updated_by field
 	rep.UpdatedBy = &userID
 
-	// Check if status is changing from draft to published
-	statusChanged := existing.Status == "draft" && rep.Status == "published"
-
-	// Update the report
-	err = s.repo.Update(rep)
-	if err != nil {
+	if err := s.normalize(rep, existing); err != nil {
 		return err
 	}
 
-	// If status changed to published, create a version history entry
-	if statusChanged {
-		// Get the latest version number
-		latestVersion, err := s.repo.GetLatestVersionNumber(id)
+	// Workflow fields are not written by Update; reflect the stored values
+	// so the version records them
+	rep.Status = existing.Status
+	rep.ReviewerID = existing.ReviewerID
+	rep.SubmittedBy = existing.SubmittedBy
+	rep.SubmittedAt = existing.SubmittedAt
+	rep.ReviewedBy = existing.ReviewedBy
+	rep.ReviewedAt = existing.ReviewedAt
+	rep.RejectionReason = existing.RejectionReason
+
+	// Every save is recorded as a version with the full report payload, in
+	// the same transaction as the update and its tags
+	err = s.tx.Transaction(func(tx *repository.Tx) error {
+		tags, err := resolveReportTags(tx.Tags, rep, existing.Tags)
 		if err != nil {
-			// Log error but don't fail the update
-			fmt.Printf("Warning: could not get latest version number: %v\n", err)
-		} else {
-			// Create new version
-			version := &report.ReportVersion{
-				ReportID:        id,
-				VersionNumber:   latestVersion + 1,
-				PublishedBy:     userID,
-				PublishedAt:     time.Now(),
-				Sections:        rep.Sections,
-				MetaTitle:       rep.MetaTitle,
-				MetaDescription: rep.MetaDescription,
-				MetaKeywords:    rep.MetaKeywords,
-			}
-
-			if err := s.repo.CreateVersion(version); err != nil {
-				// Log error but don't fail the update
-				fmt.Printf("Warning: could not create version history: %v\n", err)
-			}
+			return err
 		}
-
-		// Update publish_date if not set
-		if rep.PublishDate == nil {
-			now := time.Now()
-			rep.PublishDate = &now
-			s.repo.Update(rep) // Update again with publish_date
+		if err := tx.Reports.Update(rep); err != nil {
+			return err
 		}
+		if _, err := s.recordVersion(tx.Reports, rep, userID, report.VersionChangeUpdate, nil); err != nil {
+			return err
+		}
+		if tags == nil {
+			return nil
+		}
+		return setContentTags(tx.Tags, tag.ContentReport, id, tags)
+	})
+	if err != nil {
+		return err
 	}
 
 	// Invalidate caches
 	cache.DeletePattern("reports:list:*")
 	cache.DeletePattern("reports:total")
+	invalidateSEOCache(seo.SectionReports)
 	cache.DeletePattern(fmt.Sprintf("reports:category:*"))
 
 	// Invalidate the specific report cache (old slug)
 	cache.Delete(fmt.Sprintf("report:slug:%s", existing.Slug))
 
-	// Invalidate new slug if it changed
+	// Invalidate new slug if it changed, and keep old links working
 	if rep.Slug != existing.Slug {
 		cache.Delete(fmt.Sprintf("report:slug:%s", rep.Slug))
+		recordSlugChange(s.redirects, redirect.EntityReport, id, existing.Slug, rep.Slug)
 	}
 
 	return nil
@@ -177,12 +369,22 @@ func (s *reportService) Delete(id uint) error {
 		return err
 	}
 
-	// Get all images for this report and delete from Cloudflare (best effort)
+	// Get all images for this report and delete them from storage (best
+	// effort). Clones share their original's files, so files another report
+	// still uses are left to the image collector.
 	images, _ := s.reportImageRepo.FindByReportID(id)
+	shared, err := s.reportImageRepo.SharedImageURLs(id)
+	if err != nil {
+		log.Printf("Warning: could not check which images of report %d are shared, leaving them to the image collector: %v", id, err)
+		images = nil
+	}
+	inUse := make(map[string]bool, len(shared))
+	for _, url := range shared {
+		inUse[url] = true
+	}
 	for _, img := range images {
-		if err := s.cloudflareService.Delete(img.ImageURL); err != nil {
-			// Log error but don't fail the deletion
-			log.Printf("Failed to delete image from Cloudflare: %s, error: %v", img.ImageURL, err)
+		if !inUse[img.ImageURL] {
+			deleteImageWithVariants(s.imageStorage, img.ImageURL, img.Variants)
 		}
 	}
 
@@ -195,6 +397,7 @@ func (s *reportService) Delete(id uint) error {
 	// Invalidate caches
 	cache.DeletePattern("reports:list:*")
 	cache.DeletePattern("reports:total")
+	invalidateSEOCache(seo.SectionReports)
 	cache.DeletePattern(fmt.Sprintf("reports:category:*"))
 	cache.Delete(fmt.Sprintf("report:slug:%s", existing.Slug))
 
@@ -217,6 +420,7 @@ func (s *reportService) SoftDelete(id uint) error {
 	// Invalidate caches (same pattern as hard delete)
 	cache.DeletePattern("reports:list:*")
 	cache.DeletePattern("reports:total")
+	invalidateSEOCache(seo.SectionReports)
 	cache.DeletePattern(fmt.Sprintf("reports:category:*"))
 	cache.Delete(fmt.Sprintf("report:slug:%s", existing.Slug))
 
@@ -233,6 +437,7 @@ func (s *reportService) Restore(id uint) error {
 	// Invalidate caches to show restored report
 	cache.DeletePattern("reports:list:*")
 	cache.DeletePattern("reports:total")
+	invalidateSEOCache(seo.SectionReports)
 	cache.DeletePattern(fmt.Sprintf("reports:category:*"))
 
 	return nil
@@ -250,10 +455,13 @@ func (s *reportService) SchedulePublish(id uint, publishDate time.Time) (*report
 		return nil, err
 	}
 
-	// Validate status (can't schedule already published)
-	if r.Status == "published" {
+	// Only reports that passed review can be scheduled
+	if r.Status == report.StatusPublished {
 		return nil, errors.New("cannot schedule already published report")
 	}
+	if r.Status != report.StatusApproved {
+		return nil, errors.New("only approved reports can be scheduled for publishing")
+	}
 
 	// Schedule publish
 	if err := s.repo.SchedulePublish(id, publishDate); err != nil {
@@ -277,3 +485,324 @@ func (s *reportService) CancelScheduledPublish(id uint) (*report.Report, error)
 
 	return s.repo.GetByID(id)
 }
+
+// normalizeMarketMetrics fills the numeric market metrics from their strings
+// and checks the CAGR against the current and forecast values
+func normalizeMarketMetrics(rep *report.Report) error {
+	if err := rep.MarketMetrics.Normalize(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidMarketMetrics, err)
+	}
+	if err := rep.MarketMetrics.ValidateCAGR(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidMarketMetrics, err)
+	}
+	rep.MetricsUnparsed = false
+	return nil
+}
+
+// normalize parses the market metrics, links key players to companies and
+// replaces geography with canonical names, as every save does. When updating
+// stored, metrics it already states are kept as they are, so reports flagged
+// as unparsed or with an off CAGR can be saved until the metrics are edited.
+func (s *reportService) normalize(rep *report.Report, stored *report.Report) error {
+	if stored != nil && rep.MarketMetrics.SameAs(stored.MarketMetrics) {
+		rep.MarketMetrics = stored.MarketMetrics
+		rep.MetricsUnparsed = stored.MetricsUnparsed
+	} else if err := normalizeMarketMetrics(rep); err != nil {
+		return err
+	}
+	if err := s.linkKeyPlayers(rep.KeyPlayers, true); err != nil {
+		return err
+	}
+	return s.normalizeGeography(rep)
+}
+
+// normalizeGeography replaces the report's geography values with the
+// canonical names of the geographies they name
+func (s *reportService) normalizeGeography(rep *report.Report) error {
+	taxonomy, err := s.geographies.Taxonomy()
+	if err != nil {
+		return fmt.Errorf("failed to load geographies: %w", err)
+	}
+	names, err := taxonomy.Normalize(rep.Geography)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidGeography, err)
+	}
+	if len(names) == 0 {
+		return fmt.Errorf("%w: at least one geography is required", ErrInvalidGeography)
+	}
+	rep.Geography = names
+	return nil
+}
+
+// resolveReportTags replaces the report's tag names with those of the tags
+// they resolve to, creating tags as needed. A report sent without tags keeps
+// current, and no tags are returned because the links don't change.
+func resolveReportTags(repo repository.TagRepository, rep *report.Report, current report.StringSlice) ([]tag.Tag, error) {
+	if rep.Tags == nil {
+		rep.Tags = current
+		return nil, nil
+	}
+	tags, err := resolveTags(repo, rep.Tags)
+	if err != nil {
+		return nil, err
+	}
+	rep.Tags = tag.Names(tags)
+	return tags, nil
+}
+
+// linkKeyPlayers connects key players to the company directory. Players
+// with a company ID take the company's name when they have none; players
+// with only a name are linked when it matches exactly one company. When
+// strict is false, IDs of companies that no longer exist are dropped
+// instead of rejected.
+func (s *reportService) linkKeyPlayers(players report.KeyPlayers, strict bool) error {
+	if len(players) == 0 {
+		return nil
+	}
+
+	var ids []uint
+	var keys []string
+	for _, p := range players {
+		if p.CompanyID != nil {
+			ids = append(ids, *p.CompanyID)
+		} else if key := company.NameKey(p.Name); key != "" {
+			keys = append(keys, key)
+		}
+	}
+
+	linked, err := s.companies.GetByIDs(ids)
+	if err != nil {
+		return fmt.Errorf("failed to load key player companies: %w", err)
+	}
+	byID := make(map[uint]*company.Company, len(linked))
+	for i := range linked {
+		byID[linked[i].ID] = &linked[i]
+	}
+
+	for i := range players {
+		if players[i].CompanyID == nil {
+			continue
+		}
+		c, ok := byID[*players[i].CompanyID]
+		if !ok {
+			if strict {
+				return fmt.Errorf("%w: company %d does not exist", ErrInvalidKeyPlayers, *players[i].CompanyID)
+			}
+			players[i].CompanyID = nil
+			if key := company.NameKey(players[i].Name); key != "" {
+				keys = append(keys, key)
+			}
+			continue
+		}
+		if strings.TrimSpace(players[i].Name) == "" {
+			players[i].Name = c.Name
+		}
+	}
+
+	matches, err := s.companies.FindByNameKeys(keys, 0)
+	if err != nil {
+		return fmt.Errorf("failed to match key players: %w", err)
+	}
+	byKey := map[string][]uint{}
+	for _, c := range matches {
+		for _, key := range c.NameKeys {
+			byKey[key] = append(byKey[key], c.ID)
+		}
+	}
+
+	for i := range players {
+		if players[i].CompanyID != nil {
+			continue
+		}
+		// Ambiguous names are left for an editor to link
+		if found := byKey[company.NameKey(players[i].Name)]; len(found) == 1 {
+			id := found[0]
+			players[i].CompanyID = &id
+		}
+	}
+
+	return nil
+}
+
+// invalidateReportCaches clears the report listings and the cached copy of one report
+func (s *reportService) invalidateReportCaches(slug string) {
+	cache.DeletePattern("reports:list:*")
+	cache.DeletePattern("reports:total")
+	invalidateSEOCache(seo.SectionReports)
+	cache.DeletePattern("reports:category:*")
+	cache.Delete(fmt.Sprintf("report:slug:%s", slug))
+}
+
+// recordVersion stores the report's current payload as its next version. It
+// runs in the transaction that wrote the report: the report row is locked by
+// then, so concurrent saves can't both take the same version number.
+func (s *reportService) recordVersion(repo repository.ReportRepository, rep *report.Report, userID uint, changeType string, restoredFrom *int) (*report.ReportVersion, error) {
+	latestVersion, err := repo.GetLatestVersionNumber(rep.ID)
+	if err != nil {
+		return nil, fmt.Errorf("could not get latest version number: %w", err)
+	}
+
+	// The scheduler publishes without a user
+	var by *uint
+	if userID != 0 {
+		by = &userID
+	}
+
+	version := &report.ReportVersion{
+		ReportID:        rep.ID,
+		VersionNumber:   latestVersion + 1,
+		ChangeType:      changeType,
+		CreatedBy:       by,
+		RestoredFrom:    restoredFrom,
+		Sections:        rep.Sections,
+		MetaTitle:       rep.MetaTitle,
+		MetaDescription: rep.MetaDescription,
+		MetaKeywords:    rep.MetaKeywords,
+		Snapshot:        report.NewSnapshot(rep),
+	}
+
+	if changeType == report.VersionChangePublish {
+		now := time.Now()
+		version.PublishedBy = by
+		version.PublishedAt = &now
+	}
+
+	if err := repo.CreateVersion(version); err != nil {
+		return nil, err
+	}
+
+	return version, nil
+}
+
+func (s *reportService) ListVersions(reportID uint) ([]report.ReportVersion, error) {
+	// Make sure the report exists so an unknown ID is reported as not found
+	if _, err := s.repo.GetByID(reportID); err != nil {
+		return nil, err
+	}
+
+	versions, err := s.repo.GetVersionsByReportID(reportID)
+	if err != nil {
+		return nil, err
+	}
+	if versions == nil {
+		versions = []report.ReportVersion{}
+	}
+
+	return versions, nil
+}
+
+func (s *reportService) GetVersion(reportID uint, versionNumber int) (*report.ReportVersion, error) {
+	version, err := s.repo.GetVersion(reportID, versionNumber)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrReportVersionNotFound
+		}
+		return nil, err
+	}
+	return version, nil
+}
+
+func (s *reportService) DiffVersions(reportID uint, fromVersion, toVersion int) (*report.VersionDiff, error) {
+	from, err := s.GetVersion(reportID, fromVersion)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.GetVersion(reportID, toVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := report.DiffSnapshots(from.Payload(), to.Payload())
+	if err != nil {
+		return nil, err
+	}
+
+	return &report.VersionDiff{
+		ReportID:    reportID,
+		FromVersion: fromVersion,
+		ToVersion:   toVersion,
+		Changes:     changes,
+	}, nil
+}
+
+// Rollback restores the report content from an earlier version. The restore is
+// saved as a new version, so history is never rewritten.
+func (s *reportService) Rollback(reportID uint, versionNumber int, userID uint) (*report.RollbackResult, error) {
+	existing, err := s.repo.GetByID(reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.GetVersion(reportID, versionNumber)
+	if err != nil {
+		return nil, err
+	}
+	if target.Snapshot == nil {
+		return nil, ErrVersionSnapshotMissing
+	}
+
+	// Versions from before tags were versioned keep the current tags
+	if target.Snapshot.Tags == nil {
+		target.Snapshot.Tags = existing.Tags
+	}
+
+	changes, err := report.DiffSnapshots(report.NewSnapshot(existing), target.Snapshot)
+	if err != nil {
+		return nil, err
+	}
+	if len(changes) == 0 {
+		return nil, ErrRollbackToCurrentVersion
+	}
+
+	target.Snapshot.ApplyTo(existing)
+	existing.UpdatedBy = &userID
+
+	// Older versions may predate the numeric metrics; restore them as they
+	// were and flag them if they can't be read
+	existing.MetricsUnparsed = existing.MarketMetrics.Normalize() != nil
+
+	// Companies may have been merged away since; those players are matched
+	// by name again
+	if err := s.linkKeyPlayers(existing.KeyPlayers, false); err != nil {
+		return nil, err
+	}
+
+	// Versions from before the taxonomy may hold values it doesn't know;
+	// they are restored as they were
+	taxonomy, err := s.geographies.Taxonomy()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load geographies: %w", err)
+	}
+	if names, err := taxonomy.Normalize(existing.Geography); err == nil {
+		existing.Geography = names
+	}
+
+	// Restored tags may have been renamed, merged or deleted since; they are
+	// resolved again and the content links follow them
+	var version *report.ReportVersion
+	err = s.tx.Transaction(func(tx *repository.Tx) error {
+		tags, err := resolveReportTags(tx.Tags, existing, existing.Tags)
+		if err != nil {
+			return err
+		}
+		if err := tx.Reports.Update(existing); err != nil {
+			return err
+		}
+		version, err = s.recordVersion(tx.Reports, existing, userID, report.VersionChangeRollback, &versionNumber)
+		if err != nil || tags == nil {
+			return err
+		}
+		return setContentTags(tx.Tags, tag.ContentReport, existing.ID, tags)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateReportCaches(existing.Slug)
+
+	return &report.RollbackResult{
+		Report:  existing,
+		Version: version,
+		Changes: changes,
+	}, nil
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/healthcare-market-research/backend/internal/domain/geography"
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/domain/tag"
+	"github.com/healthcare-market-research/backend/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// stubGeographyService knows one country
+type stubGeographyService struct {
+	GeographyService
+}
+
+func (s stubGeographyService) Taxonomy() (*geography.Taxonomy, error) {
+	return geography.NewTaxonomy([]geography.Geography{{Code: "US", Name: "United States", Level: "country"}}), nil
+}
+
+func TestReportService_Validate_StoredMetrics(t *testing.T) {
+	stored := report.Report{
+		ID:              4,
+		Title:           "Cardiac Monitoring Market",
+		Slug:            "cardiac-monitoring-market",
+		CategoryID:      2,
+		Summary:         "Cardiac monitoring devices across hospital and home settings.",
+		Geography:       report.StringSlice{"United States"},
+		MarketMetrics:   &report.MarketMetrics{CurrentRevenue: "around 4 billion", CurrentYear: 2024},
+		MetricsUnparsed: true,
+	}
+	s, _ := newWorkflowTestService(stored)
+	s.geographies = stubGeographyService{}
+
+	t.Run("Unparsed metrics sent back unchanged are kept", func(t *testing.T) {
+		rep := stored
+		rep.MarketMetrics = &report.MarketMetrics{CurrentRevenue: "around 4 billion", CurrentYear: 2024}
+		rep.Summary += " Updated."
+
+		require.NoError(t, s.Validate(&rep))
+		assert.True(t, rep.MetricsUnparsed)
+	})
+
+	t.Run("Edited metrics are checked", func(t *testing.T) {
+		rep := stored
+		rep.MarketMetrics = &report.MarketMetrics{CurrentRevenue: "around 5 billion", CurrentYear: 2024}
+
+		assert.ErrorIs(t, s.Validate(&rep), ErrInvalidMarketMetrics)
+	})
+
+	t.Run("New reports are always checked", func(t *testing.T) {
+		rep := stored
+		rep.ID = 0
+
+		assert.ErrorIs(t, s.Validate(&rep), ErrInvalidMarketMetrics)
+	})
+}
+
+// versionReportRepository adds saving and version lookups to the in-memory
+// workflow repository
+type versionReportRepository struct {
+	*workflowReportRepository
+}
+
+func (m *versionReportRepository) Update(rep *report.Report) error {
+	copied := *rep
+	m.reports[rep.ID] = &copied
+	return nil
+}
+
+func (m *versionReportRepository) GetVersion(reportID uint, versionNumber int) (*report.ReportVersion, error) {
+	for _, v := range m.versions {
+		if v.ReportID == reportID && v.VersionNumber == versionNumber {
+			copied := v
+			return &copied, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// memoryTagRepository keeps tags and the tags linked to each report in memory
+type memoryTagRepository struct {
+	repository.TagRepository
+	tags  []tag.Tag
+	links map[uint][]uint
+}
+
+func (m *memoryTagRepository) FindByNames(lowerNames, slugs []string) ([]tag.Tag, error) {
+	var found []tag.Tag
+	for _, t := range m.tags {
+		for i := range lowerNames {
+			if strings.ToLower(t.Name) == lowerNames[i] || t.Slug == slugs[i] {
+				found = append(found, t)
+				break
+			}
+		}
+	}
+	return found, nil
+}
+
+func (m *memoryTagRepository) Create(t *tag.Tag) error {
+	t.ID = uint(len(m.tags) + 1)
+	m.tags = append(m.tags, *t)
+	return nil
+}
+
+func (m *memoryTagRepository) SetContentTags(contentType string, contentID uint, tagIDs []uint) error {
+	m.links[contentID] = tagIDs
+	return nil
+}
+
+func TestReportService_Rollback_RestoresTags(t *testing.T) {
+	stored := report.Report{
+		ID:        4,
+		Title:     "Cardiac Monitoring Market",
+		Slug:      "cardiac-monitoring-market",
+		Summary:   "Cardiac monitoring devices across hospital and home settings.",
+		Geography: report.StringSlice{"United States"},
+		Tags:      report.StringSlice{"Cardiology"},
+		Status:    report.StatusDraft,
+		Version:   1,
+	}
+	workflow := &workflowReportRepository{reports: map[uint]*report.Report{}}
+	workflow.reports[stored.ID] = &stored
+	workflow.versions = []report.ReportVersion{{ReportID: stored.ID, VersionNumber: 1, Snapshot: report.NewSnapshot(&stored)}}
+	repo := &versionReportRepository{workflow}
+	tags := &memoryTagRepository{tags: []tag.Tag{{ID: 1, Name: "Cardiology", Slug: "cardiology"}}, links: map[uint][]uint{}}
+	s := &reportService{
+		repo:        repo,
+		geographies: stubGeographyService{},
+		tx:          mockTransactor{&repository.Tx{Reports: repo, Tags: tags}},
+	}
+
+	// A tags-only update is a version with a tags change
+	rep := stored
+	rep.Tags = report.StringSlice{"Cardiology", "Wearables"}
+	require.NoError(t, s.Update(stored.ID, &rep, 7))
+	require.Len(t, workflow.versions, 2)
+	changes, err := report.DiffSnapshots(workflow.versions[0].Snapshot, workflow.versions[1].Snapshot)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	assert.Equal(t, "tags", changes[0].Field)
+	assert.Equal(t, []uint{1, 2}, tags.links[stored.ID])
+
+	result, err := s.Rollback(stored.ID, 1, 7)
+	require.NoError(t, err)
+	assert.Equal(t, report.StringSlice{"Cardiology"}, result.Report.Tags)
+	assert.Equal(t, report.StringSlice{"Cardiology"}, workflow.reports[stored.ID].Tags)
+	assert.Equal(t, []uint{1}, tags.links[stored.ID])
+	require.Len(t, result.Changes, 1)
+	assert.Equal(t, "tags", result.Changes[0].Field)
+}
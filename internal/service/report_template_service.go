@@ -0,0 +1,144 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/repository"
+)
+
+var (
+	ErrTemplateNotFound  = errors.New("report template not found")
+	ErrTemplateNameTaken = errors.New("report template name already in use")
+	ErrInvalidTemplate   = errors.New("invalid report template")
+)
+
+var currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+type ReportTemplateService interface {
+	GetAll() ([]report.ReportTemplate, error)
+	GetByID(id uint) (*report.ReportTemplate, error)
+	Create(req *report.ReportTemplateRequest, userID uint) (*report.ReportTemplate, error)
+	Update(id uint, req *report.ReportTemplateRequest, userID uint) (*report.ReportTemplate, error)
+	Delete(id uint) (*report.ReportTemplate, error)
+}
+
+type reportTemplateService struct {
+	repo        repository.ReportTemplateRepository
+	geographies GeographyService
+}
+
+func NewReportTemplateService(repo repository.ReportTemplateRepository, geographies GeographyService) ReportTemplateService {
+	return &reportTemplateService{repo: repo, geographies: geographies}
+}
+
+func (s *reportTemplateService) GetAll() ([]report.ReportTemplate, error) {
+	return s.repo.GetAll()
+}
+
+func (s *reportTemplateService) GetByID(id uint) (*report.ReportTemplate, error) {
+	t, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, ErrTemplateNotFound
+	}
+	return t, nil
+}
+
+func (s *reportTemplateService) Create(req *report.ReportTemplateRequest, userID uint) (*report.ReportTemplate, error) {
+	t := &report.ReportTemplate{CreatedBy: &userID}
+	if err := s.apply(t, req, userID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Update replaces every field of the template with the request's
+func (s *reportTemplateService) Update(id uint, req *report.ReportTemplateRequest, userID uint) (*report.ReportTemplate, error) {
+	t, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, ErrTemplateNotFound
+	}
+	if err := s.apply(t, req, userID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (s *reportTemplateService) Delete(id uint) (*report.ReportTemplate, error) {
+	t, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, ErrTemplateNotFound
+	}
+	if err := s.repo.Delete(id); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// apply validates the request and copies it onto the template. Geography is
+// stored by canonical name, as on reports.
+func (s *reportTemplateService) apply(t *report.ReportTemplate, req *report.ReportTemplateRequest, userID uint) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return fmt.Errorf("%w: name is required", ErrInvalidTemplate)
+	}
+	if utf8.RuneCountInString(name) > 255 {
+		return fmt.Errorf("%w: name must be at most 255 characters", ErrInvalidTemplate)
+	}
+	taken, err := s.repo.NameExists(name, t.ID)
+	if err != nil {
+		return fmt.Errorf("failed to check name availability: %w", err)
+	}
+	if taken {
+		return ErrTemplateNameTaken
+	}
+
+	if req.Price < 0 || req.DiscountedPrice < 0 {
+		return fmt.Errorf("%w: prices must not be negative", ErrInvalidTemplate)
+	}
+	currency := strings.ToUpper(strings.TrimSpace(req.Currency))
+	if currency != "" && !currencyCodePattern.MatchString(currency) {
+		return fmt.Errorf("%w: currency must be an ISO 4217 code, got %q", ErrInvalidTemplate, req.Currency)
+	}
+
+	faqs := report.FAQs{}
+	for i, faq := range req.FAQs {
+		faq.Question = strings.TrimSpace(faq.Question)
+		faq.Answer = strings.TrimSpace(faq.Answer)
+		if faq.Question == "" || faq.Answer == "" {
+			return fmt.Errorf("%w: faq %d needs a question and an answer", ErrInvalidTemplate, i+1)
+		}
+		faqs = append(faqs, faq)
+	}
+
+	taxonomy, err := s.geographies.Taxonomy()
+	if err != nil {
+		return fmt.Errorf("failed to load geographies: %w", err)
+	}
+	geography, err := taxonomy.Normalize(req.Geography)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidTemplate, err)
+	}
+
+	t.Name = name
+	t.Description = strings.TrimSpace(req.Description)
+	t.CategoryID = req.CategoryID
+	t.TableOfContents = req.TableOfContents
+	t.FAQs = faqs
+	t.Geography = geography
+	t.Price = req.Price
+	t.DiscountedPrice = req.DiscountedPrice
+	t.Currency = currency
+	t.UpdatedBy = &userID
+	return nil
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/domain/user"
+	"github.com/healthcare-market-research/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUnknownTransition   = errors.New("unknown workflow action")
+	ErrInvalidTransition   = errors.New("transition not allowed from the current status")
+	ErrTransitionForbidden = errors.New("your role cannot perform this workflow action")
+	ErrNoteRequired        = errors.New("a comment is required for this action")
+	ErrInvalidReviewer     = errors.New("reviewer must be an existing admin or editor")
+	ErrSelfReview          = errors.New("the submitter of a report cannot review it")
+	ErrAssignForbidden     = errors.New("only admins can assign reviewers")
+	ErrEmptyReviewComment  = errors.New("comment body is required")
+)
+
+// Transition moves a report through the editorial workflow. The note, when
+// given, is stored as a review comment; for rejections it is the reason.
+func (s *reportService) Transition(id uint, action string, actor report.Actor, note string) (*report.Report, error) {
+	t, ok := report.Transitions[action]
+	if !ok {
+		return nil, ErrUnknownTransition
+	}
+
+	rep, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !t.AllowedFor(actor, rep) {
+		return nil, ErrTransitionForbidden
+	}
+	if t.BarsActor(actor, rep) {
+		return nil, ErrSelfReview
+	}
+	if !t.AllowsFrom(rep.Status) {
+		return nil, fmt.Errorf("%w: cannot %s a report that is %s", ErrInvalidTransition, action, rep.Status)
+	}
+
+	note = strings.TrimSpace(note)
+	if t.NoteRequired && note == "" {
+		return nil, ErrNoteRequired
+	}
+
+	return s.applyTransition(rep, t, actor, note)
+}
+
+// PublishScheduled publishes the approved reports whose scheduled publish
+// date has passed, the same way a manual publish does. A report that fails
+// is logged and left for the next run.
+func (s *reportService) PublishScheduled(now time.Time) ([]report.Report, error) {
+	due, err := s.repo.GetScheduledDue(now)
+	if err != nil {
+		return nil, err
+	}
+
+	t := report.Transitions[report.WorkflowPublish]
+	published := make([]report.Report, 0, len(due))
+	for i := range due {
+		updated, err := s.applyTransition(&due[i], t, report.SchedulerActor, "")
+		if err != nil {
+			log.Printf("Warning: could not publish scheduled report %d: %v", due[i].ID, err)
+			continue
+		}
+		published = append(published, *updated)
+	}
+	return published, nil
+}
+
+// applyTransition moves rep, already checked against t, to t.To
+func (s *reportService) applyTransition(rep *report.Report, t report.Transition, actor report.Actor, note string) (*report.Report, error) {
+	id, action := rep.ID, t.Action
+
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status": t.To,
+	}
+	if actor.UserID != 0 {
+		updates["updated_by"] = actor.UserID
+	}
+	switch action {
+	case report.WorkflowSubmit:
+		updates["submitted_by"] = actor.UserID
+		updates["submitted_at"] = now
+		updates["rejection_reason"] = ""
+	case report.WorkflowApprove:
+		updates["reviewed_by"] = actor.UserID
+		updates["reviewed_at"] = now
+	case report.WorkflowReject:
+		updates["reviewed_by"] = actor.UserID
+		updates["reviewed_at"] = now
+		updates["rejection_reason"] = note
+	case report.WorkflowPublish:
+		updates["scheduled_publish_enabled"] = false
+		if rep.PublishDate == nil {
+			updates["publish_date"] = now
+		}
+	}
+
+	// A publish records exactly what went live, in the same transaction
+	var updated *report.Report
+	err := s.tx.Transaction(func(tx *repository.Tx) error {
+		if err := tx.Reports.UpdateWorkflow(id, rep.Status, updates); err != nil {
+			return err
+		}
+		var err error
+		updated, err = tx.Reports.GetByID(id)
+		if err != nil || action != report.WorkflowPublish {
+			return err
+		}
+		_, err = s.recordVersion(tx.Reports, updated, actor.UserID, report.VersionChangePublish, nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if note != "" {
+		comment := &report.ReviewComment{ReportID: id, UserID: actor.UserID, Action: action, Body: note}
+		if err := s.repo.CreateReviewComment(comment); err != nil {
+			// Log error but don't fail the transition
+			log.Printf("Warning: could not save review comment: %v", err)
+		}
+	}
+
+	s.invalidateReportCaches(updated.Slug)
+
+	return updated, nil
+}
+
+// AssignReviewer sets the user responsible for reviewing a report. A nil
+// reviewer clears the assignment.
+func (s *reportService) AssignReviewer(id uint, reviewerID *uint, actor report.Actor) (*report.Report, error) {
+	if actor.Role != user.RoleAdmin {
+		return nil, ErrAssignForbidden
+	}
+
+	rep, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if reviewerID != nil {
+		if rep.SubmittedBy != nil && *rep.SubmittedBy == *reviewerID {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidReviewer, ErrSelfReview)
+		}
+		reviewer, err := s.userRepo.GetByID(*reviewerID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrInvalidReviewer
+			}
+			return nil, err
+		}
+		if reviewer.Role != user.RoleAdmin && reviewer.Role != user.RoleEditor {
+			return nil, ErrInvalidReviewer
+		}
+	}
+
+	updates := map[string]interface{}{
+		"reviewer_id": reviewerID,
+		"updated_by":  actor.UserID,
+	}
+	if err := s.repo.UpdateWorkflow(id, rep.Status, updates); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateReportCaches(updated.Slug)
+
+	return updated, nil
+}
+
+func (s *reportService) AddReviewComment(id uint, actor report.Actor, body string) (*report.ReviewComment, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, ErrEmptyReviewComment
+	}
+
+	if _, err := s.repo.GetByID(id); err != nil {
+		return nil, err
+	}
+
+	comment := &report.ReviewComment{ReportID: id, UserID: actor.UserID, Body: body}
+	if err := s.repo.CreateReviewComment(comment); err != nil {
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func (s *reportService) GetReviewComments(id uint) ([]report.ReviewComment, error) {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.repo.GetReviewComments(id)
+	if err != nil {
+		return nil, err
+	}
+	if comments == nil {
+		comments = []report.ReviewComment{}
+	}
+
+	return comments, nil
+}
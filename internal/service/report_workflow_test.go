@@ -0,0 +1,164 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/healthcare-market-research/backend/internal/domain/report"
+	"github.com/healthcare-market-research/backend/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// Mock ReportRepository for workflow tests, keeping reports in memory
+type workflowReportRepository struct {
+	repository.ReportRepository
+	reports    map[uint]*report.Report
+	versions   []report.ReportVersion
+	versionErr error
+}
+
+func (m *workflowReportRepository) GetByID(id uint) (*report.Report, error) {
+	rep, ok := m.reports[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	copied := *rep
+	return &copied, nil
+}
+
+func (m *workflowReportRepository) UpdateWorkflow(id uint, fromStatus string, updates map[string]interface{}) error {
+	rep := m.reports[id]
+	if rep.Status != fromStatus {
+		return repository.ErrVersionConflict
+	}
+	if status, ok := updates["status"].(string); ok {
+		rep.Status = status
+	}
+	if enabled, ok := updates["scheduled_publish_enabled"].(bool); ok {
+		rep.ScheduledPublishEnabled = enabled
+	}
+	if by, ok := updates["updated_by"].(uint); ok {
+		rep.UpdatedBy = &by
+	}
+	return nil
+}
+
+func (m *workflowReportRepository) GetScheduledDue(now time.Time) ([]report.Report, error) {
+	var due []report.Report
+	for _, rep := range m.reports {
+		if rep.ScheduledPublishEnabled && rep.Status == report.StatusApproved && !rep.PublishDate.After(now) {
+			due = append(due, *rep)
+		}
+	}
+	return due, nil
+}
+
+func (m *workflowReportRepository) GetLatestVersionNumber(reportID uint) (int, error) {
+	return len(m.versions), nil
+}
+
+func (m *workflowReportRepository) CreateVersion(version *report.ReportVersion) error {
+	if m.versionErr != nil {
+		return m.versionErr
+	}
+	m.versions = append(m.versions, *version)
+	return nil
+}
+
+func (m *workflowReportRepository) CreateReviewComment(comment *report.ReviewComment) error {
+	return nil
+}
+
+// mockTransactor hands the work the test's repositories, without a transaction
+type mockTransactor struct {
+	tx *repository.Tx
+}
+
+func (m mockTransactor) Transaction(fn func(tx *repository.Tx) error) error {
+	return fn(m.tx)
+}
+
+func newWorkflowTestService(reports ...report.Report) (*reportService, *workflowReportRepository) {
+	repo := &workflowReportRepository{reports: map[uint]*report.Report{}}
+	for i := range reports {
+		repo.reports[reports[i].ID] = &reports[i]
+	}
+	return &reportService{repo: repo, tx: mockTransactor{&repository.Tx{Reports: repo}}}, repo
+}
+
+func TestReportService_Transition_SubmitterCannotReview(t *testing.T) {
+	submitter := uint(7)
+	s, _ := newWorkflowTestService(report.Report{ID: 5, Status: report.StatusReview, SubmittedBy: &submitter, ReviewerID: &submitter})
+
+	_, err := s.Transition(5, report.WorkflowApprove, report.Actor{UserID: 7, Role: "editor"}, "")
+	assert.ErrorIs(t, err, ErrSelfReview)
+
+	// Admins are held to it too
+	_, err = s.Transition(5, report.WorkflowReject, report.Actor{UserID: 7, Role: "admin"}, "Needs sources")
+	assert.ErrorIs(t, err, ErrSelfReview)
+
+	rep, err := s.Transition(5, report.WorkflowApprove, report.Actor{UserID: 1, Role: "admin"}, "")
+	require.NoError(t, err)
+	assert.Equal(t, report.StatusApproved, rep.Status)
+}
+
+func TestReportService_AssignReviewer_Rules(t *testing.T) {
+	submitter := uint(7)
+	s, _ := newWorkflowTestService(report.Report{ID: 5, Status: report.StatusReview, SubmittedBy: &submitter})
+
+	_, err := s.AssignReviewer(5, &submitter, report.Actor{UserID: 7, Role: "editor"})
+	assert.ErrorIs(t, err, ErrAssignForbidden)
+
+	_, err = s.AssignReviewer(5, &submitter, report.Actor{UserID: 1, Role: "admin"})
+	assert.ErrorIs(t, err, ErrInvalidReviewer)
+}
+
+func TestReportService_PublishScheduled(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	s, repo := newWorkflowTestService(
+		report.Report{ID: 1, Status: report.StatusApproved, PublishDate: &past, ScheduledPublishEnabled: true},
+		report.Report{ID: 2, Status: report.StatusApproved, PublishDate: &future, ScheduledPublishEnabled: true},
+		report.Report{ID: 3, Status: report.StatusReview, PublishDate: &past, ScheduledPublishEnabled: true},
+	)
+
+	published, err := s.PublishScheduled(time.Now())
+	require.NoError(t, err)
+	require.Len(t, published, 1)
+	assert.Equal(t, uint(1), published[0].ID)
+	assert.Equal(t, report.StatusPublished, published[0].Status)
+	assert.False(t, published[0].ScheduledPublishEnabled)
+	assert.Nil(t, published[0].UpdatedBy, "the scheduler is not a user")
+
+	// The published content is recorded like a manual publish
+	require.Len(t, repo.versions, 1)
+	assert.Equal(t, report.VersionChangePublish, repo.versions[0].ChangeType)
+	assert.Nil(t, repo.versions[0].PublishedBy)
+	assert.NotNil(t, repo.versions[0].PublishedAt)
+
+	assert.Equal(t, report.StatusApproved, repo.reports[2].Status)
+	assert.Equal(t, report.StatusReview, repo.reports[3].Status)
+}
+
+func TestReportService_PublishScheduled_SkipsConflicts(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	s, repo := newWorkflowTestService(report.Report{ID: 1, Status: report.StatusApproved, PublishDate: &past, ScheduledPublishEnabled: true})
+
+	// Reopened by an editor between the lookup and the update
+	due, _ := repo.GetScheduledDue(time.Now())
+	repo.reports[1].Status = report.StatusDraft
+	_, err := s.applyTransition(&due[0], report.Transitions[report.WorkflowPublish], report.SchedulerActor, "")
+	assert.ErrorIs(t, err, repository.ErrVersionConflict)
+	assert.Empty(t, repo.versions)
+}
+
+func TestReportService_Transition_PublishFailsWithoutVersion(t *testing.T) {
+	s, repo := newWorkflowTestService(report.Report{ID: 1, Status: report.StatusApproved})
+	repo.versionErr = errors.New("duplicate key value violates unique constraint")
+
+	_, err := s.Transition(1, report.WorkflowPublish, report.Actor{UserID: 1, Role: "admin"}, "")
+	assert.ErrorIs(t, err, repo.versionErr)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/healthcare-market-research/backend/internal/domain/audit"
+	"github.com/healthcare-market-research/backend/internal/domain/report"
 	"github.com/healthcare-market-research/backend/internal/repository"
 	"github.com/healthcare-market-research/backend/pkg/logger"
 )
@@ -14,7 +16,8 @@ type SchedulerService interface {
 }
 
 type schedulerService struct {
-	reportRepo       repository.ReportRepository
+	reportService    ReportService
+	auditService     AuditService
 	blogRepo         repository.BlogRepository
 	pressReleaseRepo repository.PressReleaseRepository
 	ticker           *time.Ticker
@@ -22,12 +25,14 @@ type schedulerService struct {
 }
 
 func NewSchedulerService(
-	reportRepo repository.ReportRepository,
+	reportService ReportService,
+	auditService AuditService,
 	blogRepo repository.BlogRepository,
 	pressReleaseRepo repository.PressReleaseRepository,
 ) SchedulerService {
 	return &schedulerService{
-		reportRepo:       reportRepo,
+		reportService:    reportService,
+		auditService:     auditService,
 		blogRepo:         blogRepo,
 		pressReleaseRepo: pressReleaseRepo,
 		stopCh:           make(chan struct{}),
@@ -57,9 +62,26 @@ func (s *schedulerService) Start(ctx context.Context) {
 func (s *schedulerService) processScheduledPublishes() {
 	now := time.Now()
 
-	if err := s.reportRepo.PublishScheduled(now); err != nil {
+	// Reports go through the editorial workflow, which records the version
+	// that went live; the audit log gets what a manual publish would log
+	published, err := s.reportService.PublishScheduled(now)
+	if err != nil {
 		logger.Error("Failed to publish scheduled reports", "error", err)
 	}
+	for i := range published {
+		entry := &audit.AuditEntry{
+			Action:     audit.ActionReportPublish,
+			EntityType: audit.EntityReport,
+			EntityID:   &published[i].ID,
+			Changes: audit.Changes{
+				"status":    {Old: report.StatusApproved, New: published[i].Status},
+				"scheduled": {Old: nil, New: true},
+			},
+		}
+		if err := s.auditService.Log(entry); err != nil {
+			logger.Error("Failed to audit scheduled report publish", "report_id", published[i].ID, "error", err)
+		}
+	}
 
 	if err := s.blogRepo.PublishScheduled(now); err != nil {
 		logger.Error("Failed to publish scheduled blogs", "error", err)
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/healthcare-market-research/backend/internal/domain/search"
+	"github.com/healthcare-market-research/backend/internal/repository"
+)
+
+var (
+	ErrEmptySearchQuery  = errors.New("search query is required")
+	ErrInvalidSearchType = errors.New("invalid search type: must be 'report', 'blog', or 'press_release'")
+)
+
+// SearchService defines the interface for cross-content search
+type SearchService interface {
+	Search(query search.Query) (*search.SearchResponse, int64, error)
+}
+
+type searchService struct {
+	repo repository.SearchRepository
+}
+
+// NewSearchService creates a new search service instance
+func NewSearchService(repo repository.SearchRepository) SearchService {
+	return &searchService{repo: repo}
+}
+
+// Search returns ranked results for the requested types along with per-type facet counts.
+// Search queries are not cached due to high variability.
+func (s *searchService) Search(query search.Query) (*search.SearchResponse, int64, error) {
+	query.Q = strings.TrimSpace(query.Q)
+	if query.Q == "" {
+		return nil, 0, ErrEmptySearchQuery
+	}
+
+	for _, t := range query.Types {
+		if !t.IsValid() {
+			return nil, 0, ErrInvalidSearchType
+		}
+	}
+
+	results, total, err := s.repo.Search(query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	facets, err := s.repo.CountByType(query.Q)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if results == nil {
+		results = []search.Result{}
+	}
+
+	return &search.SearchResponse{
+		Results: results,
+		Facets:  search.Facets{Type: facets},
+	}, total, nil
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/healthcare-market-research/backend/internal/cache"
+	"github.com/healthcare-market-research/backend/internal/config"
+	"github.com/healthcare-market-research/backend/internal/domain/seo"
+	"github.com/healthcare-market-research/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSitemapNotFound = errors.New("sitemap not found")
+	ErrFeedNotFound    = errors.New("feed not found")
+)
+
+// Sitemaps and feeds are dropped by content writes. The TTLs bound how long a
+// scheduled publish, which does not go through the services, takes to appear.
+const (
+	sitemapCacheTTL = 1 * time.Hour
+	feedCacheTTL    = 15 * time.Minute
+)
+
+// SEOService renders sitemaps, feeds and robots.txt for the public site
+type SEOService interface {
+	SitemapIndex() ([]byte, error)
+	Sitemap(name string) ([]byte, error)
+	Feed(section seo.Section, format, categorySlug string) ([]byte, error)
+	Robots() string
+}
+
+type seoService struct {
+	repo         repository.SEORepository
+	categoryRepo repository.CategoryRepository
+	site         *config.SiteConfig
+}
+
+// NewSEOService creates a new SEO service instance
+func NewSEOService(repo repository.SEORepository, categoryRepo repository.CategoryRepository, site *config.SiteConfig) SEOService {
+	normalized := *site
+	normalized.URL = strings.TrimRight(site.URL, "/")
+	return &seoService{repo: repo, categoryRepo: categoryRepo, site: &normalized}
+}
+
+func (s *seoService) SitemapIndex() ([]byte, error) {
+	return cachedDocument("seo:index", sitemapCacheTTL, func() (interface{}, error) {
+		stats, err := s.repo.SectionStats(time.Now())
+		if err != nil {
+			return nil, err
+		}
+		return seo.BuildSitemapIndex(s.site.URL, stats), nil
+	})
+}
+
+func (s *seoService) Sitemap(name string) ([]byte, error) {
+	section, page, ok := seo.ParseSitemapName(name)
+	if !ok {
+		return nil, ErrSitemapNotFound
+	}
+
+	cacheKey := fmt.Sprintf("seo:sitemap:%s:%d", section, page)
+	return cachedDocument(cacheKey, sitemapCacheTTL, func() (interface{}, error) {
+		offset := (page - 1) * seo.MaxURLsPerSitemap
+		entries, err := s.repo.SitemapEntries(section, time.Now(), offset, seo.MaxURLsPerSitemap)
+		if err != nil {
+			return nil, err
+		}
+
+		// The first page always exists; later pages only while there are entries
+		if page > 1 && len(entries) == 0 {
+			return nil, ErrSitemapNotFound
+		}
+
+		return seo.BuildURLSet(s.site.URL, section, entries), nil
+	})
+}
+
+func (s *seoService) Feed(section seo.Section, format, categorySlug string) ([]byte, error) {
+	if !section.HasFeed() || (format != seo.FormatRSS && format != seo.FormatAtom) {
+		return nil, ErrFeedNotFound
+	}
+
+	cacheKey := fmt.Sprintf("seo:feed:%s:%s:%s", section, format, categorySlug)
+	return cachedDocument(cacheKey, feedCacheTTL, func() (interface{}, error) {
+		var categoryIDs []uint
+		categoryName := ""
+
+		// A category feed includes posts from its subcategories
+		if categorySlug != "" {
+			cat, err := s.categoryRepo.GetBySlug(categorySlug)
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil, ErrCategoryNotFound
+				}
+				return nil, err
+			}
+			descendants, err := s.categoryRepo.GetDescendantIDs(cat.ID)
+			if err != nil {
+				return nil, err
+			}
+			categoryIDs = append([]uint{cat.ID}, descendants...)
+			categoryName = cat.Name
+		}
+
+		items, err := s.repo.FeedItems(section, categoryIDs, time.Now(), seo.FeedSize)
+		if err != nil {
+			return nil, err
+		}
+
+		info := seo.FeedInfo{
+			Title:   seo.FeedTitle(s.site.Name, section, categoryName),
+			SiteURL: s.site.URL,
+			SelfURL: s.feedURL(section, format, categorySlug),
+			Section: section,
+		}
+
+		if format == seo.FormatAtom {
+			return seo.BuildAtom(info, items), nil
+		}
+		return seo.BuildRSS(info, items), nil
+	})
+}
+
+func (s *seoService) Robots() string {
+	return fmt.Sprintf("User-agent: *\nAllow: /\n\nSitemap: %s/sitemap.xml\n", s.site.URL)
+}
+
+func (s *seoService) feedURL(section seo.Section, format, categorySlug string) string {
+	url := fmt.Sprintf("%s/feeds/%s.%s", s.site.URL, section, format)
+	if categorySlug != "" {
+		url += "?category=" + categorySlug
+	}
+	return url
+}
+
+// cachedDocument caches the rendered XML of the document built by fn
+func cachedDocument(cacheKey string, ttl time.Duration, fn func() (interface{}, error)) ([]byte, error) {
+	var doc string
+
+	err := cache.GetOrSet(cacheKey, &doc, ttl, func() (interface{}, error) {
+		v, err := fn()
+		if err != nil {
+			return nil, err
+		}
+		out, err := seo.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		return string(out), nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(doc), nil
+}
+
+// invalidateSEOCache drops the sitemap index and the cached sitemaps and feeds
+// listing the given sections
+func invalidateSEOCache(sections ...seo.Section) {
+	cache.Delete("seo:index")
+	for _, section := range sections {
+		cache.DeletePattern(fmt.Sprintf("seo:sitemap:%s:*", section))
+		cache.DeletePattern(fmt.Sprintf("seo:feed:%s:*", section))
+	}
+}
@@ -0,0 +1,385 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gosimple/slug"
+	"github.com/healthcare-market-research/backend/internal/cache"
+	"github.com/healthcare-market-research/backend/internal/domain/redirect"
+	"github.com/healthcare-market-research/backend/internal/domain/tag"
+	"github.com/healthcare-market-research/backend/internal/repository"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrTagNotFound     = errors.New("tag not found")
+	ErrTagSlugTaken    = errors.New("tag slug already in use")
+	ErrTagNameTaken    = errors.New("tag name already in use")
+	ErrInvalidTagData  = errors.New("invalid tag data")
+	ErrInvalidTagMerge = errors.New("invalid tag merge")
+	ErrInvalidTags     = errors.New("invalid tags")
+)
+
+// maxTagListLength is the longest comma-joined tag list content can carry;
+// blogs and press releases keep it in a varchar(500)
+const maxTagListLength = 500
+
+type TagService interface {
+	GetAll(search string, page, limit int) ([]tag.TagWithUsage, int64, error)
+	GetBySlug(slug, contentType string, page, limit int) (*tag.TagDetail, int64, error)
+	GetByID(id uint) (*tag.Tag, error)
+	Create(req *tag.CreateTagRequest) (*tag.Tag, error)
+	Update(id uint, req *tag.UpdateTagRequest) (*tag.Tag, error)
+	Delete(id uint) (*tag.Tag, error)
+	Merge(targetID uint, sourceIDs []uint) (*tag.MergeResult, error)
+}
+
+type tagService struct {
+	repo      repository.TagRepository
+	redirects repository.RedirectRepository
+}
+
+func NewTagService(repo repository.TagRepository, redirects repository.RedirectRepository) TagService {
+	return &tagService{repo: repo, redirects: redirects}
+}
+
+// GetAll lists tags by name with the published content using each
+func (s *tagService) GetAll(search string, page, limit int) ([]tag.TagWithUsage, int64, error) {
+	tags, total, err := s.repo.GetAll(strings.TrimSpace(search), page, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids := make([]uint, len(tags))
+	for i, t := range tags {
+		ids[i] = t.ID
+	}
+	usage, err := s.repo.CountUsage(ids)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count tag usage: %w", err)
+	}
+
+	result := make([]tag.TagWithUsage, len(tags))
+	for i, t := range tags {
+		result[i] = tag.TagWithUsage{Tag: t, Usage: usage[t.ID]}
+	}
+	return result, total, nil
+}
+
+// GetBySlug returns a tag with a page of the published reports, blogs and
+// press releases carrying it, and the number of items across all pages
+func (s *tagService) GetBySlug(tagSlug, contentType string, page, limit int) (*tag.TagDetail, int64, error) {
+	t, err := s.repo.GetBySlug(tagSlug)
+	if err != nil {
+		// A renamed or merged tag answers with where it lives now
+		err = slugMoved(s.redirects, redirect.EntityTag, tagSlug, err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, 0, ErrTagNotFound
+		}
+		return nil, 0, err
+	}
+
+	if contentType != "" && !tag.ValidContentType(contentType) {
+		return nil, 0, fmt.Errorf("%w: unknown content type %q", ErrInvalidTagData, contentType)
+	}
+
+	usage, err := s.repo.CountUsage([]uint{t.ID})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count tag usage: %w", err)
+	}
+
+	content, total, err := s.repo.GetContent(t.ID, contentType, page, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to load tagged content: %w", err)
+	}
+	if content == nil {
+		content = []tag.TaggedContent{}
+	}
+
+	return &tag.TagDetail{
+		TagWithUsage: tag.TagWithUsage{Tag: *t, Usage: usage[t.ID]},
+		Content:      content,
+	}, total, nil
+}
+
+func (s *tagService) GetByID(id uint) (*tag.Tag, error) {
+	t, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, ErrTagNotFound
+	}
+	return t, nil
+}
+
+func (s *tagService) Create(req *tag.CreateTagRequest) (*tag.Tag, error) {
+	name, err := validTagName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	tagSlug := strings.TrimSpace(req.Slug)
+	if tagSlug == "" {
+		tagSlug = name
+	}
+	tagSlug = slug.Make(tagSlug)
+	if tagSlug == "" {
+		return nil, fmt.Errorf("%w: slug cannot be empty", ErrInvalidTagData)
+	}
+
+	if err := s.checkAvailable(name, tagSlug, 0); err != nil {
+		return nil, err
+	}
+
+	t := &tag.Tag{Name: name, Slug: tagSlug}
+	if err := s.repo.Create(t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Update renames a tag. The new name is written to every report, blog and
+// press release carrying it, and the old slug redirects to the new one.
+func (s *tagService) Update(id uint, req *tag.UpdateTagRequest) (*tag.Tag, error) {
+	t, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, ErrTagNotFound
+	}
+	oldSlug, oldName := t.Slug, t.Name
+
+	if req.Name != nil {
+		name, err := validTagName(*req.Name)
+		if err != nil {
+			return nil, err
+		}
+		t.Name = name
+		// The slug follows the name unless one is given
+		if req.Slug == nil {
+			t.Slug = slug.Make(name)
+		}
+	}
+
+	if req.Slug != nil {
+		t.Slug = slug.Make(*req.Slug)
+	}
+	if t.Slug == "" {
+		return nil, fmt.Errorf("%w: slug cannot be empty", ErrInvalidTagData)
+	}
+
+	if t.Name == oldName && t.Slug == oldSlug {
+		return t, nil
+	}
+	if err := s.checkAvailable(t.Name, t.Slug, id); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.Update(t)
+	if err != nil {
+		return nil, err
+	}
+
+	// Old links keep working through a redirect
+	recordSlugChange(s.redirects, redirect.EntityTag, id, oldSlug, t.Slug)
+
+	if updated > 0 {
+		invalidateTaggedContentCaches()
+	}
+
+	return t, nil
+}
+
+// Delete removes a tag from all content and deletes it
+func (s *tagService) Delete(id uint) (*tag.Tag, error) {
+	t, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, ErrTagNotFound
+	}
+
+	updated, err := s.repo.Delete(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if updated > 0 {
+		invalidateTaggedContentCaches()
+	}
+
+	return t, nil
+}
+
+// Merge folds duplicate tags into the target. Content carrying a source
+// carries the target instead and the sources' slugs redirect to it.
+func (s *tagService) Merge(targetID uint, sourceIDs []uint) (*tag.MergeResult, error) {
+	target, err := s.repo.GetByID(targetID)
+	if err != nil {
+		return nil, ErrTagNotFound
+	}
+
+	ids := uniqueIDs(sourceIDs)
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%w: at least one source tag is required", ErrInvalidTagMerge)
+	}
+	for _, id := range ids {
+		if id == targetID {
+			return nil, fmt.Errorf("%w: a tag cannot be merged into itself", ErrInvalidTagMerge)
+		}
+	}
+
+	sources, err := s.repo.GetByIDs(ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load source tags: %w", err)
+	}
+	if len(sources) != len(ids) {
+		return nil, fmt.Errorf("%w: source tag does not exist", ErrTagNotFound)
+	}
+
+	updated, err := s.repo.Merge(target, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge tags: %w", err)
+	}
+
+	for _, src := range sources {
+		recordSlugChange(s.redirects, redirect.EntityTag, targetID, src.Slug, target.Slug)
+	}
+
+	if updated > 0 {
+		invalidateTaggedContentCaches()
+	}
+
+	return &tag.MergeResult{Tag: target, MergedIDs: ids, ContentUpdated: updated}, nil
+}
+
+// checkTagNames returns an error for the first of the cleaned names that no
+// tag could have
+func checkTagNames(names []string) error {
+	for _, name := range names {
+		if utf8.RuneCountInString(name) > tag.MaxNameLength {
+			return fmt.Errorf("%w: %q is longer than %d characters", ErrInvalidTags, name, tag.MaxNameLength)
+		}
+		if slug.Make(name) == "" {
+			return fmt.Errorf("%w: %q has no letters or digits", ErrInvalidTags, name)
+		}
+	}
+	return nil
+}
+
+// resolveTags matches each name to a tag by name (ignoring case) or slug,
+// creating tags for names that match none. Names that resolve to the same
+// tag are only returned once. Content services call it with the tag
+// repository of the transaction saving the content, followed by
+// setContentTags, so tags are not left behind when the save fails.
+func resolveTags(repo repository.TagRepository, names []string) ([]tag.Tag, error) {
+	names = tag.Clean(names)
+	if len(names) == 0 {
+		return []tag.Tag{}, nil
+	}
+
+	if err := checkTagNames(names); err != nil {
+		return nil, err
+	}
+
+	lowerNames := make([]string, len(names))
+	slugs := make([]string, len(names))
+	for i, name := range names {
+		lowerNames[i] = strings.ToLower(name)
+		slugs[i] = slug.Make(name)
+	}
+
+	existing, err := repo.FindByNames(lowerNames, slugs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up tags: %w", err)
+	}
+	byName := map[string]tag.Tag{}
+	bySlug := map[string]tag.Tag{}
+	for _, t := range existing {
+		byName[strings.ToLower(t.Name)] = t
+		bySlug[t.Slug] = t
+	}
+
+	tags := make([]tag.Tag, 0, len(names))
+	seen := map[uint]bool{}
+	for i, name := range names {
+		t, ok := byName[lowerNames[i]]
+		if !ok {
+			t, ok = bySlug[slugs[i]]
+		}
+		if !ok {
+			t = tag.Tag{Name: name, Slug: slugs[i]}
+			if err := repo.Create(&t); err != nil {
+				return nil, fmt.Errorf("failed to create tag %q: %w", name, err)
+			}
+			byName[lowerNames[i]] = t
+			bySlug[slugs[i]] = t
+		}
+		if seen[t.ID] {
+			continue
+		}
+		seen[t.ID] = true
+		tags = append(tags, t)
+	}
+
+	if len(tag.Join(tag.Names(tags))) > maxTagListLength {
+		return nil, fmt.Errorf("%w: tags must fit in %d characters", ErrInvalidTags, maxTagListLength)
+	}
+
+	return tags, nil
+}
+
+// setContentTags attaches the tags returned by resolveTags to the content
+func setContentTags(repo repository.TagRepository, contentType string, contentID uint, tags []tag.Tag) error {
+	ids := make([]uint, len(tags))
+	for i, t := range tags {
+		ids[i] = t.ID
+	}
+	if err := repo.SetContentTags(contentType, contentID, ids); err != nil {
+		return fmt.Errorf("failed to tag %s %d: %w", contentType, contentID, err)
+	}
+	return nil
+}
+
+// checkAvailable makes sure no other tag has the name or slug
+func (s *tagService) checkAvailable(name, tagSlug string, excludeID uint) error {
+	taken, err := s.repo.NameExists(name, excludeID)
+	if err != nil {
+		return fmt.Errorf("failed to check name availability: %w", err)
+	}
+	if taken {
+		return ErrTagNameTaken
+	}
+
+	taken, err = s.repo.SlugExists(tagSlug, excludeID)
+	if err != nil {
+		return fmt.Errorf("failed to check slug availability: %w", err)
+	}
+	if taken {
+		return ErrTagSlugTaken
+	}
+	return nil
+}
+
+func validTagName(name string) (string, error) {
+	name = tag.CleanName(name)
+	if name == "" {
+		return "", fmt.Errorf("%w: name is required", ErrInvalidTagData)
+	}
+	if strings.Contains(name, ",") {
+		return "", fmt.Errorf("%w: name cannot contain a comma", ErrInvalidTagData)
+	}
+	if utf8.RuneCountInString(name) > tag.MaxNameLength {
+		return "", fmt.Errorf("%w: name must be at most %d characters", ErrInvalidTagData, tag.MaxNameLength)
+	}
+	return name, nil
+}
+
+// invalidateTaggedContentCaches clears cached content whose tag names were
+// rewritten by a rename, merge or delete
+func invalidateTaggedContentCaches() {
+	cache.DeletePattern("reports:list:*")
+	cache.DeletePattern("report:slug:*")
+	cache.DeletePattern("blogs:*")
+	cache.DeletePattern("blog:*")
+	cache.DeletePattern("press_releases:*")
+	cache.DeletePattern("press_release:*")
+}
@@ -0,0 +1,327 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/healthcare-market-research/backend/internal/config"
+)
+
+// cloudflareAPIURL is the base of the Cloudflare v4 API
+const cloudflareAPIURL = "https://api.cloudflare.com/client/v4"
+
+// cloudflareVariant is the delivery variant image URLs point at
+const cloudflareVariant = "public"
+
+type cloudflareStorage struct {
+	config     *config.CloudflareConfig
+	apiURL     string
+	httpClient *http.Client
+}
+
+// NewCloudflareStorage keeps images in Cloudflare Images and serves them from
+// the account's delivery URL
+func NewCloudflareStorage(cfg *config.CloudflareConfig) (ImageStorage, error) {
+	if cfg.AccountID == "" || cfg.APIToken == "" || cfg.DeliveryURL == "" {
+		return nil, fmt.Errorf("cloudflare storage needs CLOUDFLARE_ACCOUNT_ID, CLOUDFLARE_IMAGES_API_TOKEN and CLOUDFLARE_DELIVERY_URL")
+	}
+
+	return &cloudflareStorage{
+		config: cfg,
+		apiURL: cloudflareAPIURL,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}, nil
+}
+
+// CloudflareResponse is the envelope of every Cloudflare Images API response
+type CloudflareResponse struct {
+	Success bool              `json:"success"`
+	Errors  []CloudflareError `json:"errors"`
+	Result  json.RawMessage   `json:"result"`
+}
+
+type CloudflareImageResult struct {
+	ID       string    `json:"id"`
+	Filename string    `json:"filename"`
+	Uploaded time.Time `json:"uploaded"`
+	Variants []string  `json:"variants"`
+}
+
+// CloudflareListResult is one page of the v2 image listing
+type CloudflareListResult struct {
+	Images            []CloudflareImageResult `json:"images"`
+	ContinuationToken string                  `json:"continuation_token"`
+}
+
+type CloudflareError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Upload uploads an image to Cloudflare Images
+func (s *cloudflareStorage) Upload(filename string, src io.Reader, metadata map[string]string) (string, error) {
+	// Create a buffer to write our multipart form
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	// Add the file
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to create form file: %w", err)
+	}
+
+	if _, err := io.Copy(part, src); err != nil {
+		return "", fmt.Errorf("failed to copy file: %w", err)
+	}
+
+	// Add metadata if provided
+	if len(metadata) > 0 {
+		metadataJSON, err := json.Marshal(metadata)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal metadata: %w", err)
+		}
+		if err := writer.WriteField("metadata", string(metadataJSON)); err != nil {
+			return "", fmt.Errorf("failed to write metadata field: %w", err)
+		}
+	}
+
+	// Close the writer to finalize the multipart message
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("failed to close writer: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.imagesURL(""), &body)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	result, err := s.do(req)
+	if err != nil {
+		return "", err
+	}
+
+	return s.URL(result.ID), nil
+}
+
+// Delete deletes an image from Cloudflare Images
+func (s *cloudflareStorage) Delete(imageURL string) error {
+	imageID, err := s.extractImageID(imageURL)
+	if err != nil {
+		return fmt.Errorf("failed to extract image ID: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodDelete, s.imagesURL(imageID), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	_, err = s.do(req)
+	return err
+}
+
+// URL returns the public variant URL of an image ID
+func (s *cloudflareStorage) URL(imageID string) string {
+	return fmt.Sprintf("%s/%s/%s", s.config.DeliveryURL, imageID, cloudflareVariant)
+}
+
+// Key returns the image ID of imageURL
+func (s *cloudflareStorage) Key(imageURL string) (string, error) {
+	return s.extractImageID(imageURL)
+}
+
+// Stat looks up the image's details. Cloudflare does not report the size or
+// type of the original upload.
+func (s *cloudflareStorage) Stat(imageURL string) (*ImageInfo, error) {
+	imageID, err := s.extractImageID(imageURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract image ID: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, s.imagesURL(imageID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	result, err := s.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ImageInfo{
+		Key:          result.ID,
+		URL:          s.URL(result.ID),
+		LastModified: result.Uploaded,
+	}, nil
+}
+
+// Open downloads the original upload through the image blob API
+func (s *cloudflareStorage) Open(imageURL string) (io.ReadCloser, error) {
+	imageID, err := s.extractImageID(imageURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract image ID: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, s.imagesURL(imageID)+"/blob", nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.config.APIToken))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrNotFound
+	default:
+		resp.Body.Close()
+		return nil, fmt.Errorf("request failed with status: %d", resp.StatusCode)
+	}
+}
+
+// cloudflareListPageSize is the number of images requested per listing page
+const cloudflareListPageSize = 1000
+
+// List pages through the account's images with the v2 listing API
+func (s *cloudflareStorage) List(fn func(*ImageInfo) error) error {
+	token := ""
+	for {
+		query := url.Values{"per_page": {strconv.Itoa(cloudflareListPageSize)}}
+		if token != "" {
+			query.Set("continuation_token", token)
+		}
+		listURL := fmt.Sprintf("%s/accounts/%s/images/v2?%s", s.apiURL, s.config.AccountID, query.Encode())
+
+		req, err := http.NewRequest(http.MethodGet, listURL, nil)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+
+		var page CloudflareListResult
+		if err := s.call(req, &page); err != nil {
+			return err
+		}
+
+		for _, image := range page.Images {
+			err := fn(&ImageInfo{
+				Key:          image.ID,
+				URL:          s.URL(image.ID),
+				LastModified: image.Uploaded,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if page.ContinuationToken == "" || len(page.Images) == 0 {
+			return nil
+		}
+		token = page.ContinuationToken
+	}
+}
+
+func (s *cloudflareStorage) imagesURL(imageID string) string {
+	url := fmt.Sprintf("%s/accounts/%s/images/v1", s.apiURL, s.config.AccountID)
+	if imageID != "" {
+		url += "/" + imageID
+	}
+	return url
+}
+
+// do executes an image request and returns the image in the response
+func (s *cloudflareStorage) do(req *http.Request) (*CloudflareImageResult, error) {
+	var result CloudflareImageResult
+	if err := s.call(req, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// call authenticates and executes req and unwraps the API response envelope
+// into result
+func (s *cloudflareStorage) call(req *http.Request, result interface{}) error {
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", s.config.APIToken))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return ErrNotFound
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var apiResp CloudflareResponse
+	if err := json.Unmarshal(respBody, &apiResp); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if !apiResp.Success || len(apiResp.Errors) > 0 {
+		if len(apiResp.Errors) > 0 {
+			return fmt.Errorf("cloudflare API error: %s", apiResp.Errors[0].Message)
+		}
+		return fmt.Errorf("request failed with status: %d", resp.StatusCode)
+	}
+
+	if len(apiResp.Result) == 0 || string(apiResp.Result) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(apiResp.Result, result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// extractImageID extracts the Cloudflare image ID from a full image URL
+// Example URL: https://imagedelivery.net/Gy9qXOTaFeYdmWJ69whXhw/2cdc28f0-017a-49c4-9ed7-87056c83901/public
+// Returns: 2cdc28f0-017a-49c4-9ed7-87056c83901
+func (s *cloudflareStorage) extractImageID(imageURL string) (string, error) {
+	if imageURL == "" {
+		return "", fmt.Errorf("image URL is empty")
+	}
+
+	// Remove the delivery URL prefix
+	if !strings.HasPrefix(imageURL, s.config.DeliveryURL) {
+		return "", ErrInvalidURL
+	}
+
+	// Remove the delivery URL and leading slash
+	remainder := strings.TrimPrefix(imageURL, s.config.DeliveryURL)
+	remainder = strings.TrimPrefix(remainder, "/")
+
+	// The image ID comes first, optionally followed by a variant name. A URL
+	// holding only the variant has no ID.
+	parts := strings.Split(remainder, "/")
+	if len(parts) == 1 && parts[0] == cloudflareVariant {
+		return "", fmt.Errorf("invalid Cloudflare image URL format")
+	}
+
+	imageID := parts[0]
+	if imageID == "" {
+		return "", fmt.Errorf("image ID is empty in URL")
+	}
+
+	return imageID, nil
+}
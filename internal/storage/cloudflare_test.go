@@ -0,0 +1,310 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/healthcare-market-research/backend/internal/config"
+)
+
+func newTestCloudflareStorage(t *testing.T, apiURL string) *cloudflareStorage {
+	t.Helper()
+
+	cfg := &config.CloudflareConfig{
+		AccountID:   "test-account",
+		APIToken:    "test-token",
+		DeliveryURL: "https://imagedelivery.net/test-hash",
+	}
+
+	s, err := NewCloudflareStorage(cfg)
+	if err != nil {
+		t.Fatalf("NewCloudflareStorage() error = %v", err)
+	}
+	cf := s.(*cloudflareStorage)
+	if apiURL != "" {
+		cf.apiURL = apiURL
+	}
+	return cf
+}
+
+func TestCloudflareStorage_ExtractImageID(t *testing.T) {
+	service := newTestCloudflareStorage(t, "")
+
+	tests := []struct {
+		name      string
+		imageURL  string
+		wantID    string
+		wantError bool
+	}{
+		{
+			name:      "Valid URL",
+			imageURL:  "https://imagedelivery.net/test-hash/2cdc28f0-017a-49c4-9ed7-87056c83901/public",
+			wantID:    "2cdc28f0-017a-49c4-9ed7-87056c83901",
+			wantError: false,
+		},
+		{
+			name:      "Empty URL",
+			imageURL:  "",
+			wantID:    "",
+			wantError: true,
+		},
+		{
+			name:      "Invalid URL - wrong prefix",
+			imageURL:  "https://example.com/image.jpg",
+			wantID:    "",
+			wantError: true,
+		},
+		{
+			name:      "Invalid URL - missing image ID",
+			imageURL:  "https://imagedelivery.net/test-hash/public",
+			wantID:    "",
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotID, err := service.extractImageID(tt.imageURL)
+			if (err != nil) != tt.wantError {
+				t.Errorf("extractImageID() error = %v, wantError %v", err, tt.wantError)
+				return
+			}
+			if gotID != tt.wantID {
+				t.Errorf("extractImageID() gotID = %v, want %v", gotID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestCloudflareStorage_Upload(t *testing.T) {
+	// Create a mock HTTP server
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Verify request method, path and headers
+		if r.Method != "POST" {
+			t.Errorf("Expected POST request, got %s", r.Method)
+		}
+		if r.URL.Path != "/accounts/test-account/images/v1" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if !strings.Contains(r.Header.Get("Authorization"), "Bearer test-token") {
+			t.Errorf("Expected Authorization header with Bearer token")
+		}
+
+		// Verify multipart form
+		err := r.ParseMultipartForm(10 << 20)
+		if err != nil {
+			t.Errorf("Failed to parse multipart form: %v", err)
+		}
+		if got := r.FormValue("metadata"); got != `{"author_id":"123"}` {
+			t.Errorf("Unexpected metadata %q", got)
+		}
+
+		// Return mock success response
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{
+			"success": true,
+			"result": {
+				"id": "test-image-id-123",
+				"filename": "test.jpg",
+				"variants": ["https://imagedelivery.net/test-hash/test-image-id-123/public"]
+			}
+		}`))
+	}))
+	defer mockServer.Close()
+
+	service := newTestCloudflareStorage(t, mockServer.URL)
+
+	imageURL, err := service.Upload("test.jpg", strings.NewReader("fake image content"), map[string]string{"author_id": "123"})
+	if err != nil {
+		t.Fatalf("Upload() error = %v", err)
+	}
+	if imageURL != "https://imagedelivery.net/test-hash/test-image-id-123/public" {
+		t.Errorf("Upload() imageURL = %v", imageURL)
+	}
+}
+
+func TestCloudflareStorage_Delete(t *testing.T) {
+	// Create a mock HTTP server
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Verify request method and headers
+		if r.Method != "DELETE" {
+			t.Errorf("Expected DELETE request, got %s", r.Method)
+		}
+		if r.URL.Path != "/accounts/test-account/images/v1/image-id-123" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if !strings.Contains(r.Header.Get("Authorization"), "Bearer test-token") {
+			t.Errorf("Expected Authorization header with Bearer token")
+		}
+
+		// Return mock success response
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{
+			"success": true
+		}`))
+	}))
+	defer mockServer.Close()
+
+	service := newTestCloudflareStorage(t, mockServer.URL)
+
+	if err := service.Delete("https://imagedelivery.net/test-hash/image-id-123/public"); err != nil {
+		t.Errorf("Delete() error = %v", err)
+	}
+}
+
+func TestCloudflareStorage_Stat(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/accounts/test-account/images/v1/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"success": false, "errors": [{"code": 5404, "message": "Image not found"}]}`))
+			return
+		}
+		w.Write([]byte(`{
+			"success": true,
+			"result": {"id": "image-id-123", "filename": "test.jpg", "uploaded": "2026-03-01T10:00:00Z"}
+		}`))
+	}))
+	defer mockServer.Close()
+
+	service := newTestCloudflareStorage(t, mockServer.URL)
+
+	info, err := service.Stat("https://imagedelivery.net/test-hash/image-id-123/public")
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+	if info.Key != "image-id-123" || info.LastModified.IsZero() {
+		t.Errorf("Stat() = %+v", info)
+	}
+
+	_, err = service.Stat("https://imagedelivery.net/test-hash/missing/public")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Stat() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestCloudflareStorage_Open(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+		}
+		if r.URL.Path != "/accounts/test-account/images/v1/image-id-123/blob" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("original bytes"))
+	}))
+	defer mockServer.Close()
+
+	service := newTestCloudflareStorage(t, mockServer.URL)
+
+	body, err := service.Open("https://imagedelivery.net/test-hash/image-id-123/public")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	data, _ := io.ReadAll(body)
+	body.Close()
+	if string(data) != "original bytes" {
+		t.Errorf("Open() read %q", data)
+	}
+
+	_, err = service.Open("https://imagedelivery.net/test-hash/missing/public")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Open() error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestCloudflareStorage_List(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/accounts/test-account/images/v2" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("continuation_token") == "" {
+			w.Write([]byte(`{"success": true, "result": {"images": [{"id": "a", "uploaded": "2026-03-01T10:00:00Z"}], "continuation_token": "next"}}`))
+			return
+		}
+		w.Write([]byte(`{"success": true, "result": {"images": [{"id": "b", "uploaded": "2026-03-02T10:00:00Z"}], "continuation_token": null}}`))
+	}))
+	defer mockServer.Close()
+
+	service := newTestCloudflareStorage(t, mockServer.URL)
+
+	var urls []string
+	err := service.List(func(info *ImageInfo) error {
+		urls = append(urls, info.URL)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	want := []string{"https://imagedelivery.net/test-hash/a/public", "https://imagedelivery.net/test-hash/b/public"}
+	if strings.Join(urls, ",") != strings.Join(want, ",") {
+		t.Errorf("List() = %v, want %v", urls, want)
+	}
+
+	key, err := service.Key(want[1])
+	if err != nil || key != "b" {
+		t.Errorf("Key() = %q, %v", key, err)
+	}
+}
+
+func TestCloudflareStorage_ExtractImageID_EdgeCases(t *testing.T) {
+	service := newTestCloudflareStorage(t, "")
+
+	tests := []struct {
+		name      string
+		imageURL  string
+		wantError bool
+	}{
+		{
+			name:      "URL with trailing slash",
+			imageURL:  "https://imagedelivery.net/test-hash/image-id-123/public/",
+			wantError: false,
+		},
+		{
+			name:      "URL without /public suffix",
+			imageURL:  "https://imagedelivery.net/test-hash/image-id-123",
+			wantError: false,
+		},
+		{
+			name:      "URL with different variant",
+			imageURL:  "https://imagedelivery.net/test-hash/image-id-123/thumbnail",
+			wantError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.extractImageID(tt.imageURL)
+			if (err != nil) != tt.wantError {
+				t.Errorf("extractImageID() error = %v, wantError %v", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestCloudflareStorage_Upload_ErrorResponse(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"success": false, "errors": [{"code": 5400, "message": "Bad request: image is too large"}]}`))
+	}))
+	defer mockServer.Close()
+
+	service := newTestCloudflareStorage(t, mockServer.URL)
+
+	_, err := service.Upload("test.jpg", strings.NewReader("fake image data"), map[string]string{"author_id": "123"})
+	if err == nil || err.Error() != "cloudflare API error: Bad request: image is too large" {
+		t.Errorf("Upload() error = %v", err)
+	}
+}
+
+func TestNewCloudflareStorage_MissingConfig(t *testing.T) {
+	if _, err := NewCloudflareStorage(&config.CloudflareConfig{AccountID: "test-account"}); err == nil {
+		t.Error("Expected error for incomplete config")
+	}
+}
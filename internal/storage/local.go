@@ -0,0 +1,179 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/healthcare-market-research/backend/internal/config"
+)
+
+// LocalRoute is the path the API serves the local storage directory under
+const LocalRoute = "/uploads"
+
+type localStorage struct {
+	dir     string
+	baseURL string
+}
+
+// NewLocalStorage keeps images in a directory on disk. The directory must be
+// served at baseURL, which cmd/api does with a static route on LocalRoute.
+func NewLocalStorage(cfg *config.LocalStorageConfig) (ImageStorage, error) {
+	if cfg.Dir == "" || cfg.BaseURL == "" {
+		return nil, fmt.Errorf("local storage needs STORAGE_LOCAL_DIR and STORAGE_LOCAL_BASE_URL")
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+
+	return &localStorage{
+		dir:     cfg.Dir,
+		baseURL: strings.TrimRight(cfg.BaseURL, "/"),
+	}, nil
+}
+
+// Upload writes the image to a new file under the storage directory. Metadata
+// is not kept on disk.
+func (s *localStorage) Upload(filename string, body io.Reader, metadata map[string]string) (string, error) {
+	key := newKey(filename)
+	dest := s.path(key)
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Write to a temporary file first so a failed upload never leaves a
+	// truncated image behind at the final path
+	tmp, err := os.CreateTemp(filepath.Dir(dest), ".upload-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, body); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		return "", fmt.Errorf("failed to move file into place: %w", err)
+	}
+
+	return s.URL(key), nil
+}
+
+// Delete removes the image file
+func (s *localStorage) Delete(imageURL string) error {
+	key, err := keyFromURL(s.baseURL, imageURL)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(s.path(key)); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	return nil
+}
+
+// URL returns the public URL of key
+func (s *localStorage) URL(key string) string {
+	return s.baseURL + "/" + key
+}
+
+// Key returns the path of imageURL below the storage directory
+func (s *localStorage) Key(imageURL string) (string, error) {
+	return keyFromURL(s.baseURL, imageURL)
+}
+
+// Open opens the image file
+func (s *localStorage) Open(imageURL string) (io.ReadCloser, error) {
+	key, err := keyFromURL(s.baseURL, imageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(s.path(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	return f, nil
+}
+
+// Stat reads the image file's size and modification time
+func (s *localStorage) Stat(imageURL string) (*ImageInfo, error) {
+	key, err := keyFromURL(s.baseURL, imageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(s.path(key))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to stat file: %w", err)
+	}
+
+	return &ImageInfo{
+		Key:          key,
+		URL:          s.URL(key),
+		Size:         info.Size(),
+		ContentType:  mime.TypeByExtension(path.Ext(key)),
+		LastModified: info.ModTime(),
+	}, nil
+}
+
+// List walks the storage directory, skipping the temporary files of uploads
+// in progress
+func (s *localStorage) List(fn func(*ImageInfo) error) error {
+	return filepath.WalkDir(s.dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("failed to list files: %w", err)
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".") {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return fmt.Errorf("failed to stat file: %w", err)
+		}
+		rel, err := filepath.Rel(s.dir, p)
+		if err != nil {
+			return fmt.Errorf("failed to list files: %w", err)
+		}
+		key := filepath.ToSlash(rel)
+
+		return fn(&ImageInfo{
+			Key:          key,
+			URL:          s.URL(key),
+			Size:         info.Size(),
+			ContentType:  mime.TypeByExtension(path.Ext(key)),
+			LastModified: info.ModTime(),
+		})
+	})
+}
+
+func (s *localStorage) path(key string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(key))
+}
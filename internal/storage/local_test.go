@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/healthcare-market-research/backend/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorage_Lifecycle(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewLocalStorage(&config.LocalStorageConfig{Dir: dir, BaseURL: "http://localhost:8081/uploads/"})
+	require.NoError(t, err)
+
+	imageURL, err := s.Upload("Chart.PNG", strings.NewReader("png bytes"), map[string]string{"report_id": "1001"})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(imageURL, "http://localhost:8081/uploads/"))
+	assert.True(t, strings.HasSuffix(imageURL, ".png"))
+
+	info, err := s.Stat(imageURL)
+	require.NoError(t, err)
+	assert.Equal(t, imageURL, info.URL)
+	assert.Equal(t, int64(9), info.Size)
+	assert.Equal(t, "image/png", info.ContentType)
+	assert.Equal(t, s.URL(info.Key), imageURL)
+
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(info.Key)))
+	require.NoError(t, err)
+	assert.Equal(t, "png bytes", string(data))
+
+	f, err := s.Open(imageURL)
+	require.NoError(t, err)
+	data, err = io.ReadAll(f)
+	f.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "png bytes", string(data))
+
+	require.NoError(t, s.Delete(imageURL))
+	_, err = s.Stat(imageURL)
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.True(t, errors.Is(s.Delete(imageURL), ErrNotFound))
+	_, err = s.Open(imageURL)
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestLocalStorage_List(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewLocalStorage(&config.LocalStorageConfig{Dir: dir, BaseURL: "http://localhost:8081/uploads"})
+	require.NoError(t, err)
+
+	first, err := s.Upload("a.png", strings.NewReader("a"), nil)
+	require.NoError(t, err)
+	second, err := s.Upload("b.jpg", strings.NewReader("bb"), nil)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".upload-123"), []byte("partial"), 0o644))
+
+	var urls []string
+	require.NoError(t, s.List(func(info *ImageInfo) error {
+		urls = append(urls, info.URL)
+		assert.Equal(t, info.URL, s.URL(info.Key))
+		return nil
+	}))
+	assert.ElementsMatch(t, []string{first, second}, urls)
+
+	key, err := s.Key(first)
+	require.NoError(t, err)
+	assert.Equal(t, first, s.URL(key))
+
+	stop := errors.New("stop")
+	assert.ErrorIs(t, s.List(func(*ImageInfo) error { return stop }), stop)
+}
+
+func TestLocalStorage_RejectsForeignURLs(t *testing.T) {
+	s, err := NewLocalStorage(&config.LocalStorageConfig{Dir: t.TempDir(), BaseURL: "http://localhost:8081/uploads"})
+	require.NoError(t, err)
+
+	for _, imageURL := range []string{
+		"https://imagedelivery.net/hash/id/public",
+		"http://localhost:8081/uploads/../config.env",
+		"http://localhost:8081/uploads/2026/03/../../../etc/passwd",
+		"http://localhost:8081/uploads/",
+	} {
+		_, err := s.Stat(imageURL)
+		assert.ErrorIs(t, err, ErrInvalidURL, imageURL)
+		assert.ErrorIs(t, s.Delete(imageURL), ErrInvalidURL, imageURL)
+	}
+}
+
+func TestNew_SelectsDriver(t *testing.T) {
+	s, err := New(&config.StorageConfig{
+		Driver: DriverLocal,
+		Local:  config.LocalStorageConfig{Dir: t.TempDir(), BaseURL: "http://localhost:8081/uploads"},
+	})
+	require.NoError(t, err)
+	assert.IsType(t, &localStorage{}, s)
+
+	_, err = New(&config.StorageConfig{Driver: "ftp"})
+	assert.ErrorIs(t, err, ErrUnknownType)
+
+	_, err = New(&config.StorageConfig{Driver: DriverS3})
+	assert.Error(t, err)
+}
+
+func TestNewDocumentStorage_AvoidsCloudflareImages(t *testing.T) {
+	s, err := NewDocumentStorage(&config.StorageConfig{
+		Driver:     DriverCloudflare,
+		Local:      config.LocalStorageConfig{Dir: t.TempDir(), BaseURL: "http://localhost:8081/uploads"},
+		Cloudflare: config.CloudflareConfig{AccountID: "account", APIToken: "token", DeliveryURL: "https://imagedelivery.net/hash"},
+	})
+	require.NoError(t, err)
+	assert.IsType(t, &localStorage{}, s)
+}
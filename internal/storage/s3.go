@@ -0,0 +1,379 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/healthcare-market-research/backend/internal/config"
+)
+
+type s3Storage struct {
+	config     *config.S3Config
+	endpoint   *url.URL
+	publicURL  string
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewS3Storage keeps images in an S3 bucket. Any S3-compatible store works;
+// MinIO needs UsePathStyle. Requests are signed with AWS Signature Version 4.
+func NewS3Storage(cfg *config.S3Config) (ImageStorage, error) {
+	if cfg.Bucket == "" || cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, fmt.Errorf("s3 storage needs S3_BUCKET, S3_ACCESS_KEY_ID and S3_SECRET_ACCESS_KEY")
+	}
+
+	endpoint, err := url.Parse(strings.TrimRight(cfg.Endpoint, "/"))
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid S3_ENDPOINT %q", cfg.Endpoint)
+	}
+
+	s := &s3Storage{
+		config:   cfg,
+		endpoint: endpoint,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		now: time.Now,
+	}
+
+	s.publicURL = strings.TrimRight(cfg.PublicURL, "/")
+	if s.publicURL == "" {
+		s.publicURL = s.bucketURL()
+	}
+
+	return s, nil
+}
+
+// Upload puts the image into the bucket. Metadata is stored as x-amz-meta-*
+// headers on the object.
+func (s *s3Storage) Upload(filename string, body io.Reader, metadata map[string]string) (string, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	key := newKey(filename)
+	req, err := http.NewRequest(http.MethodPut, s.objectURL(key), bytes.NewReader(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType(filename, data))
+	for k, v := range metadata {
+		req.Header.Set("x-amz-meta-"+strings.ToLower(k), v)
+	}
+
+	resp, err := s.do(req, data)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", s3Error(resp)
+	}
+
+	return s.URL(key), nil
+}
+
+// Delete removes the object. S3 answers 204 whether or not the key existed.
+func (s *s3Storage) Delete(imageURL string) error {
+	key, err := keyFromURL(s.publicURL, imageURL)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodDelete, s.objectURL(key), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.do(req, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent:
+		return nil
+	case http.StatusNotFound:
+		return ErrNotFound
+	default:
+		return s3Error(resp)
+	}
+}
+
+// URL returns the public URL of key
+func (s *s3Storage) URL(key string) string {
+	return s.publicURL + "/" + key
+}
+
+// Key returns the object key of imageURL
+func (s *s3Storage) Key(imageURL string) (string, error) {
+	return keyFromURL(s.publicURL, imageURL)
+}
+
+// Stat reads the object's headers
+func (s *s3Storage) Stat(imageURL string) (*ImageInfo, error) {
+	key, err := keyFromURL(s.publicURL, imageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodHead, s.objectURL(key), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.do(req, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrNotFound
+	default:
+		return nil, s3Error(resp)
+	}
+
+	info := &ImageInfo{
+		Key:         key,
+		URL:         s.URL(key),
+		ContentType: resp.Header.Get("Content-Type"),
+	}
+	info.Size, _ = strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64)
+	info.LastModified, _ = http.ParseTime(resp.Header.Get("Last-Modified"))
+	return info, nil
+}
+
+// Open downloads the object
+func (s *s3Storage) Open(imageURL string) (io.ReadCloser, error) {
+	key, err := keyFromURL(s.publicURL, imageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, s.objectURL(key), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.do(req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrNotFound
+	default:
+		defer resp.Body.Close()
+		return nil, s3Error(resp)
+	}
+}
+
+// listBucketResult is the ListObjectsV2 response body
+type listBucketResult struct {
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+	Contents              []struct {
+		Key          string    `xml:"Key"`
+		LastModified time.Time `xml:"LastModified"`
+		Size         int64     `xml:"Size"`
+	} `xml:"Contents"`
+}
+
+// List pages through the bucket with ListObjectsV2
+func (s *s3Storage) List(fn func(*ImageInfo) error) error {
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+
+		req, err := http.NewRequest(http.MethodGet, s.bucketURL()+"?"+query.Encode(), nil)
+		if err != nil {
+			return fmt.Errorf("failed to create request: %w", err)
+		}
+
+		page, err := s.listPage(req)
+		if err != nil {
+			return err
+		}
+
+		for _, object := range page.Contents {
+			err := fn(&ImageInfo{
+				Key:          object.Key,
+				URL:          s.URL(object.Key),
+				Size:         object.Size,
+				ContentType:  mime.TypeByExtension(path.Ext(object.Key)),
+				LastModified: object.LastModified,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			return nil
+		}
+		token = page.NextContinuationToken
+	}
+}
+
+func (s *s3Storage) listPage(req *http.Request) (*listBucketResult, error) {
+	resp, err := s.do(req, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, s3Error(resp)
+	}
+
+	var page listBucketResult
+	if err := xml.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, fmt.Errorf("failed to parse bucket listing: %w", err)
+	}
+	return &page, nil
+}
+
+func (s *s3Storage) do(req *http.Request, payload []byte) (*http.Response, error) {
+	s.sign(req, payload, s.now().UTC())
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	return resp, nil
+}
+
+// bucketURL addresses the bucket path-style (endpoint/bucket) or
+// virtual-hosted-style (bucket.endpoint)
+func (s *s3Storage) bucketURL() string {
+	if s.config.UsePathStyle {
+		return s.endpoint.String() + "/" + s.config.Bucket
+	}
+	return s.endpoint.Scheme + "://" + s.config.Bucket + "." + s.endpoint.Host + s.endpoint.Path
+}
+
+func (s *s3Storage) objectURL(key string) string {
+	return s.bucketURL() + "/" + key
+}
+
+// sign adds an AWS Signature Version 4 Authorization header covering every
+// header set on the request
+func (s *s3Storage) sign(req *http.Request, payload []byte, now time.Time) {
+	payloadHash := sha256Hex(payload)
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		escapePath(req.URL.Path),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.config.Region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.config.SecretAccessKey), date)
+	key = hmacSHA256(key, s.config.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.config.AccessKeyID, scope, signedHeaders, signature,
+	))
+}
+
+// escapePath URI-encodes every byte of each path segment except the
+// unreserved characters, as SigV4 requires
+func escapePath(p string) string {
+	if p == "" {
+		return "/"
+	}
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if c == '/' || c == '-' || c == '_' || c == '.' || c == '~' ||
+			('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// s3Error turns an S3 error response into an error carrying its code
+func s3Error(resp *http.Response) error {
+	var body struct {
+		Code string `xml:"Code"`
+	}
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, 4096)).Decode(&body); err != nil || body.Code == "" {
+		return fmt.Errorf("s3 request failed with status: %d", resp.StatusCode)
+	}
+	return fmt.Errorf("s3 API error: %s (status %d)", body.Code, resp.StatusCode)
+}
@@ -0,0 +1,129 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/healthcare-market-research/backend/internal/config"
+)
+
+// Storage drivers selectable with STORAGE_DRIVER
+const (
+	DriverLocal      = "local"
+	DriverS3         = "s3"
+	DriverCloudflare = "cloudflare"
+)
+
+var (
+	ErrNotFound    = errors.New("image not found")
+	ErrInvalidURL  = errors.New("image URL does not belong to this storage")
+	ErrUnknownType = errors.New("unknown storage driver")
+)
+
+// ImageStorage stores uploaded images and hands out their public URLs.
+// Images are addressed by the URL saved on the owning record, so callers
+// never need to know which driver is in use.
+type ImageStorage interface {
+	// Upload stores the image and returns its public URL. filename is only
+	// used for its extension; drivers pick a unique key themselves.
+	Upload(filename string, body io.Reader, metadata map[string]string) (imageURL string, err error)
+	Delete(imageURL string) error
+	// URL returns the public URL of the image stored under key
+	URL(key string) string
+	// Key returns the key imageURL is stored under, the inverse of URL
+	Key(imageURL string) (string, error)
+	Stat(imageURL string) (*ImageInfo, error)
+	// Open reads the stored original of imageURL; the caller closes it
+	Open(imageURL string) (io.ReadCloser, error)
+	// List calls fn for every stored image and stops at the first error fn
+	// returns
+	List(fn func(*ImageInfo) error) error
+}
+
+// ImageInfo describes a stored image. Size and ContentType are zero when the
+// backend does not report them.
+type ImageInfo struct {
+	Key          string
+	URL          string
+	Size         int64
+	ContentType  string
+	LastModified time.Time
+}
+
+// New creates the driver selected in cfg
+func New(cfg *config.StorageConfig) (ImageStorage, error) {
+	switch cfg.Driver {
+	case DriverLocal:
+		return NewLocalStorage(&cfg.Local)
+	case DriverS3:
+		return NewS3Storage(&cfg.S3)
+	case DriverCloudflare:
+		return NewCloudflareStorage(&cfg.Cloudflare)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownType, cfg.Driver)
+	}
+}
+
+// NewDocumentStorage creates the storage for non-image uploads such as PDF
+// media kits. Cloudflare Images only accepts images, so documents go to local
+// storage when it is the selected driver.
+func NewDocumentStorage(cfg *config.StorageConfig) (ImageStorage, error) {
+	if cfg.Driver == DriverCloudflare {
+		return NewLocalStorage(&cfg.Local)
+	}
+	return New(cfg)
+}
+
+// UploadFile uploads a multipart form file
+func UploadFile(s ImageStorage, file *multipart.FileHeader, metadata map[string]string) (string, error) {
+	src, err := file.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer src.Close()
+
+	return s.Upload(file.Filename, src, metadata)
+}
+
+// newKey builds a unique object key that keeps the upload's extension,
+// grouped by month so directories and bucket listings stay small
+func newKey(filename string) string {
+	ext := strings.ToLower(path.Ext(filename))
+	return time.Now().UTC().Format("2006/01/") + uuid.NewString() + ext
+}
+
+// contentType prefers the extension and falls back to sniffing the data
+func contentType(filename string, data []byte) string {
+	if ct := mime.TypeByExtension(strings.ToLower(path.Ext(filename))); ct != "" {
+		return ct
+	}
+	return http.DetectContentType(data)
+}
+
+// keyFromURL strips baseURL from imageURL, rejecting URLs outside it and keys
+// that try to climb out of the storage root
+func keyFromURL(baseURL, imageURL string) (string, error) {
+	if imageURL == "" {
+		return "", fmt.Errorf("image URL is empty")
+	}
+	if !strings.HasPrefix(imageURL, baseURL+"/") {
+		return "", ErrInvalidURL
+	}
+
+	key := strings.TrimPrefix(imageURL, baseURL+"/")
+	if i := strings.IndexAny(key, "?#"); i >= 0 {
+		key = key[:i]
+	}
+	if key == "" || path.Clean("/"+key) != "/"+key {
+		return "", ErrInvalidURL
+	}
+	return key, nil
+}
@@ -0,0 +1,396 @@
+-- Baseline: the schema as db.Migrate (AutoMigrate plus its ad-hoc statements) left it.
+-- Everything is IF NOT EXISTS so that databases created by AutoMigrate only record
+-- this version; new databases get the full schema. Columns the last AutoMigrate
+-- release did not have are also added with ADD COLUMN IF NOT EXISTS, before the
+-- indexes that use them. Earlier hand-run files are kept for reference in
+-- migrations/legacy/.
+
+-- Tables that were replaced by the category hierarchy
+ALTER TABLE IF EXISTS reports DROP COLUMN IF EXISTS sub_category_id;
+ALTER TABLE IF EXISTS reports DROP COLUMN IF EXISTS market_segment_id;
+DROP TABLE IF EXISTS market_segments CASCADE;
+DROP TABLE IF EXISTS sub_categories CASCADE;
+
+CREATE TABLE IF NOT EXISTS users (
+    id BIGSERIAL PRIMARY KEY,
+    email VARCHAR(255) NOT NULL,
+    password_hash VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    role VARCHAR(20) NOT NULL DEFAULT 'viewer',
+    is_active BOOLEAN DEFAULT true,
+    last_login_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (email);
+CREATE INDEX IF NOT EXISTS idx_users_role ON users (role);
+CREATE INDEX IF NOT EXISTS idx_users_last_login ON users (last_login_at DESC);
+
+CREATE TABLE IF NOT EXISTS categories (
+    id BIGSERIAL PRIMARY KEY,
+    parent_id BIGINT,
+    name VARCHAR(255) NOT NULL,
+    slug VARCHAR(255) NOT NULL,
+    description TEXT,
+    image_url VARCHAR(500),
+    sort_order BIGINT DEFAULT 0,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS parent_id BIGINT;
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS sort_order BIGINT DEFAULT 0;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_slug ON categories (slug);
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories (parent_id);
+CREATE INDEX IF NOT EXISTS idx_categories_sort_order ON categories (sort_order);
+
+CREATE TABLE IF NOT EXISTS authors (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    role VARCHAR(100),
+    bio TEXT,
+    image_url VARCHAR(500),
+    linkedin_url VARCHAR(500),
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+-- fa_qs and category_name are the column names AutoMigrate derived from the model
+CREATE TABLE IF NOT EXISTS reports (
+    id BIGSERIAL PRIMARY KEY,
+    category_id BIGINT NOT NULL,
+    category_name TEXT,
+    title VARCHAR(500) NOT NULL,
+    slug VARCHAR(500) NOT NULL,
+    description TEXT,
+    summary TEXT NOT NULL,
+    price DECIMAL(10,2) DEFAULT 0,
+    discounted_price DECIMAL(10,2) DEFAULT 0,
+    currency VARCHAR(3) DEFAULT 'USD',
+    page_count BIGINT DEFAULT 0,
+    formats JSONB,
+    geography JSONB NOT NULL,
+    status VARCHAR(20) DEFAULT 'draft',
+    is_featured BOOLEAN DEFAULT false,
+    publish_date TIMESTAMPTZ,
+    scheduled_publish_enabled BOOLEAN DEFAULT false,
+    author_ids JSONB,
+    market_metrics JSONB,
+    key_players JSONB,
+    sections JSONB NOT NULL,
+    fa_qs JSONB,
+    meta_title VARCHAR(255),
+    meta_description VARCHAR(500),
+    meta_keywords VARCHAR(500),
+    created_by BIGINT,
+    updated_by BIGINT,
+    internal_notes TEXT,
+    reviewer_id BIGINT,
+    submitted_by BIGINT,
+    submitted_at TIMESTAMPTZ,
+    reviewed_by BIGINT,
+    reviewed_at TIMESTAMPTZ,
+    rejection_reason TEXT,
+    version BIGINT NOT NULL DEFAULT 1,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    deleted_at TIMESTAMPTZ
+);
+
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS reviewer_id BIGINT;
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS submitted_by BIGINT;
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS submitted_at TIMESTAMPTZ;
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS reviewed_by BIGINT;
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMPTZ;
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS rejection_reason TEXT;
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_reports_slug ON reports (slug);
+CREATE INDEX IF NOT EXISTS idx_reports_category_id ON reports (category_id);
+CREATE INDEX IF NOT EXISTS idx_reports_status ON reports (status);
+CREATE INDEX IF NOT EXISTS idx_reports_publish_date ON reports (publish_date);
+CREATE INDEX IF NOT EXISTS idx_reports_created_by ON reports (created_by);
+CREATE INDEX IF NOT EXISTS idx_reports_updated_by ON reports (updated_by);
+CREATE INDEX IF NOT EXISTS idx_reports_reviewer_id ON reports (reviewer_id);
+CREATE INDEX IF NOT EXISTS idx_reports_reviewed_by ON reports (reviewed_by);
+CREATE INDEX IF NOT EXISTS idx_reports_deleted_at ON reports (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_reports_author_ids ON reports USING GIN (author_ids jsonb_path_ops);
+CREATE INDEX IF NOT EXISTS idx_reports_status_created ON reports (status, created_at DESC) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_reports_scheduled_publish
+    ON reports (status, scheduled_publish_enabled, publish_date)
+    WHERE scheduled_publish_enabled = TRUE AND status != 'published';
+
+-- Report IDs start at 1001; never moves an existing sequence backwards
+DO $$
+BEGIN
+    IF (SELECT last_value FROM reports_id_seq) < 1001 AND NOT EXISTS (SELECT 1 FROM reports) THEN
+        PERFORM setval('reports_id_seq', 1001, false);
+    END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS chart_metadata (
+    id BIGSERIAL PRIMARY KEY,
+    report_id BIGINT NOT NULL,
+    title VARCHAR(500) NOT NULL,
+    chart_type VARCHAR(50),
+    description TEXT,
+    data_points BIGINT DEFAULT 0,
+    "order" BIGINT DEFAULT 0,
+    is_active BOOLEAN DEFAULT true,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_chart_metadata_report FOREIGN KEY (report_id) REFERENCES reports (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_chart_metadata_report_id ON chart_metadata (report_id);
+
+CREATE TABLE IF NOT EXISTS report_versions (
+    id BIGSERIAL PRIMARY KEY,
+    report_id BIGINT NOT NULL,
+    version_number BIGINT NOT NULL,
+    change_type VARCHAR(20) DEFAULT 'update',
+    created_by BIGINT,
+    restored_from BIGINT,
+    published_by BIGINT,
+    published_at TIMESTAMPTZ,
+    sections JSONB NOT NULL,
+    snapshot JSONB,
+    meta_title VARCHAR(255),
+    meta_description VARCHAR(500),
+    meta_keywords VARCHAR(500),
+    created_at TIMESTAMPTZ,
+    CONSTRAINT fk_report_versions_report FOREIGN KEY (report_id) REFERENCES reports (id)
+);
+
+ALTER TABLE report_versions ADD COLUMN IF NOT EXISTS change_type VARCHAR(20) DEFAULT 'update';
+ALTER TABLE report_versions ADD COLUMN IF NOT EXISTS created_by BIGINT;
+ALTER TABLE report_versions ADD COLUMN IF NOT EXISTS restored_from BIGINT;
+ALTER TABLE report_versions ADD COLUMN IF NOT EXISTS snapshot JSONB;
+
+-- Versions are recorded on every save now, so only publishes set these
+ALTER TABLE report_versions ALTER COLUMN published_by DROP NOT NULL;
+ALTER TABLE report_versions ALTER COLUMN published_at DROP NOT NULL;
+
+-- Versions from before snapshots were all written on publish
+UPDATE report_versions
+SET change_type = 'publish', created_by = published_by
+WHERE snapshot IS NULL AND created_by IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_report_versions_report_id ON report_versions (report_id);
+CREATE INDEX IF NOT EXISTS idx_report_versions_created_by ON report_versions (created_by);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_report_versions_report_version ON report_versions (report_id, version_number);
+
+CREATE TABLE IF NOT EXISTS report_images (
+    id BIGSERIAL PRIMARY KEY,
+    report_id BIGINT NOT NULL,
+    image_url VARCHAR(500) NOT NULL,
+    title VARCHAR(255),
+    is_active BOOLEAN DEFAULT true,
+    uploaded_by BIGINT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_report_images_report FOREIGN KEY (report_id) REFERENCES reports (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_images_report_id ON report_images (report_id);
+CREATE INDEX IF NOT EXISTS idx_report_images_is_active ON report_images (is_active);
+CREATE INDEX IF NOT EXISTS idx_report_images_uploaded_by ON report_images (uploaded_by);
+CREATE INDEX IF NOT EXISTS idx_report_images_report_active ON report_images (report_id, is_active);
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_report_images_user') THEN
+        ALTER TABLE report_images
+            ADD CONSTRAINT fk_report_images_user
+            FOREIGN KEY (uploaded_by) REFERENCES users (id) ON DELETE SET NULL;
+    END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS report_review_comments (
+    id BIGSERIAL PRIMARY KEY,
+    report_id BIGINT NOT NULL,
+    user_id BIGINT NOT NULL,
+    action VARCHAR(20),
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_review_comments_report_id ON report_review_comments (report_id);
+CREATE INDEX IF NOT EXISTS idx_report_review_comments_user_id ON report_review_comments (user_id);
+
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id BIGSERIAL PRIMARY KEY,
+    user_id BIGINT,
+    user_email VARCHAR(255),
+    user_role VARCHAR(20),
+    action VARCHAR(100) NOT NULL,
+    entity_type VARCHAR(50),
+    entity_id BIGINT,
+    ip_address VARCHAR(45),
+    user_agent TEXT,
+    request_id VARCHAR(100),
+    changes JSONB,
+    status VARCHAR(20) NOT NULL,
+    error_message TEXT,
+    created_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_user_id ON audit_logs (user_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_action ON audit_logs (action);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity_type ON audit_logs (entity_type);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity_id ON audit_logs (entity_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_request_id ON audit_logs (request_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_status ON audit_logs (status);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at ON audit_logs (created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_action ON audit_logs (created_at DESC, action);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_entity ON audit_logs (entity_type, entity_id);
+
+CREATE TABLE IF NOT EXISTS form_submissions (
+    id BIGSERIAL PRIMARY KEY,
+    category VARCHAR(20) NOT NULL,
+    status VARCHAR(20) DEFAULT 'pending',
+    data JSONB NOT NULL,
+    metadata JSONB,
+    processed_at TIMESTAMPTZ,
+    processed_by BIGINT,
+    notes TEXT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_form_submissions_category ON form_submissions (category);
+CREATE INDEX IF NOT EXISTS idx_form_submissions_status ON form_submissions (status);
+CREATE INDEX IF NOT EXISTS idx_form_submissions_created ON form_submissions (created_at DESC);
+
+CREATE TABLE IF NOT EXISTS blogs (
+    id BIGSERIAL PRIMARY KEY,
+    title VARCHAR(200) NOT NULL,
+    slug VARCHAR(250) NOT NULL,
+    excerpt VARCHAR(500) NOT NULL,
+    content TEXT NOT NULL,
+    category_id BIGINT NOT NULL,
+    tags VARCHAR(500),
+    author_id BIGINT NOT NULL,
+    status VARCHAR(20) DEFAULT 'draft',
+    publish_date TIMESTAMPTZ,
+    scheduled_publish_enabled BOOLEAN DEFAULT false,
+    location VARCHAR(255),
+    metadata JSONB,
+    reviewed_by BIGINT,
+    reviewed_at TIMESTAMPTZ,
+    version BIGINT NOT NULL DEFAULT 1,
+    deleted_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_blogs_author FOREIGN KEY (author_id) REFERENCES authors (id),
+    CONSTRAINT fk_blogs_category FOREIGN KEY (category_id) REFERENCES categories (id)
+);
+
+ALTER TABLE blogs ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_blogs_slug ON blogs (slug);
+CREATE INDEX IF NOT EXISTS idx_blogs_category_id ON blogs (category_id);
+CREATE INDEX IF NOT EXISTS idx_blogs_author_id ON blogs (author_id);
+CREATE INDEX IF NOT EXISTS idx_blogs_status ON blogs (status);
+CREATE INDEX IF NOT EXISTS idx_blogs_publish_date ON blogs (publish_date);
+CREATE INDEX IF NOT EXISTS idx_blogs_reviewed_by ON blogs (reviewed_by);
+CREATE INDEX IF NOT EXISTS idx_blogs_deleted_at ON blogs (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_blogs_status_created ON blogs (status, created_at DESC) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_blogs_scheduled_publish
+    ON blogs (status, scheduled_publish_enabled, publish_date)
+    WHERE scheduled_publish_enabled = TRUE AND status != 'published';
+
+CREATE TABLE IF NOT EXISTS press_releases (
+    id BIGSERIAL PRIMARY KEY,
+    title VARCHAR(200) NOT NULL,
+    slug VARCHAR(250) NOT NULL,
+    excerpt VARCHAR(500) NOT NULL,
+    content TEXT NOT NULL,
+    category_id BIGINT NOT NULL,
+    tags VARCHAR(500),
+    author_id BIGINT NOT NULL,
+    status VARCHAR(20) DEFAULT 'draft',
+    publish_date TIMESTAMPTZ,
+    scheduled_publish_enabled BOOLEAN DEFAULT false,
+    location VARCHAR(255),
+    metadata JSONB,
+    reviewed_by BIGINT,
+    reviewed_at TIMESTAMPTZ,
+    version BIGINT NOT NULL DEFAULT 1,
+    deleted_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_press_releases_author FOREIGN KEY (author_id) REFERENCES authors (id),
+    CONSTRAINT fk_press_releases_category FOREIGN KEY (category_id) REFERENCES categories (id)
+);
+
+ALTER TABLE press_releases ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_press_releases_slug ON press_releases (slug);
+CREATE INDEX IF NOT EXISTS idx_press_releases_category_id ON press_releases (category_id);
+CREATE INDEX IF NOT EXISTS idx_press_releases_author_id ON press_releases (author_id);
+CREATE INDEX IF NOT EXISTS idx_press_releases_status ON press_releases (status);
+CREATE INDEX IF NOT EXISTS idx_press_releases_publish_date ON press_releases (publish_date);
+CREATE INDEX IF NOT EXISTS idx_press_releases_reviewed_by ON press_releases (reviewed_by);
+CREATE INDEX IF NOT EXISTS idx_press_releases_deleted_at ON press_releases (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_press_releases_status_created ON press_releases (status, created_at DESC) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_press_releases_scheduled_publish
+    ON press_releases (status, scheduled_publish_enabled, publish_date)
+    WHERE scheduled_publish_enabled = TRUE AND status != 'published';
+
+CREATE TABLE IF NOT EXISTS slug_redirects (
+    id BIGSERIAL PRIMARY KEY,
+    entity_type VARCHAR(30) NOT NULL,
+    old_slug VARCHAR(255) NOT NULL,
+    entity_id BIGINT NOT NULL,
+    is_manual BOOLEAN DEFAULT false,
+    created_by BIGINT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_slug_redirects_type_slug ON slug_redirects (entity_type, old_slug);
+CREATE INDEX IF NOT EXISTS idx_slug_redirects_entity_id ON slug_redirects (entity_id);
+
+-- Weighted full-text search: title (A) > summary/excerpt (B) > description/content (C) > sections/tags (D).
+-- The columns are not part of the GORM models so that writes never touch them.
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS search_vector tsvector
+GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(summary, '')), 'B') ||
+    setweight(to_tsvector('english', coalesce(description, '')), 'C') ||
+    setweight(to_tsvector('english',
+        coalesce(sections->>'marketDetails', '') || ' ' ||
+        coalesce(sections->>'keyPlayers', '') || ' ' ||
+        coalesce(sections->>'tableOfContents', '')), 'D')
+) STORED;
+
+ALTER TABLE blogs ADD COLUMN IF NOT EXISTS search_vector tsvector
+GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(excerpt, '')), 'B') ||
+    setweight(to_tsvector('english', coalesce(content, '')), 'C') ||
+    setweight(to_tsvector('english', coalesce(tags, '')), 'D')
+) STORED;
+
+ALTER TABLE press_releases ADD COLUMN IF NOT EXISTS search_vector tsvector
+GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(excerpt, '')), 'B') ||
+    setweight(to_tsvector('english', coalesce(content, '')), 'C') ||
+    setweight(to_tsvector('english', coalesce(tags, '')), 'D')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_reports_search_vector ON reports USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_blogs_search_vector ON blogs USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_press_releases_search_vector ON press_releases USING GIN (search_vector);
+
+-- Keyset (cursor) pagination walks (created_at, id) descending
+CREATE INDEX IF NOT EXISTS idx_reports_created_at_id ON reports (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_blogs_created_at_id ON blogs (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_press_releases_created_at_id ON press_releases (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_form_submissions_created_at_id ON form_submissions (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at_id ON audit_logs (created_at DESC, id DESC);
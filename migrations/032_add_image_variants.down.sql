@@ -0,0 +1,3 @@
+ALTER TABLE categories DROP COLUMN IF EXISTS image_variants;
+ALTER TABLE authors DROP COLUMN IF EXISTS image_variants;
+ALTER TABLE report_images DROP COLUMN IF EXISTS variants;
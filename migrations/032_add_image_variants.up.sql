@@ -0,0 +1,5 @@
+-- Resized renditions (thumb, card, og, full) of uploaded images, keyed by
+-- variant name, each with an original-format URL, a WebP URL and its size
+ALTER TABLE report_images ADD COLUMN IF NOT EXISTS variants JSONB;
+ALTER TABLE authors ADD COLUMN IF NOT EXISTS image_variants JSONB;
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS image_variants JSONB;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS orphaned_images;
@@ -0,0 +1,11 @@
+-- Stored images no record references, tracked so the image garbage collector
+-- only deletes them once they have stayed orphaned for the retention window
+CREATE TABLE IF NOT EXISTS orphaned_images (
+    key VARCHAR(500) PRIMARY KEY,
+    url VARCHAR(1000) NOT NULL,
+    size BIGINT NOT NULL DEFAULT 0,
+    first_seen_at TIMESTAMPTZ NOT NULL,
+    last_seen_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_orphaned_images_first_seen_at ON orphaned_images(first_seen_at);
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS trg_categories_delete_attachments ON categories;
+DROP TRIGGER IF EXISTS trg_press_releases_delete_attachments ON press_releases;
+DROP TRIGGER IF EXISTS trg_blogs_delete_attachments ON blogs;
+DROP FUNCTION IF EXISTS delete_owned_attachments();
+DROP TABLE IF EXISTS attachments;
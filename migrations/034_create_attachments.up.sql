@@ -0,0 +1,48 @@
+-- Images and documents attached to blogs, press releases and categories.
+-- owner_type/owner_id is polymorphic, so there is no foreign key; triggers
+-- remove the attachments of deleted owners and the image garbage collector
+-- then reclaims their files.
+CREATE TABLE IF NOT EXISTS attachments (
+    id BIGSERIAL PRIMARY KEY,
+    owner_type VARCHAR(20) NOT NULL,
+    owner_id BIGINT NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    url VARCHAR(500) NOT NULL,
+    variants JSONB,
+    file_name VARCHAR(255),
+    mime_type VARCHAR(100),
+    byte_size BIGINT NOT NULL DEFAULT 0,
+    caption VARCHAR(500),
+    alt_text VARCHAR(255),
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    is_featured BOOLEAN NOT NULL DEFAULT false,
+    uploaded_by BIGINT REFERENCES users (id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT chk_attachments_owner_type CHECK (owner_type IN ('blog', 'press_release', 'category')),
+    CONSTRAINT chk_attachments_kind CHECK (kind IN ('image', 'document'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_attachments_owner ON attachments (owner_type, owner_id);
+
+-- At most one featured image per owner
+CREATE UNIQUE INDEX IF NOT EXISTS idx_attachments_featured ON attachments (owner_type, owner_id) WHERE is_featured;
+
+CREATE OR REPLACE FUNCTION delete_owned_attachments() RETURNS trigger AS $$
+BEGIN
+    DELETE FROM attachments WHERE owner_type = TG_ARGV[0] AND owner_id = OLD.id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_blogs_delete_attachments ON blogs;
+CREATE TRIGGER trg_blogs_delete_attachments AFTER DELETE ON blogs
+    FOR EACH ROW EXECUTE FUNCTION delete_owned_attachments('blog');
+
+DROP TRIGGER IF EXISTS trg_press_releases_delete_attachments ON press_releases;
+CREATE TRIGGER trg_press_releases_delete_attachments AFTER DELETE ON press_releases
+    FOR EACH ROW EXECUTE FUNCTION delete_owned_attachments('press_release');
+
+DROP TRIGGER IF EXISTS trg_categories_delete_attachments ON categories;
+CREATE TRIGGER trg_categories_delete_attachments AFTER DELETE ON categories
+    FOR EACH ROW EXECUTE FUNCTION delete_owned_attachments('category');
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_report_images_report_hash;
+DROP INDEX IF EXISTS idx_report_images_report_sort;
+
+ALTER TABLE report_images DROP COLUMN IF EXISTS mime_type;
+ALTER TABLE report_images DROP COLUMN IF EXISTS content_hash;
+ALTER TABLE report_images DROP COLUMN IF EXISTS byte_size;
+ALTER TABLE report_images DROP COLUMN IF EXISTS height;
+ALTER TABLE report_images DROP COLUMN IF EXISTS width;
+ALTER TABLE report_images DROP COLUMN IF EXISTS sort_order;
+ALTER TABLE report_images DROP COLUMN IF EXISTS caption;
+ALTER TABLE report_images DROP COLUMN IF EXISTS alt_text;
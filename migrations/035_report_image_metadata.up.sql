@@ -0,0 +1,25 @@
+-- Accessibility text, ordering and file details for report images. Images
+-- keep their current order (newest first) as their initial sort order.
+ALTER TABLE report_images ADD COLUMN IF NOT EXISTS alt_text VARCHAR(255);
+ALTER TABLE report_images ADD COLUMN IF NOT EXISTS caption VARCHAR(500);
+ALTER TABLE report_images ADD COLUMN IF NOT EXISTS sort_order INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE report_images ADD COLUMN IF NOT EXISTS width INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE report_images ADD COLUMN IF NOT EXISTS height INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE report_images ADD COLUMN IF NOT EXISTS byte_size BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE report_images ADD COLUMN IF NOT EXISTS content_hash VARCHAR(64) NOT NULL DEFAULT '';
+ALTER TABLE report_images ADD COLUMN IF NOT EXISTS mime_type VARCHAR(100);
+
+UPDATE report_images AS ri
+SET sort_order = ordered.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY report_id ORDER BY created_at DESC, id DESC) - 1 AS position
+    FROM report_images
+) AS ordered
+WHERE ri.id = ordered.id;
+
+CREATE INDEX IF NOT EXISTS idx_report_images_report_sort ON report_images (report_id, sort_order);
+
+-- The same content is stored once per report. Images uploaded before hashing
+-- have an empty hash and are not deduplicated.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_report_images_report_hash ON report_images (report_id, content_hash)
+    WHERE content_hash <> '';
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_chart_metadata_image_id;
+ALTER TABLE chart_metadata DROP CONSTRAINT IF EXISTS fk_chart_metadata_image;
+ALTER TABLE chart_metadata DROP COLUMN IF EXISTS image_id;
+ALTER TABLE chart_metadata DROP COLUMN IF EXISTS data;
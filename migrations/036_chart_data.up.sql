@@ -0,0 +1,15 @@
+-- Chart data series (labels, series, units, source) and an optional uploaded
+-- report image shown when the chart can't be drawn
+ALTER TABLE chart_metadata ADD COLUMN IF NOT EXISTS data JSONB;
+ALTER TABLE chart_metadata ADD COLUMN IF NOT EXISTS image_id BIGINT;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'fk_chart_metadata_image') THEN
+        ALTER TABLE chart_metadata
+            ADD CONSTRAINT fk_chart_metadata_image
+            FOREIGN KEY (image_id) REFERENCES report_images (id) ON DELETE SET NULL;
+    END IF;
+END $$;
+
+CREATE INDEX IF NOT EXISTS idx_chart_metadata_image_id ON chart_metadata (image_id);
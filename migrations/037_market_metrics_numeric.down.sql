@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_reports_metrics_unparsed;
+DROP INDEX IF EXISTS idx_reports_cagr;
+DROP INDEX IF EXISTS idx_reports_forecast_size;
+DROP INDEX IF EXISTS idx_reports_market_size;
+
+UPDATE reports
+SET market_metrics = market_metrics - 'currentValue' - 'forecastValue' - 'cagrPercent'
+WHERE jsonb_typeof(market_metrics) = 'object';
+
+ALTER TABLE reports DROP COLUMN IF EXISTS metrics_unparsed;
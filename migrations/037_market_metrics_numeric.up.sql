@@ -0,0 +1,111 @@
+-- Numeric market metrics (currentValue, forecastValue, cagrPercent) parsed
+-- from the display strings, so reports can be filtered and sorted by market
+-- size and growth. The parser mirrors report.ParseMarketValue and
+-- report.ParseCAGR, including the fixed USD reference rates in report.USDRates.
+-- Rows with strings it can't read are flagged with metrics_unparsed.
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS metrics_unparsed BOOLEAN NOT NULL DEFAULT false;
+
+CREATE FUNCTION migrate_037_market_value(raw TEXT, year TEXT) RETURNS JSONB
+LANGUAGE plpgsql IMMUTABLE AS $$
+DECLARE
+    m TEXT[];
+    lead_currency TEXT;
+    trail_currency TEXT;
+    currency TEXT;
+    amount NUMERIC;
+    scale TEXT;
+    factor NUMERIC;
+    rate NUMERIC;
+BEGIN
+    IF raw IS NULL OR btrim(raw) = '' THEN
+        RETURN NULL;
+    END IF;
+
+    m := regexp_match(upper(btrim(raw)),
+        '^(US\$|USD|\$|EUR|€|GBP|£|JPY|¥|CNY|RMB|INR|₹|CAD|C\$|AUD|A\$|CHF)?\s*([0-9][0-9,]*(?:\.[0-9]+)?)\s*(THOUSANDS?|MILLIONS?|BILLIONS?|TRILLIONS?|MN|BN|TN|K|M|B|T)?\s*(USD|EUR|GBP|JPY|CNY|RMB|INR|CAD|AUD|CHF)?$');
+    IF m IS NULL THEN
+        RETURN NULL;
+    END IF;
+
+    lead_currency := CASE m[1]
+        WHEN 'US$' THEN 'USD' WHEN '$' THEN 'USD'
+        WHEN '€' THEN 'EUR' WHEN '£' THEN 'GBP' WHEN '¥' THEN 'JPY'
+        WHEN 'RMB' THEN 'CNY' WHEN '₹' THEN 'INR'
+        WHEN 'C$' THEN 'CAD' WHEN 'A$' THEN 'AUD'
+        ELSE m[1] END;
+    trail_currency := CASE m[4] WHEN 'RMB' THEN 'CNY' ELSE m[4] END;
+    IF lead_currency <> trail_currency THEN
+        RETURN NULL;
+    END IF;
+    currency := COALESCE(lead_currency, trail_currency, 'USD');
+
+    scale := CASE
+        WHEN m[3] IN ('K', 'THOUSAND', 'THOUSANDS') THEN 'thousand'
+        WHEN m[3] IN ('M', 'MN', 'MILLION', 'MILLIONS') THEN 'million'
+        WHEN m[3] IN ('B', 'BN', 'BILLION', 'BILLIONS') THEN 'billion'
+        WHEN m[3] IN ('T', 'TN', 'TRILLION', 'TRILLIONS') THEN 'trillion'
+        ELSE '' END;
+    factor := CASE scale
+        WHEN 'thousand' THEN 0.001
+        WHEN 'million' THEN 1
+        WHEN 'billion' THEN 1000
+        WHEN 'trillion' THEN 1000000
+        ELSE 0.000001 END;
+    rate := CASE currency
+        WHEN 'USD' THEN 1
+        WHEN 'EUR' THEN 1.08
+        WHEN 'GBP' THEN 1.27
+        WHEN 'JPY' THEN 0.0067
+        WHEN 'CNY' THEN 0.14
+        WHEN 'INR' THEN 0.012
+        WHEN 'CAD' THEN 0.73
+        WHEN 'AUD' THEN 0.66
+        WHEN 'CHF' THEN 1.13
+        END;
+    amount := replace(m[2], ',', '')::NUMERIC;
+
+    RETURN jsonb_strip_nulls(jsonb_build_object(
+        'amount', amount,
+        'scale', NULLIF(scale, ''),
+        'currency', currency,
+        'year', NULLIF(NULLIF(year, ''), '0')::INTEGER,
+        'usdMillions', amount * factor * rate
+    ));
+END $$;
+
+CREATE FUNCTION migrate_037_cagr(raw TEXT) RETURNS NUMERIC
+LANGUAGE sql IMMUTABLE AS $$
+    SELECT (regexp_match(btrim(raw), '^(-?[0-9]+(?:\.[0-9]+)?)\s*%?$'))[1]::NUMERIC
+$$;
+
+WITH parsed AS (
+    SELECT id,
+           market_metrics->>'currentRevenue' AS current_text,
+           market_metrics->>'forecastRevenue' AS forecast_text,
+           market_metrics->>'cagr' AS cagr_text,
+           migrate_037_market_value(market_metrics->>'currentRevenue', market_metrics->>'currentYear') AS current_value,
+           migrate_037_market_value(market_metrics->>'forecastRevenue', market_metrics->>'forecastYear') AS forecast_value,
+           migrate_037_cagr(market_metrics->>'cagr') AS cagr_percent
+    FROM reports
+    WHERE jsonb_typeof(market_metrics) = 'object'
+)
+UPDATE reports AS r
+SET market_metrics = (r.market_metrics - 'currentValue' - 'forecastValue' - 'cagrPercent')
+        || jsonb_strip_nulls(jsonb_build_object(
+            'currentValue', p.current_value,
+            'forecastValue', p.forecast_value,
+            'cagrPercent', p.cagr_percent
+        )),
+    metrics_unparsed = (COALESCE(btrim(p.current_text), '') <> '' AND p.current_value IS NULL)
+        OR (COALESCE(btrim(p.forecast_text), '') <> '' AND p.forecast_value IS NULL)
+        OR (COALESCE(btrim(p.cagr_text), '') <> '' AND p.cagr_percent IS NULL)
+FROM parsed AS p
+WHERE r.id = p.id;
+
+DROP FUNCTION migrate_037_market_value(TEXT, TEXT);
+DROP FUNCTION migrate_037_cagr(TEXT);
+
+CREATE INDEX IF NOT EXISTS idx_reports_market_size ON reports (((market_metrics->'currentValue'->>'usdMillions')::numeric));
+CREATE INDEX IF NOT EXISTS idx_reports_forecast_size ON reports (((market_metrics->'forecastValue'->>'usdMillions')::numeric));
+CREATE INDEX IF NOT EXISTS idx_reports_cagr ON reports (((market_metrics->>'cagrPercent')::numeric));
+CREATE INDEX IF NOT EXISTS idx_reports_metrics_unparsed ON reports (id) WHERE metrics_unparsed;
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS idx_reports_key_players;
+
+-- Unlink key players so no report points at a company that is gone
+UPDATE reports
+SET key_players = (
+    SELECT jsonb_agg(p.player - 'companyId' ORDER BY p.ord)
+    FROM jsonb_array_elements(key_players) WITH ORDINALITY AS p(player, ord)
+)
+WHERE jsonb_typeof(key_players) = 'array' AND jsonb_path_exists(key_players, '$[*].companyId');
+
+DELETE FROM slug_redirects WHERE entity_type = 'company';
+
+DROP TABLE IF EXISTS companies;
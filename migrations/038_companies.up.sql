@@ -0,0 +1,25 @@
+-- Company directory for report key players. Reports link to a company with
+-- a companyId inside their key_players JSON, so there is no foreign key;
+-- companies in use are merged rather than deleted.
+CREATE TABLE IF NOT EXISTS companies (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    slug VARCHAR(255) NOT NULL,
+    aliases JSONB NOT NULL DEFAULT '[]',
+    website VARCHAR(500),
+    hq_country VARCHAR(2),
+    description TEXT,
+    logo_url VARCHAR(500),
+    logo_variants JSONB,
+    -- Normalized name and aliases used to match key player names
+    name_keys JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT chk_companies_hq_country CHECK (hq_country IS NULL OR hq_country = '' OR hq_country ~ '^[A-Z]{2}$')
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_companies_slug ON companies (slug);
+CREATE INDEX IF NOT EXISTS idx_companies_name ON companies (name);
+
+-- Finds the reports a company is a key player in
+CREATE INDEX IF NOT EXISTS idx_reports_key_players ON reports USING GIN (key_players jsonb_path_ops);
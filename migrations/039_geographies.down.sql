@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_reports_geography;
+
+-- Report geography values stay as normalized; only the taxonomy is removed
+DROP TABLE IF EXISTS geographies;
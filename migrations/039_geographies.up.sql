@@ -0,0 +1,335 @@
+-- Controlled geography taxonomy: Global > Region > Country. Countries use
+-- their ISO 3166-1 alpha-2 code, regions a longer code. Reports keep storing
+-- names in reports.geography; values are resolved by name, then alias, then
+-- code, so "NA" is North America rather than Namibia.
+CREATE TABLE IF NOT EXISTS geographies (
+    code VARCHAR(10) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    level VARCHAR(10) NOT NULL,
+    parent_code VARCHAR(10) REFERENCES geographies (code),
+    aliases JSONB NOT NULL DEFAULT '[]',
+    CONSTRAINT chk_geographies_level CHECK (level IN ('global', 'region', 'country')),
+    CONSTRAINT chk_geographies_parent CHECK ((level = 'global') = (parent_code IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_geographies_name ON geographies (name);
+CREATE INDEX IF NOT EXISTS idx_geographies_parent ON geographies (parent_code);
+
+INSERT INTO geographies (code, name, level, parent_code, aliases) VALUES
+    ('GLOBAL', 'Global', 'global', NULL, '["Worldwide", "World", "Global Market"]'),
+    ('NAM', 'North America', 'region', 'GLOBAL', '["NA", "N. America"]'),
+    ('LATAM', 'Latin America', 'region', 'GLOBAL', '["South America", "Central and South America", "Latin America and Caribbean"]'),
+    ('EUR', 'Europe', 'region', 'GLOBAL', '["European Region"]'),
+    ('APAC', 'Asia Pacific', 'region', 'GLOBAL', '["Asia", "Asia Pacific Region"]'),
+    ('MEA', 'Middle East and Africa', 'region', 'GLOBAL', '["Middle East", "Africa", "MENA"]')
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO geographies (code, name, level, parent_code, aliases) VALUES
+    ('AF', 'Afghanistan', 'country', 'APAC', '[]'),
+    ('AS', 'American Samoa', 'country', 'APAC', '[]'),
+    ('AQ', 'Antarctica', 'country', 'APAC', '[]'),
+    ('AU', 'Australia', 'country', 'APAC', '[]'),
+    ('BD', 'Bangladesh', 'country', 'APAC', '[]'),
+    ('BT', 'Bhutan', 'country', 'APAC', '[]'),
+    ('IO', 'British Indian Ocean Territory', 'country', 'APAC', '[]'),
+    ('BN', 'Brunei', 'country', 'APAC', '["Brunei Darussalam"]'),
+    ('KH', 'Cambodia', 'country', 'APAC', '[]'),
+    ('CN', 'China', 'country', 'APAC', '["People''s Republic of China", "PRC", "Mainland China"]'),
+    ('CX', 'Christmas Island', 'country', 'APAC', '[]'),
+    ('CC', 'Cocos (Keeling) Islands', 'country', 'APAC', '[]'),
+    ('CK', 'Cook Islands', 'country', 'APAC', '[]'),
+    ('FJ', 'Fiji', 'country', 'APAC', '[]'),
+    ('PF', 'French Polynesia', 'country', 'APAC', '[]'),
+    ('GU', 'Guam', 'country', 'APAC', '[]'),
+    ('HM', 'Heard Island and McDonald Islands', 'country', 'APAC', '[]'),
+    ('HK', 'Hong Kong', 'country', 'APAC', '[]'),
+    ('IN', 'India', 'country', 'APAC', '[]'),
+    ('ID', 'Indonesia', 'country', 'APAC', '[]'),
+    ('JP', 'Japan', 'country', 'APAC', '[]'),
+    ('KZ', 'Kazakhstan', 'country', 'APAC', '[]'),
+    ('KI', 'Kiribati', 'country', 'APAC', '[]'),
+    ('KG', 'Kyrgyzstan', 'country', 'APAC', '[]'),
+    ('LA', 'Laos', 'country', 'APAC', '[]'),
+    ('MO', 'Macao', 'country', 'APAC', '["Macau"]'),
+    ('MY', 'Malaysia', 'country', 'APAC', '[]'),
+    ('MV', 'Maldives', 'country', 'APAC', '[]'),
+    ('MH', 'Marshall Islands', 'country', 'APAC', '[]'),
+    ('FM', 'Micronesia', 'country', 'APAC', '[]'),
+    ('MN', 'Mongolia', 'country', 'APAC', '[]'),
+    ('MM', 'Myanmar', 'country', 'APAC', '["Burma"]'),
+    ('NR', 'Nauru', 'country', 'APAC', '[]'),
+    ('NP', 'Nepal', 'country', 'APAC', '[]'),
+    ('NC', 'New Caledonia', 'country', 'APAC', '[]'),
+    ('NZ', 'New Zealand', 'country', 'APAC', '[]'),
+    ('NU', 'Niue', 'country', 'APAC', '[]'),
+    ('NF', 'Norfolk Island', 'country', 'APAC', '[]'),
+    ('KP', 'North Korea', 'country', 'APAC', '[]'),
+    ('MP', 'Northern Mariana Islands', 'country', 'APAC', '[]'),
+    ('PK', 'Pakistan', 'country', 'APAC', '[]'),
+    ('PW', 'Palau', 'country', 'APAC', '[]'),
+    ('PG', 'Papua New Guinea', 'country', 'APAC', '[]'),
+    ('PH', 'Philippines', 'country', 'APAC', '[]'),
+    ('PN', 'Pitcairn Islands', 'country', 'APAC', '[]'),
+    ('WS', 'Samoa', 'country', 'APAC', '[]'),
+    ('SG', 'Singapore', 'country', 'APAC', '[]'),
+    ('SB', 'Solomon Islands', 'country', 'APAC', '[]'),
+    ('KR', 'South Korea', 'country', 'APAC', '["Korea", "Republic of Korea"]'),
+    ('LK', 'Sri Lanka', 'country', 'APAC', '[]'),
+    ('TW', 'Taiwan', 'country', 'APAC', '[]'),
+    ('TJ', 'Tajikistan', 'country', 'APAC', '[]'),
+    ('TH', 'Thailand', 'country', 'APAC', '[]'),
+    ('TL', 'Timor-Leste', 'country', 'APAC', '["East Timor"]'),
+    ('TK', 'Tokelau', 'country', 'APAC', '[]'),
+    ('TO', 'Tonga', 'country', 'APAC', '[]'),
+    ('TM', 'Turkmenistan', 'country', 'APAC', '[]'),
+    ('TV', 'Tuvalu', 'country', 'APAC', '[]'),
+    ('UM', 'U.S. Minor Outlying Islands', 'country', 'APAC', '[]'),
+    ('UZ', 'Uzbekistan', 'country', 'APAC', '[]'),
+    ('VU', 'Vanuatu', 'country', 'APAC', '[]'),
+    ('VN', 'Vietnam', 'country', 'APAC', '["Viet Nam"]'),
+    ('WF', 'Wallis and Futuna', 'country', 'APAC', '[]'),
+    ('AX', 'Aland Islands', 'country', 'EUR', '["Åland Islands"]'),
+    ('AL', 'Albania', 'country', 'EUR', '[]'),
+    ('AD', 'Andorra', 'country', 'EUR', '[]'),
+    ('AT', 'Austria', 'country', 'EUR', '[]'),
+    ('BY', 'Belarus', 'country', 'EUR', '[]'),
+    ('BE', 'Belgium', 'country', 'EUR', '[]'),
+    ('BA', 'Bosnia and Herzegovina', 'country', 'EUR', '[]'),
+    ('BG', 'Bulgaria', 'country', 'EUR', '[]'),
+    ('HR', 'Croatia', 'country', 'EUR', '[]'),
+    ('CY', 'Cyprus', 'country', 'EUR', '[]'),
+    ('CZ', 'Czechia', 'country', 'EUR', '["Czech Republic"]'),
+    ('DK', 'Denmark', 'country', 'EUR', '[]'),
+    ('EE', 'Estonia', 'country', 'EUR', '[]'),
+    ('FO', 'Faroe Islands', 'country', 'EUR', '[]'),
+    ('FI', 'Finland', 'country', 'EUR', '[]'),
+    ('FR', 'France', 'country', 'EUR', '[]'),
+    ('DE', 'Germany', 'country', 'EUR', '["Deutschland"]'),
+    ('GI', 'Gibraltar', 'country', 'EUR', '[]'),
+    ('GR', 'Greece', 'country', 'EUR', '[]'),
+    ('GG', 'Guernsey', 'country', 'EUR', '[]'),
+    ('HU', 'Hungary', 'country', 'EUR', '[]'),
+    ('IS', 'Iceland', 'country', 'EUR', '[]'),
+    ('IE', 'Ireland', 'country', 'EUR', '[]'),
+    ('IM', 'Isle of Man', 'country', 'EUR', '[]'),
+    ('IT', 'Italy', 'country', 'EUR', '[]'),
+    ('JE', 'Jersey', 'country', 'EUR', '[]'),
+    ('XK', 'Kosovo', 'country', 'EUR', '[]'),
+    ('LV', 'Latvia', 'country', 'EUR', '[]'),
+    ('LI', 'Liechtenstein', 'country', 'EUR', '[]'),
+    ('LT', 'Lithuania', 'country', 'EUR', '[]'),
+    ('LU', 'Luxembourg', 'country', 'EUR', '[]'),
+    ('MT', 'Malta', 'country', 'EUR', '[]'),
+    ('MD', 'Moldova', 'country', 'EUR', '[]'),
+    ('MC', 'Monaco', 'country', 'EUR', '[]'),
+    ('ME', 'Montenegro', 'country', 'EUR', '[]'),
+    ('NL', 'Netherlands', 'country', 'EUR', '["The Netherlands", "Holland"]'),
+    ('MK', 'North Macedonia', 'country', 'EUR', '["Macedonia"]'),
+    ('NO', 'Norway', 'country', 'EUR', '[]'),
+    ('PL', 'Poland', 'country', 'EUR', '[]'),
+    ('PT', 'Portugal', 'country', 'EUR', '[]'),
+    ('RO', 'Romania', 'country', 'EUR', '[]'),
+    ('RU', 'Russia', 'country', 'EUR', '["Russian Federation"]'),
+    ('SM', 'San Marino', 'country', 'EUR', '[]'),
+    ('RS', 'Serbia', 'country', 'EUR', '[]'),
+    ('SK', 'Slovakia', 'country', 'EUR', '[]'),
+    ('SI', 'Slovenia', 'country', 'EUR', '[]'),
+    ('ES', 'Spain', 'country', 'EUR', '[]'),
+    ('SJ', 'Svalbard and Jan Mayen', 'country', 'EUR', '[]'),
+    ('SE', 'Sweden', 'country', 'EUR', '[]'),
+    ('CH', 'Switzerland', 'country', 'EUR', '[]'),
+    ('UA', 'Ukraine', 'country', 'EUR', '[]'),
+    ('GB', 'United Kingdom', 'country', 'EUR', '["UK", "U.K.", "Great Britain", "Britain", "England"]'),
+    ('VA', 'Vatican City', 'country', 'EUR', '["Holy See"]'),
+    ('AI', 'Anguilla', 'country', 'LATAM', '[]'),
+    ('AG', 'Antigua and Barbuda', 'country', 'LATAM', '[]'),
+    ('AR', 'Argentina', 'country', 'LATAM', '[]'),
+    ('AW', 'Aruba', 'country', 'LATAM', '[]'),
+    ('BS', 'Bahamas', 'country', 'LATAM', '[]'),
+    ('BB', 'Barbados', 'country', 'LATAM', '[]'),
+    ('BZ', 'Belize', 'country', 'LATAM', '[]'),
+    ('BO', 'Bolivia', 'country', 'LATAM', '[]'),
+    ('BR', 'Brazil', 'country', 'LATAM', '["Brasil"]'),
+    ('VG', 'British Virgin Islands', 'country', 'LATAM', '[]'),
+    ('BQ', 'Caribbean Netherlands', 'country', 'LATAM', '[]'),
+    ('KY', 'Cayman Islands', 'country', 'LATAM', '[]'),
+    ('CL', 'Chile', 'country', 'LATAM', '[]'),
+    ('CO', 'Colombia', 'country', 'LATAM', '[]'),
+    ('CR', 'Costa Rica', 'country', 'LATAM', '[]'),
+    ('CU', 'Cuba', 'country', 'LATAM', '[]'),
+    ('CW', 'Curacao', 'country', 'LATAM', '["Curaçao"]'),
+    ('DM', 'Dominica', 'country', 'LATAM', '[]'),
+    ('DO', 'Dominican Republic', 'country', 'LATAM', '[]'),
+    ('EC', 'Ecuador', 'country', 'LATAM', '[]'),
+    ('SV', 'El Salvador', 'country', 'LATAM', '[]'),
+    ('FK', 'Falkland Islands', 'country', 'LATAM', '[]'),
+    ('GF', 'French Guiana', 'country', 'LATAM', '[]'),
+    ('GD', 'Grenada', 'country', 'LATAM', '[]'),
+    ('GP', 'Guadeloupe', 'country', 'LATAM', '[]'),
+    ('GT', 'Guatemala', 'country', 'LATAM', '[]'),
+    ('GY', 'Guyana', 'country', 'LATAM', '[]'),
+    ('HT', 'Haiti', 'country', 'LATAM', '[]'),
+    ('HN', 'Honduras', 'country', 'LATAM', '[]'),
+    ('JM', 'Jamaica', 'country', 'LATAM', '[]'),
+    ('MQ', 'Martinique', 'country', 'LATAM', '[]'),
+    ('MX', 'Mexico', 'country', 'LATAM', '[]'),
+    ('MS', 'Montserrat', 'country', 'LATAM', '[]'),
+    ('NI', 'Nicaragua', 'country', 'LATAM', '[]'),
+    ('PA', 'Panama', 'country', 'LATAM', '[]'),
+    ('PY', 'Paraguay', 'country', 'LATAM', '[]'),
+    ('PE', 'Peru', 'country', 'LATAM', '[]'),
+    ('PR', 'Puerto Rico', 'country', 'LATAM', '[]'),
+    ('BL', 'Saint Barthelemy', 'country', 'LATAM', '[]'),
+    ('KN', 'Saint Kitts and Nevis', 'country', 'LATAM', '[]'),
+    ('LC', 'Saint Lucia', 'country', 'LATAM', '[]'),
+    ('MF', 'Saint Martin', 'country', 'LATAM', '[]'),
+    ('VC', 'Saint Vincent and the Grenadines', 'country', 'LATAM', '[]'),
+    ('SX', 'Sint Maarten', 'country', 'LATAM', '[]'),
+    ('GS', 'South Georgia and the South Sandwich Islands', 'country', 'LATAM', '[]'),
+    ('SR', 'Suriname', 'country', 'LATAM', '[]'),
+    ('TT', 'Trinidad and Tobago', 'country', 'LATAM', '[]'),
+    ('TC', 'Turks and Caicos Islands', 'country', 'LATAM', '[]'),
+    ('VI', 'U.S. Virgin Islands', 'country', 'LATAM', '[]'),
+    ('UY', 'Uruguay', 'country', 'LATAM', '[]'),
+    ('VE', 'Venezuela', 'country', 'LATAM', '[]'),
+    ('DZ', 'Algeria', 'country', 'MEA', '[]'),
+    ('AO', 'Angola', 'country', 'MEA', '[]'),
+    ('AM', 'Armenia', 'country', 'MEA', '[]'),
+    ('AZ', 'Azerbaijan', 'country', 'MEA', '[]'),
+    ('BH', 'Bahrain', 'country', 'MEA', '[]'),
+    ('BJ', 'Benin', 'country', 'MEA', '[]'),
+    ('BW', 'Botswana', 'country', 'MEA', '[]'),
+    ('BV', 'Bouvet Island', 'country', 'MEA', '[]'),
+    ('BF', 'Burkina Faso', 'country', 'MEA', '[]'),
+    ('BI', 'Burundi', 'country', 'MEA', '[]'),
+    ('CV', 'Cabo Verde', 'country', 'MEA', '["Cape Verde"]'),
+    ('CM', 'Cameroon', 'country', 'MEA', '[]'),
+    ('CF', 'Central African Republic', 'country', 'MEA', '[]'),
+    ('TD', 'Chad', 'country', 'MEA', '[]'),
+    ('KM', 'Comoros', 'country', 'MEA', '[]'),
+    ('CG', 'Congo', 'country', 'MEA', '["Republic of the Congo"]'),
+    ('CI', 'Cote d''Ivoire', 'country', 'MEA', '["Côte d''Ivoire", "Ivory Coast"]'),
+    ('CD', 'DR Congo', 'country', 'MEA', '["Democratic Republic of the Congo"]'),
+    ('DJ', 'Djibouti', 'country', 'MEA', '[]'),
+    ('EG', 'Egypt', 'country', 'MEA', '[]'),
+    ('GQ', 'Equatorial Guinea', 'country', 'MEA', '[]'),
+    ('ER', 'Eritrea', 'country', 'MEA', '[]'),
+    ('SZ', 'Eswatini', 'country', 'MEA', '["Swaziland"]'),
+    ('ET', 'Ethiopia', 'country', 'MEA', '[]'),
+    ('TF', 'French Southern Territories', 'country', 'MEA', '[]'),
+    ('GA', 'Gabon', 'country', 'MEA', '[]'),
+    ('GM', 'Gambia', 'country', 'MEA', '[]'),
+    ('GE', 'Georgia', 'country', 'MEA', '[]'),
+    ('GH', 'Ghana', 'country', 'MEA', '[]'),
+    ('GN', 'Guinea', 'country', 'MEA', '[]'),
+    ('GW', 'Guinea-Bissau', 'country', 'MEA', '[]'),
+    ('IR', 'Iran', 'country', 'MEA', '[]'),
+    ('IQ', 'Iraq', 'country', 'MEA', '[]'),
+    ('IL', 'Israel', 'country', 'MEA', '[]'),
+    ('JO', 'Jordan', 'country', 'MEA', '[]'),
+    ('KE', 'Kenya', 'country', 'MEA', '[]'),
+    ('KW', 'Kuwait', 'country', 'MEA', '[]'),
+    ('LB', 'Lebanon', 'country', 'MEA', '[]'),
+    ('LS', 'Lesotho', 'country', 'MEA', '[]'),
+    ('LR', 'Liberia', 'country', 'MEA', '[]'),
+    ('LY', 'Libya', 'country', 'MEA', '[]'),
+    ('MG', 'Madagascar', 'country', 'MEA', '[]'),
+    ('MW', 'Malawi', 'country', 'MEA', '[]'),
+    ('ML', 'Mali', 'country', 'MEA', '[]'),
+    ('MR', 'Mauritania', 'country', 'MEA', '[]'),
+    ('MU', 'Mauritius', 'country', 'MEA', '[]'),
+    ('YT', 'Mayotte', 'country', 'MEA', '[]'),
+    ('MA', 'Morocco', 'country', 'MEA', '[]'),
+    ('MZ', 'Mozambique', 'country', 'MEA', '[]'),
+    ('NA', 'Namibia', 'country', 'MEA', '[]'),
+    ('NE', 'Niger', 'country', 'MEA', '[]'),
+    ('NG', 'Nigeria', 'country', 'MEA', '[]'),
+    ('OM', 'Oman', 'country', 'MEA', '[]'),
+    ('PS', 'Palestine', 'country', 'MEA', '[]'),
+    ('QA', 'Qatar', 'country', 'MEA', '[]'),
+    ('RE', 'Reunion', 'country', 'MEA', '["Réunion"]'),
+    ('RW', 'Rwanda', 'country', 'MEA', '[]'),
+    ('SH', 'Saint Helena', 'country', 'MEA', '[]'),
+    ('ST', 'Sao Tome and Principe', 'country', 'MEA', '[]'),
+    ('SA', 'Saudi Arabia', 'country', 'MEA', '["KSA", "Kingdom of Saudi Arabia"]'),
+    ('SN', 'Senegal', 'country', 'MEA', '[]'),
+    ('SC', 'Seychelles', 'country', 'MEA', '[]'),
+    ('SL', 'Sierra Leone', 'country', 'MEA', '[]'),
+    ('SO', 'Somalia', 'country', 'MEA', '[]'),
+    ('ZA', 'South Africa', 'country', 'MEA', '[]'),
+    ('SS', 'South Sudan', 'country', 'MEA', '[]'),
+    ('SD', 'Sudan', 'country', 'MEA', '[]'),
+    ('SY', 'Syria', 'country', 'MEA', '[]'),
+    ('TZ', 'Tanzania', 'country', 'MEA', '[]'),
+    ('TG', 'Togo', 'country', 'MEA', '[]'),
+    ('TN', 'Tunisia', 'country', 'MEA', '[]'),
+    ('TR', 'Turkey', 'country', 'MEA', '["Türkiye", "Turkiye"]'),
+    ('UG', 'Uganda', 'country', 'MEA', '[]'),
+    ('AE', 'United Arab Emirates', 'country', 'MEA', '["UAE", "U.A.E."]'),
+    ('EH', 'Western Sahara', 'country', 'MEA', '[]'),
+    ('YE', 'Yemen', 'country', 'MEA', '[]'),
+    ('ZM', 'Zambia', 'country', 'MEA', '[]'),
+    ('ZW', 'Zimbabwe', 'country', 'MEA', '[]'),
+    ('BM', 'Bermuda', 'country', 'NAM', '[]'),
+    ('CA', 'Canada', 'country', 'NAM', '[]'),
+    ('GL', 'Greenland', 'country', 'NAM', '[]'),
+    ('PM', 'Saint Pierre and Miquelon', 'country', 'NAM', '[]'),
+    ('US', 'United States', 'country', 'NAM', '["USA", "United States of America", "U.S.", "U.S.A.", "America"]')
+ON CONFLICT (code) DO NOTHING;
+
+-- Same key as geography.Key in Go: lower case, "&" read as "and", dots
+-- dropped and any other punctuation treated as a space
+CREATE OR REPLACE FUNCTION migrate_039_geo_key(v TEXT) RETURNS TEXT AS $$
+    SELECT btrim(regexp_replace(lower(replace(replace(v, '&', ' and '), '.', '')), '[^[:alnum:]]+', ' ', 'g'))
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Rewrite every report's geography to canonical names, dropping duplicates
+-- and keeping the original order. Unknown values are kept as they are and
+-- must be fixed the next time the report is saved.
+WITH geo_keys AS (
+    SELECT name, migrate_039_geo_key(name) AS key, 1 AS priority FROM geographies
+    UNION ALL
+    SELECT g.name, migrate_039_geo_key(a.alias), 2
+    FROM geographies g CROSS JOIN LATERAL jsonb_array_elements_text(g.aliases) AS a(alias)
+    UNION ALL
+    SELECT name, migrate_039_geo_key(code), 3 FROM geographies
+)
+UPDATE reports r
+SET geography = (
+    SELECT COALESCE(jsonb_agg(x.value ORDER BY x.ord), '[]'::jsonb)
+    FROM (
+        SELECT COALESCE(m.name, btrim(e.value)) AS value, MIN(e.ord) AS ord
+        FROM jsonb_array_elements_text(r.geography) WITH ORDINALITY AS e(value, ord)
+        LEFT JOIN LATERAL (
+            SELECT k.name FROM geo_keys k
+            WHERE k.key = migrate_039_geo_key(e.value)
+            ORDER BY k.priority
+            LIMIT 1
+        ) m ON TRUE
+        WHERE btrim(e.value) <> ''
+        GROUP BY COALESCE(m.name, btrim(e.value))
+    ) x
+)
+WHERE jsonb_typeof(r.geography) = 'array';
+
+DO $$
+DECLARE
+    unmatched BIGINT;
+BEGIN
+    SELECT COUNT(DISTINCT r.id) INTO unmatched
+    FROM reports r
+    CROSS JOIN LATERAL jsonb_array_elements_text(
+        CASE WHEN jsonb_typeof(r.geography) = 'array' THEN r.geography ELSE '[]'::jsonb END
+    ) AS e(value)
+    WHERE NOT EXISTS (SELECT 1 FROM geographies g WHERE g.name = e.value);
+
+    IF unmatched > 0 THEN
+        RAISE NOTICE '% report(s) have geography values outside the taxonomy', unmatched;
+    END IF;
+END
+$$;
+
+DROP FUNCTION migrate_039_geo_key(TEXT);
+
+-- A region filter matches any of its countries, one containment test each
+CREATE INDEX IF NOT EXISTS idx_reports_geography ON reports USING GIN (geography jsonb_path_ops);
@@ -0,0 +1,13 @@
+-- blogs.tags and press_releases.tags already hold the tag names, so only the
+-- report tags are lost
+DROP TRIGGER IF EXISTS trg_reports_delete_content_tags ON reports;
+DROP TRIGGER IF EXISTS trg_blogs_delete_content_tags ON blogs;
+DROP TRIGGER IF EXISTS trg_press_releases_delete_content_tags ON press_releases;
+DROP FUNCTION IF EXISTS delete_content_tags();
+
+DELETE FROM slug_redirects WHERE entity_type = 'tag';
+
+ALTER TABLE reports DROP COLUMN IF EXISTS tags;
+
+DROP TABLE IF EXISTS content_tags;
+DROP TABLE IF EXISTS tags;
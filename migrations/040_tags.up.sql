@@ -0,0 +1,100 @@
+-- Tags shared by reports, blogs and press releases. content_tags is the
+-- source of truth; blogs.tags and press_releases.tags stay as the
+-- comma-joined names (they feed search_vector) and reports.tags holds the
+-- names as a JSON array, both rewritten whenever a tag changes.
+CREATE TABLE IF NOT EXISTS tags (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    slug VARCHAR(120) NOT NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_slug ON tags (slug);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_name_lower ON tags (lower(name));
+
+-- content_type/content_id is polymorphic, so there is no foreign key on it;
+-- triggers remove the links of deleted content
+CREATE TABLE IF NOT EXISTS content_tags (
+    tag_id BIGINT NOT NULL REFERENCES tags (id) ON DELETE CASCADE,
+    content_type VARCHAR(20) NOT NULL,
+    content_id BIGINT NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ,
+    PRIMARY KEY (content_type, content_id, tag_id),
+    CONSTRAINT chk_content_tags_content_type CHECK (content_type IN ('report', 'blog', 'press_release'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_content_tags_tag ON content_tags (tag_id, content_type);
+
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS tags JSONB NOT NULL DEFAULT '[]'::jsonb;
+
+CREATE OR REPLACE FUNCTION delete_content_tags() RETURNS trigger AS $$
+BEGIN
+    DELETE FROM content_tags WHERE content_type = TG_ARGV[0] AND content_id = OLD.id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_reports_delete_content_tags ON reports;
+CREATE TRIGGER trg_reports_delete_content_tags AFTER DELETE ON reports
+    FOR EACH ROW EXECUTE FUNCTION delete_content_tags('report');
+
+DROP TRIGGER IF EXISTS trg_blogs_delete_content_tags ON blogs;
+CREATE TRIGGER trg_blogs_delete_content_tags AFTER DELETE ON blogs
+    FOR EACH ROW EXECUTE FUNCTION delete_content_tags('blog');
+
+DROP TRIGGER IF EXISTS trg_press_releases_delete_content_tags ON press_releases;
+CREATE TRIGGER trg_press_releases_delete_content_tags AFTER DELETE ON press_releases
+    FOR EACH ROW EXECUTE FUNCTION delete_content_tags('press_release');
+
+-- Split the comma-separated strings of blogs and press releases. Slugs follow
+-- slug.Make for ASCII names; names that differ only in case or punctuation
+-- share a slug and become one tag under their most used spelling.
+CREATE TEMP TABLE migrate_040_tags ON COMMIT DROP AS
+SELECT src.content_type, src.content_id, e.ord AS position,
+    left(btrim(regexp_replace(e.name, '\s+', ' ', 'g')), 100) AS name
+FROM (
+    SELECT 'blog'::text AS content_type, id AS content_id, tags FROM blogs WHERE coalesce(tags, '') <> ''
+    UNION ALL
+    SELECT 'press_release', id, tags FROM press_releases WHERE coalesce(tags, '') <> ''
+) src
+CROSS JOIN LATERAL unnest(string_to_array(src.tags, ',')) WITH ORDINALITY AS e(name, ord);
+
+DELETE FROM migrate_040_tags WHERE name = '';
+
+ALTER TABLE migrate_040_tags ADD COLUMN slug TEXT;
+UPDATE migrate_040_tags
+SET slug = btrim(regexp_replace(lower(replace(name, '&', ' and ')), '[^a-z0-9]+', '-', 'g'), '-');
+-- Names without a single ASCII letter or digit still need a slug
+UPDATE migrate_040_tags SET slug = 'tag-' || left(md5(lower(name)), 8) WHERE slug = '';
+
+INSERT INTO tags (name, slug, created_at, updated_at)
+SELECT DISTINCT ON (slug) name, slug, NOW(), NOW()
+FROM (
+    SELECT slug, name, COUNT(*) AS uses FROM migrate_040_tags GROUP BY slug, name
+) spellings
+ORDER BY slug, uses DESC, name;
+
+INSERT INTO content_tags (tag_id, content_type, content_id, position, created_at)
+SELECT t.id, m.content_type, m.content_id, MIN(m.position) - 1, NOW()
+FROM migrate_040_tags m
+JOIN tags t ON t.slug = m.slug
+GROUP BY t.id, m.content_type, m.content_id;
+
+-- Rewrite the strings as the canonical, de-duplicated lists
+UPDATE blogs b
+SET tags = coalesce((
+    SELECT string_agg(t.name, ', ' ORDER BY ct.position, t.name)
+    FROM content_tags ct JOIN tags t ON t.id = ct.tag_id
+    WHERE ct.content_type = 'blog' AND ct.content_id = b.id
+), '')
+WHERE coalesce(b.tags, '') <> '';
+
+UPDATE press_releases p
+SET tags = coalesce((
+    SELECT string_agg(t.name, ', ' ORDER BY ct.position, t.name)
+    FROM content_tags ct JOIN tags t ON t.id = ct.tag_id
+    WHERE ct.content_type = 'press_release' AND ct.content_id = p.id
+), '')
+WHERE coalesce(p.tags, '') <> '';
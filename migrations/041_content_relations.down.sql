@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS trg_reports_delete_content_relations ON reports;
+DROP TRIGGER IF EXISTS trg_blogs_delete_content_relations ON blogs;
+DROP TRIGGER IF EXISTS trg_press_releases_delete_content_relations ON press_releases;
+DROP FUNCTION IF EXISTS delete_content_relations();
+
+DROP TABLE IF EXISTS content_relations;
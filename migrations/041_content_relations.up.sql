@@ -0,0 +1,50 @@
+-- Relations between reports, blogs and press releases. Each pair is stored
+-- once with the lower (type, id) as the source and shows on both items.
+-- Editors curate relations directly; the suggestion engine writes
+-- 'suggested' rows that editors accept or reject.
+CREATE TABLE IF NOT EXISTS content_relations (
+    id BIGSERIAL PRIMARY KEY,
+    source_type VARCHAR(20) NOT NULL,
+    source_id BIGINT NOT NULL,
+    target_type VARCHAR(20) NOT NULL,
+    target_id BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'suggested',
+    score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    reasons JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_by BIGINT REFERENCES users (id) ON DELETE SET NULL,
+    reviewed_by BIGINT REFERENCES users (id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT chk_content_relations_source_type CHECK (source_type IN ('report', 'blog', 'press_release')),
+    CONSTRAINT chk_content_relations_target_type CHECK (target_type IN ('report', 'blog', 'press_release')),
+    CONSTRAINT chk_content_relations_status CHECK (status IN ('curated', 'suggested', 'accepted', 'rejected')),
+    CONSTRAINT chk_content_relations_order CHECK ((source_type, source_id) < (target_type, target_id))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_content_relations_pair
+    ON content_relations (source_type, source_id, target_type, target_id);
+CREATE INDEX IF NOT EXISTS idx_content_relations_target ON content_relations (target_type, target_id);
+CREATE INDEX IF NOT EXISTS idx_content_relations_status ON content_relations (status, score DESC);
+
+-- content is polymorphic, so deleted items' relations go by trigger
+CREATE OR REPLACE FUNCTION delete_content_relations() RETURNS trigger AS $$
+BEGIN
+    DELETE FROM content_relations
+    WHERE (source_type = TG_ARGV[0] AND source_id = OLD.id)
+        OR (target_type = TG_ARGV[0] AND target_id = OLD.id);
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_reports_delete_content_relations ON reports;
+CREATE TRIGGER trg_reports_delete_content_relations AFTER DELETE ON reports
+    FOR EACH ROW EXECUTE FUNCTION delete_content_relations('report');
+
+DROP TRIGGER IF EXISTS trg_blogs_delete_content_relations ON blogs;
+CREATE TRIGGER trg_blogs_delete_content_relations AFTER DELETE ON blogs
+    FOR EACH ROW EXECUTE FUNCTION delete_content_relations('blog');
+
+DROP TRIGGER IF EXISTS trg_press_releases_delete_content_relations ON press_releases;
+CREATE TRIGGER trg_press_releases_delete_content_relations AFTER DELETE ON press_releases
+    FOR EACH ROW EXECUTE FUNCTION delete_content_relations('press_release');
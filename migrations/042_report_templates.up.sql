@@ -0,0 +1,22 @@
+-- Saved starting points for new reports. POST /reports with a templateId
+-- fills the table of contents, FAQs, geography, pricing and category the
+-- report leaves empty.
+CREATE TABLE IF NOT EXISTS report_templates (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    category_id BIGINT REFERENCES categories (id) ON DELETE SET NULL,
+    table_of_contents TEXT NOT NULL DEFAULT '',
+    faqs JSONB NOT NULL DEFAULT '[]'::jsonb,
+    geography JSONB NOT NULL DEFAULT '[]'::jsonb,
+    price DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    discounted_price DECIMAL(10, 2) NOT NULL DEFAULT 0,
+    currency VARCHAR(3),
+    created_by BIGINT REFERENCES users (id) ON DELETE SET NULL,
+    updated_by BIGINT REFERENCES users (id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_report_templates_name_lower ON report_templates (lower(name));
+CREATE INDEX IF NOT EXISTS idx_report_templates_category_id ON report_templates (category_id);
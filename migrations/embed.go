@@ -0,0 +1,11 @@
+// Package migrations embeds the versioned SQL migrations so that the API server
+// and cmd/migrate always agree on the schema they expect.
+package migrations
+
+import "embed"
+
+// FS holds the <version>_<name>.up.sql and .down.sql files. Files in legacy/
+// are kept for reference only and are not embedded.
+//
+//go:embed *.sql
+var FS embed.FS
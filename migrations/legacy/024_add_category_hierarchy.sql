@@ -0,0 +1,7 @@
+-- Allow categories to be nested (e.g. Diagnostics > In-Vitro > Molecular)
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS parent_id BIGINT NULL REFERENCES categories(id) ON DELETE SET NULL;
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS sort_order INTEGER NOT NULL DEFAULT 0;
+
+-- Index for walking the hierarchy and ordering siblings
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories(parent_id);
+CREATE INDEX IF NOT EXISTS idx_categories_sort_order ON categories(sort_order);
@@ -0,0 +1,36 @@
+-- Add weighted full-text search vectors to reports, blogs and press releases
+-- Weights: title (A) > summary/excerpt (B) > description/content (C) > sections/tags (D)
+-- Stored generated columns keep the vectors current on every insert and update
+
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS search_vector tsvector
+GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(summary, '')), 'B') ||
+    setweight(to_tsvector('english', coalesce(description, '')), 'C') ||
+    setweight(to_tsvector('english',
+        coalesce(sections->>'marketDetails', '') || ' ' ||
+        coalesce(sections->>'keyPlayers', '') || ' ' ||
+        coalesce(sections->>'tableOfContents', '')), 'D')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_reports_search_vector ON reports USING GIN (search_vector);
+
+ALTER TABLE blogs ADD COLUMN IF NOT EXISTS search_vector tsvector
+GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(excerpt, '')), 'B') ||
+    setweight(to_tsvector('english', coalesce(content, '')), 'C') ||
+    setweight(to_tsvector('english', coalesce(tags, '')), 'D')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_blogs_search_vector ON blogs USING GIN (search_vector);
+
+ALTER TABLE press_releases ADD COLUMN IF NOT EXISTS search_vector tsvector
+GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(excerpt, '')), 'B') ||
+    setweight(to_tsvector('english', coalesce(content, '')), 'C') ||
+    setweight(to_tsvector('english', coalesce(tags, '')), 'D')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_press_releases_search_vector ON press_releases USING GIN (search_vector);
@@ -0,0 +1,8 @@
+-- Composite indexes for keyset (cursor) pagination
+-- List endpoints page through rows ordered by (created_at, id) descending
+
+CREATE INDEX IF NOT EXISTS idx_reports_created_at_id ON reports (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_blogs_created_at_id ON blogs (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_press_releases_created_at_id ON press_releases (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_form_submissions_created_at_id ON form_submissions (created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_created_at_id ON audit_logs (created_at DESC, id DESC);
@@ -0,0 +1,19 @@
+-- Full report version history
+-- Every save now records a version; published_by/published_at are only set when the save published the report
+
+ALTER TABLE report_versions ADD COLUMN IF NOT EXISTS change_type VARCHAR(20) DEFAULT 'update';
+ALTER TABLE report_versions ADD COLUMN IF NOT EXISTS created_by BIGINT;
+ALTER TABLE report_versions ADD COLUMN IF NOT EXISTS restored_from BIGINT;
+ALTER TABLE report_versions ADD COLUMN IF NOT EXISTS snapshot JSONB;
+
+ALTER TABLE report_versions ALTER COLUMN published_by DROP NOT NULL;
+ALTER TABLE report_versions ALTER COLUMN published_at DROP NOT NULL;
+
+-- Existing versions were all created on publish
+UPDATE report_versions
+SET change_type = 'publish',
+    created_by = published_by
+WHERE created_by IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_report_versions_created_by ON report_versions (created_by);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_report_versions_report_version ON report_versions (report_id, version_number);
@@ -0,0 +1,6 @@
+-- Optimistic concurrency control
+-- Each write increments version; updates must name the version they were based on
+
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE blogs ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE press_releases ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,23 @@
+-- Editorial workflow: draft -> review -> approved -> published -> archived
+-- Status changes go through the workflow endpoints only
+
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS reviewer_id INTEGER REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS submitted_by INTEGER;
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS submitted_at TIMESTAMP;
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS reviewed_by INTEGER;
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMP;
+ALTER TABLE reports ADD COLUMN IF NOT EXISTS rejection_reason TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_reports_reviewer_id ON reports(reviewer_id);
+
+CREATE TABLE IF NOT EXISTS report_review_comments (
+    id SERIAL PRIMARY KEY,
+    report_id INTEGER NOT NULL REFERENCES reports(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id),
+    action VARCHAR(20),
+    body TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_review_comments_report_id ON report_review_comments(report_id);
+CREATE INDEX IF NOT EXISTS idx_report_review_comments_user_id ON report_review_comments(user_id);
@@ -0,0 +1,15 @@
+-- Slug history: retired slugs keep resolving to the entity that used them
+-- The target slug is looked up at resolve time, so renames never chain
+CREATE TABLE IF NOT EXISTS slug_redirects (
+    id BIGSERIAL PRIMARY KEY,
+    entity_type VARCHAR(30) NOT NULL,
+    old_slug VARCHAR(255) NOT NULL,
+    entity_id BIGINT NOT NULL,
+    is_manual BOOLEAN DEFAULT false,
+    created_by BIGINT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_slug_redirects_type_slug ON slug_redirects(entity_type, old_slug);
+CREATE INDEX IF NOT EXISTS idx_slug_redirects_entity_id ON slug_redirects(entity_id);
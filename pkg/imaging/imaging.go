@@ -0,0 +1,142 @@
+// Package imaging decodes uploaded images and renders resized copies of them
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+)
+
+// Formats images are decoded from and encoded to
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+	FormatGIF  = "gif"
+	FormatWebP = "webp"
+)
+
+// JPEGQuality is used for every JPEG rendition
+const JPEGQuality = 85
+
+var ErrUnsupportedFormat = errors.New("unsupported image format")
+
+// Decode reads a JPEG, PNG, GIF (first frame) or WebP image and reports its format
+func Decode(data []byte) (image.Image, string, error) {
+	r := bytes.NewReader(data)
+
+	var (
+		img image.Image
+		err error
+	)
+	format := sniff(data)
+	switch format {
+	case FormatJPEG:
+		img, err = jpeg.Decode(r)
+	case FormatPNG:
+		img, err = png.Decode(r)
+	case FormatGIF:
+		img, err = gif.Decode(r)
+	case FormatWebP:
+		img, err = webp.Decode(r)
+	default:
+		return nil, "", ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decode %s image: %w", format, err)
+	}
+	return img, format, nil
+}
+
+// Fit scales img down to fit within width x height, keeping its aspect ratio.
+// Images that already fit are returned unchanged.
+func Fit(img image.Image, width, height int) image.Image {
+	b := img.Bounds()
+	if b.Dx() <= width && b.Dy() <= height {
+		return img
+	}
+
+	scale := min(float64(width)/float64(b.Dx()), float64(height)/float64(b.Dy()))
+	w := max(1, int(float64(b.Dx())*scale+0.5))
+	h := max(1, int(float64(b.Dy())*scale+0.5))
+	return scaleRect(img, b, w, h)
+}
+
+// Cover scales and center-crops img to exactly width x height
+func Cover(img image.Image, width, height int) image.Image {
+	b := img.Bounds()
+
+	// Largest centered area of the source with the target aspect ratio
+	src := b
+	if b.Dx()*height > b.Dy()*width {
+		w := b.Dy() * width / height
+		src.Min.X = b.Min.X + (b.Dx()-w)/2
+		src.Max.X = src.Min.X + w
+	} else {
+		h := b.Dx() * height / width
+		src.Min.Y = b.Min.Y + (b.Dy()-h)/2
+		src.Max.Y = src.Min.Y + h
+	}
+	return scaleRect(img, src, width, height)
+}
+
+func scaleRect(img image.Image, src image.Rectangle, width, height int) image.Image {
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, src, draw.Src, nil)
+	return dst
+}
+
+// Encode renders img in format. GIFs are encoded as PNG since renditions are
+// still images.
+func Encode(img image.Image, format string) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case FormatJPEG:
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: JPEGQuality})
+	case FormatPNG, FormatGIF:
+		err = png.Encode(&buf, img)
+	case FormatWebP:
+		err = EncodeWebP(&buf, img)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s image: %w", format, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Extension returns the file extension renditions in format are stored with
+func Extension(format string) string {
+	switch format {
+	case FormatJPEG:
+		return ".jpg"
+	case FormatGIF:
+		return ".png"
+	default:
+		return "." + format
+	}
+}
+
+// sniff identifies the format from the file signature
+func sniff(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte{0xff, 0xd8, 0xff}):
+		return FormatJPEG
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return FormatPNG
+	case bytes.HasPrefix(data, []byte("GIF87a")), bytes.HasPrefix(data, []byte("GIF89a")):
+		return FormatGIF
+	case len(data) >= 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WEBP":
+		return FormatWebP
+	default:
+		return ""
+	}
+}
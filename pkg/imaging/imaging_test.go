@@ -0,0 +1,178 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/image/webp"
+)
+
+// chart draws a flat-colored bar chart, the kind of image most uploads are
+func chart(width, height int) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := color.NRGBA{R: 255, G: 255, B: 255, A: 255}
+			if bar := x / 40; x%40 > 8 && y > height-(bar+1)*height/12 {
+				c = color.NRGBA{R: uint8(40 * bar), G: 120, B: uint8(255 - 30*bar), A: 255}
+			}
+			img.SetNRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func noise(width, height int, withAlpha bool) *image.NRGBA {
+	rng := rand.New(rand.NewSource(1))
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	rng.Read(img.Pix)
+	if !withAlpha {
+		for i := 3; i < len(img.Pix); i += 4 {
+			img.Pix[i] = 0xff
+		}
+	}
+	return img
+}
+
+func flat(width, height int, c color.NRGBA) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = c.R, c.G, c.B, c.A
+	}
+	return img
+}
+
+func assertSamePixels(t *testing.T, want image.Image, got image.Image) {
+	t.Helper()
+	require.Equal(t, want.Bounds().Size(), got.Bounds().Size())
+	wb, gb := want.Bounds(), got.Bounds()
+	for y := 0; y < wb.Dy(); y++ {
+		for x := 0; x < wb.Dx(); x++ {
+			w := color.NRGBAModel.Convert(want.At(wb.Min.X+x, wb.Min.Y+y))
+			g := color.NRGBAModel.Convert(got.At(gb.Min.X+x, gb.Min.Y+y))
+			if w != g {
+				t.Fatalf("pixel (%d,%d) = %v, want %v", x, y, g, w)
+			}
+		}
+	}
+}
+
+func TestEncodeWebP_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		img  image.Image
+	}{
+		{"chart", chart(480, 270)},
+		{"noise", noise(97, 61, false)},
+		{"noise with alpha", noise(64, 33, true)},
+		{"single pixel", noise(1, 1, false)},
+		{"flat", flat(300, 200, color.NRGBA{R: 10, G: 20, B: 30, A: 255})},
+		{"wide run", chart(5000, 3)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, EncodeWebP(&buf, tt.img))
+
+			decoded, err := webp.Decode(&buf)
+			require.NoError(t, err)
+			assertSamePixels(t, tt.img, decoded)
+		})
+	}
+}
+
+func TestEncodeWebP_CompressesCharts(t *testing.T) {
+	img := chart(1200, 630)
+
+	var webpBuf, pngBuf bytes.Buffer
+	require.NoError(t, EncodeWebP(&webpBuf, img))
+	require.NoError(t, png.Encode(&pngBuf, img))
+
+	assert.Less(t, webpBuf.Len(), pngBuf.Len())
+}
+
+func TestPrefixEncode(t *testing.T) {
+	// Inverse of the decoder's prefix code expansion
+	decode := func(symbol int, extra uint32) int {
+		if symbol < 4 {
+			return symbol + 1
+		}
+		extraBits := (symbol - 2) >> 1
+		offset := (2 + symbol&1) << extraBits
+		return offset + int(extra) + 1
+	}
+
+	for v := 1; v <= maxCopyLength; v++ {
+		symbol, extraBits, extra := prefixEncode(v)
+		require.Less(t, symbol, numLengthCodes)
+		require.Less(t, extra, uint32(1)<<extraBits+1)
+		require.Equal(t, v, decode(symbol, extra), "value %d", v)
+	}
+}
+
+func TestHuffmanLengths_RespectsLimit(t *testing.T) {
+	// Fibonacci counts produce the deepest possible tree
+	hist := make([]uint32, 30)
+	a, b := uint32(1), uint32(1)
+	for i := range hist {
+		hist[i] = a
+		a, b = b, a+b
+	}
+
+	lengths := huffmanLengths(hist, 7)
+	kraft := 0.0
+	for _, l := range lengths {
+		require.NotZero(t, l)
+		require.LessOrEqual(t, l, uint8(7))
+		kraft += 1 / float64(uint(1)<<l)
+	}
+	assert.InDelta(t, 1.0, kraft, 1e-9)
+}
+
+func TestDecodeAndEncode(t *testing.T) {
+	var src bytes.Buffer
+	require.NoError(t, png.Encode(&src, chart(800, 400)))
+
+	img, format, err := Decode(src.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, FormatPNG, format)
+
+	for _, f := range []string{FormatJPEG, FormatPNG, FormatGIF, FormatWebP} {
+		data, err := Encode(img, f)
+		require.NoError(t, err, f)
+
+		_, decodedFormat, err := Decode(data)
+		require.NoError(t, err, f)
+		if f == FormatGIF {
+			f = FormatPNG
+		}
+		assert.Equal(t, f, decodedFormat)
+	}
+
+	_, _, err = Decode([]byte("not an image"))
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestFitAndCover(t *testing.T) {
+	img := chart(1600, 900)
+
+	fit := Fit(img, 320, 320)
+	assert.Equal(t, image.Pt(320, 180), fit.Bounds().Size())
+
+	// Small images are never enlarged
+	small := chart(200, 100)
+	assert.Same(t, small, Fit(small, 320, 320))
+
+	cover := Cover(img, 1200, 630)
+	assert.Equal(t, image.Pt(1200, 630), cover.Bounds().Size())
+
+	tall := Cover(chart(300, 900), 1200, 630)
+	assert.Equal(t, image.Pt(1200, 630), tall.Bounds().Size())
+}
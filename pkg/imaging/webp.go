@@ -0,0 +1,484 @@
+package imaging
+
+import (
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"io"
+	"math/bits"
+	"sort"
+)
+
+// VP8L (lossless WebP) limits and alphabet sizes
+const (
+	webpMaxDimension = 1 << 14
+	numLiteralCodes  = 256
+	numLengthCodes   = 24
+	numDistanceCodes = 40
+	maxCopyLength    = 4096
+	minCopyLength    = 3
+	maxCodeLength    = 15
+	maxCodeLenLength = 7
+
+	// Distance codes 1 and 2 address the pixel above and the pixel to the left
+	distCodeAbove = 1
+	distCodeLeft  = 2
+
+	transformSubtractGreen = 2
+)
+
+// codeLengthCodeOrder is the order code length code lengths are written in
+var codeLengthCodeOrder = [19]int{17, 18, 0, 1, 2, 3, 4, 5, 16, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+var errWebPTooLarge = errors.New("image is too large for WebP")
+
+// EncodeWebP writes img as a lossless WebP. It uses the subtract-green
+// transform and run-length backward references to the left and upper pixel,
+// which keeps charts and screenshots small without a lossy codec.
+func EncodeWebP(w io.Writer, img image.Image) error {
+	b := img.Bounds()
+	width, height := b.Dx(), b.Dy()
+	if width < 1 || height < 1 || width > webpMaxDimension || height > webpMaxDimension {
+		return errWebPTooLarge
+	}
+
+	pixels, hasAlpha := argbPixels(img)
+	subtractGreen(pixels)
+	tokens := backwardRefs(pixels, width)
+
+	// Histograms of the five prefix codes: green/length, red, blue, alpha, distance
+	hist := [5][]uint32{
+		make([]uint32, numLiteralCodes+numLengthCodes),
+		make([]uint32, numLiteralCodes),
+		make([]uint32, numLiteralCodes),
+		make([]uint32, numLiteralCodes),
+		make([]uint32, numDistanceCodes),
+	}
+	for _, t := range tokens {
+		if t.length == 0 {
+			hist[0][t.argb>>8&0xff]++
+			hist[1][t.argb>>16&0xff]++
+			hist[2][t.argb&0xff]++
+			hist[3][t.argb>>24]++
+			continue
+		}
+		lc, _, _ := prefixEncode(t.length)
+		dc, _, _ := prefixEncode(t.distCode)
+		hist[0][numLiteralCodes+lc]++
+		hist[4][dc]++
+	}
+
+	bw := &bitWriter{}
+	bw.write(0x2f, 8)
+	bw.write(uint32(width-1), 14)
+	bw.write(uint32(height-1), 14)
+	if hasAlpha {
+		bw.write(1, 1)
+	} else {
+		bw.write(0, 1)
+	}
+	bw.write(0, 3) // version
+
+	bw.write(1, 1) // transform present
+	bw.write(transformSubtractGreen, 2)
+	bw.write(0, 1) // no more transforms
+	bw.write(0, 1) // no color cache
+	bw.write(0, 1) // a single set of prefix codes for the whole image
+
+	var codes [5]*prefixCode
+	for i := range hist {
+		codes[i] = writePrefixCode(bw, hist[i])
+	}
+
+	for _, t := range tokens {
+		if t.length == 0 {
+			codes[0].write(bw, int(t.argb>>8&0xff))
+			codes[1].write(bw, int(t.argb>>16&0xff))
+			codes[2].write(bw, int(t.argb&0xff))
+			codes[3].write(bw, int(t.argb>>24))
+			continue
+		}
+		lc, lbits, lval := prefixEncode(t.length)
+		codes[0].write(bw, numLiteralCodes+lc)
+		bw.write(lval, lbits)
+		dc, dbits, dval := prefixEncode(t.distCode)
+		codes[4].write(bw, dc)
+		bw.write(dval, dbits)
+	}
+
+	data := bw.bytes()
+	pad := len(data) & 1
+
+	header := make([]byte, 20)
+	copy(header[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:8], uint32(12+len(data)+pad))
+	copy(header[8:12], "WEBP")
+	copy(header[12:16], "VP8L")
+	binary.LittleEndian.PutUint32(header[16:20], uint32(len(data)))
+
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if pad == 1 {
+		_, err := w.Write([]byte{0})
+		return err
+	}
+	return nil
+}
+
+// argbPixels flattens img into non-premultiplied ARGB words
+func argbPixels(img image.Image) ([]uint32, bool) {
+	b := img.Bounds()
+	pixels := make([]uint32, 0, b.Dx()*b.Dy())
+	hasAlpha := false
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			if c.A != 0xff {
+				hasAlpha = true
+			}
+			pixels = append(pixels, uint32(c.A)<<24|uint32(c.R)<<16|uint32(c.G)<<8|uint32(c.B))
+		}
+	}
+	return pixels, hasAlpha
+}
+
+// subtractGreen stores red and blue as their difference to green
+func subtractGreen(pixels []uint32) {
+	for i, p := range pixels {
+		g := p >> 8 & 0xff
+		r := (p>>16 - g) & 0xff
+		bl := (p - g) & 0xff
+		pixels[i] = p&0xff00ff00 | r<<16 | bl
+	}
+}
+
+// token is either a literal pixel or a copy of length pixels from distCode
+type token struct {
+	argb     uint32
+	length   int
+	distCode int
+}
+
+// backwardRefs replaces runs that repeat the pixel to the left or the row
+// above with copies, greedily taking the longer of the two
+func backwardRefs(pixels []uint32, width int) []token {
+	tokens := make([]token, 0, len(pixels)/4)
+	for i := 0; i < len(pixels); {
+		left := runLength(pixels, i, 1)
+		above := 0
+		if i >= width {
+			above = runLength(pixels, i, width)
+		}
+
+		switch {
+		case above >= minCopyLength && above >= left:
+			tokens = append(tokens, token{length: above, distCode: distCodeAbove})
+			i += above
+		case left >= minCopyLength:
+			tokens = append(tokens, token{length: left, distCode: distCodeLeft})
+			i += left
+		default:
+			tokens = append(tokens, token{argb: pixels[i]})
+			i++
+		}
+	}
+	return tokens
+}
+
+func runLength(pixels []uint32, i, dist int) int {
+	if i < dist {
+		return 0
+	}
+	n := 0
+	for i+n < len(pixels) && n < maxCopyLength && pixels[i+n] == pixels[i+n-dist] {
+		n++
+	}
+	return n
+}
+
+// prefixEncode splits a length or distance code into its prefix symbol and
+// extra bits
+func prefixEncode(v int) (symbol int, extraBits uint, extraValue uint32) {
+	if v <= 4 {
+		return v - 1, 0, 0
+	}
+	d := v - 1
+	highest := bits.Len(uint(d)) - 1
+	second := (d >> (highest - 1)) & 1
+	extraBits = uint(highest - 1)
+	extraValue = uint32(d & (1<<extraBits - 1))
+	return 2*highest + second, extraBits, extraValue
+}
+
+// prefixCode is a canonical Huffman code, stored bit-reversed because VP8L
+// packs bits least significant first
+type prefixCode struct {
+	lengths []uint8
+	codes   []uint16
+}
+
+func (c *prefixCode) write(bw *bitWriter, symbol int) {
+	bw.write(uint32(c.codes[symbol]), uint(c.lengths[symbol]))
+}
+
+// writePrefixCode picks the code for hist, writes its description and returns
+// it for writing symbols
+func writePrefixCode(bw *bitWriter, hist []uint32) *prefixCode {
+	var used []int
+	for s, n := range hist {
+		if n > 0 {
+			used = append(used, s)
+		}
+	}
+
+	// Up to two symbols below 256 fit the "simple" code. A single symbol is
+	// coded with zero bits.
+	if len(used) <= 2 && (len(used) == 0 || used[len(used)-1] < numLiteralCodes) {
+		if len(used) == 0 {
+			used = []int{0}
+		}
+		bw.write(1, 1)
+		bw.write(uint32(len(used)-1), 1)
+		if used[0] < 2 {
+			bw.write(0, 1)
+			bw.write(uint32(used[0]), 1)
+		} else {
+			bw.write(1, 1)
+			bw.write(uint32(used[0]), 8)
+		}
+		lengths := make([]uint8, len(hist))
+		if len(used) == 2 {
+			bw.write(uint32(used[1]), 8)
+			lengths[used[0]], lengths[used[1]] = 1, 1
+		}
+		return newPrefixCode(lengths)
+	}
+
+	lengths := huffmanLengths(hist, maxCodeLength)
+	bw.write(0, 1)
+	writeCodeLengths(bw, lengths)
+	return newPrefixCode(lengths)
+}
+
+// writeCodeLengths writes a normal code's lengths, run-length coded with
+// their own prefix code
+func writeCodeLengths(bw *bitWriter, lengths []uint8) {
+	type clToken struct {
+		symbol    int
+		extraBits uint
+		extra     uint32
+	}
+
+	var tokens []clToken
+	for i := 0; i < len(lengths); {
+		l := lengths[i]
+		run := 1
+		for i+run < len(lengths) && lengths[i+run] == l {
+			run++
+		}
+		i += run
+
+		if l == 0 {
+			for run > 0 {
+				switch {
+				case run >= 11:
+					n := min(run, 138)
+					tokens = append(tokens, clToken{18, 7, uint32(n - 11)})
+					run -= n
+				case run >= 3:
+					tokens = append(tokens, clToken{17, 3, uint32(run - 3)})
+					run = 0
+				default:
+					tokens = append(tokens, clToken{symbol: 0})
+					run--
+				}
+			}
+			continue
+		}
+
+		// Code 16 repeats the previous non-zero length
+		tokens = append(tokens, clToken{symbol: int(l)})
+		run--
+		for run > 0 {
+			if run < 3 {
+				tokens = append(tokens, clToken{symbol: int(l)})
+				run--
+				continue
+			}
+			n := min(run, 6)
+			tokens = append(tokens, clToken{16, 2, uint32(n - 3)})
+			run -= n
+		}
+	}
+
+	hist := make([]uint32, len(codeLengthCodeOrder))
+	for _, t := range tokens {
+		hist[t.symbol]++
+	}
+	clLengths := huffmanLengths(hist, maxCodeLenLength)
+	clCode := newPrefixCode(clLengths)
+
+	numCodes := 4
+	for i, s := range codeLengthCodeOrder {
+		if clLengths[s] != 0 && i+1 > numCodes {
+			numCodes = i + 1
+		}
+	}
+	bw.write(uint32(numCodes-4), 4)
+	for _, s := range codeLengthCodeOrder[:numCodes] {
+		bw.write(uint32(clLengths[s]), 3)
+	}
+
+	bw.write(0, 1) // code lengths run to the end of the alphabet
+	for _, t := range tokens {
+		clCode.write(bw, t.symbol)
+		bw.write(t.extra, t.extraBits)
+	}
+}
+
+// huffmanLengths builds code lengths no longer than maxLen. When the optimal
+// tree is too deep the rarest counts are raised until it fits.
+func huffmanLengths(hist []uint32, maxLen int) []uint8 {
+	lengths := make([]uint8, len(hist))
+
+	var used []int
+	for s, n := range hist {
+		if n > 0 {
+			used = append(used, s)
+		}
+	}
+	switch len(used) {
+	case 0:
+		return lengths
+	case 1:
+		lengths[used[0]] = 1
+		return lengths
+	}
+
+	for floor := uint32(1); ; floor *= 2 {
+		depths := huffmanDepths(hist, used, floor)
+		deepest := 0
+		for _, d := range depths {
+			deepest = max(deepest, d)
+		}
+		if deepest <= maxLen {
+			for i, s := range used {
+				lengths[s] = uint8(depths[i])
+			}
+			return lengths
+		}
+	}
+}
+
+// huffmanDepths returns the depth of each used symbol in a Huffman tree over
+// the counts, each raised to at least floor
+func huffmanDepths(hist []uint32, used []int, floor uint32) []int {
+	type node struct {
+		count  uint64
+		parent int
+	}
+
+	nodes := make([]node, len(used), 2*len(used)-1)
+	order := make([]int, len(used))
+	for i, s := range used {
+		nodes[i] = node{count: uint64(max(hist[s], floor)), parent: -1}
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return nodes[order[a]].count < nodes[order[b]].count })
+
+	// Two-queue construction: leaves in count order, internal nodes in the
+	// order they are created, which is also count order
+	leaves, internal := order, []int{}
+	pop := func() int {
+		if len(internal) == 0 || (len(leaves) > 0 && nodes[leaves[0]].count <= nodes[internal[0]].count) {
+			n := leaves[0]
+			leaves = leaves[1:]
+			return n
+		}
+		n := internal[0]
+		internal = internal[1:]
+		return n
+	}
+	for len(leaves)+len(internal) > 1 {
+		a, b := pop(), pop()
+		nodes = append(nodes, node{count: nodes[a].count + nodes[b].count, parent: -1})
+		parent := len(nodes) - 1
+		nodes[a].parent, nodes[b].parent = parent, parent
+		internal = append(internal, parent)
+	}
+
+	depths := make([]int, len(used))
+	for i := range used {
+		for n := i; nodes[n].parent >= 0; n = nodes[n].parent {
+			depths[i]++
+		}
+	}
+	return depths
+}
+
+// newPrefixCode assigns canonical codes to lengths. A code with one symbol
+// writes no bits, matching the decoder.
+func newPrefixCode(lengths []uint8) *prefixCode {
+	c := &prefixCode{lengths: append([]uint8(nil), lengths...), codes: make([]uint16, len(lengths))}
+
+	used := 0
+	var count [maxCodeLength + 1]int
+	for _, l := range lengths {
+		if l > 0 {
+			count[l]++
+			used++
+		}
+	}
+	if used == 1 {
+		for i := range c.lengths {
+			c.lengths[i] = 0
+		}
+		return c
+	}
+
+	var next [maxCodeLength + 1]int
+	code := 0
+	for l := 1; l <= maxCodeLength; l++ {
+		code = (code + count[l-1]) << 1
+		next[l] = code
+	}
+
+	for s, l := range lengths {
+		if l == 0 {
+			continue
+		}
+		c.codes[s] = uint16(bits.Reverse16(uint16(next[l])) >> (16 - l))
+		next[l]++
+	}
+	return c
+}
+
+// bitWriter packs bits least significant first
+type bitWriter struct {
+	buf   []byte
+	acc   uint64
+	nbits uint
+}
+
+func (w *bitWriter) write(v uint32, n uint) {
+	w.acc |= uint64(v) << w.nbits
+	w.nbits += n
+	for w.nbits >= 8 {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc >>= 8
+		w.nbits -= 8
+	}
+}
+
+func (w *bitWriter) bytes() []byte {
+	if w.nbits > 0 {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc, w.nbits = 0, 0
+	}
+	return w.buf
+}
@@ -0,0 +1,77 @@
+package pagination
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	SetSigningKey("test-secret")
+
+	createdAt := time.Date(2025, 3, 14, 9, 26, 53, 589793000, time.UTC)
+	encoded := Encode(Cursor{CreatedAt: createdAt, ID: 4821})
+
+	decoded, err := Decode(encoded)
+	require.NoError(t, err)
+	assert.True(t, createdAt.Equal(decoded.CreatedAt))
+	assert.Equal(t, uint(4821), decoded.ID)
+}
+
+func TestDecode_RejectsTampering(t *testing.T) {
+	SetSigningKey("test-secret")
+	encoded := Encode(Cursor{CreatedAt: time.Now(), ID: 10})
+
+	t.Run("Modified payload", func(t *testing.T) {
+		payload, sig, _ := strings.Cut(encoded, ".")
+		forged := Encode(Cursor{CreatedAt: time.Now(), ID: 11})
+		forgedPayload, _, _ := strings.Cut(forged, ".")
+		assert.NotEqual(t, payload, forgedPayload)
+
+		_, err := Decode(forgedPayload + "." + sig)
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+
+	t.Run("Different signing key", func(t *testing.T) {
+		SetSigningKey("another-secret")
+		defer SetSigningKey("test-secret")
+
+		_, err := Decode(encoded)
+		assert.ErrorIs(t, err, ErrInvalidCursor)
+	})
+
+	t.Run("Garbage input", func(t *testing.T) {
+		for _, input := range []string{"", "abc", "abc.def", "!!.??"} {
+			_, err := Decode(input)
+			assert.ErrorIs(t, err, ErrInvalidCursor, input)
+		}
+	})
+}
+
+func TestNext(t *testing.T) {
+	SetSigningKey("test-secret")
+	createdAt := time.Now()
+
+	assert.Empty(t, Next(5, 20, createdAt, 1), "partial page has no next cursor")
+	assert.Empty(t, Next(0, 20, createdAt, 1), "empty page has no next cursor")
+
+	next := Next(20, 20, createdAt, 7)
+	require.NotEmpty(t, next)
+	cursor, err := Decode(next)
+	require.NoError(t, err)
+	assert.Equal(t, uint(7), cursor.ID)
+}
+
+func TestParamsOffset(t *testing.T) {
+	assert.Equal(t, 40, Params{Page: 3, Limit: 20}.Offset())
+	assert.Equal(t, 0, Params{Page: 3, Limit: 20, Cursor: &Cursor{ID: 1}}.Offset())
+	assert.Equal(t, 0, Params{Page: 0, Limit: 20}.Offset())
+}
+
+func TestKeysetCondition(t *testing.T) {
+	assert.Equal(t, "(r.created_at, r.id) < (?, ?)", KeysetCondition("r.", true))
+	assert.Equal(t, "(created_at, id) > (?, ?)", KeysetCondition("", false))
+}
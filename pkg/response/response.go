@@ -63,6 +63,25 @@ func Forbidden(c *fiber.Ctx, message string) error {
 	return Error(c, fiber.StatusForbidden, message)
 }
 
+// Conflict reports a stale write along with the current server copy so the client can merge
+func Conflict(c *fiber.Ctx, message string, current interface{}) error {
+	return c.Status(fiber.StatusConflict).JSON(Response{
+		Success: false,
+		Data:    current,
+		Error:   message,
+	})
+}
+
+// MovedPermanently points the client at the canonical location of a resource
+func MovedPermanently(c *fiber.Ctx, location string, data interface{}) error {
+	c.Set(fiber.HeaderLocation, location)
+	return c.Status(fiber.StatusMovedPermanently).JSON(Response{
+		Success: false,
+		Data:    data,
+		Error:   "Resource has moved",
+	})
+}
+
 func TooManyRequests(c *fiber.Ctx, message string, retryAfter int) error {
 	c.Set("Retry-After", fmt.Sprintf("%d", retryAfter))
 	return Error(c, fiber.StatusTooManyRequests, message)
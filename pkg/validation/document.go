@@ -0,0 +1,66 @@
+package validation
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// MaxDocumentSize is the maximum allowed document file size (25 MB)
+	MaxDocumentSize = 25 * 1024 * 1024 // 25 MB in bytes
+)
+
+// pdfSignature starts every PDF file
+var pdfSignature = []byte("%PDF-")
+
+// ValidateDocumentFile validates an uploaded document. Only PDFs are accepted.
+func ValidateDocumentFile(file *multipart.FileHeader) error {
+	if file == nil {
+		return fmt.Errorf("no file provided")
+	}
+
+	// Validate file size
+	if file.Size > MaxDocumentSize {
+		return fmt.Errorf("document size must be less than 25MB (current size: %.2f MB)", float64(file.Size)/(1024*1024))
+	}
+
+	if file.Size == 0 {
+		return fmt.Errorf("document file is empty")
+	}
+
+	// Validate file extension
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	if ext != ".pdf" {
+		return fmt.Errorf("invalid file extension: %s (allowed: .pdf)", ext)
+	}
+
+	// Validate MIME type from header
+	contentType := file.Header.Get("Content-Type")
+	if contentType != "" && contentType != "application/pdf" && contentType != "application/octet-stream" {
+		return fmt.Errorf("invalid file type: %s (allowed: application/pdf)", contentType)
+	}
+
+	// Verify the content really is a PDF
+	src, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open file for validation: %w", err)
+	}
+	defer src.Close()
+
+	header := make([]byte, len(pdfSignature))
+	if _, err := io.ReadFull(src, header); err != nil || !bytes.Equal(header, pdfSignature) {
+		return fmt.Errorf("file does not appear to be a valid PDF")
+	}
+
+	return nil
+}
+
+// IsDocumentFile reports whether the upload is named like a document rather
+// than an image
+func IsDocumentFile(file *multipart.FileHeader) bool {
+	return file != nil && strings.ToLower(filepath.Ext(file.Filename)) == ".pdf"
+}
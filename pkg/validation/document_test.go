@@ -0,0 +1,68 @@
+package validation
+
+import (
+	"testing"
+)
+
+func TestValidateDocumentFile(t *testing.T) {
+	tests := []struct {
+		name        string
+		filename    string
+		content     []byte
+		contentType string
+		wantErr     bool
+	}{
+		{
+			name:     "Valid PDF",
+			filename: "media-kit.pdf",
+			content:  pdfMagicBytes,
+		},
+		{
+			name:        "Valid PDF with content type",
+			filename:    "Media-Kit.PDF",
+			content:     pdfMagicBytes,
+			contentType: "application/pdf",
+		},
+		{
+			name:     "Image is not a document",
+			filename: "chart.png",
+			content:  pngMagicBytes,
+			wantErr:  true,
+		},
+		{
+			name:     "PDF extension with image content",
+			filename: "fake.pdf",
+			content:  jpegMagicBytes,
+			wantErr:  true,
+		},
+		{
+			name:        "Wrong content type",
+			filename:    "media-kit.pdf",
+			content:     pdfMagicBytes,
+			contentType: "text/html",
+			wantErr:     true,
+		},
+		{
+			name:     "Empty file",
+			filename: "empty.pdf",
+			content:  []byte{},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fileHeader := createTestFile(t, tt.filename, tt.content, tt.contentType)
+			err := ValidateDocumentFile(fileHeader)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateDocumentFile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateDocumentFile_NilFile(t *testing.T) {
+	if err := ValidateDocumentFile(nil); err == nil {
+		t.Error("ValidateDocumentFile(nil) expected error, got nil")
+	}
+}
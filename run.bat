@@ -1,3 +1,5 @@
 @echo off
 echo Starting Healthcare Market Research API...
+go run ./cmd/migrate up
+if errorlevel 1 exit /b 1
 go run cmd/api/main.go
@@ -1,3 +1,4 @@
 #!/bin/bash
 echo "Starting Healthcare Market Research API..."
+go run ./cmd/migrate up || exit 1
 go run cmd/api/main.go